@@ -5,7 +5,7 @@
  * Pure functions, no state, no side effects
  */
 
-import { ValidationResult, AuditSummary } from '../../shared/types';
+import { ValidationResult, AuditSummary, AuditorSummary } from '../../shared/types';
 
 /**
  * Audit metrics interface
@@ -48,6 +48,25 @@ export const calculateAuditSummary = (results: ValidationResult[]): AuditSummary
   };
 };
 
+/**
+ * Pure function to break an audit down per auditor (one entry per result)
+ */
+export const calculateAuditorBreakdown = (results: ValidationResult[]): AuditorSummary[] => {
+  // Guard clause: no results
+  if (!results || results.length === 0) {
+    return [];
+  }
+
+  return results.filter(Boolean).map(result => ({
+    auditType: result.metadata?.auditType || 'unknown',
+    rulesChecked: result.metadata?.rulesChecked || 0,
+    rulesPassed: result.metadata?.rulesPassed || 0,
+    rulesFailed: result.metadata?.rulesFailed || 0,
+    errors: result.errors?.length || 0,
+    warnings: result.warnings?.length || 0
+  }));
+};
+
 /**
  * Pure function to calculate metrics from validation results
  */
@@ -19,10 +19,19 @@
 
 import { AuditResult, ValidationContext, ValidationResult, AuditSummary } from '../../shared/types';
 import { Validator } from './Validator';
-import { calculateAuditSummary, calculateMetrics, calculateScore, calculateGrade, generateRecommendations } from './AuditCalculator';
+import { calculateAuditSummary, calculateAuditorBreakdown, calculateMetrics, calculateScore, calculateGrade, generateRecommendations } from './AuditCalculator';
 import { SecurityAuditor } from '../../infrastructure/plugins/SecurityAuditor';
 import { ComplianceAuditor } from '../../infrastructure/plugins/ComplianceAuditor';
 import { PerformanceAuditor } from '../../infrastructure/plugins/PerformanceAuditor';
+import { EnvironmentLeakageAuditor } from '../../infrastructure/plugins/EnvironmentLeakageAuditor';
+import { WorkflowAuditor } from '../../infrastructure/plugins/WorkflowAuditor';
+import { CloudIdentityAuditor } from '../../infrastructure/plugins/CloudIdentityAuditor';
+import { CommentedConfigAuditor } from '../../infrastructure/plugins/CommentedConfigAuditor';
+import { FormatLintAuditor } from '../../infrastructure/plugins/FormatLintAuditor';
+import { ValueAuditor } from '../../infrastructure/plugins/ValueAuditor';
+import { ForbiddenKeysAuditor } from '../../infrastructure/plugins/ForbiddenKeysAuditor';
+import { SchemaRulesAuditor } from '../../infrastructure/plugins/SchemaRulesAuditor';
+import { isRuleEnabled, scopeFileMap } from '../validation/RuleScoping';
 
 interface AuditEngineOptions {
   plugins?: string[];
@@ -35,6 +44,14 @@ export class AuditEngine {
   private securityAuditor: SecurityAuditor;
   private complianceAuditor: ComplianceAuditor;
   private performanceAuditor: PerformanceAuditor;
+  private leakageAuditor: EnvironmentLeakageAuditor;
+  private workflowAuditor: WorkflowAuditor;
+  private cloudIdentityAuditor: CloudIdentityAuditor;
+  private commentedConfigAuditor: CommentedConfigAuditor;
+  private formatLintAuditor: FormatLintAuditor;
+  private valueAuditor: ValueAuditor;
+  private forbiddenKeysAuditor: ForbiddenKeysAuditor;
+  private schemaRulesAuditor: SchemaRulesAuditor;
   private options: AuditEngineOptions;
 
   constructor(options: AuditEngineOptions = {}) {
@@ -53,6 +70,14 @@ export class AuditEngine {
     this.securityAuditor = new SecurityAuditor();
     this.complianceAuditor = new ComplianceAuditor();
     this.performanceAuditor = new PerformanceAuditor();
+    this.leakageAuditor = new EnvironmentLeakageAuditor();
+    this.workflowAuditor = new WorkflowAuditor();
+    this.cloudIdentityAuditor = new CloudIdentityAuditor();
+    this.commentedConfigAuditor = new CommentedConfigAuditor();
+    this.formatLintAuditor = new FormatLintAuditor();
+    this.valueAuditor = new ValueAuditor();
+    this.forbiddenKeysAuditor = new ForbiddenKeysAuditor();
+    this.schemaRulesAuditor = new SchemaRulesAuditor();
   }
 
   /**
@@ -100,6 +125,11 @@ export class AuditEngine {
     const auditResults: ValidationResult[] = [];
     
     for (const auditType of this.options.types || []) {
+      // Auditors scoped away from every file are skipped
+      if (!this.scopeContext(auditType, context)) {
+        continue;
+      }
+
       const result = await this.runAuditType(auditType, context);
       auditResults.push(result);
     }
@@ -107,17 +137,55 @@ export class AuditEngine {
     return auditResults;
   }
 
+  /**
+   * Restrict the context to the files an auditor is scoped to; undefined when nothing is left
+   */
+  private scopeContext(auditType: string, context: ValidationContext): ValidationContext | undefined {
+    const scopes = context.scopes ?? [];
+
+    // Guard clause: auditor disabled
+    if (!isRuleEnabled(scopes, auditType)) {
+      return undefined;
+    }
+
+    // Guard clause: no files to scope
+    if (!context.files || Object.keys(context.files).length === 0) {
+      return context;
+    }
+
+    const files = scopeFileMap(scopes, auditType, context.files, context.labels);
+    return Object.keys(files).length > 0 ? { ...context, files } : undefined;
+  }
+
   /**
    * Run a specific type of audit
    */
   private async runAuditType(auditType: string, context: ValidationContext): Promise<ValidationResult> {
+    const scoped = this.scopeContext(auditType, context) ?? { ...context, files: {} };
+
     switch (auditType) {
       case 'security':
-        return this.securityAuditor.audit(context);
+        return this.securityAuditor.audit(scoped);
       case 'compliance':
-        return this.complianceAuditor.audit(context);
+        return this.complianceAuditor.audit(scoped);
       case 'performance':
-        return this.performanceAuditor.audit(context);
+        return this.performanceAuditor.audit(scoped);
+      case 'leakage':
+        return this.leakageAuditor.audit(scoped);
+      case 'workflows':
+        return this.workflowAuditor.audit(scoped);
+      case 'cloud-identifiers':
+        return this.cloudIdentityAuditor.audit(scoped);
+      case 'commented-config':
+        return this.commentedConfigAuditor.audit(scoped);
+      case 'format-lint':
+        return this.formatLintAuditor.audit(scoped);
+      case 'value-differences':
+        return this.valueAuditor.audit(scoped);
+      case 'forbidden-keys':
+        return this.forbiddenKeysAuditor.audit(scoped);
+      case 'schema-rules':
+        return this.schemaRulesAuditor.audit(scoped);
       default:
         return this.createUnknownAuditResult(auditType);
     }
@@ -142,7 +210,14 @@ export class AuditEngine {
       failedChecks: summary.failedChecks,
       warnings: summary.warnings,
       results,
-      summary
+      summary,
+      metadata: {
+        duration: Date.now() - startTime,
+        rulesChecked: summary.totalChecks,
+        rulesPassed: summary.passedChecks,
+        rulesFailed: summary.failedChecks,
+        auditors: calculateAuditorBreakdown(results)
+      }
     };
   }
 
@@ -166,7 +241,7 @@ export class AuditEngine {
           code: 'AUDIT_ERROR',
           message: error instanceof Error ? error.message : 'Unknown audit error',
           severity: 'error',
-          context: { error }
+          context: { extras: { error } }
         }],
         warnings: [],
         metadata: { error: error instanceof Error ? error.message : 'Unknown error' }
@@ -28,6 +28,7 @@ import {
   RuleDictionaryResult,
   overrideRulesInDictionary,
 } from './rule-loading/RuleDictionary';
+import { HttpClientOptions } from '../../infrastructure/http/HttpClient';
 
 /**
  * @interface RuleLoaderOptions
@@ -40,6 +41,8 @@ interface RuleLoaderOptions {
   includeCoreRules?: boolean;
   /** Whether to validate loaded rules */
   validateRules?: boolean;
+  /** HTTP settings for rule sets loaded from URLs */
+  http?: HttpClientOptions;
 }
 
 /**
@@ -50,11 +53,13 @@ export class RuleLoaderService {
   private readonly workingDirectory: string;
   private readonly includeCoreRules: boolean;
   private readonly validateRules: boolean;
+  private readonly httpOptions: HttpClientOptions;
 
   constructor(options: RuleLoaderOptions = {}) {
     this.workingDirectory = options.workingDirectory || process.cwd();
     this.includeCoreRules = options.includeCoreRules ?? true;
     this.validateRules = options.validateRules ?? true;
+    this.httpOptions = options.http ?? {};
   }
 
   /**
@@ -169,6 +174,7 @@ export class RuleLoaderService {
     const options: RuleSetLoadOptions = {
       workingDirectory: this.workingDirectory,
       validateRules: this.validateRules,
+      http: this.httpOptions,
     };
 
     const loadPromises = ruleSetPaths.map(path => 
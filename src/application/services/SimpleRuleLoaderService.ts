@@ -24,6 +24,7 @@ import { SimpleRuleDictionary, SimpleRuleDictionaryResult } from '../../shared/t
 import fs from 'fs/promises';
 import path from 'path';
 import yaml from 'js-yaml';
+import { HttpClient, HttpClientOptions, urlExtension } from '../../infrastructure/http/HttpClient';
 
 /**
  * @interface SimpleRuleLoaderOptions
@@ -36,6 +37,8 @@ interface SimpleRuleLoaderOptions {
   includeCoreRules?: boolean;
   /** Whether to validate loaded rules */
   validateRules?: boolean;
+  /** HTTP settings for rule sets loaded from URLs */
+  http?: HttpClientOptions;
 }
 
 /**
@@ -46,11 +49,13 @@ export class SimpleRuleLoaderService {
   private readonly workingDirectory: string;
   private readonly includeCoreRules: boolean;
   private readonly validateRules: boolean;
+  private readonly httpOptions: HttpClientOptions;
 
   constructor(options: SimpleRuleLoaderOptions = {}) {
     this.workingDirectory = options.workingDirectory || process.cwd();
     this.includeCoreRules = options.includeCoreRules ?? true;
     this.validateRules = options.validateRules ?? true;
+    this.httpOptions = options.http ?? {};
   }
 
   /**
@@ -250,7 +255,7 @@ export class SimpleRuleLoaderService {
   }
 
   /**
-   * Loads rule set from URL (Placeholder - Pure Function)
+   * Loads rule set from URL
    * @param url - URL to load from
    * @returns Rule load result
    */
@@ -260,8 +265,12 @@ export class SimpleRuleLoaderService {
       return this.createEmptyResult(['Invalid URL']);
     }
 
-    // TODO: Implement URL loading
-    return this.createEmptyResult(['URL loading not implemented yet']);
+    try {
+      const content = await new HttpClient(this.httpOptions).getText(url);
+      return this.parseContentByExtension(content, urlExtension(url));
+    } catch (error) {
+      return this.createEmptyResult([`Failed to fetch '${url}': ${(error as Error).message}`]);
+    }
   }
 
   /**
@@ -24,6 +24,7 @@ import path from 'path';
 import yaml from 'js-yaml';
 import { readdir, stat } from 'fs/promises';
 import { join, extname } from 'path';
+import { HttpClient, HttpClientOptions, urlExtension } from '../../../infrastructure/http/HttpClient';
 
 /**
  * @class DevSecOpsRuleConnector
@@ -31,9 +32,11 @@ import { join, extname } from 'path';
  */
 export class DevSecOpsRuleConnector {
   private readonly workingDirectory: string;
+  private readonly httpOptions: HttpClientOptions;
 
-  constructor(workingDirectory: string = process.cwd()) {
+  constructor(workingDirectory: string = process.cwd(), httpOptions: HttpClientOptions = {}) {
     this.workingDirectory = workingDirectory;
+    this.httpOptions = httpOptions;
   }
 
   /**
@@ -351,17 +354,46 @@ export class DevSecOpsRuleConnector {
     }
   }
 
-  // Placeholder methods for other source types (to be implemented)
+  /**
+   * Loads rules from a remote URL through the shared HTTP client
+   * @param source - Source configuration
+   * @param sourceName - Source name
+   * @returns Promise with source load result
+   */
   private async loadRemoteRules(source: RuleSource, sourceName: string, context: RuleLoadContext): Promise<SourceLoadResult> {
-    return {
-      sourceName,
-      rules: {},
-      errors: ['Remote rule loading not implemented yet'],
-      warnings: [],
-      success: false,
-    };
+    // Guard clause: no URL
+    if (!source.url) {
+      return {
+        sourceName,
+        rules: {},
+        errors: [`Remote source '${sourceName}' requires a url`],
+        warnings: [],
+        success: false,
+      };
+    }
+
+    try {
+      const content = await new HttpClient(this.httpOptions).getText(source.url);
+      return {
+        sourceName,
+        rules: this.parseRuleFile(content, urlExtension(source.url)),
+        errors: [],
+        warnings: [],
+        success: true,
+      };
+    } catch (error) {
+      return {
+        sourceName,
+        rules: {},
+        errors: [`Failed to load remote source '${sourceName}': ${(error as Error).message}`],
+        warnings: [],
+        success: false,
+      };
+    }
   }
 
+  // Placeholder methods for other source types (to be implemented)
+
   private async loadPackageRules(source: RuleSource, sourceName: string, context: RuleLoadContext): Promise<SourceLoadResult> {
     return {
       sourceName,
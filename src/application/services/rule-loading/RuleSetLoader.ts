@@ -8,6 +8,7 @@ import path from 'path';
 import yaml from 'js-yaml';
 import { PraetorianRule, RuleLoadResult } from '../../../shared/types/rules';
 import { CORE_RULE_SETS } from '../../../shared/rules/core-rules';
+import { HttpClient, HttpClientOptions, urlExtension } from '../../../infrastructure/http/HttpClient';
 
 /**
  * @interface RuleSetLoadOptions
@@ -16,6 +17,8 @@ import { CORE_RULE_SETS } from '../../../shared/rules/core-rules';
 export interface RuleSetLoadOptions {
   workingDirectory: string;
   validateRules?: boolean;
+  /** Timeout, retry, proxy and CA settings for URL rule sets */
+  http?: HttpClientOptions;
 }
 
 /**
@@ -41,7 +44,7 @@ export const loadRuleSet = async (
 
     // Check if it's a URL
     if (isUrl(ruleSetPath)) {
-      return await loadRuleSetFromUrl(ruleSetPath);
+      return await loadRuleSetFromUrl(ruleSetPath, options.http);
     }
 
     // Load from local file
@@ -107,11 +110,15 @@ export const loadRuleSetFromFile = async (
 };
 
 /**
- * Loads rule set from a URL (placeholder for future implementation)
+ * Loads rule set from a URL
  * @param url - URL to the rule set
+ * @param httpOptions - Timeout, retry, proxy and CA settings
  * @returns Promise with loaded rules
  */
-export const loadRuleSetFromUrl = async (url: string): Promise<RuleLoadResult> => {
+export const loadRuleSetFromUrl = async (
+  url: string,
+  httpOptions: HttpClientOptions = {}
+): Promise<RuleLoadResult> => {
   // Guard clause: empty URL
   if (!url || url.trim().length === 0) {
     return createEmptyResult(['URL cannot be empty']);
@@ -122,8 +129,12 @@ export const loadRuleSetFromUrl = async (url: string): Promise<RuleLoadResult> =
     return createEmptyResult(['Invalid URL format']);
   }
 
-  // TODO: Implement URL loading
-  return createEmptyResult([`URL loading not yet implemented: ${url}`]);
+  try {
+    const content = await new HttpClient(httpOptions).getText(url);
+    return parseRuleFile(content, `remote${urlExtension(url)}`);
+  } catch (error) {
+    return createEmptyResult([`Failed to fetch rule set ${url}: ${(error as Error).message}`]);
+  }
 };
 
 /**
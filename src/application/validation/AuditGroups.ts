@@ -0,0 +1,110 @@
+/**
+ * @file src/application/validation/AuditGroups.ts
+ * @description Pure functions to compare files within groups and merge the per-group results
+ */
+
+import { AuditGroup, ConfigFile, GroupSummary, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { normalizePath } from '../../shared/utils/Glob';
+
+export type { AuditGroup };
+
+/**
+ * Lists every file of the groups once, in order of first appearance
+ * @param groups - Audit groups
+ * @returns Unique file paths
+ */
+export const groupFiles = (groups: AuditGroup[]): string[] =>
+  [...new Set(groups.flatMap(group => group.files))];
+
+/**
+ * Picks the loaded files that belong to a group
+ * @param group - Audit group
+ * @param files - Every loaded file
+ * @returns Files of the group, in group order
+ */
+export const filesOfGroup = (group: AuditGroup, files: ConfigFile[]): ConfigFile[] =>
+  group.files.flatMap(filePath => files.filter(file => normalizePath(file.path) === normalizePath(filePath)));
+
+/**
+ * Tags every finding of a result with the group it was found in
+ * @param result - Result of one group
+ * @param name - Group name
+ * @returns Result whose findings carry `context.group`
+ */
+export const tagGroup = (result: ValidationResult, name: string): ValidationResult =>
+  withFindings(
+    result,
+    collectFindings(result).map(finding => ({ ...finding, context: { ...finding.context, group: name } }))
+  );
+
+/**
+ * Summarizes the result of one group
+ * @param group - Audit group
+ * @param result - Result of the group
+ * @returns Group section of the merged result
+ */
+export const summarizeGroup = (group: AuditGroup, result: ValidationResult): GroupSummary => ({
+  name: group.name,
+  files: group.files,
+  success: result.success,
+  errors: result.errors.length,
+  warnings: result.warnings.length,
+  info: result.info?.length ?? 0
+});
+
+const sumMetadata = (results: ValidationResult[], field: 'duration' | 'totalKeys' | 'emptyKeys' | 'rulesChecked' | 'rulesPassed' | 'rulesFailed'): number =>
+  results.reduce((total, result) => total + (result.metadata?.[field] ?? 0), 0);
+
+/**
+ * Merges per-group results into one result with a section per group
+ * @param entries - Each group with its result
+ * @returns Merged result; it fails when any group fails
+ */
+export const mergeGroupResults = (entries: Array<{ group: AuditGroup; result: ValidationResult }>): ValidationResult => {
+  const tagged = entries.map(({ group, result }) => tagGroup(result, group.name));
+  const merged = withFindings({ success: true, errors: [], warnings: [] }, tagged.flatMap(collectFindings));
+
+  return {
+    ...merged,
+    metadata: {
+      duration: sumMetadata(tagged, 'duration'),
+      filesCompared: groupFiles(entries.map(({ group }) => group)).length,
+      totalKeys: sumMetadata(tagged, 'totalKeys'),
+      emptyKeys: sumMetadata(tagged, 'emptyKeys'),
+      rulesChecked: sumMetadata(tagged, 'rulesChecked'),
+      rulesPassed: sumMetadata(tagged, 'rulesPassed'),
+      rulesFailed: sumMetadata(tagged, 'rulesFailed')
+    },
+    groups: entries.map(({ group }, index) => summarizeGroup(group, tagged[index]))
+  };
+};
+
+/**
+ * Runs a comparison for each group on its own files and merges the results
+ * @param groups - Audit groups
+ * @param files - Every loaded file
+ * @param compare - Comparison of one set of files
+ * @param signal - Stops before the next group once aborted
+ * @returns Merged result with one section per compared group
+ */
+export const compareByGroup = async (
+  groups: AuditGroup[],
+  files: ConfigFile[],
+  compare: (files: ConfigFile[], group: AuditGroup) => Promise<ValidationResult>,
+  signal?: AbortSignal
+): Promise<ValidationResult> => {
+  const entries: Array<{ group: AuditGroup; result: ValidationResult }> = [];
+
+  // Groups run one after the other to keep findings in configuration order
+  for (const group of groups) {
+    // Guard clause: run interrupted, keep the groups compared so far
+    if (signal?.aborted) {
+      break;
+    }
+
+    entries.push({ group, result: await compare(filesOfGroup(group, files), group) });
+  }
+
+  return mergeGroupResults(entries);
+};
@@ -0,0 +1,156 @@
+/**
+ * @file src/application/validation/BudgetRules.ts
+ * @description Pure functions enforcing `budgets:` — numeric values (replicas, memory limits, instance sizes)
+ * summed across the files of an environment, or capped one by one
+ */
+
+import { BudgetSettings, ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { normalizeQuantity } from '../../shared/utils/Quantities';
+import { inferEnvironment, leakageTokens, tokenEnvironment } from './EnvironmentLeakage';
+import { parseKubernetesQuantity } from './KubernetesRules';
+
+/**
+ * @constant BUDGET_RULE_ID
+ * @description Rule id of the budget checks, usable in `scopes:`
+ */
+export const BUDGET_RULE_ID = 'budgets';
+
+const WILDCARD = '*';
+
+/**
+ * Reads a budgeted amount: a number, a Kubernetes quantity (`500m`, `512Mi`, `1G`) or a size (`16GB`)
+ * @param value - Value as written
+ * @returns Amount in base units, or undefined when the value is not numeric
+ */
+export const budgetAmount = (value: unknown): number | undefined =>
+  parseKubernetesQuantity(value) ?? normalizeQuantity(value, 'size');
+
+/**
+ * Resolves a key pattern like `resolveKeyPattern`, except that `*` also matches list indexes,
+ * so `spec.template.spec.containers.*.resources.limits.memory` reaches every container
+ */
+const resolveValues = (value: unknown, segments: string[], prefix: string = ''): Array<{ path: string; value: unknown }> => {
+  // Guard clause: all segments consumed
+  if (segments.length === 0) {
+    return [{ path: prefix, value }];
+  }
+
+  const [segment, ...rest] = segments;
+  const children: Array<[string, unknown]> = Array.isArray(value)
+    ? value.map((child, index) => [String(index), child] as [string, unknown])
+    : isPlainObject(value) ? Object.entries(value) : [];
+
+  return children
+    .filter(([key]) => segment === WILDCARD || segment === key)
+    .flatMap(([key, child]) => resolveValues(child, rest, joinKeyPath(prefix, key)));
+};
+
+interface BudgetValue {
+  file: ConfigFile;
+  environment?: string;
+  keyPath: string;
+  amount: number;
+}
+
+type BudgetCode = 'BUDGET_TOTAL_EXCEEDED' | 'BUDGET_VALUE_EXCEEDED';
+
+const budgetFinding = (
+  code: BudgetCode,
+  message: string,
+  value: BudgetValue,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: value.keyPath,
+  context: {
+    file: value.file.path,
+    ...(value.environment !== undefined ? { environment: value.environment } : {}),
+    keyPath: value.keyPath,
+    rule: { id: BUDGET_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks one budget: every value against `maxValue`, and the sum of each environment against `maxTotal`
+ */
+const budgetFindings = (budget: BudgetSettings, files: ConfigFile[], tokens: Record<string, string[]>): ValidationError[] => {
+  const maxTotal = budgetAmount(budget.maxTotal);
+  const maxValue = budgetAmount(budget.maxValue);
+  const groupOf = (environment?: string): string | undefined =>
+    environment === undefined ? undefined : tokenEnvironment(environment, tokens) ?? environment;
+
+  const values: BudgetValue[] = files
+    .map(file => ({ file, environment: file.environment ?? inferEnvironment(file.path, tokens) }))
+    .filter(({ environment }) => budget.environment === undefined || groupOf(environment) === groupOf(budget.environment))
+    .flatMap(({ file, environment }) => [...new Set(budget.keys)]
+      .flatMap(pattern => resolveValues(file.content, pattern.split('.')))
+      .flatMap(({ path, value }) => {
+        // Non-numeric values (`${REPLICAS}`) cannot be budgeted
+        const amount = budgetAmount(value);
+        return amount === undefined ? [] : [{ file, environment, keyPath: path, amount }];
+      }))
+    .filter((value, index, all) => all.findIndex(other => other.file === value.file && other.keyPath === value.keyPath) === index);
+
+  const oversized = maxValue === undefined ? [] : values
+    .filter(value => value.amount > maxValue)
+    .map(value => budgetFinding(
+      'BUDGET_VALUE_EXCEEDED',
+      `'${value.keyPath}' is ${value.amount}, above the ${budget.maxValue} allowed by budget '${budget.name}' (${value.file.path})`,
+      value,
+      { budget: budget.name, amount: value.amount, max: budget.maxValue }
+    ));
+
+  const groups = [...new Set(values.map(value => groupOf(value.environment)))];
+  const overspent = maxTotal === undefined ? [] : groups.flatMap(group => {
+    const members = values.filter(value => groupOf(value.environment) === group);
+    // Rounded so CPU quantities do not sum to 0.30000000000000004
+    const total = Number(members.reduce((sum, value) => sum + value.amount, 0).toFixed(6));
+    // Reported on the largest contributor, the first place to cut
+    const largest = members.reduce((top, value) => (value.amount > top.amount ? value : top), members[0]);
+    const scope = group === undefined ? '' : ` in ${largest.environment}`;
+
+    return total <= maxTotal ? [] : [budgetFinding(
+      'BUDGET_TOTAL_EXCEEDED',
+      `Budget '${budget.name}' totals ${total}${scope} across ${new Set(members.map(value => value.file.path)).size} file(s), ` +
+        `above ${budget.maxTotal}; largest is '${largest.keyPath}' (${largest.amount}) in ${largest.file.path}`,
+      largest,
+      { budget: budget.name, total, max: budget.maxTotal, values: members.length }
+    )];
+  });
+
+  return [...oversized, ...overspent];
+};
+
+/**
+ * Checks the given files against budgets
+ * @param files - Files with their environment (inferred from the file name when unknown; files of
+ * no known environment are summed together)
+ * @param budgets - `budgets:` entries
+ * @returns Findings about values above `max_value` and environments whose sum is above `max_total`
+ */
+export const checkBudgets = (files: ConfigFile[], budgets: BudgetSettings[]): ValidationError[] => {
+  const tokens = leakageTokens();
+  return budgets.flatMap(budget => budgetFindings(budget, files, tokens));
+};
+
+/**
+ * Adds budget findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the budgets apply to
+ * @param budgets - `budgets:` entries
+ * @returns Result that fails when a budget is exceeded
+ */
+export const withBudgetFindings = (result: ValidationResult, files: ConfigFile[], budgets: BudgetSettings[]): ValidationResult => {
+  // Guard clause: no budgets configured
+  if (budgets.length === 0) {
+    return result;
+  }
+
+  const findings = checkBudgets(files, budgets);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
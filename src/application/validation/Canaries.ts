@@ -0,0 +1,93 @@
+/**
+ * @file src/application/validation/Canaries.ts
+ * @description Pure functions checking that honeytoken (canary) keys are present and unmodified
+ */
+
+import { createHash } from 'crypto';
+import { Canary, ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { matchesGlob } from '../../shared/utils/Glob';
+import { extractKeyValues } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant CANARY_RULE_ID
+ * @description Rule id of the canary check, usable in `scopes:`
+ */
+export const CANARY_RULE_ID = 'canary';
+
+/**
+ * Hashes a canary value the way `sha256:` in praetorian.yaml expects it
+ * @param value - Canary value
+ * @returns Hex SHA-256 of the value as a string
+ */
+export const canaryHash = (value: unknown): string =>
+  createHash('sha256').update(String(value)).digest('hex');
+
+/**
+ * Checks whether a value is the expected canary value
+ * @param canary - Canary declaration
+ * @param value - Value found in the file
+ * @returns true when the value is unmodified
+ */
+export const isCanaryIntact = (canary: Canary, value: unknown): boolean =>
+  canary.sha256 !== undefined
+    ? canaryHash(value) === canary.sha256.toLowerCase()
+    : String(value) === String(canary.value);
+
+/**
+ * Checks whether a file is expected to carry a canary
+ * @param canary - Canary declaration
+ * @param file - Path of the file
+ * @returns true when the canary applies to the file
+ */
+export const carriesCanary = (canary: Canary, file: string): boolean =>
+  !canary.files || canary.files.length === 0 || canary.files.some(pattern => matchesGlob(file, pattern));
+
+const canaryFinding = (code: 'CANARY_MISSING' | 'CANARY_MODIFIED', canary: Canary, file: string): ValidationError => ({
+  code,
+  message: code === 'CANARY_MISSING'
+    ? `🚨 Canary key '${canary.key}' was removed from ${file}`
+    : `🚨 Canary key '${canary.key}' was modified in ${file}`,
+  severity: 'error',
+  path: canary.key,
+  // Values stay out of the finding: the canary must not leak through reports
+  context: { file, keyPath: canary.key, rule: { id: CANARY_RULE_ID } },
+});
+
+/**
+ * Lists every removed or modified canary
+ * @param canaries - Declared canaries
+ * @param files - Loaded files
+ * @returns One error per canary and file that does not match
+ */
+export const checkCanaries = (canaries: Canary[], files: ConfigFile[]): ValidationError[] =>
+  files.flatMap(file => {
+    const values = extractKeyValues(file.content);
+
+    return canaries
+      .filter(canary => carriesCanary(canary, file.path))
+      .flatMap(canary => {
+        if (!values.has(canary.key)) {
+          return [canaryFinding('CANARY_MISSING', canary, file.path)];
+        }
+
+        return isCanaryIntact(canary, values.get(canary.key)) ? [] : [canaryFinding('CANARY_MODIFIED', canary, file.path)];
+      });
+  });
+
+/**
+ * Adds canary findings to a result
+ * @param result - Result of the other rules
+ * @param canaries - Declared canaries
+ * @param files - Files the canary check runs on
+ * @returns Result that fails when any canary was removed or modified
+ */
+export const withCanaries = (result: ValidationResult, canaries: Canary[], files: ConfigFile[]): ValidationResult => {
+  // Guard clause: no canaries declared
+  if (canaries.length === 0) {
+    return result;
+  }
+
+  const findings = checkCanaries(canaries, files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,179 @@
+/**
+ * @file src/application/validation/ChangeRisk.ts
+ * @description Pure functions classifying the config keys changed between two revisions by risk —
+ * secret-bearing, production-affecting, covered by rules or not — and summarizing them for reviewers
+ */
+
+import { LeakageSettings } from '../../shared/types';
+import { canonicalJson } from '../../shared/utils/CanonicalHash';
+import { extractKeyValues, isPlainObject } from '../../shared/utils/KeyPaths';
+import { isSecretKey } from '../../shared/utils/Redaction';
+import { inferEnvironment, leakageTokens, tokenEnvironment } from './EnvironmentLeakage';
+
+export type ChangeKind = 'added' | 'removed' | 'modified';
+
+export type RiskLevel = 'high' | 'medium' | 'low';
+
+export type RiskReason = 'secret' | 'production' | 'removed' | 'uncovered';
+
+/**
+ * @interface FileRevisions
+ * @description A changed file parsed at both revisions; `before` is undefined for a new file, `after` for a deleted one
+ */
+export interface FileRevisions {
+  path: string;
+  environment?: string;
+  before?: Record<string, any>;
+  after?: Record<string, any>;
+}
+
+/**
+ * @interface KeyChange
+ * @description A leaf key added, removed or modified in a file
+ */
+export interface KeyChange {
+  file: string;
+  environment?: string;
+  key: string;
+  kind: ChangeKind;
+}
+
+/**
+ * @interface RiskedChange
+ * @description A key change with its risk, why, and the rules that would catch a mistake in it
+ */
+export interface RiskedChange extends KeyChange {
+  risk: RiskLevel;
+  reasons: RiskReason[];
+  rules: string[];
+}
+
+/**
+ * @interface RiskOptions
+ * @description What a key change is classified against
+ */
+export interface RiskOptions {
+  /** Extra secret key patterns (`redaction.secret_keys`) */
+  secretKeys?: string[];
+  /** Environment tokens; the `prod` list tells production files apart */
+  leakage?: LeakageSettings;
+  /** Rules of praetorian.yaml that apply to a key; none by default */
+  rulesFor?: (key: string) => string[];
+}
+
+export const RISK_LEVELS: RiskLevel[] = ['high', 'medium', 'low'];
+
+const leavesOf = (content: Record<string, any> = {}): Map<string, string> =>
+  new Map([...extractKeyValues(content)]
+    .filter(([, value]) => !isPlainObject(value))
+    .map(([key, value]) => [key, canonicalJson(value)]));
+
+/**
+ * Lists the leaf keys that differ between the revisions of a file
+ * @param revisions - A file at both revisions
+ * @returns Added, removed and modified keys, in key order; values are compared but never kept
+ */
+export const keyChanges = (revisions: FileRevisions): KeyChange[] => {
+  const before = leavesOf(revisions.before);
+  const after = leavesOf(revisions.after);
+  const keys = [...new Set([...before.keys(), ...after.keys()])].sort();
+
+  return keys.flatMap(key => {
+    const kind: ChangeKind | undefined = !before.has(key) ? 'added'
+      : !after.has(key) ? 'removed'
+        : before.get(key) !== after.get(key) ? 'modified' : undefined;
+    return kind === undefined ? [] : [{
+      file: revisions.path,
+      ...(revisions.environment ? { environment: revisions.environment } : {}),
+      key,
+      kind,
+    }];
+  });
+};
+
+/**
+ * Classifies one key change
+ * @param change - Key change
+ * @param options - Secret keys, environment tokens and the rules of each key
+ * @returns The change rated high when it carries a secret, or touches production without a rule to
+ * catch a mistake or by removing a key; medium when it touches production or no rule covers it; low otherwise
+ */
+export const classifyChange = (change: KeyChange, options: RiskOptions = {}): RiskedChange => {
+  const tokens = leakageTokens(options.leakage);
+  const environment = change.environment ? tokenEnvironment(change.environment, tokens) : inferEnvironment(change.file, tokens);
+  const rules = options.rulesFor?.(change.key) ?? [];
+  const reasons: RiskReason[] = [
+    ...(isSecretKey(change.key.split('.').pop(), options.secretKeys) ? ['secret' as const] : []),
+    ...(environment === 'prod' ? ['production' as const] : []),
+    ...(change.kind === 'removed' ? ['removed' as const] : []),
+    ...(rules.length === 0 ? ['uncovered' as const] : []),
+  ];
+  const production = reasons.includes('production');
+  const risk: RiskLevel = reasons.includes('secret') || (production && (reasons.includes('uncovered') || reasons.includes('removed')))
+    ? 'high'
+    : production || reasons.includes('uncovered') ? 'medium' : 'low';
+
+  return { ...change, risk, reasons, rules };
+};
+
+/**
+ * Classifies every key changed in the given files
+ * @param files - Changed files at both revisions
+ * @param options - Secret keys, environment tokens and the rules of each key
+ * @returns Risked changes, highest risk first, then by file and key
+ */
+export const assessChangeRisk = (files: FileRevisions[], options: RiskOptions = {}): RiskedChange[] =>
+  files
+    .flatMap(keyChanges)
+    .map(change => classifyChange(change, options))
+    .sort((a, b) => RISK_LEVELS.indexOf(a.risk) - RISK_LEVELS.indexOf(b.risk)
+      || a.file.localeCompare(b.file)
+      || a.key.localeCompare(b.key));
+
+/**
+ * Tells whether any change reaches a risk level
+ * @param changes - Risked changes
+ * @param level - Lowest level that counts
+ */
+export const reachesRisk = (changes: RiskedChange[], level: RiskLevel): boolean =>
+  changes.some(change => RISK_LEVELS.indexOf(change.risk) <= RISK_LEVELS.indexOf(level));
+
+const REASON_LABELS: Record<RiskReason, string> = {
+  secret: 'secret-bearing',
+  production: 'production',
+  removed: 'removed',
+  uncovered: 'no rule covers it',
+};
+
+/**
+ * Renders the risk summary posted for reviewers
+ * @param changes - Risked changes, in the order to show
+ * @param base - Revision the changes are measured from
+ * @returns Markdown with the count per level and one row per change; values are never shown
+ */
+export const renderRiskSummary = (changes: RiskedChange[], base: string): string => {
+  const counts = RISK_LEVELS.map(level => `${changes.filter(change => change.risk === level).length} ${level}`).join(', ');
+
+  // Guard clause: no config key changed
+  if (changes.length === 0) {
+    return `## Configuration change risk\n\nNo configuration keys changed since \`${base}\`.\n`;
+  }
+
+  return [
+    '## Configuration change risk',
+    '',
+    `${changes.length} configuration key(s) changed since \`${base}\`: ${counts}.`,
+    '',
+    '| Risk | File | Key | Change | Why | Rules |',
+    '|------|------|-----|--------|-----|-------|',
+    ...changes.map(change => [
+      change.risk,
+      `\`${change.file}\`${change.environment ? ` (${change.environment})` : ''}`,
+      `\`${change.key}\``,
+      change.kind,
+      change.reasons.map(reason => REASON_LABELS[reason]).join(', ') || '—',
+      change.rules.join(', ').replace(/\|/g, '\\|') || '—',
+    ].join(' | ')).map(row => `| ${row} |`),
+    '',
+  ].join('\n');
+};
@@ -0,0 +1,239 @@
+/**
+ * @file src/application/validation/CloudFormationChecks.ts
+ * @description Pure functions checking the Parameters, Mappings and references of CloudFormation/SAM templates
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant CLOUDFORMATION_RULE_ID
+ * @description Rule id of the CloudFormation checks, usable in `scopes:`
+ */
+export const CLOUDFORMATION_RULE_ID = 'cloudformation';
+
+const RESOURCE_TYPE = /^(AWS|Custom|Alexa)::/;
+const SUB_VARIABLE = /\$\{([^!}][^}]*)\}/g;
+
+/**
+ * Checks whether parsed content is a CloudFormation or SAM template
+ * @param content - Parsed file
+ * @returns true when the content has Resources and a template version or AWS resource types
+ */
+export const isCloudFormationTemplate = (content: unknown): content is Record<string, any> =>
+  isPlainObject(content) &&
+  isPlainObject(content.Resources) &&
+  (content.AWSTemplateFormatVersion !== undefined ||
+    Object.values(content.Resources).some(resource => isPlainObject(resource) && RESOURCE_TYPE.test(String(resource.Type))));
+
+/**
+ * Checks whether a template uses the SAM transform
+ */
+const isSamTemplate = (template: Record<string, any>): boolean =>
+  [template.Transform].flat().some(transform => typeof transform === 'string' && transform.startsWith('AWS::Serverless'));
+
+type CloudFormationCode = 'CFN_PARAMETER_DEFAULT_INVALID' | 'CFN_MAPPING_KEY_MISSING' | 'CFN_UNDEFINED_REFERENCE';
+
+const cloudFormationFinding = (
+  code: CloudFormationCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  observedValue?: unknown
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    ...(observedValue !== undefined ? { observedValue } : {}),
+    rule: { id: CLOUDFORMATION_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Matches a whole value against an AllowedPattern; patterns JavaScript cannot compile are not judged
+ */
+const matchesAllowedPattern = (pattern: string, value: string): boolean => {
+  try {
+    return new RegExp(`^(?:${pattern})$`).test(value);
+  } catch {
+    return true;
+  }
+};
+
+/**
+ * Lists the constraints of a parameter its Default breaks
+ * @param parameter - Parameter declaration
+ * @returns Names of the broken constraints (AllowedValues, AllowedPattern, MinLength, ...)
+ */
+export const brokenParameterConstraints = (parameter: Record<string, any>): string[] => {
+  // Guard clause: nothing to check without a default
+  if (parameter.Default === undefined || parameter.Default === null) {
+    return [];
+  }
+
+  const text = String(parameter.Default);
+  // List parameters check every comma-separated element
+  const values = /List/.test(String(parameter.Type)) ? text.split(',').map(value => value.trim()) : [text];
+  const number = Number(text);
+
+  const checks: Array<[string, boolean]> = [
+    ['AllowedValues', Array.isArray(parameter.AllowedValues) &&
+      !values.every(value => parameter.AllowedValues.map(String).includes(value))],
+    ['AllowedPattern', typeof parameter.AllowedPattern === 'string' &&
+      !values.every(value => matchesAllowedPattern(parameter.AllowedPattern, value))],
+    ['MinLength', parameter.MinLength !== undefined && text.length < Number(parameter.MinLength)],
+    ['MaxLength', parameter.MaxLength !== undefined && text.length > Number(parameter.MaxLength)],
+    ['MinValue', parameter.MinValue !== undefined && (Number.isNaN(number) || number < Number(parameter.MinValue))],
+    ['MaxValue', parameter.MaxValue !== undefined && (Number.isNaN(number) || number > Number(parameter.MaxValue))],
+  ];
+
+  return checks.filter(([, broken]) => broken).map(([constraint]) => constraint);
+};
+
+/**
+ * Finds parameter defaults that would be rejected at deploy time
+ */
+const parameterFindings = (file: ConfigFile, template: Record<string, any>): ValidationError[] =>
+  Object.entries(isPlainObject(template.Parameters) ? template.Parameters : {})
+    .filter(([, parameter]) => isPlainObject(parameter))
+    .flatMap(([name, parameter]) => brokenParameterConstraints(parameter).map(constraint => cloudFormationFinding(
+      'CFN_PARAMETER_DEFAULT_INVALID',
+      `Default of parameter '${name}' breaks its ${constraint} constraint (${file.path})`,
+      file,
+      `Parameters.${name}.Default`,
+      { parameter: name, constraint },
+      parameter.Default
+    )));
+
+/**
+ * Finds mapping entries that lack a key other entries of the same mapping define,
+ * e.g. a `prod` entry of a per-environment mapping without the `MinSize` of `dev`
+ */
+const mappingFindings = (file: ConfigFile, template: Record<string, any>): ValidationError[] =>
+  Object.entries(isPlainObject(template.Mappings) ? template.Mappings : {})
+    .filter(([, mapping]) => isPlainObject(mapping))
+    .flatMap(([mapping, entries]) => {
+      const levels = Object.entries(entries as Record<string, unknown>)
+        .filter((entry): entry is [string, Record<string, any>] => isPlainObject(entry[1]));
+      const attributes = [...new Set(levels.flatMap(([, values]) => Object.keys(values)))];
+
+      return levels.flatMap(([entry, values]) => attributes
+        .filter(attribute => !Object.prototype.hasOwnProperty.call(values, attribute))
+        .map(attribute => cloudFormationFinding(
+          'CFN_MAPPING_KEY_MISSING',
+          `Mapping '${mapping}' has no '${attribute}' under '${entry}' while other entries define it (${file.path})`,
+          file,
+          `Mappings.${mapping}.${entry}.${attribute}`,
+          { mapping, entry, attribute }
+        )));
+    });
+
+/**
+ * A name a template refers to through Ref, Fn::GetAtt or Fn::Sub
+ */
+export interface TemplateReference {
+  keyPath: string;
+  name: string;
+  intrinsic: 'Ref' | 'Fn::GetAtt' | 'Fn::Sub';
+}
+
+const subReferences = (value: unknown, keyPath: string): TemplateReference[] => {
+  const [text, variables] = Array.isArray(value) ? value : [value, {}];
+
+  // Guard clause: the template string is itself computed
+  if (typeof text !== 'string') {
+    return [];
+  }
+
+  return [...text.matchAll(SUB_VARIABLE)]
+    .map(([, variable]) => variable.trim().split('.')[0])
+    .filter(name => !(isPlainObject(variables) && Object.prototype.hasOwnProperty.call(variables, name)))
+    .map(name => ({ keyPath, name, intrinsic: 'Fn::Sub' as const }));
+};
+
+/**
+ * Lists every Ref, Fn::GetAtt and Fn::Sub reference in a value
+ * @param value - Any part of a template
+ * @param keyPath - Key path of the value
+ * @returns References with the key path of the intrinsic function that holds them
+ */
+export const templateReferences = (value: unknown, keyPath: string = ''): TemplateReference[] => {
+  if (Array.isArray(value)) {
+    return value.flatMap((item, index) => templateReferences(item, joinKeyPath(keyPath, String(index))));
+  }
+
+  // Guard clause: scalars hold no references
+  if (!isPlainObject(value)) {
+    return [];
+  }
+
+  const own: TemplateReference[] = [
+    ...(typeof value.Ref === 'string' ? [{ keyPath: joinKeyPath(keyPath, 'Ref'), name: value.Ref, intrinsic: 'Ref' as const }] : []),
+    ...(Array.isArray(value['Fn::GetAtt']) && typeof value['Fn::GetAtt'][0] === 'string'
+      ? [{ keyPath: joinKeyPath(keyPath, 'Fn::GetAtt'), name: value['Fn::GetAtt'][0], intrinsic: 'Fn::GetAtt' as const }]
+      : []),
+    ...(value['Fn::Sub'] !== undefined ? subReferences(value['Fn::Sub'], joinKeyPath(keyPath, 'Fn::Sub')) : []),
+  ];
+
+  return [...own, ...Object.entries(value).flatMap(([key, child]) => templateReferences(child, joinKeyPath(keyPath, key)))];
+};
+
+/**
+ * Finds references to parameters or resources the template does not declare
+ */
+const referenceFindings = (file: ConfigFile, template: Record<string, any>): ValidationError[] => {
+  const parameters = Object.keys(isPlainObject(template.Parameters) ? template.Parameters : {});
+  const resources = Object.keys(template.Resources);
+  const sam = isSamTemplate(template);
+
+  const isDeclared = (reference: TemplateReference): boolean =>
+    reference.name.startsWith('AWS::') ||
+    resources.includes(reference.name) ||
+    (reference.intrinsic !== 'Fn::GetAtt' && parameters.includes(reference.name)) ||
+    // SAM generates resources named after the ones declared (`MyFunctionRole`, `ServerlessRestApi`, ...)
+    (sam && (reference.name.startsWith('Serverless') || resources.some(resource => reference.name.startsWith(resource))));
+
+  // Parameters and Mappings cannot hold references; Metadata is free-form
+  return ['Conditions', 'Resources', 'Outputs']
+    .flatMap(section => templateReferences(template[section], section))
+    .filter(reference => !isDeclared(reference))
+    .map(reference => cloudFormationFinding(
+      'CFN_UNDEFINED_REFERENCE',
+      `${reference.intrinsic} refers to '${reference.name}', which is neither a parameter nor a resource of the template (${file.path})`,
+      file,
+      reference.keyPath,
+      { reference: reference.name, intrinsic: reference.intrinsic }
+    ));
+};
+
+/**
+ * Checks every CloudFormation or SAM template among the given files
+ * @param files - Parsed files; files that are not templates are skipped
+ * @returns Findings about parameter defaults, incomplete mappings and undefined references
+ */
+export const checkCloudFormation = (files: ConfigFile[]): ValidationError[] =>
+  files
+    .filter(file => isCloudFormationTemplate(file.content))
+    .flatMap(file => [
+      ...parameterFindings(file, file.content),
+      ...mappingFindings(file, file.content),
+      ...referenceFindings(file, file.content),
+    ]);
+
+/**
+ * Adds CloudFormation findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the CloudFormation checks run on
+ * @returns Result that fails when a template would be rejected or break at deploy time
+ */
+export const withCloudFormationFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkCloudFormation(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
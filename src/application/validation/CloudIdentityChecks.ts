@@ -0,0 +1,234 @@
+/**
+ * @file src/application/validation/CloudIdentityChecks.ts
+ * @description Pure functions extracting cloud regions, AWS accounts, GCP projects and Azure subscriptions
+ * from values and checking that each environment sticks to its own, allow-listed set
+ */
+
+import { CloudAllowList, CloudIdentifierSettings, ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, tokenEnvironment } from './EnvironmentLeakage';
+
+/**
+ * @constant CLOUD_IDENTITY_RULE_ID
+ * @description Rule id of the cloud identifier checks, usable in `scopes:`
+ */
+export const CLOUD_IDENTITY_RULE_ID = 'cloud-identifiers';
+
+export type CloudIdentifierKind = 'region' | 'account' | 'project' | 'subscription';
+
+/**
+ * @type CloudIdentityTarget
+ * @description A parsed file with its environment; the format does not matter to these checks
+ */
+export type CloudIdentityTarget = Pick<ConfigFile, 'path' | 'content' | 'environment'>;
+
+/**
+ * @interface CloudIdentifier
+ * @description A region, account, project or subscription found in a value
+ */
+export interface CloudIdentifier {
+  kind: CloudIdentifierKind;
+  value: string;
+  keyPath: string;
+}
+
+// `cloud_identifiers:` lists, by kind
+const ALLOW_LIST_FIELDS: Record<CloudIdentifierKind, keyof CloudAllowList> = {
+  region: 'regions',
+  account: 'accounts',
+  project: 'projects',
+  subscription: 'subscriptions',
+};
+
+// us-east-1 (also inside us-east-1a and ARNs), europe-west1 (also inside europe-west1-b)
+const AWS_REGION = /(?<![a-z0-9])(?:us|eu|ap|sa|ca|me|af|il|mx)(?:-gov)?-(?:north|south|east|west|central|northeast|southeast|northwest|southwest)-\d(?!\d)/g;
+const GCP_REGION = /(?<![a-z0-9])(?:us|europe|asia|australia|northamerica|southamerica|me|africa)-(?:north|south|east|west|central|northeast|southeast|northwest|southwest)\d(?!\d)/g;
+const ARN_ACCOUNT = /arn:aws[a-z-]*:[a-z0-9-]*:[a-z0-9-]*:(\d{12}):/g;
+const ECR_ACCOUNT = /(?<!\d)(\d{12})\.dkr\.ecr\./g;
+const GCP_PROJECT_PATH = /(?:^|\/)projects\/([a-z][a-z0-9-]{4,28}[a-z0-9])(?=\/|$)/g;
+const AZURE_SUBSCRIPTION_PATH = /\/subscriptions\/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})/gi;
+
+const ACCOUNT_ID = /^\d{12}$/;
+const PROJECT_ID = /^[a-z][a-z0-9-]{4,28}[a-z0-9]$/;
+const SUBSCRIPTION_ID = /^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$/i;
+
+// Key names, lower case without separators, whose whole value is an identifier
+const ACCOUNT_KEYS = ['account', 'accountid', 'awsaccount', 'awsaccountid'];
+const PROJECT_KEYS = ['project', 'projectid', 'gcpproject', 'gcpprojectid', 'googlecloudproject', 'gcloudproject'];
+const SUBSCRIPTION_KEYS = ['subscription', 'subscriptionid', 'azuresubscriptionid', 'armsubscriptionid'];
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+const matches = (text: string, pattern: RegExp, group: number = 0): string[] =>
+  [...text.matchAll(pattern)].map(match => match[group]);
+
+/**
+ * Lists the cloud identifiers in the values of a parsed file, once per key path
+ * @param content - Parsed file
+ * @returns Regions from any string, accounts from ARNs, ECR hosts and account keys, projects from
+ * `projects/<id>` paths and project keys, subscriptions from `/subscriptions/<id>` paths and subscription keys
+ */
+export const cloudIdentifiers = (content: unknown): CloudIdentifier[] =>
+  leaves(content).flatMap(({ keyPath, value }) => {
+    // Guard clause: identifiers are strings, or numbers for AWS accounts
+    if (typeof value !== 'string' && typeof value !== 'number') {
+      return [];
+    }
+
+    const text = String(value);
+    const key = normalize(keyPath.split('.').pop() ?? '');
+    const found: Array<[CloudIdentifierKind, string]> = [
+      ...[...matches(text, AWS_REGION), ...matches(text, GCP_REGION)].map(region => ['region', region] as [CloudIdentifierKind, string]),
+      ...[...matches(text, ARN_ACCOUNT, 1), ...matches(text, ECR_ACCOUNT, 1), ...(ACCOUNT_KEYS.includes(key) && ACCOUNT_ID.test(text) ? [text] : [])]
+        .map(account => ['account', account] as [CloudIdentifierKind, string]),
+      ...[...matches(text, GCP_PROJECT_PATH, 1), ...(PROJECT_KEYS.includes(key) && PROJECT_ID.test(text) ? [text] : [])]
+        .map(project => ['project', project] as [CloudIdentifierKind, string]),
+      ...[...matches(text, AZURE_SUBSCRIPTION_PATH, 1), ...(SUBSCRIPTION_KEYS.includes(key) && SUBSCRIPTION_ID.test(text) ? [text] : [])]
+        .map(subscription => ['subscription', subscription.toLowerCase()] as [CloudIdentifierKind, string]),
+    ];
+
+    return [...new Set(found.map(([kind, identifier]) => `${kind}\u0000${identifier}`))]
+      .map(entry => {
+        const [kind, identifier] = entry.split('\u0000');
+        return { kind: kind as CloudIdentifierKind, value: identifier, keyPath };
+      });
+  });
+
+type CloudIdentityCode = 'CLOUD_IDENTIFIER_NOT_ALLOWED' | 'CLOUD_IDENTIFIER_SHARED' | 'CLOUD_IDENTIFIER_INCONSISTENT';
+
+const cloudIdentityFinding = (
+  code: CloudIdentityCode,
+  message: string,
+  file: CloudIdentityTarget,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment: string
+): ValidationError => ({
+  code,
+  message,
+  // Several accounts in one environment can be deliberate (cross-account access); the rest cross environments
+  severity: code === 'CLOUD_IDENTIFIER_INCONSISTENT' ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    environment,
+    keyPath,
+    rule: { id: CLOUD_IDENTITY_RULE_ID },
+    extras,
+  },
+});
+
+interface LocatedIdentifier extends CloudIdentifier {
+  file: CloudIdentityTarget;
+  environment: string;
+  /** Environment of the token lists (`production` -> `prod`), to compare environments named differently */
+  group: string;
+}
+
+/**
+ * Checks the cloud identifiers of the given files. With an allow-list for an environment and
+ * kind, any other identifier is reported. Without one, accounts, projects and subscriptions
+ * used by another environment are reported (except in production, taken as the reference),
+ * and so are the ones that differ from what most files of the same environment use.
+ * @param files - Files with their environment (inferred from the file name when unknown); others are skipped
+ * @param settings - Environment name -> allowed identifiers
+ * @returns Findings about identifiers outside their environment's set
+ */
+export const checkCloudIdentities = (files: CloudIdentityTarget[], settings: CloudIdentifierSettings = {}): ValidationError[] => {
+  const tokens = leakageTokens();
+  const groupOf = (environment: string): string => tokenEnvironment(environment, tokens) ?? environment;
+  const allowLists = Object.entries(settings).map(([environment, allowList]) => ({ environment, group: groupOf(environment), allowList }));
+  const allowed = (group: string, kind: CloudIdentifierKind): string[] | undefined =>
+    allowLists.find(entry => entry.group === group)?.allowList[ALLOW_LIST_FIELDS[kind]]?.map(value => value.toLowerCase());
+
+  const located: LocatedIdentifier[] = files.flatMap(file => {
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    return environment === undefined
+      ? []
+      : cloudIdentifiers(file.content).map(identifier => ({ ...identifier, file, environment, group: groupOf(environment) }));
+  });
+
+  const notAllowed = located
+    .filter(identifier => allowed(identifier.group, identifier.kind) !== undefined)
+    .filter(identifier => !(allowed(identifier.group, identifier.kind) as string[]).includes(identifier.value.toLowerCase()))
+    .map(identifier => {
+      const belongsTo = allowLists
+        .filter(entry => entry.group !== identifier.group &&
+          (entry.allowList[ALLOW_LIST_FIELDS[identifier.kind]] ?? []).some(value => value.toLowerCase() === identifier.value.toLowerCase()))
+        .map(entry => entry.environment);
+      return cloudIdentityFinding(
+        'CLOUD_IDENTIFIER_NOT_ALLOWED',
+        `${identifier.kind} ${identifier.value} at '${identifier.keyPath}' is not allowed in ${identifier.environment}` +
+          `${belongsTo.length > 0 ? `; it belongs to ${belongsTo.join(', ')}` : ''} (${identifier.file.path})`,
+        identifier.file,
+        identifier.keyPath,
+        { kind: identifier.kind, value: identifier.value, belongsTo: belongsTo.join(', ') },
+        identifier.environment
+      );
+    });
+
+  // Regions are commonly shared between environments; accounts, projects and subscriptions should not be
+  const unlisted = located.filter(identifier => identifier.kind !== 'region' && allowed(identifier.group, identifier.kind) === undefined);
+
+  const shared = unlisted
+    .filter(identifier => identifier.group !== 'prod')
+    .flatMap(identifier => {
+      const others = [...new Set(located
+        .filter(other => other.group !== identifier.group && other.kind === identifier.kind && other.value === identifier.value)
+        .map(other => other.environment))];
+      return others.length === 0 ? [] : [{ identifier, others }];
+    });
+
+  const inconsistent = unlisted
+    .filter(identifier => !shared.some(entry => entry.identifier === identifier))
+    .filter(identifier => {
+      const values = unlisted
+        .filter(other => other.group === identifier.group && other.kind === identifier.kind)
+        .map(other => other.value);
+      const count = (value: string): number => values.filter(other => other === value).length;
+      const baseline = values.reduce((common, value) => (count(value) > count(common) ? value : common), values[0]);
+      return identifier.value !== baseline;
+    });
+
+  return [
+    ...notAllowed,
+    ...shared.map(({ identifier, others }) => cloudIdentityFinding(
+      'CLOUD_IDENTIFIER_SHARED',
+      `${identifier.kind} ${identifier.value} at '${identifier.keyPath}' is also used by ${others.join(', ')}, not only ${identifier.environment} (${identifier.file.path})`,
+      identifier.file,
+      identifier.keyPath,
+      { kind: identifier.kind, value: identifier.value, otherEnvironments: others.join(', ') },
+      identifier.environment
+    )),
+    ...inconsistent.map(identifier => cloudIdentityFinding(
+      'CLOUD_IDENTIFIER_INCONSISTENT',
+      `${identifier.kind} ${identifier.value} at '${identifier.keyPath}' differs from the ${identifier.kind} the other ${identifier.environment} files use (${identifier.file.path})`,
+      identifier.file,
+      identifier.keyPath,
+      { kind: identifier.kind, value: identifier.value },
+      identifier.environment
+    )),
+  ];
+};
+
+/**
+ * Adds cloud identifier findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @param settings - `cloud_identifiers:` allow-lists
+ * @returns Result that fails when an environment uses another environment's cloud identifiers
+ */
+export const withCloudIdentityFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings: CloudIdentifierSettings = {}
+): ValidationResult => {
+  const findings = checkCloudIdentities(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
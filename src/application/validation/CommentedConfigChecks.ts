@@ -0,0 +1,158 @@
+/**
+ * @file src/application/validation/CommentedConfigChecks.ts
+ * @description Pure functions finding large commented-out configuration blocks: runs of comment
+ * lines that still read as `key = value` settings, often dead config or stale secrets
+ */
+
+import { CommentedConfigSettings, ConfigComment, ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isSecretKey } from '../../shared/utils/Redaction';
+
+/**
+ * @constant COMMENTED_CONFIG_RULE_ID
+ * @description Rule id of the commented-out configuration checks, usable in `scopes:`
+ */
+export const COMMENTED_CONFIG_RULE_ID = 'commented-config';
+
+/**
+ * @constant DEFAULT_COMMENTED_CONFIG
+ * @description Thresholds used when `commented_config:` leaves them out
+ */
+export const DEFAULT_COMMENTED_CONFIG: Required<CommentedConfigSettings> = { minLines: 3, minAssignments: 2 };
+
+export type CommentedConfigTarget = Pick<ConfigFile, 'path' | 'comments'>;
+
+// `key = value`, `key: value`, `export KEY=value`, `- key: value`
+const ASSIGNMENT = /^(?:export\s+|-\s+)?["']?([A-Za-z_][\w.\-]*)["']?\s*(?:=|:\s)\s*(\S.*)$/;
+// `<key>value</key>` and `<property name="key" value="..."/>`
+const XML_ASSIGNMENT = /^<([\w.\-]+)[^>]*>[^<]*<\/\1>$|^<[\w.\-]+\s+[^>]*\w+\s*=\s*["'][^>]*\/?>$/;
+// `[section]` and `section:` lines neither make a block config nor prose
+const SECTION = /^\[[^\]]+\]$|^[A-Za-z_][\w.\-]*:$/;
+// Values of more words than this read as a sentence (`Remember: this one is no longer used`)
+const MAX_VALUE_WORDS = 3;
+// Labels that open a remark rather than set a key (`TODO: drop this`)
+const REMARK_LABELS = ['todo', 'fixme', 'note', 'nb', 'xxx', 'hack', 'warning', 'example', 'usage', 'see'];
+
+/**
+ * @interface CommentedBlock
+ * @description A run of comment lines on consecutive lines
+ */
+export interface CommentedBlock {
+  /** First and last line of the block */
+  startLine: number;
+  endLine: number;
+  /** Keys of the lines that read as settings */
+  keys: string[];
+  /** Lines that read as neither settings, sections nor blank */
+  proseLines: number;
+}
+
+/**
+ * Reads a comment line as a setting
+ * @returns The key it sets, or undefined when the line is not a setting
+ */
+const assignedKey = (comment: string): string | undefined => {
+  const text = comment.trim();
+  const xml = XML_ASSIGNMENT.exec(text);
+
+  if (xml) {
+    return xml[1] ?? /\bname\s*=\s*["']([^"']+)["']/.exec(text)?.[1] ?? 'element';
+  }
+
+  const match = ASSIGNMENT.exec(text);
+  const value = match?.[2].trim() ?? '';
+  const quoted = /^["'].*["']$/.test(value);
+
+  // Guard clause: not a setting, or a remark
+  if (!match || REMARK_LABELS.includes(match[1].toLowerCase())) {
+    return undefined;
+  }
+
+  return quoted || value.split(/\s+/).length <= MAX_VALUE_WORDS ? match[1] : undefined;
+};
+
+/**
+ * Groups comments into blocks of consecutive lines
+ * @param comments - Comments of a file, in line order
+ * @returns Blocks with the keys their lines set
+ */
+export const commentedBlocks = (comments: ConfigComment[]): CommentedBlock[] =>
+  comments
+    .reduce<ConfigComment[][]>((blocks, comment) => {
+      const last = blocks[blocks.length - 1];
+      return last !== undefined && last[last.length - 1].line === comment.line - 1
+        ? [...blocks.slice(0, -1), [...last, comment]]
+        : [...blocks, [comment]];
+    }, [])
+    .map(block => {
+      const keys = block.flatMap(({ text }) => {
+        const key = assignedKey(text);
+        return key === undefined ? [] : [key];
+      });
+
+      return {
+        startLine: block[0].line,
+        endLine: block[block.length - 1].line,
+        keys,
+        proseLines: block.filter(({ text }) => text.trim() !== '' && !SECTION.test(text.trim()) && assignedKey(text) === undefined).length,
+      };
+    });
+
+/**
+ * Checks the comments of the given files for commented-out configuration
+ * @param files - Files with their retained comments; files without comments are skipped
+ * @param settings - Lines and settings a block needs to be reported
+ * @returns One finding per block of at least `minLines` lines holding at least `minAssignments`
+ * settings and no more prose than settings; an error when a setting looks like a secret
+ */
+export const checkCommentedConfig = (
+  files: CommentedConfigTarget[],
+  settings: CommentedConfigSettings = {}
+): ValidationError[] => {
+  const { minLines, minAssignments } = { ...DEFAULT_COMMENTED_CONFIG, ...settings };
+
+  return files.flatMap(file => commentedBlocks(file.comments ?? [])
+    .filter(block => block.endLine - block.startLine + 1 >= minLines)
+    .filter(block => block.keys.length >= minAssignments && block.keys.length >= block.proseLines)
+    .map(block => {
+      const secrets = block.keys.filter(key => isSecretKey(key));
+      const lines = block.endLine - block.startLine + 1;
+      const span = `lines ${block.startLine}-${block.endLine}`;
+
+      return {
+        code: secrets.length > 0 ? 'COMMENTED_OUT_SECRET' : 'COMMENTED_OUT_CONFIG',
+        // Values are never shown: a commented-out secret is still a secret
+        message: secrets.length > 0
+          ? `Commented-out block at ${span} of ${file.path} still sets ${secrets.join(', ')}`
+          : `${block.keys.length} settings are commented out at ${span} of ${file.path}`,
+        severity: secrets.length > 0 ? 'error' as const : 'warning' as const,
+        context: {
+          file: file.path,
+          line: block.startLine,
+          rule: { id: COMMENTED_CONFIG_RULE_ID },
+          extras: { endLine: block.endLine, lines, assignments: block.keys.length, keys: block.keys, secrets },
+        },
+      };
+    }));
+};
+
+/**
+ * Adds commented-out configuration findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files read with their comments
+ * @param settings - `commented_config:` settings; undefined when the check is off
+ * @returns Result that fails when a commented-out block still sets a secret
+ */
+export const withCommentedConfigFindings = (
+  result: ValidationResult,
+  files: CommentedConfigTarget[],
+  settings?: CommentedConfigSettings
+): ValidationResult => {
+  // Guard clause: check not configured
+  if (settings === undefined) {
+    return result;
+  }
+
+  const findings = checkCommentedConfig(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,187 @@
+/**
+ * @file src/application/validation/ComparisonMatrix.ts
+ * @description Pure functions laying out the keys of N environments as a keys × environments matrix —
+ * present, missing, or a short hash where values differ — and rendering it as text, HTML or CSV,
+ * which stays readable where pairwise messages stop scaling
+ */
+
+import { extractKeyValues, isPlainObject, matchesKeyPattern } from '../../shared/utils/KeyPaths';
+import { hashValue } from '../../shared/utils/Redaction';
+
+export type MatrixFormat = 'text' | 'html' | 'csv';
+
+export const MATRIX_FORMATS: MatrixFormat[] = ['text', 'html', 'csv'];
+
+/** Cell of a key that a column has, with the same value as every other column having it */
+export const PRESENT = '✓';
+
+/** Cell of a key that a column lacks */
+export const MISSING = '✗';
+
+/**
+ * @interface MatrixColumn
+ * @description An environment (or file) and its parsed content
+ */
+export interface MatrixColumn {
+  name: string;
+  content: Record<string, any>;
+}
+
+/**
+ * @interface MatrixRow
+ * @description A key and one cell per column: ✓, ✗, or the short hash of its value where values differ
+ */
+export interface MatrixRow {
+  key: string;
+  cells: string[];
+}
+
+/**
+ * @interface ComparisonMatrix
+ * @description Keys × columns
+ */
+export interface ComparisonMatrix {
+  columns: string[];
+  rows: MatrixRow[];
+}
+
+/**
+ * @interface MatrixOptions
+ * @description Which keys the matrix shows
+ */
+export interface MatrixOptions {
+  /** Also show the keys every column has with the same value */
+  all?: boolean;
+  /** Key patterns left out, as in `ignore_keys:` */
+  ignoreKeys?: string[];
+}
+
+/**
+ * Short hash of a value; equal values get equal hashes and no value can be read back
+ */
+export const cellHash = (value: unknown): string =>
+  hashValue(value).replace('sha256:', '').slice(0, 8);
+
+const leavesOf = (content: Record<string, any>): Map<string, unknown> =>
+  new Map([...extractKeyValues(content)].filter(([, value]) => !isPlainObject(value)));
+
+/**
+ * Lays out the leaf keys of the columns
+ * @param columns - Environments (or files) in the order to show
+ * @param options - Whether to keep keys that agree everywhere, and the keys to leave out
+ * @returns One row per key, sorted; by default only the keys missing somewhere or with differing values
+ */
+export const buildComparisonMatrix = (columns: MatrixColumn[], options: MatrixOptions = {}): ComparisonMatrix => {
+  const leaves = columns.map(column => leavesOf(column.content));
+  const keys = [...new Set(leaves.flatMap(column => [...column.keys()]))]
+    .filter(key => !(options.ignoreKeys ?? []).some(pattern => matchesKeyPattern(key, pattern)))
+    .sort();
+
+  const rows = keys.map(key => {
+    const hashes = leaves.map(column => column.has(key) ? cellHash(column.get(key)) : undefined);
+    const agree = new Set(hashes.filter(hash => hash !== undefined)).size === 1;
+    return {
+      key,
+      cells: hashes.map(hash => hash === undefined ? MISSING : agree ? PRESENT : hash),
+      consistent: agree && hashes.every(hash => hash !== undefined),
+    };
+  });
+
+  return {
+    columns: columns.map(column => column.name),
+    rows: rows
+      .filter(row => options.all || !row.consistent)
+      .map(({ key, cells }) => ({ key, cells })),
+  };
+};
+
+/**
+ * Renders the matrix as an aligned text table
+ */
+export const renderMatrixText = (matrix: ComparisonMatrix): string => {
+  // Guard clause: every key agrees everywhere
+  if (matrix.rows.length === 0) {
+    return `Every key is present with the same value in ${matrix.columns.join(', ')}\n`;
+  }
+
+  const header = ['Key', ...matrix.columns];
+  const lines = [header, ...matrix.rows.map(row => [row.key, ...row.cells])];
+  const widths = header.map((_, index) => Math.max(...lines.map(line => line[index].length)));
+  const format = (line: string[]) => line.map((cell, index) => cell.padEnd(widths[index])).join('  ').trimEnd();
+
+  return [
+    format(header),
+    widths.map(width => '-'.repeat(width)).join('  '),
+    ...lines.slice(1).map(format),
+    '',
+    `${PRESENT} same value everywhere it is set   ${MISSING} missing   <hash> values differ; equal hashes are equal values`,
+    '',
+  ].join('\n');
+};
+
+const csvField = (value: string): string =>
+  /[",\r\n]/.test(value) ? `"${value.replace(/"/g, '""')}"` : value;
+
+/**
+ * Renders the matrix as CSV, one row per key
+ */
+export const renderMatrixCsv = (matrix: ComparisonMatrix): string =>
+  [['key', ...matrix.columns], ...matrix.rows.map(row => [row.key, ...row.cells])]
+    .map(line => line.map(csvField).join(','))
+    .join('\n') + '\n';
+
+const escapeHtml = (value: string): string =>
+  value.replace(/&/g, '&amp;').replace(/</g, '&lt;').replace(/>/g, '&gt;').replace(/"/g, '&quot;');
+
+const cellClass = (cell: string): string =>
+  cell === PRESENT ? 'present' : cell === MISSING ? 'missing' : 'differs';
+
+/**
+ * Renders the matrix as a standalone HTML page
+ * @param matrix - Matrix to render
+ * @param title - Page title and heading
+ */
+export const renderMatrixHtml = (matrix: ComparisonMatrix, title: string = 'Configuration matrix'): string => [
+  '<!DOCTYPE html>',
+  '<html lang="en">',
+  '<head>',
+  '<meta charset="utf-8">',
+  `<title>${escapeHtml(title)}</title>`,
+  '<style>',
+  'body { font-family: sans-serif; } table { border-collapse: collapse; } th, td { border: 1px solid #ccc; padding: 2px 8px; }',
+  'td.key { font-family: monospace; } td.present { color: #1a7f37; } td.missing { color: #cf222e; background: #ffebe9; } td.differs { font-family: monospace; background: #fff8c5; }',
+  '</style>',
+  '</head>',
+  '<body>',
+  `<h1>${escapeHtml(title)}</h1>`,
+  matrix.rows.length === 0
+    ? `<p>Every key is present with the same value in ${escapeHtml(matrix.columns.join(', '))}.</p>`
+    : [
+      '<table>',
+      `<thead><tr><th>Key</th>${matrix.columns.map(column => `<th>${escapeHtml(column)}</th>`).join('')}</tr></thead>`,
+      '<tbody>',
+      ...matrix.rows.map(row =>
+        `<tr><td class="key">${escapeHtml(row.key)}</td>${row.cells.map(cell => `<td class="${cellClass(cell)}">${escapeHtml(cell)}</td>`).join('')}</tr>`
+      ),
+      '</tbody>',
+      '</table>',
+      `<p>${PRESENT} same value everywhere it is set, ${MISSING} missing, a hash where values differ (equal hashes are equal values).</p>`,
+    ].join('\n'),
+  '</body>',
+  '</html>',
+  '',
+].join('\n');
+
+/**
+ * Renders the matrix in a format
+ */
+export const renderMatrix = (matrix: ComparisonMatrix, format: MatrixFormat, title?: string): string => {
+  switch (format) {
+    case 'html':
+      return renderMatrixHtml(matrix, title);
+    case 'csv':
+      return renderMatrixCsv(matrix);
+    default:
+      return renderMatrixText(matrix);
+  }
+};
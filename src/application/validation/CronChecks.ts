@@ -0,0 +1,143 @@
+/**
+ * @file src/application/validation/CronChecks.ts
+ * @description Pure functions checking the cron expressions of scheduler keys and Kubernetes CronJobs:
+ * invalid expressions, and production schedules that fire more often than allowed
+ */
+
+import { ConfigFile, CronSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { parseCron } from '../../shared/utils/Cron';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { joinKeyPath, resolveKeyPattern } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+import { kubernetesResources } from './KubernetesRules';
+
+/**
+ * @constant CRON_RULE_ID
+ * @description Rule id of the cron expression checks, usable in `scopes:`
+ */
+export const CRON_RULE_ID = 'cron-schedules';
+
+// `${SCHEDULE}` and `{{ schedule }}` are resolved at deploy time
+const INTERPOLATION = /\$\{|\{\{/;
+
+/**
+ * @interface CronEntry
+ * @description A schedule found in a file
+ */
+export interface CronEntry {
+  keyPath: string;
+  value: unknown;
+  /** Kubernetes CronJob schedules take five fields only */
+  kubernetes: boolean;
+}
+
+/**
+ * Lists the schedules of a parsed file: the `spec.schedule` of Kubernetes CronJobs and the values of the configured keys
+ * @param content - Parsed file
+ * @param keys - Key path patterns of scheduler keys (`*` matches one segment)
+ * @returns Schedules with their key path, once per key path
+ */
+export const cronEntries = (content: Record<string, any>, keys: string[] = []): CronEntry[] => {
+  const cronJobs = kubernetesResources(content)
+    .filter(resource => resource.manifest.kind === 'CronJob' && resource.manifest.spec?.schedule !== undefined)
+    .map(resource => ({ keyPath: joinKeyPath(resource.keyPath, 'spec.schedule'), value: resource.manifest.spec.schedule, kubernetes: true }));
+  const configured = keys
+    .flatMap(pattern => resolveKeyPattern(content, pattern))
+    .filter(({ value }) => value !== undefined && value !== null)
+    .map(({ path, value }) => ({ keyPath: path, value, kubernetes: false }));
+
+  return [...cronJobs, ...configured]
+    .filter((entry, index, entries) => entries.findIndex(other => other.keyPath === entry.keyPath) === index);
+};
+
+type CronCode = 'CRON_EXPRESSION_INVALID' | 'CRON_SCHEDULE_TOO_FREQUENT';
+
+const cronFinding = (
+  code: CronCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  // A frequent schedule may be intended; an invalid one never runs
+  severity: code === 'CRON_EXPRESSION_INVALID' ? 'error' : 'warning',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: CRON_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks the cron expressions of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @param settings - Scheduler key patterns and the shortest interval production accepts
+ * @returns Findings about invalid expressions and too frequent production schedules
+ */
+export const checkCronSchedules = (files: ConfigFile[], settings: CronSettings = { keys: [] }): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const production = environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+
+    return cronEntries(file.content, settings.keys)
+      .filter(({ value }) => !(typeof value === 'string' && INTERPOLATION.test(value)))
+      .flatMap(({ keyPath, value, kubernetes }) => {
+        const parsed = typeof value === 'string'
+          ? parseCron(value, !kubernetes)
+          : { valid: false as const, reason: 'the expression is not a string' };
+
+        // Guard clause: expression does not parse
+        if (!parsed.valid) {
+          return [cronFinding(
+            'CRON_EXPRESSION_INVALID',
+            `'${keyPath}' is not a valid cron expression: ${parsed.reason} (${file.path})`,
+            file,
+            keyPath,
+            { expression: value, reason: parsed.reason },
+            environment
+          )];
+        }
+
+        const interval = parsed.minIntervalMs;
+        const minimum = settings.minIntervalMs;
+
+        // Guard clause: only production schedules are held to the minimum interval
+        if (!production || minimum === undefined || interval === undefined || interval >= minimum) {
+          return [];
+        }
+
+        return [cronFinding(
+          'CRON_SCHEDULE_TOO_FREQUENT',
+          `'${keyPath}' runs every ${interval / 1000}s in production, more often than every ${minimum / 1000}s (${file.path})`,
+          file,
+          keyPath,
+          { expression: value, intervalMs: interval, minIntervalMs: minimum },
+          environment
+        )];
+      });
+  });
+};
+
+/**
+ * Adds cron expression findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @param settings - `cron:` settings
+ * @returns Result that fails when a schedule does not parse
+ */
+export const withCronFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings: CronSettings = { keys: [] }
+): ValidationResult => {
+  const findings = checkCronSchedules(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
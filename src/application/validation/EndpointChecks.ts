@@ -0,0 +1,147 @@
+/**
+ * @file src/application/validation/EndpointChecks.ts
+ * @description Pure functions finding URL values and turning endpoint probe results into findings
+ */
+
+import { ConfigFile, ValidationError, ValidationResult, ValidationSeverity } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { extractKeyValues } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant ENDPOINT_RULE_ID
+ * @description Rule id of the endpoint checks, usable in `scopes:`
+ */
+export const ENDPOINT_RULE_ID = 'endpoints';
+
+/**
+ * @interface EndpointTarget
+ * @description A URL value and where it was found
+ */
+export interface EndpointTarget {
+  file: string;
+  keyPath: string;
+  url: string;
+}
+
+/**
+ * @interface EndpointOutcome
+ * @description What probing a URL found; no problem means reachable
+ */
+export interface EndpointOutcome {
+  problem?: 'DNS_FAILED' | 'UNREACHABLE' | 'TLS_EXPIRED' | 'REDIRECT_HOST';
+  detail?: string;
+  location?: string;
+}
+
+const FINDINGS: Record<NonNullable<EndpointOutcome['problem']>, { code: string; severity: ValidationSeverity; describe: (target: EndpointTarget, outcome: EndpointOutcome) => string }> = {
+  DNS_FAILED: {
+    code: 'ENDPOINT_DNS_FAILED',
+    severity: 'error',
+    describe: target => `Host of '${target.keyPath}' in ${target.file} does not resolve: ${displayUrl(target.url)}`,
+  },
+  UNREACHABLE: {
+    code: 'ENDPOINT_UNREACHABLE',
+    severity: 'error',
+    describe: (target, outcome) => `Endpoint '${target.keyPath}' in ${target.file} is unreachable: ${displayUrl(target.url)} (${outcome.detail})`,
+  },
+  TLS_EXPIRED: {
+    code: 'ENDPOINT_TLS_EXPIRED',
+    severity: 'error',
+    describe: target => `TLS certificate of '${target.keyPath}' in ${target.file} has expired: ${displayUrl(target.url)}`,
+  },
+  REDIRECT_HOST: {
+    code: 'ENDPOINT_REDIRECT_HOST',
+    severity: 'warning',
+    describe: (target, outcome) => `Endpoint '${target.keyPath}' in ${target.file} redirects to another host: ${displayUrl(target.url)} -> ${outcome.location}`,
+  },
+};
+
+/**
+ * Checks whether a value is an absolute http(s) URL
+ * @param value - Configuration value
+ * @returns true for http:// and https:// URLs with a host
+ */
+export const isHttpUrl = (value: unknown): value is string => {
+  // Guard clause: not a URL-looking string
+  if (typeof value !== 'string' || !/^https?:\/\//i.test(value.trim())) {
+    return false;
+  }
+
+  try {
+    return new URL(value.trim()).hostname.length > 0;
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Drops credentials from a URL before it is shown
+ * @param url - URL value
+ * @returns URL without user and password
+ */
+export const displayUrl = (url: string): string => {
+  const parsed = new URL(url);
+
+  // Guard clause: no credentials to hide
+  if (!parsed.username && !parsed.password) {
+    return url;
+  }
+
+  parsed.username = '';
+  parsed.password = '';
+  return parsed.href;
+};
+
+/**
+ * Lists every URL-typed value of the files
+ * @param files - Loaded files
+ * @returns URL values with their file and key path
+ */
+export const endpointTargets = (files: ConfigFile[]): EndpointTarget[] =>
+  files.flatMap(file => [...extractKeyValues(file.content)]
+    .filter(([, value]) => isHttpUrl(value))
+    .map(([keyPath, value]) => ({ file: file.path, keyPath, url: (value as string).trim() })));
+
+/**
+ * Turns probe outcomes into findings
+ * @param targets - URL values
+ * @param outcomes - URL -> probe outcome
+ * @returns One finding per value whose endpoint has a problem
+ */
+export const endpointFindings = (targets: EndpointTarget[], outcomes: Map<string, EndpointOutcome>): ValidationError[] =>
+  targets.flatMap(target => {
+    const outcome = outcomes.get(target.url);
+
+    // Guard clause: reachable or not probed
+    if (!outcome?.problem) {
+      return [];
+    }
+
+    const finding = FINDINGS[outcome.problem];
+    return [{
+      code: finding.code,
+      message: finding.describe(target, outcome),
+      severity: finding.severity,
+      path: target.keyPath,
+      context: {
+        file: target.file,
+        keyPath: target.keyPath,
+        observedValue: target.url,
+        rule: { id: ENDPOINT_RULE_ID },
+        extras: {
+          url: displayUrl(target.url),
+          ...(outcome.detail ? { detail: outcome.detail } : {}),
+          ...(outcome.location ? { location: outcome.location } : {}),
+        },
+      },
+    }];
+  });
+
+/**
+ * Adds endpoint findings to a result
+ * @param result - Result of the other rules
+ * @param findings - Endpoint findings
+ * @returns Result that fails when an endpoint error was found
+ */
+export const withEndpointFindings = (result: ValidationResult, findings: ValidationError[]): ValidationResult =>
+  findings.length === 0 ? result : withFindings(result, [...collectFindings(result), ...findings]);
@@ -0,0 +1,170 @@
+/**
+ * @file src/application/validation/EnvironmentLeakage.ts
+ * @description Heuristics flagging values that belong to another environment than their file
+ */
+
+import { LeakageSettings, ValidationResult, ValidationWarning } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { extractKeyValues } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant LEAKAGE_RULE_ID
+ * @description Rule id of the leakage check, usable in `scopes:`
+ */
+export const LEAKAGE_RULE_ID = 'environment-leakage';
+
+/**
+ * @constant DEFAULT_LEAKAGE_TOKENS
+ * @description Tokens that give a value away as belonging to an environment
+ */
+export const DEFAULT_LEAKAGE_TOKENS: Record<string, string[]> = {
+  dev: ['dev', 'development', 'local', 'localhost', '127.0.0.1'],
+  staging: ['staging', 'stage', 'stg', 'preprod'],
+  prod: ['prod', 'production', 'prd'],
+};
+
+/**
+ * @interface LeakageTarget
+ * @description A parsed file and the environment it belongs to, when known
+ */
+export interface LeakageTarget {
+  path: string;
+  content: Record<string, any>;
+  environment?: string;
+}
+
+/**
+ * Resolves the token list of every environment; configured lists replace the defaults
+ * @param settings - `leakage:` settings
+ * @param environments - Environments of the audited files
+ * @returns Environment name -> lower-case tokens; an unknown environment is its own token
+ */
+export const leakageTokens = (settings: LeakageSettings = {}, environments: string[] = []): Record<string, string[]> => {
+  const names = [...new Set([...Object.keys(DEFAULT_LEAKAGE_TOKENS), ...Object.keys(settings.tokens ?? {}), ...environments])];
+
+  return Object.fromEntries(names.map(name => [
+    name,
+    (settings.tokens?.[name] ?? DEFAULT_LEAKAGE_TOKENS[name] ?? [name]).map(token => token.toLowerCase()),
+  ]));
+};
+
+const escapeRegExp = (text: string): string => text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+
+/**
+ * Checks whether a text mentions a token as a whole word (`db-prod-1` mentions `prod`, `product` does not)
+ * @param text - Value or file name
+ * @param token - Lower-case token
+ * @returns true when the token appears delimited by non-alphanumeric characters
+ */
+export const mentionsToken = (text: string, token: string): boolean =>
+  new RegExp(`(^|[^a-z0-9])${escapeRegExp(token)}([^a-z0-9]|$)`).test(text.toLowerCase());
+
+/**
+ * Guesses the environment of a file from its name
+ * @param filePath - Path of the file
+ * @param tokens - Environment tokens
+ * @returns The only environment whose tokens appear in the file name, if exactly one does
+ */
+export const inferEnvironment = (filePath: string, tokens: Record<string, string[]>): string | undefined => {
+  const fileName = filePath.split(/[\\/]/).pop() ?? filePath;
+  const matches = Object.keys(tokens).filter(environment => tokens[environment].some(token => mentionsToken(fileName, token)));
+  return matches.length === 1 ? matches[0] : undefined;
+};
+
+/**
+ * Finds the environment of the token lists an environment name stands for (`production` -> `prod`)
+ * @param environment - Environment name
+ * @param tokens - Environment tokens
+ * @returns The environment itself when it has tokens, else the first one whose tokens it mentions
+ */
+export const tokenEnvironment = (environment: string, tokens: Record<string, string[]>): string | undefined =>
+  tokens[environment] ? environment : Object.keys(tokens).find(name => tokens[name].some(token => mentionsToken(environment, token)));
+
+/**
+ * Finds the foreign environment a value gives away
+ * @param value - String value
+ * @param environment - Environment of the file
+ * @param tokens - Environment tokens
+ * @returns The other environment and the token matched, unless the value also mentions its own environment
+ */
+export const foreignEnvironment = (
+  value: string,
+  environment: string,
+  tokens: Record<string, string[]>
+): { environment: string; token: string } | undefined => {
+  const own = tokens[environment] ?? [];
+
+  // Guard clause: the value names its own environment, e.g. a dev-to-prod bridge
+  if (own.some(token => mentionsToken(value, token))) {
+    return undefined;
+  }
+
+  return Object.entries(tokens)
+    .filter(([other]) => other !== environment)
+    .flatMap(([other, otherTokens]) => otherTokens
+      // Tokens shared with the file's own environment say nothing
+      .filter(token => !own.includes(token) && mentionsToken(value, token))
+      .map(token => ({ environment: other, token })))[0];
+};
+
+/**
+ * Flags string values that clearly belong to another environment than their file
+ * @param targets - Files with their environment (inferred from the file name when unknown)
+ * @param settings - `leakage:` settings
+ * @returns One warning per leaking value
+ */
+export const detectLeakage = (targets: LeakageTarget[], settings: LeakageSettings = {}): ValidationWarning[] => {
+  const tokens = leakageTokens(settings, targets.flatMap(target => target.environment ? [target.environment] : []));
+
+  return targets.flatMap(target => {
+    const environment = target.environment ?? inferEnvironment(target.path, tokens);
+
+    // Guard clause: a file of unknown environment cannot leak
+    if (!environment) {
+      return [];
+    }
+
+    return [...extractKeyValues(target.content)]
+      .filter(([, value]) => typeof value === 'string')
+      .flatMap(([keyPath, value]) => {
+        const foreign = foreignEnvironment(value, environment, tokens);
+        return foreign
+          ? [{
+            code: 'ENVIRONMENT_LEAKAGE',
+            message: `Value of '${keyPath}' in ${target.path} (${environment}) looks like ${foreign.environment}: it mentions '${foreign.token}'`,
+            severity: 'warning' as const,
+            path: keyPath,
+            context: {
+              file: target.path,
+              environment,
+              keyPath,
+              observedValue: value,
+              rule: { id: LEAKAGE_RULE_ID },
+              extras: { suspectedEnvironment: foreign.environment, token: foreign.token },
+            },
+          }]
+          : [];
+      });
+  });
+};
+
+/**
+ * Adds leakage warnings to a result
+ * @param result - Result of the other rules
+ * @param targets - Files the leakage check runs on
+ * @param settings - `leakage:` settings; undefined when the check is off
+ * @returns Result with the leakage warnings appended
+ */
+export const withLeakage = (
+  result: ValidationResult,
+  targets: LeakageTarget[],
+  settings: LeakageSettings | undefined
+): ValidationResult => {
+  // Guard clause: check disabled
+  if (!settings) {
+    return result;
+  }
+
+  const warnings = detectLeakage(targets, settings);
+  return warnings.length === 0 ? result : withFindings(result, [...collectFindings(result), ...warnings]);
+};
@@ -0,0 +1,171 @@
+/**
+ * @file src/application/validation/FeatureFlagChecks.ts
+ * @description Pure functions auditing LaunchDarkly and Unleash flag exports: flags only production
+ * turns on, flags past their expiry date and percentage rollouts above a threshold
+ */
+
+import { ConfigFile, FeatureFlagSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { readFeatureFlags } from '../../shared/utils/FeatureFlags';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant FEATURE_FLAG_RULE_ID
+ * @description Rule id of the feature flag checks, usable in `scopes:`
+ */
+export const FEATURE_FLAG_RULE_ID = 'feature-flags';
+
+/**
+ * @constant DEFAULT_ROLLOUT_THRESHOLD
+ * @description Rollout percentage above which a rollout is reported, unless `feature_flags.rollout_threshold` says otherwise
+ */
+export const DEFAULT_ROLLOUT_THRESHOLD = 50;
+
+type FeatureFlagCode =
+  | 'FEATURE_FLAG_MISSING_IN_STAGING'
+  | 'FEATURE_FLAG_OFF_IN_STAGING'
+  | 'FEATURE_FLAG_EXPIRED'
+  | 'FEATURE_FLAG_ROLLOUT_OVER_THRESHOLD';
+
+/**
+ * A flag state with the file it was read from and the environment it belongs to
+ */
+interface LocatedState {
+  file: ConfigFile;
+  flag: string;
+  environment?: string;
+  enabled: boolean;
+  keyPath: string;
+  rollout?: { percentage: number; keyPath: string };
+}
+
+const featureFlagFinding = (
+  code: FeatureFlagCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  // A flag production serves and staging never had is untested; the rest may be deliberate
+  severity: code === 'FEATURE_FLAG_MISSING_IN_STAGING' ? 'error' : 'warning',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: FEATURE_FLAG_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Finds the flags production turns on that a staging environment lacks or keeps off
+ */
+const stagingFindings = (states: LocatedState[], tokens: Record<string, string[]>): ValidationError[] => {
+  const isIn = (name: string) => (state: LocatedState): boolean =>
+    state.environment !== undefined && tokens[name].some(token => mentionsToken(state.environment as string, token));
+  const staging = states.filter(isIn('staging'));
+  const stagingEnvironments = [...new Set(staging.map(state => state.environment as string))];
+
+  return states
+    .filter(isIn('prod'))
+    .filter(state => state.enabled)
+    .flatMap(state => stagingEnvironments.flatMap(stagingEnvironment => {
+      const counterparts = staging.filter(other => other.environment === stagingEnvironment && other.flag === state.flag);
+      const extras = { flag: state.flag, stagingEnvironment };
+
+      if (counterparts.length === 0) {
+        return [featureFlagFinding(
+          'FEATURE_FLAG_MISSING_IN_STAGING',
+          `Flag '${state.flag}' is on in ${state.environment} but missing from ${stagingEnvironment} (${state.file.path})`,
+          state.file,
+          state.keyPath,
+          extras,
+          state.environment
+        )];
+      }
+
+      return counterparts.some(other => other.enabled) ? [] : [featureFlagFinding(
+        'FEATURE_FLAG_OFF_IN_STAGING',
+        `Flag '${state.flag}' is on in ${state.environment} but off in ${stagingEnvironment} (${counterparts[0].file.path})`,
+        state.file,
+        state.keyPath,
+        { ...extras, stagingFile: counterparts[0].file.path },
+        state.environment
+      )];
+    }));
+};
+
+/**
+ * Audits the feature flag exports among the given files
+ * @param files - Parsed files with their environment when known; files that are not flag exports are skipped
+ * @param settings - Rollout threshold
+ * @param now - Date expiries are compared with
+ * @returns Findings about production-only flags, expired flags and large rollouts
+ */
+export const checkFeatureFlags = (
+  files: ConfigFile[],
+  settings: FeatureFlagSettings = {},
+  now: Date = new Date()
+): ValidationError[] => {
+  const tokens = leakageTokens();
+  const threshold = settings.rolloutThreshold ?? DEFAULT_ROLLOUT_THRESHOLD;
+  const exports = files.flatMap(file => {
+    const flags = readFeatureFlags(file.content);
+    return flags ? [{ file, flags }] : [];
+  });
+
+  // Flag data files hold one environment: the file's
+  const states: LocatedState[] = exports.flatMap(({ file, flags }) => flags.states.map(state => ({
+    ...state,
+    file,
+    environment: state.environment ?? file.environment ?? inferEnvironment(file.path, tokens),
+  })));
+
+  const expired = exports.flatMap(({ file, flags }) => flags.expiries
+    .filter(expiry => !isNaN(Date.parse(expiry.date)) && Date.parse(expiry.date) < now.getTime())
+    .map(expiry => featureFlagFinding(
+      'FEATURE_FLAG_EXPIRED',
+      `Flag '${expiry.flag}' expired on ${expiry.date} and is still defined (${file.path})`,
+      file,
+      expiry.keyPath,
+      { flag: expiry.flag, expiresAt: expiry.date }
+    )));
+
+  const rollouts = states
+    .filter(state => state.rollout !== undefined && state.rollout.percentage > threshold)
+    .map(state => {
+      const { percentage, keyPath } = state.rollout as { percentage: number; keyPath: string };
+      return featureFlagFinding(
+        'FEATURE_FLAG_ROLLOUT_OVER_THRESHOLD',
+        `Flag '${state.flag}' rolls out to ${percentage}% of users${state.environment ? ` in ${state.environment}` : ''}, above the ${threshold}% threshold (${state.file.path})`,
+        state.file,
+        keyPath,
+        { flag: state.flag, percentage, threshold },
+        state.environment
+      );
+    });
+
+  return [...stagingFindings(states, tokens), ...expired, ...rollouts];
+};
+
+/**
+ * Adds feature flag findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on, with their environment when known
+ * @param settings - Rollout threshold
+ * @param now - Date expiries are compared with
+ * @returns Result that fails when production turns on a flag staging does not have
+ */
+export const withFeatureFlagFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings: FeatureFlagSettings = {},
+  now: Date = new Date()
+): ValidationResult => {
+  const findings = checkFeatureFlags(files, settings, now);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
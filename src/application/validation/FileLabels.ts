@@ -0,0 +1,44 @@
+/**
+ * @file src/application/validation/FileLabels.ts
+ * @description Pure functions selecting files by label (`--label tier=critical`) and carrying the
+ * labels of the files a finding was found in into the finding
+ */
+
+import { ValidationError, ValidationResult } from '../../shared/types';
+import { commonLabels, Labels, matchesLabels } from '../../shared/utils/Labels';
+
+/**
+ * Keeps the files carrying every label of a selector
+ * @param files - Candidate paths
+ * @param labels - Labels of the files, by path
+ * @param selector - Required labels; every file is kept when empty
+ * @returns Selected paths, in their original order
+ */
+export const selectLabelledFiles = (files: string[], labels: Record<string, Labels>, selector: Labels): string[] =>
+  files.filter(file => matchesLabels(labels[file], selector));
+
+/**
+ * Adds the labels of the files of each finding to the finding
+ * @param result - Result to label
+ * @param labels - Labels of the files, by path; the result is left untouched when no file has labels
+ * @returns Result whose findings carry `context.labels`; an aggregated finding keeps the labels all its files share
+ */
+export const withFileLabels = <T extends ValidationResult>(result: T, labels: Record<string, Labels>): T => {
+  // Guard clause: no labelled files
+  if (!Object.values(labels).some(set => Object.keys(set).length > 0)) {
+    return result;
+  }
+
+  const label = <F extends ValidationError>(finding: F): F => {
+    const files = [finding.context?.file, ...(finding.context?.files ?? [])].filter((file): file is string => typeof file === 'string');
+    const shared = files.length > 0 ? commonLabels(files.map(file => labels[file] ?? {})) : {};
+    return Object.keys(shared).length === 0 ? finding : { ...finding, context: { ...finding.context, labels: shared } };
+  };
+
+  return {
+    ...result,
+    errors: result.errors.map(label),
+    warnings: result.warnings.map(label),
+    ...(result.info ? { info: result.info.map(label) } : {}),
+  };
+};
@@ -0,0 +1,98 @@
+/**
+ * @file src/application/validation/FindingAggregation.ts
+ * @description Pure functions to deduplicate findings and group the same finding across files
+ */
+
+import { ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { didYouMean } from '../../shared/utils/Similarity';
+
+/**
+ * Builds the identity of a finding, ignoring the file it was found in (but not its audit group)
+ * @param finding - Finding
+ * @returns Grouping key
+ */
+export const findingGroupKey = (finding: ValidationError): string =>
+  [finding.severity, finding.code, finding.path ?? finding.message, finding.context?.group ?? ''].join('\u0000');
+
+/**
+ * Removes findings that are exact repeats (same code, path, file and message)
+ * @param findings - Findings to deduplicate
+ * @returns Findings in original order, first occurrence kept
+ */
+export const deduplicateFindings = (findings: ValidationError[]): ValidationError[] => {
+  const seen = new Set<string>();
+
+  return findings.filter(finding => {
+    const identity = [findingGroupKey(finding), finding.context?.file ?? '', finding.message].join('\u0000');
+    if (seen.has(identity)) {
+      return false;
+    }
+    seen.add(identity);
+    return true;
+  });
+};
+
+/**
+ * Merges a group of the same finding reported for several files into one entry
+ * @param group - Findings sharing a group key
+ * @returns Single finding listing the affected files
+ */
+const mergeGroup = (group: ValidationError[]): ValidationError => {
+  const [first] = group;
+  const files = group.map(finding => finding.context?.file as string);
+  const { file = '', extras = {}, ...context } = first.context ?? {};
+  // The available keys differ per file, so they can't describe the merged finding
+  const { availableKeys, suggestions, ...otherExtras } = extras;
+  // Suggestions are kept only when every file has the same ones
+  const sameSuggestions = group.every(finding =>
+    JSON.stringify(finding.context?.extras?.suggestions ?? []) === JSON.stringify(suggestions ?? []));
+  const sharedExtras = sameSuggestions && suggestions !== undefined ? { ...otherExtras, suggestions } : otherExtras;
+  const base = sameSuggestions ? first.message : first.message.replace(didYouMean((suggestions as string[] | undefined) ?? []), '');
+  const message = file && base.includes(file)
+    ? base.split(file).join(files.join(', '))
+    : `${base} (${files.length} files)`;
+
+  return { ...first, message, context: { ...context, files, extras: sharedExtras } };
+};
+
+/**
+ * Groups findings that only differ by file into one entry with a `files` list
+ * @param findings - Findings to aggregate
+ * @returns Aggregated findings, in order of first occurrence
+ */
+export const aggregateFindings = (findings: ValidationError[]): ValidationError[] => {
+  const groups = new Map<string, ValidationError[]>();
+  const order: Array<string | ValidationError> = [];
+
+  deduplicateFindings(findings).forEach(finding => {
+    // Findings without a file or a path cannot be grouped
+    if (typeof finding.context?.file !== 'string' || finding.path === undefined) {
+      order.push(finding);
+      return;
+    }
+
+    const key = findingGroupKey(finding);
+    if (!groups.has(key)) {
+      groups.set(key, []);
+      order.push(key);
+    }
+    groups.get(key)!.push(finding);
+  });
+
+  return order.map(entry => {
+    if (typeof entry !== 'string') {
+      return entry;
+    }
+    const group = groups.get(entry)!;
+    return group.length > 1 ? mergeGroup(group) : group[0];
+  });
+};
+
+/**
+ * Aggregates the findings of a result
+ * @param result - Validation result
+ * @returns Result with deduplicated and grouped findings
+ */
+export const aggregateResult = (result: ValidationResult): ValidationResult =>
+  withFindings(result, aggregateFindings(collectFindings(result)));
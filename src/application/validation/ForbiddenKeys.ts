@@ -0,0 +1,77 @@
+/**
+ * @file src/application/validation/ForbiddenKeys.ts
+ * @description Pure functions finding the keys of `forbidden_keys:` (e.g. `debug`, `root_password`,
+ * `*.insecure`) in the files of the environments they are forbidden in
+ */
+
+import { ConfigFile, ForbiddenKeyRule, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { extractKeyPaths, matchesKeyPattern } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant FORBIDDEN_KEY_RULE_ID
+ * @description Rule id of the deny-list, the name of the `forbidden-keys` audit type usable in `scopes:`
+ */
+export const FORBIDDEN_KEY_RULE_ID = 'forbidden-keys';
+
+/**
+ * Tells whether a rule applies to the files of an environment
+ * @returns True for rules without environments; otherwise only for a file of one of them (ignoring case)
+ */
+export const appliesToEnvironment = (rule: ForbiddenKeyRule, environment?: string): boolean =>
+  rule.environments.length === 0 ||
+  (environment !== undefined && rule.environments.some(name => name.toLowerCase() === environment.toLowerCase()));
+
+/**
+ * Finds the forbidden keys of one file
+ * @param file - Parsed file, with its environment when known
+ * @param rules - `forbidden_keys:` entries
+ * @returns One error per key matching a pattern forbidden in the environment of the file
+ */
+export const findForbiddenKeys = (file: ConfigFile, rules: ForbiddenKeyRule[]): ValidationError[] => {
+  const patterns = rules.filter(rule => appliesToEnvironment(rule, file.environment)).flatMap(rule => rule.keys);
+
+  // Guard clause: nothing forbidden here
+  if (patterns.length === 0) {
+    return [];
+  }
+
+  return [...extractKeyPaths(file.content)].flatMap((keyPath): ValidationError[] => {
+    const pattern = patterns.find(candidate => matchesKeyPattern(keyPath, candidate));
+
+    return pattern === undefined ? [] : [{
+      code: 'FORBIDDEN_KEY',
+      message: `Key '${keyPath}' is forbidden by '${pattern}' (${file.path})`,
+      severity: 'error',
+      path: keyPath,
+      context: {
+        file: file.path,
+        ...(file.environment !== undefined ? { environment: file.environment } : {}),
+        keyPath,
+        rule: { id: FORBIDDEN_KEY_RULE_ID },
+        extras: { pattern },
+      },
+    }];
+  });
+};
+
+/**
+ * Adds the forbidden keys of the given files to a result
+ * @param result - Validation result
+ * @param files - Files, with their environment when known
+ * @param rules - `forbidden_keys:` entries; none means nothing is forbidden
+ * @returns The failed result when a file sets a forbidden key, otherwise the result itself
+ */
+export const withForbiddenKeys = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  rules: ForbiddenKeyRule[] = []
+): ValidationResult => {
+  // Guard clause: no deny-list
+  if (rules.length === 0) {
+    return result;
+  }
+
+  const findings = files.flatMap(file => findForbiddenKeys(file, rules));
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,180 @@
+/**
+ * @file src/application/validation/FormatLintChecks.ts
+ * @description Pure functions linting the text of config files — trailing whitespace, line endings,
+ * the final newline and tab indentation — and fixing what can be fixed without changing values
+ */
+
+import { FormatLintSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+
+/**
+ * @constant FORMAT_LINT_RULE_ID
+ * @description Rule id of the formatting checks, usable in `scopes:`
+ */
+export const FORMAT_LINT_RULE_ID = 'format-lint';
+
+export type FormatLintCode =
+  | 'FORMAT_TRAILING_WHITESPACE'
+  | 'FORMAT_LINE_ENDINGS'
+  | 'FORMAT_MISSING_FINAL_NEWLINE'
+  | 'FORMAT_TAB_INDENTATION'
+  | 'FORMAT_MIXED_INDENTATION';
+
+/**
+ * @interface FormatIssue
+ * @description A formatting problem of a file and the lines it is on
+ */
+export interface FormatIssue {
+  code: FormatLintCode;
+  /** 1-based lines, in order */
+  lines: number[];
+  /** Whether `praetorian fix` can fix it */
+  fixable: boolean;
+}
+
+/**
+ * @interface FormatLintTarget
+ * @description The raw text of a file and its parser format
+ */
+export interface FormatLintTarget {
+  path: string;
+  format: string;
+  text: string;
+}
+
+// YAML forbids tabs in indentation; in properties files they are legal but render differently everywhere
+const TAB_SENSITIVE_FORMATS = ['yaml', 'properties'];
+// Tabs in YAML indentation stop the file from parsing, so there is no parsed content to check a fix against
+const UNFIXABLE_TAB_FORMATS = ['yaml'];
+
+/**
+ * Splits text into lines, each with the line ending it had; the empty remainder after a final newline is dropped
+ */
+const linesOf = (text: string): Array<{ text: string; ending: string }> => {
+  const parts = text.split('\n');
+  const lines = parts[parts.length - 1] === '' ? parts.slice(0, -1) : parts;
+
+  return lines.map((line, index) => {
+    const terminated = index < parts.length - 1;
+    const crlf = terminated && line.endsWith('\r');
+    return { text: crlf ? line.slice(0, -1) : line, ending: !terminated ? '' : crlf ? '\r\n' : '\n' };
+  });
+};
+
+const numbered = <T>(items: T[], keep: (item: T) => boolean): number[] =>
+  items.flatMap((item, index) => (keep(item) ? [index + 1] : []));
+
+const indentationOf = (line: string): string => /^[ \t]*/.exec(line)?.[0] ?? '';
+
+/**
+ * Lints the text of a file
+ * @param text - Raw file text
+ * @param format - Parser format (`yaml`, `properties`, ...)
+ * @param settings - Expected line endings
+ * @returns Formatting issues, empty for a clean file
+ */
+export const lintFormat = (text: string, format: string, settings: FormatLintSettings = {}): FormatIssue[] => {
+  const lines = linesOf(text);
+  const expected = settings.lineEndings === 'crlf' ? '\r\n' : '\n';
+  const indented = (tab: boolean) => (line: { text: string }) =>
+    line.text.trim() !== '' && indentationOf(line.text).includes(tab ? '\t' : ' ');
+  const tabLines = numbered(lines, indented(true));
+  const spaceLines = numbered(lines, indented(false));
+  const tabSensitive = TAB_SENSITIVE_FORMATS.includes(format);
+
+  const issues: FormatIssue[] = [
+    { code: 'FORMAT_TRAILING_WHITESPACE', lines: numbered(lines, line => /[ \t]+$/.test(line.text)), fixable: true },
+    { code: 'FORMAT_LINE_ENDINGS', lines: numbered(lines, line => line.ending !== '' && line.ending !== expected), fixable: true },
+    { code: 'FORMAT_MISSING_FINAL_NEWLINE', lines: text !== '' && !text.endsWith('\n') ? [lines.length] : [], fixable: true },
+    {
+      code: 'FORMAT_TAB_INDENTATION',
+      lines: tabSensitive ? tabLines : [],
+      fixable: !UNFIXABLE_TAB_FORMATS.includes(format),
+    },
+    {
+      code: 'FORMAT_MIXED_INDENTATION',
+      lines: !tabSensitive && spaceLines.length > 0 ? tabLines : [],
+      fixable: true,
+    },
+  ];
+
+  return issues.filter(issue => issue.lines.length > 0);
+};
+
+/**
+ * Fixes the fixable issues of a file: trims trailing whitespace, normalizes line endings, adds the
+ * final newline and replaces reported tab indentation with spaces
+ * @param text - Raw file text
+ * @param format - Parser format
+ * @param settings - Expected line endings and tab width
+ * @returns Fixed text; the same text when nothing was fixable
+ */
+export const fixFormat = (text: string, format: string, settings: FormatLintSettings = {}): string => {
+  const issues = lintFormat(text, format, settings).filter(issue => issue.fixable);
+
+  // Guard clause: nothing to fix
+  if (issues.length === 0) {
+    return text;
+  }
+
+  const eol = settings.lineEndings === 'crlf' ? '\r\n' : '\n';
+  const tab = ' '.repeat(settings.tabWidth ?? 2);
+  const untab = issues.some(issue => issue.code === 'FORMAT_TAB_INDENTATION' || issue.code === 'FORMAT_MIXED_INDENTATION');
+
+  return linesOf(text)
+    .map(line => line.text.replace(/[ \t]+$/, ''))
+    .map(line => (untab ? line.replace(/^[ \t]+/, indentation => indentation.replace(/\t/g, tab)) : line))
+    .map(line => `${line}${eol}`)
+    .join('');
+};
+
+const DESCRIPTIONS: Record<FormatLintCode, string> = {
+  FORMAT_TRAILING_WHITESPACE: 'trailing whitespace',
+  FORMAT_LINE_ENDINGS: 'unexpected line endings',
+  FORMAT_MISSING_FINAL_NEWLINE: 'no final newline',
+  FORMAT_TAB_INDENTATION: 'tab indentation',
+  FORMAT_MIXED_INDENTATION: 'tabs mixed with spaces in indentation',
+};
+
+// Line numbers shown in findings; the count tells how many there are in all
+const MAX_LINES_SHOWN = 10;
+
+/**
+ * Lints the given files
+ * @param files - Raw text and format of each file
+ * @param settings - `format_lint:` settings
+ * @returns One finding per issue and file; tab-indented YAML is an error, as it does not parse
+ */
+export const checkFormat = (files: FormatLintTarget[], settings: FormatLintSettings = {}): ValidationError[] =>
+  files.flatMap(file => lintFormat(file.text, file.format, settings).map(issue => ({
+    code: issue.code,
+    message: `${file.path} has ${DESCRIPTIONS[issue.code]} on ${issue.lines.length} line(s), first at line ${issue.lines[0]}` +
+      (issue.fixable ? '; run `praetorian fix`' : ''),
+    severity: issue.code === 'FORMAT_TAB_INDENTATION' && !issue.fixable ? 'error' as const : 'warning' as const,
+    context: {
+      file: file.path,
+      line: issue.lines[0],
+      rule: { id: FORMAT_LINT_RULE_ID },
+      extras: { lines: issue.lines.slice(0, MAX_LINES_SHOWN), count: issue.lines.length, fixable: issue.fixable },
+    },
+  })));
+
+/**
+ * Adds the formatting findings of the files to a result
+ * @param result - Result of the rules before
+ * @param files - Raw text and format of each file
+ * @param settings - `format_lint:` settings; the check is off when undefined
+ */
+export const withFormatLintFindings = (
+  result: ValidationResult,
+  files: FormatLintTarget[],
+  settings?: FormatLintSettings
+): ValidationResult => {
+  // Guard clause: check not configured
+  if (settings === undefined) {
+    return result;
+  }
+
+  const findings = checkFormat(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
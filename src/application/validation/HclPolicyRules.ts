@@ -0,0 +1,197 @@
+/**
+ * @file src/application/validation/HclPolicyRules.ts
+ * @description Pure functions auditing Vault policies and Terraform provider blocks for risky grants
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { isSecretKey } from '../../shared/utils/Redaction';
+
+/**
+ * @constant HCL_POLICY_RULE_ID
+ * @description Rule id of the Vault policy and Terraform provider checks, usable in `scopes:`
+ */
+export const HCL_POLICY_RULE_ID = 'hcl-policies';
+
+/**
+ * @constant VAULT_SENSITIVE_PREFIXES
+ * @description Vault mounts a wildcard grant should not reach without an explicit deny
+ */
+export const VAULT_SENSITIVE_PREFIXES = ['sys/', 'auth/', 'identity/'];
+
+const VAULT_BROAD_CAPABILITIES = ['create', 'update', 'delete'];
+// Only wildcards: `*`, `+/*`, `+/+/*`, ...
+const VAULT_CATCH_ALL = /^(\+\/)*\*$/;
+const TLS_SKIP_KEYS = ['skip_tls_verify', 'skip_tls_verification', 'insecure', 'insecure_skip_verify', 'tls_insecure'];
+const EXPRESSION = /^(var|local|data|module)\.|\$\{|^\w+\(/;
+
+/**
+ * @interface VaultPathRule
+ * @description One `path "..." { capabilities = [...] }` rule of a Vault policy
+ */
+export interface VaultPathRule {
+  keyPath: string;
+  path: string;
+  capabilities: string[];
+}
+
+/**
+ * Lists the path rules of a Vault policy, written in HCL (`path.<glob>` keys) or JSON (a `path` mapping)
+ * @param content - Parsed file
+ * @returns Rules with a capabilities list
+ */
+export const vaultPathRules = (content: Record<string, any>): VaultPathRule[] => [
+  ...Object.entries(content)
+    .filter(([key]) => key.startsWith('path.'))
+    .map(([key, rule]) => ({ keyPath: key, path: key.slice('path.'.length), rule })),
+  ...Object.entries(isPlainObject(content.path) ? content.path : {})
+    .map(([path, rule]) => ({ keyPath: joinKeyPath('path', path), path, rule })),
+]
+  .filter(({ rule }) => isPlainObject(rule) && Array.isArray(rule.capabilities))
+  .map(({ keyPath, path, rule }) => ({ keyPath, path, capabilities: (rule as any).capabilities.map(String) }));
+
+/**
+ * Checks whether a Vault path glob matches a path: `+` stands for one segment, a trailing `*` for any suffix
+ * @param glob - Path of a policy rule
+ * @param target - Concrete path
+ * @returns true when the rule applies to the path
+ */
+export const vaultPathCovers = (glob: string, target: string): boolean => {
+  const pattern = [...glob]
+    .map((char, index) => char === '+'
+      ? '[^/]+'
+      : char === '*' && index === glob.length - 1 ? '.*' : char.replace(/[.*+?^${}()|[\]\\]/g, '\\$&'))
+    .join('');
+  return new RegExp(`^${pattern}$`).test(target);
+};
+
+const grants = (rule: VaultPathRule): string[] => rule.capabilities.filter(capability => capability !== 'deny');
+
+const isBroadGrant = (rule: VaultPathRule): boolean =>
+  rule.capabilities.includes('sudo') || VAULT_BROAD_CAPABILITIES.every(capability => rule.capabilities.includes(capability));
+
+type HclPolicyCode = 'VAULT_POLICY_WILDCARD_GRANT' | 'VAULT_POLICY_DENY_MISSING' | 'TF_PROVIDER_INLINE_CREDENTIAL' | 'TF_PROVIDER_TLS_DISABLED';
+
+// Grants that may be intended; the others are errors
+const WARNING_CODES: HclPolicyCode[] = ['VAULT_POLICY_DENY_MISSING', 'TF_PROVIDER_TLS_DISABLED'];
+
+const hclPolicyFinding = (
+  code: HclPolicyCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  observedValue?: unknown
+): ValidationError => ({
+  code,
+  message,
+  severity: WARNING_CODES.includes(code) ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    ...(observedValue !== undefined ? { observedValue } : {}),
+    rule: { id: HCL_POLICY_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Finds catch-all paths granted with sudo or full write access, and wildcard grants that reach
+ * a sensitive mount no rule denies or names explicitly
+ */
+const vaultFindings = (file: ConfigFile, rules: VaultPathRule[]): ValidationError[] => {
+  const wildcard = rules.filter(rule => VAULT_CATCH_ALL.test(rule.path) && isBroadGrant(rule));
+
+  // A rule naming the mount itself, or denying it, is a deliberate decision about it
+  const isDecided = (prefix: string): boolean =>
+    rules.some(rule => vaultPathCovers(rule.path, `${prefix}x`) && (rule.path.startsWith(prefix) || rule.capabilities.includes('deny')));
+
+  const uncovered = rules
+    .filter(rule => /[*+]/.test(rule.path) && grants(rule).length > 0 && !wildcard.includes(rule))
+    .flatMap(rule => VAULT_SENSITIVE_PREFIXES
+      .filter(prefix => vaultPathCovers(rule.path, `${prefix}x`) && !isDecided(prefix))
+      .map(prefix => ({ rule, prefix })));
+
+  return [
+    ...wildcard.map(rule => hclPolicyFinding(
+      'VAULT_POLICY_WILDCARD_GRANT',
+      `Policy grants ${grants(rule).join(', ')} on '${rule.path}', which matches every path in Vault (${file.path})`,
+      file,
+      joinKeyPath(rule.keyPath, 'capabilities'),
+      { policyPath: rule.path, capabilities: grants(rule).join(', ') }
+    )),
+    ...uncovered.map(({ rule, prefix }) => hclPolicyFinding(
+      'VAULT_POLICY_DENY_MISSING',
+      `'${rule.path}' grants ${grants(rule).join(', ')} on ${prefix}* and no rule denies it (${file.path})`,
+      file,
+      joinKeyPath(rule.keyPath, 'capabilities'),
+      { policyPath: rule.path, capabilities: grants(rule).join(', '), prefix }
+    )),
+  ];
+};
+
+const leaves = (value: unknown, keyPath: string): Array<{ keyPath: string; key: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => isPlainObject(child)
+      ? leaves(child, joinKeyPath(keyPath, key))
+      : [{ keyPath: joinKeyPath(keyPath, key), key, value: child }])
+    : [];
+
+/**
+ * Finds credentials written into provider blocks and providers told to skip TLS verification;
+ * values are never part of the message
+ */
+const providerFindings = (file: ConfigFile): ValidationError[] =>
+  Object.entries(file.content)
+    .filter(([key, block]) => key.startsWith('provider.') && isPlainObject(block))
+    .flatMap(([key, block]) => {
+      const provider = key.slice('provider.'.length);
+      const settings = leaves(block, key);
+
+      return [
+        ...settings
+          .filter(setting => (isSecretKey(setting.key) || setting.key === 'access_key') &&
+            typeof setting.value === 'string' && setting.value !== '' && !EXPRESSION.test(setting.value))
+          .map(setting => hclPolicyFinding(
+            'TF_PROVIDER_INLINE_CREDENTIAL',
+            `'${setting.key}' of provider '${provider}' is written in the file; pass it through a variable or the environment (${file.path})`,
+            file,
+            setting.keyPath,
+            { provider, variable: setting.key },
+            setting.value
+          )),
+        ...settings
+          .filter(setting => TLS_SKIP_KEYS.includes(setting.key) && setting.value === true)
+          .map(setting => hclPolicyFinding(
+            'TF_PROVIDER_TLS_DISABLED',
+            `Provider '${provider}' skips TLS verification ('${setting.key}') (${file.path})`,
+            file,
+            setting.keyPath,
+            { provider, variable: setting.key }
+          )),
+      ];
+    });
+
+/**
+ * Audits every HCL or JSON Vault policy and every Terraform provider block among the given files
+ * @param files - Parsed files; files without path rules or provider blocks yield nothing
+ * @returns Findings about risky grants
+ */
+export const checkHclPolicies = (files: ConfigFile[]): ValidationError[] =>
+  files.flatMap(file => [
+    ...vaultFindings(file, vaultPathRules(file.content)),
+    ...(file.format === 'hcl' ? providerFindings(file) : []),
+  ]);
+
+/**
+ * Adds Vault policy and Terraform provider findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when a policy or provider grants too much
+ */
+export const withHclPolicyFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkHclPolicies(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,233 @@
+/**
+ * @file src/application/validation/IamPolicyRules.ts
+ * @description Pure functions auditing the AWS IAM policy statements found in configuration and Terraform files
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant IAM_POLICY_RULE_ID
+ * @description Rule id of the IAM policy checks, usable in `scopes:`
+ */
+export const IAM_POLICY_RULE_ID = 'iam-policies';
+
+/**
+ * @constant IAM_SENSITIVE_ACTIONS
+ * @description Actions that escalate privileges or expose secrets; granting them needs a Condition
+ */
+export const IAM_SENSITIVE_ACTIONS = [
+  'iam:PassRole',
+  'iam:CreateAccessKey',
+  'iam:CreatePolicyVersion',
+  'iam:AttachRolePolicy',
+  'iam:AttachUserPolicy',
+  'iam:PutRolePolicy',
+  'iam:PutUserPolicy',
+  'iam:UpdateAssumeRolePolicy',
+  'sts:AssumeRole',
+  'kms:Decrypt',
+  'secretsmanager:GetSecretValue',
+];
+
+/**
+ * @interface PolicyStatement
+ * @description One statement of a policy, written as IAM JSON (`Action`) or as a Terraform
+ * `aws_iam_policy_document` block (`actions`); `fields` names the keys it uses
+ */
+export interface PolicyStatement {
+  keyPath: string;
+  effect: string;
+  actions?: string[];
+  notActions?: string[];
+  resources?: string[];
+  conditioned: boolean;
+  fields: { action: string; notAction: string; resource: string };
+}
+
+const IAM_FIELDS = { action: 'Action', notAction: 'NotAction', resource: 'Resource' };
+const TERRAFORM_FIELDS = { action: 'actions', notAction: 'not_actions', resource: 'resources' };
+
+const listOf = (value: unknown): string[] | undefined =>
+  value === undefined || value === null ? undefined : [value].flat().map(String);
+
+const hasCondition = (value: unknown): boolean => isPlainObject(value) ? Object.keys(value).length > 0 : Array.isArray(value) && value.length > 0;
+
+/**
+ * Reads a statement from a value, when the value is one
+ */
+const toStatement = (value: Record<string, any>, keyPath: string, key: string): PolicyStatement | undefined => {
+  if (typeof value.Effect === 'string' && (value.Action !== undefined || value.NotAction !== undefined)) {
+    return {
+      keyPath,
+      effect: value.Effect,
+      actions: listOf(value.Action),
+      notActions: listOf(value.NotAction),
+      resources: listOf(value.Resource),
+      conditioned: hasCondition(value.Condition),
+      fields: IAM_FIELDS,
+    };
+  }
+
+  // Terraform statement blocks allow unless told otherwise
+  if (key === 'statement' && (value.actions !== undefined || value.not_actions !== undefined)) {
+    return {
+      keyPath,
+      effect: typeof value.effect === 'string' ? value.effect : 'Allow',
+      actions: listOf(value.actions),
+      notActions: listOf(value.not_actions),
+      resources: listOf(value.resources),
+      conditioned: hasCondition(value.condition),
+      fields: TERRAFORM_FIELDS,
+    };
+  }
+
+  return undefined;
+};
+
+/**
+ * Parses a policy document kept as a JSON string (a Terraform heredoc, an inline CloudFormation string)
+ */
+const embeddedDocument = (text: string): unknown => {
+  // Guard clause: only JSON objects mentioning statements are worth parsing
+  if (!text.trim().startsWith('{') || !text.includes('Statement')) {
+    return undefined;
+  }
+
+  try {
+    return JSON.parse(text);
+  } catch {
+    return undefined;
+  }
+};
+
+/**
+ * Lists the policy statements of a value, wherever they sit: policy documents, serverless
+ * `iamRoleStatements`, CloudFormation `PolicyDocument`s, Terraform statement blocks and JSON strings
+ * @param value - Parsed file or any part of it
+ * @param keyPath - Key path of the value
+ * @returns Statements with their key path
+ */
+export const policyStatements = (value: unknown, keyPath: string = '', key: string = ''): PolicyStatement[] => {
+  if (typeof value === 'string') {
+    const document = embeddedDocument(value);
+    return document === undefined ? [] : policyStatements(document, keyPath);
+  }
+
+  if (Array.isArray(value)) {
+    return value.flatMap((item, index) => policyStatements(item, joinKeyPath(keyPath, String(index)), key));
+  }
+
+  // Guard clause: scalars hold no statements
+  if (!isPlainObject(value)) {
+    return [];
+  }
+
+  const statement = toStatement(value, keyPath, key);
+  return statement
+    ? [statement]
+    : Object.entries(value).flatMap(([child, childValue]) => policyStatements(childValue, joinKeyPath(keyPath, child), child));
+};
+
+/**
+ * Checks whether an IAM action pattern (`*` and `?` wildcards, case-insensitive) matches an action
+ * @param pattern - Action of a statement, e.g. `iam:*` or `s3:Get*`
+ * @param action - Concrete action
+ * @returns true when the pattern grants the action
+ */
+export const actionMatches = (pattern: string, action: string): boolean => {
+  const expression = pattern
+    .split('')
+    .map(char => (char === '*' ? '.*' : char === '?' ? '.' : char.replace(/[.+^${}()|[\]\\]/g, '\\$&')))
+    .join('');
+  return new RegExp(`^${expression}$`, 'i').test(action);
+};
+
+type IamCode = 'IAM_WILDCARD_ACTION' | 'IAM_WILDCARD_RESOURCE' | 'IAM_SENSITIVE_ACTION_UNCONDITIONED' | 'IAM_NOT_ACTION_ALLOW';
+
+// Sometimes needed (read-only describes, account-wide services); the others are errors
+const WARNING_CODES: IamCode[] = ['IAM_WILDCARD_RESOURCE', 'IAM_SENSITIVE_ACTION_UNCONDITIONED'];
+
+const iamFinding = (
+  code: IamCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown> = {}
+): ValidationError => ({
+  code,
+  message,
+  severity: WARNING_CODES.includes(code) ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    rule: { id: IAM_POLICY_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks the Allow statements of a file; Deny statements only take permissions away
+ */
+const statementFindings = (file: ConfigFile, statement: PolicyStatement): ValidationError[] => {
+  // Guard clause: nothing is granted
+  if (statement.effect !== 'Allow') {
+    return [];
+  }
+
+  const actions = statement.actions ?? [];
+  const allActions = actions.includes('*');
+  const sensitive = allActions
+    ? []
+    : IAM_SENSITIVE_ACTIONS.filter(action => actions.some(pattern => actionMatches(pattern, action)));
+  const at = (field: string): string => joinKeyPath(statement.keyPath, field);
+
+  return [
+    ...(allActions ? [iamFinding(
+      'IAM_WILDCARD_ACTION',
+      `Statement '${statement.keyPath}' allows every action ("${statement.fields.action}": "*") (${file.path})`,
+      file,
+      at(statement.fields.action)
+    )] : []),
+    ...(statement.notActions ? [iamFinding(
+      'IAM_NOT_ACTION_ALLOW',
+      `Statement '${statement.keyPath}' allows everything except its ${statement.fields.notAction} list, including actions AWS adds later (${file.path})`,
+      file,
+      at(statement.fields.notAction)
+    )] : []),
+    ...((statement.resources ?? []).includes('*') ? [iamFinding(
+      'IAM_WILDCARD_RESOURCE',
+      `Statement '${statement.keyPath}' applies to every resource ("${statement.fields.resource}": "*") (${file.path})`,
+      file,
+      at(statement.fields.resource)
+    )] : []),
+    ...(sensitive.length > 0 && !statement.conditioned ? [iamFinding(
+      'IAM_SENSITIVE_ACTION_UNCONDITIONED',
+      `Statement '${statement.keyPath}' allows ${sensitive.join(', ')} without a Condition (${file.path})`,
+      file,
+      at(statement.fields.action),
+      { actions: sensitive.join(', ') }
+    )] : []),
+  ];
+};
+
+/**
+ * Audits the IAM policy statements of the given files
+ * @param files - Parsed files; files without statements yield nothing
+ * @returns Findings about wildcard grants, NotAction allows and unconditioned sensitive actions
+ */
+export const checkIamPolicies = (files: ConfigFile[]): ValidationError[] =>
+  files.flatMap(file => policyStatements(file.content).flatMap(statement => statementFindings(file, statement)));
+
+/**
+ * Adds IAM policy findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when a policy allows every action or uses NotAction to allow
+ */
+export const withIamPolicyFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkIamPolicies(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,126 @@
+/**
+ * @file src/application/validation/ImageDefaults.ts
+ * @description Pure functions comparing ENV defaults baked into images with runtime environments
+ */
+
+import { ConfigFile, ValidationResult, ValidationWarning } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { DOCKERFILE_ARG_KEY } from '../../infrastructure/adapters/readers/DockerfileAdapter';
+
+/**
+ * @constant IMAGE_DEFAULTS_RULE_ID
+ * @description Rule id of the image default check, usable in `scopes:`
+ */
+export const IMAGE_DEFAULTS_RULE_ID = 'image-defaults';
+
+/**
+ * @interface RuntimeEnvironment
+ * @description Variables a runtime file sets: a whole env file or one compose service
+ */
+export interface RuntimeEnvironment {
+  /** Key path the variables live under ('' for env files) */
+  keyPath: string;
+  service?: string;
+  values: Record<string, string>;
+}
+
+/**
+ * Reads a compose `environment:` section, given as a mapping or as a `KEY=value` list
+ * @param environment - Parsed section
+ * @returns Variable -> value; list entries without `=` take their value from the host and are skipped
+ */
+export const composeEnvironment = (environment: unknown): Record<string, string> => {
+  if (Array.isArray(environment)) {
+    return Object.fromEntries(
+      environment
+        .filter((entry): entry is string => typeof entry === 'string' && entry.indexOf('=') > 0)
+        .map(entry => [entry.slice(0, entry.indexOf('=')), entry.slice(entry.indexOf('=') + 1)])
+    );
+  }
+
+  return isPlainObject(environment)
+    ? Object.fromEntries(
+      Object.entries(environment)
+        .filter(([, value]) => value !== null && value !== undefined && typeof value !== 'object')
+        .map(([key, value]) => [key, String(value)])
+    )
+    : {};
+};
+
+/**
+ * Lists the variables a file sets at runtime
+ * @param file - Env file or compose file
+ * @returns One entry for an env file, one per service with an environment for a compose file
+ */
+export const runtimeEnvironments = (file: ConfigFile): RuntimeEnvironment[] => {
+  if (file.format === 'env') {
+    return [{ keyPath: '', values: composeEnvironment(file.content) }];
+  }
+
+  const services = file.content?.services;
+
+  // Guard clause: neither an env file nor a compose file
+  if (!isPlainObject(services)) {
+    return [];
+  }
+
+  return Object.entries(services)
+    .filter(([, service]) => isPlainObject(service) && service.environment !== undefined)
+    .map(([name, service]) => ({
+      keyPath: `services.${name}.environment`,
+      service: name,
+      values: composeEnvironment(service.environment),
+    }));
+};
+
+/**
+ * Lists runtime values that disagree with the default baked into an image
+ * @param files - Dockerfiles, env files and compose files
+ * @returns One warning per runtime variable whose value differs from a Dockerfile ENV default
+ */
+export const checkImageDefaults = (files: ConfigFile[]): ValidationWarning[] => {
+  const dockerfiles = files.filter(file => file.format === 'dockerfile');
+
+  // Guard clause: no image to compare with
+  if (dockerfiles.length === 0) {
+    return [];
+  }
+
+  return files
+    .filter(file => file.format !== 'dockerfile')
+    .flatMap(file => runtimeEnvironments(file).map(environment => ({ file, environment })))
+    .flatMap(({ file, environment }) => dockerfiles.flatMap(dockerfile =>
+      Object.entries(dockerfile.content)
+        .filter(([key, value]) => key !== DOCKERFILE_ARG_KEY && typeof value === 'string')
+        .filter(([key, value]) => environment.values[key] !== undefined && environment.values[key] !== value)
+        .map(([key, value]): ValidationWarning => {
+          const keyPath = joinKeyPath(environment.keyPath, key);
+          return {
+            code: 'IMAGE_DEFAULT_MISMATCH',
+            message: `'${key}' in ${file.path}${environment.service ? ` (service ${environment.service})` : ''} differs from the default baked into ${dockerfile.path}`,
+            severity: 'warning',
+            path: keyPath,
+            context: {
+              file: file.path,
+              keyPath,
+              observedValue: environment.values[key],
+              expectedValue: value,
+              rule: { id: IMAGE_DEFAULTS_RULE_ID },
+              extras: { variable: key, dockerfile: dockerfile.path, ...(environment.service ? { service: environment.service } : {}) },
+            },
+          };
+        })
+    ));
+};
+
+/**
+ * Adds image default warnings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the check runs on
+ * @returns Result with one warning per disagreeing runtime value
+ */
+export const withImageDefaults = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const warnings = checkImageDefaults(files);
+  return warnings.length === 0 ? result : withFindings(result, [...warnings, ...collectFindings(result)]);
+};
@@ -0,0 +1,106 @@
+/**
+ * @file src/application/validation/KeyOrderRules.ts
+ * @description Pure functions checking that the keys of each mapping appear in a canonical order,
+ * alphabetical or declared under `key_order:`, so that concurrent edits land in predictable places
+ */
+
+import { ConfigFile, KeyOrderSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { matchesGlob } from '../../shared/utils/Glob';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { INTEGER_KEY, KeyComparator, keyComparator } from '../../shared/utils/KeySorting';
+
+/**
+ * @constant KEY_ORDER_RULE_ID
+ * @description Rule id of the key order check, usable in `scopes:`
+ */
+export const KEY_ORDER_RULE_ID = 'key-order';
+
+/**
+ * Tells whether a file is held to the key order
+ * @param filePath - File path
+ * @param settings - `key_order:` settings
+ * @returns true when `files` is empty or one of its globs matches
+ */
+export const isKeyOrderTarget = (filePath: string, settings: KeyOrderSettings): boolean =>
+  settings.files.length === 0 || settings.files.some(pattern => matchesGlob(filePath, pattern));
+
+/**
+ * Lists the mappings of a parsed file reached through mappings; list items are left alone,
+ * as their keys often follow the order of the tool that reads them (`name` first)
+ */
+const mappingsOf = (value: Record<string, any>, keyPath: string = ''): Array<{ keyPath: string; keys: string[] }> => [
+  { keyPath, keys: Object.keys(value) },
+  ...Object.entries(value)
+    .filter(([, child]) => isPlainObject(child))
+    .flatMap(([key, child]) => mappingsOf(child, joinKeyPath(keyPath, key))),
+];
+
+/**
+ * Finds the first key out of order in a mapping
+ * @returns The key that comes too late and the key it should come before
+ */
+const firstMisplaced = (keys: string[], compare: KeyComparator): { key: string; before: string } | undefined => {
+  const sorted = [...keys].sort(compare);
+  const index = keys.findIndex((key, position) => key !== sorted[position]);
+  return index === -1 ? undefined : { key: sorted[index], before: keys[index] };
+};
+
+/**
+ * Checks the key order of the given files
+ * @param files - Loaded files
+ * @param settings - Canonical order and the files it applies to
+ * @returns One warning per mapping whose keys are out of order; mappings with integer-like keys are
+ * skipped, as parsed objects do not keep their order
+ */
+export const checkKeyOrder = (files: ConfigFile[], settings: KeyOrderSettings): ValidationError[] => {
+  const compare = keyComparator(settings.order);
+
+  return files
+    .filter(file => isKeyOrderTarget(file.path, settings))
+    .flatMap(file => mappingsOf(file.content)
+      .filter(({ keys }) => !keys.some(key => INTEGER_KEY.test(key)))
+      .flatMap(({ keyPath, keys }) => {
+        const misplaced = firstMisplaced(keys, compare);
+
+        // Guard clause: mapping in order
+        if (misplaced === undefined) {
+          return [];
+        }
+
+        const mapping = keyPath === '' ? 'the top level' : `'${keyPath}'`;
+        return [{
+          code: 'KEY_ORDER_VIOLATION',
+          message: `'${misplaced.key}' should come before '${misplaced.before}' in ${mapping} of ${file.path}; run \`praetorian fix\``,
+          severity: 'warning' as const,
+          path: joinKeyPath(keyPath, misplaced.key),
+          context: {
+            file: file.path,
+            keyPath: joinKeyPath(keyPath, misplaced.key),
+            rule: { id: KEY_ORDER_RULE_ID },
+            extras: { mapping: keyPath, before: misplaced.before, order: Array.isArray(settings.order) ? 'declared' : settings.order },
+          },
+        }];
+      }));
+};
+
+/**
+ * Adds key order findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the check runs on
+ * @param settings - `key_order:` settings; undefined when the check is off
+ * @returns Result with a warning per mapping out of order
+ */
+export const withKeyOrderFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings?: KeyOrderSettings
+): ValidationResult => {
+  // Guard clause: check not configured
+  if (settings === undefined) {
+    return result;
+  }
+
+  const findings = checkKeyOrder(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
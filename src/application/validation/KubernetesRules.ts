@@ -0,0 +1,306 @@
+/**
+ * @file src/application/validation/KubernetesRules.ts
+ * @description Pure functions checking the resource semantics of Kubernetes manifests
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant KUBERNETES_RULE_ID
+ * @description Rule id of the Kubernetes checks, usable in `scopes:`
+ */
+export const KUBERNETES_RULE_ID = 'kubernetes';
+
+/**
+ * @constant KUBERNETES_MIN_PROD_REPLICAS
+ * @description Replicas a production workload needs to survive the loss of one pod
+ */
+export const KUBERNETES_MIN_PROD_REPLICAS = 2;
+
+/**
+ * @constant KUBERNETES_REQUIRED_PROBES
+ * @description Probes every container of a long-running workload must declare
+ */
+export const KUBERNETES_REQUIRED_PROBES = ['readinessProbe', 'livenessProbe'];
+
+// Workloads that keep pods running (Jobs and CronJobs run to completion and need no probes)
+const LONG_RUNNING_KINDS = ['Deployment', 'StatefulSet', 'DaemonSet', 'ReplicaSet', 'Pod'];
+const REPLICATED_KINDS = ['Deployment', 'StatefulSet', 'ReplicaSet'];
+
+/**
+ * @interface KubernetesResource
+ * @description A manifest found in a file and the key path it was found under
+ */
+export interface KubernetesResource {
+  /** Key path of the manifest in the parsed file ('' for a single-manifest file) */
+  keyPath: string;
+  manifest: Record<string, any>;
+}
+
+/**
+ * Checks whether a value is a Kubernetes manifest
+ * @param value - Parsed value
+ * @returns true when the value has an apiVersion and a kind
+ */
+export const isKubernetesManifest = (value: unknown): value is Record<string, any> =>
+  isPlainObject(value) && typeof value.apiVersion === 'string' && typeof value.kind === 'string';
+
+/**
+ * Lists the manifests of a parsed file: a single manifest, the items of a `List`,
+ * or the documents of a multi-document YAML file
+ * @param content - Parsed file
+ * @returns Manifests with their key path; none for ordinary configuration files
+ */
+export const kubernetesResources = (content: Record<string, any>): KubernetesResource[] => {
+  if (isKubernetesManifest(content)) {
+    return content.kind === 'List' && Array.isArray(content.items)
+      ? content.items
+        .map((item: unknown, index: number) => ({ keyPath: `items.${index}`, manifest: item }))
+        .filter((resource: { manifest: unknown }): resource is KubernetesResource => isKubernetesManifest(resource.manifest))
+      : [{ keyPath: '', manifest: content }];
+  }
+
+  const entries = Object.entries(content ?? {});
+  return entries.length > 0 && entries.every(([, value]) => isKubernetesManifest(value))
+    ? entries.map(([keyPath, manifest]) => ({ keyPath, manifest }))
+    : [];
+};
+
+/**
+ * Describes a manifest as `Kind/name`
+ * @param manifest - Kubernetes manifest
+ * @returns Kind and name of the resource
+ */
+export const resourceName = (manifest: Record<string, any>): string =>
+  `${manifest.kind}/${manifest.metadata?.name ?? 'unnamed'}`;
+
+/**
+ * Finds the pod template of a workload
+ * @param resource - Manifest and its key path
+ * @returns Pod spec and its key path, if the resource runs pods
+ */
+export const podSpecOf = (resource: KubernetesResource): { keyPath: string; spec: Record<string, any> } | undefined => {
+  const { kind } = resource.manifest;
+  const specPath = kind === 'Pod'
+    ? ['spec']
+    : kind === 'CronJob'
+      ? ['spec', 'jobTemplate', 'spec', 'template', 'spec']
+      : ['spec', 'template', 'spec'];
+  const spec = specPath.reduce<any>((value, key) => (isPlainObject(value) ? value[key] : undefined), resource.manifest);
+
+  return isPlainObject(spec) ? { keyPath: joinKeyPath(resource.keyPath, specPath.join('.')), spec } : undefined;
+};
+
+const KUBERNETES_SUFFIXES: Record<string, number> = {
+  m: 1e-3,
+  k: 1e3,
+  M: 1e6,
+  G: 1e9,
+  T: 1e12,
+  P: 1e15,
+  E: 1e18,
+  Ki: 2 ** 10,
+  Mi: 2 ** 20,
+  Gi: 2 ** 30,
+  Ti: 2 ** 40,
+  Pi: 2 ** 50,
+  Ei: 2 ** 60,
+};
+
+/**
+ * Parses a Kubernetes resource quantity (`500m` CPU, `512Mi` or `1G` memory)
+ * @param value - Quantity as written in the manifest
+ * @returns Amount in base units, or undefined when the value is not a quantity
+ */
+export const parseKubernetesQuantity = (value: unknown): number | undefined => {
+  if (typeof value === 'number') {
+    return Number.isFinite(value) ? value : undefined;
+  }
+
+  const match = typeof value === 'string'
+    ? /^([+-]?\d+(?:\.\d+)?)(?:[eE]([+-]?\d+)|(Ki|Mi|Gi|Ti|Pi|Ei|m|k|M|G|T|P|E))?$/.exec(value.trim())
+    : null;
+
+  // Guard clause: not a quantity
+  if (!match) {
+    return undefined;
+  }
+
+  const [, amount, exponent, suffix] = match;
+  return Number(amount) * (exponent !== undefined ? 10 ** Number(exponent) : KUBERNETES_SUFFIXES[suffix] ?? 1);
+};
+
+/**
+ * Checks whether an image reference is pinned to a tag other than `latest` or to a digest
+ * @param image - Image reference, e.g. `registry:5000/team/api:1.4.2`
+ * @returns false for untagged and `:latest` images
+ */
+export const isImagePinned = (image: string): boolean => {
+  // Guard clause: digests are immutable
+  if (image.includes('@')) {
+    return true;
+  }
+
+  // A colon after the last slash separates the tag; one before it belongs to a registry port
+  const name = image.split('/').pop() ?? image;
+  const tag = name.includes(':') ? name.split(':').pop() : undefined;
+  return tag !== undefined && tag !== '' && tag !== 'latest';
+};
+
+type KubernetesCode = 'K8S_LIMIT_BELOW_REQUEST' | 'K8S_PROBE_MISSING' | 'K8S_IMAGE_NOT_PINNED' | 'K8S_REPLICAS_TOO_LOW';
+
+const kubernetesFinding = (
+  code: KubernetesCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: code === 'K8S_PROBE_MISSING' ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(file.environment ? { environment: file.environment } : {}),
+    keyPath,
+    rule: { id: KUBERNETES_RULE_ID },
+    extras,
+  },
+});
+
+const containersOf = (
+  pod: { keyPath: string; spec: Record<string, any> },
+  field: 'containers' | 'initContainers'
+): Array<{ keyPath: string; container: Record<string, any> }> =>
+  (Array.isArray(pod.spec[field]) ? pod.spec[field] : [])
+    .map((container: unknown, index: number) => ({ keyPath: `${pod.keyPath}.${field}.${index}`, container }))
+    .filter((entry: { container: unknown }): entry is { keyPath: string; container: Record<string, any> } => isPlainObject(entry.container));
+
+/**
+ * Checks the containers of one workload: limits, probes and image tags
+ */
+const containerFindings = (file: ConfigFile, resource: KubernetesResource): ValidationError[] => {
+  const pod = podSpecOf(resource);
+
+  // Guard clause: not a workload
+  if (!pod) {
+    return [];
+  }
+
+  const name = resourceName(resource.manifest);
+  const longRunning = LONG_RUNNING_KINDS.includes(resource.manifest.kind);
+  const containers = [
+    ...containersOf(pod, 'initContainers').map(entry => ({ ...entry, init: true })),
+    ...containersOf(pod, 'containers').map(entry => ({ ...entry, init: false })),
+  ];
+
+  return containers.flatMap(({ keyPath, container, init }) => {
+    const containerName = container.name ?? keyPath;
+    const extras = { resource: name, container: containerName };
+    const requests = isPlainObject(container.resources?.requests) ? container.resources.requests : {};
+    const limits = isPlainObject(container.resources?.limits) ? container.resources.limits : {};
+
+    const belowRequest = Object.keys(limits)
+      .filter(key => {
+        const limit = parseKubernetesQuantity(limits[key]);
+        const request = parseKubernetesQuantity(requests[key]);
+        return limit !== undefined && request !== undefined && limit < request;
+      })
+      .map(key => kubernetesFinding(
+        'K8S_LIMIT_BELOW_REQUEST',
+        `${key} limit ${limits[key]} of container '${containerName}' in ${name} is below its request ${requests[key]} (${file.path})`,
+        file,
+        `${keyPath}.resources.limits.${key}`,
+        { ...extras, resourceName: key, limit: limits[key], request: requests[key] }
+      ));
+
+    // Init containers run to completion before the probes start
+    const missingProbes = longRunning && !init
+      ? KUBERNETES_REQUIRED_PROBES
+        .filter(probe => !isPlainObject(container[probe]))
+        .map(probe => kubernetesFinding(
+          'K8S_PROBE_MISSING',
+          `Container '${containerName}' in ${name} has no ${probe} (${file.path})`,
+          file,
+          `${keyPath}.${probe}`,
+          { ...extras, probe }
+        ))
+      : [];
+
+    const unpinned = typeof container.image === 'string' && !isImagePinned(container.image)
+      ? [kubernetesFinding(
+        'K8S_IMAGE_NOT_PINNED',
+        `Image '${container.image}' of container '${containerName}' in ${name} is not pinned to a tag or digest (${file.path})`,
+        file,
+        `${keyPath}.image`,
+        { ...extras, image: container.image }
+      )]
+      : [];
+
+    return [...belowRequest, ...missingProbes, ...unpinned];
+  });
+};
+
+/**
+ * Checks that a production workload runs more than one replica
+ */
+const replicaFindings = (file: ConfigFile, resource: KubernetesResource, production: boolean): ValidationError[] => {
+  // Guard clause: only replicated workloads of production files
+  if (!production || !REPLICATED_KINDS.includes(resource.manifest.kind)) {
+    return [];
+  }
+
+  // Kubernetes runs one replica when none is declared
+  const replicas = resource.manifest.spec?.replicas ?? 1;
+  const name = resourceName(resource.manifest);
+
+  return typeof replicas === 'number' && replicas < KUBERNETES_MIN_PROD_REPLICAS
+    ? [kubernetesFinding(
+      'K8S_REPLICAS_TOO_LOW',
+      `${name} runs ${replicas} replica(s) in production; at least ${KUBERNETES_MIN_PROD_REPLICAS} are required (${file.path})`,
+      file,
+      joinKeyPath(resource.keyPath, 'spec.replicas'),
+      { resource: name, replicas, minimum: KUBERNETES_MIN_PROD_REPLICAS }
+    )]
+    : [];
+};
+
+/**
+ * Checks every Kubernetes manifest of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about limits, probes, image tags and production replicas
+ */
+export const checkKubernetes = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const resources = kubernetesResources(file.content);
+
+    // Guard clause: not a manifest
+    if (resources.length === 0) {
+      return [];
+    }
+
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const production = environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+    return resources.flatMap(resource => [
+      ...containerFindings(file, resource),
+      ...replicaFindings(file, resource, production),
+    ]);
+  });
+};
+
+/**
+ * Adds Kubernetes findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the Kubernetes checks run on
+ * @returns Result that fails when a manifest breaks a resource rule
+ */
+export const withKubernetesFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkKubernetes(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,107 @@
+/**
+ * @file src/application/validation/LocaleSettingChecks.ts
+ * @description Pure functions checking well-known time zone, locale and currency keys against
+ * the `timezone`, `locale` and `currency` value types
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { matchesValueType } from '../../shared/utils/ValueTypes';
+
+/**
+ * @constant LOCALE_SETTING_RULE_ID
+ * @description Rule id of the time zone, locale and currency checks, usable in `scopes:`
+ */
+export const LOCALE_SETTING_RULE_ID = 'locale-settings';
+
+export type LocaleSettingType = 'timezone' | 'locale' | 'currency';
+
+/**
+ * @constant LOCALE_SETTING_KEYS
+ * @description Key names, lower case without separators, matched against the last segment of a key path
+ * (`spring.jackson.time-zone` is `timezone`, `TZ` is `tz`); list keys are checked item by item
+ */
+export const LOCALE_SETTING_KEYS: Record<LocaleSettingType, string[]> = {
+  timezone: ['timezone', 'tz', 'timezoneid', 'defaulttimezone', 'usertimezone', 'displaytimezone'],
+  locale: ['locale', 'defaultlocale', 'fallbacklocale', 'locales', 'supportedlocales', 'availablelocales'],
+  currency: ['currency', 'currencycode', 'defaultcurrency', 'basecurrency', 'currencies', 'supportedcurrencies'],
+};
+
+const CODES: Record<LocaleSettingType, string> = {
+  timezone: 'INVALID_TIMEZONE',
+  locale: 'INVALID_LOCALE',
+  currency: 'INVALID_CURRENCY',
+};
+
+const EXPECTED: Record<LocaleSettingType, string> = {
+  timezone: 'an IANA time zone',
+  locale: 'a BCP 47 language tag',
+  currency: 'an ISO 4217 currency code',
+};
+
+// `${TZ}` and `{{ locale }}` are resolved at deploy time
+const INTERPOLATION = /\$\{|\{\{/;
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+/**
+ * Finds the value type a key stands for
+ * @param keyPath - Dotted key path
+ * @returns `timezone`, `locale` or `currency`, if the last segment is a well-known key
+ */
+export const localeSettingOf = (keyPath: string): LocaleSettingType | undefined => {
+  const key = normalize(keyPath.split('.').pop() ?? '');
+  return (Object.keys(LOCALE_SETTING_KEYS) as LocaleSettingType[]).find(type => LOCALE_SETTING_KEYS[type].includes(key));
+};
+
+/**
+ * Checks the time zone, locale and currency keys of the given files
+ * @param files - Loaded files
+ * @returns One error per value that is not a valid time zone, language tag or currency code;
+ * non-string values (a `locale:` section) and interpolated values are left alone
+ */
+export const checkLocaleSettings = (files: ConfigFile[]): ValidationError[] =>
+  files.flatMap(file =>
+    leaves(file.content).flatMap(({ keyPath, value }) => {
+      const type = localeSettingOf(keyPath);
+
+      // Guard clause: not a locale-sensitive key
+      if (type === undefined) {
+        return [];
+      }
+
+      return ((Array.isArray(value) ? value : [value]) as unknown[])
+        .filter((item): item is string => typeof item === 'string' && !INTERPOLATION.test(item))
+        .filter(item => !matchesValueType(type, item))
+        .map(item => ({
+          code: CODES[type],
+          message: `'${keyPath}' is '${item}', not ${EXPECTED[type]} (${file.path})`,
+          severity: 'error' as const,
+          path: keyPath,
+          context: {
+            file: file.path,
+            keyPath,
+            observedValue: item,
+            rule: { id: LOCALE_SETTING_RULE_ID },
+            extras: { type, expected: EXPECTED[type] },
+          },
+        }));
+    })
+  );
+
+/**
+ * Adds time zone, locale and currency findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when a well-known key holds an invalid value
+ */
+export const withLocaleSettingFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkLocaleSettings(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,359 @@
+/**
+ * @file src/application/validation/LoggingRules.ts
+ * @description Pure functions auditing logging configurations (logback, log4j2, zap): verbose
+ * production levels, sensitive fields missing from the redaction list and file outputs without rotation
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant LOGGING_RULE_ID
+ * @description Rule id of the logging checks, usable in `scopes:`
+ */
+export const LOGGING_RULE_ID = 'logging';
+
+/**
+ * @constant LOGGING_SENSITIVE_FIELDS
+ * @description Fields every logging configuration must mask or drop
+ */
+export const LOGGING_SENSITIVE_FIELDS = ['password', 'secret', 'token', 'authorization', 'api_key', 'cookie'];
+
+// DEBUG and anything more verbose
+const VERBOSE_LEVELS = ['debug', 'trace', 'all'];
+const REDACTION_MARKER = /mask|redact/i;
+const CONSOLE_OUTPUTS = ['stdout', 'stderr'];
+const LOG4J2_FILE_APPENDERS = ['file', 'randomaccessfile', 'memorymappedfile'];
+const LOG4J2_ROLLING_APPENDERS = ['rollingfile', 'rollingrandomaccessfile'];
+
+export type LoggingFramework = 'logback' | 'log4j2' | 'zap';
+
+/**
+ * @interface LoggingConfig
+ * @description What the checks need from a logging configuration, whatever its framework
+ */
+export interface LoggingConfig {
+  framework: LoggingFramework;
+  /** Levels of the root logger and the named loggers */
+  levels: Array<{ keyPath: string; logger: string; level: string }>;
+  /** Outputs that write to files */
+  fileOutputs: Array<{ keyPath: string; name: string; rotated: boolean }>;
+}
+
+interface Located {
+  keyPath: string;
+  value: Record<string, any>;
+}
+
+/**
+ * Lists the elements of a parsed XML/JSON node that may be one element or a list of them
+ */
+const elements = (value: unknown, keyPath: string): Located[] =>
+  Array.isArray(value)
+    ? value.flatMap((item, index) => (isPlainObject(item) ? [{ keyPath: joinKeyPath(keyPath, String(index)), value: item }] : []))
+    : isPlainObject(value) ? [{ keyPath, value }] : [];
+
+// log4j2 element names are case-insensitive
+const childKey = (node: Record<string, any>, name: string): string | undefined =>
+  Object.keys(node).find(key => key.toLowerCase() === name.toLowerCase());
+
+const children = (node: Located, name: string): Located[] => {
+  const key = childKey(node.value, name);
+  return key === undefined ? [] : elements(node.value[key], joinKeyPath(node.keyPath, key));
+};
+
+/**
+ * Reads a level as written (`DEBUG`, `<level value="debug"/>`, `${LOG_LEVEL:-debug}`), lower-cased;
+ * a substitution counts as its default
+ */
+export const levelName = (value: unknown): string | undefined => {
+  const raw = isPlainObject(value) ? value.value : value;
+
+  // Guard clause: no level set
+  if (typeof raw !== 'string') {
+    return undefined;
+  }
+
+  const substitution = /^\$\{.*?:-(.*)\}$/.exec(raw.trim());
+  return (substitution ? substitution[1] : raw).trim().toLowerCase();
+};
+
+const levelOf = (node: Located, logger: string): LoggingConfig['levels'] => {
+  const key = childKey(node.value, 'level');
+  const level = key === undefined ? undefined : levelName(node.value[key]);
+
+  // Guard clause: the logger inherits its level
+  if (key === undefined || level === undefined) {
+    return [];
+  }
+
+  return [{ keyPath: joinKeyPath(node.keyPath, isPlainObject(node.value[key]) ? `${key}.value` : key), logger, level }];
+};
+
+const isLogback = (file: ConfigFile): boolean =>
+  file.format === 'xml' && (file.content.appender !== undefined || file.content.root !== undefined) &&
+  (/logback/i.test(file.path) || elements(file.content.appender, 'appender').some(({ value }) => /^ch\.qos\.logback\./.test(String(value.class))));
+
+/**
+ * Reads a logback.xml: `<root>` and `<logger>` levels, file appenders and their rolling policy
+ */
+const logbackConfig = (content: Record<string, any>): LoggingConfig => ({
+  framework: 'logback',
+  levels: [
+    ...elements(content.root, 'root').flatMap(root => levelOf(root, 'root')),
+    ...elements(content.logger, 'logger').flatMap(logger => levelOf(logger, String(logger.value.name))),
+  ],
+  fileOutputs: elements(content.appender, 'appender')
+    .filter(({ value }) => /FileAppender$/.test(String(value.class)))
+    .map(({ keyPath, value }) => ({
+      keyPath,
+      name: String(value.name),
+      // A RollingFileAppender without a policy never rolls
+      rotated: value.rollingPolicy !== undefined || value.triggeringPolicy !== undefined,
+    })),
+});
+
+/**
+ * Reads a log4j2 configuration in XML, JSON or YAML: `Root`/`Logger` levels (sync or async)
+ * and File/RollingFile appenders, written as elements or as `Appender` entries with a `type`
+ */
+const log4j2Config = (configuration: Located): LoggingConfig => {
+  const appenders = children(configuration, 'Appenders').flatMap(section => Object.keys(section.value).flatMap(key =>
+    elements(section.value[key], joinKeyPath(section.keyPath, key)).map(appender => ({
+      ...appender,
+      type: (key.toLowerCase() === 'appender' ? String(appender.value.type) : key).toLowerCase(),
+    }))
+  ));
+  const loggers = children(configuration, 'Loggers');
+
+  return {
+    framework: 'log4j2',
+    levels: loggers.flatMap(section => [
+      ...['Root', 'AsyncRoot'].flatMap(name => children(section, name)).flatMap(root => levelOf(root, 'root')),
+      ...['Logger', 'AsyncLogger'].flatMap(name => children(section, name)).flatMap(logger => levelOf(logger, String(logger.value.name))),
+    ]),
+    fileOutputs: appenders
+      .filter(({ type }) => LOG4J2_FILE_APPENDERS.includes(type) || LOG4J2_ROLLING_APPENDERS.includes(type))
+      .map(({ keyPath, value, type }) => ({
+        keyPath,
+        name: String(value.name),
+        rotated: LOG4J2_ROLLING_APPENDERS.includes(type) && (childKey(value, 'Policies') !== undefined || childKey(value, 'Policy') !== undefined),
+      })),
+  };
+};
+
+/**
+ * Reads a log4j2.properties file: `rootLogger.level`, `logger.<id>.level` and `appender.<id>.*`
+ */
+const log4j2PropertiesConfig = (content: Record<string, any>): LoggingConfig => {
+  const keys = Object.keys(content);
+  const rootLevel = content['rootLogger.level'] !== undefined ? 'rootLogger.level' : 'rootLogger';
+  const appenderIds = [...new Set(keys.filter(key => /^appender\.[^.]+\.type$/.test(key)).map(key => key.split('.')[1]))];
+
+  return {
+    framework: 'log4j2',
+    levels: [
+      // `rootLogger = debug, STDOUT` is shorthand for the level and the appender refs
+      ...(typeof content[rootLevel] === 'string'
+        ? [{ keyPath: rootLevel, logger: 'root', level: content[rootLevel].split(',')[0].trim().toLowerCase() }]
+        : []),
+      ...keys
+        .filter(key => /^logger\.[^.]+\.level$/.test(key) && typeof content[key] === 'string')
+        .map(key => ({ keyPath: key, logger: String(content[`logger.${key.split('.')[1]}.name`] ?? key.split('.')[1]), level: levelName(content[key]) as string })),
+    ],
+    fileOutputs: appenderIds
+      .map(id => ({ id, type: String(content[`appender.${id}.type`]).toLowerCase() }))
+      .filter(({ type }) => LOG4J2_FILE_APPENDERS.includes(type) || LOG4J2_ROLLING_APPENDERS.includes(type))
+      .map(({ id, type }) => ({
+        keyPath: `appender.${id}.type`,
+        name: String(content[`appender.${id}.name`] ?? id),
+        rotated: LOG4J2_ROLLING_APPENDERS.includes(type) && keys.some(key => key.startsWith(`appender.${id}.polic`)),
+      })),
+  };
+};
+
+/**
+ * Reads a zap configuration: `level`, and the files among `outputPaths`/`errorOutputPaths`;
+ * files rotate through a lumberjack sink or a `rotation`/`lumberjack` section
+ */
+const zapConfig = (content: Record<string, any>): LoggingConfig => {
+  const rotation = isPlainObject(content.rotation) || isPlainObject(content.lumberjack);
+
+  return {
+    framework: 'zap',
+    levels: levelOf({ keyPath: '', value: content }, 'root'),
+    fileOutputs: ['outputPaths', 'errorOutputPaths']
+      .flatMap(field => (Array.isArray(content[field]) ? content[field] : [])
+        .map((output: unknown, index: number) => ({ keyPath: `${field}.${index}`, name: String(output) })))
+      .filter(({ name }) => !CONSOLE_OUTPUTS.includes(name))
+      .map(({ keyPath, name }) => ({ keyPath, name, rotated: rotation || /^lumberjack:/i.test(name) })),
+  };
+};
+
+/**
+ * Recognizes a logging configuration and reads it
+ * @param file - Parsed file
+ * @returns Levels and file outputs, or undefined when the file is not a logback, log4j2 or zap configuration
+ */
+export const loggingConfig = (file: ConfigFile): LoggingConfig | undefined => {
+  const { content } = file;
+
+  // Guard clause: nothing parsed
+  if (!isPlainObject(content)) {
+    return undefined;
+  }
+
+  if (isLogback(file)) {
+    return logbackConfig(content);
+  }
+
+  // XML log4j2 files lose their <Configuration> root when parsed; JSON and YAML ones keep it
+  const rootKey = childKey(content, 'Configuration');
+  const configuration = rootKey !== undefined && isPlainObject(content[rootKey]) ? { keyPath: rootKey, value: content[rootKey] } : { keyPath: '', value: content };
+  if (childKey(configuration.value, 'Appenders') !== undefined || childKey(configuration.value, 'Loggers') !== undefined) {
+    return log4j2Config(configuration);
+  }
+
+  if (file.format === 'properties' && Object.keys(content).some(key => key.startsWith('rootLogger') || /^appender\.[^.]+\.type$/.test(key))) {
+    return log4j2PropertiesConfig(content);
+  }
+
+  return Array.isArray(content.outputPaths) || (isPlainObject(content.encoderConfig) && content.level !== undefined)
+    ? zapConfig(content)
+    : undefined;
+};
+
+const stringLeaves = (value: unknown): string[] =>
+  typeof value === 'string'
+    ? value.split(',').map(entry => entry.trim()).filter(entry => entry !== '')
+    : Array.isArray(value) ? value.flatMap(stringLeaves) : isPlainObject(value) ? Object.values(value).flatMap(stringLeaves) : [];
+
+/**
+ * Finds the redaction lists of a logging configuration: every section named or classed as a
+ * mask or redaction (logstash-logback-encoder maskers, masking layouts, pino-style `redact` lists)
+ * @param value - Parsed file or any part of it
+ * @param keyPath - Key path of the value
+ * @returns Each list with the fields, paths or patterns it names
+ */
+export const redactionLists = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; entries: string[] }> => {
+  if (Array.isArray(value)) {
+    return value.flatMap((item, index) => redactionLists(item, joinKeyPath(keyPath, String(index))));
+  }
+
+  // Guard clause: scalars hold no lists
+  if (!isPlainObject(value)) {
+    return [];
+  }
+
+  return Object.entries(value).flatMap(([key, child]) => {
+    const childPath = joinKeyPath(keyPath, key);
+    const marked = REDACTION_MARKER.test(key) || (isPlainObject(child) && REDACTION_MARKER.test(String(child.class ?? child.type ?? '')));
+    return marked ? [{ keyPath: childPath, entries: stringLeaves(child) }] : redactionLists(child, childPath);
+  });
+};
+
+const normalize = (name: string): string => name.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+/**
+ * Checks whether a redaction entry covers a field: a field name, a path ending in it or a
+ * pattern naming it (`password`, `req.headers.authorization`, `(password|secret)`)
+ */
+const covers = (entry: string, field: string): boolean => normalize(entry).includes(normalize(field));
+
+type LoggingCode = 'LOGGING_DEBUG_IN_PRODUCTION' | 'LOGGING_FIELDS_UNREDACTED' | 'LOGGING_FILE_ROTATION_MISSING';
+
+const loggingFinding = (
+  code: LoggingCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  observedValue?: unknown
+): ValidationError => ({
+  code,
+  message,
+  // Verbose production logs leak data; the rest is hygiene
+  severity: code === 'LOGGING_DEBUG_IN_PRODUCTION' ? 'error' : 'warning',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    ...(observedValue !== undefined ? { observedValue } : {}),
+    rule: { id: LOGGING_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Audits one logging configuration
+ */
+const configFindings = (file: ConfigFile, config: LoggingConfig, production: boolean): ValidationError[] => {
+  const lists = redactionLists(file.content);
+  const unredacted = LOGGING_SENSITIVE_FIELDS.filter(field => !lists.some(list => list.entries.some(entry => covers(entry, field))));
+
+  return [
+    ...(production ? config.levels : [])
+      .filter(({ level }) => VERBOSE_LEVELS.includes(level))
+      .map(({ keyPath, logger, level }) => loggingFinding(
+        'LOGGING_DEBUG_IN_PRODUCTION',
+        `Logger '${logger}' logs at ${level.toUpperCase()} in production (${file.path})`,
+        file,
+        keyPath,
+        { logger, level: level.toUpperCase(), framework: config.framework },
+        level
+      )),
+    ...(unredacted.length > 0 ? [loggingFinding(
+      'LOGGING_FIELDS_UNREDACTED',
+      lists.length > 0
+        ? `The redaction list does not cover ${unredacted.join(', ')} (${file.path})`
+        : `No redaction list masks ${unredacted.join(', ')} (${file.path})`,
+      file,
+      lists.length > 0 ? lists[0].keyPath : '',
+      { fields: unredacted.join(', '), framework: config.framework }
+    )] : []),
+    ...config.fileOutputs
+      .filter(output => !output.rotated)
+      .map(({ keyPath, name }) => loggingFinding(
+        'LOGGING_FILE_ROTATION_MISSING',
+        `Output '${name}' writes to a file that is never rotated (${file.path})`,
+        file,
+        keyPath,
+        { output: name, framework: config.framework }
+      )),
+  ];
+};
+
+/**
+ * Audits every logging configuration among the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about production DEBUG levels, unredacted sensitive fields and unrotated files
+ */
+export const checkLogging = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const config = loggingConfig(file);
+
+    // Guard clause: not a logging configuration
+    if (!config) {
+      return [];
+    }
+
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const production = environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+    return configFindings(file, config, production);
+  });
+};
+
+/**
+ * Adds logging findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the logging checks run on
+ * @returns Result that fails when production logs at DEBUG or below
+ */
+export const withLoggingFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkLogging(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
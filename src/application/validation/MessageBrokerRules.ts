@@ -0,0 +1,222 @@
+/**
+ * @file src/application/validation/MessageBrokerRules.ts
+ * @description Pure functions checking Kafka and RabbitMQ client settings in any parsed format: brokers and
+ * consumer groups of the file's environment, SASL/SSL in production, consumer group names and replication factors
+ */
+
+import { BrokerSettings, ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { foreignEnvironment, inferEnvironment, leakageTokens, tokenEnvironment } from './EnvironmentLeakage';
+
+/**
+ * @constant BROKER_RULE_ID
+ * @description Rule id of the message broker checks, usable in `scopes:`
+ */
+export const BROKER_RULE_ID = 'message-brokers';
+
+/**
+ * @constant DEFAULT_MIN_REPLICATION_FACTOR
+ * @description Smallest Kafka replication factor production accepts, unless `brokers.min_replication_factor` says otherwise
+ */
+export const DEFAULT_MIN_REPLICATION_FACTOR = 3;
+
+// Key names, lower case without separators, matched against the last segments of a key path
+const BOOTSTRAP_KEYS = ['bootstrapservers', 'bootstrapserver', 'kafkabootstrapservers', 'kafkabrokers', 'metadatabrokerlist', 'brokerlist'];
+const GROUP_KEYS = ['groupid', 'consumergroup', 'consumergroupid', 'kafkagroupid', 'kafkaconsumergroup'];
+const RABBITMQ_HOST_KEYS = ['host', 'hosts', 'address', 'addresses', 'url', 'uri'];
+// Bootstrap entries may carry their listener protocol: SASL_SSL://broker:9093
+const SECURE_LISTENER = /^(sasl_)?ssl:\/\//i;
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+// `spring.kafka.bootstrap-servers` ends with `bootstrapservers`, `default.replication.factor` with `replicationfactor`
+const endsWith = (keyPath: string, names: string[]): boolean => {
+  const segments = keyPath.split('.');
+  return segments.some((_, index) => names.includes(normalize(segments.slice(index).join(''))));
+};
+
+const textOf = (value: unknown): string => (Array.isArray(value) ? value.join(',') : String(value ?? ''));
+
+/**
+ * @interface BrokerConfig
+ * @description The broker settings of a file
+ */
+export interface BrokerConfig {
+  /** Kafka bootstrap servers, RabbitMQ hosts and AMQP URIs */
+  endpoints: Array<{ keyPath: string; value: string; broker: 'kafka' | 'rabbitmq' }>;
+  consumerGroups: Array<{ keyPath: string; value: string }>;
+  replicationFactors: Array<{ keyPath: string; value: number }>;
+  /** Whether a Kafka `security.protocol` is set anywhere in the file */
+  securityProtocol: boolean;
+}
+
+/**
+ * Reads the Kafka and RabbitMQ settings of a parsed file; consumer groups and replication
+ * factors are only read from Kafka configurations, so Maven's `groupId` is not one
+ * @param content - Parsed file
+ * @returns Broker settings, empty when the file configures no broker
+ */
+export const brokerConfig = (content: unknown): BrokerConfig => {
+  const all = leaves(content);
+  const kafka = all.filter(({ keyPath, value }) => endsWith(keyPath, BOOTSTRAP_KEYS) && textOf(value) !== '');
+  const isKafka = (keyPath: string): boolean => kafka.length > 0 || /kafka/i.test(keyPath);
+  const rabbitmq = all.filter(({ keyPath, value }) =>
+    (typeof value === 'string' && /^amqps?:\/\//i.test(value)) ||
+    (/rabbit/i.test(keyPath) && RABBITMQ_HOST_KEYS.includes(normalize(keyPath.split('.').pop() ?? '')) && textOf(value) !== ''));
+  // Strimzi topics keep their replication factor in spec.replicas
+  const topicReplicas = isPlainObject(content) && content.kind === 'KafkaTopic' && isPlainObject(content.spec) && content.spec.replicas !== undefined
+    ? [{ keyPath: 'spec.replicas', value: content.spec.replicas }]
+    : [];
+
+  return {
+    endpoints: [
+      ...kafka.map(({ keyPath, value }) => ({ keyPath, value: textOf(value), broker: 'kafka' as const })),
+      ...rabbitmq.map(({ keyPath, value }) => ({ keyPath, value: textOf(value), broker: 'rabbitmq' as const })),
+    ],
+    consumerGroups: all
+      .filter(({ keyPath, value }) => endsWith(keyPath, GROUP_KEYS) && isKafka(keyPath) && typeof value === 'string')
+      .map(({ keyPath, value }) => ({ keyPath, value: value as string })),
+    replicationFactors: [
+      ...all.filter(({ keyPath }) => endsWith(keyPath, ['replicationfactor']) && isKafka(keyPath)),
+      ...topicReplicas,
+    ]
+      .map(({ keyPath, value }) => ({ keyPath, value: Number(value) }))
+      // -1 leaves the choice to the broker default
+      .filter(({ value }) => Number.isFinite(value) && value > 0),
+    securityProtocol: all.some(({ keyPath }) => endsWith(keyPath, ['securityprotocol'])),
+  };
+};
+
+type BrokerCode =
+  | 'BROKER_ENVIRONMENT_MISMATCH'
+  | 'BROKER_SECURITY_MISSING'
+  | 'BROKER_CONSUMER_GROUP_NAMING'
+  | 'BROKER_REPLICATION_TOO_LOW';
+
+const brokerFinding = (
+  code: BrokerCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  // A naming convention is a team agreement; the others misroute or expose messages
+  severity: code === 'BROKER_CONSUMER_GROUP_NAMING' ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: BROKER_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks SASL/SSL and replication factors of a production file
+ */
+const productionFindings = (file: ConfigFile, config: BrokerConfig, minimum: number, environment?: string): ValidationError[] => {
+  const bootstrap = config.endpoints.filter(endpoint => endpoint.broker === 'kafka');
+  const plaintextKafka = bootstrap.length > 0 && !config.securityProtocol &&
+    !bootstrap.every(endpoint => endpoint.value.split(',').every(server => SECURE_LISTENER.test(server.trim())));
+
+  return [
+    ...(plaintextKafka ? [brokerFinding(
+      'BROKER_SECURITY_MISSING',
+      `Kafka client at '${bootstrap[0].keyPath}' sets no security.protocol, so it connects in PLAINTEXT in production (${file.path})`,
+      file,
+      bootstrap[0].keyPath,
+      { broker: 'kafka', expected: 'SASL_SSL or SSL' },
+      environment
+    )] : []),
+    ...config.endpoints
+      .filter(endpoint => endpoint.broker === 'rabbitmq' && /^amqp:\/\//i.test(endpoint.value))
+      .map(endpoint => brokerFinding(
+        'BROKER_SECURITY_MISSING',
+        `RabbitMQ URI at '${endpoint.keyPath}' uses amqp:// instead of amqps:// in production (${file.path})`,
+        file,
+        endpoint.keyPath,
+        { broker: 'rabbitmq', expected: 'amqps://' },
+        environment
+      )),
+    ...config.replicationFactors
+      .filter(factor => factor.value < minimum)
+      .map(factor => brokerFinding(
+        'BROKER_REPLICATION_TOO_LOW',
+        `Replication factor ${factor.value} at '${factor.keyPath}' is below ${minimum} in production (${file.path})`,
+        file,
+        factor.keyPath,
+        { replicationFactor: factor.value, minimum },
+        environment
+      )),
+  ];
+};
+
+/**
+ * Checks the message broker settings of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @param settings - Consumer group pattern and replication factor minimum
+ * @returns Findings about brokers of another environment, unsecured production clients,
+ * misnamed consumer groups and low production replication factors
+ */
+export const checkMessageBrokers = (files: ConfigFile[], settings: BrokerSettings = {}): ValidationError[] => {
+  const tokens = leakageTokens();
+  const pattern = settings.consumerGroupPattern ? new RegExp(settings.consumerGroupPattern) : undefined;
+  const minimum = settings.minReplicationFactor ?? DEFAULT_MIN_REPLICATION_FACTOR;
+
+  return files.flatMap(file => {
+    const config = brokerConfig(file.content);
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const own = environment !== undefined ? tokenEnvironment(environment, tokens) : undefined;
+
+    const mismatches = own === undefined ? [] : [...config.endpoints, ...config.consumerGroups].flatMap(setting => {
+      const foreign = foreignEnvironment(setting.value, own, tokens);
+      return foreign ? [brokerFinding(
+        'BROKER_ENVIRONMENT_MISMATCH',
+        `'${setting.keyPath}' points at ${foreign.environment} ('${foreign.token}') from a ${environment} file (${file.path})`,
+        file,
+        setting.keyPath,
+        { value: setting.value, suspectedEnvironment: foreign.environment, token: foreign.token },
+        environment
+      )] : [];
+    });
+
+    const misnamed = pattern === undefined ? [] : config.consumerGroups
+      .filter(group => !pattern.test(group.value))
+      .map(group => brokerFinding(
+        'BROKER_CONSUMER_GROUP_NAMING',
+        `Consumer group '${group.value}' at '${group.keyPath}' does not match ${pattern.source} (${file.path})`,
+        file,
+        group.keyPath,
+        { group: group.value, pattern: pattern.source },
+        environment
+      ));
+
+    return [...mismatches, ...(own === 'prod' ? productionFindings(file, config, minimum, environment) : []), ...misnamed];
+  });
+};
+
+/**
+ * Adds message broker findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @param settings - `brokers:` settings
+ * @returns Result that fails when a broker setting belongs to another environment or production is unsecured
+ */
+export const withMessageBrokerFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings: BrokerSettings = {}
+): ValidationResult => {
+  const findings = checkMessageBrokers(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,55 @@
+/**
+ * @file src/application/validation/MessageTemplates.ts
+ * @description Pure functions to render finding messages from user templates
+ */
+
+import { ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { renderTemplate } from '../../shared/utils/Template';
+import { EscalationOptions, findingEnvironment } from './SeverityEscalation';
+
+export { renderTemplate };
+
+/**
+ * Builds the variables a template can use for a finding
+ * @param finding - Finding to describe
+ * @param options - Environment attribution, as for severity escalation
+ * @returns Template variables: code, severity, message, key, file, environment, every context field and every extra
+ */
+export const templateVariables = (finding: ValidationError, options: EscalationOptions = {}): Record<string, any> => ({
+  ...(finding.context?.extras ?? {}),
+  ...(finding.context ?? {}),
+  code: finding.code,
+  severity: finding.severity,
+  message: finding.message,
+  key: finding.path ?? finding.context?.keyPath ?? '',
+  file: finding.context?.file ?? '',
+  // Findings that inferred the environment of their file keep it when none is attributed
+  environment: findingEnvironment(finding, options) ?? finding.context?.environment ?? '',
+  context: finding.context ?? {}
+});
+
+/**
+ * Replaces the message of every finding whose code has a template
+ * @param result - Validation result
+ * @param templates - Finding code -> message template
+ * @param options - Environment attribution
+ * @returns Result with rendered messages
+ */
+export const applyMessageTemplates = (
+  result: ValidationResult,
+  templates: Record<string, string>,
+  options: EscalationOptions = {}
+): ValidationResult => {
+  // Guard clause: no templates
+  if (!templates || Object.keys(templates).length === 0) {
+    return result;
+  }
+
+  return withFindings(result, collectFindings(result).map(finding => {
+    const template = templates[finding.code];
+    return typeof template === 'string'
+      ? { ...finding, message: renderTemplate(template, templateVariables(finding, options)) }
+      : finding;
+  }));
+};
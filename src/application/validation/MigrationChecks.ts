@@ -0,0 +1,283 @@
+/**
+ * @file src/application/validation/MigrationChecks.ts
+ * @description Pure functions checking database migration settings (Flyway, Liquibase, golang-migrate)
+ * for drift between environments and for production safety flags
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant MIGRATION_RULE_ID
+ * @description Rule id of the migration checks, usable in `scopes:`
+ */
+export const MIGRATION_RULE_ID = 'migrations';
+
+export type MigrationTool = 'flyway' | 'liquibase' | 'golang-migrate';
+
+/**
+ * @constant MIGRATION_SETTINGS
+ * @description Settings read for each tool: normalized key (lower case, no separators) -> setting name
+ */
+export const MIGRATION_SETTINGS: Record<MigrationTool, Record<string, string>> = {
+  flyway: {
+    schemas: 'schemas',
+    defaultschema: 'defaultSchema',
+    locations: 'locations',
+    baselineversion: 'baselineVersion',
+    baselineonmigrate: 'baselineOnMigrate',
+    table: 'table',
+    cleandisabled: 'cleanDisabled',
+    cleanonvalidationerror: 'cleanOnValidationError',
+  },
+  liquibase: {
+    changelogfile: 'changeLog',
+    changelog: 'changeLog',
+    defaultschemaname: 'defaultSchema',
+    defaultschema: 'defaultSchema',
+    liquibaseschemaname: 'liquibaseSchema',
+    liquibaseschema: 'liquibaseSchema',
+    databasechangelogtablename: 'table',
+    databasechangelogtable: 'table',
+    dropfirst: 'dropFirst',
+  },
+  'golang-migrate': {
+    source: 'source',
+    path: 'source',
+    dir: 'source',
+    table: 'table',
+    migrationstable: 'table',
+    xmigrationstable: 'table',
+  },
+};
+
+// Section names and environment variable prefixes that introduce a tool's settings
+const TOOL_MARKERS: Record<string, MigrationTool> = { flyway: 'flyway', liquibase: 'liquibase', migrate: 'golang-migrate' };
+
+// Flags production must set to true, and options production must not turn on
+const REQUIRED_FLAGS: Partial<Record<MigrationTool, string[]>> = { flyway: ['cleanDisabled'] };
+const DESTRUCTIVE_OPTIONS: Partial<Record<MigrationTool, string[]>> = {
+  flyway: ['cleanOnValidationError'],
+  liquibase: ['dropFirst'],
+};
+
+const SAFETY_SETTINGS = ['cleanDisabled', 'cleanOnValidationError', 'dropFirst'];
+
+/**
+ * @interface MigrationSetting
+ * @description A migration setting found in a file
+ */
+export interface MigrationSetting {
+  tool: MigrationTool;
+  setting: string;
+  keyPath: string;
+  value: unknown;
+  /** Where an unset setting of the same tool would go (`spring.flyway`, `FLYWAY_`) */
+  section: string;
+}
+
+const normalize = (name: string): string => name.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+/**
+ * Reads one key path as a migration setting: `flyway.locations`, `spring.flyway.clean-disabled`,
+ * `FLYWAY_BASELINE_VERSION`, or any key of a liquibase.properties file
+ */
+const toSetting = (keyPath: string, value: unknown, fileTool?: MigrationTool): MigrationSetting | undefined => {
+  const segments = keyPath.split('.');
+  const markerIndex = segments.findIndex(segment => TOOL_MARKERS[segment.toLowerCase()] !== undefined);
+
+  if (markerIndex >= 0 && markerIndex < segments.length - 1) {
+    const tool = TOOL_MARKERS[segments[markerIndex].toLowerCase()];
+    const setting = MIGRATION_SETTINGS[tool][normalize(segments.slice(markerIndex + 1).join(''))];
+    return setting ? { tool, setting, keyPath, value, section: segments.slice(0, markerIndex + 1).join('.') } : undefined;
+  }
+
+  // Environment variables: FLYWAY_CLEAN_DISABLED
+  const variable = /^([A-Za-z]+)_(.+)$/.exec(segments[segments.length - 1]);
+  const variableTool = variable ? TOOL_MARKERS[variable[1].toLowerCase()] : undefined;
+  if (variable && variableTool) {
+    const setting = MIGRATION_SETTINGS[variableTool][normalize(variable[2])];
+    return setting
+      ? { tool: variableTool, setting, keyPath, value, section: joinKeyPath(segments.slice(0, -1).join('.'), `${variable[1]}_`) }
+      : undefined;
+  }
+
+  const setting = fileTool ? MIGRATION_SETTINGS[fileTool][normalize(segments[segments.length - 1])] : undefined;
+  return fileTool && setting ? { tool: fileTool, setting, keyPath, value, section: segments.slice(0, -1).join('.') } : undefined;
+};
+
+/**
+ * Lists the migration settings of a file
+ * @param file - Parsed file; liquibase.properties and flyway.conf/toml files need no prefix
+ * @returns Settings of every tool the file configures
+ */
+export const migrationSettings = (file: ConfigFile): MigrationSetting[] => {
+  const fileName = file.path.split(/[\\/]/).pop() ?? file.path;
+  const fileTool = /liquibase/i.test(fileName) ? 'liquibase' : /flyway/i.test(fileName) ? 'flyway' : undefined;
+  return leaves(file.content).flatMap(({ keyPath, value }) => {
+    const setting = toSetting(keyPath, value, fileTool);
+    return setting ? [setting] : [];
+  });
+};
+
+/**
+ * Normalizes a value for comparison: lists and comma-separated strings become lists
+ */
+const comparable = (value: unknown): string => {
+  const items = Array.isArray(value) ? value : typeof value === 'string' && value.includes(',') ? value.split(',') : [value];
+  return items.map(item => String(item).trim()).join(',');
+};
+
+const isTrue = (value: unknown): boolean => String(value).trim().toLowerCase() === 'true';
+
+const TOOL_NAMES: Record<MigrationTool, string> = { flyway: 'Flyway', liquibase: 'Liquibase', 'golang-migrate': 'golang-migrate' };
+
+type MigrationCode = 'MIGRATION_SETTING_DRIFT' | 'MIGRATION_CLEAN_NOT_DISABLED' | 'MIGRATION_DESTRUCTIVE_OPTION';
+
+const migrationFinding = (
+  code: MigrationCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: MIGRATION_RULE_ID },
+    extras,
+  },
+});
+
+interface ToolConfig {
+  file: ConfigFile;
+  environment?: string;
+  settings: MigrationSetting[];
+}
+
+const labelOf = (config: ToolConfig): string => config.environment ?? config.file.path;
+
+/**
+ * Key path an unset setting would have in the section of a file (`FLYWAY_` sections take variable names)
+ */
+const settingPath = (section: string, setting: string): string =>
+  section.endsWith('_') ? `${section}${setting.replace(/([a-z])([A-Z])/g, '$1_$2').toUpperCase()}` : joinKeyPath(section, setting);
+
+/**
+ * Finds the settings of one tool that differ between files; the files holding the most
+ * common value (the first one on a tie) are the baseline, the others are reported
+ */
+const driftFindings = (tool: MigrationTool, configs: ToolConfig[]): ValidationError[] => {
+  const settings = [...new Set(configs.flatMap(config => config.settings.map(setting => setting.setting)))]
+    .filter(setting => !SAFETY_SETTINGS.includes(setting));
+
+  return settings.flatMap(setting => {
+    const values = configs.map(config => {
+      const found = config.settings.find(entry => entry.setting === setting);
+      return { config, found, value: found ? comparable(found.value) : '(unset)' };
+    });
+    const groups = [...new Set(values.map(entry => entry.value))]
+      .map(value => values.filter(entry => entry.value === value));
+    const baseline = groups.reduce((largest, group) => (group.length > largest.length ? group : largest));
+
+    return groups.length < 2 ? [] : values
+      .filter(entry => !baseline.includes(entry))
+      .map(({ config, found, value }) => {
+        const expected = baseline[0].value;
+        const where = baseline.map(entry => labelOf(entry.config)).join(', ');
+        const keyPath = found?.keyPath ?? settingPath(config.settings[0].section, setting);
+        return migrationFinding(
+          'MIGRATION_SETTING_DRIFT',
+          `${TOOL_NAMES[tool]} ${setting} is ${value} in ${labelOf(config)} but ${expected} in ${where} (${config.file.path})`,
+          config.file,
+          keyPath,
+          { tool: TOOL_NAMES[tool], setting, value, expected, expectedIn: where },
+          config.environment
+        );
+      });
+  });
+};
+
+/**
+ * Checks the safety flags of one tool in a production file
+ */
+const safetyFindings = (tool: MigrationTool, config: ToolConfig): ValidationError[] => {
+  const valueOf = (setting: string): MigrationSetting | undefined => config.settings.find(entry => entry.setting === setting);
+  const { file, environment } = config;
+
+  return [
+    ...(REQUIRED_FLAGS[tool] ?? [])
+      .filter(flag => !isTrue(valueOf(flag)?.value))
+      .map(flag => migrationFinding(
+        'MIGRATION_CLEAN_NOT_DISABLED',
+        `${TOOL_NAMES[tool]} ${flag} is not set to true in production; one clean command drops every object of the schema (${file.path})`,
+        file,
+        valueOf(flag)?.keyPath ?? settingPath(config.settings[0].section, flag),
+        { tool: TOOL_NAMES[tool], setting: flag },
+        environment
+      )),
+    ...(DESTRUCTIVE_OPTIONS[tool] ?? [])
+      .map(valueOf)
+      .filter((setting): setting is MigrationSetting => setting !== undefined && isTrue(setting.value))
+      .map(setting => migrationFinding(
+        'MIGRATION_DESTRUCTIVE_OPTION',
+        `${TOOL_NAMES[tool]} ${setting.setting} is enabled in production and can drop the schema (${file.path})`,
+        file,
+        setting.keyPath,
+        { tool: TOOL_NAMES[tool], setting: setting.setting },
+        environment
+      )),
+  ];
+};
+
+/**
+ * Checks the migration settings of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about settings that differ between environments and unsafe production settings
+ */
+export const checkMigrations = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+  const isProduction = (environment?: string): boolean =>
+    environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+  const configured = files.map(file => ({
+    file,
+    environment: file.environment ?? inferEnvironment(file.path, tokens),
+    settings: migrationSettings(file),
+  }));
+
+  return (Object.keys(MIGRATION_SETTINGS) as MigrationTool[]).flatMap(tool => {
+    const configs = configured
+      .map(config => ({ ...config, settings: config.settings.filter(setting => setting.tool === tool) }))
+      .filter(config => config.settings.length > 0);
+
+    return [
+      // Drift needs at least two configurations to compare
+      ...(configs.length < 2 ? [] : driftFindings(tool, configs)),
+      ...configs.filter(config => isProduction(config.environment)).flatMap(config => safetyFindings(tool, config)),
+    ];
+  });
+};
+
+/**
+ * Adds migration findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the migration checks run on
+ * @returns Result that fails when migration settings drift or production can drop its schema
+ */
+export const withMigrationFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkMigrations(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
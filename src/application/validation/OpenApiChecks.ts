@@ -0,0 +1,257 @@
+/**
+ * @file src/application/validation/OpenApiChecks.ts
+ * @description Pure functions comparing OpenAPI/Swagger specs and gateway exports of production with the other environments
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant OPENAPI_RULE_ID
+ * @description Rule id of the OpenAPI checks, usable in `scopes:`
+ */
+export const OPENAPI_RULE_ID = 'openapi';
+
+const HTTP_METHODS = ['get', 'put', 'post', 'delete', 'options', 'head', 'patch', 'trace'];
+// API Gateway exports route every method of a path through one operation
+const ANY_METHOD = 'x-amazon-apigateway-any-method';
+
+/**
+ * @constant AUTH_LEVELS
+ * @description How strongly an endpoint is protected, weakest first
+ */
+export const AUTH_LEVELS = ['none', 'apiKey/basic', 'token'] as const;
+
+export type AuthLevel = typeof AUTH_LEVELS[number];
+
+const AUTH_DESCRIPTIONS: Record<AuthLevel, string> = {
+  none: 'no auth',
+  'apiKey/basic': 'an API key or basic auth',
+  token: 'token auth (bearer, OAuth2, OpenID Connect or mutual TLS)',
+};
+
+/**
+ * @interface OpenApiEndpoint
+ * @description One operation of a spec and the protection it requires
+ */
+export interface OpenApiEndpoint {
+  /** Upper-case method; `ANY` for an API Gateway any-method operation */
+  verb: string;
+  /** Path with its parameters written as `{}`, so `{id}` and `{userId}` match */
+  route: string;
+  /** `METHOD /path` as written in the spec */
+  label: string;
+  keyPath: string;
+  auth: AuthLevel;
+}
+
+/**
+ * Checks whether parsed content is an OpenAPI 3 or Swagger 2 document
+ * @param content - Parsed file
+ * @returns true when the content has an `openapi` or `swagger` version and `paths`
+ */
+export const isOpenApiSpec = (content: unknown): content is Record<string, any> =>
+  isPlainObject(content) && (content.openapi !== undefined || content.swagger !== undefined) && isPlainObject(content.paths);
+
+/**
+ * Rates a security scheme definition
+ */
+const schemeLevel = (scheme: unknown): AuthLevel => {
+  // Guard clause: a requirement naming a scheme the spec does not define still asks for something
+  if (!isPlainObject(scheme)) {
+    return 'apiKey/basic';
+  }
+
+  const type = String(scheme.type);
+  return type === 'apiKey' || type === 'basic' || (type === 'http' && String(scheme.scheme).toLowerCase() === 'basic')
+    ? 'apiKey/basic'
+    : 'token';
+};
+
+/**
+ * Rates a security requirement: alternatives are ORed (the weakest wins), the schemes of one
+ * alternative are ANDed (the strongest counts), and an empty alternative makes auth optional
+ * @param requirement - `security:` list of an operation or of the spec
+ * @param schemes - Scheme definitions by name
+ * @returns Protection the requirement guarantees
+ */
+export const requirementLevel = (requirement: unknown, schemes: Record<string, unknown>): AuthLevel => {
+  // Guard clause: no requirement at all
+  if (!Array.isArray(requirement) || requirement.length === 0) {
+    return 'none';
+  }
+
+  const levels = requirement.map(alternative => {
+    const names = isPlainObject(alternative) ? Object.keys(alternative) : [];
+    return names.length === 0
+      ? 0
+      : Math.max(...names.map(name => AUTH_LEVELS.indexOf(schemeLevel(schemes[name]))));
+  });
+
+  return AUTH_LEVELS[Math.min(...levels)];
+};
+
+/**
+ * Lists the endpoints of a spec with the protection each requires
+ * @param spec - Parsed OpenAPI 3 or Swagger 2 document
+ * @returns One entry per operation
+ */
+export const openApiEndpoints = (spec: Record<string, any>): OpenApiEndpoint[] => {
+  const schemes = { ...(spec.securityDefinitions ?? {}), ...(spec.components?.securitySchemes ?? {}) };
+
+  return Object.entries(spec.paths)
+    .filter((entry): entry is [string, Record<string, any>] => isPlainObject(entry[1]))
+    .flatMap(([route, item]) => [...HTTP_METHODS, ANY_METHOD]
+      .filter(method => isPlainObject(item[method]))
+      .map(method => {
+        const verb = method === ANY_METHOD ? 'ANY' : method.toUpperCase();
+        return {
+          verb,
+          route: route.replace(/\{[^}]*\}/g, '{}'),
+          label: `${verb} ${route}`,
+          keyPath: joinKeyPath(joinKeyPath('paths', route), method),
+          // An operation-level `security` replaces the global one, even when empty
+          auth: requirementLevel(item[method].security ?? spec.security, schemes),
+        };
+      }));
+};
+
+/**
+ * Checks whether two endpoints are the same operation; `ANY` stands for every method
+ */
+const sameEndpoint = (a: OpenApiEndpoint, b: OpenApiEndpoint): boolean =>
+  a.route === b.route && (a.verb === b.verb || a.verb === 'ANY' || b.verb === 'ANY');
+
+/**
+ * Lists the server URLs of a spec: `servers[].url`, or `host` + `basePath` for Swagger 2
+ */
+export const openApiServers = (spec: Record<string, any>): string[] => {
+  if (Array.isArray(spec.servers)) {
+    return spec.servers.filter(isPlainObject).map(server => String(server.url)).filter(url => url !== 'undefined');
+  }
+
+  const schemes: string[] = Array.isArray(spec.schemes) && spec.schemes.length > 0 ? spec.schemes : ['https'];
+  return typeof spec.host === 'string' ? schemes.map(scheme => `${scheme}://${spec.host}${spec.basePath ?? ''}`) : [];
+};
+
+type OpenApiCode = 'OPENAPI_ENDPOINT_UNDOCUMENTED' | 'OPENAPI_AUTH_WEAKENED' | 'OPENAPI_SERVER_SHARED';
+
+const openApiFinding = (
+  code: OpenApiCode,
+  message: string,
+  file: ConfigFile,
+  environment: string,
+  keyPath: string,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: code === 'OPENAPI_SERVER_SHARED' ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    environment,
+    keyPath,
+    rule: { id: OPENAPI_RULE_ID },
+    extras,
+  },
+});
+
+interface ProductionSpec {
+  file: ConfigFile;
+  environment: string;
+}
+
+/**
+ * Compares one production spec with the specs of the other environments
+ */
+const productionFindings = (production: ProductionSpec, others: ConfigFile[]): ValidationError[] => {
+  const { file, environment } = production;
+  const otherEndpoints = others.map(other => ({ other, endpoints: openApiEndpoints(other.content) }));
+  const otherNames = others.map(other => other.path).join(', ');
+
+  const endpointFindings = openApiEndpoints(file.content).flatMap(endpoint => {
+    const matches = otherEndpoints.flatMap(({ other, endpoints }) =>
+      endpoints.filter(candidate => sameEndpoint(candidate, endpoint)).map(candidate => ({ other, candidate }))
+    );
+
+    if (matches.length === 0) {
+      return [openApiFinding(
+        'OPENAPI_ENDPOINT_UNDOCUMENTED',
+        `'${endpoint.label}' is exposed in ${environment} but absent from ${otherNames} (${file.path})`,
+        file,
+        environment,
+        endpoint.keyPath,
+        { endpoint: endpoint.label, specs: otherNames }
+      )];
+    }
+
+    const strongest = matches.reduce((best, match) =>
+      AUTH_LEVELS.indexOf(match.candidate.auth) > AUTH_LEVELS.indexOf(best.candidate.auth) ? match : best
+    );
+
+    return AUTH_LEVELS.indexOf(endpoint.auth) < AUTH_LEVELS.indexOf(strongest.candidate.auth)
+      ? [openApiFinding(
+        'OPENAPI_AUTH_WEAKENED',
+        `'${endpoint.label}' requires ${AUTH_DESCRIPTIONS[endpoint.auth]} in ${environment} but ${AUTH_DESCRIPTIONS[strongest.candidate.auth]} in ${strongest.other.path} (${file.path})`,
+        file,
+        environment,
+        endpoint.keyPath,
+        { endpoint: endpoint.label, auth: endpoint.auth, expectedAuth: strongest.candidate.auth, spec: strongest.other.path }
+      )]
+      : [];
+  });
+
+  const otherServers = new Map(others.flatMap(other => openApiServers(other.content).map(url => [url, other.path] as const)));
+  const serverFindings = openApiServers(file.content)
+    .map((url, index) => ({ url, index }))
+    .filter(({ url }) => otherServers.has(url))
+    .map(({ url, index }) => openApiFinding(
+      'OPENAPI_SERVER_SHARED',
+      `Server ${url} of ${environment} is also a server of ${otherServers.get(url)} (${file.path})`,
+      file,
+      environment,
+      Array.isArray(file.content.servers) ? `servers.${index}.url` : 'host',
+      { url, spec: otherServers.get(url) }
+    ));
+
+  return [...endpointFindings, ...serverFindings];
+};
+
+/**
+ * Compares the production OpenAPI specs among the given files with the specs of the other environments
+ * @param files - Parsed files with their environment when known; files that are not specs are skipped
+ * @returns Findings about endpoints only production exposes, weaker production auth and shared servers
+ */
+export const checkOpenApi = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+  const specs = files
+    .filter(file => isOpenApiSpec(file.content))
+    .map(file => ({ file, environment: file.environment ?? inferEnvironment(file.path, tokens) }));
+  const isProduction = (environment?: string): boolean =>
+    environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+  // Specs without an environment (the canonical `openapi.yaml`) are references too
+  const others = specs.filter(spec => !isProduction(spec.environment)).map(spec => spec.file);
+
+  // Guard clause: nothing to compare production with
+  if (others.length === 0) {
+    return [];
+  }
+
+  return specs
+    .filter((spec): spec is ProductionSpec => isProduction(spec.environment))
+    .flatMap(spec => productionFindings(spec, others));
+};
+
+/**
+ * Adds OpenAPI findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on, with their environment when known
+ * @returns Result that fails when production exposes or weakens an endpoint
+ */
+export const withOpenApiFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkOpenApi(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
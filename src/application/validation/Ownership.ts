@@ -0,0 +1,81 @@
+/**
+ * @file src/application/validation/Ownership.ts
+ * @description Pure functions routing findings to the teams of `owners:` by key pattern or file glob,
+ * and summarizing the findings of each team so reports can be grouped and notifications routed
+ */
+
+import { OwnerSettings, OwnerSummary, ValidationError, ValidationResult } from '../../shared/types';
+import { matchesGlob } from '../../shared/utils/Glob';
+import { matchesKeyPattern } from '../../shared/utils/KeyPaths';
+
+/**
+ * @constant UNOWNED
+ * @description Team of the findings no `owners:` entry matches
+ */
+export const UNOWNED = 'unowned';
+
+/**
+ * Tells whether a key pattern owns a key: the key itself or one of the keys above it matches
+ * (`payments` owns `payments.stripe.key`; `services.*` owns `services.api.port`)
+ */
+export const ownsKey = (keyPath: string, pattern: string): boolean => {
+  const segments = keyPath.split('.');
+  const depth = pattern.split('.').length;
+  return segments.length >= depth && matchesKeyPattern(segments.slice(0, depth).join('.'), pattern);
+};
+
+/**
+ * Finds the team a finding belongs to
+ * @param finding - Finding with its key path and file(s)
+ * @param owners - `owners:` entries, in declaration order
+ * @returns The first entry owning the key of the finding, else the first owning one of its files
+ */
+export const ownerOf = (finding: ValidationError, owners: OwnerSettings[]): OwnerSettings | undefined => {
+  const keyPath = finding.context?.keyPath ?? finding.path;
+  const files = [finding.context?.file, ...(finding.context?.files ?? [])].filter((file): file is string => typeof file === 'string');
+
+  return owners.find(owner => keyPath !== undefined && owner.keys.some(pattern => ownsKey(keyPath, pattern)))
+    ?? owners.find(owner => files.some(file => owner.files.some(pattern => matchesGlob(file, pattern))));
+};
+
+/**
+ * Adds the owner of each finding and the findings of each team to a result
+ * @param result - Result to route
+ * @param owners - `owners:` entries; the result is left untouched when there are none
+ * @returns Result whose findings carry `context.owner`, with `owners` listing the teams that have findings
+ * and `unowned` last
+ */
+export const withOwners = <T extends ValidationResult>(result: T, owners: OwnerSettings[]): T => {
+  // Guard clause: no owners configured
+  if (owners.length === 0) {
+    return result;
+  }
+
+  const route = <F extends ValidationError>(finding: F): F => {
+    const owner = ownerOf(finding, owners);
+    return { ...finding, context: { ...finding.context, owner: owner?.team ?? UNOWNED } };
+  };
+  const routed = {
+    ...result,
+    errors: result.errors.map(route),
+    warnings: result.warnings.map(route),
+    ...(result.info ? { info: result.info.map(route) } : {}),
+  };
+  const count = (team: string, findings: ValidationError[] = []): number =>
+    findings.filter(finding => finding.context?.owner === team).length;
+  const teams = [...new Set(owners.map(owner => owner.team)), UNOWNED];
+  const summaries: OwnerSummary[] = teams
+    .map(team => {
+      const channel = owners.find(owner => owner.team === team && owner.channel !== undefined)?.channel;
+      return {
+        team,
+        ...(channel ? { channel } : {}),
+        errors: count(team, routed.errors),
+        warnings: count(team, routed.warnings),
+        info: count(team, routed.info),
+      };
+    })
+    .filter(summary => summary.errors + summary.warnings + summary.info > 0);
+
+  return { ...routed, owners: summaries };
+};
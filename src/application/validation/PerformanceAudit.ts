@@ -0,0 +1,279 @@
+/**
+ * @file src/application/validation/PerformanceAudit.ts
+ * @description Pure functions finding performance foot-guns: clients without timeouts, timeouts of 0,
+ * empty or unbounded connection pools, endless retries and unbounded caches
+ */
+
+import { ConfigFile, PerformanceBounds, PerformanceSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath, matchesKeyPattern } from '../../shared/utils/KeyPaths';
+import { normalizeQuantity } from '../../shared/utils/Quantities';
+
+/**
+ * @constant PERFORMANCE_RULE_ID
+ * @description Rule id of the performance checks, the name of the `performance` audit type usable in `scopes:`
+ */
+export const PERFORMANCE_RULE_ID = 'performance';
+
+/**
+ * @constant PERFORMANCE_CATEGORIES
+ * @description Kinds of values checked, the sections of `performance:`
+ */
+export const PERFORMANCE_CATEGORIES = ['timeouts', 'pools', 'retries', 'caches'] as const;
+
+export type PerformanceCategory = typeof PERFORMANCE_CATEGORIES[number];
+
+/**
+ * @constant DEFAULT_PERFORMANCE_BOUNDS
+ * @description Bounds used when `performance:` leaves them out; more than 10 retries multiplies an outage
+ */
+export const DEFAULT_PERFORMANCE_BOUNDS: Record<PerformanceCategory, { min?: number; max?: number }> = {
+  timeouts: {},
+  pools: {},
+  retries: { max: 10 },
+  caches: {},
+};
+
+// Names are compared lowercased, without `_` and `-`: `max_pool_size`, `maxPoolSize` and `max-pool-size` alike
+const normalizeName = (name: string): string => name.toLowerCase().replace(/[_-]/g, '');
+
+/**
+ * @constant CATEGORY_KEYS
+ * @description Built-in recognition of each category from the name of a key and of its parent
+ */
+const CATEGORY_KEYS: Record<PerformanceCategory, (name: string, parent: string) => boolean> = {
+  timeouts: name => name.includes('timeout'),
+  pools: (name, parent) =>
+    /^(max(imum)?)?(db|connection)?pool(size|max|maxsize)?$/.test(name) ||
+    /^max(open)?conn(ection)?s$|^connectionlimit$/.test(name) ||
+    (/pool$/.test(parent) && /^(max|size|maxsize)$/.test(name)),
+  retries: name => /^(max)?retr(y|ies)(count|attempts|limit|max)?$|^max(retry)?attempts$|^retryattempts$/.test(name),
+  caches: (name, parent) =>
+    (name.includes('cache') && /(size|entries|capacity|items|limit|max)$/.test(name)) ||
+    (parent.includes('cache') && /^(max)?(size|entries|capacity|items|memory)$|^max$/.test(name)),
+};
+
+// Keys naming where a client connects; their section is a client that should set a timeout
+const CLIENT_KEYS = new Set(['url', 'uri', 'baseurl', 'endpoint', 'dsn', 'connectionstring']);
+
+// Values meaning "no limit" whatever the category
+const UNBOUNDED_WORDS = new Set(['unlimited', 'infinite', 'infinity', 'unbounded', 'none', 'never']);
+
+interface KeyValue {
+  path: string;
+  name: string;
+  parent: string;
+  value: unknown;
+}
+
+/**
+ * Lists every key of a parsed file with its value, list items included (`hosts.0`)
+ */
+const keyValues = (value: unknown, prefix = '', parent = ''): KeyValue[] => {
+  const children: Array<[string, unknown]> = Array.isArray(value)
+    ? value.map((child, index) => [String(index), child] as [string, unknown])
+    : isPlainObject(value) ? Object.entries(value) : [];
+
+  return children.flatMap(([key, child]) => {
+    const path = joinKeyPath(prefix, key);
+    return [{ path, name: normalizeName(key), parent, value: child }, ...keyValues(child, path, normalizeName(key))];
+  });
+};
+
+/**
+ * Reads a value of a category
+ * @returns 'unbounded' for words such as `unlimited`, the amount for numbers (durations in
+ * milliseconds for timeouts), undefined for anything else
+ */
+const amountOf = (category: PerformanceCategory, value: unknown): number | 'unbounded' | undefined => {
+  if (typeof value === 'string' && UNBOUNDED_WORDS.has(value.trim().toLowerCase())) {
+    return 'unbounded';
+  }
+  return normalizeQuantity(value, category === 'timeouts' ? 'duration' : category === 'caches' ? 'size' : undefined);
+};
+
+const performanceFinding = (
+  code: string,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: 'warning',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(file.environment !== undefined ? { environment: file.environment } : {}),
+    keyPath,
+    rule: { id: PERFORMANCE_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks one value against the limits its category can never accept
+ * @returns The finding about a timeout, pool, retry count or cache without limit, if any
+ */
+const unboundedFinding = (
+  category: PerformanceCategory,
+  file: ConfigFile,
+  entry: KeyValue,
+  amount: number | 'unbounded'
+): ValidationError | undefined => {
+  const shown = amount === 'unbounded' ? String(entry.value) : amount;
+  const extras = { category, value: shown };
+
+  // Guard clause: a positive number is bounded
+  if (amount !== 'unbounded' && amount > 0) {
+    return undefined;
+  }
+
+  switch (category) {
+    case 'timeouts':
+      return performanceFinding(
+        'PERF_TIMEOUT_UNBOUNDED',
+        `'${entry.path}' is ${shown}: calls wait forever on a peer that hangs (${file.path})`,
+        file, entry.path, extras
+      );
+    case 'pools':
+      return amount === 0
+        ? performanceFinding(
+          'PERF_POOL_EMPTY',
+          `'${entry.path}' is 0: the pool either holds no connection or, for some drivers, has no limit (${file.path})`,
+          file, entry.path, extras
+        )
+        : performanceFinding(
+          'PERF_POOL_UNBOUNDED',
+          `'${entry.path}' is ${shown}: the pool can open connections until the server refuses them (${file.path})`,
+          file, entry.path, extras
+        );
+    case 'retries':
+      // No retry at all is a choice, not a foot-gun
+      return amount === 0 ? undefined : performanceFinding(
+        'PERF_RETRIES_UNBOUNDED',
+        `'${entry.path}' is ${shown}: failed calls are retried forever (${file.path})`,
+        file, entry.path, extras
+      );
+    case 'caches':
+      return performanceFinding(
+        'PERF_CACHE_UNBOUNDED',
+        `'${entry.path}' is ${shown}: the cache grows until memory runs out (${file.path})`,
+        file, entry.path, extras
+      );
+  }
+};
+
+/**
+ * Checks one file for the foot-guns of one category
+ * @param file - Parsed file
+ * @param category - Kind of values checked
+ * @param settings - `performance:` settings; key patterns replace the built-in key names of their category
+ * @returns Warnings about unbounded values and values out of the configured bounds; for timeouts,
+ * also about client sections that set none
+ */
+export const checkPerformanceCategory = (
+  file: ConfigFile,
+  category: PerformanceCategory,
+  settings: PerformanceSettings = {}
+): ValidationError[] => {
+  const bounds: Partial<PerformanceBounds> = settings[category] ?? {};
+  const patterns = bounds.keys ?? [];
+  const min = normalizeQuantity(bounds.min ?? DEFAULT_PERFORMANCE_BOUNDS[category].min, category === 'timeouts' ? 'duration' : undefined);
+  const max = normalizeQuantity(bounds.max ?? DEFAULT_PERFORMANCE_BOUNDS[category].max, category === 'timeouts' ? 'duration' : category === 'caches' ? 'size' : undefined);
+  const entries = keyValues(file.content);
+  const inCategory = (entry: KeyValue): boolean => patterns.length > 0
+    ? patterns.some(pattern => matchesKeyPattern(entry.path, pattern))
+    : CATEGORY_KEYS[category](entry.name, entry.parent);
+
+  const valueFindings = entries.filter(inCategory).flatMap((entry): ValidationError[] => {
+    const amount = amountOf(category, entry.value);
+
+    // Guard clause: sections, flags and placeholders (`${POOL_SIZE}`) are not amounts
+    if (amount === undefined) {
+      return [];
+    }
+
+    const unbounded = unboundedFinding(category, file, entry, amount);
+    if (unbounded) {
+      return [unbounded];
+    }
+
+    // Guard clause: every category reports its words without limit above
+    if (amount === 'unbounded') {
+      return [];
+    }
+
+    if (min !== undefined && amount < min) {
+      const bound = bounds.min ?? min;
+      return [performanceFinding(
+        'PERF_VALUE_TOO_LOW',
+        `'${entry.path}' is ${String(entry.value)}, below the ${bound} required for ${category} (${file.path})`,
+        file, entry.path, { category, value: entry.value, bound }
+      )];
+    }
+
+    const bound = bounds.max ?? max;
+    return max !== undefined && amount > max ? [performanceFinding(
+      'PERF_VALUE_TOO_HIGH',
+      `'${entry.path}' is ${String(entry.value)}, above the ${bound} allowed for ${category} (${file.path})`,
+      file, entry.path, { category, value: entry.value, bound }
+    )] : [];
+  });
+
+  // Guard clause: only timeouts can be missing
+  if (category !== 'timeouts' || settings.timeouts?.required === false) {
+    return valueFindings;
+  }
+
+  // A timeout applies to its own section and to the sections nested in it
+  const timeoutSections = entries.filter(inCategory).map(entry => entry.path.slice(0, Math.max(0, entry.path.lastIndexOf('.'))));
+  const covered = (section: string): boolean =>
+    timeoutSections.some(owner => owner === '' || section === owner || section.startsWith(`${owner}.`));
+  const clients = entries
+    .filter(entry => CLIENT_KEYS.has(entry.name) && typeof entry.value === 'string' && entry.path.includes('.'))
+    .map(entry => entry.path.slice(0, entry.path.lastIndexOf('.')))
+    .filter((section, index, all) => all.indexOf(section) === index && !covered(section));
+
+  return [
+    ...valueFindings,
+    ...clients.map(section => performanceFinding(
+      'PERF_TIMEOUT_MISSING',
+      `'${section}' connects to a remote service without a timeout; calls wait on it as long as the OS lets them (${file.path})`,
+      file,
+      section,
+      { category: 'timeouts' }
+    )),
+  ];
+};
+
+/**
+ * Runs every performance check on the given files
+ * @param files - Parsed files
+ * @param settings - `performance:` settings; built-in key names and bounds when empty
+ * @returns One warning per foot-gun
+ */
+export const runPerformanceAudit = (files: ConfigFile[], settings: PerformanceSettings = {}): ValidationError[] =>
+  files.flatMap(file => PERFORMANCE_CATEGORIES.flatMap(category => checkPerformanceCategory(file, category, settings)));
+
+/**
+ * Adds performance findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks apply to
+ * @param settings - `performance:` settings; undefined when the checks are off
+ * @returns Result with the warnings added
+ */
+export const withPerformanceAudit = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings?: PerformanceSettings
+): ValidationResult => {
+  // Guard clause: checks not configured
+  if (settings === undefined) {
+    return result;
+  }
+
+  const findings = runPerformanceAudit(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,167 @@
+/**
+ * @file src/application/validation/RateLimitRules.ts
+ * @description Pure functions checking rate limits and quotas found at the key paths of `rate_limits:`:
+ * public endpoints must be limited, bursts must stay near the limit, production quotas must be finite
+ */
+
+import { ConfigFile, RateLimitSettings, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { joinKeyPath, resolveKeyPattern } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant RATE_LIMIT_RULE_ID
+ * @description Rule id of the rate limit checks, usable in `scopes:`
+ */
+export const RATE_LIMIT_RULE_ID = 'rate-limits';
+
+/**
+ * @constant DEFAULT_RATE_LIMIT_KEYS
+ * @description Key paths inside an endpoint block used when `rate_limits:` does not name them
+ */
+export const DEFAULT_RATE_LIMIT_KEYS = {
+  public: 'public',
+  limit: 'rate_limit.limit',
+  burst: 'rate_limit.burst',
+  burstFactor: 2,
+};
+
+// Values gateways and quota systems use for "no limit"
+const UNLIMITED_WORDS = ['unlimited', 'infinite', 'infinity', 'inf', 'none'];
+
+/**
+ * Whether a limit or quota value means "no limit": a negative number or a word such as `unlimited`
+ * @param value - Limit or quota value
+ * @returns True when the value sets no limit
+ */
+export const isUnlimited = (value: unknown): boolean =>
+  (typeof value === 'number' && value < 0) ||
+  (typeof value === 'string' && (UNLIMITED_WORDS.includes(value.trim().toLowerCase()) || Number(value) < 0));
+
+// Endpoints are public when flagged true or `public` (`visibility: public`)
+const isPublic = (value: unknown): boolean => value === true || ['true', 'public'].includes(String(value).trim().toLowerCase());
+
+// Limits are often written with a unit: `100r/s`, `50/minute`
+const amountOf = (value: unknown): number | undefined => {
+  const amount = typeof value === 'number' ? value : typeof value === 'string' ? parseFloat(value) : NaN;
+  return Number.isFinite(amount) ? amount : undefined;
+};
+
+const valueAt = (block: unknown, keyPath: string): { path: string; value: unknown } | undefined =>
+  resolveKeyPattern(block, keyPath)[0];
+
+type RateLimitCode = 'RATE_LIMIT_MISSING' | 'RATE_LIMIT_BURST_EXCESSIVE' | 'RATE_LIMIT_QUOTA_UNLIMITED';
+
+const rateLimitFinding = (
+  code: RateLimitCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  // A burst above the factor still limits the endpoint; the rest leave it open
+  severity: code === 'RATE_LIMIT_BURST_EXCESSIVE' ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: RATE_LIMIT_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks the endpoint blocks of one file: public ones need a finite limit, and no burst may exceed limit * factor
+ */
+const endpointFindings = (file: ConfigFile, settings: RateLimitSettings, environment?: string): ValidationError[] => {
+  const publicKey = settings.public ?? DEFAULT_RATE_LIMIT_KEYS.public;
+  const limitKey = settings.limit ?? DEFAULT_RATE_LIMIT_KEYS.limit;
+  const burstKey = settings.burst ?? DEFAULT_RATE_LIMIT_KEYS.burst;
+  const factor = settings.burstFactor ?? DEFAULT_RATE_LIMIT_KEYS.burstFactor;
+
+  return settings.endpoints
+    .flatMap(pattern => resolveKeyPattern(file.content, pattern))
+    .flatMap(({ path: endpoint, value: block }) => {
+      const limit = valueAt(block, limitKey);
+      const burst = valueAt(block, burstKey);
+      const limited = limit !== undefined && limit.value !== null && !isUnlimited(limit.value);
+
+      if (!limited && isPublic(valueAt(block, publicKey)?.value)) {
+        return [rateLimitFinding(
+          'RATE_LIMIT_MISSING',
+          `Public endpoint '${endpoint}' has no rate limit at '${limitKey}' (${file.path})`,
+          file,
+          joinKeyPath(endpoint, limit?.path ?? limitKey),
+          { endpoint, limitKey },
+          environment
+        )];
+      }
+
+      const limitAmount = limit && limited ? amountOf(limit.value) : undefined;
+      const burstAmount = burst ? amountOf(burst.value) : undefined;
+
+      return burst && limitAmount !== undefined && burstAmount !== undefined && burstAmount > limitAmount * factor ? [rateLimitFinding(
+        'RATE_LIMIT_BURST_EXCESSIVE',
+        `Endpoint '${endpoint}' allows a burst of ${burstAmount}, more than ${factor} times its limit of ${limitAmount} (${file.path})`,
+        file,
+        joinKeyPath(endpoint, burst.path),
+        { endpoint, burst: burstAmount, limit: limitAmount, factor, maximum: limitAmount * factor },
+        environment
+      )] : [];
+    });
+};
+
+/**
+ * Checks the rate limits and quotas of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @param settings - Key paths of the endpoints, limits and quotas
+ * @returns Findings about unlimited public endpoints, excessive bursts and unlimited production quotas
+ */
+export const checkRateLimits = (files: ConfigFile[], settings: RateLimitSettings): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const production = environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+    const quotas = production
+      ? settings.quotas.flatMap(pattern => resolveKeyPattern(file.content, pattern)).filter(quota => isUnlimited(quota.value))
+      : [];
+
+    return [
+      ...endpointFindings(file, settings, environment),
+      ...quotas.map(quota => rateLimitFinding(
+        'RATE_LIMIT_QUOTA_UNLIMITED',
+        `Quota '${quota.path}' is unlimited (${quota.value}) in production (${file.path})`,
+        file,
+        quota.path,
+        { quota: quota.path, value: quota.value },
+        environment
+      )),
+    ];
+  });
+};
+
+/**
+ * Adds rate limit findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @param settings - Key paths from `rate_limits:`; the checks are off without them
+ * @returns Result that fails when a public endpoint or a production quota is unlimited
+ */
+export const withRateLimitFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  settings?: RateLimitSettings
+): ValidationResult => {
+  // Guard clause: no key paths configured
+  if (!settings) {
+    return result;
+  }
+
+  const findings = checkRateLimits(files, settings);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
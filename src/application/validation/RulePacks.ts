@@ -0,0 +1,88 @@
+/**
+ * @file src/application/validation/RulePacks.ts
+ * @description The rule packs validate runs after the key comparison: each one adds its findings
+ * to the result, on the files it is scoped to, in the order they are listed
+ */
+
+import { ConfigFile, PerformanceMetadata, RuleScope, ValidationResult } from '../../shared/types';
+import { measure } from '../../shared/utils/Timing';
+import { isRuleEnabled, scopeFiles } from './RuleScoping';
+
+/**
+ * @interface RulePack
+ * @description One rule pack of the validate pipeline
+ */
+export interface RulePack {
+  /** Rule id, usable in `scopes:` */
+  id: string;
+  /** false when the pack has nothing to check (its section is not configured); enabled by default */
+  enabled?: boolean;
+  /** Files the pack may run on before scoping; the compared files when omitted */
+  files?: ConfigFile[];
+  /** Adds the findings of the pack to the result of the packs before it */
+  run: (result: ValidationResult, files: ConfigFile[]) => ValidationResult | Promise<ValidationResult>;
+}
+
+/**
+ * @interface RulePackRun
+ * @description What running the packs produced
+ */
+export interface RulePackRun {
+  /** The result with the findings of every pack */
+  result: ValidationResult;
+  /** Evaluation time of each pack that ran, in the order they ran */
+  timings: PerformanceMetadata['rules'];
+}
+
+/**
+ * Runs rule packs one after the other, timing each one; disabled packs and packs a scope
+ * disables are skipped
+ * @param result - Result of the key comparison
+ * @param packs - Packs, in the order they run
+ * @param scopes - Configured scopes
+ * @param files - Compared files
+ * @returns The final result and one timing per pack that ran
+ */
+export const runRulePacks = (
+  result: ValidationResult,
+  packs: RulePack[],
+  scopes: RuleScope[],
+  files: ConfigFile[]
+): Promise<RulePackRun> =>
+  packs.reduce<Promise<RulePackRun>>(async (previous, pack) => {
+    const done = await previous;
+
+    // Guard clause: nothing to check, or turned off in `scopes:`
+    if (pack.enabled === false || !isRuleEnabled(scopes, pack.id)) {
+      return done;
+    }
+
+    const scoped = scopeFiles(scopes, pack.id, pack.files ?? files);
+    const evaluation = await measure(async () => pack.run(done.result, scoped));
+
+    return { result: evaluation.value, timings: [...done.timings, { id: pack.id, evaluationMs: evaluation.ms }] };
+  }, Promise.resolve({ result, timings: [] }));
+
+/**
+ * @constant ALL_CORE_RULES
+ * @description Rule set selecting every rule, rule packs included
+ */
+export const ALL_CORE_RULES = '@praetorian/core/all';
+
+/**
+ * Keeps the packs a `ruleSets` list selects enabled: the packs it names by id, or every pack when it
+ * lists `@praetorian/core/all` or only rule sources of the rule system
+ * @param packs - Packs, in the order they run
+ * @param ruleSets - Selected rule sets; every pack when undefined
+ * @returns The packs, those left out disabled
+ */
+export const selectRulePacks = (packs: RulePack[], ruleSets?: string[]): RulePack[] => {
+  const named = new Set((ruleSets ?? []).filter(ruleSet => packs.some(pack => pack.id === ruleSet)));
+
+  // Guard clause: no selection among the packs
+  if (ruleSets === undefined || ruleSets.includes(ALL_CORE_RULES) || named.size === 0) {
+    return packs;
+  }
+
+  return packs.map(pack => (named.has(pack.id) ? pack : { ...pack, enabled: false }));
+};
@@ -0,0 +1,103 @@
+/**
+ * @file src/application/validation/RuleScoping.ts
+ * @description Pure functions deciding which files and groups each rule or auditor runs on
+ */
+
+import { RuleScope } from '../../shared/types';
+import { matchesGlob } from '../../shared/utils/Glob';
+import { Labels, matchesLabels } from '../../shared/utils/Labels';
+
+/**
+ * @interface ScopeTarget
+ * @description What a rule is about to run on; unknown parts are left undefined
+ */
+export interface ScopeTarget {
+  file?: string;
+  group?: string;
+  labels?: Labels;
+}
+
+/**
+ * Lists the scopes that mention a rule
+ * @param scopes - Configured scopes
+ * @param ruleId - Rule id or auditor name
+ * @returns Scopes of the rule
+ */
+export const scopesOfRule = (scopes: RuleScope[], ruleId: string): RuleScope[] =>
+  scopes.filter(scope => Array.isArray(scope.rules) && scope.rules.includes(ruleId));
+
+/**
+ * Checks whether a rule is enabled at all
+ * @param scopes - Configured scopes
+ * @param ruleId - Rule id or auditor name
+ * @returns false when a scope disables the rule
+ */
+export const isRuleEnabled = (scopes: RuleScope[], ruleId: string): boolean =>
+  !scopesOfRule(scopes, ruleId).some(scope => scope.enabled === false);
+
+const matchesAny = (file: string | undefined, patterns: string[] = []): boolean =>
+  file !== undefined && patterns.some(pattern => matchesGlob(file, pattern));
+
+const hasLabels = (scope: RuleScope): boolean => Object.keys(scope.labels ?? {}).length > 0;
+
+const includesTarget = (scope: RuleScope, target: ScopeTarget): boolean =>
+  matchesAny(target.file, scope.files) ||
+  (target.group !== undefined && (scope.groups ?? []).includes(target.group)) ||
+  (hasLabels(scope) && target.labels !== undefined && matchesLabels(target.labels, scope.labels));
+
+/**
+ * Checks whether a rule runs on a file or group.
+ * Rules without scopes run everywhere; with several scopes, matching any of them is enough,
+ * while `exclude` and `enabled: false` always win.
+ * @param scopes - Configured scopes
+ * @param ruleId - Rule id or auditor name
+ * @param target - File and group the rule would run on
+ * @returns true when the rule applies to the target
+ */
+export const isInScope = (scopes: RuleScope[], ruleId: string, target: ScopeTarget): boolean => {
+  const applicable = scopesOfRule(scopes, ruleId);
+
+  // Guard clause: disabled everywhere
+  if (!isRuleEnabled(scopes, ruleId)) {
+    return false;
+  }
+
+  // Guard clause: explicitly excluded file
+  if (applicable.some(scope => matchesAny(target.file, scope.exclude))) {
+    return false;
+  }
+
+  const restricting = applicable.filter(scope => (scope.files?.length ?? 0) > 0 || (scope.groups?.length ?? 0) > 0 || hasLabels(scope));
+  return restricting.length === 0 || restricting.some(scope => includesTarget(scope, target));
+};
+
+/**
+ * Keeps the files a rule runs on
+ * @param scopes - Configured scopes
+ * @param ruleId - Rule id or auditor name
+ * @param files - Candidate files, with their labels when they have any
+ * @param group - Audit group the files belong to, if any
+ * @returns Files in scope, in their original order
+ */
+export const scopeFiles = <T extends { path: string; labels?: Labels }>(
+  scopes: RuleScope[],
+  ruleId: string,
+  files: T[],
+  group?: string
+): T[] => files.filter(file => isInScope(scopes, ruleId, { file: file.path, group, labels: file.labels }));
+
+/**
+ * Keeps the entries of a path -> content map a rule runs on
+ * @param scopes - Configured scopes
+ * @param ruleId - Rule id or auditor name
+ * @param files - Map of file path to content
+ * @param labels - Labels of the files, by path
+ * @returns Map restricted to the files in scope
+ */
+export const scopeFileMap = (
+  scopes: RuleScope[],
+  ruleId: string,
+  files: Record<string, any>,
+  labels: Record<string, Labels> = {}
+): Record<string, any> =>
+  Object.fromEntries(Object.entries(files).filter(([file]) => isInScope(scopes, ruleId, { file, labels: labels[file] })));
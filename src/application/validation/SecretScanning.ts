@@ -0,0 +1,148 @@
+/**
+ * @file src/application/validation/SecretScanning.ts
+ * @description Pure functions finding secrets in configuration values: provider token formats and random-looking strings
+ */
+
+import { ValidationError } from '../../shared/types';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { maskSecret } from '../validators/SecretDetector';
+
+/**
+ * @constant SECRET_RULE_ID
+ * @description Rule id of the secret scan, the name of the `security` audit type usable in `scopes:`
+ */
+export const SECRET_RULE_ID = 'security';
+
+/**
+ * @interface SecretPattern
+ * @description A provider credential recognizable by its format
+ */
+export interface SecretPattern {
+  id: string;
+  name: string;
+  pattern: RegExp;
+}
+
+/**
+ * @constant SECRET_PATTERNS
+ * @description Credential formats reported whatever key holds them
+ */
+export const SECRET_PATTERNS: SecretPattern[] = [
+  { id: 'aws-access-key-id', name: 'AWS access key ID', pattern: /\b(?:AKIA|ASIA)[0-9A-Z]{16}\b/ },
+  { id: 'github-token', name: 'GitHub token', pattern: /\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b/ },
+  { id: 'gitlab-token', name: 'GitLab token', pattern: /\bglpat-[A-Za-z0-9_-]{20}(?![A-Za-z0-9_-])/ },
+  { id: 'slack-webhook', name: 'Slack webhook URL', pattern: /https:\/\/hooks\.slack\.com\/services\/T[A-Z0-9]+\/B[A-Z0-9]+\/[A-Za-z0-9]+/ },
+  { id: 'slack-token', name: 'Slack token', pattern: /\bxox[abposr]-[A-Za-z0-9-]{10,}/ },
+  { id: 'private-key', name: 'Private key', pattern: /-----BEGIN (?:[A-Z]+ )*PRIVATE KEY(?: BLOCK)?-----/ },
+];
+
+/**
+ * @constant ENTROPY_THRESHOLD
+ * @description Bits per character above which a token-like value looks random; words and slugs stay well below
+ */
+export const ENTROPY_THRESHOLD = 4.0;
+
+/**
+ * @constant ENTROPY_MIN_LENGTH
+ * @description Shorter values carry too few characters for their entropy to mean anything
+ */
+export const ENTROPY_MIN_LENGTH = 24;
+
+// Base64, base64url and hex alphabets; values with spaces, paths or URLs are not tokens
+const TOKEN_LIKE = /^[A-Za-z0-9+/_=-]+$/;
+
+/**
+ * Computes the Shannon entropy of a text
+ * @param text - Text to measure
+ * @returns Bits per character, 0 for empty text
+ */
+export const shannonEntropy = (text: string): number => {
+  const counts = [...text].reduce((tally, char) => tally.set(char, (tally.get(char) ?? 0) + 1), new Map<string, number>());
+  return [...counts.values()].reduce((bits, count) => {
+    const probability = count / text.length;
+    return bits - probability * Math.log2(probability);
+  }, 0);
+};
+
+/**
+ * Checks whether a value looks like a randomly generated secret
+ * @param value - String value
+ * @returns true for long token-like values mixing letters and digits with high entropy
+ */
+export const isHighEntropy = (value: string): boolean =>
+  value.length >= ENTROPY_MIN_LENGTH &&
+  TOKEN_LIKE.test(value) &&
+  /[A-Za-z]/.test(value) &&
+  /\d/.test(value) &&
+  shannonEntropy(value) >= ENTROPY_THRESHOLD;
+
+/**
+ * Lists every string value of a parsed file, list items included
+ * @param value - Parsed content
+ * @param keyPath - Key path of the value; list items are numbered (`hosts.0`)
+ * @returns Each string with its key path
+ */
+export const stringValues = (value: unknown, keyPath = ''): Array<{ keyPath: string; value: string }> => {
+  if (typeof value === 'string') {
+    return keyPath ? [{ keyPath, value }] : [];
+  }
+  if (Array.isArray(value)) {
+    return value.flatMap((item, index) => stringValues(item, joinKeyPath(keyPath, String(index))));
+  }
+  return isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, item]) => stringValues(item, joinKeyPath(keyPath, key)))
+    : [];
+};
+
+const secretFinding = (
+  code: 'SECRET_DETECTED' | 'HIGH_ENTROPY_VALUE',
+  message: string,
+  file: string,
+  keyPath: string,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: code === 'SECRET_DETECTED' ? 'error' : 'warning',
+  path: keyPath,
+  context: { file, keyPath, rule: { id: SECRET_RULE_ID }, extras },
+});
+
+/**
+ * Scans the values of a file for secrets; values only appear masked
+ * @param file - Path of the file
+ * @param content - Parsed content
+ * @returns One error per value holding a provider credential, else one warning per random-looking value
+ */
+export const scanSecrets = (file: string, content: unknown): ValidationError[] =>
+  stringValues(content).flatMap(({ keyPath, value }) => {
+    const known = SECRET_PATTERNS
+      .map(secret => ({ secret, match: secret.pattern.exec(value) }))
+      .find(candidate => candidate.match !== null);
+
+    if (known?.match) {
+      const maskedValue = maskSecret(known.match[0]);
+      return [secretFinding(
+        'SECRET_DETECTED',
+        `${known.secret.name} found in '${keyPath}' (${maskedValue}) of ${file}`,
+        file,
+        keyPath,
+        { secretType: known.secret.id, secretName: known.secret.name, maskedValue }
+      )];
+    }
+
+    // Guard clause: nothing random-looking either
+    if (!isHighEntropy(value)) {
+      return [];
+    }
+
+    const maskedValue = maskSecret(value);
+    const entropy = shannonEntropy(value).toFixed(1);
+    return [secretFinding(
+      'HIGH_ENTROPY_VALUE',
+      `'${keyPath}' holds a random-looking value (${maskedValue}, ${entropy} bits per character) that may be a secret (${file})`,
+      file,
+      keyPath,
+      { secretType: 'high-entropy', maskedValue, entropy }
+    )];
+  });
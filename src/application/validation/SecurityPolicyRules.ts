@@ -0,0 +1,318 @@
+/**
+ * @file src/application/validation/SecurityPolicyRules.ts
+ * @description Pure functions checking the CORS blocks, TLS settings and security headers modeled in configuration files
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant SECURITY_POLICY_RULE_ID
+ * @description Rule id of the CORS, TLS and security header checks, usable in `scopes:`
+ */
+export const SECURITY_POLICY_RULE_ID = 'security-policies';
+
+/**
+ * @constant TLS_MIN_VERSION
+ * @description Oldest TLS version a configuration may accept
+ */
+export const TLS_MIN_VERSION = 1.2;
+
+/**
+ * @constant HSTS_MIN_MAX_AGE
+ * @description Shortest Strict-Transport-Security max-age accepted, in seconds (one year)
+ */
+export const HSTS_MIN_MAX_AGE = 31536000;
+
+// Keys are compared lower-cased without separators: `allowed-origins`, `AllowedOrigins`, `allowed_origins`
+const ORIGIN_KEYS = ['origin', 'origins', 'allowedorigins', 'alloworigins', 'alloworigin', 'allowedoriginpatterns', 'accesscontrolalloworigin'];
+// Origin keys that make a block a CORS block without a `cors` parent
+const CORS_ONLY_ORIGIN_KEYS = ORIGIN_KEYS.filter(key => key.startsWith('allow') || key.startsWith('accesscontrol'));
+const CREDENTIAL_KEYS = ['credentials', 'allowcredentials', 'supportscredentials', 'accesscontrolallowcredentials'];
+const MIN_VERSION_KEYS = [
+  'minversion', 'minimumversion', 'minprotocolversion', 'minimumprotocolversion', 'minprotocol',
+  'tlsminversion', 'tlsminimumversion', 'mintlsversion', 'minimumtlsversion', 'sslminversion',
+];
+const PROTOCOL_KEYS = ['protocols', 'sslprotocols', 'tlsprotocols', 'enabledprotocols', 'supportedprotocols', 'sslprotocol'];
+const CIPHER_KEYS = ['ciphers', 'cipherlist', 'ciphersuites', 'ciphersuite', 'sslciphers', 'tlsciphers', 'sslciphersuite', 'sslcipherlist'];
+// Parts of OpenSSL and IANA cipher names that mark a broken or unauthenticated cipher
+const WEAK_CIPHER_PARTS = ['NULL', 'ANULL', 'ENULL', 'EXPORT', 'EXP', 'LOW', 'RC4', 'DES', '3DES', 'MD5', 'ANON', 'ADH', 'AECDH'];
+const TLS_CONTEXT = /tls|ssl|https/i;
+
+/**
+ * @constant SECURITY_HEADERS
+ * @description Header name -> keys that configure it (header names and common framework options)
+ */
+export const SECURITY_HEADERS: Record<string, string[]> = {
+  'X-Frame-Options': ['xframeoptions', 'frameoptions', 'frameguard'],
+  'Strict-Transport-Security': ['stricttransportsecurity', 'hsts'],
+  'X-Content-Type-Options': ['xcontenttypeoptions', 'contenttypeoptions', 'nosniff'],
+  'Content-Security-Policy': ['contentsecuritypolicy', 'csp'],
+};
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+interface Node {
+  keyPath: string;
+  key: string;
+  value: unknown;
+  /** Keys of the enclosing sections, outermost first */
+  ancestors: string[];
+}
+
+/**
+ * Lists every key of a parsed file with its value and enclosing sections; list items keep the key of their list
+ */
+const nodes = (value: unknown, keyPath: string = '', key: string = '', ancestors: string[] = []): Node[] => {
+  if (Array.isArray(value)) {
+    return value.flatMap((item, index) => isPlainObject(item) ? nodes(item, joinKeyPath(keyPath, String(index)), key, ancestors) : []);
+  }
+
+  // Guard clause: leaves are listed by their parent
+  if (!isPlainObject(value)) {
+    return [];
+  }
+
+  const inside = key === '' ? ancestors : [...ancestors, key];
+  return Object.entries(value).flatMap(([child, childValue]) => {
+    const childPath = joinKeyPath(keyPath, child);
+    return [{ keyPath: childPath, key: child, value: childValue, ancestors: inside }, ...nodes(childValue, childPath, child, inside)];
+  });
+};
+
+const entryOf = (block: Record<string, any>, keys: string[]): [string, unknown] | undefined =>
+  Object.entries(block).find(([key]) => keys.includes(normalize(key)));
+
+const listOf = (value: unknown, separator: RegExp): string[] =>
+  (Array.isArray(value) ? value.map(String) : typeof value === 'string' ? value.split(separator) : [])
+    .map(item => item.trim())
+    .filter(item => item !== '');
+
+const isEnabled = (value: unknown): boolean => value === true || String(value).trim().toLowerCase() === 'true';
+
+/**
+ * Reads a TLS version as written (`TLSv1.1`, `TLS1_0`, `VersionTLS12`, `1.2`, `TLSv1.2_2021`); SSL counts as 0
+ * @param text - Version
+ * @returns Version number, or undefined when the text names no version
+ */
+export const parseTlsVersion = (text: string): number | undefined => {
+  // Guard clause: every SSL version is older than TLS 1.0
+  if (/^ssl/i.test(text.trim())) {
+    return 0;
+  }
+
+  // CloudFront policies carry a year: TLSv1_2016 is TLS 1.0
+  const digits = /(\d)(?:[._]?(\d))?(?!\d)/.exec(text);
+  return digits && digits[1] === '1' ? 1 + Number(digits[2] ?? 0) / 10 : undefined;
+};
+
+type SecurityPolicyCode =
+  | 'CORS_WILDCARD_CREDENTIALS'
+  | 'TLS_VERSION_WEAK'
+  | 'TLS_CIPHER_WEAK'
+  | 'SECURITY_HEADER_WEAK'
+  | 'SECURITY_HEADER_DISABLED';
+
+// Header settings depend on what is served; the others are errors
+const WARNING_CODES: SecurityPolicyCode[] = ['SECURITY_HEADER_WEAK', 'SECURITY_HEADER_DISABLED'];
+
+const securityPolicyFinding = (
+  code: SecurityPolicyCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  observedValue?: unknown
+): ValidationError => ({
+  code,
+  message,
+  severity: WARNING_CODES.includes(code) ? 'warning' : 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    ...(observedValue !== undefined ? { observedValue } : {}),
+    rule: { id: SECURITY_POLICY_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Finds CORS blocks that accept any origin (`*`, or `true` to reflect the caller's) and send credentials
+ */
+const corsFindings = (file: ConfigFile, all: Node[]): ValidationError[] =>
+  all
+    .filter(node => isPlainObject(node.value) &&
+      (/cors/i.test(node.key) || Object.keys(node.value).some(key => CORS_ONLY_ORIGIN_KEYS.includes(normalize(key)))))
+    .flatMap(node => {
+      const block = node.value as Record<string, any>;
+      const origin = entryOf(block, ORIGIN_KEYS);
+      const credentials = entryOf(block, CREDENTIAL_KEYS);
+      const anyOrigin = origin !== undefined && (origin[1] === true || listOf(origin[1], /[\s,]+/).includes('*'));
+
+      return origin && anyOrigin && credentials && isEnabled(credentials[1]) ? [securityPolicyFinding(
+        'CORS_WILDCARD_CREDENTIALS',
+        `CORS block '${node.keyPath}' accepts any origin with credentials in production; list the allowed origins (${file.path})`,
+        file,
+        joinKeyPath(node.keyPath, origin[0]),
+        { block: node.keyPath },
+        origin[1]
+      )] : [];
+    });
+
+/**
+ * Finds minimum TLS versions and protocol lists that accept versions older than TLS 1.2,
+ * in TLS/SSL/HTTPS sections or under keys that name TLS or SSL
+ */
+const tlsVersionFindings = (file: ConfigFile, all: Node[]): ValidationError[] =>
+  all
+    .filter(node => [...node.ancestors, node.key].some(key => TLS_CONTEXT.test(key)))
+    .flatMap(node => {
+      const key = normalize(node.key);
+      const versions = MIN_VERSION_KEYS.includes(key) && (typeof node.value === 'string' || typeof node.value === 'number')
+        ? [String(node.value)]
+        : PROTOCOL_KEYS.includes(key)
+          // `-TLSv1` and `!SSLv3` exclude a version
+          ? listOf(node.value, /[\s,:]+/).filter(entry => !/^[-!]/.test(entry)).map(entry => entry.replace(/^\+/, ''))
+          : [];
+      const weak = versions.filter(version => {
+        const parsed = parseTlsVersion(version);
+        return parsed !== undefined && parsed < TLS_MIN_VERSION;
+      });
+
+      return weak.length === 0 ? [] : [securityPolicyFinding(
+        'TLS_VERSION_WEAK',
+        `'${node.keyPath}' accepts ${weak.join(', ')}, older than TLS ${TLS_MIN_VERSION} (${file.path})`,
+        file,
+        node.keyPath,
+        { versions: weak.join(', '), minimum: `TLS ${TLS_MIN_VERSION}` },
+        node.value
+      )];
+    });
+
+/**
+ * Finds cipher lists that enable NULL, export, anonymous, RC4, DES/3DES or MD5 ciphers
+ */
+const cipherFindings = (file: ConfigFile, all: Node[]): ValidationError[] =>
+  all
+    .filter(node => CIPHER_KEYS.includes(normalize(node.key)))
+    .flatMap(node => {
+      const weak = listOf(node.value, /[\s:,]+/)
+        // `!RC4` and `-DES` remove ciphers from an OpenSSL list
+        .filter(cipher => !/^[-!]/.test(cipher))
+        .filter(cipher => cipher.replace(/^\+/, '').toUpperCase().split(/[-_+]/).some(part => WEAK_CIPHER_PARTS.includes(part)));
+
+      return weak.length === 0 ? [] : [securityPolicyFinding(
+        'TLS_CIPHER_WEAK',
+        `'${node.keyPath}' enables weak ciphers: ${weak.join(', ')} (${file.path})`,
+        file,
+        node.keyPath,
+        { ciphers: weak.join(', ') },
+        node.value
+      )];
+    });
+
+const leafText = (value: unknown): string =>
+  isPlainObject(value) || Array.isArray(value) ? Object.values(value).map(leafText).join(' ') : String(value);
+
+/**
+ * Explains why a header value is weak, if it is
+ */
+const headerWeakness = (header: string, value: unknown): string | undefined => {
+  switch (header) {
+    case 'X-Frame-Options': {
+      const action = isPlainObject(value) ? value.action : value;
+      return typeof action === 'string' && !['DENY', 'SAMEORIGIN'].includes(action.trim().toUpperCase())
+        ? `'${action}' lets other sites frame the pages; use DENY or SAMEORIGIN`
+        : undefined;
+    }
+    case 'Strict-Transport-Security': {
+      const maxAge = isPlainObject(value)
+        ? entryOf(value, ['maxage', 'maxageinseconds'])?.[1]
+        : typeof value === 'string' ? /max-age\s*=\s*(\d+)/i.exec(value)?.[1] : undefined;
+      return maxAge !== undefined && Number(maxAge) < HSTS_MIN_MAX_AGE
+        ? `max-age ${maxAge} is shorter than ${HSTS_MIN_MAX_AGE} seconds`
+        : undefined;
+    }
+    case 'X-Content-Type-Options':
+      return typeof value === 'string' && value.trim().toLowerCase() !== 'nosniff' ? `'${value}' is not nosniff` : undefined;
+    default: {
+      const policy = leafText(value);
+      const unsafe = ["'unsafe-inline'", "'unsafe-eval'"].filter(source => policy.includes(source));
+      return unsafe.length > 0
+        ? `the policy allows ${unsafe.join(' and ')}`
+        : /default-src\s+\*(\s|;|$)/.test(policy) ? 'default-src allows any source' : undefined;
+    }
+  }
+};
+
+/**
+ * Finds security headers that are switched off or set to weak values
+ */
+const headerFindings = (file: ConfigFile, all: Node[]): ValidationError[] =>
+  all.flatMap(node => {
+    const header = Object.keys(SECURITY_HEADERS).find(name => SECURITY_HEADERS[name].includes(normalize(node.key)));
+
+    // Guard clause: not a security header setting
+    if (!header) {
+      return [];
+    }
+
+    const disabled = node.value === false ||
+      (isPlainObject(node.value) && (node.value.enabled === false || isEnabled(node.value.disable) || isEnabled(node.value.disabled)));
+    if (disabled) {
+      return [securityPolicyFinding(
+        'SECURITY_HEADER_DISABLED',
+        `'${node.keyPath}' turns off ${header} (${file.path})`,
+        file,
+        node.keyPath,
+        { header }
+      )];
+    }
+
+    const weakness = headerWeakness(header, node.value);
+    return weakness === undefined ? [] : [securityPolicyFinding(
+      'SECURITY_HEADER_WEAK',
+      `${header} at '${node.keyPath}' is weak: ${weakness} (${file.path})`,
+      file,
+      node.keyPath,
+      { header, reason: weakness },
+      node.value
+    )];
+  });
+
+/**
+ * Checks the CORS, TLS and security header settings of the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about permissive production CORS, old TLS versions, weak ciphers and weak headers
+ */
+export const checkSecurityPolicies = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const all = nodes(file.content);
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const production = environment !== undefined && tokens.prod.some(token => mentionsToken(environment, token));
+
+    return [
+      // Browsers refuse `*` with credentials, so servers reflect the caller's origin instead; only production is held to it
+      ...(production ? corsFindings(file, all) : []),
+      ...tlsVersionFindings(file, all),
+      ...cipherFindings(file, all),
+      ...headerFindings(file, all),
+    ];
+  });
+};
+
+/**
+ * Adds CORS, TLS and security header findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when production CORS is open with credentials or TLS accepts broken versions or ciphers
+ */
+export const withSecurityPolicyFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkSecurityPolicies(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
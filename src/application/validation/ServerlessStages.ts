@@ -0,0 +1,211 @@
+/**
+ * @file src/application/validation/ServerlessStages.ts
+ * @description Pure functions detecting stage drift in Serverless Framework configurations
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { extractKeyPaths, isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { resolveText, resolveValue } from '../../shared/utils/ServerlessVariables';
+
+/**
+ * @constant SERVERLESS_RULE_ID
+ * @description Rule id of the stage drift check, usable in `scopes:`
+ */
+export const SERVERLESS_RULE_ID = 'serverless';
+
+// Placeholder stage used to find the mappings a variable indexes by stage
+const STAGE_PROBE = '\u0000stage';
+const STAGE_INDEXED = /\$\{self:([\w.-]+)\.(\$\{[^{}]*\})/g;
+
+/**
+ * @interface StageView
+ * @description The keys a stage ends up with and the variables that did not resolve for it
+ */
+export interface StageView {
+  stage: string;
+  keys: Set<string>;
+  unresolved: Array<{ keyPath: string; variable: string }>;
+}
+
+/**
+ * Checks whether parsed content is a Serverless Framework configuration
+ * @param content - Parsed file
+ * @returns true when the content has a `service` and a `provider` with a name
+ */
+export const isServerlessConfig = (content: unknown): content is Record<string, any> =>
+  isPlainObject(content) && content.service !== undefined && isPlainObject(content.provider) && typeof content.provider.name === 'string';
+
+const leafStrings = (value: unknown, keyPath: string): Array<{ keyPath: string; text: string }> =>
+  typeof value === 'string'
+    ? [{ keyPath, text: value }]
+    : Array.isArray(value) || isPlainObject(value)
+      ? Object.entries(value).flatMap(([key, child]) => leafStrings(child, joinKeyPath(keyPath, key)))
+      : [];
+
+const valueAt = (content: Record<string, any>, dottedPath: string): unknown =>
+  dottedPath.split('.').reduce<any>((value, key) => (isPlainObject(value) ? value[key] : undefined), content);
+
+/**
+ * Lists the mappings a variable indexes by stage, e.g. `custom.dbHost` for
+ * `${self:custom.dbHost.${sls:stage}}`; the top-level `custom` itself is not one
+ * @param content - Parsed serverless.yml
+ * @returns Dotted paths of the stage-indexed mappings
+ */
+export const stageIndexedPaths = (content: Record<string, any>): string[] => [
+  ...new Set(
+    leafStrings(content, '')
+      .flatMap(({ text }) => [...text.matchAll(STAGE_INDEXED)])
+      .filter(([, , stageVariable]) => resolveText(stageVariable, { stage: STAGE_PROBE, self: content }).value === STAGE_PROBE)
+      .map(([, mappingPath]) => mappingPath)
+      .filter(mappingPath => mappingPath !== 'custom')
+  ),
+];
+
+/**
+ * Lists the stages of a configuration: `params:` entries, a `custom.stages` list or mapping,
+ * the default of `provider.stage` and the entries of stage-indexed mappings
+ * @param content - Parsed serverless.yml
+ * @returns Stage names in order of appearance
+ */
+export const serverlessStages = (content: Record<string, any>): string[] => {
+  const params = isPlainObject(content.params) ? Object.keys(content.params).filter(stage => stage !== 'default') : [];
+  const declared = content.custom?.stages;
+  const custom = Array.isArray(declared)
+    ? declared.filter((stage): stage is string => typeof stage === 'string')
+    : isPlainObject(declared) ? Object.keys(declared) : [];
+  // Serverless deploys to `dev` unless told otherwise
+  const defaultStage = resolveText(String(content.provider.stage ?? 'dev'), { self: content }).value;
+  const indexed = stageIndexedPaths(content).flatMap(mappingPath => {
+    const mapping = valueAt(content, mappingPath);
+    return isPlainObject(mapping) ? Object.keys(mapping) : [];
+  });
+
+  return [...new Set([
+    ...params,
+    ...custom,
+    ...(typeof defaultStage === 'string' && !defaultStage.includes('${') ? [defaultStage] : []),
+    ...indexed,
+  ])];
+};
+
+/**
+ * Resolves the stage-dependent blocks of a configuration for one stage: `provider.environment`,
+ * every `functions.<name>.environment`, `custom`, and the entry of the stage in each
+ * stage-indexed mapping (reported under the path of the mapping)
+ * @param content - Parsed serverless.yml
+ * @param stage - Stage to resolve
+ * @param env - Process environment for `${env:...}`
+ * @returns Resolved key paths and unresolved variables of the stage
+ */
+export const resolveStage = (
+  content: Record<string, any>,
+  stage: string,
+  env: Record<string, string | undefined> = {}
+): StageView => {
+  const scope = { stage, self: content, env };
+  const functions = isPlainObject(content.functions) ? Object.entries(content.functions) : [];
+  const sections: Array<[string, unknown]> = [
+    ['provider.environment', content.provider.environment],
+    ...functions.map(([name, definition]): [string, unknown] => [`functions.${name}.environment`, definition?.environment]),
+    ['custom', content.custom],
+    ...stageIndexedPaths(content).map((mappingPath): [string, unknown] => [mappingPath, (valueAt(content, mappingPath) as any)?.[stage]]),
+  ];
+
+  return {
+    stage,
+    keys: new Set(sections.flatMap(([keyPath, value]) => [...extractKeyPaths(resolveValue(value, scope).value, keyPath)])),
+    unresolved: sections.flatMap(([keyPath, value]) =>
+      leafStrings(value, keyPath).flatMap(leaf =>
+        resolveText(leaf.text, scope).unresolved.map(variable => ({ keyPath: leaf.keyPath, variable }))
+      )
+    ),
+  };
+};
+
+const serverlessFinding = (
+  code: 'SLS_STAGE_UNRESOLVED' | 'SLS_STAGE_KEY_MISSING',
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    environment: extras.stage as string,
+    keyPath,
+    rule: { id: SERVERLESS_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Checks one configuration: variables that do not resolve for a stage, and keys
+ * some stages end up with and others do not
+ */
+const stageFindings = (file: ConfigFile, env: Record<string, string | undefined>): ValidationError[] => {
+  const views = serverlessStages(file.content).map(stage => resolveStage(file.content, stage, env));
+  const allKeys = new Set(views.flatMap(view => [...view.keys]));
+  // Only the topmost missing key is reported, and nothing below a value that did not resolve
+  const isReportedMissing = (view: StageView, keyPath: string): boolean => {
+    const parent = keyPath.slice(0, keyPath.lastIndexOf('.'));
+    return !view.keys.has(keyPath) &&
+      (!allKeys.has(parent) || view.keys.has(parent)) &&
+      !view.unresolved.some(entry => keyPath.startsWith(`${entry.keyPath}.`));
+  };
+
+  const unresolved = views.flatMap(view => view.unresolved.map(({ keyPath, variable }) => serverlessFinding(
+    'SLS_STAGE_UNRESOLVED',
+    `'${keyPath}' does not resolve for stage '${view.stage}': \${${variable}} has no value (${file.path})`,
+    file,
+    keyPath,
+    { stage: view.stage, variable }
+  )));
+
+  // Drift needs at least two stages to compare
+  const missing = views.length < 2 ? [] : views.flatMap(view => [...allKeys]
+    .filter(keyPath => isReportedMissing(view, keyPath))
+    .map(keyPath => {
+      const presentIn = views.filter(other => other.keys.has(keyPath)).map(other => other.stage);
+      return serverlessFinding(
+        'SLS_STAGE_KEY_MISSING',
+        `'${keyPath}' is set for stage(s) ${presentIn.join(', ')} but not for stage '${view.stage}' (${file.path})`,
+        file,
+        keyPath,
+        { stage: view.stage, presentIn: presentIn.join(', ') }
+      );
+    }));
+
+  return [...unresolved, ...missing];
+};
+
+/**
+ * Checks every Serverless Framework configuration among the given files
+ * @param files - Parsed files; files that are not serverless.yml configurations are skipped
+ * @param env - Process environment for `${env:...}` variables
+ * @returns Findings about stage drift
+ */
+export const checkServerless = (files: ConfigFile[], env: Record<string, string | undefined> = {}): ValidationError[] =>
+  files
+    .filter(file => isServerlessConfig(file.content))
+    .flatMap(file => stageFindings(file, env));
+
+/**
+ * Adds stage drift findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the check runs on
+ * @param env - Process environment for `${env:...}` variables
+ * @returns Result that fails when a stage drifts from the others
+ */
+export const withServerlessFindings = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  env: Record<string, string | undefined> = {}
+): ValidationResult => {
+  const findings = checkServerless(files, env);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,81 @@
+/**
+ * @file src/application/validation/SeverityEscalation.ts
+ * @description Pure functions to escalate (or relax) finding severities per environment
+ */
+
+import { ValidationError, ValidationResult, ValidationSeverity } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { normalizePath } from '../../shared/utils/Glob';
+
+/**
+ * @interface EscalationRule
+ * @description Change findings of one severity to another in an environment
+ */
+export interface EscalationRule {
+  environment: string;
+  from: ValidationSeverity;
+  to: ValidationSeverity;
+  /** Restrict the rule to these finding codes */
+  codes?: string[];
+}
+
+/**
+ * @interface EscalationOptions
+ * @description How findings are attributed to environments
+ */
+export interface EscalationOptions {
+  /** Environment of the whole run (e.g. --env prod) */
+  environment?: string;
+  /** File path -> environment name, from the environments section */
+  environmentFiles?: Record<string, string>;
+}
+
+/**
+ * Resolves the environment a finding belongs to: the environment of its file, else the run's
+ * @param finding - Finding to attribute
+ * @param options - Escalation options
+ * @returns Environment name, if known
+ */
+export const findingEnvironment = (
+  finding: ValidationError,
+  options: EscalationOptions
+): string | undefined => {
+  const file = finding.context?.file;
+  const byFile = Object.entries(options.environmentFiles ?? {})
+    .find(([filePath]) => typeof file === 'string' && normalizePath(filePath) === normalizePath(file));
+
+  return byFile ? byFile[1] : options.environment;
+};
+
+/**
+ * Applies escalation rules to every finding of a result; the first matching rule wins
+ * @param result - Validation result
+ * @param rules - Escalation rules from praetorian.yaml
+ * @param options - Escalation options
+ * @returns Result with severities adjusted and findings re-bucketed
+ */
+export const applySeverityEscalation = (
+  result: ValidationResult,
+  rules: EscalationRule[],
+  options: EscalationOptions = {}
+): ValidationResult => {
+  // Guard clause: no rules
+  if (!rules || rules.length === 0) {
+    return result;
+  }
+
+  const findings = collectFindings(result).map(finding => {
+    const environment = findingEnvironment(finding, options);
+    const rule = rules.find(candidate =>
+      candidate.environment === environment &&
+      candidate.from === finding.severity &&
+      (!candidate.codes || candidate.codes.includes(finding.code))
+    );
+
+    return rule && rule.to !== finding.severity
+      ? { ...finding, severity: rule.to, context: { ...finding.context, escalatedFrom: finding.severity, environment } }
+      : finding;
+  });
+
+  return withFindings(result, findings);
+};
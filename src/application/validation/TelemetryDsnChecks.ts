@@ -0,0 +1,213 @@
+/**
+ * @file src/application/validation/TelemetryDsnChecks.ts
+ * @description Pure functions checking Sentry DSNs and SMTP URLs: their syntax, and that no other
+ * environment reports to the production project or sends through the production mail account
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, tokenEnvironment } from './EnvironmentLeakage';
+
+/**
+ * @constant DSN_RULE_ID
+ * @description Rule id of the DSN checks, usable in `scopes:`
+ */
+export const DSN_RULE_ID = 'telemetry-dsns';
+
+export type DsnKind = 'sentry' | 'smtp';
+
+/**
+ * @interface Dsn
+ * @description A Sentry DSN or SMTP URL found in a file
+ */
+export interface Dsn {
+  kind: DsnKind;
+  keyPath: string;
+  /** Where events or mail go, without credentials: `sentry.example.com/42`, `smtp.example.com:587` */
+  destination?: string;
+  /** Why the value is not a valid DSN */
+  reason?: string;
+  /** Destination plus the account used, to tell shared destinations apart */
+  identity?: string;
+}
+
+// Key names, lower case without separators, whose value is a mail server URL
+const SMTP_KEYS = ['smtpurl', 'smtpuri', 'mailurl', 'maildsn', 'mailerdsn', 'mailerurl', 'emailurl', 'smtpdsn'];
+const SENTRY_PUBLIC_KEY = /^[0-9a-f]{32}$/i;
+// `${SENTRY_DSN}` and `{{ dsn }}` are resolved at deploy time
+const INTERPOLATION = /\$\{|\{\{/;
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+const parseUrl = (value: string): URL | undefined => {
+  try {
+    return new URL(value);
+  } catch {
+    return undefined;
+  }
+};
+
+/**
+ * Tells which kind of DSN a value is, if any
+ */
+const dsnKindOf = (keyPath: string, value: string): DsnKind | undefined => {
+  const key = normalize(keyPath.split('.').pop() ?? '');
+
+  if ((key.includes('sentry') && key.endsWith('dsn')) || /^https?:\/\/[^\s@/]+@[^\s/]*sentry\.io\//i.test(value)) {
+    return 'sentry';
+  }
+  // A bare `dsn` key may hold a database DSN (`mysql:host=db`); only URLs with a key are Sentry's
+  if (key.endsWith('dsn') && /^https?:\/\/[^\s@/]+@/i.test(value)) {
+    return 'sentry';
+  }
+  return /^smtps?:\/\//i.test(value) || (SMTP_KEYS.includes(key) && value.includes('://')) ? 'smtp' : undefined;
+};
+
+/**
+ * Explains why a parsed Sentry DSN is malformed
+ */
+const sentryProblem = (url: URL): string | undefined => {
+  // Guard clause: Sentry only takes events over HTTP
+  if (!['http:', 'https:'].includes(url.protocol)) {
+    return `scheme ${url.protocol} is not http or https`;
+  }
+
+  // Guard clause: the public key authenticates events
+  if (!SENTRY_PUBLIC_KEY.test(url.username)) {
+    return 'the public key is missing or malformed';
+  }
+
+  return /^\d+$/.test(url.pathname.replace(/\/+$/, '').split('/').pop() ?? '') ? undefined : 'the project id is missing';
+};
+
+/**
+ * Checks a Sentry DSN: `https://<public key>@<host>/<project id>`
+ */
+const sentryDsn = (keyPath: string, value: string): Dsn => {
+  const url = parseUrl(value);
+  const reason = url === undefined ? 'it is not a URL' : sentryProblem(url);
+
+  // Guard clause: malformed DSN
+  if (url === undefined || reason !== undefined) {
+    return { kind: 'sentry', keyPath, reason };
+  }
+
+  const destination = `${url.host}${url.pathname.replace(/\/+$/, '')}`;
+  return { kind: 'sentry', keyPath, destination, identity: destination };
+};
+
+/**
+ * Checks an SMTP URL: `smtp[s]://[user[:password]@]host[:port]`
+ */
+const smtpUrl = (keyPath: string, value: string): Dsn => {
+  const url = parseUrl(value);
+
+  // Guard clause: malformed URL
+  if (url === undefined || url.hostname === '') {
+    return { kind: 'smtp', keyPath, reason: url === undefined ? 'it is not a URL' : 'the host is missing' };
+  }
+
+  const destination = url.port === '' ? url.hostname : `${url.hostname}:${url.port}`;
+  // Two environments may share a relay with their own accounts
+  return { kind: 'smtp', keyPath, destination, identity: `${url.username}@${destination}` };
+};
+
+/**
+ * Lists the Sentry DSNs and SMTP URLs of a parsed file, checked for syntax
+ * @param content - Parsed file
+ * @returns DSNs with their destination, or the reason they are malformed; interpolated values are skipped
+ */
+export const dsnsOf = (content: unknown): Dsn[] =>
+  leaves(content).flatMap(({ keyPath, value }) => {
+    // Guard clause: DSNs are non-empty literal strings
+    if (typeof value !== 'string' || value.trim() === '' || INTERPOLATION.test(value)) {
+      return [];
+    }
+
+    const kind = dsnKindOf(keyPath, value.trim());
+    return kind === undefined ? [] : [kind === 'sentry' ? sentryDsn(keyPath, value.trim()) : smtpUrl(keyPath, value.trim())];
+  });
+
+type DsnCode = 'DSN_INVALID' | 'DSN_SHARED_WITH_PRODUCTION';
+
+const dsnFinding = (
+  code: DsnCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: DSN_RULE_ID },
+    extras,
+  },
+});
+
+const KIND_NAMES: Record<DsnKind, string> = { sentry: 'Sentry DSN', smtp: 'SMTP URL' };
+
+/**
+ * Checks the Sentry DSNs and SMTP URLs of the given files; values never appear in findings, as they hold credentials
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about malformed DSNs, and DSNs of other environments that reuse a production destination
+ */
+export const checkDsns = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+  const located = files.map(file => {
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+    const group = environment !== undefined ? tokenEnvironment(environment, tokens) : undefined;
+    return { file, environment, group, dsns: dsnsOf(file.content) };
+  });
+  const production = located
+    .filter(({ group }) => group === 'prod')
+    .flatMap(({ dsns }) => dsns.flatMap(dsn => (dsn.identity !== undefined ? [`${dsn.kind}:${dsn.identity}`] : [])));
+
+  return located.flatMap(({ file, environment, group, dsns }) => dsns.flatMap(dsn => {
+    // Guard clause: a malformed DSN has no destination to compare
+    if (dsn.reason !== undefined) {
+      return [dsnFinding(
+        'DSN_INVALID',
+        `${KIND_NAMES[dsn.kind]} at '${dsn.keyPath}' is malformed: ${dsn.reason} (${file.path})`,
+        file,
+        dsn.keyPath,
+        { kind: dsn.kind, reason: dsn.reason },
+        environment
+      )];
+    }
+
+    const shared = group !== undefined && group !== 'prod' && production.includes(`${dsn.kind}:${dsn.identity}`);
+
+    return shared ? [dsnFinding(
+      'DSN_SHARED_WITH_PRODUCTION',
+      `${KIND_NAMES[dsn.kind]} at '${dsn.keyPath}' sends ${environment} data to the production destination ${dsn.destination} (${file.path})`,
+      file,
+      dsn.keyPath,
+      { kind: dsn.kind, destination: dsn.destination },
+      environment
+    )] : [];
+  }));
+};
+
+/**
+ * Adds DSN findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when a DSN is malformed or shared with production
+ */
+export const withDsnFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkDsns(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
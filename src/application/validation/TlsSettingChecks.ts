@@ -0,0 +1,182 @@
+/**
+ * @file src/application/validation/TlsSettingChecks.ts
+ * @description Pure functions checking that production files keep the well-known TLS/SSL switches of
+ * databases, brokers, clients and runtimes on their secure values
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { inferEnvironment, leakageTokens, mentionsToken } from './EnvironmentLeakage';
+
+/**
+ * @constant TLS_SETTING_RULE_ID
+ * @description Rule id of the TLS/SSL setting checks, usable in `scopes:`
+ */
+export const TLS_SETTING_RULE_ID = 'tls-settings';
+
+const TRUE_VALUES = ['true', 'yes', 'on', '1'];
+const FALSE_VALUES = ['false', 'no', 'off', '0'];
+
+/**
+ * @interface TlsSetting
+ * @description A well-known TLS/SSL setting and the values that leave a connection unprotected
+ */
+export interface TlsSetting {
+  /** Key names, lower case without separators, matched against the last segments of a key path (`ssl.mode` is `sslmode`) */
+  keys: string[];
+  /** Whether the setting turns encryption on or controls certificate verification */
+  kind: 'encryption' | 'verification';
+  /** Reported values, lower case; any other value (a CA path, a variable) is left alone */
+  insecure: string[];
+  /** Secure values, for messages */
+  expected: string;
+}
+
+/**
+ * @constant TLS_SETTINGS
+ * @description Curated mapping of TLS/SSL settings across ecosystems
+ */
+export const TLS_SETTINGS: TlsSetting[] = [
+  // Postgres sslmode (also PGSSLMODE) and MySQL ssl-mode
+  { keys: ['sslmode', 'pgsslmode'], kind: 'encryption', insecure: ['disable', 'disabled', 'allow', 'prefer', 'preferred'], expected: 'require, verify-ca or verify-full' },
+  // Kafka clients and brokers
+  { keys: ['securityprotocol'], kind: 'encryption', insecure: ['plaintext', 'sasl_plaintext'], expected: 'SSL or SASL_SSL' },
+  // Keycloak realms
+  { keys: ['sslrequired'], kind: 'encryption', insecure: ['none'], expected: 'external or all' },
+  // Redis, MongoDB, JDBC, Elasticsearch, Spring
+  {
+    keys: ['ssl', 'tls', 'sslenabled', 'tlsenabled', 'enablessl', 'enabletls', 'usessl', 'usetls', 'requiressl', 'requiretls'],
+    kind: 'encryption',
+    insecure: FALSE_VALUES,
+    expected: 'true',
+  },
+  // `ssl.verify`, Elasticsearch verify_certs, Node rejectUnauthorized
+  {
+    keys: ['sslverify', 'verifyssl', 'tlsverify', 'verifytls', 'verifycerts', 'verifycertificate', 'verifycertificates', 'verifyhostname', 'rejectunauthorized'],
+    kind: 'verification',
+    insecure: FALSE_VALUES,
+    expected: 'true',
+  },
+  // Go and Kubernetes clients, MongoDB, SQL Server
+  {
+    keys: [
+      'insecureskipverify', 'insecureskiptlsverify', 'skiptlsverify', 'skipsslverify', 'tlsskipverify', 'skipverify',
+      'tlsinsecure', 'sslinsecure', 'trustservercertificate', 'tlsallowinvalidcertificates', 'tlsallowinvalidhostnames',
+    ],
+    kind: 'verification',
+    insecure: TRUE_VALUES,
+    expected: 'false',
+  },
+  // Kafka hostname verification is off when the algorithm is empty
+  { keys: ['sslendpointidentificationalgorithm'], kind: 'verification', insecure: ['', 'none'], expected: 'https' },
+  { keys: ['nodetlsrejectunauthorized'], kind: 'verification', insecure: ['0', 'false'], expected: '1' },
+];
+
+// Connection strings: `postgres://db/app?sslmode=disable`, `mongodb://db/?tls=false`
+const URL_PARAMETER = /[?&;]([A-Za-z_.-]+)=([^&;#]*)/g;
+
+const normalize = (key: string): string => key.toLowerCase().replace(/[^a-z0-9]/g, '');
+
+const leaves = (value: unknown, keyPath: string = ''): Array<{ keyPath: string; value: unknown }> =>
+  isPlainObject(value)
+    ? Object.entries(value).flatMap(([key, child]) => leaves(child, joinKeyPath(keyPath, key)))
+    : [{ keyPath, value }];
+
+/**
+ * Finds the TLS setting a key names, trying its last segment, then its last two, and so on
+ * @param keyPath - Dotted key path or connection string parameter
+ * @returns The setting and the key as written, if any
+ */
+export const tlsSettingOf = (keyPath: string): { setting: TlsSetting; key: string } | undefined => {
+  const segments = keyPath.split('.');
+
+  return segments
+    .map((_, index) => segments.slice(segments.length - 1 - index).join('.'))
+    .flatMap(key => {
+      const setting = TLS_SETTINGS.find(entry => entry.keys.includes(normalize(key)));
+      return setting ? [{ setting, key }] : [];
+    })[0];
+};
+
+type TlsSettingCode = 'TLS_ENCRYPTION_DISABLED' | 'TLS_VERIFICATION_DISABLED';
+
+const tlsSettingFinding = (
+  code: TlsSettingCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  environment?: string
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    ...(environment !== undefined ? { environment } : {}),
+    keyPath,
+    rule: { id: TLS_SETTING_RULE_ID },
+    extras,
+  },
+});
+
+/**
+ * Lists the settings of a file with their values: plain keys, and the parameters of connection strings
+ */
+const settingsOf = (file: ConfigFile): Array<{ keyPath: string; key: string; value: unknown; setting: TlsSetting }> =>
+  leaves(file.content)
+    // Terraform provider blocks are audited by the hcl-policies rule
+    .filter(({ keyPath }) => !(file.format === 'hcl' && keyPath.startsWith('provider.')))
+    .flatMap(({ keyPath, value }) => {
+      const parameters = typeof value === 'string' && value.includes('://')
+        ? [...value.matchAll(URL_PARAMETER)].map(([, key, parameterValue]) => ({ name: key, value: parameterValue }))
+        : [];
+
+      return [{ name: keyPath, value }, ...parameters].flatMap(({ name, value: settingValue }) => {
+        const match = tlsSettingOf(name);
+        return match ? [{ keyPath, key: match.key, value: settingValue, setting: match.setting }] : [];
+      });
+    });
+
+/**
+ * Checks the TLS/SSL settings of the production files among the given files
+ * @param files - Files with their environment (inferred from the file name when unknown)
+ * @returns Findings about settings that turn encryption or certificate verification off in production
+ */
+export const checkTlsSettings = (files: ConfigFile[]): ValidationError[] => {
+  const tokens = leakageTokens();
+
+  return files.flatMap(file => {
+    const environment = file.environment ?? inferEnvironment(file.path, tokens);
+
+    // Guard clause: only production files are held to secure values
+    if (environment === undefined || !tokens.prod.some(token => mentionsToken(environment, token))) {
+      return [];
+    }
+
+    return settingsOf(file)
+      // An empty value (`ssl.endpoint.identification.algorithm=`) parses as null in YAML
+      .filter(({ value, setting }) => setting.insecure.includes(String(value ?? '').trim().toLowerCase()))
+      .map(({ keyPath, key, value, setting }) => tlsSettingFinding(
+        setting.kind === 'encryption' ? 'TLS_ENCRYPTION_DISABLED' : 'TLS_VERIFICATION_DISABLED',
+        `'${key}' is '${value ?? ''}' at '${keyPath}' in production; expected ${setting.expected} (${file.path})`,
+        file,
+        keyPath,
+        { setting: key, value, expected: setting.expected },
+        environment
+      ));
+  });
+};
+
+/**
+ * Adds TLS/SSL setting findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the checks run on
+ * @returns Result that fails when production turns TLS or certificate verification off
+ */
+export const withTlsSettingFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkTlsSettings(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -4,7 +4,9 @@
  */
 
 import { PraetorianRule } from '../../shared/types/rules';
-import { ValidationResult, ValidationError, ValidationWarning } from '../../shared/types';
+import { RuleScope, ValidationResult, ValidationError, ValidationWarning } from '../../shared/types';
+import { isInScope } from './RuleScoping';
+import { isFormatType, matchesValueType } from '../../shared/utils/ValueTypes';
 
 /**
  * @interface ValidationInput
@@ -15,10 +17,13 @@ export interface ValidationInput {
   data: any;
   /** Rules to apply */
   rules: PraetorianRule[];
+  /** Files and groups each rule runs on (see `scopes:` in praetorian.yaml) */
+  scopes?: RuleScope[];
   /** Context information */
   context?: {
     filePath?: string;
     environment?: string;
+    group?: string;
     [key: string]: any;
   };
 }
@@ -66,10 +71,13 @@ export const validate = (input: ValidationInput): ValidationOutput => {
   }
 
   const startTime = Date.now();
+  const target = { file: input.context?.filePath, group: input.context?.group };
+  const applicableRules = input.rules
+    .filter(rule => rule.enabled)
+    .filter(rule => isInScope(input.scopes ?? [], rule.id, target));
   
   // Apply all rules to the data
-  const ruleResults = input.rules
-    .filter(rule => rule.enabled)
+  const ruleResults = applicableRules
     .map(rule => validateRule(rule, input.data, input.context));
 
   // Collect all results
@@ -83,7 +91,7 @@ export const validate = (input: ValidationInput): ValidationOutput => {
     errors: allErrors,
     warnings: allWarnings,
     metadata: {
-      rulesApplied: input.rules.filter(rule => rule.enabled).length,
+      rulesApplied: applicableRules.length,
       rulesPassed: passedRules,
       rulesFailed: failedRules,
       duration: Date.now() - startTime,
@@ -540,7 +548,8 @@ export const validateFormat = (value: any, format: string): boolean => {
     case 'string':
       return typeof value === 'string';
     default:
-      return true; // Unknown format, assume valid
+      // Built-in value types (ipv4, cidr, hostname, duration, port, ...); other unknown formats are assumed valid
+      return isFormatType(format) ? matchesValueType(format, value) : true;
   }
 };
 
@@ -0,0 +1,103 @@
+/**
+ * @file src/application/validation/ValueDifferences.ts
+ * @description Pure functions comparing the values of the same key across files (e.g. `api.timeout`
+ * in staging and production), for the keys of `value_differences:` that must not drift apart
+ */
+
+import { ConfigFile, KeyDifference, ValidationError, ValidationResult, ValueDifferenceRule } from '../../shared/types';
+import { canonicalJson } from '../../shared/utils/CanonicalHash';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { extractKeyValues, isPlainObject } from '../../shared/utils/KeyPaths';
+import { quantityOf } from '../../shared/utils/Quantities';
+import { ownsKey } from './Ownership';
+
+/**
+ * @constant VALUE_DIFFERENCE_RULE_ID
+ * @description Rule id of the value comparison, the name of the `value-differences` audit type usable in `scopes:`
+ */
+export const VALUE_DIFFERENCE_RULE_ID = 'value-differences';
+
+/**
+ * Reads a value the way it is compared: numbers, and durations and sizes written with a unit,
+ * by amount (`30s`, `30000ms` and `30000` are the same); anything else by its canonical JSON, so
+ * strings such as versions and tags (`"1.10"` and `"1.1"`, `"007"` and `"7"`) stay distinct
+ */
+const comparableValue = (value: unknown): string => {
+  const amount = typeof value === 'number' ? value : quantityOf(value)?.amount;
+  return amount !== undefined ? `#${amount}` : canonicalJson(value);
+};
+
+/**
+ * Finds the keys whose value is not the same in every file setting them
+ * @param files - Compared files
+ * @param rules - `value_differences:` entries; the first one owning a key gives its severity
+ * @returns One difference per key owned by a rule, set (as a value, not a section) in at least two
+ * files with at least two distinct values, in the order keys first appear
+ */
+export const findKeyDifferences = (files: ConfigFile[], rules: ValueDifferenceRule[]): KeyDifference[] => {
+  const valuesByFile = files.map(file => ({ file, values: extractKeyValues(file.content) }));
+  const keys = [...new Set(valuesByFile.flatMap(({ values }) => [...values.keys()]))];
+
+  return keys.flatMap((keyPath): KeyDifference[] => {
+    const rule = rules.find(candidate => candidate.keys.some(pattern => ownsKey(keyPath, pattern)));
+    const values = valuesByFile
+      .filter(({ values }) => values.has(keyPath) && !isPlainObject(values.get(keyPath)))
+      .map(({ file, values }) => ({
+        file: file.path,
+        ...(file.environment !== undefined ? { environment: file.environment } : {}),
+        value: values.get(keyPath),
+      }));
+
+    // Guard clause: not compared, or nothing to compare against
+    if (!rule || values.length < 2) {
+      return [];
+    }
+
+    return new Set(values.map(entry => comparableValue(entry.value))).size > 1
+      ? [{ keyPath, severity: rule.severity, values }]
+      : [];
+  });
+};
+
+/**
+ * Turns a difference into a finding; the values go to `observedValue` so the redaction policy hides them
+ */
+export const keyDifferenceFinding = (difference: KeyDifference): ValidationError => {
+  const files = difference.values.map(entry => entry.file);
+  const distinct = new Set(difference.values.map(entry => comparableValue(entry.value))).size;
+
+  return {
+    code: 'KEY_DIFFERENCE',
+    message: `'${difference.keyPath}' has ${distinct} different values across ${files.join(', ')}`,
+    severity: difference.severity,
+    path: difference.keyPath,
+    context: {
+      files,
+      keyPath: difference.keyPath,
+      observedValue: Object.fromEntries(difference.values.map(entry => [entry.file, entry.value])),
+      rule: { id: VALUE_DIFFERENCE_RULE_ID },
+      extras: { distinct },
+    },
+  };
+};
+
+/**
+ * Adds the value differences of the compared files to a result
+ * @param result - Validation result
+ * @param files - Compared files
+ * @param rules - `value_differences:` entries; none means no comparison
+ * @returns The result with one finding per differing key
+ */
+export const withValueDifferences = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  rules: ValueDifferenceRule[] = []
+): ValidationResult => {
+  // Guard clause: no key to compare
+  if (rules.length === 0) {
+    return result;
+  }
+
+  const findings = findKeyDifferences(files, rules).map(keyDifferenceFinding);
+  return findings.length === 0 ? result : withFindings(result, [...collectFindings(result), ...findings]);
+};
@@ -0,0 +1,160 @@
+/**
+ * @file src/application/validation/ValueRules.ts
+ * @description Pure functions checking values against the constraints of `rules:` (type, regex,
+ * enum, min/max) declared per key pattern, reporting the key path and, when known, its line
+ */
+
+import { ConfigFile, KeyLocation, ValidationError, ValidationResult, ValueConstraint } from '../../shared/types';
+import { canonicalJson } from '../../shared/utils/CanonicalHash';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, resolveKeyPattern } from '../../shared/utils/KeyPaths';
+import { normalizeQuantity } from '../../shared/utils/Quantities';
+import { matchesValueType } from '../../shared/utils/ValueTypes';
+
+/**
+ * @constant VALUE_RULE_ID
+ * @description Rule id of the `rules:` constraints, the name of the `schema-rules` audit type usable in `scopes:`
+ */
+export const VALUE_RULE_ID = 'schema-rules';
+
+/**
+ * Finds where the keys of a file are written
+ */
+export type KeyLocator = (file: ConfigFile) => Map<string, KeyLocation>;
+
+interface Violation {
+  code: string;
+  message: string;
+  expectedValue: unknown;
+  extras: Record<string, unknown>;
+}
+
+const shown = (value: unknown): string => (typeof value === 'string' ? value : JSON.stringify(value));
+
+/**
+ * Checks one value against its constraint
+ * @returns The first violation: a wrong type hides the other checks, which would only repeat it
+ */
+const violationOf = (keyPath: string, value: unknown, constraint: ValueConstraint): Violation | undefined => {
+  if (constraint.type !== undefined && !matchesValueType(constraint.type, value)) {
+    return {
+      code: 'RULE_TYPE_MISMATCH',
+      message: `Value of '${keyPath}' is not a valid ${constraint.type}`,
+      expectedValue: constraint.type,
+      extras: { type: constraint.type },
+    };
+  }
+
+  if (constraint.enum !== undefined && !constraint.enum.some(allowed => canonicalJson(allowed) === canonicalJson(value))) {
+    return {
+      code: 'RULE_VALUE_NOT_ALLOWED',
+      message: `Value of '${keyPath}' is not one of ${constraint.enum.map(shown).join(', ')}`,
+      expectedValue: constraint.enum,
+      extras: { allowed: constraint.enum.map(shown) },
+    };
+  }
+
+  // Sections and lists have no text to match
+  if (constraint.regex !== undefined && !isPlainObject(value) && !Array.isArray(value) && !new RegExp(constraint.regex).test(String(value))) {
+    return {
+      code: 'RULE_PATTERN_MISMATCH',
+      message: `Value of '${keyPath}' does not match /${constraint.regex}/`,
+      expectedValue: constraint.regex,
+      extras: { regex: constraint.regex },
+    };
+  }
+
+  // Values that are not amounts are left to `type`
+  const amount = normalizeQuantity(value);
+  if (amount === undefined) {
+    return undefined;
+  }
+
+  const min = normalizeQuantity(constraint.min);
+  if (min !== undefined && amount < min) {
+    return {
+      code: 'RULE_VALUE_TOO_LOW',
+      message: `Value of '${keyPath}' is ${shown(value)}, below the minimum of ${constraint.min}`,
+      expectedValue: constraint.min,
+      extras: { value: shown(value), bound: constraint.min },
+    };
+  }
+
+  const max = normalizeQuantity(constraint.max);
+  return max !== undefined && amount > max ? {
+    code: 'RULE_VALUE_TOO_HIGH',
+    message: `Value of '${keyPath}' is ${shown(value)}, above the maximum of ${constraint.max}`,
+    expectedValue: constraint.max,
+    extras: { value: shown(value), bound: constraint.max },
+  } : undefined;
+};
+
+/**
+ * Checks the values of files against `rules:`
+ * @param files - Loaded files
+ * @param rules - Key pattern (`*` matches one segment) -> constraints
+ * @param locate - Finds where the keys of a file are written; findings of unlocated keys carry no line
+ * @returns One finding per value breaking its constraints; absent keys are left to `required_keys`
+ */
+export const checkValueRules = (
+  files: ConfigFile[],
+  rules: Record<string, ValueConstraint>,
+  locate: KeyLocator = () => new Map()
+): ValidationError[] =>
+  files.flatMap(file => {
+    const locations = locate(file);
+
+    return Object.entries(rules).flatMap(([pattern, constraint]) =>
+      resolveKeyPattern(file.content, pattern)
+        .filter(({ value }) => value !== undefined && value !== null)
+        .flatMap(({ path, value }): ValidationError[] => {
+          const violation = violationOf(path, value, constraint);
+
+          // Guard clause: value within its constraints
+          if (!violation) {
+            return [];
+          }
+
+          const location = locations.get(path);
+          const where = location ? `${file.path}:${location.line}` : file.path;
+          return [{
+            code: violation.code,
+            message: `${violation.message} (${where})`,
+            severity: constraint.severity ?? 'error',
+            path,
+            context: {
+              file: file.path,
+              keyPath: path,
+              observedValue: value,
+              expectedValue: violation.expectedValue,
+              ...(location ? { line: location.line, column: location.column } : {}),
+              rule: { id: VALUE_RULE_ID },
+              extras: { ...violation.extras, pattern, location: where },
+            },
+          }];
+        })
+    );
+  });
+
+/**
+ * Adds the `rules:` findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the constraints apply to
+ * @param rules - Declared constraints
+ * @param locate - Finds where the keys of a file are written
+ * @returns Result that fails when a value breaks a constraint of severity error
+ */
+export const withValueRules = (
+  result: ValidationResult,
+  files: ConfigFile[],
+  rules: Record<string, ValueConstraint>,
+  locate?: KeyLocator
+): ValidationResult => {
+  // Guard clause: no constraints declared
+  if (Object.keys(rules).length === 0) {
+    return result;
+  }
+
+  const findings = checkValueRules(files, rules, locate);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
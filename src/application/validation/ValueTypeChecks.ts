@@ -0,0 +1,63 @@
+/**
+ * @file src/application/validation/ValueTypeChecks.ts
+ * @description Pure functions checking values against the types declared in `schema:`
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { resolveKeyPattern } from '../../shared/utils/KeyPaths';
+import { matchesValueType } from '../../shared/utils/ValueTypes';
+
+/**
+ * @constant VALUE_TYPE_RULE_ID
+ * @description Rule id of the `schema:` type check, usable in `scopes:`
+ */
+export const VALUE_TYPE_RULE_ID = 'value-types';
+
+/**
+ * Lists every value whose type does not match `schema:`
+ * @param schema - Key pattern (`*` matches one segment) -> value type name
+ * @param files - Loaded files
+ * @returns One error per mismatching value; absent keys are left to `required_keys`
+ */
+export const checkValueTypes = (schema: Record<string, string>, files: ConfigFile[]): ValidationError[] =>
+  files.flatMap(file =>
+    Object.entries(schema).flatMap(([pattern, type]) =>
+      resolveKeyPattern(file.content, pattern)
+        .filter(({ value }) => value !== undefined && value !== null && !matchesValueType(type, value))
+        .map(({ path, value }) => ({
+          code: 'INVALID_VALUE_TYPE',
+          message: `Value of '${path}' in ${file.path} is not a valid ${type}`,
+          severity: 'error' as const,
+          path,
+          context: {
+            file: file.path,
+            keyPath: path,
+            observedValue: value,
+            rule: { id: VALUE_TYPE_RULE_ID },
+            extras: { type },
+          },
+        }))
+    )
+  );
+
+/**
+ * Adds value type findings to a result
+ * @param result - Result of the other rules
+ * @param schema - Declared value types
+ * @param files - Files the type check runs on
+ * @returns Result that fails when any value has the wrong type
+ */
+export const withValueTypes = (
+  result: ValidationResult,
+  schema: Record<string, string>,
+  files: ConfigFile[]
+): ValidationResult => {
+  // Guard clause: no types declared
+  if (Object.keys(schema).length === 0) {
+    return result;
+  }
+
+  const findings = checkValueTypes(schema, files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -0,0 +1,232 @@
+/**
+ * @file src/application/validation/WorkflowChecks.ts
+ * @description Pure functions auditing GitHub Actions workflows: env/with/secrets usage, permissions and action pins
+ */
+
+import { ConfigFile, ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings, withFindings } from '../../shared/utils/Findings';
+import { isPlainObject, joinKeyPath } from '../../shared/utils/KeyPaths';
+import { isSecretKey } from '../../shared/utils/Redaction';
+
+/**
+ * @constant WORKFLOW_RULE_ID
+ * @description Rule id of the workflow checks, usable in `scopes:`
+ */
+export const WORKFLOW_RULE_ID = 'workflows';
+
+/**
+ * @constant WORKFLOW_GLOBS
+ * @description Where GitHub looks for workflows, relative to the repository root
+ */
+export const WORKFLOW_GLOBS = ['.github/workflows/*.yml', '.github/workflows/*.yaml'];
+
+const EXPRESSION = /\$\{\{[^}]*\}\}/;
+const COMMIT_SHA = /^[0-9a-f]{40}$/;
+
+/**
+ * @interface WorkflowSetting
+ * @description One `env:`, `with:` or `secrets:` entry of a workflow
+ */
+export interface WorkflowSetting {
+  keyPath: string;
+  section: 'env' | 'with' | 'secrets';
+  key: string;
+  value: unknown;
+  job?: string;
+  step?: string;
+}
+
+/**
+ * Checks whether parsed content is a GitHub Actions workflow
+ * @param content - Parsed file
+ * @returns true when the content has an `on` trigger and a `jobs` mapping
+ */
+export const isWorkflow = (content: unknown): content is Record<string, any> =>
+  isPlainObject(content) && content.on !== undefined && isPlainObject(content.jobs);
+
+const jobsOf = (workflow: Record<string, any>): Array<[string, Record<string, any>]> =>
+  Object.entries(workflow.jobs).filter((entry): entry is [string, Record<string, any>] => isPlainObject(entry[1]));
+
+const stepsOf = (job: Record<string, any>): Array<{ index: number; label: string; step: Record<string, any> }> =>
+  (Array.isArray(job.steps) ? job.steps : [])
+    .map((step: unknown, index: number) => ({ index, step }))
+    .filter((entry: { step: unknown }): entry is { index: number; step: Record<string, any> } => isPlainObject(entry.step))
+    .map((entry: { index: number; step: Record<string, any> }) => ({
+      ...entry,
+      label: String(entry.step.name ?? entry.step.id ?? `step ${entry.index + 1}`),
+    }));
+
+const sectionSettings = (
+  section: WorkflowSetting['section'],
+  values: unknown,
+  keyPath: string,
+  location: { job?: string; step?: string }
+): WorkflowSetting[] =>
+  isPlainObject(values)
+    ? Object.entries(values).map(([key, value]) => ({ keyPath: joinKeyPath(keyPath, key), section, key, value, ...location }))
+    : [];
+
+/**
+ * Lists the `env:`, `with:` and `secrets:` entries of a workflow, at workflow, job,
+ * container, service and step level
+ * @param workflow - Parsed workflow
+ * @returns Every entry with its key path and the job and step it belongs to
+ */
+export const workflowSettings = (workflow: Record<string, any>): WorkflowSetting[] => [
+  ...sectionSettings('env', workflow.env, 'env', {}),
+  ...jobsOf(workflow).flatMap(([job, definition]) => {
+    const jobPath = `jobs.${job}`;
+    const services = isPlainObject(definition.services) ? Object.entries(definition.services) : [];
+
+    return [
+      ...sectionSettings('env', definition.env, `${jobPath}.env`, { job }),
+      ...sectionSettings('env', definition.container?.env, `${jobPath}.container.env`, { job }),
+      ...services.flatMap(([name, service]) =>
+        sectionSettings('env', (service as any)?.env, `${jobPath}.services.${name}.env`, { job })
+      ),
+      // A job calling a reusable workflow passes inputs and secrets directly
+      ...sectionSettings('with', definition.with, `${jobPath}.with`, { job }),
+      ...sectionSettings('secrets', definition.secrets, `${jobPath}.secrets`, { job }),
+      ...stepsOf(definition).flatMap(({ index, label, step }) => [
+        ...sectionSettings('env', step.env, `${jobPath}.steps.${index}.env`, { job, step: label }),
+        ...sectionSettings('with', step.with, `${jobPath}.steps.${index}.with`, { job, step: label }),
+      ]),
+    ];
+  }),
+];
+
+/**
+ * Checks whether a setting holds a literal value instead of an expression
+ */
+const isPlaintext = (value: unknown): boolean =>
+  (typeof value === 'string' && value.trim() !== '' && !EXPRESSION.test(value)) || typeof value === 'number';
+
+/**
+ * Checks whether an action or reusable workflow reference is pinned to a full commit SHA
+ * @param uses - `uses:` value, e.g. `actions/checkout@v4` or `./.github/actions/build`
+ * @returns true for local actions, commit SHAs and docker images pinned to a digest
+ */
+export const isActionPinned = (uses: string): boolean => {
+  // Guard clause: local actions live in the same commit as the workflow
+  if (uses.startsWith('./')) {
+    return true;
+  }
+
+  if (uses.startsWith('docker://')) {
+    return uses.includes('@sha256:');
+  }
+
+  const ref = uses.includes('@') ? uses.slice(uses.lastIndexOf('@') + 1) : '';
+  return COMMIT_SHA.test(ref);
+};
+
+type WorkflowCode = 'WORKFLOW_PLAINTEXT_SECRET' | 'WORKFLOW_PERMISSIONS_MISSING' | 'WORKFLOW_ACTION_NOT_PINNED';
+
+const workflowFinding = (
+  code: WorkflowCode,
+  message: string,
+  file: ConfigFile,
+  keyPath: string,
+  extras: Record<string, unknown>,
+  observedValue?: unknown
+): ValidationError => ({
+  code,
+  message,
+  severity: 'error',
+  path: keyPath,
+  context: {
+    file: file.path,
+    keyPath,
+    ...(observedValue !== undefined ? { observedValue } : {}),
+    rule: { id: WORKFLOW_RULE_ID },
+    extras,
+  },
+});
+
+const locationOf = (setting: { job?: string; step?: string }): string =>
+  setting.step ? `step '${setting.step}' of job '${setting.job}'` : setting.job ? `job '${setting.job}'` : 'the workflow';
+
+/**
+ * Finds secrets written in plain text; values are never part of the message
+ */
+const plaintextSecretFindings = (file: ConfigFile, workflow: Record<string, any>): ValidationError[] =>
+  workflowSettings(workflow)
+    // Everything passed as `secrets:` is a secret; `secrets: inherit` is not a mapping and never listed
+    .filter(setting => (setting.section === 'secrets' || isSecretKey(setting.key)) && isPlaintext(setting.value))
+    .map(setting => workflowFinding(
+      'WORKFLOW_PLAINTEXT_SECRET',
+      `'${setting.key}' in ${setting.section} of ${locationOf(setting)} is written in plain text; use \${{ secrets.${setting.key} }} (${file.path})`,
+      file,
+      setting.keyPath,
+      {
+        variable: setting.key,
+        section: setting.section,
+        ...(setting.job ? { job: setting.job } : {}),
+        ...(setting.step ? { step: setting.step } : {}),
+      },
+      setting.value
+    ));
+
+/**
+ * Finds jobs that run with the default token permissions
+ */
+const permissionFindings = (file: ConfigFile, workflow: Record<string, any>): ValidationError[] => {
+  // Guard clause: a top-level block covers every job
+  if (workflow.permissions !== undefined) {
+    return [];
+  }
+
+  return jobsOf(workflow)
+    .filter(([, definition]) => definition.permissions === undefined)
+    .map(([job]) => workflowFinding(
+      'WORKFLOW_PERMISSIONS_MISSING',
+      `Job '${job}' has no permissions block and neither has the workflow; the token gets the repository defaults (${file.path})`,
+      file,
+      `jobs.${job}.permissions`,
+      { job }
+    ));
+};
+
+/**
+ * Finds actions and reusable workflows referenced by a movable tag or branch
+ */
+const pinFindings = (file: ConfigFile, workflow: Record<string, any>): ValidationError[] =>
+  jobsOf(workflow).flatMap(([job, definition]) => [
+    ...(typeof definition.uses === 'string' ? [{ keyPath: `jobs.${job}.uses`, uses: definition.uses, step: undefined }] : []),
+    ...stepsOf(definition)
+      .filter(({ step }) => typeof step.uses === 'string')
+      .map(({ index, label, step }) => ({ keyPath: `jobs.${job}.steps.${index}.uses`, uses: step.uses as string, step: label })),
+  ]
+    .filter(({ uses }) => !isActionPinned(uses))
+    .map(({ keyPath, uses, step }) => workflowFinding(
+      'WORKFLOW_ACTION_NOT_PINNED',
+      `'${uses}' in ${locationOf({ job, step })} is not pinned to a full commit SHA (${file.path})`,
+      file,
+      keyPath,
+      { job, uses, ...(step ? { step } : {}) }
+    )));
+
+/**
+ * Audits every GitHub Actions workflow among the given files
+ * @param files - Parsed files; files that are not workflows are skipped
+ * @returns Findings about plaintext secrets, missing permissions blocks and unpinned actions
+ */
+export const checkWorkflows = (files: ConfigFile[]): ValidationError[] =>
+  files
+    .filter(file => isWorkflow(file.content))
+    .flatMap(file => [
+      ...plaintextSecretFindings(file, file.content),
+      ...permissionFindings(file, file.content),
+      ...pinFindings(file, file.content),
+    ]);
+
+/**
+ * Adds workflow findings to a result
+ * @param result - Result of the other rules
+ * @param files - Files the workflow checks run on
+ * @returns Result that fails when a workflow breaks a rule
+ */
+export const withWorkflowFindings = (result: ValidationResult, files: ConfigFile[]): ValidationResult => {
+  const findings = checkWorkflows(files);
+  return findings.length === 0 ? result : withFindings(result, [...findings, ...collectFindings(result)]);
+};
@@ -6,6 +6,7 @@
  */
 
 import { JsonSchema, SchemaValidationError } from '../../shared/types';
+import { isFormatType, matchesValueType } from '../../shared/utils/ValueTypes';
 
 /**
  * Pure function registry for format validators
@@ -41,6 +42,13 @@ export const validateFormat = (value: any, schema: JsonSchema, path: string): Sc
     return [];
   }
 
+  // Built-in value types take precedence (ipv6 accepts compressed addresses, cidr, duration, port, ...)
+  if (isFormatType(schema.format)) {
+    return !matchesValueType(schema.format, value)
+      ? [createInvalidFormatError(path, value, schema.format)]
+      : [];
+  }
+
   const validator = formatValidators[schema.format];
   if (!validator) {
     return [createUnsupportedFormatError(path, schema.format)];
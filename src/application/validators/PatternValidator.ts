@@ -140,7 +140,7 @@ const createFailedResult = (
     message,
     severity: rule.severity,
     path: path || '',
-    context: { pattern: rule.pattern, value }
+    context: { observedValue: value, expectedValue: rule.pattern, rule: { id: rule.id } }
   },
   testedValue: value,
   path
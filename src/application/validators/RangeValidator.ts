@@ -6,6 +6,7 @@
  */
 
 import { JsonSchema, SchemaValidationError } from '../../shared/types';
+import { normalizeQuantity, quantityOf } from '../../shared/utils/Quantities';
 
 /**
  * Pure function to validate string length
@@ -41,19 +42,22 @@ export const validateNumberRange = (value: any, schema: JsonSchema, path: string
     return [];
   }
 
-  // Guard clause: not a number
-  if (typeof value !== 'number') {
+  // Guard clause: no range constraints
+  if (schema.minimum === undefined && schema.maximum === undefined) {
     return [];
   }
 
-  // Guard clause: no range constraints
-  if (schema.minimum === undefined && schema.maximum === undefined) {
+  // Durations and sizes are compared in milliseconds and bytes ("30s" is 30000)
+  const quantity = typeof value === 'number' ? value : quantityOf(value)?.amount;
+
+  // Guard clause: not a number or quantity
+  if (quantity === undefined) {
     return [];
   }
 
   return [
-    ...validateMinimum(value, schema, path),
-    ...validateMaximum(value, schema, path)
+    ...validateMinimum(quantity, schema, path),
+    ...validateMaximum(quantity, schema, path)
   ];
 };
 
@@ -79,8 +83,9 @@ const validateMaxLength = (value: string, schema: JsonSchema, path: string): Sch
  * Pure function to validate minimum number value
  */
 const validateMinimum = (value: number, schema: JsonSchema, path: string): SchemaValidationError[] => {
-  return schema.minimum !== undefined && value < schema.minimum
-    ? [createMinimumError(path, value, schema.minimum)]
+  const minimum = normalizeQuantity(schema.minimum);
+  return minimum !== undefined && value < minimum
+    ? [createMinimumError(path, value, schema.minimum as number | string)]
     : [];
 };
 
@@ -88,8 +93,9 @@ const validateMinimum = (value: number, schema: JsonSchema, path: string): Schem
  * Pure function to validate maximum number value
  */
 const validateMaximum = (value: number, schema: JsonSchema, path: string): SchemaValidationError[] => {
-  return schema.maximum !== undefined && value > schema.maximum
-    ? [createMaximumError(path, value, schema.maximum)]
+  const maximum = normalizeQuantity(schema.maximum);
+  return maximum !== undefined && value > maximum
+    ? [createMaximumError(path, value, schema.maximum as number | string)]
     : [];
 };
 
@@ -118,7 +124,7 @@ const createMaxLengthError = (path: string, actual: number, expected: number): S
 /**
  * Pure function to create minimum error
  */
-const createMinimumError = (path: string, actual: number, expected: number): SchemaValidationError => ({
+const createMinimumError = (path: string, actual: number, expected: number | string): SchemaValidationError => ({
   path,
   message: `Value must be at least ${expected}`,
   code: 'MINIMUM_ERROR',
@@ -129,7 +135,7 @@ const createMinimumError = (path: string, actual: number, expected: number): Sch
 /**
  * Pure function to create maximum error
  */
-const createMaximumError = (path: string, actual: number, expected: number): SchemaValidationError => ({
+const createMaximumError = (path: string, actual: number, expected: number | string): SchemaValidationError => ({
   path,
   message: `Value must be at most ${expected}`,
   code: 'MAXIMUM_ERROR',
@@ -12,6 +12,7 @@ import {
   SecuritySeverity 
 } from '../../shared/types/security';
 import { ValidationError } from '../../shared/types';
+import { createRedactor } from '../../shared/utils/Redaction';
 
 /**
  * Pure function to detect secrets in content
@@ -53,7 +54,7 @@ const detectSecretsWithRule = (
   
   return matches
     .filter(match => !isFalsePositive(match.value, rule, context))
-    .map(match => createSecretDetectionResult(match, rule, content));
+    .map(match => createSecretDetectionResult(match, rule, content, context));
 };
 
 /**
@@ -104,14 +105,22 @@ const isFalsePositive = (
 const createSecretDetectionResult = (
   match: { value: string; index: number },
   rule: SecretDetectionRule,
-  content: string
+  content: string,
+  securityContext?: SecurityContext
 ): SecretDetectionResult => {
   const { lineNumber, columnNumber } = getLineAndColumn(content, match.index);
-  const context = getContextAroundMatch(content, match.index, 50);
+  const snippet = getContextAroundMatch(content, match.index, 50);
+  const redactor = createRedactor(securityContext?.redaction);
+
+  // With a redaction policy, the secret is never shown in plaintext, not even in the snippet
+  const maskedValue = redactor.name === 'none'
+    ? maskSecret(match.value)
+    : String(redactor.redact(match.value, 'secret'));
+  const context = redactor.name === 'none' ? snippet : snippet.split(match.value).join(maskedValue);
   
   return {
     secretType: rule.name,
-    maskedValue: maskSecret(match.value),
+    maskedValue,
     confidence: calculateConfidence(match.value, rule),
     context,
     lineNumber,
@@ -143,7 +152,7 @@ const getContextAroundMatch = (content: string, index: number, contextLength: nu
 /**
  * Pure function to mask secret value
  */
-const maskSecret = (value: string): string => {
+export const maskSecret = (value: string): string => {
   // Guard clause: empty value
   if (!value || value.length === 0) {
     return value;
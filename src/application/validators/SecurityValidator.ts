@@ -210,7 +210,7 @@ const createFailedRuleResult = (
     message,
     severity: mapSecuritySeverityToValidationSeverity(rule.severity),
     path: '',
-    context: { matchedValue }
+    context: { observedValue: matchedValue, rule: { id: rule.id } }
   }
 });
 
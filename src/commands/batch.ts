@@ -0,0 +1,96 @@
+import { Command, Flags } from '@oclif/core';
+import * as fs from 'fs';
+import { EqualityRule } from '../domain/rules/EqualityRule';
+import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
+import { BatchJob, BatchJobResult, jobContext, parseBatchInput, readJobFiles } from '../infrastructure/batch/BatchJobs';
+import { ComparisonStrategyName, RedactionPolicyName, ValidationContext } from '../shared/types';
+import { cliLanguage, translate } from '../shared/i18n';
+import { COMPARISON_STRATEGIES } from '../domain/rules/ComparisonStrategies';
+import { createRedactor, REDACTION_POLICIES, redactResult, Redactor } from '../shared/utils/Redaction';
+import { EXIT_CODES, exitCodeFor, isExitOnly } from '../shared/utils/ExitCodes';
+
+export default class Batch extends Command {
+  static override description = translate('command.batch.description', {}, cliLanguage());
+
+  static override examples = [
+    `$ echo '{"id":"app","files":["dev.yaml","prod.yaml"]}' | praetorian batch`,
+    '$ praetorian batch --input jobs.ndjson',
+  ];
+
+  static override flags = {
+    input: Flags.string({
+      char: 'i',
+      description: 'Read the jobs from this file instead of stdin',
+    }),
+    comparison: Flags.string({
+      description: 'How keys are compared across the files of each job',
+      options: COMPARISON_STRATEGIES,
+    }),
+    redact: Flags.string({
+      description: 'Hide raw values in findings',
+      options: REDACTION_POLICIES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  async run() {
+    const { flags } = await this.parse(Batch);
+
+    try {
+      const jobs = parseBatchInput(flags.input ? fs.readFileSync(flags.input, 'utf8') : await this.readStdin());
+      const redactor = createRedactor(flags.redact !== undefined ? { policy: flags.redact as RedactionPolicyName } : {});
+      const baseContext: ValidationContext = flags.comparison !== undefined
+        ? { comparison: { strategy: flags.comparison as ComparisonStrategyName } }
+        : {};
+      let exitCode: number = EXIT_CODES.SUCCESS;
+
+      // One NDJSON line per job, written as soon as the job finishes
+      for (const job of jobs) {
+        const outcome = await this.runJob(job, baseContext, redactor);
+        this.log(JSON.stringify(outcome.line));
+        exitCode = Math.max(exitCode, outcome.exitCode);
+      }
+
+      if (exitCode !== EXIT_CODES.SUCCESS) {
+        this.exit(exitCode);
+      }
+    } catch (error) {
+      // Guard clause: `this.exit` above
+      if (isExitOnly(error)) {
+        throw error;
+      }
+
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  /**
+   * Runs one job; a failing job is reported on its own line without stopping the others
+   */
+  private async runJob(
+    job: BatchJob,
+    baseContext: ValidationContext,
+    redactor: Redactor
+  ): Promise<{ line: BatchJobResult; exitCode: number }> {
+    try {
+      const files = await readJobFiles(job, new FileReaderService());
+      const result = redactResult(await new EqualityRule().execute(files, jobContext(job, baseContext)), redactor);
+      return { line: { id: job.id, result }, exitCode: result.success ? EXIT_CODES.SUCCESS : EXIT_CODES.FINDINGS };
+    } catch (error) {
+      return {
+        line: { id: job.id, error: error instanceof Error ? error.message : 'Unknown error' },
+        exitCode: exitCodeFor(error),
+      };
+    }
+  }
+
+  private async readStdin(): Promise<string> {
+    const chunks: Buffer[] = [];
+
+    for await (const chunk of process.stdin) {
+      chunks.push(Buffer.isBuffer(chunk) ? chunk : Buffer.from(chunk));
+    }
+
+    return Buffer.concat(chunks).toString('utf8');
+  }
+}
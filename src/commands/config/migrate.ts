@@ -0,0 +1,66 @@
+import { Command, Flags } from '@oclif/core';
+import chalk from 'chalk';
+import * as fs from 'fs';
+import { migrateConfigContent } from '../../infrastructure/parsers/config-parsing/ConfigMigrations';
+import { CONFIG_SCHEMA_VERSION } from '../../infrastructure/parsers/config-parsing/ConfigSchema';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../../shared/i18n';
+
+export default class ConfigMigrate extends Command {
+  static override description = translate('command.config.migrate.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian config migrate',
+    '$ praetorian config migrate --config ci/praetorian.yaml',
+    '$ praetorian config migrate --dry-run',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    'dry-run': Flags.boolean({
+      description: 'Print the migrated configuration instead of writing it',
+      default: false,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { flags } = await this.parse(ConfigMigrate);
+    this.language = resolveLanguage(flags.lang);
+
+    // Guard clause: nothing to migrate
+    if (!fs.existsSync(flags.config)) {
+      this.error(`Configuration file not found: ${flags.config}`);
+    }
+
+    const result = migrateConfigContent(fs.readFileSync(flags.config, 'utf8'));
+
+    // Guard clause: already current
+    if (result.applied.length === 0) {
+      this.log(chalk.green(this.t('migrate.current', { path: flags.config, from: result.from, current: CONFIG_SCHEMA_VERSION })));
+      return;
+    }
+
+    if (flags['dry-run']) {
+      this.log(result.content);
+      return;
+    }
+
+    fs.writeFileSync(flags.config, result.content);
+    this.log(chalk.green(this.t('migrate.migrated', { path: flags.config, from: result.from, to: result.to })));
+    result.applied.forEach(migration => this.log(chalk.gray(`  • ${migration.from} → ${migration.to}: ${migration.description}`)));
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
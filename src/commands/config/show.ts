@@ -0,0 +1,70 @@
+import { Command, Flags } from '@oclif/core';
+import * as fs from 'fs';
+import { ConfigParser } from '../../infrastructure/parsers/ConfigParser';
+import { pathPlaceholders, stringifyToYaml } from '../../infrastructure/parsers/config-parsing/ConfigFileOperations';
+import { loadUserConfig, settingOrigins, userConfigPath } from '../../infrastructure/parsers/config-parsing/UserConfig';
+import { ConfigLayer } from '../../shared/types';
+import { cliLanguage, translate } from '../../shared/i18n';
+import { exitCodeFor } from '../../shared/utils/ExitCodes';
+
+export default class ConfigShow extends Command {
+  static override description = translate('command.config.show.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian config show',
+    '$ praetorian config show --effective',
+    '$ praetorian config show --effective --config ci/praetorian.yaml',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    effective: Flags.boolean({
+      description: 'Show the user configuration merged under the project configuration and templated environments expanded, with the source of each setting; extends, profiles and command-line flags are not part of it',
+      default: false,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  async run() {
+    const { flags } = await this.parse(ConfigShow);
+
+    try {
+      // Guard clause: the project configuration as written
+      if (!flags.effective) {
+        this.log(`# ${flags.config}`);
+        this.log(stringifyToYaml(new ConfigParser(flags.config).load()));
+        return;
+      }
+
+      // Outside a project only the user configuration applies
+      const parser = fs.existsSync(flags.config) ? new ConfigParser(flags.config) : undefined;
+      const project = parser?.load();
+      const layers: ConfigLayer[] = [
+        { source: userConfigPath(), config: { ...loadUserConfig() } },
+        ...(parser && project ? [{ source: flags.config, config: { ...project } }] : []),
+        // Templated environments are shown with the files they resolve to
+        ...(parser && project && this.hasEnvironmentTemplates(project.environments)
+          ? [{ source: `${flags.config}, placeholders expanded from the files on disk`, config: { environments: parser.getExpandedEnvironments() } }]
+          : []),
+      ];
+      const effective: Record<string, unknown> = Object.assign({}, ...layers.map(layer => layer.config));
+      const origins = settingOrigins(layers);
+
+      this.log(`# Effective configuration (later sources win): ${layers.map(layer => layer.source).join(', ')}`);
+      Object.entries(effective).forEach(([field, value]) => {
+        this.log(`# from ${origins[field]}`);
+        this.log(stringifyToYaml({ [field]: value }).trimEnd());
+      });
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  private hasEnvironmentTemplates(environments?: Record<string, string>): boolean {
+    return Object.values(environments ?? {}).some(file => pathPlaceholders(String(file)).length > 0);
+  }
+}
@@ -0,0 +1,77 @@
+import { Command, Flags } from '@oclif/core';
+import chalk from 'chalk';
+import * as fs from 'fs';
+import { ConfigParser } from '../../infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../../infrastructure/adapters/FileReaderService';
+import { buildConfigDocs, docRulesOf } from '../../infrastructure/inventory/ConfigDocs';
+import { inventorySourcesOf } from '../../infrastructure/inventory/Inventory';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../../shared/i18n';
+import { ConfigError, exitCodeFor, IoError } from '../../shared/utils/ExitCodes';
+import { createRedactor } from '../../shared/utils/Redaction';
+
+export default class DocsGenerate extends Command {
+  static override description = translate('command.docs.generate.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian docs generate',
+    '$ praetorian docs generate --out docs/configuration.md',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    out: Flags.string({
+      description: 'Write the documentation to this file instead of stdout',
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { flags } = await this.parse(DocsGenerate);
+    this.language = resolveLanguage(flags.lang);
+
+    try {
+      const parser = new ConfigParser(flags.config);
+
+      // Guard clause: nothing to document
+      if (!parser.exists()) {
+        throw new ConfigError(`Configuration file not found: ${flags.config}`);
+      }
+
+      const docs = await buildConfigDocs(inventorySourcesOf(parser), {
+        config: flags.config,
+        reader: new FileReaderService(parser.getFormatOverrides()),
+        rules: docRulesOf(parser),
+        // Secret keys are masked whatever the policy; `mask-all` and `hash` also hide the other examples
+        redactor: createRedactor(parser.getRedaction()),
+      });
+
+      if (!flags.out) {
+        this.log(docs.markdown);
+        return;
+      }
+
+      try {
+        fs.writeFileSync(flags.out, docs.markdown);
+      } catch (error) {
+        throw new IoError(`Failed to write documentation to ${flags.out}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      }
+      this.log(chalk.green(this.t('docs.written', { keys: docs.keys.length, files: docs.files.length, path: flags.out })));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
@@ -0,0 +1,175 @@
+import { Command, Flags, Args } from '@oclif/core';
+import chalk from 'chalk';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { isDeepStrictEqual } from 'util';
+import { fixFormat, lintFormat } from '../application/validation/FormatLintChecks';
+import { isKeyOrderTarget } from '../application/validation/KeyOrderRules';
+import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
+import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+import { ConfigFile, FormatLintSettings, KeyOrderSettings } from '../shared/types';
+import { ConfigError, exitCodeFor, IoError } from '../shared/utils/ExitCodes';
+import { keyComparator, sortKeysInText } from '../shared/utils/KeySorting';
+
+type FixOutcome = 'fixed' | 'clean' | 'skipped';
+
+export default class Fix extends Command {
+  static override description = translate('command.fix.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian fix',
+    '$ praetorian fix config/app.yaml config/app.properties',
+    '$ praetorian fix --dry-run',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    'dry-run': Flags.boolean({
+      description: 'List the files that would be fixed without writing them',
+      default: false,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  static override args = {
+    files: Args.string({
+      description: 'Files to fix (default: the files of the configuration)',
+      required: false,
+      multiple: true,
+    }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { args, flags } = await this.parse(Fix);
+    this.language = resolveLanguage(flags.lang);
+
+    try {
+      const parser = new ConfigParser(flags.config);
+      const files = args.files && args.files.length > 0 ? args.files : this.configuredFiles(parser, flags.config);
+      const settings = (parser.exists() ? parser.getFormatLintSettings() : undefined) ?? {};
+      const keyOrder = parser.exists() ? parser.getKeyOrderSettings() : undefined;
+      const reader = new FileReaderService(parser.exists() ? parser.getFormatOverrides() : {});
+      const outcomes: FixOutcome[] = [];
+
+      for (const file of files) {
+        outcomes.push(await this.fixFile(file, reader, settings, keyOrder, flags['dry-run']));
+      }
+
+      const fixed = outcomes.filter(outcome => outcome === 'fixed').length;
+      this.log(chalk.green(this.t(flags['dry-run'] ? 'fix.summaryDryRun' : 'fix.summary', { fixed, total: files.length })));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  /**
+   * Files listed by the configuration
+   */
+  private configuredFiles(parser: ConfigParser, config: string): string[] {
+    // Guard clause: no files given and nothing configured
+    if (!parser.exists()) {
+      throw new ConfigError(`Configuration file not found: ${config}`);
+    }
+
+    return parser.getFilesToCompare();
+  }
+
+  /**
+   * Fix one file, and sort its keys when `key_order:` covers it. The fix is kept, or listed by a
+   * dry run, only when the fixed text parses to the same content: trailing spaces are part of
+   * properties values and YAML block scalars.
+   */
+  private async fixFile(
+    file: string,
+    reader: FileReaderService,
+    settings: FormatLintSettings,
+    keyOrder: KeyOrderSettings | undefined,
+    dryRun: boolean
+  ): Promise<FixOutcome> {
+    // Guard clause: missing file
+    if (!fs.existsSync(file)) {
+      this.warn(this.t('fix.notFound', { file }));
+      return 'skipped';
+    }
+
+    const text = fs.readFileSync(file, 'utf8');
+    const format = await reader.detectFormat(file).catch(() => 'text');
+    const formatted = fixFormat(text, format, settings);
+    const sorted = keyOrder && isKeyOrderTarget(file, keyOrder)
+      ? sortKeysInText(formatted, format, keyComparator(keyOrder.order))
+      : formatted;
+    const fixedText = sorted ?? formatted;
+    const unfixable = lintFormat(text, format, settings).filter(issue => !issue.fixable);
+    unfixable.forEach(issue => this.warn(this.t('fix.byHand', { file, code: issue.code, lines: issue.lines.join(', ') })));
+    if (sorted === undefined) {
+      this.warn(this.t('fix.keyOrderByHand', { file, format }));
+    }
+
+    // Guard clause: already clean
+    if (fixedText === text) {
+      return unfixable.length > 0 ? 'skipped' : 'clean';
+    }
+
+    // Guard clause: a file that does not parse cannot be checked after the fix
+    const before = await reader.readFile(file).catch(() => undefined);
+    if (!before) {
+      this.warn(this.t('fix.unparseable', { file }));
+      return 'skipped';
+    }
+
+    // Guard clause: the fix would change a value
+    const after = await this.readFixed(file, fixedText, before.format);
+    if (!after || !isDeepStrictEqual(before.content, after.content)) {
+      this.warn(this.t('fix.changesValues', { file }));
+      return 'skipped';
+    }
+
+    if (!dryRun) {
+      this.write(file, fixedText);
+    }
+
+    this.log(chalk.gray(`  • ${file}`));
+    return 'fixed';
+  }
+
+  /**
+   * Parse the fixed text with the parser of the original file, staged in a private temporary
+   * directory so the file itself is never written before the check; undefined when it does not parse
+   */
+  private async readFixed(file: string, fixedText: string, format: string): Promise<ConfigFile | undefined> {
+    const directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-fix-'));
+    // Glob characters are dropped so the staged path can double as a format override pattern
+    const stagedPath = path.join(directory, path.basename(file).replace(/[*?]/g, '_'));
+
+    try {
+      fs.writeFileSync(stagedPath, fixedText);
+      return await new FileReaderService({ [stagedPath]: format }).readFile(stagedPath).catch(() => undefined);
+    } finally {
+      fs.rmSync(directory, { recursive: true, force: true });
+    }
+  }
+
+  private write(file: string, text: string): void {
+    try {
+      fs.writeFileSync(file, text);
+    } catch (error) {
+      throw new IoError(`Failed to write ${file}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
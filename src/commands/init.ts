@@ -3,9 +3,11 @@ import chalk from 'chalk';
 import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
 import fs from 'fs';
 import path from 'path';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+import { exitCodeFor } from '../shared/utils/ExitCodes';
 
 export default class Init extends Command {
-  static override description = 'Initialize a new Praetorian configuration file';
+  static override description = translate('command.init.description', {}, cliLanguage());
 
   static override examples = [
     '$ praetorian init',
@@ -25,11 +27,18 @@ export default class Init extends Command {
       description: 'Generate DevSecOps configuration template',
       default: false,
     }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
     help: Flags.help({ char: 'h' }),
   };
 
+  private language: Language = 'en';
+
   async run() {
     const { flags } = await this.parse(Init);
+    this.language = resolveLanguage(flags.lang);
 
     try {
       if (flags.devsecops) {
@@ -39,8 +48,7 @@ export default class Init extends Command {
       }
 
     } catch (error) {
-      this.error(error instanceof Error ? error.message : 'Unknown error');
-      this.exit(1);
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
     }
   }
 
@@ -48,31 +56,31 @@ export default class Init extends Command {
     const configParser = new ConfigParser(configPath);
 
     if (configParser.exists()) {
-      this.log(chalk.yellow(`⚠️  Configuration file already exists: ${configPath}`));
-      this.log(chalk.gray('Use --config to specify a different path'));
+      this.log(chalk.yellow(this.t('init.exists', { path: configPath })));
+      this.log(chalk.gray(this.t('init.useConfigFlag')));
       return;
     }
 
     // Create default configuration
     configParser.createDefault();
 
-    this.log(chalk.green(`✅ Configuration file created: ${configPath}`));
-    this.log(chalk.green(`✅ Example rule files created in ./rules/ directory`));
+    this.log(chalk.green(this.t('init.created', { path: configPath })));
+    this.log(chalk.green(this.t('init.rulesCreated')));
     
-    this.log(chalk.blue('\n📋 What was created:'));
-    this.log(chalk.gray(`• ${configPath} - Main configuration with core rules`));
-    this.log(chalk.gray('• ./rules/structure.yaml - Structure validation rules'));
-    this.log(chalk.gray('• ./rules/format.yaml - Format validation rules'));
-    this.log(chalk.gray('• ./rules/security.yaml - Security validation rules'));
-    this.log(chalk.gray('• ./rules/schema.yaml - Schema validation rules'));
+    this.log(chalk.blue(this.t('init.whatWasCreated')));
+    this.log(chalk.gray(this.t('init.created.config', { path: configPath })));
+    this.log(chalk.gray(this.t('init.created.structure')));
+    this.log(chalk.gray(this.t('init.created.format')));
+    this.log(chalk.gray(this.t('init.created.security')));
+    this.log(chalk.gray(this.t('init.created.schema')));
     
-    this.log(chalk.blue('\n🎯 Next steps:'));
-    this.log(chalk.gray('1. Edit praetorian.yaml to configure your validation'));
-    this.log(chalk.gray('2. Customize rule files in ./rules/ directory'));
-    this.log(chalk.gray('3. Add your configuration files to validate'));
-    this.log(chalk.gray('4. Run: praetorian validate'));
+    this.log(chalk.blue(this.t('init.nextSteps')));
+    this.log(chalk.gray(this.t('init.nextSteps.edit')));
+    this.log(chalk.gray(this.t('init.nextSteps.customize')));
+    this.log(chalk.gray(this.t('init.nextSteps.addFiles')));
+    this.log(chalk.gray(this.t('init.nextSteps.run')));
     
-    this.log(chalk.blue('\n📖 Example usage:'));
+    this.log(chalk.blue(this.t('init.exampleUsage')));
     this.log(chalk.gray('$ praetorian validate'));
     this.log(chalk.gray('$ praetorian validate --config my-config.yaml'));
     this.log(chalk.gray('$ praetorian validate config1.yaml config2.yaml'));
@@ -80,8 +88,8 @@ export default class Init extends Command {
 
   private async createDevSecOpsConfig(configPath: string): Promise<void> {
     if (fs.existsSync(configPath)) {
-      this.log(chalk.yellow(`⚠️  Configuration file already exists: ${configPath}`));
-      this.log(chalk.gray('Use --config to specify a different path'));
+      this.log(chalk.yellow(this.t('init.exists', { path: configPath })));
+      this.log(chalk.gray(this.t('init.useConfigFlag')));
       return;
     }
 
@@ -116,24 +124,24 @@ export default class Init extends Command {
     // Create environments YAML file
     this.createEnvironmentsFile(environmentsDir);
 
-    this.log(chalk.green(`✅ DevSecOps configuration created: ${configPath}`));
-    this.log(chalk.green(`✅ DevSecOps structure created in ./rules/ and ./environments/`));
+    this.log(chalk.green(this.t('init.devsecops.created', { path: configPath })));
+    this.log(chalk.green(this.t('init.devsecops.structureCreated')));
     
-    this.log(chalk.blue('\n📋 What was created:'));
-    this.log(chalk.gray(`• ${configPath} - DevSecOps configuration template`));
-    this.log(chalk.gray('• ./rules/ - Directory for team-specific rules'));
-    this.log(chalk.gray('• ./rules/security.yaml - Security rules example'));
-    this.log(chalk.gray('• ./rules/compliance.yaml - Compliance rules example'));
-    this.log(chalk.gray('• ./environments/ - Directory for environment-specific configs'));
-    this.log(chalk.gray('• ./environments/environments.yaml - Environment configuration template'));
+    this.log(chalk.blue(this.t('init.whatWasCreated')));
+    this.log(chalk.gray(this.t('init.devsecops.created.config', { path: configPath })));
+    this.log(chalk.gray(this.t('init.devsecops.created.rulesDir')));
+    this.log(chalk.gray(this.t('init.devsecops.created.security')));
+    this.log(chalk.gray(this.t('init.devsecops.created.compliance')));
+    this.log(chalk.gray(this.t('init.devsecops.created.environmentsDir')));
+    this.log(chalk.gray(this.t('init.devsecops.created.environments')));
     
-    this.log(chalk.blue('\n🎯 DevSecOps Features:'));
-    this.log(chalk.gray('• Multi-source rule loading (core, local, remote, package, git)'));
-    this.log(chalk.gray('• Environment-specific configurations'));
-    this.log(chalk.gray('• Team visibility and collaboration'));
-    this.log(chalk.gray('• Pipeline integration ready'));
+    this.log(chalk.blue(this.t('init.devsecops.features')));
+    this.log(chalk.gray(this.t('init.devsecops.features.sources')));
+    this.log(chalk.gray(this.t('init.devsecops.features.environments')));
+    this.log(chalk.gray(this.t('init.devsecops.features.visibility')));
+    this.log(chalk.gray(this.t('init.devsecops.features.pipeline')));
     
-    this.log(chalk.blue('\n📖 DevSecOps Usage:'));
+    this.log(chalk.blue(this.t('init.devsecops.usage')));
     this.log(chalk.gray('$ praetorian validate --env=dev'));
     this.log(chalk.gray('$ praetorian validate --env=prod --config praetorian.yaml'));
     this.log(chalk.gray('$ praetorian validate --env=ci --rules=security'));
@@ -343,4 +351,8 @@ validation:
 # Use 'overrides' to customize rule behavior per environment.
 `;
   }
-} 
\ No newline at end of file
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
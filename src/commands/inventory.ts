@@ -0,0 +1,88 @@
+import { Command, Flags } from '@oclif/core';
+import chalk from 'chalk';
+import * as fs from 'fs';
+import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
+import { buildInventory, inventorySourcesOf } from '../infrastructure/inventory/Inventory';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+import { ConfigError, exitCodeFor, IoError } from '../shared/utils/ExitCodes';
+import { HASH_SALT_ENV, hashSaltOf } from '../shared/utils/Redaction';
+
+export default class Inventory extends Command {
+  static override description = translate('command.inventory.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian inventory',
+    '$ praetorian inventory --config ci/praetorian.yaml --out config-bom.json',
+    '$ PRAETORIAN_HASH_SALT=... praetorian inventory --hash-values --out values.json',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    out: Flags.string({
+      description: 'Write the inventory to this file instead of stdout',
+    }),
+    'hash-values': Flags.boolean({
+      description: `List the value of every key as a hash salted with ${HASH_SALT_ENV}, to compare values with another team without revealing them`,
+      default: false,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { flags } = await this.parse(Inventory);
+    this.language = resolveLanguage(flags.lang);
+
+    try {
+      const parser = new ConfigParser(flags.config);
+
+      // Guard clause: nothing to describe
+      if (!parser.exists()) {
+        throw new ConfigError(`Configuration file not found: ${flags.config}`);
+      }
+
+      const hashSalt = hashSaltOf();
+
+      // Guard clause: unsalted hashes of short secrets can be reversed by guessing
+      if (flags['hash-values'] && !hashSalt) {
+        throw new ConfigError(`--hash-values needs a salt shared with the other team in ${HASH_SALT_ENV}`);
+      }
+
+      const inventory = await buildInventory(inventorySourcesOf(parser), {
+        config: flags.config,
+        reader: new FileReaderService(parser.getFormatOverrides()),
+        secretKeys: parser.getRedaction().secretKeys,
+        ...(flags['hash-values'] ? { hashSalt } : {}),
+      });
+      const json = JSON.stringify(inventory, null, 2);
+
+      if (!flags.out) {
+        this.log(json);
+        return;
+      }
+
+      try {
+        fs.writeFileSync(flags.out, `${json}\n`);
+      } catch (error) {
+        throw new IoError(`Failed to write inventory to ${flags.out}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      }
+      this.log(chalk.green(this.t('inventory.written', { files: inventory.files.length, path: flags.out })));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
@@ -0,0 +1,130 @@
+import { Command, Flags, Args } from '@oclif/core';
+import chalk from 'chalk';
+import * as fs from 'fs';
+import {
+  buildComparisonMatrix,
+  MATRIX_FORMATS,
+  MatrixColumn,
+  MatrixFormat,
+  renderMatrix,
+} from '../application/validation/ComparisonMatrix';
+import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
+import { inventorySourcesOf, InventoryTarget, inventoryTargets } from '../infrastructure/inventory/Inventory';
+import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+import { ConfigError, exitCodeFor, IoError } from '../shared/utils/ExitCodes';
+
+export default class Matrix extends Command {
+  static override description = translate('command.matrix.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian matrix',
+    '$ praetorian matrix --group payments --all',
+    '$ praetorian matrix --format html --out matrix.html',
+    '$ praetorian matrix config/dev.yaml config/staging.yaml config/prod.yaml --format csv',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    format: Flags.string({
+      char: 'f',
+      description: 'Matrix format',
+      options: MATRIX_FORMATS,
+      default: 'text',
+    }),
+    out: Flags.string({
+      description: 'Write the matrix to this file instead of stdout',
+    }),
+    group: Flags.string({
+      description: 'Only the files of this group of praetorian.yaml',
+    }),
+    all: Flags.boolean({
+      description: 'Also show the keys every file has with the same value',
+      default: false,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  static override args = {
+    files: Args.string({
+      description: 'Files to compare (default: the files of the configuration)',
+      required: false,
+      multiple: true,
+    }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { args, flags } = await this.parse(Matrix);
+    this.language = resolveLanguage(flags.lang);
+
+    try {
+      const parser = new ConfigParser(flags.config);
+      const targets: InventoryTarget[] = args.files && args.files.length > 0
+        ? args.files.map(file => ({ path: file }))
+        : this.configuredTargets(parser, flags.config, flags.group);
+      const reader = new FileReaderService(parser.exists() ? parser.getFormatOverrides() : {});
+      const columns: MatrixColumn[] = [];
+
+      // Files are read one after the other to keep the columns in configuration order
+      for (const target of targets) {
+        columns.push({ name: target.environment ?? target.path, content: (await reader.readFile(target.path)).content });
+      }
+
+      const matrix = buildComparisonMatrix(columns, {
+        all: flags.all,
+        ignoreKeys: parser.exists() ? parser.getIgnoreKeys() : [],
+      });
+      const rendered = renderMatrix(matrix, flags.format as MatrixFormat, flags.group ? this.t('matrix.title', { group: flags.group }) : undefined);
+
+      if (!flags.out) {
+        this.log(rendered.trimEnd());
+        return;
+      }
+
+      try {
+        fs.writeFileSync(flags.out, rendered);
+      } catch (error) {
+        throw new IoError(`Failed to write matrix to ${flags.out}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      }
+      this.log(chalk.green(this.t('matrix.written', { keys: matrix.rows.length, files: columns.length, path: flags.out })));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  /**
+   * Files of the configuration, or of one of its groups
+   */
+  private configuredTargets(parser: ConfigParser, config: string, group?: string): InventoryTarget[] {
+    // Guard clause: no files given and nothing configured
+    if (!parser.exists()) {
+      throw new ConfigError(`Configuration file not found: ${config}`);
+    }
+
+    const targets = inventoryTargets(inventorySourcesOf(parser));
+    const selected = group === undefined ? targets : targets.filter(target => target.group === group);
+
+    // Guard clause: a matrix needs something to compare
+    if (selected.length < 2) {
+      throw new ConfigError(group === undefined
+        ? `A matrix needs at least two files; ${config} lists ${selected.length}`
+        : `Group ${group} has fewer than two files in ${config}`);
+    }
+
+    return selected;
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
@@ -0,0 +1,83 @@
+import { Command, Flags } from '@oclif/core';
+import { assessChangeRisk, reachesRisk, renderRiskSummary, RiskedChange, RiskLevel } from '../application/validation/ChangeRisk';
+import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
+import { docRulesOf, rulesForKey } from '../infrastructure/inventory/ConfigDocs';
+import { readChangedFiles } from '../infrastructure/inventory/GitRevisions';
+import { inventorySourcesOf } from '../infrastructure/inventory/Inventory';
+import { cliLanguage, translate } from '../shared/i18n';
+import { ConfigError, EXIT_CODES, exitCodeFor } from '../shared/utils/ExitCodes';
+
+export default class Risk extends Command {
+  static override description = translate('command.risk.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian risk',
+    '$ praetorian risk --base origin/main --fail-on high',
+    '$ praetorian risk --base v1.4.0 --head v1.5.0 --output json',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    base: Flags.string({
+      description: 'Git revision the changes are measured from',
+      default: 'HEAD',
+    }),
+    head: Flags.string({
+      description: 'Git revision the changes go up to (default: the working tree)',
+    }),
+    output: Flags.string({
+      char: 'o',
+      description: 'Output format (markdown, json)',
+      options: ['markdown', 'json'],
+      default: 'markdown',
+    }),
+    'fail-on': Flags.string({
+      description: 'Exit with 1 when a change reaches this risk level',
+      options: ['high', 'medium', 'low'],
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  async run() {
+    const { flags } = await this.parse(Risk);
+    let changes: RiskedChange[] = [];
+
+    try {
+      const parser = new ConfigParser(flags.config);
+
+      // Guard clause: no files to look at
+      if (!parser.exists()) {
+        throw new ConfigError(`Configuration file not found: ${flags.config}`);
+      }
+
+      const revisions = await readChangedFiles(inventorySourcesOf(parser), {
+        base: flags.base,
+        head: flags.head,
+        reader: new FileReaderService(parser.getFormatOverrides()),
+      });
+      // Ignored keys are left out of comparisons, so they do not count as covered
+      const rules = { ...docRulesOf(parser), ignoreKeys: [] };
+      changes = assessChangeRisk(revisions, {
+        secretKeys: parser.getRedaction().secretKeys,
+        leakage: parser.getLeakage(),
+        rulesFor: key => rulesForKey(key, rules),
+      });
+
+      this.log(flags.output === 'json'
+        ? JSON.stringify({ base: flags.base, ...(flags.head ? { head: flags.head } : {}), changes }, null, 2)
+        : renderRiskSummary(changes, flags.base));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+
+    // Exit outside the try block so the exit itself is not reported as an error
+    if (flags['fail-on'] && reachesRisk(changes, flags['fail-on'] as RiskLevel)) {
+      this.exit(EXIT_CODES.FINDINGS);
+    }
+  }
+}
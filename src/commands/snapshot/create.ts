@@ -0,0 +1,64 @@
+import { Command, Flags } from '@oclif/core';
+import chalk from 'chalk';
+import { ConfigParser } from '../../infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../../infrastructure/adapters/FileReaderService';
+import { inventorySourcesOf } from '../../infrastructure/inventory/Inventory';
+import { createSnapshot, DEFAULT_SNAPSHOT_FILE, writeSnapshot } from '../../infrastructure/inventory/Snapshot';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../../shared/i18n';
+import { ConfigError, exitCodeFor } from '../../shared/utils/ExitCodes';
+
+export default class SnapshotCreate extends Command {
+  static override description = translate('command.snapshot.create.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian snapshot create',
+    '$ praetorian snapshot create --file golden/prod.snapshot.json',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    file: Flags.string({
+      char: 'f',
+      description: 'Where the snapshot is written',
+      default: DEFAULT_SNAPSHOT_FILE,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { flags } = await this.parse(SnapshotCreate);
+    this.language = resolveLanguage(flags.lang);
+
+    try {
+      const parser = new ConfigParser(flags.config);
+
+      // Guard clause: nothing to record
+      if (!parser.exists()) {
+        throw new ConfigError(`Configuration file not found: ${flags.config}`);
+      }
+
+      const snapshot = await createSnapshot(inventorySourcesOf(parser), {
+        config: flags.config,
+        reader: new FileReaderService(parser.getFormatOverrides()),
+      });
+      writeSnapshot(flags.file, snapshot);
+      this.log(chalk.green(this.t('snapshot.written', { files: snapshot.files.length, path: flags.file })));
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
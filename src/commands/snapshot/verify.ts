@@ -0,0 +1,102 @@
+import { Command, Flags } from '@oclif/core';
+import chalk from 'chalk';
+import { ConfigParser } from '../../infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../../infrastructure/adapters/FileReaderService';
+import { inventorySourcesOf } from '../../infrastructure/inventory/Inventory';
+import {
+  compareSnapshots,
+  createSnapshot,
+  DEFAULT_SNAPSHOT_FILE,
+  readSnapshot,
+  SnapshotDrift,
+} from '../../infrastructure/inventory/Snapshot';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../../shared/i18n';
+import { ConfigError, EXIT_CODES, exitCodeFor } from '../../shared/utils/ExitCodes';
+
+export default class SnapshotVerify extends Command {
+  static override description = translate('command.snapshot.verify.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian snapshot verify',
+    '$ praetorian snapshot verify --file golden/prod.snapshot.json --output json',
+  ];
+
+  static override flags = {
+    config: Flags.string({
+      char: 'c',
+      description: 'Path to praetorian.yaml configuration file',
+      default: 'praetorian.yaml',
+    }),
+    file: Flags.string({
+      char: 'f',
+      description: 'Snapshot to verify against',
+      default: DEFAULT_SNAPSHOT_FILE,
+    }),
+    output: Flags.string({
+      char: 'o',
+      description: 'Output format (pretty, json)',
+      options: ['pretty', 'json'],
+      default: 'pretty',
+    }),
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { flags } = await this.parse(SnapshotVerify);
+    this.language = resolveLanguage(flags.lang);
+    let drift: SnapshotDrift[] = [];
+
+    try {
+      const parser = new ConfigParser(flags.config);
+
+      // Guard clause: nothing to verify
+      if (!parser.exists()) {
+        throw new ConfigError(`Configuration file not found: ${flags.config}`);
+      }
+
+      const recorded = readSnapshot(flags.file);
+      const current = await createSnapshot(inventorySourcesOf(parser), {
+        config: flags.config,
+        reader: new FileReaderService(parser.getFormatOverrides()),
+      });
+      drift = compareSnapshots(recorded, current);
+      this.display(drift, flags.output, flags.file);
+    } catch (error) {
+      this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+
+    // Exit outside the try block so the exit itself is not reported as an error
+    if (drift.length > 0) {
+      this.exit(EXIT_CODES.FINDINGS);
+    }
+  }
+
+  private display(drift: SnapshotDrift[], outputFormat: string, snapshotFile: string): void {
+    if (outputFormat === 'json') {
+      this.log(JSON.stringify({ success: drift.length === 0, drift }, null, 2));
+      return;
+    }
+
+    // Guard clause: golden state intact
+    if (drift.length === 0) {
+      this.log(chalk.green(this.t('snapshot.matches', { path: snapshotFile })));
+      return;
+    }
+
+    this.log(chalk.red(this.t('snapshot.diverges', { path: snapshotFile })));
+    drift.forEach(entry => {
+      const keys = entry.keys ? `: ${entry.keys.join(', ')}` : '';
+      this.log(chalk.red(`  • ${entry.kind} ${entry.path}${keys}`));
+    });
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
@@ -0,0 +1,81 @@
+import { Command, Flags, Args } from '@oclif/core';
+import chalk from 'chalk';
+import { buildTelemetryPayload, TelemetryStore, TELEMETRY_ENDPOINT_ENV } from '../infrastructure/telemetry/Telemetry';
+import { loadUserConfig, userConfigPath } from '../infrastructure/parsers/config-parsing/UserConfig';
+import { cliLanguage, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+
+export default class Telemetry extends Command {
+  static override description = translate('command.telemetry.description', {}, cliLanguage());
+
+  static override examples = [
+    '$ praetorian telemetry show',
+    '$ praetorian telemetry enable',
+    '$ praetorian telemetry disable',
+    '$ praetorian telemetry status',
+  ];
+
+  static override flags = {
+    lang: Flags.string({
+      description: 'Language of messages (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    help: Flags.help({ char: 'h' }),
+  };
+
+  static override args = {
+    action: Args.string({
+      description: 'show: print exactly what would be sent; enable/disable: opt in or out; status: current settings',
+      options: ['show', 'enable', 'disable', 'status'],
+      default: 'show',
+    }),
+  };
+
+  private language: Language = 'en';
+
+  async run() {
+    const { args, flags } = await this.parse(Telemetry);
+    this.language = resolveLanguage(flags.lang);
+    const store = new TelemetryStore(process.env, undefined, loadUserConfig().telemetry);
+
+    switch (args.action) {
+      case 'enable':
+        store.setEnabled(true);
+        this.reportChoice(store, 'telemetry.enabled');
+        break;
+      case 'disable':
+        store.setEnabled(false);
+        this.reportChoice(store, 'telemetry.disabled');
+        break;
+      case 'status':
+        this.displayStatus(store);
+        break;
+      default:
+        this.log(JSON.stringify(buildTelemetryPayload(store.load().counters, this.config.version), null, 2));
+    }
+  }
+
+  /**
+   * Confirm an enable/disable, unless the user configuration makes the choice instead
+   */
+  private reportChoice(store: TelemetryStore, confirmation: string): void {
+    // Guard clause: the state file does not decide while the user configuration sets `telemetry:`
+    if (store.isChosenByUserConfig()) {
+      this.warn(this.t('telemetry.chosenByUserConfig', { enabled: store.isEnabled(), path: userConfigPath() }));
+      return;
+    }
+
+    this.log(chalk.green(this.t(confirmation)));
+  }
+
+  private displayStatus(store: TelemetryStore): void {
+    this.log(this.t('telemetry.status.enabled', { enabled: store.isEnabled() }) +
+      (store.isChosenByUserConfig() ? this.t('telemetry.status.setBy', { path: userConfigPath() }) : ''));
+    this.log(this.t('telemetry.status.stateFile', { path: store.getStatePath() }));
+    const endpoint = store.getEndpoint();
+    this.log(endpoint ? this.t('telemetry.status.endpoint', { endpoint }) : this.t('telemetry.status.noEndpoint', { variable: TELEMETRY_ENDPOINT_ENV }));
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+}
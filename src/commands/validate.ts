@@ -1,18 +1,123 @@
 import { Command, Flags, Args } from '@oclif/core';
 import chalk from 'chalk';
 import { ConfigParser } from '../infrastructure/parsers/ConfigParser';
+import { formatUnknownConfigField } from '../infrastructure/parsers/config-parsing/ConfigSchema';
+import { expandFileGlob } from '../infrastructure/parsers/config-parsing/ConfigFileOperations';
 import { EqualityRule } from '../domain/rules/EqualityRule';
-import { FileReaderService } from '../infrastructure/adapters/FileReaderService';
-import { ConfigFile } from '../shared/types';
+import { FileReaderService, FileReadFailure } from '../infrastructure/adapters/FileReaderService';
+import { DEFAULT_STREAM_ABOVE_BYTES } from '../infrastructure/adapters/StreamingKeyTree';
+import { readKeyLocations } from '../infrastructure/adapters/KeyLocations';
+import {
+  BrokerSettings,
+  BudgetSettings,
+  Canary,
+  CloudIdentifierSettings,
+  CommentedConfigSettings,
+  ComparisonStrategyName,
+  ConfigFile,
+  CronSettings,
+  FormatLintSettings,
+  FeatureFlagSettings,
+  ForbiddenKeyRule,
+  HookSettings,
+  HttpSettings,
+  KeyOrderSettings,
+  LeakageSettings,
+  OwnerSettings,
+  PerformanceMetadata,
+  PerformanceSettings,
+  RateLimitSettings,
+  RedactionPolicyName,
+  RedactionSettings,
+  ValidationContext,
+  ValidationError,
+  ValidationResult,
+  ValidationSeverity,
+  UserConfig,
+  ValueConstraint,
+  ValueDifferenceRule,
+} from '../shared/types';
+import { Logger, LogFormat, LogLevel, LOG_FORMATS, LOG_LEVELS } from '../shared/utils/Logger';
+import { applyTerminalStyle, isCiEnvironment, resolveTerminalStyle, styleLine, TerminalStyle } from '../presentation/cli/Terminal';
+import { isAtLeast, SEVERITIES } from '../shared/utils/Severity';
+import { Labels, labelsOfFile, parseLabelSelector } from '../shared/utils/Labels';
+import { collectFindings, withFindings } from '../shared/utils/Findings';
+import { limitFiles, resolveExtractionLimits } from '../shared/utils/ExtractionLimits';
+import { TelemetryStore } from '../infrastructure/telemetry/Telemetry';
+import { applySeverityEscalation, EscalationRule } from '../application/validation/SeverityEscalation';
+import { aggregateResult } from '../application/validation/FindingAggregation';
+import { applyMessageTemplates } from '../application/validation/MessageTemplates';
+import { capFindings, formatOverflow } from '../presentation/cli/FindingCap';
+import { isStructuredFormat, serializeResult, SerializeOptions, STRUCTURED_FORMATS } from '../presentation/cli/ResultFormats';
+import { cliLanguage, findingTemplates, Language, LANGUAGES, resolveLanguage, translate } from '../shared/i18n';
+import { buildPerformanceMetadata, measure, slowestFirst } from '../shared/utils/Timing';
+import { performance } from 'perf_hooks';
+import { RunProfiler } from '../infrastructure/profiling/Profiler';
+import { Sandbox } from '../infrastructure/sandbox/Sandbox';
+import { COMPARISON_STRATEGIES } from '../domain/rules/ComparisonStrategies';
+import { AuditGroup, compareByGroup, groupFiles } from '../application/validation/AuditGroups';
+import { scopeFiles } from '../application/validation/RuleScoping';
+import { RulePack, runRulePacks, selectRulePacks } from '../application/validation/RulePacks';
+import { CANARY_RULE_ID, withCanaries } from '../application/validation/Canaries';
+import { VALUE_TYPE_RULE_ID, withValueTypes } from '../application/validation/ValueTypeChecks';
+import { KUBERNETES_RULE_ID, withKubernetesFindings } from '../application/validation/KubernetesRules';
+import { CLOUDFORMATION_RULE_ID, withCloudFormationFindings } from '../application/validation/CloudFormationChecks';
+import { SERVERLESS_RULE_ID, withServerlessFindings } from '../application/validation/ServerlessStages';
+import { HCL_POLICY_RULE_ID, withHclPolicyFindings } from '../application/validation/HclPolicyRules';
+import { IAM_POLICY_RULE_ID, withIamPolicyFindings } from '../application/validation/IamPolicyRules';
+import { OPENAPI_RULE_ID, withOpenApiFindings } from '../application/validation/OpenApiChecks';
+import { FEATURE_FLAG_RULE_ID, withFeatureFlagFindings } from '../application/validation/FeatureFlagChecks';
+import { LOGGING_RULE_ID, withLoggingFindings } from '../application/validation/LoggingRules';
+import { MIGRATION_RULE_ID, withMigrationFindings } from '../application/validation/MigrationChecks';
+import { SECURITY_POLICY_RULE_ID, withSecurityPolicyFindings } from '../application/validation/SecurityPolicyRules';
+import { RATE_LIMIT_RULE_ID, withRateLimitFindings } from '../application/validation/RateLimitRules';
+import { TLS_SETTING_RULE_ID, withTlsSettingFindings } from '../application/validation/TlsSettingChecks';
+import { BROKER_RULE_ID, withMessageBrokerFindings } from '../application/validation/MessageBrokerRules';
+import { CLOUD_IDENTITY_RULE_ID, withCloudIdentityFindings } from '../application/validation/CloudIdentityChecks';
+import { LOCALE_SETTING_RULE_ID, withLocaleSettingFindings } from '../application/validation/LocaleSettingChecks';
+import { CRON_RULE_ID, withCronFindings } from '../application/validation/CronChecks';
+import { DSN_RULE_ID, withDsnFindings } from '../application/validation/TelemetryDsnChecks';
+import { BUDGET_RULE_ID, withBudgetFindings } from '../application/validation/BudgetRules';
+import { COMMENTED_CONFIG_RULE_ID, withCommentedConfigFindings } from '../application/validation/CommentedConfigChecks';
+import { FORMAT_LINT_RULE_ID, withFormatLintFindings } from '../application/validation/FormatLintChecks';
+import { FormatLintAuditor } from '../infrastructure/plugins/FormatLintAuditor';
+import { PERFORMANCE_RULE_ID, withPerformanceAudit } from '../application/validation/PerformanceAudit';
+import { VALUE_DIFFERENCE_RULE_ID, withValueDifferences } from '../application/validation/ValueDifferences';
+import { FORBIDDEN_KEY_RULE_ID, withForbiddenKeys } from '../application/validation/ForbiddenKeys';
+import { VALUE_RULE_ID, withValueRules } from '../application/validation/ValueRules';
+import { KEY_ORDER_RULE_ID, withKeyOrderFindings } from '../application/validation/KeyOrderRules';
+import { withOwners } from '../application/validation/Ownership';
+import { selectLabelledFiles, withFileLabels } from '../application/validation/FileLabels';
+import { IMAGE_DEFAULTS_RULE_ID, withImageDefaults } from '../application/validation/ImageDefaults';
+import { WORKFLOW_GLOBS, WORKFLOW_RULE_ID, withWorkflowFindings } from '../application/validation/WorkflowChecks';
+import { LEAKAGE_RULE_ID, withLeakage } from '../application/validation/EnvironmentLeakage';
+import { ENDPOINT_RULE_ID, endpointFindings, endpointTargets, withEndpointFindings } from '../application/validation/EndpointChecks';
+import { loadAnsibleInventories } from '../infrastructure/ansible/AnsibleInventory';
+import { probeEndpoints } from '../infrastructure/http/EndpointProbe';
+import { HttpClient } from '../infrastructure/http/HttpClient';
+import { HookLimitBreach, HookLimitError, hookLimitFinding, runHooks, runPostHooks } from '../infrastructure/hooks/HookRunner';
+import { createRedactor, REDACTION_POLICIES, redactResult } from '../shared/utils/Redaction';
+import { ConfigError, EXIT_CODES, exitCodeFor, exitCodeForResult, FAIL_ON_LEVELS, FailOn } from '../shared/utils/ExitCodes';
+import { RunInterrupt } from '../shared/utils/Interrupt';
+import { loadUserConfig, userConfigPath } from '../infrastructure/parsers/config-parsing/UserConfig';
+
+const OUTPUT_FORMATS = ['pretty', ...STRUCTURED_FORMATS];
 
 export default class Validate extends Command {
-  static override description = 'Validate configuration files for key consistency';
+  static override description = translate('command.validate.description', {}, cliLanguage());
 
   static override examples = [
     '$ praetorian validate',
     '$ praetorian validate --env dev',
+    '$ praetorian validate --fail-on warning',
     '$ praetorian validate config-dev.yaml config-prod.yaml',
     '$ praetorian validate --output json',
+    '$ praetorian validate --output yaml',
+    '$ praetorian validate --output sarif > praetorian.sarif',
+    '$ praetorian validate --output junit > praetorian-junit.xml',
+    '$ praetorian validate --output codeclimate > gl-code-quality-report.json',
+    '$ praetorian validate --workflows',
+    '$ praetorian validate --ansible inventories',
   ];
 
   static override flags = {
@@ -21,25 +126,157 @@ export default class Validate extends Command {
       description: 'Environment to validate (dev, staging, prod)',
       required: false,
     }),
+    label: Flags.string({
+      description: 'Only validate the files carrying this label (key=value); repeat to require several',
+      multiple: true,
+    }),
     output: Flags.string({
       char: 'o',
-      description: 'Output format (pretty, json)',
-      options: ['pretty', 'json'],
-      default: 'pretty',
+      description: 'Output format (pretty, json, yaml, sarif, junit, codeclimate); defaults to the user configuration, then pretty',
+      options: OUTPUT_FORMATS,
     }),
     config: Flags.string({
       char: 'c',
       description: 'Path to praetorian.yaml configuration file',
       default: 'praetorian.yaml',
     }),
+    'strict-config': Flags.boolean({
+      description: 'Fail when praetorian.yaml contains unknown fields instead of warning about them',
+      default: false,
+    }),
     pipeline: Flags.boolean({
       char: 'p',
       description: 'Pipeline mode - concise output for CI/CD',
       default: false,
     }),
+    quiet: Flags.boolean({
+      char: 'q',
+      aliases: ['ci'],
+      description: 'Quiet/CI mode - one-line summary plus findings at or above --min-severity (auto-enabled when CI=true)',
+      default: false,
+    }),
+    'min-severity': Flags.string({
+      description: 'Lowest severity listed in quiet mode',
+      options: SEVERITIES,
+      default: 'warning',
+    }),
+    'fail-on': Flags.string({
+      description: 'Lowest severity that exits with 1 (never: findings never fail the run)',
+      options: FAIL_ON_LEVELS,
+      default: 'error',
+    }),
+    'no-color': Flags.boolean({
+      description: 'Disable colored output (also honored via NO_COLOR)',
+      default: false,
+    }),
+    plain: Flags.boolean({
+      description: 'Plain text output without colors or emoji',
+      default: false,
+    }),
+    comparison: Flags.string({
+      description: 'How keys are compared across files (overrides comparison.strategy)',
+      options: COMPARISON_STRATEGIES,
+    }),
+    reference: Flags.string({
+      description: 'Reference file for the subset-of-reference and symmetric comparisons',
+    }),
+    redact: Flags.string({
+      description: 'Hide raw values in findings and output (overrides redaction.policy)',
+      options: REDACTION_POLICIES,
+    }),
+    'check-endpoints': Flags.boolean({
+      description: 'Resolve the host of every URL value and report the ones that do not resolve (makes network requests)',
+      default: false,
+    }),
+    'head-requests': Flags.boolean({
+      description: 'With --check-endpoints, also send HEAD requests: unreachable endpoints, expired TLS certificates, redirects to other hosts',
+      default: false,
+      dependsOn: ['check-endpoints'],
+    }),
+    workflows: Flags.boolean({
+      description: 'Also audit the GitHub Actions workflows in .github/workflows: plaintext secrets, missing permissions blocks, unpinned actions',
+      default: false,
+    }),
+    ansible: Flags.string({
+      description: 'Compare the effective group variables of Ansible inventories (an inventory directory, or a directory with one inventory per environment)',
+      multiple: true,
+    }),
+    'rule-set': Flags.string({
+      description: 'Only run this rule pack (such as kubernetes or key-order) after the key comparison; repeat for several. Defaults to the ruleSets of praetorian.yaml, then of the user configuration',
+      multiple: true,
+    }),
+    'keep-going': Flags.boolean({
+      description: 'Record unreadable or unparseable files as findings and keep validating the rest',
+      default: false,
+    }),
+    'max-findings': Flags.integer({
+      description: 'Show at most N findings per severity and summarize the rest',
+      min: 0,
+    }),
+    expand: Flags.boolean({
+      description: 'List the same finding once per file instead of grouping the affected files',
+      default: false,
+    }),
+    verbose: Flags.boolean({
+      description: 'Show per-file parse and per-rule evaluation timings',
+      default: false,
+    }),
+    lang: Flags.string({
+      description: 'Language of messages and findings (defaults to PRAETORIAN_LANG or LANG)',
+      options: LANGUAGES,
+    }),
+    'log-level': Flags.string({
+      description: 'Minimum level of progress logs written to stderr',
+      options: LOG_LEVELS,
+      default: 'warn',
+    }),
+    'log-format': Flags.string({
+      description: 'Format of progress logs written to stderr',
+      options: LOG_FORMATS,
+      default: 'text',
+    }),
+    'http-timeout': Flags.integer({
+      description: 'With --head-requests, timeout in milliseconds for each request (overrides http.timeout_ms)',
+      min: 1,
+      dependsOn: ['head-requests'],
+    }),
+    'http-retries': Flags.integer({
+      description: 'With --head-requests, retries for failed requests (overrides http.retries; default 0)',
+      min: 0,
+      dependsOn: ['head-requests'],
+    }),
+    proxy: Flags.string({
+      description: 'With --head-requests, proxy URL for the requests (defaults to HTTPS_PROXY/HTTP_PROXY)',
+      dependsOn: ['head-requests'],
+    }),
+    'ca-file': Flags.string({
+      description: 'With --head-requests, PEM CA bundle trusted for the requests (overrides http.ca_file)',
+      dependsOn: ['head-requests'],
+    }),
+    'assert-no-writes': Flags.boolean({
+      description: 'Fail the run on any write outside the sandbox directory, any child process and any network call not enabled by --check-endpoints',
+      default: false,
+    }),
+    'sandbox-dir': Flags.string({
+      description: 'With --assert-no-writes, the only directory writes are allowed in (default: a new temp directory)',
+      dependsOn: ['assert-no-writes'],
+    }),
+    'profile-cpu': Flags.string({
+      description: 'Write a pprof CPU profile of this run to the given path',
+      hidden: true,
+    }),
+    'profile-mem': Flags.string({
+      description: 'Write a pprof heap allocation profile of this run to the given path',
+      hidden: true,
+    }),
     help: Flags.help({ char: 'h' }),
   };
 
+  private logger: Logger = new Logger();
+  private style: TerminalStyle = resolveTerminalStyle();
+  private maxFindings?: number;
+  private language: Language = 'en';
+
   static override args = {
     files: Args.string({
       description: 'Configuration files to compare',
@@ -50,73 +287,499 @@ export default class Validate extends Command {
 
   async run() {
     const { args, flags } = await this.parse(Validate);
+    this.logger = new Logger({
+      level: flags['log-level'] as LogLevel,
+      format: flags['log-format'] as LogFormat,
+    });
+    const userConfig = this.readUserConfig();
+    const output = flags.output ?? userConfig.output ?? 'pretty';
+
+    // Guard clause: the user configuration names a format validate does not write
+    if (!OUTPUT_FORMATS.includes(output)) {
+      this.error(`Unknown output format "${output}" in ${userConfigPath()} (expected one of: ${OUTPUT_FORMATS.join(', ')})`, { exit: EXIT_CODES.USAGE });
+    }
+
+    const quiet = flags.quiet || isCiEnvironment();
+    this.style = resolveTerminalStyle({ noColor: flags['no-color'] || userConfig.color === false, plain: flags.plain, quiet });
+    applyTerminalStyle(this.style);
+    this.maxFindings = flags['max-findings'];
+    this.language = resolveLanguage(flags.lang);
+    // Installed before anything else runs; only --check-endpoints enables the network
+    const sandbox = flags['assert-no-writes']
+      ? new Sandbox({
+        writableDir: flags['sandbox-dir'],
+        allowNetwork: flags['check-endpoints'],
+        // Profiles are written where they were asked for
+        writableFiles: [flags['profile-cpu'], flags['profile-mem']].filter((file): file is string => file !== undefined),
+      }).install()
+      : undefined;
+    const profiler = new RunProfiler({ cpu: flags['profile-cpu'], mem: flags['profile-mem'] });
+    await profiler.start();
+    const startedAt = performance.now();
+    let exitCode: number = EXIT_CODES.SUCCESS;
+    // First SIGINT/SIGTERM stops the run at the next checkpoint and flushes what was found so far
+    const interrupt = new RunInterrupt().listen();
 
     try {
       // Determine files to compare
       let filesToCompare: string[];
+      let context: ValidationContext = {};
+      let formatOverrides: Record<string, string> = {};
+      let streamAboveBytes = DEFAULT_STREAM_ABOVE_BYTES;
+      let parsers: string[] | undefined;
+      let httpSettings: HttpSettings = {};
+      let escalationRules: EscalationRule[] = [];
+      let environmentFiles: Record<string, string> = {};
+      let messageTemplates: Record<string, string> = {};
+      let groups: AuditGroup[] = [];
+      let hooks: HookSettings = { pre: [], post: [] };
+      let redaction: RedactionSettings = {};
+      let canaries: Canary[] = [];
+      let leakage: LeakageSettings | undefined;
+      let featureFlags: FeatureFlagSettings = {};
+      let rateLimits: RateLimitSettings | undefined;
+      let brokers: BrokerSettings = {};
+      let cloudIdentifiers: CloudIdentifierSettings = {};
+      let cron: CronSettings = { keys: [] };
+      let budgets: BudgetSettings[] = [];
+      let commentedConfig: CommentedConfigSettings | undefined;
+      let formatLint: FormatLintSettings | undefined;
+      let performanceSettings: PerformanceSettings | undefined;
+      let valueDifferences: ValueDifferenceRule[] = [];
+      let forbiddenKeys: ForbiddenKeyRule[] = [];
+      let valueRules: Record<string, ValueConstraint> = {};
+      let keyOrder: KeyOrderSettings | undefined;
+      let owners: OwnerSettings[] = [];
+      let fileLabels: Record<string, Labels> = {};
+      let valueTypes: Record<string, string> = {};
+      let ruleSets = userConfig.ruleSets;
+      const ansible = flags.ansible ?? [];
 
       if (args.files && args.files.length > 0) {
         // Use files from command line arguments
         filesToCompare = Array.isArray(args.files) ? args.files : [args.files];
+      } else if (ansible.length > 0) {
+        // Ansible inventories stand in for the files of praetorian.yaml
+        filesToCompare = [];
       } else {
         // Use configuration file
-        const configParser = new ConfigParser(flags.config);
+        this.logger.debug('Loading configuration', { config: flags.config });
+        const configParser = new ConfigParser(flags.config, { strict: flags['strict-config'], userConfig });
         
         if (!configParser.exists()) {
-          this.error(`Configuration file not found: ${flags.config}`);
-          this.log(chalk.yellow('\nCreate a configuration file with:'));
+          this.log(chalk.yellow(this.t('validate.createConfigHint')));
           this.log(chalk.gray('praetorian init'));
-          return;
+          throw new ConfigError(this.t('validate.configNotFound', { path: flags.config }));
         }
 
         if (flags.env) {
           filesToCompare = configParser.getEnvironmentFiles(flags.env);
+          // Templated environments hold one file per service, each audited on its own
+          groups = configParser.getTemplateGroups(flags.env);
+        } else if (configParser.getGroups().length > 0) {
+          // Groups are compared within themselves, never across each other
+          groups = configParser.getGroups();
+          filesToCompare = groupFiles(groups);
         } else {
           filesToCompare = configParser.getFilesToCompare();
         }
+
+        configParser.getUnknownFields().forEach(field =>
+          this.logger.warn(formatUnknownConfigField(field), { config: flags.config })
+        );
+
+        context = {
+          ignoreKeys: configParser.getIgnoreKeys(),
+          requiredKeys: configParser.getRequiredKeys(),
+          limits: configParser.getLimits(),
+          comparison: configParser.getComparison(),
+          scopes: configParser.getScopes(),
+        };
+        formatOverrides = configParser.getFormatOverrides();
+        streamAboveBytes = configParser.getStreamAboveBytes();
+        parsers = configParser.getParsers();
+        httpSettings = configParser.getHttpSettings();
+        escalationRules = configParser.getEscalationRules();
+        messageTemplates = configParser.getMessageTemplates();
+        hooks = configParser.getHooks();
+        redaction = configParser.getRedaction();
+        canaries = configParser.getCanaries();
+        leakage = configParser.getLeakage();
+        featureFlags = configParser.getFeatureFlagSettings();
+        rateLimits = configParser.getRateLimitSettings();
+        brokers = configParser.getBrokerSettings();
+        cloudIdentifiers = configParser.getCloudIdentifiers();
+        cron = configParser.getCronSettings();
+        budgets = configParser.getBudgets();
+        commentedConfig = configParser.getCommentedConfigSettings();
+        formatLint = configParser.getFormatLintSettings();
+        performanceSettings = configParser.getPerformanceSettings();
+        valueDifferences = configParser.getValueDifferenceRules();
+        forbiddenKeys = configParser.getForbiddenKeyRules();
+        valueRules = configParser.getValueRules();
+        keyOrder = configParser.getKeyOrderSettings();
+        owners = configParser.getOwners();
+        const labelRules = configParser.getLabelRules();
+        const labelGroups = labelRules.some(labelRule => labelRule.groups.length > 0) ? configParser.getGroups() : [];
+        fileLabels = Object.fromEntries(filesToCompare.map(file => [file, labelsOfFile(file, labelRules, labelGroups)]));
+        valueTypes = configParser.getSchema();
+        environmentFiles = configParser.getEnvironmentFileMap();
+        ruleSets = configParser.getRuleSets();
+      }
+
+      // --label keeps the files carrying the labels; files given as arguments carry none
+      if (flags.label && flags.label.length > 0) {
+        const selector = this.labelSelector(flags.label);
+        filesToCompare = selectLabelledFiles(filesToCompare, fileLabels, selector);
+        groups = groups
+          .map(group => ({ ...group, files: group.files.filter(file => filesToCompare.includes(file)) }))
+          .filter(group => group.files.length > 0);
+
+        if (filesToCompare.length === 0) {
+          throw new ConfigError(`No configured file carries the labels ${flags.label.join(', ')}`);
+        }
       }
 
+      // Flags take precedence over the http and comparison sections of praetorian.yaml
+      context = {
+        ...context,
+        http: { ...httpSettings, ...this.httpSettingsFromFlags(flags) },
+        comparison: {
+          ...context.comparison,
+          ...(flags.comparison !== undefined ? { strategy: flags.comparison as ComparisonStrategyName } : {}),
+          ...(flags.reference !== undefined ? { reference: flags.reference } : {}),
+        },
+        labels: fileLabels,
+        signal: interrupt.signal,
+      };
+
+      // Guard clause: hooks start child processes, which the sandbox refuses
+      if (sandbox && (hooks.pre.length > 0 || hooks.post.length > 0)) {
+        throw new ConfigError(`--assert-no-writes cannot run the hooks of ${flags.config}: hooks start child processes, which the sandbox refuses`);
+      }
+
+      // Pre hooks may render or decrypt the files about to be read
+      const hookInfo = { config: flags.config, environment: flags.env, files: filesToCompare };
+      const hookOptions = { timeoutMs: hooks.timeoutMs, cpuSeconds: hooks.cpuSeconds, memoryMb: hooks.memoryMb, signal: interrupt.signal };
+      hooks.pre.forEach(command => this.logger.info('Running pre hook', { command }));
+      await runHooks('pre', hooks.pre, hookInfo, hookOptions);
+
       // Load and parse files
-      const configFiles = await this.loadFiles(filesToCompare);
+      this.logger.info('Loading configuration files', { count: filesToCompare.length });
+      // Comments are only kept for the commented-out configuration check
+      const fileReaderService = new FileReaderService(formatOverrides, { retainComments: commentedConfig !== undefined, streamAboveBytes, parsers });
+      const { files: readFiles, failures } = flags['keep-going']
+        ? await fileReaderService.readFilesTolerant(filesToCompare, interrupt.signal)
+        : { files: await this.loadFiles(fileReaderService, filesToCompare, interrupt.signal), failures: [] };
+      readFiles
+        .filter(file => file.metadata?.streamed)
+        .forEach(file => this.logger.info('Streamed large file into its key tree', { file: file.path }));
+      // Each inventory becomes one file of group -> effective variables
+      const labelled = readFiles.map(file => Object.keys(fileLabels[file.path] ?? {}).length > 0 ? { ...file, labels: fileLabels[file.path] } : file);
+      // Pathological files are truncated once, before any rule walks them
+      const limits = resolveExtractionLimits(context.limits);
+      const limited = limitFiles([...labelled, ...(ansible.length > 0 ? await this.loadInventories(ansible) : [])], limits);
+      const configFiles = limited.files;
+      // Workflows are audited on their own, never compared with the configuration files
+      const workflowFiles = flags.workflows
+        ? limitFiles(await this.loadFiles(fileReaderService, this.workflowPaths(filesToCompare), interrupt.signal), limits).files
+        : [];
 
-      // Run validation
+      // Run validation; an interrupted run skips whatever has not started yet
       const rule = new EqualityRule();
-      const result = await rule.execute(configFiles);
+      const scopes = context.scopes ?? [];
+      const evaluation = await measure(() => {
+        if (interrupt.isInterrupted()) {
+          return Promise.resolve(this.emptyResult(configFiles));
+        }
+
+        return groups.length > 0
+          ? compareByGroup(groups, configFiles, (files, group) => rule.execute(scopeFiles(scopes, rule.id, files, group.name), context), interrupt.signal)
+          : rule.execute(scopeFiles(scopes, rule.id, configFiles), context);
+      });
+      const withEnvironment = (files: ConfigFile[]) =>
+        files.map(file => ({ ...file, environment: environmentFiles[file.path] ?? flags.env }));
+      // Each pack runs on the files it is scoped to, in this order, after the key comparison;
+      // packs whose section is not configured are skipped
+      const rulePacks: RulePack[] = [
+        { id: VALUE_TYPE_RULE_ID, enabled: Object.keys(valueTypes).length > 0, run: (result, files) => withValueTypes(result, valueTypes, files) },
+        { id: CANARY_RULE_ID, enabled: canaries.length > 0, run: (result, files) => withCanaries(result, canaries, files) },
+        { id: IMAGE_DEFAULTS_RULE_ID, run: withImageDefaults },
+        { id: KUBERNETES_RULE_ID, run: (result, files) => withKubernetesFindings(result, withEnvironment(files)) },
+        { id: CLOUDFORMATION_RULE_ID, run: withCloudFormationFindings },
+        { id: SERVERLESS_RULE_ID, run: (result, files) => withServerlessFindings(result, files, process.env) },
+        { id: HCL_POLICY_RULE_ID, run: withHclPolicyFindings },
+        { id: IAM_POLICY_RULE_ID, run: withIamPolicyFindings },
+        { id: OPENAPI_RULE_ID, run: (result, files) => withOpenApiFindings(result, withEnvironment(files)) },
+        { id: FEATURE_FLAG_RULE_ID, run: (result, files) => withFeatureFlagFindings(result, withEnvironment(files), featureFlags) },
+        { id: LOGGING_RULE_ID, run: (result, files) => withLoggingFindings(result, withEnvironment(files)) },
+        { id: MIGRATION_RULE_ID, run: (result, files) => withMigrationFindings(result, withEnvironment(files)) },
+        { id: SECURITY_POLICY_RULE_ID, run: (result, files) => withSecurityPolicyFindings(result, withEnvironment(files)) },
+        { id: RATE_LIMIT_RULE_ID, enabled: rateLimits !== undefined, run: (result, files) => withRateLimitFindings(result, withEnvironment(files), rateLimits) },
+        { id: TLS_SETTING_RULE_ID, run: (result, files) => withTlsSettingFindings(result, withEnvironment(files)) },
+        { id: BROKER_RULE_ID, run: (result, files) => withMessageBrokerFindings(result, withEnvironment(files), brokers) },
+        { id: CLOUD_IDENTITY_RULE_ID, run: (result, files) => withCloudIdentityFindings(result, withEnvironment(files), cloudIdentifiers) },
+        { id: LOCALE_SETTING_RULE_ID, run: withLocaleSettingFindings },
+        { id: CRON_RULE_ID, run: (result, files) => withCronFindings(result, withEnvironment(files), cron) },
+        { id: DSN_RULE_ID, run: (result, files) => withDsnFindings(result, withEnvironment(files)) },
+        { id: BUDGET_RULE_ID, enabled: budgets.length > 0, run: (result, files) => withBudgetFindings(result, withEnvironment(files), budgets) },
+        { id: PERFORMANCE_RULE_ID, enabled: performanceSettings !== undefined, run: (result, files) => withPerformanceAudit(result, withEnvironment(files), performanceSettings) },
+        { id: VALUE_DIFFERENCE_RULE_ID, enabled: valueDifferences.length > 0, run: (result, files) => withValueDifferences(result, withEnvironment(files), valueDifferences) },
+        { id: FORBIDDEN_KEY_RULE_ID, enabled: forbiddenKeys.length > 0, run: (result, files) => withForbiddenKeys(result, withEnvironment(files), forbiddenKeys) },
+        { id: VALUE_RULE_ID, enabled: Object.keys(valueRules).length > 0, run: (result, files) => withValueRules(result, files, valueRules, file => readKeyLocations(file.path, file.format)) },
+        { id: COMMENTED_CONFIG_RULE_ID, enabled: commentedConfig !== undefined, run: (result, files) => withCommentedConfigFindings(result, files, commentedConfig) },
+        {
+          id: FORMAT_LINT_RULE_ID,
+          enabled: formatLint !== undefined,
+          // The raw text is read again from disk; inline and inventory contents have none
+          run: async (result, files) => withFormatLintFindings(result, await new FormatLintAuditor(fileReaderService).readTargets(files.map(file => file.path)), formatLint),
+        },
+        { id: KEY_ORDER_RULE_ID, enabled: keyOrder !== undefined, run: (result, files) => withKeyOrderFindings(result, files, keyOrder) },
+        { id: LEAKAGE_RULE_ID, enabled: leakage !== undefined, run: (result, files) => withLeakage(result, withEnvironment(files), leakage) },
+        {
+          id: ENDPOINT_RULE_ID,
+          enabled: flags['check-endpoints'],
+          run: async (result, files) => !interrupt.isInterrupted()
+            ? withEndpointFindings(result, await this.checkEndpoints(files, flags['head-requests'], context.http ?? {}, userConfig.concurrency))
+            : result,
+        },
+        { id: WORKFLOW_RULE_ID, files: [...configFiles, ...workflowFiles], run: withWorkflowFindings },
+      ];
+      const compared = limited.warnings.length === 0
+        ? evaluation.value
+        : withFindings(evaluation.value, [...limited.warnings, ...collectFindings(evaluation.value)]);
+      // --rule-set wins over the ruleSets of praetorian.yaml, which win over the user configuration's
+      const packed = await runRulePacks(compared, selectRulePacks(rulePacks, flags['rule-set'] ?? ruleSets), scopes, configFiles);
+      const ranRules = [rule.id, ...packed.timings.map(timing => timing.id)];
+      const timed = this.withPerformance(
+        packed.result,
+        buildPerformanceMetadata(configFiles, [{ id: rule.id, evaluationMs: evaluation.ms }, ...packed.timings], performance.now() - startedAt)
+      );
+      const attribution = { environment: flags.env, environmentFiles };
+      const redactor = createRedactor({
+        ...redaction,
+        ...(flags.redact !== undefined ? { policy: flags.redact as RedactionPolicyName } : {}),
+      });
+      // Values are redacted before templates render them into messages
+      const escalated = applyMessageTemplates(
+        redactResult(
+          applySeverityEscalation(
+            this.withReadFailures(timed, failures),
+            escalationRules,
+            attribution
+          ),
+          redactor
+        ),
+        this.language === 'en' ? messageTemplates : { ...findingTemplates(this.language), ...messageTemplates },
+        attribution
+      );
+      // Owners are resolved after aggregation, from the files each aggregated finding lists
+      const aggregated = withOwners(withFileLabels(flags.expand ? escalated : aggregateResult(escalated), fileLabels), owners);
+      const result = interrupt.isInterrupted()
+        ? { ...aggregated, metadata: { ...aggregated.metadata, interrupted: true } }
+        : aggregated;
+      this.logger.info('Validation finished', {
+        success: result.success,
+        errors: result.errors.length,
+        warnings: result.warnings.length,
+        durationMs: result.metadata?.duration ?? 0,
+      });
 
       // Display results
-      this.displayResults(result, flags.output, flags.pipeline);
+      if (quiet && !isStructuredFormat(output)) {
+        this.displayQuietResults(result, flags['min-severity'] as ValidationSeverity);
+      } else {
+        this.displayResults(result, output, flags.pipeline, { files: configFiles.map(file => file.path), configFile: flags.config });
+      }
+
+      if (flags.verbose && !isStructuredFormat(output)) {
+        this.displayPerformance(result.metadata?.performance);
+      }
+
+      // Guard clause: interrupted, the partial result is flushed and post hooks are skipped
+      if (interrupt.isInterrupted()) {
+        // stderr keeps structured --output parseable
+        console.error(chalk.yellow(this.t('validate.interrupted', { signal: interrupt.getSignal() ?? 'SIGINT' })));
+        await this.stopProfiler(profiler);
+        exitCode = EXIT_CODES.INTERRUPTED;
+      } else {
+        hooks.post.forEach(command => this.logger.info('Running post hook', { command }));
+        await runPostHooks(hooks.post, { ...hookInfo, result }, hookOptions);
+
+        // Telemetry writes its counters to the user config directory
+        if (!sandbox) {
+          await this.recordTelemetry(configFiles.map(file => file.format), ranRules, userConfig.telemetry);
+        }
+        await this.stopProfiler(profiler);
 
-      // Exit with appropriate code
-      if (!result.success) {
-        this.exit(1);
+        exitCode = exitCodeForResult(result, flags['fail-on'] as FailOn);
       }
 
+      // A refused write fails the run even when the code that attempted it carried on
+      sandbox?.assertClean();
     } catch (error) {
-      this.error(error instanceof Error ? error.message : 'Unknown error');
-      this.exit(1);
-    }
-  }
-
-  private async loadFiles(filePaths: string[]): Promise<ConfigFile[]> {
-    const fileReaderService = new FileReaderService();
-    
-    // Validate files before reading
-    const { valid, invalid } = fileReaderService.validateFiles(filePaths);
-    
-    if (invalid.length > 0) {
-      const supportedExtensions = fileReaderService.getSupportedExtensions().join(', ');
-      throw new Error(
-        `Unsupported file formats: ${invalid.join(', ')}. ` +
-        `Supported extensions: ${supportedExtensions}`
-      );
+      await this.stopProfiler(profiler);
+      const failure = sandbox?.violation() ?? error;
+
+      // A hook killed for going over its limits is a finding, not a tool failure
+      if (failure instanceof HookLimitError) {
+        this.reportHookLimit(failure.breach, output, flags.pipeline, flags.config);
+        // The breach is an error finding
+        exitCode = exitCodeForResult({ success: false, warnings: [] }, flags['fail-on'] as FailOn);
+      } else {
+        this.error(failure instanceof Error ? failure.message : 'Unknown error', { exit: exitCodeFor(failure) });
+      }
+    } finally {
+      interrupt.dispose();
+      sandbox?.dispose();
+    }
+
+    // Exit outside the try block so the exit itself is not reported as an error
+    if (exitCode !== EXIT_CODES.SUCCESS) {
+      this.exit(exitCode);
+    }
+  }
+
+  /**
+   * Read the user configuration; a broken one stops the run before anything is printed
+   */
+  private readUserConfig(): UserConfig {
+    try {
+      return loadUserConfig();
+    } catch (error) {
+      return this.error(error instanceof Error ? error.message : 'Unknown error', { exit: exitCodeFor(error) });
+    }
+  }
+
+  private async stopProfiler(profiler: RunProfiler): Promise<void> {
+    try {
+      const written = await profiler.stop();
+      written.forEach(file => this.logger.info('Profile written', { file }));
+    } catch (error) {
+      this.logger.warn('Profile not written', { error: error instanceof Error ? error.message : 'Unknown error' });
+    }
+  }
+
+  private async recordTelemetry(formats: string[], ruleIds: string[], choice?: boolean): Promise<void> {
+    const telemetry = new TelemetryStore(process.env, undefined, choice);
+    telemetry.record({ command: 'validate', formats, ruleCategories: ruleIds });
+
+    try {
+      await telemetry.flush(this.config.version);
+    } catch (error) {
+      this.logger.debug('Telemetry not sent', { error: error instanceof Error ? error.message : 'Unknown error' });
+    }
+  }
+
+  private httpSettingsFromFlags(flags: Record<string, any>): HttpSettings {
+    return {
+      ...(flags['http-timeout'] !== undefined ? { timeoutMs: flags['http-timeout'] } : {}),
+      ...(flags['http-retries'] !== undefined ? { retries: flags['http-retries'] } : {}),
+      ...(flags.proxy !== undefined ? { proxy: flags.proxy } : {}),
+      ...(flags['ca-file'] !== undefined ? { caFile: flags['ca-file'] } : {}),
+    };
+  }
+
+  private async checkEndpoints(files: ConfigFile[], head: boolean, httpSettings: HttpSettings, concurrency?: number): Promise<ValidationError[]> {
+    const targets = endpointTargets(files);
+    this.logger.info('Checking endpoints', { count: new Set(targets.map(target => target.url)).size, head });
+
+    const outcomes = await probeEndpoints(targets.map(target => target.url), {
+      head,
+      // A single attempt unless retries are configured: retrying an unreachable host only slows the report down
+      client: head ? new HttpClient({ retries: 0, ...httpSettings }) : undefined,
+    }, concurrency);
+    return endpointFindings(targets, outcomes);
+  }
+
+  private async loadInventories(paths: string[]): Promise<ConfigFile[]> {
+    const inventories = await loadAnsibleInventories(paths);
+    inventories.forEach(inventory => this.logger.debug('Read Ansible inventory', { file: inventory.path, groups: Object.keys(inventory.content).length }));
+    return inventories;
+  }
+
+  private workflowPaths(filesToCompare: string[]): string[] {
+    return WORKFLOW_GLOBS
+      .flatMap(pattern => expandFileGlob(pattern))
+      .filter(filePath => !filesToCompare.includes(filePath));
+  }
+
+  /**
+   * Report a hook killed for going over its limits. A pre hook stops the run before any file is
+   * read, so the breach is the whole result; post hooks run once the result has been shown.
+   */
+  private reportHookLimit(breach: HookLimitBreach, outputFormat: string, isPipelineMode: boolean, configFile: string) {
+    const result = applyMessageTemplates(
+      withFindings(this.emptyResult([]), [hookLimitFinding(breach)]),
+      this.language === 'en' ? {} : findingTemplates(this.language)
+    );
+
+    // Guard clause: the result is already out; stderr keeps structured --output parseable
+    if (breach.stage === 'post') {
+      console.error(chalk.red(result.errors[0].message));
+      return;
+    }
+
+    this.displayResults(result, outputFormat, isPipelineMode, { configFile });
+  }
+
+  private emptyResult(configFiles: ConfigFile[]): ValidationResult {
+    return {
+      success: true,
+      errors: [],
+      warnings: [],
+      metadata: { duration: 0, filesCompared: configFiles.length, totalKeys: 0 },
+    };
+  }
+
+  private withPerformance(result: ValidationResult, performanceMetadata: PerformanceMetadata): ValidationResult {
+    return {
+      ...result,
+      metadata: { ...result.metadata, performance: performanceMetadata },
+    };
+  }
+
+  private withReadFailures(result: ValidationResult, failures: FileReadFailure[]): ValidationResult {
+    // Guard clause: every file was read
+    if (failures.length === 0) {
+      return result;
     }
-    
-    return await fileReaderService.readFiles(valid);
+
+    const parseErrors: ValidationError[] = failures.map(failure => {
+      this.logger.warn('Skipping unreadable file', { file: failure.path, error: failure.error });
+      return {
+        code: 'PARSE_ERROR',
+        message: failure.error,
+        severity: 'error',
+        context: {
+          file: failure.path,
+          ...(failure.line !== undefined ? { line: failure.line } : {}),
+          ...(failure.column !== undefined ? { column: failure.column } : {}),
+          extras: { error: failure.error },
+        },
+      };
+    });
+
+    return {
+      ...result,
+      success: false,
+      errors: [...parseErrors, ...result.errors],
+    };
+  }
+
+  private async loadFiles(fileReaderService: FileReaderService, filePaths: string[], signal?: AbortSignal): Promise<ConfigFile[]> {
+    // Files with unknown extensions are still attempted: the reader sniffs their content
+    filePaths
+      .filter(filePath => !fileReaderService.isSupported(filePath))
+      .forEach(filePath => this.logger.debug('Unknown extension, sniffing content', { file: filePath }));
+
+    filePaths.forEach(filePath => this.logger.debug('Reading file', { file: filePath }));
+    return await fileReaderService.readFiles(filePaths, signal);
   }
 
-  private displayResults(result: any, outputFormat: string, isPipelineMode: boolean = false) {
-    if (outputFormat === 'json') {
-      console.log(JSON.stringify(result, null, 2));
+  private displayResults(result: ValidationResult, outputFormat: string, isPipelineMode: boolean = false, options: SerializeOptions = {}) {
+    if (isStructuredFormat(outputFormat)) {
+      console.log(serializeResult(result, outputFormat, { toolVersion: this.config.version, ...options }));
       return;
     }
 
@@ -128,21 +791,21 @@ export default class Validate extends Command {
     this.displayUserResults(result);
   }
 
-  private displayPipelineResults(result: any) {
+  private displayPipelineResults(result: ValidationResult) {
     // Pipeline mode - concise output for CI/CD
     if (result.success) {
-      console.log(chalk.green('✅ PRAETORIAN_VALIDATION: PASSED'));
+      this.print(chalk.green('✅ PRAETORIAN_VALIDATION: PASSED'));
     } else {
-      console.log(chalk.red('❌ PRAETORIAN_VALIDATION: FAILED'));
+      this.print(chalk.red('❌ PRAETORIAN_VALIDATION: FAILED'));
       
       // Show only critical errors for pipeline
       const criticalErrors = result.errors?.slice(0, 5) || [];
       for (const error of criticalErrors) {
-        console.log(chalk.red(`  • ${error.message}`));
+        this.print(chalk.red(`  • ${error.message}`));
       }
       
       if (result.errors?.length > 5) {
-        console.log(chalk.red(`  • ... and ${result.errors.length - 5} more errors`));
+        this.print(chalk.red(`  • ... and ${result.errors.length - 5} more errors`));
       }
     }
 
@@ -152,57 +815,161 @@ export default class Validate extends Command {
       const errors = result.errors?.length || 0;
       const warnings = result.warnings?.length || 0;
       
-      console.log(chalk.blue(`PRAETORIAN_SUMMARY: files=${files}, errors=${errors}, warnings=${warnings}, duration=${result.metadata.duration || 0}ms`));
+      this.print(chalk.blue(`PRAETORIAN_SUMMARY: files=${files}, errors=${errors}, warnings=${warnings}, duration=${result.metadata.duration || 0}ms`));
     }
   }
 
-  private displayUserResults(result: any) {
+  private displayQuietResults(result: ValidationResult, minSeverity: ValidationSeverity) {
+    // Quiet/CI mode - single summary line plus relevant findings
+    const findings = [...(result.errors ?? []), ...(result.warnings ?? []), ...(result.info ?? [])]
+      .filter((finding: any) => isAtLeast(finding.severity, minSeverity));
+    const status = result.success ? chalk.green('PASSED') : chalk.red('FAILED');
+
+    this.print(
+      `PRAETORIAN: ${status} files=${result.metadata?.filesCompared || 0} ` +
+      `errors=${result.errors?.length || 0} warnings=${result.warnings?.length || 0} ` +
+      `duration=${result.metadata?.duration || 0}ms`
+    );
+
+    for (const severity of SEVERITIES.slice().reverse()) {
+      this.printCapped(
+        findings.filter((finding: any) => finding.severity === severity),
+        severity,
+        (finding: any) => `  ${finding.severity} ${finding.code}: ${finding.message}`
+      );
+    }
+  }
+
+  private displayUserResults(result: ValidationResult) {
     // User mode - detailed output with explanations
-    console.log(chalk.blue('\n📊 Validation Results:\n'));
+    this.print(chalk.blue(this.t('validate.resultsTitle')));
 
     if (result.success) {
-      console.log(chalk.green('✅ All files have consistent keys!'));
-      console.log(chalk.gray('   Your configuration files are properly synchronized across environments.'));
+      this.print(chalk.green(this.t('validate.consistent')));
+      this.print(chalk.gray(this.t('validate.consistentDetail')));
     } else {
-      console.log(chalk.red('❌ Key inconsistencies found:'));
-      console.log(chalk.gray('   The following keys are missing in some configuration files:'));
+      this.print(chalk.red(this.t('validate.inconsistent')));
+      this.print(chalk.gray(this.t('validate.inconsistentDetail')));
       
-      for (const error of result.errors) {
-        console.log(chalk.red(`  • ${error.message}`));
-      }
+      this.printCapped(result.errors, 'error', (error: any) => chalk.red(`  • ${error.message}`));
       
-      console.log(chalk.yellow('\n💡 Tip: Use --pipeline flag for concise CI/CD output'));
+      this.print(chalk.yellow(this.t('validate.pipelineTip')));
     }
 
     if (result.warnings && result.warnings.length > 0) {
-      console.log(chalk.yellow(`\n⚠️  ${result.warnings.length} warning(s):`));
-      for (const warning of result.warnings) {
-        console.log(chalk.yellow(`  • ${warning.message}`));
-      }
+      this.print(chalk.yellow(this.t('validate.warnings', { count: result.warnings.length })));
+      this.printCapped(result.warnings, 'warning', (warning: any) => chalk.yellow(`  • ${warning.message}`));
     }
 
     // Mostrar claves vacías como información (no afecta el pipeline)
     if (result.info && result.info.length > 0) {
-      console.log(chalk.blue(`\nℹ️  ${result.info.length} empty key(s) found (informational):`));
-      for (const info of result.info) {
-        console.log(chalk.blue(`  • ${info.message}`));
-      }
-      console.log(chalk.gray('    Note: Empty keys are informational only and do not affect validation success'));
+      this.print(chalk.blue(this.t('validate.emptyKeys', { count: result.info.length })));
+      this.printCapped(result.info, 'info', (info: any) => chalk.blue(`  • ${info.message}`));
+      this.print(chalk.gray(this.t('validate.emptyKeysNote')));
     }
 
     // Summary
     if (result.metadata) {
-      console.log(chalk.blue('\n📈 Summary:'));
-      console.log(`  • Files compared: ${result.metadata.filesCompared || 0}`);
-      console.log(`  • Total keys: ${result.metadata.totalKeys || 0}`);
-      console.log(`  • Empty keys: ${result.metadata.emptyKeys || 0}`);
-      console.log(`  • Duration: ${result.metadata.duration || 0}ms`);
+      this.print(chalk.blue(this.t('validate.summary')));
+      this.print(this.t('validate.summary.files', { count: result.metadata.filesCompared || 0 }));
+      this.print(this.t('validate.summary.keys', { count: result.metadata.totalKeys || 0 }));
+      this.print(this.t('validate.summary.emptyKeys', { count: result.metadata.emptyKeys || 0 }));
+      this.print(this.t('validate.summary.duration', { duration: result.metadata.duration || 0 }));
+      this.displayGroups(result);
+      this.displayOwners(result);
       
       if (result.success) {
-        console.log(chalk.green('\n🎉 Validation completed successfully!'));
+        this.print(chalk.green(this.t('validate.success')));
       } else {
-        console.log(chalk.red('\n🔧 Fix the inconsistencies above and run validation again.'));
+        this.print(chalk.red(this.t('validate.failure')));
+      }
+    }
+  }
+
+  /**
+   * Merge `--label key=value` flags into one selector
+   */
+  private labelSelector(flags: string[]): Labels {
+    return flags.reduce<Labels>((selector, flag) => {
+      const label = parseLabelSelector(flag);
+
+      // Guard clause: not a key=value pair
+      if (!label) {
+        throw new ConfigError(`Invalid --label "${flag}": expected key=value`);
       }
+
+      return { ...selector, ...label };
+    }, {});
+  }
+
+  private displayOwners(result: ValidationResult) {
+    // Guard clause: findings were not routed to owners
+    if (!result.owners || result.owners.length === 0) {
+      return;
+    }
+
+    this.print(chalk.blue(this.t('validate.owners')));
+    result.owners.forEach(owner => {
+      const line = this.t('validate.owner', {
+        team: owner.team,
+        channel: owner.channel ? ` (${owner.channel})` : '',
+        errors: owner.errors,
+        warnings: owner.warnings,
+      });
+      this.print(owner.errors > 0 ? chalk.red(line) : chalk.yellow(line));
+    });
+  }
+
+  private displayGroups(result: ValidationResult) {
+    // Guard clause: files were not compared in groups
+    if (!result.groups || result.groups.length === 0) {
+      return;
+    }
+
+    this.print(chalk.blue(this.t('validate.groups')));
+    result.groups.forEach(group => {
+      const line = this.t('validate.group', {
+        name: group.name,
+        files: group.files.length,
+        errors: group.errors,
+        warnings: group.warnings,
+      });
+      this.print(group.success ? chalk.green(line) : chalk.red(line));
+    });
+  }
+
+  private displayPerformance(performanceMetadata?: PerformanceMetadata) {
+    // Guard clause: nothing was measured
+    if (!performanceMetadata) {
+      return;
+    }
+
+    this.print(chalk.blue(this.t('validate.performance')));
+    this.print(this.t('validate.performance.total', { duration: performanceMetadata.totalMs }));
+    for (const file of slowestFirst(performanceMetadata.files, file => file.parseMs)) {
+      this.print(chalk.gray(this.t('validate.performance.file', { file: file.path, format: file.format, duration: file.parseMs })));
     }
+    for (const rule of slowestFirst(performanceMetadata.rules, rule => rule.evaluationMs)) {
+      this.print(chalk.gray(this.t('validate.performance.rule', { rule: rule.id, duration: rule.evaluationMs })));
+    }
+  }
+
+  private printCapped(findings: any[], severity: string, render: (finding: any) => string) {
+    const { shown, overflow } = capFindings(findings, this.maxFindings);
+
+    for (const finding of shown) {
+      this.print(render(finding));
+    }
+    for (const hidden of overflow) {
+      this.print(chalk.gray(`  ${formatOverflow(hidden, severity, this.language)}`));
+    }
+  }
+
+  private t(id: string, variables: Record<string, any> = {}): string {
+    return translate(id, variables, this.language);
+  }
+
+  private print(line: string = '') {
+    console.log(styleLine(line, this.style));
   }
-} 
\ No newline at end of file
+}
@@ -0,0 +1,234 @@
+/**
+ * ComparisonStrategies - How the keys of several files are compared
+ *
+ * Single Responsibility: Turn the keys (and values) of the compared files into
+ * findings according to one comparison semantic. EqualityRule selects the
+ * strategy; every strategy is a pure function of its input.
+ */
+
+import { ComparisonStrategyName, ConfigFile, ValidationError, ValidationWarning } from '../../shared/types';
+import { extractKeyValues, isPlainObject } from '../../shared/utils/KeyPaths';
+import { normalizePath } from '../../shared/utils/Glob';
+import { quantityOf } from '../../shared/utils/Quantities';
+
+export interface ComparisonInput {
+  files: ConfigFile[];
+  isIgnored: (key: string) => boolean;
+  /** Path of the reference file; defaults to the first file */
+  reference?: string;
+}
+
+export interface ComparisonOutput {
+  errors: ValidationError[];
+  warnings: ValidationWarning[];
+}
+
+export interface ComparisonStrategy {
+  name: ComparisonStrategyName;
+  description: string;
+  compare(input: ComparisonInput): ComparisonOutput;
+}
+
+export const COMPARISON_STRATEGIES: ComparisonStrategyName[] = ['strict', 'subset-of-reference', 'symmetric', 'value-aware'];
+
+export const DEFAULT_COMPARISON_STRATEGY: ComparisonStrategyName = 'strict';
+
+/**
+ * Pure function to list the non-ignored keys of a file
+ */
+const fileKeys = (file: ConfigFile, isIgnored: (key: string) => boolean): Set<string> =>
+  new Set([...extractKeyValues(file.content).keys()].filter(key => !isIgnored(key)));
+
+/**
+ * Pure function to build a MISSING_KEY finding
+ */
+export const missingKeyError = (file: ConfigFile, missingKey: string, availableKeys: Set<string>): ValidationError => ({
+  code: 'MISSING_KEY',
+  message: `Key '${missingKey}' is missing in ${file.path}`,
+  severity: 'error',
+  path: missingKey,
+  context: {
+    file: file.path,
+    keyPath: missingKey,
+    extras: { availableKeys: Array.from(availableKeys) }
+  }
+});
+
+/**
+ * Pure function to pick the reference file (configured path or the first file)
+ */
+export const findReferenceFile = (files: ConfigFile[], reference?: string): ConfigFile | undefined =>
+  reference ? files.find(file => normalizePath(file.path) === normalizePath(reference)) : files[0];
+
+/**
+ * Pure function to report a configured reference that is not among the compared files
+ */
+const referenceNotFound = (files: ConfigFile[], reference?: string): ValidationError[] =>
+  reference && files.length > 0
+    ? [{
+      code: 'REFERENCE_NOT_FOUND',
+      message: `Reference file ${reference} is not among the compared files`,
+      severity: 'error',
+      context: { files: files.map(file => file.path), extras: { reference } }
+    }]
+    : [];
+
+/**
+ * Pure function to report keys a file lacks compared to an expected key set
+ */
+const missingAgainst = (file: ConfigFile, expected: Set<string>): ValidationError[] => {
+  const keys = new Set(extractKeyValues(file.content).keys());
+  return Array.from(expected)
+    .filter(key => !keys.has(key))
+    .map(key => missingKeyError(file, key, keys));
+};
+
+/**
+ * Every key found in any file must exist in every file
+ */
+const strictStrategy: ComparisonStrategy = {
+  name: 'strict',
+  description: 'Every key found in any file must exist in all files',
+  compare: ({ files, isIgnored }) => {
+    const union = new Set(files.flatMap(file => [...fileKeys(file, isIgnored)]));
+    return { errors: files.flatMap(file => missingAgainst(file, union)), warnings: [] };
+  }
+};
+
+/**
+ * Every key of the reference file must exist in the others; extra keys are allowed
+ */
+const subsetOfReferenceStrategy: ComparisonStrategy = {
+  name: 'subset-of-reference',
+  description: 'Files must contain every key of the reference file and may add their own',
+  compare: ({ files, isIgnored, reference }) => {
+    const referenceFile = findReferenceFile(files, reference);
+
+    // Guard clause: nothing to compare against
+    if (!referenceFile) {
+      return { errors: referenceNotFound(files, reference), warnings: [] };
+    }
+
+    const expected = fileKeys(referenceFile, isIgnored);
+    return {
+      errors: files
+        .filter(file => file !== referenceFile)
+        .flatMap(file => missingAgainst(file, expected)),
+      warnings: []
+    };
+  }
+};
+
+/**
+ * Files must have exactly the keys of the reference file: missing and extra keys are reported
+ */
+const symmetricStrategy: ComparisonStrategy = {
+  name: 'symmetric',
+  description: 'Files must have exactly the keys of the reference file',
+  compare: input => {
+    const referenceFile = findReferenceFile(input.files, input.reference);
+
+    // Guard clause: nothing to compare against
+    if (!referenceFile) {
+      return { errors: referenceNotFound(input.files, input.reference), warnings: [] };
+    }
+
+    const expected = fileKeys(referenceFile, input.isIgnored);
+    const extraKeys = input.files
+      .filter(file => file !== referenceFile)
+      .flatMap(file => {
+        const keys = fileKeys(file, input.isIgnored);
+        return Array.from(keys)
+          .filter(key => !expected.has(key))
+          .map((key): ValidationError => ({
+            code: 'EXTRA_KEY',
+            message: `Key '${key}' in ${file.path} is not in the reference ${referenceFile.path}`,
+            severity: 'error',
+            path: key,
+            context: { file: file.path, keyPath: key, extras: { reference: referenceFile.path } }
+          }));
+      });
+
+    return {
+      errors: [...subsetOfReferenceStrategy.compare(input).errors, ...extraKeys],
+      warnings: []
+    };
+  }
+};
+
+/**
+ * Pure function to describe the kind of a value (object, array, string, number, boolean)
+ */
+export const valueKind = (value: unknown): string => {
+  if (Array.isArray(value)) return 'array';
+  if (isPlainObject(value)) return 'object';
+  return typeof value;
+};
+
+/**
+ * Pure function to describe the kind of a value, reading durations and sizes as quantities
+ */
+export const quantityAwareKind = (value: unknown): string => quantityOf(value)?.kind ?? valueKind(value);
+
+/**
+ * Pure function to check if value kinds disagree. A bare number next to a duration or size
+ * is the same quantity in base units (`30000` and `30s`, `10485760` and `10MB`).
+ */
+export const kindsDiffer = (kinds: string[]): boolean => {
+  const distinct = [...new Set(kinds)];
+  const quantities = distinct.filter(kind => kind === 'duration' || kind === 'size');
+
+  return quantities.length === 1
+    ? distinct.some(kind => kind !== quantities[0] && kind !== 'number')
+    : distinct.length > 1;
+};
+
+/**
+ * Strict key comparison plus a warning when a key holds different kinds of values across files
+ */
+const valueAwareStrategy: ComparisonStrategy = {
+  name: 'value-aware',
+  description: 'Strict key comparison that also flags keys whose value types differ between files',
+  compare: input => {
+    const values = input.files.map(file => ({ file, values: extractKeyValues(file.content) }));
+    const keys = new Set(values.flatMap(entry => [...entry.values.keys()].filter(key => !input.isIgnored(key))));
+
+    const warnings = Array.from(keys).flatMap((key): ValidationWarning[] => {
+      // Empty values are reported as EMPTY_KEY, so they don't count as a type
+      const kinds = values
+        .filter(entry => entry.values.has(key) && entry.values.get(key) !== null && entry.values.get(key) !== undefined)
+        .map(entry => ({ file: entry.file.path, kind: quantityAwareKind(entry.values.get(key)) }));
+
+      return kindsDiffer(kinds.map(entry => entry.kind))
+        ? [{
+          code: 'VALUE_TYPE_MISMATCH',
+          message: `Key '${key}' has different value types across files: ${kinds.map(entry => `${entry.kind} in ${entry.file}`).join(', ')}`,
+          severity: 'warning',
+          path: key,
+          context: { keyPath: key, extras: { types: Object.fromEntries(kinds.map(entry => [entry.file, entry.kind])) } }
+        }]
+        : [];
+    });
+
+    return { errors: strictStrategy.compare(input).errors, warnings };
+  }
+};
+
+const STRATEGIES: Record<ComparisonStrategyName, ComparisonStrategy> = {
+  'strict': strictStrategy,
+  'subset-of-reference': subsetOfReferenceStrategy,
+  'symmetric': symmetricStrategy,
+  'value-aware': valueAwareStrategy
+};
+
+/**
+ * Pure function to check if a name is a known comparison strategy
+ */
+export const isComparisonStrategy = (name: unknown): name is ComparisonStrategyName =>
+  typeof name === 'string' && Object.prototype.hasOwnProperty.call(STRATEGIES, name);
+
+/**
+ * Pure function to get a comparison strategy by name (strict by default)
+ */
+export const getComparisonStrategy = (name?: ComparisonStrategyName): ComparisonStrategy =>
+  STRATEGIES[name ?? DEFAULT_COMPARISON_STRATEGY] ?? STRATEGIES[DEFAULT_COMPARISON_STRATEGY];
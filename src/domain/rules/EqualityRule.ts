@@ -1,4 +1,13 @@
 import { ValidationRule, ValidationResult, ConfigFile, ValidationError, ValidationWarning, ValidationInfo, ValidationContext } from '../../shared/types';
+import {
+  extractKeyPaths,
+  findMissingWildcardKeys,
+  isWildcardPattern
+} from '../../shared/utils/KeyPaths';
+import { limitFiles, resolveExtractionLimits } from '../../shared/utils/ExtractionLimits';
+import { getComparisonStrategy } from './ComparisonStrategies';
+import { detectRenames } from './KeyRenames';
+import { suggestMissingKeys } from './KeySuggestions';
 
 export class EqualityRule implements ValidationRule {
   id = 'equality-rule';
@@ -33,11 +42,16 @@ export class EqualityRule implements ValidationRule {
       };
     }
 
+    // Pasada 0: Acotar profundidad y cantidad de claves antes de recorrer los archivos
+    // (validate ya los acota al leerlos; aquí no recortan nada más)
+    const limitsReport = limitFiles(files, resolveExtractionLimits(context?.limits));
+    files = limitsReport.files;
+
     // Pasada 1: Recolectar todas las claves de todos los archivos (excluyendo ignoradas)
     const masterKeyDictionary = this.collectAllKeys(files, ignoreKeys);
     
     // Pasada 2: Comparar diferencias - qué le falta a cada archivo
-    const missingKeysReport = this.compareDifferences(files, masterKeyDictionary, ignoreKeys);
+    const missingKeysReport = this.compareDifferences(files, ignoreKeys, context);
     
     // Pasada 3: Validar claves requeridas
     const requiredKeysReport = this.validateRequiredKeys(files, requiredKeys);
@@ -47,7 +61,7 @@ export class EqualityRule implements ValidationRule {
     
     // Combinar todos los errores y warnings
     const allErrors = [...missingKeysReport.errors, ...requiredKeysReport.errors];
-    const allWarnings = [...missingKeysReport.warnings, ...requiredKeysReport.warnings];
+    const allWarnings = [...limitsReport.warnings, ...missingKeysReport.warnings, ...requiredKeysReport.warnings];
     
     // Las claves vacías NO afectan el success - solo son información
     const success = allErrors.length === 0;
@@ -81,54 +95,26 @@ export class EqualityRule implements ValidationRule {
     );
   }
 
-  // Pasada 2: Comparar diferencias - qué le falta a cada archivo
+  // Pasada 2: Comparar diferencias según la estrategia configurada (strict por defecto)
   private compareDifferences(
-    files: ConfigFile[], 
-    masterKeyDictionary: Set<string>,
-    ignoreKeys: string[]
+    files: ConfigFile[],
+    ignoreKeys: string[],
+    context?: ValidationContext
   ): { errors: ValidationError[]; warnings: ValidationWarning[] } {
-    const errors = files.flatMap(file => {
-      const fileKeys = this.extractAllKeys(file.content);
-      
-      // Encontrar claves que faltan en este archivo (excluyendo ignoradas)
-      const missingKeys = Array.from(masterKeyDictionary).filter(
-        masterKey => !fileKeys.has(masterKey) && !this.isKeyIgnored(masterKey, ignoreKeys)
-      );
-      
-      // Crear errores por cada clave faltante
-      return missingKeys.map(missingKey => ({
-        code: 'MISSING_KEY',
-        message: `Key '${missingKey}' is missing in ${file.path}`,
-        severity: 'error' as const,
-        path: missingKey,
-        context: { 
-          file: file.path, 
-          missingKey,
-          availableKeys: Array.from(fileKeys)
-        }
-      }));
-    });
+    const strategy = getComparisonStrategy(context?.comparison?.strategy);
+    const input = {
+      files,
+      isIgnored: (key: string) => this.isKeyIgnored(key, ignoreKeys),
+      reference: context?.comparison?.reference
+    };
 
-    return { errors, warnings: [] };
+    // Una clave que falta junto a otra parecida que sobra se reporta como renombrada;
+    // las demás claves que faltan sugieren las claves parecidas del archivo
+    return suggestMissingKeys(detectRenames(strategy.compare(input), input), input);
   }
 
   private extractAllKeys(obj: any, prefix = ''): Set<string> {
-    const keys = new Set<string>();
-    
-    if (obj && typeof obj === 'object' && !Array.isArray(obj)) {
-      for (const [key, value] of Object.entries(obj)) {
-        const fullKey = prefix ? `${prefix}.${key}` : key;
-        keys.add(fullKey);
-        
-        // Recursively extract nested keys
-        if (value && typeof value === 'object' && !Array.isArray(value)) {
-          const nestedKeys = this.extractAllKeys(value, fullKey);
-          nestedKeys.forEach(nestedKey => keys.add(nestedKey));
-        }
-      }
-    }
-    
-    return keys;
+    return extractKeyPaths(obj, prefix);
   }
 
   // Verificar si una clave debe ser ignorada
@@ -151,18 +137,21 @@ export class EqualityRule implements ValidationRule {
     const errors = requiredKeys.flatMap(requiredKey =>
       files.flatMap(file => {
         const fileKeys = this.extractAllKeys(file.content);
+        const missingKeys = isWildcardPattern(requiredKey)
+          ? findMissingWildcardKeys(file.content, requiredKey)
+          : fileKeys.has(requiredKey) ? [] : [requiredKey];
         
-        return !fileKeys.has(requiredKey) ? [{
+        return missingKeys.map(missingKey => ({
           code: 'REQUIRED_KEY_MISSING',
-          message: `Required key '${requiredKey}' is missing in ${file.path}`,
+          message: `Required key '${missingKey}' is missing in ${file.path}`,
           severity: 'error' as const,
-          path: requiredKey,
+          path: missingKey,
           context: { 
             file: file.path, 
-            requiredKey,
-            availableKeys: Array.from(fileKeys)
+            keyPath: missingKey,
+            extras: { requiredKey, availableKeys: Array.from(fileKeys) }
           }
-        }] : [];
+        }));
       })
     );
 
@@ -208,9 +197,9 @@ export class EqualityRule implements ValidationRule {
             path: fullKey,
             context: {
               file: filePath,
-              key: fullKey,
-              value: value,
-              valueType: typeof value
+              keyPath: fullKey,
+              observedValue: value,
+              extras: { valueType: typeof value }
             }
           });
         }
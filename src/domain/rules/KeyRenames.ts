@@ -0,0 +1,142 @@
+/**
+ * KeyRenames - Probable key renames between compared files
+ *
+ * Single Responsibility: Recognize a key missing in one file next to a similar key the
+ * other files lack — a close name, or the same section under another name — and report
+ * the pair as one KEY_RENAMED finding instead of unrelated MISSING_KEY and EXTRA_KEY ones.
+ * Pure functions, no state, no side effects
+ */
+
+import { ConfigFile, ValidationError } from '../../shared/types';
+import { canonicalJson } from '../../shared/utils/CanonicalHash';
+import { extractKeyValues, isPlainObject } from '../../shared/utils/KeyPaths';
+import { levenshtein } from '../../shared/utils/Similarity';
+import { ComparisonInput, ComparisonOutput } from './ComparisonStrategies';
+
+/** Largest edit distance between two key names, relative to the longer one, still read as a rename */
+export const RENAME_DISTANCE_RATIO = 0.25;
+
+/** Names shorter than this are too short for their edit distance to mean anything */
+const MIN_RENAMED_NAME_LENGTH = 4;
+
+const parentOf = (key: string): string => key.slice(0, Math.max(0, key.lastIndexOf('.')));
+
+const nameOf = (key: string): string => key.slice(key.lastIndexOf('.') + 1);
+
+// `dbHost`, `db_host` and `db-host` are one name written in different conventions
+const normalizeName = (name: string): string => name.toLowerCase().replace(/[_-]/g, '');
+
+/**
+ * Pure function to score how likely two sibling keys are one key renamed
+ * @param missing - Key the file lacks, with its value in the files that have it
+ * @param candidate - Key only the file has, with its value there
+ * @returns 0 for the same section under another name, up to RENAME_DISTANCE_RATIO for close
+ * names, undefined when the keys look unrelated
+ */
+export const renameScore = (
+  missing: { key: string; value: unknown },
+  candidate: { key: string; value: unknown }
+): number | undefined => {
+  // Guard clause: renames keep their parent
+  if (parentOf(missing.key) !== parentOf(candidate.key)) {
+    return undefined;
+  }
+
+  if (isPlainObject(missing.value) && Object.keys(missing.value).length > 0 && canonicalJson(missing.value) === canonicalJson(candidate.value)) {
+    return 0;
+  }
+
+  const [a, b] = [normalizeName(nameOf(missing.key)), normalizeName(nameOf(candidate.key))];
+  const longest = Math.max(a.length, b.length);
+
+  // Guard clause: names too short to compare
+  if (Math.min(a.length, b.length) < MIN_RENAMED_NAME_LENGTH) {
+    return undefined;
+  }
+
+  const ratio = levenshtein(a, b) / longest;
+  return ratio <= RENAME_DISTANCE_RATIO ? Math.max(ratio, Number.EPSILON) : undefined;
+};
+
+const isUnder = (key: string, root: string): boolean => key === root || key.startsWith(`${root}.`);
+
+const renamedFinding = (file: ConfigFile, owners: ConfigFile[], missing: string, renamed: string): ValidationError => {
+  const others = owners.map(owner => owner.path).join(', ');
+
+  return {
+    code: 'KEY_RENAMED',
+    message: `Key '${missing}' is missing in ${file.path}, which has '${renamed}' instead: probably renamed (${others} keep '${missing}')`,
+    severity: 'error',
+    path: missing,
+    context: { file: file.path, keyPath: missing, extras: { renamed, others } }
+  };
+};
+
+/**
+ * Pure function to replace the MISSING_KEY and EXTRA_KEY findings of probable renames
+ * @param output - Findings of a comparison strategy
+ * @param input - The compared files
+ * @returns The same findings, with one KEY_RENAMED finding per renamed key (its nested keys included)
+ * in place of the missing and extra keys it explains
+ */
+export const detectRenames = (output: ComparisonOutput, input: ComparisonInput): ComparisonOutput => {
+  const values = new Map(input.files.map(file => [file.path, extractKeyValues(file.content)]));
+  const ownersOf = (key: string, except: ConfigFile) =>
+    input.files.filter(file => file !== except && values.get(file.path)!.has(key));
+  const consumed = new Set<ValidationError>();
+  const paired = new Set<string>();
+  const renames: ValidationError[] = [];
+
+  // Parents first, so that a renamed section explains the keys nested in it
+  const missing = output.errors
+    .filter(error => error.code === 'MISSING_KEY' && error.path !== undefined)
+    .sort((a, b) => a.path!.split('.').length - b.path!.split('.').length);
+
+  missing.forEach(error => {
+    const file = input.files.find(candidate => candidate.path === error.context?.file);
+
+    // Guard clause: already explained by a rename
+    if (!file || consumed.has(error) || paired.has(error.path!)) {
+      return;
+    }
+
+    const key = error.path!;
+    const owners = ownersOf(key, file);
+
+    // Guard clause: no file to take the original name from
+    if (owners.length === 0) {
+      return;
+    }
+
+    const own = values.get(file.path)!;
+    const best = [...own.keys()]
+      .filter(candidate => !input.isIgnored(candidate) && !paired.has(candidate) && owners.every(owner => !values.get(owner.path)!.has(candidate)))
+      // The key is reported where fewer files disagree, so each rename is reported once
+      .filter(candidate => ownersOf(candidate, file).length + 1 <= owners.length)
+      .map(candidate => ({ candidate, score: renameScore({ key, value: values.get(owners[0].path)!.get(key) }, { key: candidate, value: own.get(candidate) }) }))
+      .filter((entry): entry is { candidate: string; score: number } => entry.score !== undefined)
+      .sort((a, b) => a.score - b.score)[0];
+
+    // Guard clause: no similar key
+    if (!best) {
+      return;
+    }
+
+    const explained = (finding: ValidationError): boolean =>
+      finding.path !== undefined && (
+        (finding.context?.file === file.path && finding.code === 'MISSING_KEY' && isUnder(finding.path, key)) ||
+        (finding.context?.file === file.path && finding.code === 'EXTRA_KEY' && isUnder(finding.path, best.candidate)) ||
+        (owners.some(owner => owner.path === finding.context?.file) && finding.code === 'MISSING_KEY' && isUnder(finding.path, best.candidate))
+      );
+
+    output.errors.filter(explained).forEach(finding => consumed.add(finding));
+    // Each key takes part in one rename at most
+    paired.add(key).add(best.candidate);
+    renames.push(renamedFinding(file, owners, key, best.candidate));
+  });
+
+  return {
+    errors: [...output.errors.filter(error => !consumed.has(error)), ...renames],
+    warnings: output.warnings
+  };
+};
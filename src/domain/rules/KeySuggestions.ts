@@ -0,0 +1,47 @@
+/**
+ * KeySuggestions - "Did you mean" hints for missing keys
+ *
+ * Single Responsibility: Point each MISSING_KEY finding at the keys of its file that look
+ * like a typo of the missing one, so `databse.host` next to a missing `database.host` stands out.
+ * Pure functions, no state, no side effects
+ */
+
+import { ValidationError } from '../../shared/types';
+import { extractKeyValues } from '../../shared/utils/KeyPaths';
+import { closestMatches, didYouMean } from '../../shared/utils/Similarity';
+import { ComparisonInput, ComparisonOutput } from './ComparisonStrategies';
+
+/**
+ * Pure function to add suggestions to the MISSING_KEY findings of a comparison
+ * @param output - Findings of a comparison strategy
+ * @param input - The compared files
+ * @returns The same findings; a missing key with close matches among the keys of its file
+ * that the other files lack gets them in `extras.suggestions` and in its message
+ */
+export const suggestMissingKeys = (output: ComparisonOutput, input: ComparisonInput): ComparisonOutput => {
+  const keys = new Map(input.files.map(file => [file.path, new Set(extractKeyValues(file.content).keys())]));
+
+  const withSuggestions = (error: ValidationError): ValidationError => {
+    const file = error.context?.file;
+    const own = file !== undefined ? keys.get(file) : undefined;
+
+    // Guard clause: not a missing key of a compared file
+    if (error.code !== 'MISSING_KEY' || error.path === undefined || !own) {
+      return error;
+    }
+
+    const missing = error.path;
+    const owners = input.files.filter(other => other.path !== file && keys.get(other.path)!.has(missing));
+    // A key every file with the missing one also has is not a typo of it
+    const candidates = [...own].filter(key => !input.isIgnored(key) && !owners.every(owner => keys.get(owner.path)!.has(key)));
+    const suggestions = closestMatches(missing, candidates);
+
+    return suggestions.length === 0 ? error : {
+      ...error,
+      message: `${error.message}${didYouMean(suggestions)}`,
+      context: { ...error.context, extras: { ...error.context?.extras, suggestions } },
+    };
+  };
+
+  return { errors: output.errors.map(withSuggestions), warnings: output.warnings };
+};
@@ -16,36 +16,96 @@ import { FileAdapter } from './base/FileAdapter';
 import { YamlFileAdapter } from './readers/YamlFileAdapter';
 import { JsonFileAdapter } from './readers/JsonFileAdapter';
 import { EnvFileAdapter } from './readers/EnvFileAdapter';
-import { TomlFileAdapter } from './readers/TomlFileAdapter';
 import { IniFileAdapter } from './readers/IniFileAdapter';
-import { XmlFileAdapter } from './readers/XmlFileAdapter';
 import { PropertiesFileAdapter } from './readers/PropertiesFileAdapter';
-import { HclFileAdapter } from './readers/HclFileAdapter';
 import { PlistFileAdapterV2 } from './readers/PlistFileAdapterV2';
+import { DockerfileAdapter } from './readers/DockerfileAdapter';
+
+const FORMAT_ALIASES: Record<string, string> = {
+  yml: 'yaml',
+  dotenv: 'env',
+  tf: 'hcl',
+  cfg: 'ini',
+  conf: 'ini',
+  containerfile: 'dockerfile',
+};
+
+/**
+ * Adapter known by its format and extensions only, so that its module, and the parser
+ * library it pulls in, is loaded the first time a file of that format is read
+ */
+export interface LazyAdapter {
+  format: string;
+  extensions: string[];
+  load: () => FileAdapter;
+}
+
+type AdapterEntry = FileAdapter | LazyAdapter;
+
+const isLazy = (entry: AdapterEntry): entry is LazyAdapter => 'load' in entry;
+
+/**
+ * Pure function to resolve a format name or alias (e.g. 'yml', 'tf') to its format
+ */
+export const canonicalFormat = (format: string): string => {
+  const normalized = String(format).toLowerCase();
+  return FORMAT_ALIASES[normalized] ?? normalized;
+};
+
+const lazy = (format: string, extensions: string[], load: () => FileAdapter): LazyAdapter => {
+  const loaded: { adapter?: FileAdapter } = {};
+  return {
+    format,
+    extensions,
+    load: () => {
+      loaded.adapter = loaded.adapter ?? load();
+      return loaded.adapter;
+    },
+  };
+};
+
+const formatOf = (entry: AdapterEntry): string => isLazy(entry) ? entry.format : entry.getFormat();
+
+const extensionsOf = (entry: AdapterEntry): string[] => isLazy(entry) ? entry.extensions : entry.getSupportedExtensions();
+
+const handles = (entry: AdapterEntry, filePath: string): boolean =>
+  isLazy(entry) ? entry.extensions.some(extension => filePath.endsWith(extension)) : entry.canHandle(filePath);
+
+const resolve = (entry: AdapterEntry): FileAdapter => isLazy(entry) ? entry.load() : entry;
 
 export class FileAdapterFactory {
-  private static adapters: FileAdapter[] = [
+  // TOML and XML pull in their parser libraries and HCL is the largest reader,
+  // so YAML/JSON-only projects never load them
+  private static adapters: AdapterEntry[] = [
     new YamlFileAdapter(),
     new JsonFileAdapter(),
     new EnvFileAdapter(),
-    new TomlFileAdapter(),
+    lazy('toml', ['.toml'], () => {
+      const { TomlFileAdapter } = require('./readers/TomlFileAdapter');
+      return new TomlFileAdapter();
+    }),
     new IniFileAdapter(),
-    new XmlFileAdapter(),
+    lazy('xml', ['.xml'], () => {
+      const { XmlFileAdapter } = require('./readers/XmlFileAdapter');
+      return new XmlFileAdapter();
+    }),
     new PropertiesFileAdapter(),
-    new HclFileAdapter(),
+    lazy('hcl', ['.hcl', '.tf', '.tfvars'], () => {
+      const { HclFileAdapter } = require('./readers/HclFileAdapter');
+      return new HclFileAdapter();
+    }),
     new PlistFileAdapterV2(),
+    new DockerfileAdapter(),
   ];
 
   /**
    * Get the appropriate adapter for a file
    */
   static getAdapter(filePath: string): FileAdapter {
-    const adapter = this.adapters.find(adapter => adapter.canHandle(filePath));
+    const adapter = this.adapters.find(adapter => handles(adapter, filePath));
     
     if (!adapter) {
-      const supportedExtensions = this.adapters
-        .flatMap(adapter => adapter.getSupportedExtensions())
-        .join(', ');
+      const supportedExtensions = this.getSupportedExtensions().join(', ');
       
       throw new Error(
         `Unsupported file format: ${filePath}. ` +
@@ -53,27 +113,58 @@ export class FileAdapterFactory {
       );
     }
     
-    return adapter;
+    return resolve(adapter);
+  }
+
+  /**
+   * Get the adapter for an explicit format name (e.g. 'ini', 'yaml')
+   */
+  static getAdapterForFormat(format: string): FileAdapter {
+    const adapter = this.adapters.find(adapter => formatOf(adapter) === canonicalFormat(format));
+
+    if (!adapter) {
+      throw new Error(
+        `Unsupported format: ${format}. ` +
+        `Supported formats: ${this.getSupportedFormats().join(', ')}`
+      );
+    }
+
+    return resolve(adapter);
+  }
+
+  /**
+   * Get the format of a file from its extension without loading its adapter
+   */
+  static getFormatFor(filePath: string): string | undefined {
+    const adapter = this.adapters.find(adapter => handles(adapter, filePath));
+    return adapter ? formatOf(adapter) : undefined;
+  }
+
+  /**
+   * Get all supported format names
+   */
+  static getSupportedFormats(): string[] {
+    return this.adapters.map(formatOf);
   }
 
   /**
    * Get all supported file extensions
    */
   static getSupportedExtensions(): string[] {
-    return this.adapters.flatMap(adapter => adapter.getSupportedExtensions());
+    return this.adapters.flatMap(extensionsOf);
   }
 
   /**
-   * Get all available adapters
+   * Get all available adapters, loading the lazy ones
    */
   static getAllAdapters(): FileAdapter[] {
-    return [...this.adapters];
+    return this.adapters.map(resolve);
   }
 
   /**
-   * Register a new adapter
+   * Register a new adapter; a lazy one is only loaded when a file of its format is read
    */
-  static registerAdapter(adapter: FileAdapter): void {
+  static registerAdapter(adapter: FileAdapter | LazyAdapter): void {
     this.adapters.push(adapter);
   }
 
@@ -81,6 +172,6 @@ export class FileAdapterFactory {
    * Check if a file format is supported
    */
   static isSupported(filePath: string): boolean {
-    return this.adapters.some(adapter => adapter.canHandle(filePath));
+    return this.adapters.some(adapter => handles(adapter, filePath));
   }
 } 
\ No newline at end of file
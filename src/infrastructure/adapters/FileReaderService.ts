@@ -12,45 +12,230 @@
  * Mutation Score: 86.36% - Declarative patterns make testing reliable!
  */
 
-import { FileAdapterFactory } from './FileAdapterFactory';
-import { ConfigFile } from '../../shared/types';
+import * as fs from 'fs';
+import { performance } from 'perf_hooks';
+import { canonicalFormat, FileAdapterFactory } from './FileAdapterFactory';
+import { FileAdapter } from './base/FileAdapter';
+import { sniffFormat } from './FormatSniffer';
+import { isStreamedFormat, streamKeyTree, StreamingUnsupportedError } from './StreamingKeyTree';
+import { ConfigComment, ConfigFile } from '../../shared/types';
+import { matchesGlob } from '../../shared/utils/Glob';
+import { roundMs } from '../../shared/utils/Timing';
+import { IoError } from '../../shared/utils/ExitCodes';
+
+export interface FileReadFailure {
+  path: string;
+  error: string;
+  line?: number;
+  column?: number;
+}
+
+export interface TolerantReadResult {
+  files: ConfigFile[];
+  failures: FileReadFailure[];
+}
+
+/**
+ * Pure function to extract a line/column location from a parser error message
+ */
+export const extractErrorLocation = (message: string): { line?: number; column?: number } => {
+  const lineColumn = message.match(/line (\d+),? column (\d+)/i) ?? message.match(/\((\d+):(\d+)\)/);
+  if (lineColumn) {
+    return { line: Number(lineColumn[1]), column: Number(lineColumn[2]) };
+  }
+
+  const line = message.match(/line (\d+)/i);
+  return line ? { line: Number(line[1]) } : {};
+};
+
+export interface FileReaderOptions {
+  /** Keep the comments of each file in `ConfigFile.comments` */
+  retainComments?: boolean;
+  /** Stream JSON and YAML files larger than this many bytes into their key tree (see StreamingKeyTree) */
+  streamAboveBytes?: number;
+  /** Formats whose parsers may be used, every format when unset; other files are rejected unread */
+  parsers?: string[];
+}
 
 export class FileReaderService {
+  /**
+   * @param formatOverrides - Glob pattern to format name, taking precedence over extension detection
+   * @param options - What to keep besides the parsed content
+   */
+  constructor(
+    private readonly formatOverrides: Record<string, string> = {},
+    private readonly options: FileReaderOptions = {}
+  ) {}
+
   /**
    * Read a single file and return its parsed content
    */
   async readFile(filePath: string): Promise<ConfigFile> {
-    const adapter = FileAdapterFactory.getAdapter(filePath);
-    const content = await adapter.read(filePath);
+    const startedAt = performance.now();
+    const adapter = await this.resolveAdapter(filePath);
+    const streamed = await this.readStreamed(filePath, adapter.getFormat());
+    const content = streamed ?? await adapter.read(filePath);
+    const parseMs = roundMs(performance.now() - startedAt);
+    // A streamed file is too large to be read whole again for its comments
+    const comments = !streamed && this.options.retainComments && adapter.readComments ? await adapter.readComments(filePath) : undefined;
     
     return {
       path: filePath,
       content,
       format: adapter.getFormat(),
+      ...(comments ? { comments } : {}),
       metadata: {
-        encoding: 'utf8'
+        encoding: 'utf8',
+        parseMs,
+        ...(streamed ? { streamed: true } : {})
       }
     };
   }
 
   /**
-   * Read multiple files and return their parsed contents
+   * Key tree of a file too large to parse whole; undefined for smaller files, other
+   * formats, and YAML the streaming reader does not cover
+   */
+  private async readStreamed(filePath: string, format: string): Promise<Record<string, any> | undefined> {
+    const threshold = this.options.streamAboveBytes;
+
+    // Guard clause: streaming off, or a format that is always parsed whole
+    if (threshold === undefined || !isStreamedFormat(format)) {
+      return undefined;
+    }
+
+    const size = await fs.promises.stat(filePath).then(stats => stats.size, () => 0);
+
+    // Guard clause: small enough to parse whole
+    if (size <= threshold) {
+      return undefined;
+    }
+
+    try {
+      return await streamKeyTree(filePath, format);
+    } catch (error) {
+      // Guard clause: the adapter parses what the line-based YAML reader does not cover
+      if (error instanceof StreamingUnsupportedError) {
+        return undefined;
+      }
+      throw new Error(`Failed to parse ${format.toUpperCase()} file ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  /**
+   * Read the comments of a file with the comment syntax of its format; none for formats without comments
+   */
+  async readComments(filePath: string): Promise<ConfigComment[]> {
+    const adapter = await this.resolveAdapter(filePath);
+    return adapter.readComments ? adapter.readComments(filePath) : [];
+  }
+
+  /**
+   * Read multiple files and return their parsed contents.
+   * When the signal is aborted, reading stops and the files read so far are returned.
    */
-  async readFiles(filePaths: string[]): Promise<ConfigFile[]> {
+  async readFiles(filePaths: string[], signal?: AbortSignal): Promise<ConfigFile[]> {
     const configFiles: ConfigFile[] = [];
     
     for (const filePath of filePaths) {
+      // Guard clause: run interrupted
+      if (signal?.aborted) {
+        break;
+      }
+
       try {
         const configFile = await this.readFile(filePath);
         configFiles.push(configFile);
       } catch (error) {
-        throw new Error(`Failed to read file ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+        throw new IoError(`Failed to read file ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
       }
     }
     
     return configFiles;
   }
 
+  /**
+   * Read multiple files, collecting failures instead of aborting on the first one
+   */
+  async readFilesTolerant(filePaths: string[], signal?: AbortSignal): Promise<TolerantReadResult> {
+    const settled = await Promise.all(
+      filePaths.map(async (filePath): Promise<{ file?: ConfigFile; failure?: FileReadFailure }> => {
+        // Guard clause: run interrupted, the file is neither read nor reported
+        if (signal?.aborted) {
+          return {};
+        }
+
+        try {
+          return { file: await this.readFile(filePath) };
+        } catch (error) {
+          const message = error instanceof Error ? error.message : 'Unknown error';
+          return { failure: { path: filePath, error: message, ...extractErrorLocation(message) } };
+        }
+      })
+    );
+
+    return {
+      files: settled.flatMap(entry => entry.file ? [entry.file] : []),
+      failures: settled.flatMap(entry => entry.failure ? [entry.failure] : [])
+    };
+  }
+
+  /**
+   * Tell the format a file would be parsed as
+   */
+  async detectFormat(filePath: string): Promise<string> {
+    return (await this.resolveAdapter(filePath)).getFormat();
+  }
+
+  /**
+   * Resolve the adapter for a file, refusing formats whose parsers are not enabled
+   */
+  private async resolveAdapter(filePath: string): Promise<FileAdapter> {
+    const format = await this.resolveFormat(filePath);
+
+    // Guard clause: no format recognized
+    if (format === undefined) {
+      return FileAdapterFactory.getAdapter(filePath);
+    }
+
+    const parsers = this.options.parsers?.map(canonicalFormat);
+    if (parsers && FileAdapterFactory.getSupportedFormats().includes(format) && !parsers.includes(format)) {
+      throw new Error(`The ${format} parser is not enabled: ${filePath}. Enabled parsers: ${parsers.join(', ')}`);
+    }
+
+    return FileAdapterFactory.getAdapterForFormat(format);
+  }
+
+  /**
+   * Resolve the format of a file: configured override, extension, then content sniffing
+   */
+  private async resolveFormat(filePath: string): Promise<string | undefined> {
+    const override = Object.entries(this.formatOverrides)
+      .find(([pattern]) => matchesGlob(filePath, pattern));
+
+    if (override) {
+      return canonicalFormat(override[1]);
+    }
+
+    return FileAdapterFactory.getFormatFor(filePath) ?? await this.sniffFileFormat(filePath);
+  }
+
+  /**
+   * Guess the format of a file from its content
+   */
+  private async sniffFileFormat(filePath: string): Promise<string | undefined> {
+    // Guard clause: nothing to sniff
+    if (!fs.existsSync(filePath)) {
+      return undefined;
+    }
+
+    try {
+      return sniffFormat(await fs.promises.readFile(filePath, 'utf8'));
+    } catch {
+      return undefined;
+    }
+  }
+
   /**
    * Check if a file format is supported
    */
@@ -0,0 +1,85 @@
+import * as yaml from 'js-yaml';
+
+/**
+ * Format Sniffer - Functional Programming
+ *
+ * Single Responsibility: Guess the format of configuration content whose
+ * file name does not identify a parser (no extension, `.config`, ...).
+ * Tries JSON, then YAML, then INI sections and key=value heuristics.
+ */
+
+const KEY_VALUE_LINE = /^\s*(export\s+)?[A-Za-z_][\w.\-]*\s*[=:]/;
+const ENV_LINE = /^\s*(export\s+)?[A-Z_][A-Z0-9_]*=/;
+const INI_SECTION = /^\s*\[[^\]]+\]\s*$/;
+
+/**
+ * Pure function to get meaningful (non-blank, non-comment) lines
+ */
+const getMeaningfulLines = (content: string): string[] =>
+  content
+    .split(/\r?\n/)
+    .map(line => line.trim())
+    .filter(line => line.length > 0 && !line.startsWith('#') && !line.startsWith(';') && !line.startsWith('!'));
+
+/**
+ * Pure function to check if a parsed value is a configuration object
+ */
+const isConfigObject = (value: any): boolean =>
+  value !== null && typeof value === 'object' && !Array.isArray(value);
+
+/**
+ * Pure function to check if content parses as a JSON object
+ */
+const looksLikeJson = (content: string): boolean => {
+  try {
+    return isConfigObject(JSON.parse(content));
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Pure function to check if content parses as a YAML mapping
+ */
+const looksLikeYaml = (content: string): boolean => {
+  try {
+    return isConfigObject(yaml.load(content));
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Pure function to sniff the format of configuration content
+ * @returns Format name understood by FileAdapterFactory, or undefined if unknown
+ */
+export const sniffFormat = (content: string): string | undefined => {
+  // Guard clause: no content
+  if (typeof content !== 'string' || content.trim().length === 0) {
+    return undefined;
+  }
+
+  const lines = getMeaningfulLines(content);
+
+  if (looksLikeJson(content)) {
+    return 'json';
+  }
+
+  if (looksLikeYaml(content)) {
+    return 'yaml';
+  }
+
+  if (lines.some(line => INI_SECTION.test(line)) && lines.every(line => INI_SECTION.test(line) || KEY_VALUE_LINE.test(line))) {
+    return 'ini';
+  }
+
+  if (lines.length > 0 && lines.every(line => ENV_LINE.test(line))) {
+    return 'env';
+  }
+
+  if (lines.some(line => line.includes('=')) && lines.every(line => KEY_VALUE_LINE.test(line))) {
+    return 'properties';
+  }
+
+  return undefined;
+};
@@ -0,0 +1,71 @@
+/**
+ * KeyLocations - Where each key of a file is written
+ *
+ * Single Responsibility: Map the dotted key paths of a YAML or JSON text to the line and
+ * column of their key, so findings about a value can point at it.
+ */
+
+import * as fs from 'fs';
+import { isMap, isScalar, isSeq, LineCounter, Node, parseDocument } from 'yaml';
+import { KeyLocation } from '../../shared/types';
+import { joinKeyPath } from '../../shared/utils/KeyPaths';
+
+// JSON is read by the YAML parser as well
+const LOCATED_FORMATS = ['yaml', 'json'];
+
+const locationsInNode = (node: unknown, path: string, lineCounter: LineCounter): Array<[string, KeyLocation]> => {
+  if (isSeq(node)) {
+    return node.items.flatMap((item, index) => {
+      const itemPath = joinKeyPath(path, String(index));
+      const { line, col } = lineCounter.linePos((item as Node | null)?.range?.[0] ?? 0);
+      return [[itemPath, { line, column: col }] as [string, KeyLocation], ...locationsInNode(item, itemPath, lineCounter)];
+    });
+  }
+
+  // Guard clause: scalars hold no keys
+  if (!isMap(node)) {
+    return [];
+  }
+
+  return node.items.flatMap(pair => {
+    const keyPath = joinKeyPath(path, isScalar(pair.key) ? String(pair.key.value) : String(pair.key));
+    const { line, col } = lineCounter.linePos((pair.key as Node | null)?.range?.[0] ?? 0);
+    return [[keyPath, { line, column: col }] as [string, KeyLocation], ...locationsInNode(pair.value, keyPath, lineCounter)];
+  });
+};
+
+/**
+ * Pure function to locate the keys of a YAML or JSON text
+ * @param text - File text
+ * @returns Key path (list items as `hosts.0`) -> line and column, both 1-based; empty when the text does not parse
+ */
+export const keyLocations = (text: string): Map<string, KeyLocation> => {
+  const lineCounter = new LineCounter();
+  const document = parseDocument(text, { lineCounter });
+
+  // Guard clause: syntax errors are reported by the readers
+  if (document.errors.length > 0) {
+    return new Map();
+  }
+
+  return new Map(locationsInNode(document.contents, '', lineCounter));
+};
+
+/**
+ * Locates the keys of a file on disk
+ * @param filePath - File path
+ * @param format - Parser format of the file; only YAML and JSON files are located
+ * @returns Key locations; empty for other formats and for files that cannot be read (in-memory files)
+ */
+export const readKeyLocations = (filePath: string, format: string): Map<string, KeyLocation> => {
+  // Guard clause: format without a located syntax
+  if (!LOCATED_FORMATS.includes(format)) {
+    return new Map();
+  }
+
+  try {
+    return keyLocations(fs.readFileSync(filePath, 'utf8'));
+  } catch {
+    return new Map();
+  }
+};
@@ -1,6 +1,7 @@
 import * as fs from 'fs';
 import { FileAdapter } from './FileAdapter';
-import { ConfigFile } from '../../../shared/types';
+import { ConfigComment, ConfigFile } from '../../../shared/types';
+import { CommentSyntax, extractComments, HASH_COMMENTS } from '../../../shared/utils/Comments';
 
 export abstract class AbstractFileAdapter implements FileAdapter {
   abstract canHandle(filePath: string): boolean;
@@ -8,6 +9,21 @@ export abstract class AbstractFileAdapter implements FileAdapter {
   abstract getFormat(): string;
   abstract getSupportedExtensions(): string[];
 
+  /**
+   * Read the full-line and block comments of a file
+   */
+  async readComments(filePath: string): Promise<ConfigComment[]> {
+    this.validateFileExists(filePath);
+    return extractComments(await this.readFileContent(filePath), this.getCommentSyntax());
+  }
+
+  /**
+   * Comment syntax of the format; `#` line comments unless overridden
+   */
+  protected getCommentSyntax(): CommentSyntax {
+    return HASH_COMMENTS;
+  }
+
   /**
    * Read file content as string with error handling
    */
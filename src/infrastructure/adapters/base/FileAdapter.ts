@@ -1,4 +1,4 @@
-import { ConfigFile } from '../../../shared/types';
+import { ConfigComment, ConfigFile } from '../../../shared/types';
 
 export interface FileAdapter {
   /**
@@ -11,6 +11,11 @@ export interface FileAdapter {
    */
   read(filePath: string): Promise<Record<string, any>>;
 
+  /**
+   * Read the comments of the file, for formats that have them
+   */
+  readComments?(filePath: string): Promise<ConfigComment[]>;
+
   /**
    * Get the format name for this adapter
    */
@@ -12,6 +12,7 @@ export * from './readers/XmlFileAdapter';
 export * from './readers/PropertiesFileAdapter';
 export * from './readers/HclFileAdapter';
 export * from './readers/PlistFileAdapterV2';
+export * from './readers/DockerfileAdapter';
 
 // Factory and service
 export * from './FileAdapterFactory';
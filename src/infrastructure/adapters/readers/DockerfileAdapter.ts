@@ -0,0 +1,254 @@
+import * as path from 'path';
+import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+
+/**
+ * Dockerfile Adapter - Functional Programming
+ *
+ * Single Responsibility: Extract the ENV and ARG defaults baked into an image,
+ * so they can be compared with runtime env files and compose definitions.
+ * Pure functions, no state, no side effects
+ */
+
+export class DockerfileAdapter extends AbstractFileAdapter {
+  canHandle(filePath: string): boolean {
+    // Guard clause: no file path
+    if (!filePath || typeof filePath !== 'string') {
+      return false;
+    }
+
+    return isDockerfile(filePath);
+  }
+
+  async read(filePath: string): Promise<Record<string, any>> {
+    // Guard clause: no file path
+    if (!filePath || typeof filePath !== 'string') {
+      throw new Error('File path is required');
+    }
+
+    this.validateFileExists(filePath);
+
+    try {
+      const content = await this.readFileContent(filePath);
+      return parseDockerfileContent(content);
+    } catch (error) {
+      throw new Error(`Failed to parse Dockerfile ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  getFormat(): string {
+    return 'dockerfile';
+  }
+
+  getSupportedExtensions(): string[] {
+    return ['Dockerfile', 'Containerfile', '.dockerfile'];
+  }
+}
+
+/**
+ * @constant DOCKERFILE_ARG_KEY
+ * @description Key the ARG defaults are reported under; ENV defaults are top-level keys
+ */
+export const DOCKERFILE_ARG_KEY = 'ARG';
+
+/**
+ * Pure function to check if a file is a Dockerfile (`Dockerfile`, `Dockerfile.prod`, `api.Dockerfile`, `Containerfile`)
+ */
+const isDockerfile = (filePath: string): boolean => {
+  const name = path.basename(filePath).toLowerCase();
+  return ['dockerfile', 'containerfile'].some(base => name === base || name.startsWith(`${base}.`)) ||
+    name.endsWith('.dockerfile');
+};
+
+/**
+ * A build stage: its name and the ENV and ARG values visible in it
+ */
+interface Stage {
+  name?: string;
+  env: Record<string, string>;
+  args: Record<string, string>;
+}
+
+/**
+ * ARGs declared before the first FROM, and the stages built so far
+ */
+interface BuildState {
+  globalArgs: Record<string, string>;
+  stages: Stage[];
+}
+
+/**
+ * Pure function to join continuation lines and drop comments
+ */
+const toInstructions = (content: string): string[] =>
+  content
+    .split(/\r?\n/)
+    .filter(line => !line.trim().startsWith('#'))
+    .join('\n')
+    .replace(/\\[ \t]*\n/g, ' ')
+    .split('\n')
+    .map(line => line.trim())
+    .filter(line => line.length > 0);
+
+/**
+ * Pure function to split instruction arguments into words, honoring quotes and escapes
+ */
+export const splitDockerWords = (text: string): string[] => {
+  const words: string[] = [];
+  let current = '';
+  let quote: string | undefined;
+  let started = false;
+
+  for (let index = 0; index < text.length; index++) {
+    const char = text[index];
+
+    if (char === '\\' && quote !== "'" && index + 1 < text.length) {
+      current += text[++index];
+      started = true;
+    } else if (quote) {
+      if (char === quote) {
+        quote = undefined;
+      } else {
+        current += char;
+      }
+    } else if (char === '"' || char === "'") {
+      quote = char;
+      started = true;
+    } else if (/\s/.test(char)) {
+      if (started) {
+        words.push(current);
+      }
+      current = '';
+      started = false;
+    } else {
+      current += char;
+      started = true;
+    }
+  }
+
+  return started ? [...words, current] : words;
+};
+
+/**
+ * Pure function to expand `$VAR`, `${VAR}`, `${VAR:-default}` and `${VAR:+alternative}`
+ */
+export const expandDockerVariables = (text: string, variables: Record<string, string>): string =>
+  text.replace(/\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?::([-+])([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))/g,
+    (_match, braced?: string, operator?: string, word?: string, bare?: string) => {
+      const value = variables[braced ?? bare ?? ''];
+
+      if (operator === '-') {
+        return value ? value : word ?? '';
+      }
+
+      if (operator === '+') {
+        return value ? word ?? '' : '';
+      }
+
+      return value ?? '';
+    });
+
+/**
+ * Pure function to read `KEY=value` pairs, or the legacy `ENV KEY value` form
+ */
+const keyValuePairs = (argumentsText: string, legacyForm: boolean): Array<[string, string | undefined]> => {
+  const words = splitDockerWords(argumentsText);
+
+  // Guard clause: legacy `ENV KEY value` form
+  if (legacyForm && words.length > 1 && !words[0].includes('=')) {
+    return [[words[0], words.slice(1).join(' ')]];
+  }
+
+  return words.map((word): [string, string | undefined] => {
+    const equalIndex = word.indexOf('=');
+    return equalIndex > 0 ? [word.slice(0, equalIndex), word.slice(equalIndex + 1)] : [word, undefined];
+  });
+};
+
+/**
+ * Pure function to resolve the ARG values of an instruction.
+ * An ARG without default inherits the default of a global ARG of the same name.
+ */
+const resolveArgs = (argumentsText: string, state: BuildState, stage?: Stage): Record<string, string> =>
+  Object.fromEntries(
+    keyValuePairs(argumentsText, false).flatMap(([key, value]) => {
+      const resolved = value !== undefined
+        ? expandDockerVariables(value, { ...state.globalArgs, ...stage?.args, ...stage?.env })
+        : state.globalArgs[key];
+      return resolved !== undefined ? [[key, resolved]] : [];
+    })
+  );
+
+/**
+ * Pure function to replace the last stage
+ */
+const withLastStage = (state: BuildState, stage: Stage): BuildState => ({
+  ...state,
+  stages: [...state.stages.slice(0, -1), stage],
+});
+
+/**
+ * Pure function to apply one instruction to the build state
+ */
+const applyInstruction = (state: BuildState, instruction: string): BuildState => {
+  const [keyword] = instruction.split(/\s+/, 1);
+  const argumentsText = instruction.slice(keyword.length).trim();
+  const current = state.stages[state.stages.length - 1];
+
+  switch (keyword.toUpperCase()) {
+    case 'FROM': {
+      const [image, as, name] = splitDockerWords(expandDockerVariables(argumentsText, state.globalArgs))
+        .filter(word => !word.startsWith('--'));
+      // A stage built FROM an earlier stage inherits its environment
+      const base = state.stages.find(stage => stage.name !== undefined && stage.name === image?.toLowerCase());
+      return {
+        ...state,
+        stages: [...state.stages, {
+          name: as?.toUpperCase() === 'AS' ? name?.toLowerCase() : undefined,
+          env: { ...base?.env },
+          args: {},
+        }],
+      };
+    }
+    case 'ARG':
+      return current
+        ? withLastStage(state, { ...current, args: { ...current.args, ...resolveArgs(argumentsText, state, current) } })
+        : { ...state, globalArgs: { ...state.globalArgs, ...resolveArgs(argumentsText, state) } };
+    case 'ENV': {
+      // Guard clause: ENV before any FROM is invalid
+      if (!current) {
+        return state;
+      }
+
+      // Every value of one instruction sees the environment before it
+      const variables = { ...current.args, ...current.env };
+      const env = Object.fromEntries(
+        keyValuePairs(argumentsText, true).map(([key, value]) => [key, expandDockerVariables(value ?? '', variables)])
+      );
+      return withLastStage(state, { ...current, env: { ...current.env, ...env } });
+    }
+    default:
+      return state;
+  }
+};
+
+/**
+ * Pure function to extract the image defaults of a Dockerfile.
+ * ENV values of the final stage become top-level keys; ARG defaults are
+ * reported under `ARG`. `$VAR` references are expanded where the value is known.
+ */
+export const parseDockerfileContent = (content: string): Record<string, any> => {
+  // Guard clause: no content
+  if (!content || typeof content !== 'string') {
+    return {};
+  }
+
+  const { globalArgs, stages } = toInstructions(content)
+    .reduce<BuildState>(applyInstruction, { globalArgs: {}, stages: [] });
+  const finalStage = stages[stages.length - 1];
+  const args = { ...globalArgs, ...finalStage?.args };
+
+  return {
+    ...finalStage?.env,
+    ...(Object.keys(args).length > 0 ? { [DOCKERFILE_ARG_KEY]: args } : {}),
+  };
+};
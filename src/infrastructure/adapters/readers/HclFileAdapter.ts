@@ -1,4 +1,5 @@
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax } from '../../../shared/utils/Comments';
 
 /**
  * HCL File Adapter - Functional Programming
@@ -37,6 +38,10 @@ export class HclFileAdapter extends AbstractFileAdapter {
     return 'hcl';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    return { line: ['#', '//'], block: ['/*', '*/'] };
+  }
+
   getSupportedExtensions(): string[] {
     return ['.hcl', '.tf', '.tfvars'];
   }
@@ -52,7 +57,7 @@ export const parseHclContent = (content: string): Record<string, any> => {
   }
 
   const lines = splitIntoLines(content);
-  const cleanLines = removeCommentsFromLines(lines);
+  const cleanLines = joinMultilineArrays(joinHeredocs(removeCommentsFromLines(lines)));
   const blocks = parseBlocks(cleanLines);
   
   return blocks.reduce((result, block) => {
@@ -104,6 +109,72 @@ const removeCommentsFromLine = (line: string): string => {
     .trim();
 };
 
+const HEREDOC_START = /^(\w+\s*=\s*)<<-?(\w+)$/;
+
+/**
+ * Pure function to turn a heredoc into a one-line assignment: JSON documents (IAM policies,
+ * container definitions) keep their structure, other text becomes a string
+ */
+const heredocAssignment = (head: string, body: string[]): string => {
+  const text = body.join(' ');
+
+  try {
+    const parsed = JSON.parse(text);
+    return typeof parsed === 'object' && parsed !== null ? `${head}${text}` : `${head}"${text}"`;
+  } catch {
+    return `${head}"${text}"`;
+  }
+};
+
+/**
+ * Pure function to join heredocs (`policy = <<EOF` ... `EOF`) into one assignment line each
+ */
+const joinHeredocs = (lines: string[]): string[] => {
+  const state = lines.reduce<{ joined: string[]; heredoc?: { head: string; marker: string; body: string[] } }>((current, line) => {
+    const { joined, heredoc } = current;
+
+    if (heredoc) {
+      return line === heredoc.marker
+        ? { joined: [...joined, heredocAssignment(heredoc.head, heredoc.body)] }
+        : { joined, heredoc: { ...heredoc, body: [...heredoc.body, line] } };
+    }
+
+    const start = HEREDOC_START.exec(line);
+    return start ? { joined, heredoc: { head: start[1], marker: start[2], body: [] } } : { joined: [...joined, line] };
+  }, { joined: [] });
+
+  // Guard clause: every heredoc was closed
+  if (!state.heredoc) {
+    return state.joined;
+  }
+
+  return [...state.joined, heredocAssignment(state.heredoc.head, state.heredoc.body)];
+};
+
+/**
+ * Pure function to count the brackets a line leaves open
+ */
+const bracketBalance = (line: string): number =>
+  (line.match(/\[/g) ?? []).length - (line.match(/\]/g) ?? []).length;
+
+/**
+ * Pure function to join array values written over several lines
+ * (`capabilities = [` ... `]`, as in Vault policies) into one assignment line
+ */
+const joinMultilineArrays = (lines: string[]): string[] =>
+  lines.reduce<{ joined: string[]; open: number }>((state, line) => {
+    // Guard clause: a line outside of an unfinished array
+    if (state.open === 0) {
+      const open = /^\w+\s*=\s*\[/.test(line) ? Math.max(bracketBalance(line), 0) : 0;
+      return { joined: [...state.joined, line], open };
+    }
+
+    const open = Math.max(state.open + bracketBalance(line), 0);
+    const joined = `${state.joined[state.joined.length - 1]} ${line}`;
+    // A trailing comma before the closing bracket is valid HCL but not valid JSON
+    return { joined: [...state.joined.slice(0, -1), open > 0 ? joined : joined.replace(/,\s*\]$/, ']')], open };
+  }, { joined: [], open: 0 }).joined;
+
 /**
  * Pure function to check if line is not empty
  */
@@ -204,7 +275,8 @@ const parseBlockDefinition = (line: string): { name: string; type: string } | nu
   }
 
   // Check for resource block (only valid resource types)
-  const resourceMatch = line.match(/^(resource|provider|terraform|locals|output)\s+([^{]+)\s*{/);
+  // `path` blocks are the rules of Vault policies
+  const resourceMatch = line.match(/^(resource|provider|terraform|locals|output|path)\s+([^{]+)\s*{/);
   if (isValidResourceMatch(resourceMatch)) {
     const [, blockType, blockName] = resourceMatch!;
     const cleanBlockName = removeQuotesFromBlockName(blockName.trim());
@@ -344,6 +416,12 @@ const containsOpeningBrace = (line: string): boolean => {
   return line.includes('{');
 };
 
+/**
+ * Pure function to check if a line closes every brace it opens
+ */
+const isBraceBalanced = (line: string): boolean =>
+  (line.match(/{/g) ?? []).length === (line.match(/}/g) ?? []).length;
+
 /**
  * Pure function to check if nested block match is valid
  */
@@ -380,7 +458,8 @@ const parseNestedBlockContent = (lines: string[], startIndex: number): { data: R
       continue;
     }
 
-    if (containsOpeningBrace(line)) {
+    // A line closing what it opens (a one-line object) leaves the depth unchanged
+    if (containsOpeningBrace(line) && !isBraceBalanced(line)) {
       braceCount++;
     }
     
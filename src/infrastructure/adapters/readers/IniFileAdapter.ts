@@ -1,4 +1,5 @@
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax } from '../../../shared/utils/Comments';
 
 /**
  * INI File Adapter - Functional Programming
@@ -37,6 +38,10 @@ export class IniFileAdapter extends AbstractFileAdapter {
     return 'ini';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    return { line: [';', '#'] };
+  }
+
   getSupportedExtensions(): string[] {
     return ['.ini', '.cfg', '.conf'];
   }
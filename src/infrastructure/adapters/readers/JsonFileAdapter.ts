@@ -1,4 +1,5 @@
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax, NO_COMMENTS } from '../../../shared/utils/Comments';
 
 /**
  * JSON File Adapter - Functional Programming
@@ -37,6 +38,11 @@ export class JsonFileAdapter extends AbstractFileAdapter {
     return 'json';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    // JSON has no comments
+    return NO_COMMENTS;
+  }
+
   getSupportedExtensions(): string[] {
     return ['.json'];
   }
@@ -1,4 +1,5 @@
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax } from '../../../shared/utils/Comments';
 
 // ============================================================================
 // TYPES
@@ -376,6 +377,10 @@ export class PlistFileAdapterV2 extends AbstractFileAdapter {
     return 'plist';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    return { line: [], block: ['<!--', '-->'] };
+  }
+
   getSupportedExtensions(): string[] {
     return ['.plist'];
   }
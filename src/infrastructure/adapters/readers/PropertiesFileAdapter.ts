@@ -1,4 +1,5 @@
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax } from '../../../shared/utils/Comments';
 
 /**
  * Properties File Adapter - Functional Programming
@@ -37,6 +38,10 @@ export class PropertiesFileAdapter extends AbstractFileAdapter {
     return 'properties';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    return { line: ['#', '!'] };
+  }
+
   getSupportedExtensions(): string[] {
     return ['.properties'];
   }
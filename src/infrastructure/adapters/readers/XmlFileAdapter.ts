@@ -1,5 +1,6 @@
 import * as xml2js from 'xml2js';
 import { AbstractFileAdapter } from '../base/AbstractFileAdapter';
+import { CommentSyntax } from '../../../shared/utils/Comments';
 
 /**
  * XML File Adapter - Functional Programming
@@ -38,6 +39,10 @@ export class XmlFileAdapter extends AbstractFileAdapter {
     return 'xml';
   }
 
+  protected getCommentSyntax(): CommentSyntax {
+    return { line: [], block: ['<!--', '-->'] };
+  }
+
   getSupportedExtensions(): string[] {
     return ['.xml'];
   }
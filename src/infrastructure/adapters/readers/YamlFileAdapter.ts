@@ -55,6 +55,42 @@ const isYamlFile = (filePath: string): boolean => {
   return filePath.endsWith('.yaml') || filePath.endsWith('.yml');
 };
 
+/**
+ * CloudFormation and SAM short-form intrinsic functions (`!Ref`, `!Sub`, `!GetAtt`, ...).
+ * Plain YAML rejects these tags; they are read as their long form (`{ Ref: ... }`,
+ * `{ 'Fn::Sub': ... }`) so templates compare and validate like any other file.
+ */
+const CLOUDFORMATION_FUNCTIONS = [
+  'Base64', 'Cidr', 'FindInMap', 'GetAtt', 'GetAZs', 'ImportValue', 'Join', 'Select', 'Split', 'Sub',
+  'Transform', 'And', 'Equals', 'If', 'Not', 'Or', 'Length', 'ToJsonString',
+];
+
+const longForm = (name: string, data: unknown): Record<string, unknown> => {
+  // `!GetAtt Resource.Attribute` is the scalar spelling of `Fn::GetAtt: [Resource, Attribute]`
+  if (name === 'GetAtt' && typeof data === 'string' && data.includes('.')) {
+    return { 'Fn::GetAtt': [data.slice(0, data.indexOf('.')), data.slice(data.indexOf('.') + 1)] };
+  }
+
+  return { [name === 'Ref' || name === 'Condition' ? name : `Fn::${name}`]: data };
+};
+
+const intrinsicTypes = (name: string): yaml.Type[] =>
+  (['scalar', 'sequence', 'mapping'] as const).map(kind =>
+    new yaml.Type(`!${name}`, { kind, construct: (data: unknown) => longForm(name, data) })
+  );
+
+export const CLOUDFORMATION_SCHEMA = yaml.DEFAULT_SCHEMA.extend(
+  ['Ref', 'Condition', ...CLOUDFORMATION_FUNCTIONS].flatMap(intrinsicTypes)
+);
+
+/**
+ * Ansible `!vault` (inline encrypted value) and `!unsafe` (untemplated string) tags;
+ * both are read as the string they hold so group_vars files parse like any other YAML
+ */
+export const YAML_SCHEMA = CLOUDFORMATION_SCHEMA.extend(
+  ['!vault', '!unsafe'].map(tag => new yaml.Type(tag, { kind: 'scalar', construct: (data: unknown) => String(data ?? '') }))
+);
+
 /**
  * Pure function to parse YAML content
  */
@@ -65,14 +101,29 @@ export const parseYamlContent = (content: string, filePath?: string): Record<str
   }
 
   try {
-    const parsedContent = yaml.load(content);
-    return validateYamlContent(parsedContent, filePath);
+    const documents = yaml.loadAll(content, undefined, { schema: YAML_SCHEMA }).filter(document => document !== null && document !== undefined);
+    return documents.length > 1
+      ? combineYamlDocuments(documents.map(document => validateYamlContent(document, filePath)))
+      : validateYamlContent(documents[0], filePath);
   } catch (error) {
     const errorMessage = getYamlErrorMessage(error, filePath);
     throw new Error(errorMessage);
   }
 };
 
+/**
+ * Pure function to key the documents of a multi-document file (`---` separated, as in
+ * Kubernetes manifests) by `Kind/name` when they have one, by position otherwise
+ */
+const combineYamlDocuments = (documents: Record<string, any>[]): Record<string, any> =>
+  documents.reduce<Record<string, any>>((combined, document, index) => {
+    const name = typeof document.kind === 'string' && typeof document.metadata?.name === 'string'
+      ? `${document.kind}/${document.metadata.name}`
+      : `document${index + 1}`;
+    const key = Object.prototype.hasOwnProperty.call(combined, name) ? `${name}#${index + 1}` : name;
+    return { ...combined, [key]: document };
+  }, {});
+
 /**
  * Pure function to validate YAML content structure
  */
@@ -0,0 +1,177 @@
+/**
+ * AnsibleInventory - Ansible inventories as comparable files
+ *
+ * Single Responsibility: Find the inventories of an Ansible layout (one directory per
+ * environment with an inventory file and group_vars/), read them and turn each into one
+ * file whose keys are its groups and whose values are the variables each group ends up
+ * with, so inventories are compared like any other set of configuration files.
+ * host_vars are left out: host names differ from one inventory to the next.
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { ConfigFile } from '../../shared/types';
+import {
+  combineInventoryGroups,
+  effectiveGroupVariables,
+  InventoryGroups,
+  parseIniInventory,
+  yamlInventoryGroups,
+} from '../../shared/utils/AnsibleVariables';
+import { ConfigError, IoError } from '../../shared/utils/ExitCodes';
+import { parseYamlContent } from '../adapters/readers/YamlFileAdapter';
+
+/**
+ * @constant ANSIBLE_FORMAT
+ * @description Format of the files built from inventories
+ */
+export const ANSIBLE_FORMAT = 'ansible';
+
+const INVENTORY_FILES = ['hosts', 'hosts.ini', 'hosts.yml', 'hosts.yaml', 'inventory', 'inventory.ini', 'inventory.yml', 'inventory.yaml'];
+const VARIABLE_EXTENSIONS = ['.yml', '.yaml', '.json'];
+const VAULT_HEADER = '$ANSIBLE_VAULT;';
+const INI_HEADER = /^\s*\[[^\]]+\]\s*$/m;
+
+const isDirectory = (target: string): boolean => fs.existsSync(target) && fs.statSync(target).isDirectory();
+
+const isFile = (target: string): boolean => fs.existsSync(target) && fs.statSync(target).isFile();
+
+/**
+ * Checks whether a directory is an inventory: it holds an inventory file or a group_vars directory
+ * @param directory - Directory to look at
+ * @returns true for inventory directories
+ */
+export const isInventoryDirectory = (directory: string): boolean =>
+  isDirectory(path.join(directory, 'group_vars')) || INVENTORY_FILES.some(name => isFile(path.join(directory, name)));
+
+/**
+ * Finds the inventories to compare
+ * @param paths - Inventory directories, or directories holding one inventory per environment
+ * @returns Inventory directories in a stable order
+ * @throws ConfigError when a path holds no inventory
+ */
+export const findInventories = (paths: string[]): string[] => [
+  ...new Set(paths.flatMap(target => {
+    const inventories = isInventoryDirectory(target)
+      ? [target]
+      : isDirectory(target)
+        ? fs.readdirSync(target).sort().map(name => path.join(target, name)).filter(isInventoryDirectory)
+        : [];
+
+    // Guard clause: a path that is neither an inventory nor a directory of them is a mistake
+    if (inventories.length === 0) {
+      throw new ConfigError(`No Ansible inventory found in ${target}`);
+    }
+
+    return inventories;
+  })),
+];
+
+const readText = async (filePath: string): Promise<string> => {
+  try {
+    return await fs.promises.readFile(filePath, 'utf8');
+  } catch (error) {
+    throw new IoError(`Failed to read ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+};
+
+/**
+ * Reads one variables file; files encrypted whole with ansible-vault cannot be read and count as empty
+ */
+const readVariablesFile = async (filePath: string): Promise<Record<string, unknown>> => {
+  const text = await readText(filePath);
+  return text.trimStart().startsWith(VAULT_HEADER) ? {} : parseYamlContent(text, filePath);
+};
+
+const isVariablesFile = (name: string): boolean =>
+  !name.startsWith('.') && (path.extname(name) === '' || VARIABLE_EXTENSIONS.includes(path.extname(name)));
+
+/**
+ * Reads `group_vars/`: `<group>`, `<group>.yml` or a `<group>/` directory whose files are merged in name order
+ * @param directory - group_vars directory
+ * @returns Group name -> variables
+ */
+export const readGroupVars = async (directory: string): Promise<Record<string, Record<string, unknown>>> => {
+  // Guard clause: an inventory without group_vars
+  if (!isDirectory(directory)) {
+    return {};
+  }
+
+  const entries = await Promise.all(fs.readdirSync(directory).sort().map(async (name): Promise<Array<[string, Record<string, unknown>]>> => {
+    const entryPath = path.join(directory, name);
+
+    if (isDirectory(entryPath)) {
+      const files = fs.readdirSync(entryPath).sort().filter(isVariablesFile).map(file => path.join(entryPath, file)).filter(isFile);
+      const parts = await Promise.all(files.map(readVariablesFile));
+      return [[name, Object.assign({}, ...parts)]];
+    }
+
+    return isVariablesFile(name) ? [[path.basename(name, path.extname(name)), await readVariablesFile(entryPath)]] : [];
+  }));
+
+  // `web.yml` and `web/` may both exist; Ansible reads both
+  return entries.flat().reduce<Record<string, Record<string, unknown>>>(
+    (groupVars, [group, variables]) => ({ ...groupVars, [group]: { ...(groupVars[group] ?? {}), ...variables } }),
+    {}
+  );
+};
+
+/**
+ * Parses one inventory file: `.ini` files and files with `[group]` headers are INI, the rest YAML;
+ * an extensionless file that is not YAML (a bare list of hosts) is INI as well
+ */
+const parseInventoryFile = (filePath: string, text: string): InventoryGroups => {
+  const extension = path.extname(filePath);
+
+  // Guard clause: INI by name or by content
+  if (extension === '.ini' || (extension === '' && INI_HEADER.test(text))) {
+    return parseIniInventory(text);
+  }
+
+  try {
+    return yamlInventoryGroups(parseYamlContent(text, filePath));
+  } catch (error) {
+    if (extension !== '') {
+      throw error;
+    }
+    return parseIniInventory(text);
+  }
+};
+
+/**
+ * Reads the inventory files of a directory, INI or YAML
+ * @param directory - Inventory directory
+ * @returns Groups of every inventory file found, combined
+ */
+export const readInventoryGroups = async (directory: string): Promise<InventoryGroups> => {
+  const files = INVENTORY_FILES.map(name => path.join(directory, name)).filter(isFile);
+  const sources = await Promise.all(files.map(async filePath => parseInventoryFile(filePath, await readText(filePath))));
+  return combineInventoryGroups(sources);
+};
+
+/**
+ * Reads one inventory as a file of group -> effective variables
+ * @param directory - Inventory directory; its name is the environment
+ * @returns Comparable file
+ */
+export const readAnsibleInventory = async (directory: string): Promise<ConfigFile> => {
+  const [groups, groupVars] = await Promise.all([
+    readInventoryGroups(directory),
+    readGroupVars(path.join(directory, 'group_vars')),
+  ]);
+
+  return {
+    path: directory,
+    format: ANSIBLE_FORMAT,
+    environment: path.basename(path.resolve(directory)),
+    content: effectiveGroupVariables(groups, groupVars),
+  };
+};
+
+/**
+ * Finds and reads the inventories to compare
+ * @param paths - Inventory directories, or directories holding one inventory per environment
+ * @returns One file per inventory
+ */
+export const loadAnsibleInventories = async (paths: string[]): Promise<ConfigFile[]> =>
+  Promise.all(findInventories(paths).map(readAnsibleInventory));
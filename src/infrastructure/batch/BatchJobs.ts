@@ -0,0 +1,211 @@
+/**
+ * BatchJobs - Job descriptions read from stdin by `praetorian batch`
+ *
+ * Single Responsibility: Parse the JSON/NDJSON job description other tools pipe in
+ * (file sets on disk or inline contents with their format) and load the files of a
+ * job, so Praetorian can be driven without laying files out for it.
+ */
+
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { ConfigFile, ValidationContext, ValidationResult } from '../../shared/types';
+import { ConfigError } from '../../shared/utils/ExitCodes';
+import { FileReaderService } from '../adapters/FileReaderService';
+
+/**
+ * File passed by content instead of by path
+ */
+export interface InlineFile {
+  /** Name reported in findings; its extension picks the format unless `format` is set */
+  name: string;
+  content: string;
+  format?: string;
+}
+
+export interface BatchJob {
+  /** Echoed back with the result; defaults to the position of the job */
+  id: string;
+  files: string[];
+  inline: InlineFile[];
+  ignoreKeys?: string[];
+  requiredKeys?: string[];
+}
+
+/**
+ * One line of `praetorian batch` output
+ */
+export interface BatchJobResult {
+  id: string;
+  result?: ValidationResult;
+  error?: string;
+}
+
+const isStringArray = (value: unknown): value is string[] =>
+  Array.isArray(value) && value.every(item => typeof item === 'string');
+
+const toInlineFile = (value: unknown, where: string): InlineFile => {
+  const entry = (value ?? {}) as Record<string, unknown>;
+
+  // Guard clause: inline files need a name and string content
+  if (typeof entry.name !== 'string' || typeof entry.content !== 'string') {
+    throw new ConfigError(`${where}: inline files need a string "name" and "content"`);
+  }
+
+  return {
+    name: entry.name,
+    content: entry.content,
+    ...(typeof entry.format === 'string' ? { format: entry.format } : {}),
+  };
+};
+
+/**
+ * Pure function to normalize one job description
+ * @param value - Parsed JSON of the job
+ * @param index - Position of the job, used as default id
+ */
+export const toBatchJob = (value: unknown, index: number): BatchJob => {
+  const where = `job ${index + 1}`;
+
+  // Guard clause: not an object
+  if (value === null || typeof value !== 'object' || Array.isArray(value)) {
+    throw new ConfigError(`${where}: expected an object`);
+  }
+
+  const job = value as Record<string, unknown>;
+  const files = job.files ?? [];
+  const inline = job.inline ?? [];
+
+  if (!isStringArray(files)) {
+    throw new ConfigError(`${where}: "files" must be a list of paths`);
+  }
+
+  if (!Array.isArray(inline)) {
+    throw new ConfigError(`${where}: "inline" must be a list of files`);
+  }
+
+  if (files.length + inline.length === 0) {
+    throw new ConfigError(`${where}: no "files" or "inline" contents to compare`);
+  }
+
+  return {
+    id: job.id !== undefined ? String(job.id) : String(index + 1),
+    files,
+    inline: inline.map(entry => toInlineFile(entry, where)),
+    ...(isStringArray(job.ignoreKeys) ? { ignoreKeys: job.ignoreKeys } : {}),
+    ...(isStringArray(job.requiredKeys) ? { requiredKeys: job.requiredKeys } : {}),
+  };
+};
+
+const parseJson = (text: string, where: string): unknown => {
+  try {
+    return JSON.parse(text);
+  } catch (error) {
+    throw new ConfigError(`${where}: invalid JSON: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+};
+
+const isJson = (text: string): boolean => {
+  try {
+    JSON.parse(text);
+    return true;
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * The whole input is one JSON document, pretty-printed or not; it is NDJSON only when it is not
+ * and its first line is a document of its own
+ */
+const parseDocuments = (trimmed: string): unknown[] => {
+  // Guard clause: a single document, on one line or many
+  if (isJson(trimmed)) {
+    return [JSON.parse(trimmed)];
+  }
+
+  const lines = trimmed.split(/\r?\n/).filter(line => line.trim() !== '');
+
+  // Guard clause: not NDJSON either, so the error is about the document
+  if (lines.length === 1 || !isJson(lines[0])) {
+    return [parseJson(trimmed, 'input')];
+  }
+
+  return lines.map((line, index) => parseJson(line, `line ${index + 1}`));
+};
+
+/**
+ * Pure function to parse the batch input.
+ * Accepts a JSON array of jobs, a JSON object with a `jobs` array, a single job object,
+ * or NDJSON with one job per line.
+ */
+export const parseBatchInput = (text: string): BatchJob[] => {
+  const trimmed = text.trim();
+
+  // Guard clause: empty input
+  if (trimmed === '') {
+    return [];
+  }
+
+  const documents = parseDocuments(trimmed);
+
+  const jobs = documents.flatMap(document => {
+    if (Array.isArray(document)) {
+      return document;
+    }
+
+    const jobsField = (document as { jobs?: unknown } | null)?.jobs;
+    return Array.isArray(jobsField) ? jobsField : [document];
+  });
+
+  return jobs.map(toBatchJob);
+};
+
+/**
+ * Pure function to build the validation context of a job
+ */
+export const jobContext = (job: BatchJob, base: ValidationContext = {}): ValidationContext => ({
+  ...base,
+  ...(job.ignoreKeys ? { ignoreKeys: job.ignoreKeys } : {}),
+  ...(job.requiredKeys ? { requiredKeys: job.requiredKeys } : {}),
+});
+
+/**
+ * Reads inline files through the regular adapters, reporting them under their own names.
+ * Contents are staged in a private temporary directory that is removed afterwards.
+ */
+export const readInlineFiles = async (inline: InlineFile[]): Promise<ConfigFile[]> => {
+  // Guard clause: nothing inline
+  if (inline.length === 0) {
+    return [];
+  }
+
+  const directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-batch-'));
+
+  try {
+    const staged = inline.map((file, index) => ({
+      file,
+      // Glob characters are dropped so the staged path can double as a format override pattern
+      stagedPath: path.join(directory, `${index}-${path.basename(file.name).replace(/[*?]/g, '_')}`),
+    }));
+    staged.forEach(({ file, stagedPath }) => fs.writeFileSync(stagedPath, file.content));
+
+    const overrides = Object.fromEntries(
+      staged.flatMap(({ file, stagedPath }) => file.format ? [[stagedPath, file.format]] : [])
+    );
+    const reader = new FileReaderService(overrides);
+    const files = await reader.readFiles(staged.map(({ stagedPath }) => stagedPath));
+
+    return files.map((configFile, index) => ({ ...configFile, path: staged[index].file.name }));
+  } finally {
+    fs.rmSync(directory, { recursive: true, force: true });
+  }
+};
+
+/**
+ * Loads every file of a job: paths on disk first, then inline contents
+ */
+export const readJobFiles = async (job: BatchJob, reader: FileReaderService = new FileReaderService()): Promise<ConfigFile[]> => [
+  ...(await reader.readFiles(job.files)),
+  ...(await readInlineFiles(job.inline)),
+];
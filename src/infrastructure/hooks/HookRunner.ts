@@ -0,0 +1,306 @@
+/**
+ * HookRunner - Pre/post audit hooks
+ *
+ * Single Responsibility: Run the shell commands configured under `hooks:` before
+ * an audit (render helm values, decrypt files) and after it (upload the report),
+ * passing paths and the result summary through PRAETORIAN_* environment variables.
+ * Hook output goes to stderr so JSON results on stdout stay parseable. Each command
+ * runs in its own process group under wall-clock, CPU time and memory limits, and
+ * is killed with everything it started once it goes over one.
+ */
+
+import { ChildProcess, spawn } from 'child_process';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { ValidationError, ValidationResult } from '../../shared/types';
+import { InterruptedError, IoError, throwIfInterrupted } from '../../shared/utils/ExitCodes';
+
+export type HookStage = 'pre' | 'post';
+
+export type HookLimit = 'time' | 'cpu' | 'memory';
+
+export interface HookInfo {
+  /** Path of praetorian.yaml */
+  config?: string;
+  environment?: string;
+  /** Files being audited */
+  files: string[];
+  /** Result of the audit (post hooks only) */
+  result?: ValidationResult;
+  /** JSON file holding the full result (post hooks only) */
+  resultFile?: string;
+}
+
+export interface HookRunOptions {
+  timeoutMs?: number;
+  /** CPU time each command (with the processes it starts) may use, in seconds */
+  cpuSeconds?: number;
+  /** Resident memory each command (with the processes it starts) may use, in megabytes */
+  memoryMb?: number;
+  /** Where hook stdout/stderr are forwarded; defaults to process.stderr */
+  output?: NodeJS.WritableStream;
+  /** Kills the running command once aborted */
+  signal?: AbortSignal;
+}
+
+/**
+ * @interface HookLimitBreach
+ * @description A hook command killed for going over one of its limits
+ */
+export interface HookLimitBreach {
+  stage?: HookStage;
+  command: string;
+  limit: HookLimit;
+  /** The limit: milliseconds, CPU seconds or megabytes */
+  max: number;
+}
+
+/**
+ * @interface ProcessUsage
+ * @description CPU and memory of one process, as read from /proc/<pid>/stat
+ */
+export interface ProcessUsage {
+  pid: number;
+  pgrp: number;
+  /** User and system time of the process and of its reaped children, in clock ticks */
+  cpuTicks: number;
+  rssPages: number;
+}
+
+export const DEFAULT_HOOK_TIMEOUT_MS = 300000;
+
+export const HOOK_LIMIT_CODE = 'HOOK_LIMIT_EXCEEDED';
+
+// How often the CPU time and memory of a running hook are sampled
+const USAGE_SAMPLE_INTERVAL_MS = 200;
+// USER_HZ and the page size of Linux on mainstream architectures
+const CLOCK_TICKS_PER_SECOND = 100;
+const PAGE_SIZE_BYTES = 4096;
+
+const describeBreach = (breach: HookLimitBreach, subject: string = 'Hook'): string => {
+  switch (breach.limit) {
+    case 'cpu':
+      return `${subject} exceeded its CPU time limit of ${breach.max}s: ${breach.command}`;
+    case 'memory':
+      return `${subject} exceeded its memory limit of ${breach.max} MB: ${breach.command}`;
+    default:
+      return `${subject} timed out after ${breach.max}ms: ${breach.command}`;
+  }
+};
+
+/**
+ * Hook command killed for going over its wall-clock, CPU time or memory limit (exit code 3)
+ */
+export class HookLimitError extends IoError {
+  constructor(readonly breach: HookLimitBreach) {
+    super(describeBreach(breach));
+  }
+}
+
+/**
+ * Pure function to read the fields of a /proc/<pid>/stat line the limits need
+ */
+export const parseProcStat = (text: string): ProcessUsage | undefined => {
+  // The command name (field 2) is in parentheses and may itself contain spaces or parentheses
+  const end = text.lastIndexOf(')');
+
+  // Guard clause: not a stat line
+  if (end < 0) {
+    return undefined;
+  }
+
+  // fields[0] is field 3 (state): pgrp is field 5, utime to cstime fields 14-17, rss field 24
+  const fields = text.slice(end + 2).trim().split(/\s+/).map(Number);
+  return {
+    pid: Number(text.slice(0, text.indexOf(' '))),
+    pgrp: fields[2],
+    cpuTicks: fields[11] + fields[12] + fields[13] + fields[14],
+    rssPages: fields[21],
+  };
+};
+
+/**
+ * Pure function to add up the CPU time and memory of the processes of a process group
+ */
+export const groupUsage = (processes: ProcessUsage[], pgid: number): { cpuSeconds: number; memoryMb: number } => {
+  const members = processes.filter(usage => usage.pgrp === pgid);
+  return {
+    cpuSeconds: members.reduce((total, usage) => total + usage.cpuTicks, 0) / CLOCK_TICKS_PER_SECOND,
+    memoryMb: members.reduce((total, usage) => total + usage.rssPages, 0) * PAGE_SIZE_BYTES / (1024 * 1024),
+  };
+};
+
+/**
+ * CPU time and memory of a process group, or undefined where /proc is not available
+ */
+export const processGroupUsage = (pgid: number, procDir: string = '/proc'): { cpuSeconds: number; memoryMb: number } | undefined => {
+  let entries: string[];
+  try {
+    entries = fs.readdirSync(procDir).filter(entry => /^\d+$/.test(entry));
+  } catch {
+    return undefined;
+  }
+
+  const processes = entries
+    .map(entry => {
+      try {
+        return parseProcStat(fs.readFileSync(path.join(procDir, entry, 'stat'), 'utf8'));
+      } catch {
+        // The process ended while the group was being read
+        return undefined;
+      }
+    })
+    .filter((usage): usage is ProcessUsage => usage !== undefined);
+  return groupUsage(processes, pgid);
+};
+
+/**
+ * Pure function to report a hook killed for going over a limit as a finding
+ */
+export const hookLimitFinding = (breach: HookLimitBreach): ValidationError => ({
+  code: HOOK_LIMIT_CODE,
+  message: describeBreach(breach, breach.stage === 'post' ? 'Post hook' : breach.stage === 'pre' ? 'Pre hook' : 'Hook'),
+  severity: 'error',
+  context: {
+    extras: { ...(breach.stage ? { stage: breach.stage } : {}), command: breach.command, limit: breach.limit, max: breach.max },
+  },
+});
+
+/**
+ * Kills a hook together with the processes it started
+ */
+const killHook = (child: ChildProcess, signal: NodeJS.Signals): void => {
+  try {
+    // The hook leads its own process group
+    process.kill(-(child.pid as number), signal);
+  } catch {
+    child.kill(signal);
+  }
+};
+
+/**
+ * Pure function to build the PRAETORIAN_* variables passed to a hook
+ */
+export const hookEnvironment = (stage: HookStage, info: HookInfo): Record<string, string> => ({
+  PRAETORIAN_HOOK: stage,
+  PRAETORIAN_CONFIG: info.config ?? '',
+  PRAETORIAN_ENV: info.environment ?? '',
+  PRAETORIAN_FILES: info.files.join(path.delimiter),
+  ...(info.result
+    ? {
+      PRAETORIAN_SUCCESS: String(info.result.success),
+      PRAETORIAN_ERRORS: String(info.result.errors.length),
+      PRAETORIAN_WARNINGS: String(info.result.warnings.length),
+    }
+    : {}),
+  ...(info.resultFile ? { PRAETORIAN_RESULT_FILE: info.resultFile } : {}),
+});
+
+/**
+ * Runs one hook command through the shell
+ * @returns Exit code of the command
+ */
+export const runHookCommand = (
+  command: string,
+  env: Record<string, string>,
+  options: HookRunOptions = {}
+): Promise<number> =>
+  new Promise((resolve, reject) => {
+    const output = options.output ?? process.stderr;
+    const timeoutMs = options.timeoutMs ?? DEFAULT_HOOK_TIMEOUT_MS;
+    const child = spawn(command, {
+      shell: true,
+      env: { ...process.env, ...env },
+      stdio: ['ignore', 'pipe', 'pipe'],
+      // Its own process group lets the limits cover, and the kill reach, what the command starts
+      detached: process.platform !== 'win32',
+    });
+
+    child.stdout?.pipe(output, { end: false });
+    child.stderr?.pipe(output, { end: false });
+
+    const breach = (limit: HookLimit, max: number) => {
+      cleanup();
+      killHook(child, 'SIGKILL');
+      reject(new HookLimitError({ command, limit, max }));
+    };
+    const timer = setTimeout(() => breach('time', timeoutMs), timeoutMs);
+    const sampler = (options.cpuSeconds !== undefined || options.memoryMb !== undefined) && child.pid !== undefined
+      ? setInterval(() => {
+        const usage = processGroupUsage(child.pid as number);
+        if (usage && options.cpuSeconds !== undefined && usage.cpuSeconds > options.cpuSeconds) {
+          breach('cpu', options.cpuSeconds);
+        } else if (usage && options.memoryMb !== undefined && usage.memoryMb > options.memoryMb) {
+          breach('memory', options.memoryMb);
+        }
+      }, USAGE_SAMPLE_INTERVAL_MS)
+      : undefined;
+    const onAbort = () => {
+      cleanup();
+      killHook(child, 'SIGTERM');
+      reject(new InterruptedError(`Interrupted while running hook: ${command}`));
+    };
+    const cleanup = () => {
+      clearTimeout(timer);
+      clearInterval(sampler);
+      options.signal?.removeEventListener('abort', onAbort);
+    };
+    options.signal?.addEventListener('abort', onAbort, { once: true });
+
+    child.on('error', error => {
+      cleanup();
+      reject(error);
+    });
+    child.on('close', code => {
+      cleanup();
+      resolve(code ?? 1);
+    });
+  });
+
+/**
+ * Runs the hooks of a stage in order, stopping at the first failing command
+ */
+export const runHooks = async (
+  stage: HookStage,
+  commands: string[],
+  info: HookInfo,
+  options: HookRunOptions = {}
+): Promise<void> => {
+  const env = hookEnvironment(stage, info);
+
+  for (const command of commands) {
+    throwIfInterrupted(options.signal);
+    const exitCode = await runHookCommand(command, env, options).catch(error => {
+      throw error instanceof HookLimitError ? new HookLimitError({ ...error.breach, stage }) : error;
+    });
+
+    if (exitCode !== 0) {
+      throw new IoError(`${stage} hook failed with exit code ${exitCode}: ${command}`);
+    }
+  }
+};
+
+/**
+ * Runs post hooks with the result written to a temporary JSON file (PRAETORIAN_RESULT_FILE)
+ */
+export const runPostHooks = async (
+  commands: string[],
+  info: HookInfo & { result: ValidationResult },
+  options: HookRunOptions = {}
+): Promise<void> => {
+  // Guard clause: nothing to run
+  if (commands.length === 0) {
+    return;
+  }
+
+  const directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-hook-'));
+  const resultFile = path.join(directory, 'result.json');
+  fs.writeFileSync(resultFile, JSON.stringify(info.result, null, 2));
+
+  try {
+    await runHooks('post', commands, { ...info, resultFile }, options);
+  } finally {
+    fs.rmSync(directory, { recursive: true, force: true });
+  }
+};
@@ -0,0 +1,97 @@
+/**
+ * EndpointProbe - Reachability of URLs found in configuration values
+ *
+ * Single Responsibility: Resolve the host of a URL and, when asked, send it a HEAD
+ * request, classifying what went wrong: unknown host, unreachable endpoint, expired
+ * TLS certificate or a redirect to another host. Only run behind --check-endpoints.
+ */
+
+import * as dns from 'dns';
+import { HttpClient } from './HttpClient';
+
+export type EndpointProblem = 'DNS_FAILED' | 'UNREACHABLE' | 'TLS_EXPIRED' | 'REDIRECT_HOST';
+
+export interface EndpointProbeResult {
+  url: string;
+  problem?: EndpointProblem;
+  detail?: string;
+  /** Redirect target (REDIRECT_HOST only) */
+  location?: string;
+}
+
+export interface EndpointProbeOptions {
+  /** Send a HEAD request after resolving the host */
+  head?: boolean;
+  client?: HttpClient;
+  /** Host resolver (injectable for tests) */
+  lookup?: (hostname: string) => Promise<unknown>;
+}
+
+const EXPIRED_CERTIFICATE = /certificate has expired|CERT_HAS_EXPIRED/i;
+
+/**
+ * Pure function to check if a redirect leaves the host of the original URL
+ */
+export const redirectsToOtherHost = (url: string, location: string | undefined): boolean => {
+  // Guard clause: no redirect target
+  if (!location) {
+    return false;
+  }
+
+  try {
+    return new URL(location, url).hostname.toLowerCase() !== new URL(url).hostname.toLowerCase();
+  } catch {
+    return false;
+  }
+};
+
+const errorMessage = (error: unknown): string => error instanceof Error ? error.message : 'Unknown error';
+
+/**
+ * Probes one URL; problems are reported in the result, never thrown
+ */
+export const probeEndpoint = async (url: string, options: EndpointProbeOptions = {}): Promise<EndpointProbeResult> => {
+  const lookup = options.lookup ?? ((hostname: string) => dns.promises.lookup(hostname));
+
+  try {
+    await lookup(new URL(url).hostname);
+  } catch (error) {
+    return { url, problem: 'DNS_FAILED', detail: errorMessage(error) };
+  }
+
+  // Guard clause: DNS only
+  if (!options.head) {
+    return { url };
+  }
+
+  try {
+    const response = await (options.client ?? new HttpClient({ retries: 0 })).head(url);
+    const location = response.headers.location;
+
+    return response.status >= 300 && response.status < 400 && redirectsToOtherHost(url, location)
+      ? { url, problem: 'REDIRECT_HOST', detail: `HTTP ${response.status}`, location: new URL(location as string, url).href }
+      : { url };
+  } catch (error) {
+    const detail = errorMessage(error);
+    return { url, problem: EXPIRED_CERTIFICATE.test(detail) ? 'TLS_EXPIRED' : 'UNREACHABLE', detail };
+  }
+};
+
+/**
+ * Probes every URL once, a few at a time
+ */
+export const probeEndpoints = async (
+  urls: string[],
+  options: EndpointProbeOptions = {},
+  concurrency = 8
+): Promise<Map<string, EndpointProbeResult>> => {
+  const unique = [...new Set(urls)];
+  const results = new Map<string, EndpointProbeResult>();
+
+  for (let start = 0; start < unique.length; start += concurrency) {
+    const batch = await Promise.all(unique.slice(start, start + concurrency).map(url => probeEndpoint(url, options)));
+    batch.forEach(result => results.set(result.url, result));
+  }
+
+  return results;
+};
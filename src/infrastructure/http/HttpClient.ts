@@ -0,0 +1,288 @@
+/**
+ * HttpClient - Shared HTTP client for remote operations
+ *
+ * Single Responsibility: Perform GET/HEAD/POST requests for remote sources and publishers
+ * with a per-request timeout, retries with exponential backoff, HTTP(S)_PROXY /
+ * NO_PROXY support (CONNECT tunnelling for https) and an optional CA bundle.
+ */
+
+import * as fs from 'fs';
+import * as http from 'http';
+import * as https from 'https';
+import * as net from 'net';
+import * as tls from 'tls';
+import { HttpSettings } from '../../shared/types';
+import { ConfigError, RemoteError } from '../../shared/utils/ExitCodes';
+
+export interface HttpClientOptions extends HttpSettings {
+  /** Environment used to resolve proxy variables */
+  env?: Record<string, string | undefined>;
+}
+
+export interface HttpRequest {
+  method: 'GET' | 'HEAD' | 'POST';
+  headers: Record<string, string>;
+  body?: string;
+}
+
+export interface HttpResponse {
+  status: number;
+  headers: http.IncomingHttpHeaders;
+  body: string;
+}
+
+export const DEFAULT_HTTP_OPTIONS = {
+  timeoutMs: 10000,
+  retries: 2,
+  retryBackoffMs: 500
+};
+
+/**
+ * Pure function to check if a response status is worth retrying
+ */
+export const isRetryableStatus = (status: number): boolean =>
+  status === 408 || status === 429 || status >= 500;
+
+/**
+ * Pure function to compute the delay before a retry (attempt 0 is the first retry)
+ */
+export const backoffDelay = (attempt: number, baseMs: number): number =>
+  baseMs * 2 ** attempt;
+
+/**
+ * Pure function to check if a host is excluded from proxying by a NO_PROXY list
+ */
+export const shouldBypassProxy = (hostname: string, noProxy = ''): boolean => {
+  const host = hostname.toLowerCase();
+
+  return noProxy
+    .split(',')
+    .map(entry => entry.trim().toLowerCase().replace(/:\d+$/, ''))
+    .filter(entry => entry.length > 0)
+    .some(entry => {
+      const domain = entry.replace(/^\*?\./, '');
+      return entry === '*' || host === domain || host.endsWith(`.${domain}`);
+    });
+};
+
+/**
+ * Pure function to resolve the proxy URL to use for a request, if any
+ */
+export const resolveProxy = (url: URL, options: HttpClientOptions = {}): string | undefined => {
+  const env = options.env ?? process.env;
+  const noProxy = options.noProxy ?? env.NO_PROXY ?? env.no_proxy;
+
+  // Guard clause: host excluded from proxying
+  if (shouldBypassProxy(url.hostname, noProxy)) {
+    return undefined;
+  }
+
+  const httpProxy = env.HTTP_PROXY ?? env.http_proxy;
+  const fromEnv = url.protocol === 'https:' ? env.HTTPS_PROXY ?? env.https_proxy ?? httpProxy : httpProxy;
+  const proxy = options.proxy ?? fromEnv;
+
+  return proxy ? proxy : undefined;
+};
+
+/**
+ * Pure function to build the Proxy-Authorization header from proxy URL credentials
+ */
+export const proxyAuthorization = (proxy: URL): Record<string, string> => {
+  // Guard clause: anonymous proxy
+  if (!proxy.username) {
+    return {};
+  }
+
+  const credentials = `${decodeURIComponent(proxy.username)}:${decodeURIComponent(proxy.password)}`;
+  return { 'proxy-authorization': `Basic ${Buffer.from(credentials).toString('base64')}` };
+};
+
+/**
+ * Pure function to get the file extension of a URL path, defaulting to `.yaml`
+ * (YAML parsing also accepts JSON documents)
+ */
+export const urlExtension = (url: string): string => {
+  const match = new URL(url).pathname.match(/\.[A-Za-z0-9]+$/);
+  return match ? match[0].toLowerCase() : '.yaml';
+};
+
+const sleep = (ms: number): Promise<void> => new Promise(resolve => setTimeout(resolve, ms));
+
+export class HttpClient {
+  private readonly timeoutMs: number;
+  private readonly retries: number;
+  private readonly retryBackoffMs: number;
+  private readonly ca?: Buffer;
+
+  constructor(private readonly options: HttpClientOptions = {}) {
+    this.timeoutMs = options.timeoutMs ?? DEFAULT_HTTP_OPTIONS.timeoutMs;
+    this.retries = options.retries ?? DEFAULT_HTTP_OPTIONS.retries;
+    this.retryBackoffMs = options.retryBackoffMs ?? DEFAULT_HTTP_OPTIONS.retryBackoffMs;
+    this.ca = options.caFile ? this.readCaBundle(options.caFile) : undefined;
+  }
+
+  /**
+   * GET a URL, retrying transient failures. Non-retryable statuses are returned as-is.
+   */
+  async get(url: string, headers: Record<string, string> = {}): Promise<HttpResponse> {
+    return this.request(url, { method: 'GET', headers });
+  }
+
+  /**
+   * HEAD a URL, with the same retry policy as GET. Redirects are returned, not followed.
+   */
+  async head(url: string, headers: Record<string, string> = {}): Promise<HttpResponse> {
+    return this.request(url, { method: 'HEAD', headers });
+  }
+
+  /**
+   * POST a body to a URL, with the same retry policy as GET
+   */
+  async post(url: string, body: string, headers: Record<string, string> = {}): Promise<HttpResponse> {
+    return this.request(url, {
+      method: 'POST',
+      headers: { 'content-length': String(Buffer.byteLength(body)), ...headers },
+      body
+    });
+  }
+
+  private async request(url: string, request: HttpRequest): Promise<HttpResponse> {
+    const target = new URL(url);
+    let lastError = 'no attempt made';
+
+    for (let attempt = 0; attempt <= this.retries; attempt++) {
+      if (attempt > 0) {
+        await sleep(backoffDelay(attempt - 1, this.retryBackoffMs));
+      }
+
+      try {
+        const response = await this.requestOnce(target, request);
+        if (!isRetryableStatus(response.status) || attempt === this.retries) {
+          return response;
+        }
+        lastError = `HTTP ${response.status}`;
+      } catch (error) {
+        lastError = error instanceof Error ? error.message : 'Unknown error';
+      }
+    }
+
+    throw new RemoteError(`Request to ${url} failed after ${this.retries + 1} attempt(s): ${lastError}`);
+  }
+
+  /**
+   * GET a URL and return its body, failing on non-2xx statuses
+   */
+  async getText(url: string, headers: Record<string, string> = {}): Promise<string> {
+    const response = await this.get(url, headers);
+
+    // Guard clause: unsuccessful status
+    if (response.status < 200 || response.status >= 300) {
+      throw new RemoteError(`Request to ${url} failed with HTTP ${response.status}`);
+    }
+
+    return response.body;
+  }
+
+  private readCaBundle(caFile: string): Buffer {
+    try {
+      return fs.readFileSync(caFile);
+    } catch (error) {
+      throw new ConfigError(`Failed to read CA bundle ${caFile}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+    }
+  }
+
+  private async requestOnce(url: URL, request: HttpRequest): Promise<HttpResponse> {
+    const controller = new AbortController();
+    const timer = setTimeout(() => controller.abort(), this.timeoutMs);
+
+    try {
+      return await this.send(url, request, controller.signal);
+    } catch (error) {
+      if (controller.signal.aborted) {
+        throw new Error(`timed out after ${this.timeoutMs}ms`);
+      }
+      throw error;
+    } finally {
+      clearTimeout(timer);
+    }
+  }
+
+  private async send(url: URL, request: HttpRequest, signal: AbortSignal): Promise<HttpResponse> {
+    const { method, headers, body } = request;
+    const proxyUrl = resolveProxy(url, this.options);
+    const proxy = proxyUrl ? new URL(proxyUrl) : undefined;
+    const isHttps = url.protocol === 'https:';
+
+    // https through a proxy: tunnel with CONNECT, then speak TLS over the socket
+    if (proxy && isHttps) {
+      const socket = await this.openTunnel(proxy, url, signal);
+      return this.collect(https.request(url, {
+        method,
+        headers,
+        signal,
+        createConnection: () => tls.connect({
+          socket,
+          servername: net.isIP(url.hostname) ? undefined : url.hostname,
+          ca: this.ca
+        })
+      }), body);
+    }
+
+    // http through a proxy: send the absolute URL to the proxy
+    if (proxy) {
+      return this.collect(http.request({
+        host: proxy.hostname,
+        port: proxy.port || 80,
+        path: url.href,
+        method,
+        headers: { ...headers, host: url.host, ...proxyAuthorization(proxy) },
+        signal
+      }), body);
+    }
+
+    return isHttps
+      ? this.collect(https.request(url, { method, headers, signal, ca: this.ca }), body)
+      : this.collect(http.request(url, { method, headers, signal }), body);
+  }
+
+  private openTunnel(proxy: URL, target: URL, signal: AbortSignal): Promise<net.Socket> {
+    return new Promise((resolve, reject) => {
+      const request = http.request({
+        host: proxy.hostname,
+        port: proxy.port || 80,
+        method: 'CONNECT',
+        path: `${target.hostname}:${target.port || 443}`,
+        headers: { host: `${target.hostname}:${target.port || 443}`, ...proxyAuthorization(proxy) },
+        signal
+      });
+
+      request.once('connect', (response, socket) => {
+        if (response.statusCode === 200) {
+          resolve(socket);
+          return;
+        }
+        socket.destroy();
+        reject(new Error(`proxy CONNECT failed with HTTP ${response.statusCode}`));
+      });
+      request.once('error', reject);
+      request.end();
+    });
+  }
+
+  private collect(request: http.ClientRequest, body?: string): Promise<HttpResponse> {
+    return new Promise((resolve, reject) => {
+      request.once('response', response => {
+        const chunks: Buffer[] = [];
+        response.on('data', (chunk: Buffer) => chunks.push(chunk));
+        response.once('end', () => resolve({
+          status: response.statusCode ?? 0,
+          headers: response.headers,
+          body: Buffer.concat(chunks).toString('utf8')
+        }));
+        response.once('error', reject);
+      });
+      request.once('error', reject);
+      request.end(body);
+    });
+  }
+}
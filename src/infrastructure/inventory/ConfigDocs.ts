@@ -0,0 +1,196 @@
+/**
+ * ConfigDocs - Living documentation of the configuration surface
+ *
+ * Single Responsibility: Describe every key of the configured files — type, the
+ * environments that set it, an example value and the rules of praetorian.yaml that
+ * apply to it — and render the description as Markdown for operations teams.
+ * Example values of secret keys and canaries are always redacted.
+ */
+
+import { BudgetSettings, Canary } from '../../shared/types';
+import { extractKeyValues, isPlainObject, isWildcardPattern, matchesKeyPattern } from '../../shared/utils/KeyPaths';
+import { createRedactor, isSecretKey, REDACTED, Redactor } from '../../shared/utils/Redaction';
+import { FileReaderService } from '../adapters/FileReaderService';
+import { ConfigParser } from '../parsers/ConfigParser';
+import { InventorySources, InventoryTarget, inventoryTargets } from './Inventory';
+
+/**
+ * The rules of praetorian.yaml that can apply to a key
+ */
+export interface DocRules {
+  requiredKeys?: string[];
+  schema?: Record<string, string>;
+  patterns?: Record<string, string>;
+  forbiddenKeys?: string[];
+  ignoreKeys?: string[];
+  canaries?: Canary[];
+  cronKeys?: string[];
+  budgets?: BudgetSettings[];
+  secretKeys?: string[];
+}
+
+/**
+ * A parsed file to document; `content` is undefined when the file could not be read
+ */
+export interface DocumentedFile extends InventoryTarget {
+  content?: Record<string, any>;
+  error?: string;
+}
+
+export interface KeyDoc {
+  key: string;
+  /** Declared `schema:` type, or the types of the values found */
+  types: string[];
+  /** Environments (or paths of files without one) that set the key; empty when no file does */
+  setIn: string[];
+  /** First value found, redacted when the key holds a secret */
+  example?: unknown;
+  rules: string[];
+}
+
+// Longest example shown before it is cut
+const MAX_EXAMPLE_LENGTH = 60;
+
+const typeOf = (value: unknown): string =>
+  value === null ? 'null' : Array.isArray(value) ? 'array' : typeof value;
+
+const matchingPatterns = (key: string, patterns: string[] = []): string[] =>
+  patterns.filter(pattern => matchesKeyPattern(key, pattern));
+
+/**
+ * Pure function to list the rules that apply to a key
+ */
+export const rulesForKey = (key: string, rules: DocRules): string[] => [
+  ...(matchingPatterns(key, rules.requiredKeys).length > 0 ? ['required'] : []),
+  ...matchingPatterns(key, Object.keys(rules.schema ?? {})).map(pattern => `type \`${rules.schema?.[pattern]}\``),
+  ...matchingPatterns(key, Object.keys(rules.patterns ?? {})).map(pattern => `pattern \`${rules.patterns?.[pattern]}\``),
+  ...(matchingPatterns(key, rules.forbiddenKeys).length > 0 ? ['forbidden'] : []),
+  ...(matchingPatterns(key, rules.ignoreKeys).length > 0 ? ['not compared'] : []),
+  ...((rules.canaries ?? []).some(canary => canary.key === key) ? ['canary'] : []),
+  ...(matchingPatterns(key, rules.cronKeys).length > 0 ? ['cron expression'] : []),
+  ...(rules.budgets ?? []).filter(budget => matchingPatterns(key, budget.keys).length > 0).map(budget => `budget \`${budget.name}\``),
+];
+
+/**
+ * Pure function to describe the keys of the given files
+ * @param files - Parsed files, in configuration order
+ * @param rules - Rules of praetorian.yaml
+ * @param redactor - Redaction applied to every example on top of secret keys
+ * @returns One entry per leaf key, and one per key named by a rule that no file sets, sorted by key
+ */
+export const documentKeys = (
+  files: DocumentedFile[],
+  rules: DocRules = {},
+  redactor: Redactor = createRedactor()
+): KeyDoc[] => {
+  const found = files.flatMap(file => [...extractKeyValues(file.content ?? {})]
+    .filter(([, value]) => !isPlainObject(value))
+    .map(([key, value]) => ({ key, value, setIn: file.environment ?? file.path })));
+  // Keys a rule names without wildcards are documented even when no file sets them yet
+  const declared = [
+    ...(rules.requiredKeys ?? []),
+    ...Object.keys(rules.schema ?? {}),
+    ...(rules.canaries ?? []).map(canary => canary.key),
+  ].filter(key => !isWildcardPattern(key));
+  const keys = [...new Set([...found.map(entry => entry.key), ...declared])].sort();
+
+  return keys.map(key => {
+    const values = found.filter(entry => entry.key === key);
+    const declaredType = Object.entries(rules.schema ?? {}).find(([pattern]) => matchesKeyPattern(key, pattern))?.[1];
+    const secret = isSecretKey(key.split('.').pop(), rules.secretKeys) || (rules.canaries ?? []).some(canary => canary.key === key);
+    const example = values.find(entry => entry.value !== null && entry.value !== '')?.value;
+
+    return {
+      key,
+      types: declaredType ? [declaredType] : [...new Set(values.map(entry => typeOf(entry.value)))].sort(),
+      setIn: [...new Set(values.map(entry => entry.setIn))],
+      ...(example === undefined ? {} : { example: secret ? REDACTED : redactor.redact(example, key.split('.').pop()) }),
+      rules: rulesForKey(key, rules),
+    };
+  });
+};
+
+/**
+ * Pure function to write a value as a Markdown table cell
+ */
+const cell = (value: unknown): string => {
+  const text = typeof value === 'string' ? value : JSON.stringify(value);
+  const short = text.length > MAX_EXAMPLE_LENGTH ? `${text.slice(0, MAX_EXAMPLE_LENGTH)}…` : text;
+  const escaped = short.replace(/\r?\n/g, ' ').replace(/\|/g, '\\|');
+  return escaped.includes('`') ? `\`\` ${escaped} \`\`` : `\`${escaped}\``;
+};
+
+/**
+ * Pure function to render the documentation as Markdown
+ * @param keys - Described keys
+ * @param files - Documented files, listed with their environment or read error
+ * @param config - Path of praetorian.yaml, named in the header
+ * @returns Markdown without dates, so that regenerating unchanged files gives the same text
+ */
+export const renderConfigDocs = (keys: KeyDoc[], files: DocumentedFile[], config?: string): string => [
+  '# Configuration Reference',
+  '',
+  `Generated by \`praetorian docs generate\`${config ? ` from \`${config}\`` : ''}. Do not edit by hand.`,
+  '',
+  '## Files',
+  '',
+  '| File | Environment | Group | Keys |',
+  '|------|-------------|-------|------|',
+  ...files.map(file => [
+    `\`${file.path}\``,
+    file.environment ?? '—',
+    file.group ?? '—',
+    file.content ? String(keys.filter(doc => doc.setIn.includes(file.environment ?? file.path)).length) : `unreadable: ${file.error ?? ''}`.replace(/\|/g, '\\|'),
+  ].join(' | ')).map(row => `| ${row} |`),
+  '',
+  '## Keys',
+  '',
+  '| Key | Type | Set in | Example | Rules |',
+  '|-----|------|--------|---------|-------|',
+  ...keys.map(doc => [
+    `\`${doc.key}\``,
+    doc.types.join(', ') || '—',
+    doc.setIn.join(', ') || 'not set',
+    doc.example === undefined ? '—' : cell(doc.example),
+    doc.rules.join(', ') || '—',
+  ].join(' | ')).map(row => `| ${row} |`),
+  '',
+].join('\n');
+
+/**
+ * Collects the rules of praetorian.yaml that apply to keys
+ */
+export const docRulesOf = (parser: ConfigParser): DocRules => ({
+  requiredKeys: parser.getRequiredKeys(),
+  schema: parser.getSchema(),
+  patterns: parser.getPatterns(),
+  forbiddenKeys: parser.getForbiddenKeys(),
+  ignoreKeys: parser.getIgnoreKeys(),
+  canaries: parser.getCanaries(),
+  cronKeys: parser.getCronSettings().keys,
+  budgets: parser.getBudgets(),
+  secretKeys: parser.getRedaction().secretKeys,
+});
+
+/**
+ * Reads the configured files and renders their documentation
+ */
+export const buildConfigDocs = async (
+  sources: InventorySources,
+  options: { config?: string; reader?: FileReaderService; rules?: DocRules; redactor?: Redactor } = {}
+): Promise<{ markdown: string; keys: KeyDoc[]; files: DocumentedFile[] }> => {
+  const reader = options.reader ?? new FileReaderService();
+  const files: DocumentedFile[] = [];
+
+  // Files are read one after the other to keep the output in configuration order
+  for (const target of inventoryTargets(sources)) {
+    try {
+      files.push({ ...target, content: (await reader.readFile(target.path)).content });
+    } catch (error) {
+      files.push({ ...target, error: error instanceof Error ? error.message : 'Unknown error' });
+    }
+  }
+
+  const keys = documentKeys(files, options.rules, options.redactor);
+  return { markdown: renderConfigDocs(keys, files, options.config), keys, files };
+};
@@ -0,0 +1,86 @@
+/**
+ * GitRevisions - Configured files at two git revisions
+ *
+ * Single Responsibility: Find which configured files changed between a base revision
+ * and the working tree (or a head revision), and parse each of them at both revisions
+ * so that their keys can be compared.
+ */
+
+import { execFile } from 'child_process';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { FileRevisions } from '../../application/validation/ChangeRisk';
+import { IoError } from '../../shared/utils/ExitCodes';
+import { FileReaderService } from '../adapters/FileReaderService';
+import { InventorySources, inventoryTargets } from './Inventory';
+
+const git = (args: string[]): Promise<string> =>
+  new Promise((resolve, reject) => {
+    execFile('git', args, { maxBuffer: 64 * 1024 * 1024 }, (error, stdout, stderr) =>
+      error ? reject(new IoError(`git ${args[0]} failed: ${(stderr || error.message).trim()}`)) : resolve(stdout));
+  });
+
+/**
+ * Paths, relative to the working directory, of the files changed since a revision
+ * @param base - Revision the changes are measured from
+ * @param head - Revision the changes go up to; the working tree when undefined
+ */
+export const gitChangedFiles = async (base: string, head?: string): Promise<string[]> =>
+  (await git(['diff', '--name-only', '--no-renames', '--relative', base, ...(head ? [head] : []), '--']))
+    .split('\n')
+    .filter(line => line.trim() !== '');
+
+/**
+ * Text of a file at a revision, or undefined when the file does not exist there
+ */
+export const gitShowFile = (revision: string, filePath: string): Promise<string | undefined> =>
+  git(['show', `${revision}:./${path.relative(process.cwd(), path.resolve(filePath)).replace(/\\/g, '/')}`])
+    .catch(() => undefined);
+
+/**
+ * Parses the text a file had at another revision with the adapter the file would use today
+ */
+const parseRevision = async (text: string, filePath: string, reader: FileReaderService): Promise<Record<string, any>> => {
+  const format = await reader.detectFormat(filePath).catch(() => undefined);
+  const directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-revision-'));
+  const copy = path.join(directory, path.basename(filePath));
+
+  try {
+    fs.writeFileSync(copy, text);
+    return (await new FileReaderService(format ? { [copy]: format } : {}).readFile(copy)).content;
+  } finally {
+    fs.rmSync(directory, { recursive: true, force: true });
+  }
+};
+
+/**
+ * Reads the configured files changed since a revision at both revisions
+ * @param sources - Environments, groups and files of praetorian.yaml
+ * @param options - Base and head revisions, and the reader of the working tree
+ * @returns One entry per changed configured file; a side is undefined where the file does not exist
+ */
+export const readChangedFiles = async (
+  sources: InventorySources,
+  options: { base: string; head?: string; reader?: FileReaderService }
+): Promise<FileRevisions[]> => {
+  const reader = options.reader ?? new FileReaderService();
+  const changed = new Set((await gitChangedFiles(options.base, options.head)).map(file => path.resolve(file)));
+  const revisions: FileRevisions[] = [];
+
+  for (const target of inventoryTargets(sources).filter(candidate => changed.has(path.resolve(candidate.path)))) {
+    const beforeText = await gitShowFile(options.base, target.path);
+    const afterText = options.head
+      ? await gitShowFile(options.head, target.path)
+      : fs.existsSync(target.path) ? fs.readFileSync(target.path, 'utf8') : undefined;
+
+    revisions.push({
+      path: target.path,
+      ...(target.environment ? { environment: target.environment } : {}),
+      ...(beforeText === undefined ? {} : { before: await parseRevision(beforeText, target.path, reader) }),
+      ...(afterText === undefined ? {} : { after: await parseRevision(afterText, target.path, reader) }),
+    });
+  }
+
+  return revisions;
+};
@@ -0,0 +1,185 @@
+/**
+ * Inventory - Configuration bill of materials (config-BOM)
+ *
+ * Single Responsibility: Describe every configuration file Praetorian knows about —
+ * path, environment, format, size, content hash, key counts and last git change —
+ * as a machine-readable artifact for compliance evidence and drift tracking.
+ * Values are never included; the hash is enough to tell two versions apart. On
+ * request each value is listed as a salted hash, so that teams can check that their
+ * values match a golden configuration without showing them to each other.
+ */
+
+import { execFile } from 'child_process';
+import { createHash } from 'crypto';
+import * as fs from 'fs';
+import * as path from 'path';
+import { AuditGroup, LabelRule } from '../../shared/types';
+import { extractKeyPaths, extractKeyValues, isPlainObject } from '../../shared/utils/KeyPaths';
+import { labelsOfFile } from '../../shared/utils/Labels';
+import { hashValue, isSecretKey } from '../../shared/utils/Redaction';
+import { ConfigError } from '../../shared/utils/ExitCodes';
+import { FileReaderService } from '../adapters/FileReaderService';
+import { ConfigParser } from '../parsers/ConfigParser';
+
+export const INVENTORY_VERSION = 1;
+
+/**
+ * A file to describe, with where it appears in praetorian.yaml
+ */
+export interface InventoryTarget {
+  path: string;
+  environment?: string;
+  group?: string;
+  /** Labels of `labels:` and `files:` entries */
+  labels?: Record<string, string>;
+}
+
+export interface InventoryEntry extends InventoryTarget {
+  format?: string;
+  size?: number;
+  /** `sha256:<hex>` of the raw file bytes */
+  sha256?: string;
+  keyCount?: number;
+  secretKeyCount?: number;
+  /** ISO date of the last commit touching the file, when it is tracked by git */
+  lastModifiedInGit?: string;
+  /** Salted hash of the value of every leaf key, when value hashes are requested */
+  values?: Record<string, string>;
+  /** Why the file could not be described */
+  error?: string;
+}
+
+export interface Inventory {
+  version: number;
+  generatedAt: string;
+  config?: string;
+  files: InventoryEntry[];
+}
+
+export interface InventorySources {
+  files?: string[];
+  environments?: Record<string, string>;
+  groups?: AuditGroup[];
+  labelRules?: LabelRule[];
+}
+
+/**
+ * Pure function to list the files to describe, once each, keeping their environment and group
+ */
+export const inventoryTargets = (sources: InventorySources): InventoryTarget[] => {
+  const targets = [
+    ...Object.entries(sources.environments ?? {}).map(([environment, file]) => ({ path: file, environment })),
+    ...(sources.groups ?? []).flatMap(group => group.files.map(file => ({ path: file, group: group.name }))),
+    ...(sources.files ?? []).map(file => ({ path: file })),
+  ];
+
+  const byPath = new Map<string, InventoryTarget>();
+  targets.forEach(target => byPath.set(target.path, { ...target, ...byPath.get(target.path) }));
+  return [...byPath.values()].map(target => {
+    const labels = labelsOfFile(target.path, sources.labelRules ?? [], sources.groups);
+    return Object.keys(labels).length > 0 ? { ...target, labels } : target;
+  });
+};
+
+/**
+ * Collects the environments, groups and files of praetorian.yaml
+ */
+export const inventorySourcesOf = (parser: ConfigParser): InventorySources => {
+  const groups = parser.getGroups();
+  const labelRules = parser.getLabelRules();
+
+  try {
+    return { environments: parser.getEnvironments(), groups, files: parser.getFilesToCompare(), labelRules };
+  } catch (error) {
+    // Guard clause: a configuration with only groups has no `files:` section
+    if (groups.length > 0 && error instanceof ConfigError) {
+      return { environments: parser.getEnvironments(), groups, labelRules };
+    }
+    throw error;
+  }
+};
+
+/**
+ * Pure function to hash raw file bytes
+ */
+export const sha256Of = (raw: Buffer | string): string =>
+  `sha256:${createHash('sha256').update(raw).digest('hex')}`;
+
+/**
+ * Pure function to count the keys of a parsed file whose name marks a secret
+ */
+export const countSecretKeys = (content: Record<string, any>, secretKeys: string[] = []): number =>
+  [...extractKeyPaths(content)].filter(keyPath => isSecretKey(keyPath.split('.').pop(), secretKeys)).length;
+
+/**
+ * Pure function to hash the value of every leaf key of a parsed file
+ */
+export const hashLeafValues = (content: Record<string, any>, salt: string): Record<string, string> =>
+  Object.fromEntries([...extractKeyValues(content)]
+    .filter(([, value]) => !isPlainObject(value))
+    .sort(([a], [b]) => a.localeCompare(b))
+    .map(([key, value]) => [key, hashValue(value, salt)]));
+
+/**
+ * Date of the last commit touching a file, or undefined when git or the history is unavailable
+ */
+export const gitLastModified = (filePath: string): Promise<string | undefined> =>
+  new Promise(resolve => {
+    execFile(
+      'git',
+      ['log', '-1', '--format=%cI', '--', path.basename(filePath)],
+      { cwd: path.dirname(path.resolve(filePath)) },
+      (error, stdout) => resolve(error || !stdout.trim() ? undefined : stdout.trim())
+    );
+  });
+
+/**
+ * Describes one file; unreadable files are listed with an error instead of failing the inventory
+ */
+export const describeFile = async (
+  target: InventoryTarget,
+  reader: FileReaderService = new FileReaderService(),
+  secretKeys: string[] = [],
+  hashSalt?: string
+): Promise<InventoryEntry> => {
+  try {
+    const raw = await fs.promises.readFile(target.path);
+    const parsed = await reader.readFile(target.path);
+    const lastModifiedInGit = await gitLastModified(target.path);
+
+    return {
+      ...target,
+      format: parsed.format,
+      size: raw.length,
+      sha256: sha256Of(raw),
+      keyCount: extractKeyPaths(parsed.content).size,
+      secretKeyCount: countSecretKeys(parsed.content, secretKeys),
+      ...(lastModifiedInGit ? { lastModifiedInGit } : {}),
+      ...(hashSalt ? { values: hashLeafValues(parsed.content, hashSalt) } : {}),
+    };
+  } catch (error) {
+    return { ...target, error: error instanceof Error ? error.message : 'Unknown error' };
+  }
+};
+
+/**
+ * Builds the inventory of every configured file
+ */
+export const buildInventory = async (
+  sources: InventorySources,
+  options: { config?: string; reader?: FileReaderService; secretKeys?: string[]; hashSalt?: string; now?: Date } = {}
+): Promise<Inventory> => {
+  const files: InventoryEntry[] = [];
+
+  // Files are described one after the other to keep the output in configuration order
+  for (const target of inventoryTargets(sources)) {
+    files.push(await describeFile(target, options.reader, options.secretKeys, options.hashSalt));
+  }
+
+  return {
+    version: INVENTORY_VERSION,
+    generatedAt: (options.now ?? new Date()).toISOString(),
+    ...(options.config ? { config: options.config } : {}),
+    files,
+  };
+};
@@ -0,0 +1,161 @@
+/**
+ * Snapshot - Golden configuration states
+ *
+ * Single Responsibility: Record the content hash and key set of every configured file,
+ * and compare a recorded snapshot with the current state, as a lightweight tamper and
+ * drift check for environments managed outside git. Values are never recorded; the
+ * canonical hash of the parsed content lets reformatted files match their snapshot.
+ */
+
+import * as fs from 'fs';
+import { canonicalHash } from '../../shared/utils/CanonicalHash';
+import { extractKeyPaths } from '../../shared/utils/KeyPaths';
+import { ConfigError, IoError } from '../../shared/utils/ExitCodes';
+import { FileReaderService } from '../adapters/FileReaderService';
+import { InventorySources, InventoryTarget, inventoryTargets, sha256Of } from './Inventory';
+
+export const SNAPSHOT_VERSION = 1;
+export const DEFAULT_SNAPSHOT_FILE = '.praetorian-snapshot.json';
+
+export interface SnapshotEntry extends InventoryTarget {
+  sha256: string;
+  /** Canonical hash of the parsed content, equal across key order, whitespace and format (see CanonicalHash) */
+  contentHash?: string;
+  /** Sorted dotted key paths */
+  keys: string[];
+}
+
+export interface Snapshot {
+  version: number;
+  createdAt: string;
+  config?: string;
+  files: SnapshotEntry[];
+}
+
+export type SnapshotDriftKind = 'FILE_ADDED' | 'FILE_REMOVED' | 'CONTENT_CHANGED' | 'KEYS_ADDED' | 'KEYS_REMOVED';
+
+export interface SnapshotDrift {
+  kind: SnapshotDriftKind;
+  path: string;
+  /** Keys added or removed (KEYS_* only) */
+  keys?: string[];
+}
+
+/**
+ * Records one file; a file that cannot be read cannot be part of a golden state
+ */
+export const snapshotFile = async (
+  target: InventoryTarget,
+  reader: FileReaderService = new FileReaderService()
+): Promise<SnapshotEntry> => {
+  try {
+    const raw = await fs.promises.readFile(target.path);
+    const parsed = await reader.readFile(target.path);
+    return {
+      ...target,
+      sha256: sha256Of(raw),
+      contentHash: canonicalHash(parsed.content),
+      keys: [...extractKeyPaths(parsed.content)].sort(),
+    };
+  } catch (error) {
+    throw new IoError(`Failed to snapshot ${target.path}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+};
+
+/**
+ * Records the current state of every configured file
+ */
+export const createSnapshot = async (
+  sources: InventorySources,
+  options: { config?: string; reader?: FileReaderService; now?: Date } = {}
+): Promise<Snapshot> => {
+  const files: SnapshotEntry[] = [];
+
+  for (const target of inventoryTargets(sources)) {
+    files.push(await snapshotFile(target, options.reader));
+  }
+
+  return {
+    version: SNAPSHOT_VERSION,
+    createdAt: (options.now ?? new Date()).toISOString(),
+    ...(options.config ? { config: options.config } : {}),
+    files,
+  };
+};
+
+const difference = (left: string[], right: string[]): string[] => {
+  const excluded = new Set(right);
+  return left.filter(item => !excluded.has(item));
+};
+
+/**
+ * Pure function to compare two entries of the same file
+ */
+const entryDrift = (recorded: SnapshotEntry, current: SnapshotEntry): SnapshotDrift[] => {
+  // Guard clause: unchanged bytes, or the same content written differently
+  if (recorded.sha256 === current.sha256 || (recorded.contentHash !== undefined && recorded.contentHash === current.contentHash)) {
+    return [];
+  }
+
+  const added = difference(current.keys, recorded.keys);
+  const removed = difference(recorded.keys, current.keys);
+
+  return [
+    { kind: 'CONTENT_CHANGED' as const, path: current.path },
+    ...(added.length > 0 ? [{ kind: 'KEYS_ADDED' as const, path: current.path, keys: added }] : []),
+    ...(removed.length > 0 ? [{ kind: 'KEYS_REMOVED' as const, path: current.path, keys: removed }] : []),
+  ];
+};
+
+/**
+ * Pure function to list how the current state diverges from a recorded snapshot
+ */
+export const compareSnapshots = (recorded: Snapshot, current: Snapshot): SnapshotDrift[] => {
+  const recordedByPath = new Map(recorded.files.map(entry => [entry.path, entry]));
+  const currentPaths = new Set(current.files.map(entry => entry.path));
+
+  return [
+    ...recorded.files
+      .filter(entry => !currentPaths.has(entry.path))
+      .map(entry => ({ kind: 'FILE_REMOVED' as const, path: entry.path })),
+    ...current.files.flatMap(entry => {
+      const previous = recordedByPath.get(entry.path);
+      return previous ? entryDrift(previous, entry) : [{ kind: 'FILE_ADDED' as const, path: entry.path }];
+    }),
+  ];
+};
+
+/**
+ * Reads a snapshot written by `praetorian snapshot create`
+ */
+export const readSnapshot = (filePath: string): Snapshot => {
+  // Guard clause: no snapshot recorded yet
+  if (!fs.existsSync(filePath)) {
+    throw new ConfigError(`Snapshot not found: ${filePath}. Run "praetorian snapshot create" first.`);
+  }
+
+  let snapshot: Snapshot;
+  try {
+    snapshot = JSON.parse(fs.readFileSync(filePath, 'utf8')) as Snapshot;
+  } catch (error) {
+    throw new ConfigError(`Invalid snapshot file ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+
+  // Guard clause: not a snapshot or written by a newer version
+  if (!Array.isArray(snapshot?.files) || snapshot.version > SNAPSHOT_VERSION) {
+    throw new ConfigError(`Unsupported snapshot file: ${filePath}`);
+  }
+
+  return snapshot;
+};
+
+/**
+ * Writes a snapshot as pretty-printed JSON
+ */
+export const writeSnapshot = (filePath: string, snapshot: Snapshot): void => {
+  try {
+    fs.writeFileSync(filePath, `${JSON.stringify(snapshot, null, 2)}\n`);
+  } catch (error) {
+    throw new IoError(`Failed to write snapshot to ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+};
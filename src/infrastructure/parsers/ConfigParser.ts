@@ -1,6 +1,7 @@
 import * as path from 'path';
-import { PraetorianConfig } from '../../shared/types';
+import { AuditGroup, BrokerSettings, BudgetSettings, Canary, CloudIdentifierSettings, CommentedConfigSettings, ComparisonSettings, CronSettings, EscalationConfig, FeatureFlagSettings, ForbiddenKeyRule, FormatLintSettings, HookSettings, HttpSettings, KeyOrderSettings, LabelRule, LeakageSettings, OwnerSettings, PerformanceBounds, PerformanceSettings, PraetorianConfig, RateLimitSettings, RedactionSettings, RuleScope, UserConfig, ValueConstraint, ValueDifferenceRule } from '../../shared/types';
 import { DEFAULT_PRAETORIAN_CONFIG } from '../../shared/templates/rule-templates';
+import { DEFAULT_STREAM_ABOVE_BYTES } from '../adapters/StreamingKeyTree';
 import {
   fileExists,
   readFileSync,
@@ -11,18 +12,46 @@ import {
   resolvePath,
   getDirectoryName,
   joinPath,
+  expandFileGlob,
+  pathPlaceholders,
+  fillPathTemplate,
+  discoverPathTemplateValues,
 } from './config-parsing/ConfigFileOperations';
 import {
   validatePraetorianConfig,
   hasFilesToValidate,
 } from './config-parsing/ConfigValidation';
+import {
+  configSchemaVersion,
+  findUnknownConfigFields,
+  formatUnknownConfigField,
+  getConfigSchema,
+  UnknownConfigField,
+} from './config-parsing/ConfigSchema';
+import { mergeUserConfig } from './config-parsing/UserConfig';
+import { getFileEntryFormats, getFileEntryLabels, getFileEntryPaths, stringifyLabels } from '../../shared/utils/FileEntries';
+import { hasGlobMagic } from '../../shared/utils/Glob';
+import { parseDurationString } from '../../shared/utils/Quantities';
+import { ConfigError, IoError } from '../../shared/utils/ExitCodes';
+
+const asList = (value?: string | string[]): string[] => (value === undefined ? [] : Array.isArray(value) ? value : [value]);
+
+export interface ConfigParserOptions {
+  /** Reject fields that are not part of the configuration schema */
+  strict?: boolean;
+  /** User configuration merged under the file (see UserConfig) */
+  userConfig?: UserConfig;
+}
 
 export class ConfigParser {
   private configPath: string;
   private config: PraetorianConfig | null = null;
+  private unknownFields: UnknownConfigField[] = [];
+  private readonly options: ConfigParserOptions;
 
-  constructor(configPath: string = 'praetorian.yaml') {
+  constructor(configPath: string = 'praetorian.yaml', options: ConfigParserOptions = {}) {
     this.configPath = configPath;
+    this.options = options;
   }
 
   /**
@@ -36,31 +65,52 @@ export class ConfigParser {
 
     // Guard clause: file doesn't exist
     if (!fileExists(this.configPath)) {
-      throw new Error(`Configuration file not found: ${this.configPath}`);
+      throw new ConfigError(`Configuration file not found: ${this.configPath}`);
     }
 
     const readResult = readFileSync(this.configPath);
     
     // Guard clause: failed to read file
     if (!readResult.success || !readResult.content) {
-      throw new Error(readResult.error || 'Failed to read configuration file');
+      throw new ConfigError(readResult.error || 'Failed to read configuration file');
     }
 
     try {
-      this.config = parseYamlContent(readResult.content) as PraetorianConfig;
-      
+      const config = parseYamlContent(readResult.content) as PraetorianConfig;
+      const unknownFields = findUnknownConfigFields(readResult.content, getConfigSchema(configSchemaVersion(config)));
+
+      // Guard clause: strict mode rejects misspelled or unsupported fields
+      if (this.options.strict && unknownFields.length > 0) {
+        throw new ConfigError(unknownFields.map(formatUnknownConfigField).join(', '));
+      }
+
       // Validate configuration
-      const validation = validatePraetorianConfig(this.config);
+      const validation = validatePraetorianConfig(config);
       if (!validation.isValid) {
-        throw new Error(`Configuration validation failed: ${validation.errors.join(', ')}`);
+        throw new ConfigError(`Configuration validation failed: ${validation.errors.join(', ')}`);
       }
-      
+
+      this.config = this.options.userConfig ? mergeUserConfig(this.options.userConfig, config) : config;
+      this.unknownFields = unknownFields;
       return this.config;
     } catch (error) {
-      throw new Error(`Failed to parse configuration file: ${error instanceof Error ? error.message : 'Unknown error'}`);
+      // Guard clause: strict mode and validation errors already say what is wrong
+      if (error instanceof ConfigError) {
+        throw error;
+      }
+
+      throw new ConfigError(`Failed to parse configuration file: ${error instanceof Error ? error.message : 'Unknown error'}`);
     }
   }
 
+  /**
+   * Get fields of the configuration file that are not part of the schema
+   */
+  getUnknownFields(): UnknownConfigField[] {
+    this.load();
+    return this.unknownFields;
+  }
+
   /**
    * Get files to compare from configuration
    */
@@ -69,20 +119,102 @@ export class ConfigParser {
     
     // Guard clause: no files to validate
     if (!hasFilesToValidate(config)) {
-      throw new Error('No files specified in configuration. Use "files" or "environments" section.');
+      throw new ConfigError('No files specified in configuration. Use "files" or "environments" section.');
     }
 
-    // Return files array if available
+    // Return files array if available, expanding glob entries
     if (config.files && Array.isArray(config.files) && config.files.length > 0) {
-      return config.files;
+      return this.expandFilePaths(getFileEntryPaths(config.files));
     }
 
     // Return environment files if available
     if (config.environments && typeof config.environments === 'object') {
-      return Object.values(config.environments);
+      return this.expandEnvironments().map(entry => entry.file);
+    }
+
+    throw new ConfigError('No files specified in configuration. Use "files" or "environments" section.');
+  }
+
+  /**
+   * Expand glob paths into the files they match
+   */
+  private expandFilePaths(filePaths: string[]): string[] {
+    const expanded = filePaths.flatMap(filePath => hasGlobMagic(filePath) ? expandFileGlob(filePath) : [filePath]);
+
+    // Guard clause: globs matched nothing
+    if (expanded.length === 0) {
+      throw new ConfigError(`No files matched the configured patterns: ${filePaths.join(', ')}`);
+    }
+
+    return [...new Set(expanded)];
+  }
+
+  /**
+   * Get audit groups (files compared among themselves only), with globs expanded
+   */
+  getGroups(): AuditGroup[] {
+    const config = this.load();
+    const configured = (config.groups && typeof config.groups === 'object')
+      ? Object.entries(config.groups).map(([name, entries]) => ({
+        name,
+        files: this.expandFilePaths(getFileEntryPaths(entries)),
+      }))
+      : [];
+
+    return [...configured, ...this.getTemplateGroups()];
+  }
+
+  /**
+   * Get one group per set of placeholder values of templated environments, holding the
+   * file of each environment (or of one environment); empty without templates
+   */
+  getTemplateGroups(environment?: string): AuditGroup[] {
+    const entries = this.expandEnvironments()
+      .filter(entry => entry.group !== undefined && (environment === undefined || entry.environment === environment));
+    const names = [...new Set(entries.map(entry => entry.group as string))];
+
+    return names.map(name => ({ name, files: entries.filter(entry => entry.group === name).map(entry => entry.file) }));
+  }
+
+  /**
+   * Expand the environments map: plain paths as they are, and templated paths
+   * (`configs/{service}/prod.yaml`) once per set of placeholder values found on disk
+   * for any environment, so that a service missing one environment's file is reported
+   */
+  private expandEnvironments(): Array<{ environment: string; file: string; group?: string }> {
+    const config = this.load();
+    const environments: Record<string, string> = (config.environments && typeof config.environments === 'object') ? config.environments : {};
+    const templates = Object.values(environments).filter(file => pathPlaceholders(file).length > 0);
+
+    // Guard clause: no templates
+    if (templates.length === 0) {
+      return Object.entries(environments).map(([environment, file]) => ({ environment, file }));
+    }
+
+    const placeholders = pathPlaceholders(templates[0]);
+    const placeholderSet = (template: string) => pathPlaceholders(template).sort().map(name => `{${name}}`).join(', ');
+    const mismatched = templates.filter(template => placeholderSet(template) !== placeholderSet(templates[0]));
+
+    // Guard clause: every environment is filled from the same values, so every template needs the same placeholders
+    if (mismatched.length > 0) {
+      const uses = [templates[0], ...mismatched].map(template => `${template} uses ${placeholderSet(template)}`);
+      throw new ConfigError(`Environment templates must all use the same placeholders: ${uses.join('; ')}`);
     }
 
-    throw new Error('No files specified in configuration. Use "files" or "environments" section.');
+    const discovered = new Map(templates
+      .flatMap(discoverPathTemplateValues)
+      .map(values => [placeholders.map(name => values[name]).join('/'), values] as const));
+
+    // Guard clause: no service found
+    if (discovered.size === 0) {
+      throw new ConfigError(`No files matched the environment templates: ${templates.join(', ')}`);
+    }
+
+    return [...discovered.keys()].sort().flatMap(group => Object.entries(environments).map(([environment, template]) => ({
+      environment,
+      file: fillPathTemplate(template, discovered.get(group)!),
+      group,
+    })));
   }
 
   /**
@@ -95,14 +227,16 @@ export class ConfigParser {
     if (environment && config.environments) {
       const envFile = config.environments[environment];
       if (!envFile) {
-        throw new Error(`Environment '${environment}' not found in configuration`);
+        throw new ConfigError(`Environment '${environment}' not found in configuration`);
       }
-      return [envFile];
+      return pathPlaceholders(envFile).length > 0
+        ? this.expandEnvironments().filter(entry => entry.environment === environment).map(entry => entry.file)
+        : [envFile];
     }
 
     // Return all environment files if no specific environment requested
     if (config.environments && typeof config.environments === 'object') {
-      return Object.values(config.environments);
+      return this.expandEnvironments().map(entry => entry.file);
     }
 
     // Fallback to files array
@@ -117,6 +251,14 @@ export class ConfigParser {
     return Array.isArray(config.ignore_keys) ? config.ignore_keys : [];
   }
 
+  /**
+   * Get the rule sets to run, the user configuration's when praetorian.yaml sets none; undefined when neither does
+   */
+  getRuleSets(): string[] | undefined {
+    const config = this.load();
+    return Array.isArray(config.ruleSets) ? config.ruleSets.filter(ruleSet => typeof ruleSet === 'string') : undefined;
+  }
+
   /**
    * Get required keys that must be present
    */
@@ -142,19 +284,474 @@ export class ConfigParser {
   }
 
   /**
-   * Get forbidden keys
+   * Get the value constraints of `rules:` by key pattern; a list of rules belongs to the rule system
+   */
+  getValueRules(): Record<string, ValueConstraint> {
+    const config = this.load();
+    return config.rules && typeof config.rules === 'object' && !Array.isArray(config.rules) ? config.rules : {};
+  }
+
+  /**
+   * Get forbidden key patterns, whatever environments they are forbidden in
    */
   getForbiddenKeys(): string[] {
+    return this.getForbiddenKeyRules().flatMap(rule => rule.keys);
+  }
+
+  /**
+   * Get forbidden keys with the environments they apply to; a plain pattern applies to every environment
+   */
+  getForbiddenKeyRules(): ForbiddenKeyRule[] {
     const config = this.load();
-    return Array.isArray(config.forbidden_keys) ? config.forbidden_keys : [];
+
+    // Guard clause: no deny-list configured
+    if (!Array.isArray(config.forbidden_keys)) {
+      return [];
+    }
+
+    return config.forbidden_keys.map(entry => typeof entry === 'string'
+      ? { keys: [entry], environments: [] }
+      : { keys: asList(entry.keys), environments: asList(entry.environments) });
   }
 
   /**
-   * Get available environments
+   * Get extraction limits (max nesting depth and key count)
+   */
+  getLimits(): { maxDepth?: number; maxKeys?: number } {
+    const config = this.load();
+    const limits = (config.limits && typeof config.limits === 'object') ? config.limits : {};
+
+    return {
+      ...(typeof limits.max_depth === 'number' ? { maxDepth: limits.max_depth } : {}),
+      ...(typeof limits.max_keys === 'number' ? { maxKeys: limits.max_keys } : {}),
+    };
+  }
+
+  /**
+   * Get the size in bytes above which JSON and YAML files are streamed into their key tree
+   */
+  getStreamAboveBytes(): number {
+    const limits = this.load().limits;
+    return typeof limits?.stream_above_mb === 'number'
+      ? Math.round(limits.stream_above_mb * 1024 * 1024)
+      : DEFAULT_STREAM_ABOVE_BYTES;
+  }
+
+  /**
+   * Get the formats whose parsers are enabled, or undefined when every format is
+   */
+  getParsers(): string[] | undefined {
+    const parsers = this.load().parsers;
+    return Array.isArray(parsers) ? parsers.filter((format): format is string => typeof format === 'string') : undefined;
+  }
+
+  /**
+   * Get severity escalation rules (a single `escalate:` object is treated as a list of one)
+   */
+  getEscalationRules(): EscalationConfig[] {
+    const config = this.load();
+
+    // Guard clause: no escalation configured
+    if (!config.escalate || typeof config.escalate !== 'object') {
+      return [];
+    }
+
+    return Array.isArray(config.escalate) ? config.escalate : [config.escalate];
+  }
+
+  /**
+   * Get the key comparison strategy (`comparison: symmetric` is shorthand for `{ strategy: symmetric }`)
+   */
+  getComparison(): ComparisonSettings {
+    const config = this.load();
+
+    // Guard clause: no comparison configured
+    if (!config.comparison) {
+      return {};
+    }
+
+    return typeof config.comparison === 'string' ? { strategy: config.comparison } : config.comparison;
+  }
+
+  /**
+   * Get rule scopes (files and groups each rule or auditor runs on)
+   */
+  getScopes(): RuleScope[] {
+    const config = this.load();
+
+    // Guard clause: no scopes configured
+    if (!config.scopes || typeof config.scopes !== 'object') {
+      return [];
+    }
+
+    return Array.isArray(config.scopes) ? config.scopes : [config.scopes];
+  }
+
+  /**
+   * Get canaries (honeytoken keys that must stay present and unmodified)
+   */
+  getCanaries(): Canary[] {
+    const config = this.load();
+
+    // Guard clause: no canaries configured
+    if (!config.canaries || typeof config.canaries !== 'object') {
+      return [];
+    }
+
+    return Array.isArray(config.canaries) ? config.canaries : [config.canaries];
+  }
+
+  /**
+   * Get environment leakage settings; undefined when the check is off (the default)
+   */
+  getLeakage(): LeakageSettings | undefined {
+    const config = this.load();
+
+    // Guard clause: not enabled
+    if (!config.leakage || (typeof config.leakage === 'object' && config.leakage.enabled === false)) {
+      return undefined;
+    }
+
+    return typeof config.leakage === 'object' && config.leakage.tokens ? { tokens: config.leakage.tokens } : {};
+  }
+
+  /**
+   * Get feature flag settings (rollout threshold)
+   */
+  getFeatureFlagSettings(): FeatureFlagSettings {
+    const config = this.load();
+    const featureFlags = (config.feature_flags && typeof config.feature_flags === 'object') ? config.feature_flags : {};
+
+    return typeof featureFlags.rollout_threshold === 'number' ? { rolloutThreshold: featureFlags.rollout_threshold } : {};
+  }
+
+  /**
+   * Get the cloud identifiers each environment may use; identifiers are compared as strings
+   */
+  getCloudIdentifiers(): CloudIdentifierSettings {
+    const config = this.load();
+    const environments = (config.cloud_identifiers && typeof config.cloud_identifiers === 'object') ? config.cloud_identifiers : {};
+    const kinds = ['regions', 'accounts', 'projects', 'subscriptions'] as const;
+
+    return Object.fromEntries(Object.entries(environments).map(([environment, allowList]) => [
+      environment,
+      Object.fromEntries(kinds
+        .filter(kind => Array.isArray(allowList?.[kind]))
+        .map(kind => [kind, (allowList[kind] as unknown[]).map(String)])),
+    ]));
+  }
+
+  /**
+   * Get message broker settings (consumer group pattern, replication factor minimum)
+   */
+  getBrokerSettings(): BrokerSettings {
+    const config = this.load();
+    const brokers = (config.brokers && typeof config.brokers === 'object') ? config.brokers : {};
+
+    return {
+      ...(typeof brokers.consumer_group_pattern === 'string' ? { consumerGroupPattern: brokers.consumer_group_pattern } : {}),
+      ...(typeof brokers.min_replication_factor === 'number' ? { minReplicationFactor: brokers.min_replication_factor } : {}),
+    };
+  }
+
+  /**
+   * Get cron settings (scheduler key patterns, shortest production interval)
+   */
+  getCronSettings(): CronSettings {
+    const config = this.load();
+    const cron = (config.cron && typeof config.cron === 'object') ? config.cron : {};
+    const keys = cron.keys === undefined ? [] : Array.isArray(cron.keys) ? cron.keys : [cron.keys];
+    const interval = typeof cron.min_interval === 'string' ? parseDurationString(cron.min_interval) : undefined;
+
+    return {
+      keys,
+      ...(interval !== undefined ? { minIntervalMs: interval } : {}),
+    };
+  }
+
+  /**
+   * Get budgets; amounts stay as written and are read by the budget rule
+   */
+  getBudgets(): BudgetSettings[] {
+    const config = this.load();
+
+    // Guard clause: no budgets configured
+    if (!config.budgets || typeof config.budgets !== 'object') {
+      return [];
+    }
+
+    return (Array.isArray(config.budgets) ? config.budgets : [config.budgets]).map(budget => ({
+      name: budget.name,
+      keys: Array.isArray(budget.keys) ? budget.keys : [budget.keys],
+      ...(budget.max_total !== undefined ? { maxTotal: budget.max_total } : {}),
+      ...(budget.max_value !== undefined ? { maxValue: budget.max_value } : {}),
+      ...(budget.environment !== undefined ? { environment: budget.environment } : {}),
+    }));
+  }
+
+  /**
+   * Get the performance check settings; undefined when the checks are off (the default)
+   */
+  getPerformanceSettings(): PerformanceSettings | undefined {
+    const config = this.load();
+    const performance = config.performance;
+
+    // Guard clause: checks not configured
+    if (!performance || typeof performance !== 'object') {
+      return undefined;
+    }
+
+    const bounds = (category?: { keys?: string | string[]; min?: number | string; max?: number | string }): PerformanceBounds => ({
+      keys: asList(category?.keys),
+      ...(category?.min !== undefined ? { min: category.min } : {}),
+      ...(category?.max !== undefined ? { max: category.max } : {}),
+    });
+
+    return {
+      timeouts: {
+        ...bounds(performance.timeouts),
+        ...(typeof performance.timeouts?.required === 'boolean' ? { required: performance.timeouts.required } : {}),
+      },
+      pools: bounds(performance.pools),
+      retries: bounds(performance.retries),
+      caches: bounds(performance.caches),
+    };
+  }
+
+  /**
+   * Get the keys whose values are compared across files; a difference is a warning unless told otherwise
+   */
+  getValueDifferenceRules(): ValueDifferenceRule[] {
+    const config = this.load();
+
+    // Guard clause: no value_differences configured
+    if (!config.value_differences || typeof config.value_differences !== 'object') {
+      return [];
+    }
+
+    return (Array.isArray(config.value_differences) ? config.value_differences : [config.value_differences]).map(rule => ({
+      keys: asList(rule.keys),
+      severity: rule.severity ?? 'warning',
+    }));
+  }
+
+  /**
+   * Get commented-out configuration thresholds; undefined when the check is off (the default)
+   */
+  getCommentedConfigSettings(): CommentedConfigSettings | undefined {
+    const config = this.load();
+    const commented = config.commented_config;
+
+    // Guard clause: check not configured
+    if (!commented || typeof commented !== 'object') {
+      return undefined;
+    }
+
+    return {
+      ...(typeof commented.min_lines === 'number' ? { minLines: commented.min_lines } : {}),
+      ...(typeof commented.min_assignments === 'number' ? { minAssignments: commented.min_assignments } : {}),
+    };
+  }
+
+  /**
+   * Get formatting lint settings (line endings, tab width); undefined when the check is off (the default)
+   */
+  getFormatLintSettings(): FormatLintSettings | undefined {
+    const config = this.load();
+    const lint = config.format_lint;
+
+    // Guard clause: check not configured
+    if (!lint || typeof lint !== 'object') {
+      return undefined;
+    }
+
+    return {
+      ...(lint.line_endings === 'lf' || lint.line_endings === 'crlf' ? { lineEndings: lint.line_endings } : {}),
+      ...(typeof lint.tab_width === 'number' ? { tabWidth: lint.tab_width } : {}),
+    };
+  }
+
+  /**
+   * Get the canonical key order; undefined when the check is off (the default)
+   */
+  getKeyOrderSettings(): KeyOrderSettings | undefined {
+    const config = this.load();
+    const keyOrder = config.key_order;
+
+    // Guard clause: check not configured
+    if (!keyOrder || typeof keyOrder !== 'object') {
+      return undefined;
+    }
+
+    return {
+      order: Array.isArray(keyOrder.order) ? keyOrder.order : 'alphabetical',
+      files: Array.isArray(keyOrder.files) ? keyOrder.files : [],
+    };
+  }
+
+  /**
+   * Get the label rules of `labels:`, then of labelled `files:` and `groups:` entries, which override them
+   */
+  getLabelRules(): LabelRule[] {
+    const config = this.load();
+    const groupEntries = config.groups && typeof config.groups === 'object' ? Object.values(config.groups).flat() : [];
+
+    return [
+      ...(Array.isArray(config.labels) ? config.labels : []).map(entry => ({
+        files: asList(entry.files),
+        groups: asList(entry.groups),
+        labels: stringifyLabels(entry.labels),
+      })),
+      ...getFileEntryLabels(config.files),
+      ...getFileEntryLabels(groupEntries),
+    ];
+  }
+
+  /**
+   * Get the teams owning key patterns and files, in declaration order
+   */
+  getOwners(): OwnerSettings[] {
+    const config = this.load();
+
+    return (Array.isArray(config.owners) ? config.owners : []).map(owner => ({
+      team: owner.team,
+      keys: asList(owner.keys),
+      files: asList(owner.files),
+      ...(owner.channel !== undefined ? { channel: owner.channel } : {}),
+    }));
+  }
+
+  /**
+   * Get rate limit settings; undefined when no endpoints or quotas are configured (the check is off)
+   */
+  getRateLimitSettings(): RateLimitSettings | undefined {
+    const config = this.load();
+    const rateLimits = (config.rate_limits && typeof config.rate_limits === 'object') ? config.rate_limits : {};
+    const patterns = (value?: string | string[]): string[] => value === undefined ? [] : Array.isArray(value) ? value : [value];
+    const endpoints = patterns(rateLimits.endpoints);
+    const quotas = patterns(rateLimits.quotas);
+
+    // Guard clause: nothing to check
+    if (endpoints.length === 0 && quotas.length === 0) {
+      return undefined;
+    }
+
+    return {
+      endpoints,
+      quotas,
+      ...(rateLimits.public !== undefined ? { public: rateLimits.public } : {}),
+      ...(rateLimits.limit !== undefined ? { limit: rateLimits.limit } : {}),
+      ...(rateLimits.burst !== undefined ? { burst: rateLimits.burst } : {}),
+      ...(typeof rateLimits.burst_factor === 'number' ? { burstFactor: rateLimits.burst_factor } : {}),
+    };
+  }
+
+  /**
+   * Get message templates (finding code -> template)
+   */
+  getMessageTemplates(): Record<string, string> {
+    const config = this.load();
+    return (config.messages && typeof config.messages === 'object') ? config.messages : {};
+  }
+
+  /**
+   * Get HTTP settings for remote operations (timeout, retries, proxy, CA bundle)
+   */
+  getHttpSettings(): HttpSettings {
+    const config = this.load();
+    const http = (config.http && typeof config.http === 'object') ? config.http : {};
+
+    return {
+      ...(typeof http.timeout_ms === 'number' ? { timeoutMs: http.timeout_ms } : {}),
+      ...(typeof http.retries === 'number' ? { retries: http.retries } : {}),
+      ...(typeof http.retry_backoff_ms === 'number' ? { retryBackoffMs: http.retry_backoff_ms } : {}),
+      ...(typeof http.proxy === 'string' ? { proxy: http.proxy } : {}),
+      ...(typeof http.no_proxy === 'string' ? { noProxy: http.no_proxy } : {}),
+      ...(typeof http.ca_file === 'string' ? { caFile: http.ca_file } : {}),
+    };
+  }
+
+  /**
+   * Get the redaction policy (`redaction: hash` is shorthand for `{ policy: hash }`)
+   */
+  getRedaction(): RedactionSettings {
+    const config = this.load();
+
+    // Guard clause: no redaction configured
+    if (!config.redaction) {
+      return {};
+    }
+
+    // Guard clause: policy name only
+    if (typeof config.redaction === 'string') {
+      return { policy: config.redaction };
+    }
+
+    return {
+      ...(config.redaction.policy !== undefined ? { policy: config.redaction.policy } : {}),
+      ...(Array.isArray(config.redaction.secret_keys) ? { secretKeys: config.redaction.secret_keys } : {}),
+    };
+  }
+
+  /**
+   * Get pre/post audit hooks (`pre: cmd` is shorthand for `pre: [cmd]`)
+   */
+  getHooks(): HookSettings {
+    const config = this.load();
+    const hooks = (config.hooks && typeof config.hooks === 'object') ? config.hooks : {};
+    const commands = (value: string | string[] | undefined): string[] =>
+      value === undefined ? [] : (Array.isArray(value) ? value : [value]);
+
+    return {
+      pre: commands(hooks.pre),
+      post: commands(hooks.post),
+      ...(typeof hooks.timeout_ms === 'number' ? { timeoutMs: hooks.timeout_ms } : {}),
+      ...(typeof hooks.cpu_seconds === 'number' ? { cpuSeconds: hooks.cpu_seconds } : {}),
+      ...(typeof hooks.memory_mb === 'number' ? { memoryMb: hooks.memory_mb } : {}),
+    };
+  }
+
+  /**
+   * Get format overrides (glob pattern -> parser format).
+   * Formats declared on `files:` entries take precedence over the `formats:` map.
+   */
+  getFormatOverrides(): Record<string, string> {
+    const config = this.load();
+    const formats = (config.formats && typeof config.formats === 'object') ? config.formats : {};
+    const groupEntries = Object.values(config.groups ?? {}).flat();
+    const entryFormats = getFileEntryFormats([...(config.files ?? []), ...groupEntries]);
+
+    // Overrides are matched in order, so entry formats are listed first
+    return Object.fromEntries([
+      ...Object.entries(entryFormats),
+      ...Object.entries(formats).filter(([pattern]) => !(pattern in entryFormats)),
+    ]);
+  }
+
+  /**
+   * Get available environments with plain paths; templated ones are expanded by getTemplateGroups
    */
   getEnvironments(): Record<string, string> {
     const config = this.load();
-    return (config.environments && typeof config.environments === 'object') ? config.environments : {};
+    const environments: Record<string, string> = (config.environments && typeof config.environments === 'object') ? config.environments : {};
+    return Object.fromEntries(Object.entries(environments).filter(([, file]) => pathPlaceholders(String(file)).length === 0));
+  }
+
+  /**
+   * Get the files of every environment, templated environments expanded from the files on disk
+   */
+  getExpandedEnvironments(): Record<string, string[]> {
+    return this.expandEnvironments().reduce<Record<string, string[]>>((expanded, entry) => ({
+      ...expanded,
+      [entry.environment]: [...(expanded[entry.environment] ?? []), entry.file],
+    }), {});
+  }
+
+  /**
+   * Get the environment of every environment file, templated environments expanded
+   */
+  getEnvironmentFileMap(): Record<string, string> {
+    return Object.fromEntries(this.expandEnvironments().map(entry => [entry.file, entry.environment]));
   }
 
   /**
@@ -170,14 +767,14 @@ export class ConfigParser {
   createDefault(): void {
     // Guard clause: file already exists
     if (fileExists(this.configPath)) {
-      throw new Error(`Configuration file already exists: ${this.configPath}`);
+      throw new ConfigError(`Configuration file already exists: ${this.configPath}`);
     }
 
     const writeResult = writeFileSync(this.configPath, DEFAULT_PRAETORIAN_CONFIG);
     
     // Guard clause: failed to write file
     if (!writeResult.success) {
-      throw new Error(writeResult.error || 'Failed to create configuration file');
+      throw new IoError(writeResult.error || 'Failed to create configuration file');
     }
     
     // Create example rule files
@@ -193,7 +790,7 @@ export class ConfigParser {
     // Create rules directory if it doesn't exist
     const createResult = createDirectorySync(rulesDir);
     if (!createResult.success) {
-      throw new Error(createResult.error || 'Failed to create rules directory');
+      throw new IoError(createResult.error || 'Failed to create rules directory');
     }
 
     // Create example rule files
@@ -217,7 +814,7 @@ export class ConfigParser {
       
       const writeResult = writeFileSync(filePath, content);
       if (!writeResult.success) {
-        throw new Error(writeResult.error || `Failed to create ${file.name}`);
+        throw new IoError(writeResult.error || `Failed to create ${file.name}`);
       }
     }
   }
@@ -7,6 +7,7 @@ import * as fs from 'fs';
 import * as path from 'path';
 import * as yaml from 'yaml';
 import { PraetorianConfig } from '../../../shared/types';
+import { globToRegExp, hasGlobMagic, normalizePath } from '../../../shared/utils/Glob';
 
 /**
  * @interface FileOperationResult
@@ -249,3 +250,82 @@ export const getFileExtension = (filePath: string): string => {
 
   return path.extname(filePath);
 };
+
+/**
+ * Lists the files matching a glob pattern, relative to the current directory
+ * @param pattern - Glob pattern such as "configs/*.conf"
+ * @returns Sorted matching file paths, or the pattern itself when it has no glob syntax
+ */
+export const expandFileGlob = (pattern: string): string[] => {
+  // Guard clause: plain path
+  if (!hasGlobMagic(pattern)) {
+    return [pattern];
+  }
+
+  const normalized = normalizePath(pattern);
+  const segments = normalized.split('/');
+  const firstMagic = segments.findIndex(segment => hasGlobMagic(segment));
+  const baseDir = segments.slice(0, firstMagic).join('/');
+  const matcher = globToRegExp(normalized);
+
+  const walk = (dir: string): string[] => {
+    try {
+      return fs.readdirSync(dir || '.', { withFileTypes: true }).flatMap(entry => {
+        const entryPath = dir ? `${dir}/${entry.name}` : entry.name;
+        if (entry.isDirectory()) {
+          return entry.name === 'node_modules' || entry.name.startsWith('.') ? [] : walk(entryPath);
+        }
+        return matcher.test(entryPath) ? [entryPath] : [];
+      });
+    } catch {
+      return [];
+    }
+  };
+
+  return walk(baseDir).sort();
+};
+
+const PLACEHOLDER = /\{([A-Za-z_][A-Za-z0-9_]*)\}/g;
+
+/**
+ * Lists the placeholders of a path template
+ * @param template - Path such as "configs/{service}/prod.yaml"
+ * @returns Placeholder names, once each, in order of appearance
+ */
+export const pathPlaceholders = (template: string): string[] =>
+  [...new Set([...template.matchAll(PLACEHOLDER)].map(match => match[1]))];
+
+/**
+ * Fills the placeholders of a path template
+ * @param template - Path template
+ * @param values - Placeholder name to value; unknown placeholders are left as they are
+ * @returns The path
+ */
+export const fillPathTemplate = (template: string, values: Record<string, string>): string =>
+  template.replace(PLACEHOLDER, (placeholder, name: string) => values[name] ?? placeholder);
+
+/**
+ * Discovers the values the placeholders of a path template take on disk
+ * @param template - Path template; each placeholder stands for part of one path segment
+ * @returns Placeholder values of every existing file matching the template, in path order
+ */
+export const discoverPathTemplateValues = (template: string): Array<Record<string, string>> => {
+  const normalized = normalizePath(template);
+  const seen = new Set<string>();
+  const pattern = new RegExp(`^${normalized.split(/(\{[A-Za-z_][A-Za-z0-9_]*\})/).map(part => {
+    const name = /^\{(.+)\}$/.exec(part)?.[1];
+    // Guard clause: literal text
+    if (name === undefined) {
+      return part.replace(/[.+^$()|[\]\\*?{}]/g, '\\$&');
+    }
+    // A placeholder used twice takes the same value both times
+    const group = seen.has(name) ? `\\k<${name}>` : `(?<${name}>[^/]+)`;
+    seen.add(name);
+    return group;
+  }).join('')}$`);
+
+  return expandFileGlob(normalized.replace(PLACEHOLDER, '*'))
+    .map(file => pattern.exec(file)?.groups)
+    .filter((values): values is Record<string, string> => values !== undefined)
+    .map(values => ({ ...values }));
+};
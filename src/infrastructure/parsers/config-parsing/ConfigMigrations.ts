@@ -0,0 +1,113 @@
+/**
+ * @file src/infrastructure/parsers/config-parsing/ConfigMigrations.ts
+ * @description Step-by-step upgrades of praetorian.yaml between schema versions, preserving comments
+ */
+
+import { Document, isMap, parseDocument } from 'yaml';
+import { CONFIG_SCHEMA_VERSION, configSchemaVersion } from './ConfigSchema';
+
+/**
+ * @interface ConfigMigration
+ * @description Upgrade of a configuration document from one schema version to the next
+ */
+export interface ConfigMigration {
+  from: number;
+  to: number;
+  description: string;
+  apply: (document: Document) => void;
+}
+
+/**
+ * @interface ConfigMigrationResult
+ * @description Outcome of migrating a configuration file
+ */
+export interface ConfigMigrationResult {
+  content: string;
+  from: number;
+  to: number;
+  applied: ConfigMigration[];
+}
+
+/**
+ * Sets the top-level `version`, keeping its position or placing it first
+ * @param document - Configuration document to update
+ * @param version - Schema version to declare
+ */
+export const setSchemaVersion = (document: Document, version: number): void => {
+  // Guard clause: empty document
+  if (!isMap(document.contents)) {
+    document.contents = document.createNode({ version }) as Document['contents'];
+    return;
+  }
+
+  if (document.has('version')) {
+    document.set('version', version);
+    return;
+  }
+
+  document.contents.items.unshift(document.createPair('version', version));
+};
+
+/**
+ * @constant CONFIG_MIGRATIONS
+ * @description Every migration, in order; add one per schema version bump
+ */
+export const CONFIG_MIGRATIONS: ConfigMigration[] = [
+  {
+    from: 0,
+    to: 1,
+    description: 'Declare schema version 1 (replaces free-form "version" labels such as "1.0.0")',
+    apply: document => setSchemaVersion(document, 1),
+  },
+];
+
+/**
+ * Lists the migrations needed to bring a configuration up to date
+ * @param version - Current schema version of the configuration
+ * @param migrations - Available migrations
+ * @returns Migrations to apply, in order
+ */
+export const pendingMigrations = (
+  version: number,
+  migrations: ConfigMigration[] = CONFIG_MIGRATIONS
+): ConfigMigration[] =>
+  migrations
+    .filter(migration => migration.from >= version && migration.to <= CONFIG_SCHEMA_VERSION)
+    .sort((a, b) => a.from - b.from);
+
+/**
+ * Migrates praetorian.yaml content to the current schema version
+ * @param content - YAML source of the configuration file
+ * @param migrations - Available migrations
+ * @returns Migrated content and the migrations applied
+ */
+export const migrateConfigContent = (
+  content: string,
+  migrations: ConfigMigration[] = CONFIG_MIGRATIONS
+): ConfigMigrationResult => {
+  const document = parseDocument(content);
+
+  // Guard clause: invalid YAML
+  if (document.errors.length > 0) {
+    throw new Error(`Failed to parse YAML: ${document.errors[0].message}`);
+  }
+
+  const from = configSchemaVersion(document.toJS());
+
+  // Guard clause: written by a newer praetorian
+  if (from > CONFIG_SCHEMA_VERSION) {
+    throw new Error(
+      `Configuration version ${from} is newer than the supported version ${CONFIG_SCHEMA_VERSION}; upgrade praetorian`
+    );
+  }
+
+  const applied = pendingMigrations(from, migrations);
+
+  // Guard clause: already current
+  if (applied.length === 0) {
+    return { content, from, to: from, applied };
+  }
+
+  applied.forEach(migration => migration.apply(document));
+  return { content: document.toString(), from, to: applied[applied.length - 1].to, applied };
+};
@@ -0,0 +1,222 @@
+/**
+ * @file src/infrastructure/parsers/config-parsing/ConfigSchema.ts
+ * @description Known praetorian.yaml fields and detection of unknown ones with their location
+ */
+
+import { isMap, isScalar, isSeq, LineCounter, Node, parseDocument } from 'yaml';
+
+/**
+ * @type ConfigFieldSpec
+ * @description Shape of a configuration field; only mappings are checked for unknown keys
+ */
+export type ConfigFieldSpec =
+  | { kind: 'any' }
+  | { kind: 'object'; fields: Record<string, ConfigFieldSpec> }
+  | { kind: 'list'; items: ConfigFieldSpec }
+  | { kind: 'map'; values: ConfigFieldSpec };
+
+/**
+ * @interface UnknownConfigField
+ * @description A key that is not part of the configuration schema
+ */
+export interface UnknownConfigField {
+  path: string;
+  line: number;
+  column: number;
+}
+
+const ANY: ConfigFieldSpec = { kind: 'any' };
+const object = (fields: Record<string, ConfigFieldSpec>): ConfigFieldSpec => ({ kind: 'object', fields });
+const list = (items: ConfigFieldSpec = ANY): ConfigFieldSpec => ({ kind: 'list', items });
+const map = (values: ConfigFieldSpec = ANY): ConfigFieldSpec => ({ kind: 'map', values });
+
+/**
+ * @constant PRAETORIAN_CONFIG_SPEC
+ * @description Every field read from praetorian.yaml by the CLI, the rule loaders and the examples
+ */
+export const PRAETORIAN_CONFIG_SPEC: ConfigFieldSpec = object({
+  // Validation
+  files: list(object({ path: ANY, format: ANY, labels: map() })),
+  groups: map(list(object({ path: ANY, format: ANY, labels: map() }))),
+  labels: list(object({ files: ANY, groups: ANY, labels: map() })),
+  environments: map(),
+  ignore_keys: list(),
+  required_keys: list(),
+  forbidden_keys: list(),
+  schema: map(),
+  patterns: map(),
+  formats: map(),
+  parsers: list(),
+  limits: object({ max_depth: ANY, max_keys: ANY, stream_above_mb: ANY }),
+  escalate: list(object({ environment: ANY, from: ANY, to: ANY, codes: list() })),
+  messages: map(),
+  comparison: object({ strategy: ANY, reference: ANY }),
+  redaction: object({ policy: ANY, secret_keys: list() }),
+  hooks: object({ pre: list(), post: list(), timeout_ms: ANY, cpu_seconds: ANY, memory_mb: ANY }),
+  leakage: object({ enabled: ANY, tokens: map(list()) }),
+  feature_flags: object({ rollout_threshold: ANY }),
+  cloud_identifiers: map(object({ regions: list(), accounts: list(), projects: list(), subscriptions: list() })),
+  brokers: object({ consumer_group_pattern: ANY, min_replication_factor: ANY }),
+  cron: object({ keys: list(), min_interval: ANY }),
+  rate_limits: object({ endpoints: list(), public: ANY, limit: ANY, burst: ANY, burst_factor: ANY, quotas: list() }),
+  canaries: list(object({ key: ANY, value: ANY, sha256: ANY, files: list() })),
+  budgets: list(object({ name: ANY, keys: list(), max_total: ANY, max_value: ANY, environment: ANY })),
+  performance: object({
+    timeouts: object({ keys: list(), min: ANY, max: ANY, required: ANY }),
+    pools: object({ keys: list(), min: ANY, max: ANY }),
+    retries: object({ keys: list(), min: ANY, max: ANY }),
+    caches: object({ keys: list(), min: ANY, max: ANY }),
+  }),
+  value_differences: list(object({ keys: list(), severity: ANY })),
+  commented_config: object({ min_lines: ANY, min_assignments: ANY }),
+  format_lint: object({ line_endings: ANY, tab_width: ANY }),
+  key_order: object({ order: ANY, files: list() }),
+  owners: list(object({ team: ANY, keys: ANY, files: ANY, channel: ANY })),
+  scopes: list(object({ rules: list(), files: list(), groups: list(), labels: map(), exclude: list(), enabled: ANY })),
+  http: object({
+    timeout_ms: ANY,
+    retries: ANY,
+    retry_backoff_ms: ANY,
+    proxy: ANY,
+    no_proxy: ANY,
+    ca_file: ANY,
+  }),
+  // Rule system
+  ruleSets: list(),
+  overrideRules: list(),
+  customRules: list(),
+  rules: ANY,
+  sources: map(),
+  globalOverrides: map(),
+  globalDisabled: list(),
+  options: map(),
+  validation: map(),
+  strict: ANY,
+  // Schema version (`version: 1`); unversioned files may carry a free-form label
+  version: ANY,
+  // Project information
+  name: ANY,
+  description: ANY,
+  project: ANY,
+});
+
+/**
+ * @constant CONFIG_SCHEMA_VERSION
+ * @description Schema version written by `praetorian init` and `praetorian config migrate`
+ */
+export const CONFIG_SCHEMA_VERSION = 1;
+
+/**
+ * @constant CONFIG_SCHEMAS
+ * @description Published schema of each configuration version (0 = unversioned files)
+ */
+export const CONFIG_SCHEMAS: Record<number, ConfigFieldSpec> = {
+  0: PRAETORIAN_CONFIG_SPEC,
+  1: PRAETORIAN_CONFIG_SPEC,
+};
+
+/**
+ * Reads the schema version of a configuration
+ * @param config - Parsed configuration
+ * @returns The numeric `version`, or 0 for unversioned files and legacy string labels such as "1.0.0"
+ */
+export const configSchemaVersion = (config: unknown): number => {
+  const version = config && typeof config === 'object' ? (config as { version?: unknown }).version : undefined;
+  return typeof version === 'number' ? version : 0;
+};
+
+/**
+ * Gets the schema of a configuration version
+ * @param version - Configuration schema version
+ * @returns Schema of that version, or the current one for unpublished versions
+ */
+export const getConfigSchema = (version: number): ConfigFieldSpec =>
+  CONFIG_SCHEMAS[version] ?? CONFIG_SCHEMAS[CONFIG_SCHEMA_VERSION];
+
+/**
+ * Finds the keys of a parsed YAML node that the spec does not know about
+ * @param node - YAML node to inspect
+ * @param spec - Expected shape of the node
+ * @param path - Dotted path of the node, for reporting
+ * @param lineCounter - Line index of the source document
+ * @returns Unknown fields in document order
+ */
+export const findUnknownFieldsInNode = (
+  node: unknown,
+  spec: ConfigFieldSpec,
+  path: string,
+  lineCounter: LineCounter
+): UnknownConfigField[] => {
+  // Guard clause: free-form field
+  if (spec.kind === 'any') {
+    return [];
+  }
+
+  if (isSeq(node)) {
+    return spec.kind === 'list'
+      ? node.items.flatMap((item, index) => findUnknownFieldsInNode(item, spec.items, `${path}[${index}]`, lineCounter))
+      : [];
+  }
+
+  // Guard clause: scalars are type-checked by ConfigValidation
+  if (!isMap(node)) {
+    return [];
+  }
+
+  // A single mapping where a list is expected stands for a list of one (e.g. `escalate:`)
+  if (spec.kind === 'list') {
+    return findUnknownFieldsInNode(node, spec.items, path, lineCounter);
+  }
+
+  return node.items.flatMap(pair => {
+    const key = isScalar(pair.key) ? String(pair.key.value) : String(pair.key);
+    const keyPath = path ? `${path}.${key}` : key;
+
+    if (spec.kind === 'map') {
+      return findUnknownFieldsInNode(pair.value, spec.values, keyPath, lineCounter);
+    }
+
+    const fieldSpec = spec.fields[key];
+    if (!fieldSpec) {
+      const offset = (pair.key as Node | null)?.range?.[0] ?? 0;
+      const { line, col } = lineCounter.linePos(offset);
+      return [{ path: keyPath, line, column: col }];
+    }
+
+    return findUnknownFieldsInNode(pair.value, fieldSpec, keyPath, lineCounter);
+  });
+};
+
+/**
+ * Finds fields of a praetorian.yaml document that are not part of the schema
+ * @param content - YAML source of the configuration file
+ * @param spec - Configuration schema to check against
+ * @returns Unknown fields with their line and column
+ */
+export const findUnknownConfigFields = (
+  content: string,
+  spec: ConfigFieldSpec = PRAETORIAN_CONFIG_SPEC
+): UnknownConfigField[] => {
+  // Guard clause: empty content
+  if (!content || content.trim().length === 0) {
+    return [];
+  }
+
+  const lineCounter = new LineCounter();
+  const document = parseDocument(content, { lineCounter });
+
+  // Guard clause: syntax errors are reported by the parser itself
+  if (document.errors.length > 0) {
+    return [];
+  }
+
+  return findUnknownFieldsInNode(document.contents, spec, '', lineCounter);
+};
+
+/**
+ * Formats an unknown field for error and warning messages
+ * @param field - Unknown field
+ * @returns Message such as 'Unknown field "http.timout_ms" at line 12, column 3'
+ */
+export const formatUnknownConfigField = (field: UnknownConfigField): string =>
+  `Unknown field "${field.path}" at line ${field.line}, column ${field.column}`;
@@ -4,6 +4,14 @@
  */
 
 import { PraetorianConfig } from '../../../shared/types';
+import { isSeverity, SEVERITIES } from '../../../shared/utils/Severity';
+import { CONFIG_SCHEMA_VERSION } from './ConfigSchema';
+import { COMPARISON_STRATEGIES, isComparisonStrategy } from '../../../domain/rules/ComparisonStrategies';
+import { isRedactionPolicy, REDACTION_POLICIES } from '../../../shared/utils/Redaction';
+import { isValueType, VALUE_TYPE_NAMES } from '../../../shared/utils/ValueTypes';
+import { parseDurationString } from '../../../shared/utils/Quantities';
+import { canonicalFormat, FileAdapterFactory } from '../../adapters/FileAdapterFactory';
+import { pathPlaceholders } from './ConfigFileOperations';
 
 /**
  * @interface ValidationResult
@@ -33,6 +41,9 @@ export const validatePraetorianConfig = (config: PraetorianConfig): ValidationRe
   const errors: string[] = [];
   const warnings: string[] = [];
 
+  // Validate schema version
+  validateVersionSection(config, errors);
+
   // Validate required sections
   validateRequiredSections(config, errors);
   
@@ -41,10 +52,67 @@ export const validatePraetorianConfig = (config: PraetorianConfig): ValidationRe
   
   // Validate environments section
   validateEnvironmentsSection(config, errors, warnings);
+
+  // Validate groups section
+  validateGroupsSection(config, errors);
   
   // Validate arrays
   validateArraySections(config, errors);
 
+  // Validate objects
+  validateObjectSections(config, errors);
+
+  // Validate extraction limits
+  validateLimitsSection(config, errors);
+
+  // Validate HTTP settings
+  validateHttpSection(config, errors);
+
+  // Validate severity escalation
+  validateEscalateSection(config, errors);
+
+  // Validate comparison strategy
+  validateComparisonSection(config, errors);
+
+  // Validate rule scopes
+  validateScopesSection(config, errors);
+
+  // Validate audit hooks
+  validateHooksSection(config, errors);
+
+  // Validate redaction policy
+  validateRedactionSection(config, errors);
+
+  // Validate canaries
+  validateCanariesSection(config, errors);
+
+  // Validate environment leakage
+  validateLeakageSection(config, errors);
+
+  // Validate feature flag settings
+  validateFeatureFlagsSection(config, errors);
+
+  // Validate cloud identifier allow-lists
+  validateCloudIdentifiersSection(config, errors);
+
+  // Validate message broker settings
+  validateBrokersSection(config, errors);
+
+  // Validate rate limit key paths
+  validateRateLimitsSection(config, errors);
+
+  // Validate cron scheduler keys
+  validateCronSection(config, errors);
+  validateBudgetsSection(config, errors);
+  validatePerformanceSection(config, errors);
+  validateValueDifferencesSection(config, errors);
+  validateValueRulesSection(config, errors);
+  validateCommentedConfigSection(config, errors);
+  validateFormatLintSection(config, errors);
+  validateKeyOrderSection(config, errors);
+  validateOwnersSection(config, errors);
+  validateLabelsSection(config, errors);
+
   return {
     isValid: errors.length === 0,
     errors,
@@ -52,6 +120,32 @@ export const validatePraetorianConfig = (config: PraetorianConfig): ValidationRe
   };
 };
 
+/**
+ * Validates the schema version; string labels of unversioned files are left alone
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateVersionSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: unversioned configuration
+  if (!config || typeof config.version !== 'number') {
+    return;
+  }
+
+  if (!Number.isInteger(config.version) || config.version < 1) {
+    errors.push('"version" must be a positive integer');
+    return;
+  }
+
+  if (config.version > CONFIG_SCHEMA_VERSION) {
+    errors.push(
+      `Configuration version ${config.version} is newer than the supported version ${CONFIG_SCHEMA_VERSION}; upgrade praetorian`
+    );
+  }
+};
+
 /**
  * Validates that required sections are present
  * @param config - Configuration to validate
@@ -67,8 +161,8 @@ export const validateRequiredSections = (
     return;
   }
 
-  if (!config.files && !config.environments) {
-    errors.push('Configuration must specify either "files" or "environments"');
+  if (!config.files && !config.environments && !config.groups) {
+    errors.push('Configuration must specify "files", "environments" or "groups"');
   }
 };
 
@@ -100,11 +194,99 @@ export const validateFilesSection = (
     return;
   }
 
-  // Validate each file path
+  // Validate each file entry: a path or a { path, format } object
   config.files.forEach((file, index) => {
+    if (file && typeof file === 'object' && !Array.isArray(file)) {
+      validateFileEntryObject(file, index, errors);
+      return;
+    }
+
     if (!file || typeof file !== 'string' || file.trim().length === 0) {
-      errors.push(`File at index ${index} must be a non-empty string`);
+      errors.push(`File at index ${index} must be a non-empty string or an object with "path"`);
+    }
+  });
+};
+
+/**
+ * Validates a `{ path, format }` file entry
+ * @param entry - File entry to validate
+ * @param index - Position of the entry in "files"
+ * @param errors - Errors array to populate
+ */
+export const validateFileEntryObject = (
+  entry: { path?: unknown; format?: unknown; labels?: unknown },
+  index: number,
+  errors: string[]
+): void => {
+  if (typeof entry.path !== 'string' || entry.path.trim().length === 0) {
+    errors.push(`File at index ${index} must have a non-empty "path"`);
+  }
+
+  if (entry.format !== undefined && (typeof entry.format !== 'string' || entry.format.trim().length === 0)) {
+    errors.push(`File at index ${index} must have a non-empty string "format"`);
+  }
+
+  if (entry.labels !== undefined) {
+    validateLabelMap(entry.labels, `File at index ${index} "labels"`, errors);
+  }
+};
+
+/**
+ * Validates a label map (label name -> string value)
+ * @param labels - Labels to validate
+ * @param fieldName - Field name for error messages
+ * @param errors - Errors array to populate
+ */
+export const validateLabelMap = (
+  labels: unknown,
+  fieldName: string,
+  errors: string[]
+): void => {
+  // Guard clause: not a map
+  if (!labels || typeof labels !== 'object' || Array.isArray(labels)) {
+    errors.push(`${fieldName} must map label names to values`);
+    return;
+  }
+
+  Object.entries(labels)
+    .filter(([, value]) => typeof value !== 'string' && typeof value !== 'number' && typeof value !== 'boolean')
+    .forEach(([name]) => errors.push(`${fieldName}.${name} must be a string`));
+};
+
+/**
+ * Validates the groups section (group name -> file entries)
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateGroupsSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no groups section
+  if (!config || config.groups === undefined) {
+    return;
+  }
+
+  // Guard clause: not an object
+  if (!config.groups || typeof config.groups !== 'object' || Array.isArray(config.groups)) {
+    errors.push('"groups" must be an object mapping group names to file lists');
+    return;
+  }
+
+  Object.entries(config.groups).forEach(([name, entries]) => {
+    // Guard clause: not a file list
+    if (!Array.isArray(entries) || entries.length === 0) {
+      errors.push(`Group "${name}" must be a non-empty array of files`);
+      return;
     }
+
+    entries.forEach((entry, index) => {
+      if (entry && typeof entry === 'object' && !Array.isArray(entry)) {
+        validateFileEntryObject(entry, index, errors);
+      } else if (!entry || typeof entry !== 'string' || entry.trim().length === 0) {
+        errors.push(`Group "${name}" file at index ${index} must be a non-empty string or an object with "path"`);
+      }
+    });
   });
 };
 
@@ -146,6 +328,17 @@ export const validateEnvironmentsSection = (
       errors.push(`Environment "${envName}" must have a non-empty file path`);
     }
   });
+
+  // Templated paths are expanded per service, so every environment needs the same placeholders
+  const placeholderSets = entries
+    .filter(([, filePath]) => typeof filePath === 'string')
+    .map(([envName, filePath]) => ({ envName, placeholders: pathPlaceholders(filePath).sort().join(', ') }));
+  const templated = placeholderSets.find(entry => entry.placeholders !== '');
+  if (templated) {
+    placeholderSets
+      .filter(entry => entry.placeholders !== templated.placeholders)
+      .forEach(entry => errors.push(`Environment "${entry.envName}" must use the same placeholders as "${templated.envName}": {${templated.placeholders.split(', ').join('}, {')}}`));
+  }
 };
 
 /**
@@ -177,73 +370,1068 @@ export const validateArraySections = (
     errors.push('"forbidden_keys" must be an array');
   }
 
+  // Validate parsers
+  if (config.parsers && !Array.isArray(config.parsers)) {
+    errors.push('"parsers" must be an array');
+  } else if (config.parsers) {
+    const supported = FileAdapterFactory.getSupportedFormats();
+    config.parsers
+      .filter(format => typeof format === 'string' && !supported.includes(canonicalFormat(format)))
+      .forEach(format => errors.push(`parsers: "${format}" must be one of: ${supported.join(', ')}`));
+  }
+
   // Validate array contents
   validateStringArray(config.ignore_keys, 'ignore_keys', errors);
   validateStringArray(config.required_keys, 'required_keys', errors);
-  validateStringArray(config.forbidden_keys, 'forbidden_keys', errors);
+  validateForbiddenKeys(config.forbidden_keys, errors);
+  validateStringArray(config.parsers, 'parsers', errors);
 };
 
 /**
- * Validates that an array contains only strings
- * @param array - Array to validate
- * @param fieldName - Name of the field for error messages
+ * Validates the entries of forbidden_keys: key patterns, or objects limiting patterns to some environments
+ * @param entries - forbidden_keys as written
  * @param errors - Errors array to populate
  */
-export const validateStringArray = (
-  array: any,
-  fieldName: string,
+const validateForbiddenKeys = (entries: any, errors: string[]): void => {
+  // Guard clause: not an array (reported by the caller)
+  if (!Array.isArray(entries)) {
+    return;
+  }
+
+  entries.forEach((entry, index) => {
+    // Guard clause: a plain key pattern
+    if (typeof entry === 'string') {
+      if (entry.trim().length === 0) {
+        errors.push(`forbidden_keys at index ${index} must be a non-empty string`);
+      }
+      return;
+    }
+
+    // Guard clause: neither a pattern nor an object
+    if (!entry || typeof entry !== 'object' || Array.isArray(entry)) {
+      errors.push(`forbidden_keys at index ${index} must be a key pattern or an object with "keys" and "environments"`);
+      return;
+    }
+
+    if (typeof entry.keys !== 'string') {
+      Array.isArray(entry.keys) && entry.keys.length > 0
+        ? validateStringArray(entry.keys, `forbidden_keys[${index}].keys`, errors)
+        : errors.push(`forbidden_keys[${index}].keys must be a key pattern or a non-empty array of key patterns`);
+    }
+
+    if (entry.environments !== undefined && typeof entry.environments !== 'string') {
+      Array.isArray(entry.environments)
+        ? validateStringArray(entry.environments, `forbidden_keys[${index}].environments`, errors)
+        : errors.push(`forbidden_keys[${index}].environments must be an environment name or an array of names`);
+    }
+  });
+};
+
+/**
+ * Validates the limits section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateLimitsSection = (
+  config: PraetorianConfig,
   errors: string[]
 ): void => {
-  // Guard clause: not an array
-  if (!Array.isArray(array)) {
+  // Guard clause: no limits section
+  if (!config || config.limits === undefined) {
     return;
   }
 
-  array.forEach((item, index) => {
-    if (!item || typeof item !== 'string' || item.trim().length === 0) {
-      errors.push(`${fieldName} at index ${index} must be a non-empty string`);
+  // Guard clause: not an object
+  if (typeof config.limits !== 'object' || config.limits === null || Array.isArray(config.limits)) {
+    errors.push('"limits" must be an object');
+    return;
+  }
+
+  (['max_depth', 'max_keys'] as const).forEach(field => {
+    const value = config.limits![field];
+    if (value !== undefined && (!Number.isInteger(value) || value < 1)) {
+      errors.push(`limits.${field} must be a positive integer`);
     }
   });
+
+  const streamAboveMb = config.limits.stream_above_mb;
+  if (streamAboveMb !== undefined && (typeof streamAboveMb !== 'number' || !(streamAboveMb > 0))) {
+    errors.push('limits.stream_above_mb must be a positive number');
+  }
 };
 
 /**
- * Validates object sections
+ * Validates the http section
  * @param config - Configuration to validate
  * @param errors - Errors array to populate
  */
-export const validateObjectSections = (
+export const validateHttpSection = (
   config: PraetorianConfig,
   errors: string[]
 ): void => {
-  // Guard clause: no config
-  if (!config) {
+  // Guard clause: no http section
+  if (!config || config.http === undefined) {
     return;
   }
 
-  // Validate schema
-  if (config.schema && typeof config.schema !== 'object') {
-    errors.push('"schema" must be an object');
+  // Guard clause: not an object
+  if (typeof config.http !== 'object' || config.http === null || Array.isArray(config.http)) {
+    errors.push('"http" must be an object');
+    return;
   }
 
-  // Validate patterns
-  if (config.patterns && typeof config.patterns !== 'object') {
-    errors.push('"patterns" must be an object');
+  const http = config.http;
+
+  if (http.timeout_ms !== undefined && (!Number.isInteger(http.timeout_ms) || http.timeout_ms < 1)) {
+    errors.push('http.timeout_ms must be a positive integer');
   }
+
+  (['retries', 'retry_backoff_ms'] as const).forEach(field => {
+    const value = http[field];
+    if (value !== undefined && (!Number.isInteger(value) || value < 0)) {
+      errors.push(`http.${field} must be a non-negative integer`);
+    }
+  });
+
+  (['proxy', 'no_proxy', 'ca_file'] as const).forEach(field => {
+    if (http[field] !== undefined && typeof http[field] !== 'string') {
+      errors.push(`http.${field} must be a string`);
+    }
+  });
 };
 
 /**
- * Checks if configuration has files to validate
- * @param config - Configuration to check
- * @returns True if has files, false otherwise
+ * Validates the escalate section (one rule object or a list of them)
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
  */
-export const hasFilesToValidate = (config: PraetorianConfig): boolean => {
-  // Guard clause: no config
-  if (!config) {
-    return false;
+export const validateEscalateSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no escalate section
+  if (!config || config.escalate === undefined) {
+    return;
   }
 
-  // Check files array
-  if (config.files && Array.isArray(config.files) && config.files.length > 0) {
+  const rules: any[] = Array.isArray(config.escalate) ? config.escalate : [config.escalate];
+
+  rules.forEach((rule, index) => {
+    // Guard clause: not an object
+    if (!rule || typeof rule !== 'object' || Array.isArray(rule)) {
+      errors.push(`escalate rule at index ${index} must be an object`);
+      return;
+    }
+
+    if (typeof rule.environment !== 'string' || rule.environment.trim().length === 0) {
+      errors.push(`escalate rule at index ${index} must have a non-empty "environment"`);
+    }
+
+    (['from', 'to'] as const).forEach(field => {
+      if (!isSeverity(rule[field])) {
+        errors.push(`escalate rule at index ${index} "${field}" must be one of: ${SEVERITIES.join(', ')}`);
+      }
+    });
+
+    if (rule.codes !== undefined && !Array.isArray(rule.codes)) {
+      errors.push(`escalate rule at index ${index} "codes" must be an array`);
+    }
+    validateStringArray(rule.codes, `escalate[${index}].codes`, errors);
+  });
+};
+
+/**
+ * Validates the comparison section (a strategy name or { strategy, reference })
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateComparisonSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no comparison section
+  if (!config || config.comparison === undefined) {
+    return;
+  }
+
+  const comparison: any = typeof config.comparison === 'string' ? { strategy: config.comparison } : config.comparison;
+
+  // Guard clause: not an object
+  if (!comparison || typeof comparison !== 'object' || Array.isArray(comparison)) {
+    errors.push('"comparison" must be a strategy name or an object');
+    return;
+  }
+
+  if (comparison.strategy !== undefined && !isComparisonStrategy(comparison.strategy)) {
+    errors.push(`comparison.strategy must be one of: ${COMPARISON_STRATEGIES.join(', ')}`);
+  }
+
+  if (comparison.reference !== undefined && (typeof comparison.reference !== 'string' || comparison.reference.trim().length === 0)) {
+    errors.push('comparison.reference must be a non-empty file path');
+  }
+};
+
+/**
+ * Validates the rule scopes section (a single scope or a list of them)
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateScopesSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no scopes section
+  if (!config || config.scopes === undefined) {
+    return;
+  }
+
+  const scopes: any[] = Array.isArray(config.scopes) ? config.scopes : [config.scopes];
+
+  scopes.forEach((scope, index) => {
+    // Guard clause: not an object
+    if (!scope || typeof scope !== 'object' || Array.isArray(scope)) {
+      errors.push(`scopes[${index}] must be an object with "rules"`);
+      return;
+    }
+
+    if (!Array.isArray(scope.rules) || scope.rules.length === 0) {
+      errors.push(`scopes[${index}].rules must be a non-empty array of rule ids or auditor names`);
+    } else {
+      validateStringArray(scope.rules, `scopes[${index}].rules`, errors);
+    }
+
+    ['files', 'groups', 'exclude']
+      .filter(field => scope[field] !== undefined)
+      .forEach(field => Array.isArray(scope[field])
+        ? validateStringArray(scope[field], `scopes[${index}].${field}`, errors)
+        : errors.push(`scopes[${index}].${field} must be an array`));
+
+    if (scope.labels !== undefined) {
+      validateLabelMap(scope.labels, `scopes[${index}].labels`, errors);
+    }
+
+    if (scope.enabled !== undefined && typeof scope.enabled !== 'boolean') {
+      errors.push(`scopes[${index}].enabled must be true or false`);
+    }
+  });
+};
+
+/**
+ * Validates the redaction section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateRedactionSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no redaction section
+  if (!config || config.redaction === undefined) {
+    return;
+  }
+
+  const redaction: any = typeof config.redaction === 'string' ? { policy: config.redaction } : config.redaction;
+
+  // Guard clause: not an object
+  if (!redaction || typeof redaction !== 'object' || Array.isArray(redaction)) {
+    errors.push('"redaction" must be a policy name or an object');
+    return;
+  }
+
+  if (redaction.policy !== undefined && !isRedactionPolicy(redaction.policy)) {
+    errors.push(`redaction.policy must be one of: ${REDACTION_POLICIES.join(', ')}`);
+  }
+
+  if (redaction.secret_keys !== undefined && !Array.isArray(redaction.secret_keys)) {
+    errors.push('redaction.secret_keys must be an array of key patterns');
+  } else if (redaction.secret_keys !== undefined) {
+    validateStringArray(redaction.secret_keys, 'redaction.secret_keys', errors);
+  }
+};
+
+/**
+ * Validates the canaries section (a single canary or a list of them)
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateCanariesSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no canaries section
+  if (!config || config.canaries === undefined) {
+    return;
+  }
+
+  const canaries: any[] = Array.isArray(config.canaries) ? config.canaries : [config.canaries];
+
+  canaries.forEach((canary, index) => {
+    // Guard clause: not an object
+    if (!canary || typeof canary !== 'object' || Array.isArray(canary)) {
+      errors.push(`canaries[${index}] must be an object with "key" and "value" or "sha256"`);
+      return;
+    }
+
+    if (typeof canary.key !== 'string' || canary.key.trim().length === 0) {
+      errors.push(`canaries[${index}].key must be a non-empty key path`);
+    }
+
+    if ((canary.value === undefined) === (canary.sha256 === undefined)) {
+      errors.push(`canaries[${index}] must set exactly one of "value" or "sha256"`);
+    }
+
+    if (canary.value !== undefined && !['string', 'number', 'boolean'].includes(typeof canary.value)) {
+      errors.push(`canaries[${index}].value must be a string, number or boolean`);
+    }
+
+    if (canary.sha256 !== undefined && !(typeof canary.sha256 === 'string' && /^[0-9a-f]{64}$/i.test(canary.sha256))) {
+      errors.push(`canaries[${index}].sha256 must be a hex SHA-256 digest`);
+    }
+
+    if (canary.files !== undefined && !Array.isArray(canary.files)) {
+      errors.push(`canaries[${index}].files must be an array`);
+    } else if (canary.files !== undefined) {
+      validateStringArray(canary.files, `canaries[${index}].files`, errors);
+    }
+  });
+};
+
+/**
+ * Validates the environment leakage section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateLeakageSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no leakage section or a plain switch
+  if (!config || config.leakage === undefined || typeof config.leakage === 'boolean') {
+    return;
+  }
+
+  const leakage: any = config.leakage;
+
+  // Guard clause: not an object
+  if (!leakage || typeof leakage !== 'object' || Array.isArray(leakage)) {
+    errors.push('"leakage" must be true, false or an object with "tokens"');
+    return;
+  }
+
+  if (leakage.enabled !== undefined && typeof leakage.enabled !== 'boolean') {
+    errors.push('leakage.enabled must be true or false');
+  }
+
+  // Guard clause: default tokens
+  if (leakage.tokens === undefined) {
+    return;
+  }
+
+  if (!leakage.tokens || typeof leakage.tokens !== 'object' || Array.isArray(leakage.tokens)) {
+    errors.push('leakage.tokens must map environment names to token lists');
+    return;
+  }
+
+  Object.entries(leakage.tokens).forEach(([environment, tokens]) => Array.isArray(tokens)
+    ? validateStringArray(tokens, `leakage.tokens.${environment}`, errors)
+    : errors.push(`leakage.tokens.${environment} must be an array of tokens`));
+};
+
+/**
+ * Validates the feature flags section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateFeatureFlagsSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no feature flags section
+  if (!config || config.feature_flags === undefined) {
+    return;
+  }
+
+  // Guard clause: not an object
+  if (typeof config.feature_flags !== 'object' || config.feature_flags === null || Array.isArray(config.feature_flags)) {
+    errors.push('"feature_flags" must be an object with "rollout_threshold"');
+    return;
+  }
+
+  const threshold = config.feature_flags.rollout_threshold;
+
+  if (threshold !== undefined && (typeof threshold !== 'number' || threshold < 0 || threshold > 100)) {
+    errors.push('feature_flags.rollout_threshold must be a percentage between 0 and 100');
+  }
+};
+
+/**
+ * Validates the cloud identifiers section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateCloudIdentifiersSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no cloud identifiers section
+  if (!config || config.cloud_identifiers === undefined) {
+    return;
+  }
+
+  const environments: any = config.cloud_identifiers;
+
+  // Guard clause: not a mapping
+  if (!environments || typeof environments !== 'object' || Array.isArray(environments)) {
+    errors.push('"cloud_identifiers" must map environment names to allowed regions, accounts, projects and subscriptions');
+    return;
+  }
+
+  Object.entries(environments).forEach(([environment, allowList]: [string, any]) => {
+    if (!allowList || typeof allowList !== 'object' || Array.isArray(allowList)) {
+      errors.push(`cloud_identifiers.${environment} must be an object`);
+      return;
+    }
+
+    ['regions', 'accounts', 'projects', 'subscriptions']
+      .filter(kind => allowList[kind] !== undefined)
+      .forEach(kind => Array.isArray(allowList[kind])
+        // Account ids are often written as numbers
+        ? allowList[kind].forEach((value: unknown, index: number) => {
+          if (!((typeof value === 'string' && value.trim() !== '') || typeof value === 'number')) {
+            errors.push(`cloud_identifiers.${environment}.${kind} at index ${index} must be a non-empty string`);
+          }
+        })
+        : errors.push(`cloud_identifiers.${environment}.${kind} must be an array`));
+  });
+};
+
+/**
+ * Checks that a value is a string that compiles as a regular expression
+ */
+const isValidPattern = (value: unknown): boolean => {
+  // Guard clause: not a string
+  if (typeof value !== 'string') {
+    return false;
+  }
+
+  try {
+    new RegExp(value);
+    return true;
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Validates the message brokers section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateBrokersSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no brokers section
+  if (!config || config.brokers === undefined) {
+    return;
+  }
+
+  const brokers: any = config.brokers;
+
+  // Guard clause: not an object
+  if (!brokers || typeof brokers !== 'object' || Array.isArray(brokers)) {
+    errors.push('"brokers" must be an object with "consumer_group_pattern" and/or "min_replication_factor"');
+    return;
+  }
+
+  if (brokers.consumer_group_pattern !== undefined && !isValidPattern(brokers.consumer_group_pattern)) {
+    errors.push('brokers.consumer_group_pattern must be a valid regular expression');
+  }
+
+  const minimum = brokers.min_replication_factor;
+
+  if (minimum !== undefined && !(Number.isInteger(minimum) && minimum >= 1)) {
+    errors.push('brokers.min_replication_factor must be a whole number of at least 1');
+  }
+};
+
+/**
+ * Validates the rate limits section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateRateLimitsSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no rate limits section
+  if (!config || config.rate_limits === undefined) {
+    return;
+  }
+
+  const rateLimits: any = config.rate_limits;
+
+  // Guard clause: not an object
+  if (!rateLimits || typeof rateLimits !== 'object' || Array.isArray(rateLimits)) {
+    errors.push('"rate_limits" must be an object with "endpoints" and/or "quotas"');
+    return;
+  }
+
+  (['endpoints', 'quotas'] as const).forEach(field => {
+    const patterns = rateLimits[field];
+    if (patterns !== undefined && typeof patterns !== 'string') {
+      Array.isArray(patterns)
+        ? validateStringArray(patterns, `rate_limits.${field}`, errors)
+        : errors.push(`rate_limits.${field} must be a key path or an array of key paths`);
+    }
+  });
+
+  (['public', 'limit', 'burst'] as const)
+    .filter(field => rateLimits[field] !== undefined && (typeof rateLimits[field] !== 'string' || rateLimits[field] === ''))
+    .forEach(field => errors.push(`rate_limits.${field} must be a key path`));
+
+  const factor = rateLimits.burst_factor;
+
+  if (factor !== undefined && (typeof factor !== 'number' || factor < 1)) {
+    errors.push('rate_limits.burst_factor must be a number of at least 1');
+  }
+};
+
+/**
+ * Validates the cron section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateCronSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no cron section
+  if (!config || config.cron === undefined) {
+    return;
+  }
+
+  const cron: any = config.cron;
+
+  // Guard clause: not an object
+  if (!cron || typeof cron !== 'object' || Array.isArray(cron)) {
+    errors.push('"cron" must be an object with "keys" and/or "min_interval"');
+    return;
+  }
+
+  if (cron.keys !== undefined && typeof cron.keys !== 'string') {
+    Array.isArray(cron.keys)
+      ? validateStringArray(cron.keys, 'cron.keys', errors)
+      : errors.push('cron.keys must be a key path or an array of key paths');
+  }
+
+  const interval = cron.min_interval;
+
+  if (interval !== undefined && !(typeof interval === 'string' && (parseDurationString(interval) ?? 0) > 0)) {
+    errors.push('cron.min_interval must be a duration such as "5m" or "1h"');
+  }
+};
+
+// A number, or a number with a unit (`64Gi`, `500m`, `16GB`)
+const BUDGET_AMOUNT = /^\d+(\.\d+)?\s*[A-Za-z]*$/;
+
+/**
+ * Validates the budgets section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateBudgetsSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no budgets section
+  if (!config || config.budgets === undefined) {
+    return;
+  }
+
+  const budgets: any[] = Array.isArray(config.budgets) ? config.budgets : [config.budgets];
+
+  budgets.forEach((budget, index) => {
+    // Guard clause: not an object
+    if (!budget || typeof budget !== 'object' || Array.isArray(budget)) {
+      errors.push(`budgets[${index}] must be an object with "name", "keys" and "max_total" or "max_value"`);
+      return;
+    }
+
+    if (typeof budget.name !== 'string' || budget.name.trim().length === 0) {
+      errors.push(`budgets[${index}].name must be a non-empty string`);
+    }
+
+    if (typeof budget.keys !== 'string') {
+      Array.isArray(budget.keys) && budget.keys.length > 0
+        ? validateStringArray(budget.keys, `budgets[${index}].keys`, errors)
+        : errors.push(`budgets[${index}].keys must be a key path or a non-empty array of key paths`);
+    }
+
+    if (budget.max_total === undefined && budget.max_value === undefined) {
+      errors.push(`budgets[${index}] must set "max_total", "max_value" or both`);
+    }
+
+    ['max_total', 'max_value']
+      .filter(field => budget[field] !== undefined)
+      .filter(field => !(typeof budget[field] === 'number' ? budget[field] >= 0 : typeof budget[field] === 'string' && BUDGET_AMOUNT.test(budget[field].trim())))
+      .forEach(field => errors.push(`budgets[${index}].${field} must be a number or a quantity such as "64Gi"`));
+
+    if (budget.environment !== undefined && typeof budget.environment !== 'string') {
+      errors.push(`budgets[${index}].environment must be a string`);
+    }
+  });
+};
+
+const PERFORMANCE_SECTIONS = ['timeouts', 'pools', 'retries', 'caches'];
+
+/**
+ * Validates the performance section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validatePerformanceSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no performance section
+  if (!config || config.performance === undefined) {
+    return;
+  }
+
+  const performance: any = config.performance;
+
+  // Guard clause: not an object
+  if (!performance || typeof performance !== 'object' || Array.isArray(performance)) {
+    errors.push(`"performance" must be an object with any of ${PERFORMANCE_SECTIONS.map(section => `"${section}"`).join(', ')}`);
+    return;
+  }
+
+  PERFORMANCE_SECTIONS
+    .filter(section => performance[section] !== undefined && performance[section] !== null)
+    .forEach(section => {
+      const category = performance[section];
+
+      // Guard clause: not an object
+      if (typeof category !== 'object' || Array.isArray(category)) {
+        errors.push(`performance.${section} must be an object with "keys", "min" and/or "max"`);
+        return;
+      }
+
+      if (category.keys !== undefined && typeof category.keys !== 'string') {
+        Array.isArray(category.keys)
+          ? validateStringArray(category.keys, `performance.${section}.keys`, errors)
+          : errors.push(`performance.${section}.keys must be a key path or an array of key paths`);
+      }
+
+      ['min', 'max']
+        .filter(field => category[field] !== undefined)
+        .filter(field => !(typeof category[field] === 'number' ? category[field] >= 0 : typeof category[field] === 'string' && BUDGET_AMOUNT.test(category[field].trim())))
+        .forEach(field => errors.push(`performance.${section}.${field} must be a number or a quantity such as "30s"`));
+
+      if (section === 'timeouts' && category.required !== undefined && typeof category.required !== 'boolean') {
+        errors.push('performance.timeouts.required must be a boolean');
+      }
+    });
+};
+
+/**
+ * Validates the value differences section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateValueDifferencesSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no value_differences section
+  if (!config || config.value_differences === undefined) {
+    return;
+  }
+
+  const rules: any[] = Array.isArray(config.value_differences) ? config.value_differences : [config.value_differences];
+
+  rules.forEach((rule, index) => {
+    // Guard clause: not an object
+    if (!rule || typeof rule !== 'object' || Array.isArray(rule)) {
+      errors.push(`value_differences[${index}] must be an object with "keys" and optionally "severity"`);
+      return;
+    }
+
+    if (typeof rule.keys !== 'string') {
+      Array.isArray(rule.keys) && rule.keys.length > 0
+        ? validateStringArray(rule.keys, `value_differences[${index}].keys`, errors)
+        : errors.push(`value_differences[${index}].keys must be a key pattern or a non-empty array of key patterns`);
+    }
+
+    if (rule.severity !== undefined && !isSeverity(rule.severity)) {
+      errors.push(`value_differences[${index}].severity must be one of: ${SEVERITIES.join(', ')}`);
+    }
+  });
+};
+
+// Fields of a `rules:` entry
+const VALUE_CONSTRAINT_FIELDS = ['type', 'regex', 'enum', 'min', 'max', 'severity'];
+
+/**
+ * Validates the value constraints of the rules section; a list of rules belongs to the rule system
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateValueRulesSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no rules mapping
+  if (!config || !config.rules || typeof config.rules !== 'object' || Array.isArray(config.rules)) {
+    return;
+  }
+
+  Object.entries(config.rules).forEach(([pattern, constraint]: [string, any]) => {
+    // Guard clause: not an object
+    if (!constraint || typeof constraint !== 'object' || Array.isArray(constraint)) {
+      errors.push(`rules.${pattern} must be an object with any of ${VALUE_CONSTRAINT_FIELDS.map(field => `"${field}"`).join(', ')}`);
+      return;
+    }
+
+    Object.keys(constraint)
+      .filter(field => !VALUE_CONSTRAINT_FIELDS.includes(field))
+      .forEach(field => errors.push(`rules.${pattern}.${field} is not a constraint (expected one of: ${VALUE_CONSTRAINT_FIELDS.join(', ')})`));
+
+    if (constraint.type !== undefined && !isValueType(constraint.type)) {
+      errors.push(`rules.${pattern}.type must be one of: ${VALUE_TYPE_NAMES.join(', ')}`);
+    }
+
+    if (constraint.regex !== undefined && !isValidPattern(constraint.regex)) {
+      errors.push(`rules.${pattern}.regex must be a valid regular expression`);
+    }
+
+    if (constraint.enum !== undefined && (!Array.isArray(constraint.enum) || constraint.enum.length === 0)) {
+      errors.push(`rules.${pattern}.enum must be a non-empty array of allowed values`);
+    }
+
+    ['min', 'max']
+      .filter(field => constraint[field] !== undefined)
+      .filter(field => !(typeof constraint[field] === 'number' || (typeof constraint[field] === 'string' && BUDGET_AMOUNT.test(constraint[field].trim()))))
+      .forEach(field => errors.push(`rules.${pattern}.${field} must be a number or a quantity such as "30s"`));
+
+    if (constraint.severity !== undefined && !isSeverity(constraint.severity)) {
+      errors.push(`rules.${pattern}.severity must be one of: ${SEVERITIES.join(', ')}`);
+    }
+  });
+};
+
+/**
+ * Validates the commented-out configuration section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateCommentedConfigSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no commented_config section
+  if (!config || config.commented_config === undefined) {
+    return;
+  }
+
+  const commented: any = config.commented_config;
+
+  // Guard clause: not an object
+  if (!commented || typeof commented !== 'object' || Array.isArray(commented)) {
+    errors.push('"commented_config" must be an object with "min_lines" and/or "min_assignments"');
+    return;
+  }
+
+  ['min_lines', 'min_assignments']
+    .filter(field => commented[field] !== undefined && !(Number.isInteger(commented[field]) && commented[field] >= 1))
+    .forEach(field => errors.push(`commented_config.${field} must be a positive integer`));
+};
+
+/**
+ * Validates the formatting lint section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateFormatLintSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no format_lint section
+  if (!config || config.format_lint === undefined) {
+    return;
+  }
+
+  const lint: any = config.format_lint;
+
+  // Guard clause: not an object
+  if (!lint || typeof lint !== 'object' || Array.isArray(lint)) {
+    errors.push('"format_lint" must be an object with "line_endings" and/or "tab_width"');
+    return;
+  }
+
+  if (lint.line_endings !== undefined && !['lf', 'crlf'].includes(lint.line_endings)) {
+    errors.push('format_lint.line_endings must be "lf" or "crlf"');
+  }
+
+  if (lint.tab_width !== undefined && !(Number.isInteger(lint.tab_width) && lint.tab_width >= 1 && lint.tab_width <= 8)) {
+    errors.push('format_lint.tab_width must be an integer from 1 to 8');
+  }
+};
+
+/**
+ * Validates the key order section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateKeyOrderSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no key_order section
+  if (!config || config.key_order === undefined) {
+    return;
+  }
+
+  const keyOrder: any = config.key_order;
+
+  // Guard clause: not an object
+  if (!keyOrder || typeof keyOrder !== 'object' || Array.isArray(keyOrder)) {
+    errors.push('"key_order" must be an object with "order" and/or "files"');
+    return;
+  }
+
+  if (keyOrder.order !== undefined && keyOrder.order !== 'alphabetical') {
+    Array.isArray(keyOrder.order)
+      ? validateStringArray(keyOrder.order, 'key_order.order', errors)
+      : errors.push('key_order.order must be "alphabetical" or an array of keys');
+  }
+
+  if (keyOrder.files !== undefined) {
+    Array.isArray(keyOrder.files)
+      ? validateStringArray(keyOrder.files, 'key_order.files', errors)
+      : errors.push('key_order.files must be an array of globs');
+  }
+};
+
+/**
+ * Validates the owners section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateOwnersSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no owners section
+  if (!config || config.owners === undefined) {
+    return;
+  }
+
+  // Guard clause: not a list
+  if (!Array.isArray(config.owners)) {
+    errors.push('"owners" must be an array of entries with "team" and "keys" and/or "files"');
+    return;
+  }
+
+  config.owners.forEach((owner: any, index) => {
+    // Guard clause: not an object
+    if (!owner || typeof owner !== 'object' || Array.isArray(owner)) {
+      errors.push(`owners[${index}] must be an object with "team" and "keys" and/or "files"`);
+      return;
+    }
+
+    if (typeof owner.team !== 'string' || owner.team.trim().length === 0) {
+      errors.push(`owners[${index}].team must be a non-empty string`);
+    }
+
+    if (owner.keys === undefined && owner.files === undefined) {
+      errors.push(`owners[${index}] must set "keys", "files" or both`);
+    }
+
+    ['keys', 'files']
+      .filter(field => owner[field] !== undefined && typeof owner[field] !== 'string')
+      .forEach(field => Array.isArray(owner[field])
+        ? validateStringArray(owner[field], `owners[${index}].${field}`, errors)
+        : errors.push(`owners[${index}].${field} must be a string or an array of strings`));
+
+    if (owner.channel !== undefined && typeof owner.channel !== 'string') {
+      errors.push(`owners[${index}].channel must be a string`);
+    }
+  });
+};
+
+/**
+ * Validates the labels section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateLabelsSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no labels section
+  if (!config || config.labels === undefined) {
+    return;
+  }
+
+  // Guard clause: not a list
+  if (!Array.isArray(config.labels)) {
+    errors.push('"labels" must be an array of entries with "labels" and "files" and/or "groups"');
+    return;
+  }
+
+  config.labels.forEach((entry: any, index) => {
+    // Guard clause: not an object
+    if (!entry || typeof entry !== 'object' || Array.isArray(entry)) {
+      errors.push(`labels[${index}] must be an object with "labels" and "files" and/or "groups"`);
+      return;
+    }
+
+    if (entry.files === undefined && entry.groups === undefined) {
+      errors.push(`labels[${index}] must set "files", "groups" or both`);
+    }
+
+    ['files', 'groups']
+      .filter(field => entry[field] !== undefined && typeof entry[field] !== 'string')
+      .forEach(field => Array.isArray(entry[field])
+        ? validateStringArray(entry[field], `labels[${index}].${field}`, errors)
+        : errors.push(`labels[${index}].${field} must be a string or an array of strings`));
+
+    validateLabelMap(entry.labels, `labels[${index}].labels`, errors);
+  });
+};
+
+/**
+ * Validates the hooks section
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateHooksSection = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no hooks section
+  if (!config || config.hooks === undefined) {
+    return;
+  }
+
+  // Guard clause: not an object
+  if (typeof config.hooks !== 'object' || config.hooks === null || Array.isArray(config.hooks)) {
+    errors.push('"hooks" must be an object with "pre" and/or "post" commands');
+    return;
+  }
+
+  const hooks = config.hooks;
+
+  (['pre', 'post'] as const)
+    .filter(stage => hooks[stage] !== undefined)
+    .forEach(stage => {
+      const commands = hooks[stage];
+      if (typeof commands === 'string') {
+        validateStringArray([commands], `hooks.${stage}`, errors);
+      } else if (Array.isArray(commands)) {
+        validateStringArray(commands, `hooks.${stage}`, errors);
+      } else {
+        errors.push(`hooks.${stage} must be a command or a list of commands`);
+      }
+    });
+
+  if (hooks.timeout_ms !== undefined && (!Number.isInteger(hooks.timeout_ms) || hooks.timeout_ms < 1)) {
+    errors.push('hooks.timeout_ms must be a positive integer');
+  }
+  (['cpu_seconds', 'memory_mb'] as const)
+    .filter(limit => {
+      const value: unknown = hooks[limit];
+      return value !== undefined && (typeof value !== 'number' || !(value > 0));
+    })
+    .forEach(limit => errors.push(`hooks.${limit} must be a positive number`));
+};
+
+/**
+ * Validates that an array contains only strings
+ * @param array - Array to validate
+ * @param fieldName - Name of the field for error messages
+ * @param errors - Errors array to populate
+ */
+export const validateStringArray = (
+  array: any,
+  fieldName: string,
+  errors: string[]
+): void => {
+  // Guard clause: not an array
+  if (!Array.isArray(array)) {
+    return;
+  }
+
+  array.forEach((item, index) => {
+    if (!item || typeof item !== 'string' || item.trim().length === 0) {
+      errors.push(`${fieldName} at index ${index} must be a non-empty string`);
+    }
+  });
+};
+
+/**
+ * Validates object sections
+ * @param config - Configuration to validate
+ * @param errors - Errors array to populate
+ */
+export const validateObjectSections = (
+  config: PraetorianConfig,
+  errors: string[]
+): void => {
+  // Guard clause: no config
+  if (!config) {
+    return;
+  }
+
+  // Validate schema
+  if (config.schema && (typeof config.schema !== 'object' || Array.isArray(config.schema))) {
+    errors.push('"schema" must be an object mapping keys to value types');
+  } else if (config.schema) {
+    Object.entries(config.schema)
+      .filter(([, type]) => !isValueType(type))
+      .forEach(([key]) => errors.push(`schema.${key} must be one of: ${VALUE_TYPE_NAMES.join(', ')}`));
+  }
+
+  // Validate patterns
+  if (config.patterns && typeof config.patterns !== 'object') {
+    errors.push('"patterns" must be an object');
+  }
+
+  // Validate format overrides
+  if (config.formats && (typeof config.formats !== 'object' || Array.isArray(config.formats))) {
+    errors.push('"formats" must be an object mapping file patterns to formats');
+  }
+
+  // Validate message templates
+  if (config.messages && (typeof config.messages !== 'object' || Array.isArray(config.messages))) {
+    errors.push('"messages" must be an object mapping finding codes to templates');
+  } else if (config.messages) {
+    Object.entries(config.messages)
+      .filter(([, template]) => typeof template !== 'string')
+      .forEach(([code]) => errors.push(`messages.${code} must be a string template`));
+  }
+};
+
+/**
+ * Checks if configuration has files to validate
+ * @param config - Configuration to check
+ * @returns True if has files, false otherwise
+ */
+export const hasFilesToValidate = (config: PraetorianConfig): boolean => {
+  // Guard clause: no config
+  if (!config) {
+    return false;
+  }
+
+  // Check files array
+  if (config.files && Array.isArray(config.files) && config.files.length > 0) {
+    return true;
+  }
+
+  // Check groups
+  if (config.groups && typeof config.groups === 'object' && Object.keys(config.groups).length > 0) {
     return true;
   }
 
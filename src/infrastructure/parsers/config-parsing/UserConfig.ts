@@ -0,0 +1,114 @@
+/**
+ * @file src/infrastructure/parsers/config-parsing/UserConfig.ts
+ * @description The user-level configuration in the XDG config directory: defaults (output format,
+ * colors, concurrency, telemetry choice, rule packs) merged under the praetorian.yaml of each project
+ */
+
+import * as os from 'os';
+import * as path from 'path';
+import { ConfigLayer, PraetorianConfig, UserConfig } from '../../../shared/types';
+import { ConfigError } from '../../../shared/utils/ExitCodes';
+import { fileExists, parseYamlContent, readFileSync } from './ConfigFileOperations';
+
+/**
+ * @constant USER_CONFIG_FIELDS
+ * @description Fields the user configuration may set
+ */
+export const USER_CONFIG_FIELDS: Array<keyof UserConfig> = ['output', 'color', 'concurrency', 'telemetry', 'ruleSets'];
+
+/**
+ * Locates the praetorian directory of the user configuration, following the XDG base directory spec
+ * @param env - Environment; `XDG_CONFIG_HOME` replaces `~/.config`
+ */
+export const userConfigDir = (env: Record<string, string | undefined> = process.env): string =>
+  path.join(env.XDG_CONFIG_HOME || path.join(os.homedir(), '.config'), 'praetorian');
+
+/**
+ * Locates the user configuration file
+ */
+export const userConfigPath = (env: Record<string, string | undefined> = process.env): string =>
+  path.join(userConfigDir(env), 'config.yaml');
+
+/**
+ * Validates a parsed user configuration
+ * @param config - Parsed content of the file
+ * @returns One message per invalid or unknown field
+ */
+export const validateUserConfig = (config: any): string[] => {
+  // Guard clause: not a mapping
+  if (!config || typeof config !== 'object' || Array.isArray(config)) {
+    return ['the user configuration must be a mapping of fields'];
+  }
+
+  const unknown = Object.keys(config)
+    .filter(field => !(USER_CONFIG_FIELDS as string[]).includes(field))
+    .map(field => `"${field}" is not a user configuration field (expected one of: ${USER_CONFIG_FIELDS.join(', ')})`);
+
+  return [
+    ...unknown,
+    ...(config.output !== undefined && (typeof config.output !== 'string' || config.output.trim().length === 0)
+      ? ['"output" must be a format name'] : []),
+    ...(config.color !== undefined && typeof config.color !== 'boolean' ? ['"color" must be a boolean'] : []),
+    ...(config.concurrency !== undefined && !(Number.isInteger(config.concurrency) && config.concurrency > 0)
+      ? ['"concurrency" must be a positive integer'] : []),
+    ...(config.telemetry !== undefined && typeof config.telemetry !== 'boolean' ? ['"telemetry" must be a boolean'] : []),
+    ...(config.ruleSets !== undefined && !(Array.isArray(config.ruleSets) && config.ruleSets.every((ruleSet: unknown) => typeof ruleSet === 'string'))
+      ? ['"ruleSets" must be an array of rule pack names or paths'] : []),
+  ];
+};
+
+/**
+ * Reads the user configuration
+ * @param filePath - File to read
+ * @returns Its fields; none when the file does not exist or is empty
+ * @throws ConfigError when the file cannot be read, parsed or validated
+ */
+export const loadUserConfig = (filePath: string = userConfigPath()): UserConfig => {
+  // Guard clause: no user configuration
+  if (!fileExists(filePath)) {
+    return {};
+  }
+
+  const readResult = readFileSync(filePath);
+
+  // Guard clause: unreadable file
+  if (!readResult.success) {
+    throw new ConfigError(`Failed to read user configuration ${filePath}: ${readResult.error ?? 'Unknown error'}`);
+  }
+
+  let config: any;
+  try {
+    config = parseYamlContent(readResult.content ?? '') ?? {};
+  } catch (error) {
+    throw new ConfigError(`Failed to parse user configuration ${filePath}: ${error instanceof Error ? error.message : 'Unknown error'}`);
+  }
+
+  const errors = validateUserConfig(config);
+  if (errors.length > 0) {
+    throw new ConfigError(`Invalid user configuration ${filePath}: ${errors.join(', ')}`);
+  }
+
+  return config as UserConfig;
+};
+
+/**
+ * Merges the user configuration under a project configuration: a field set by the project wins
+ * @param user - User configuration
+ * @param project - praetorian.yaml of the project
+ * @returns The effective configuration
+ */
+export const mergeUserConfig = (user: UserConfig, project: PraetorianConfig): PraetorianConfig & UserConfig => ({
+  ...user,
+  ...project,
+});
+
+/**
+ * Finds the layer each effective setting comes from: a later layer wins, as in mergeUserConfig
+ * @param layers - Merged layers, lowest first
+ * @returns Top-level field -> source of its layer
+ */
+export const settingOrigins = (layers: ConfigLayer[]): Record<string, string> =>
+  layers.reduce<Record<string, string>>((origins, layer) => ({
+    ...origins,
+    ...Object.fromEntries(Object.keys(layer.config).map(field => [field, layer.source])),
+  }), {});
@@ -0,0 +1,31 @@
+import { ValidationResult, ValidationContext } from '../../shared/types';
+import { CLOUD_IDENTITY_RULE_ID, checkCloudIdentities } from '../../application/validation/CloudIdentityChecks';
+
+export class CloudIdentityAuditor {
+  /**
+   * Run the cloud region and account audit across the files of every environment.
+   * Files take `context.environment` when given, otherwise the environment named in the file name.
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const targets = Object.entries(context.files ?? {}).map(([path, content]) => ({
+      path,
+      content,
+      environment: context.environment,
+    }));
+    const findings = checkCloudIdentities(targets, context.cloudIdentifiers);
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: findings.filter(finding => finding.severity !== 'error'),
+      metadata: {
+        auditType: CLOUD_IDENTITY_RULE_ID,
+        rulesChecked: targets.length,
+        rulesPassed: targets.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+}
@@ -0,0 +1,47 @@
+import * as fs from 'fs';
+import { ConfigComment, ValidationResult, ValidationContext } from '../../shared/types';
+import { COMMENTED_CONFIG_RULE_ID, checkCommentedConfig } from '../../application/validation/CommentedConfigChecks';
+import { FileReaderService } from '../adapters/FileReaderService';
+
+export class CommentedConfigAuditor {
+  constructor(private readonly reader: FileReaderService = new FileReaderService()) {}
+
+  /**
+   * Run the commented-out configuration audit. Comments come from `context.comments` when given,
+   * otherwise they are read from the files on disk; files that cannot be read have none.
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const targets = await Promise.all(Object.keys(context.files ?? {}).map(async path => ({
+      path,
+      comments: context.comments?.[path] ?? await this.readComments(path),
+    })));
+    const findings = checkCommentedConfig(targets, context.commentedConfig);
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: findings.filter(finding => finding.severity !== 'error'),
+      metadata: {
+        auditType: COMMENTED_CONFIG_RULE_ID,
+        rulesChecked: targets.length,
+        rulesPassed: targets.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+
+  private async readComments(path: string): Promise<ConfigComment[]> {
+    // Guard clause: in-memory file
+    if (!fs.existsSync(path)) {
+      return [];
+    }
+
+    try {
+      return await this.reader.readComments(path);
+    } catch {
+      return [];
+    }
+  }
+}
@@ -0,0 +1,31 @@
+import { ConfigFile, ValidationResult, ValidationContext } from '../../shared/types';
+import { findForbiddenKeys, FORBIDDEN_KEY_RULE_ID } from '../../application/validation/ForbiddenKeys';
+
+export class ForbiddenKeysAuditor {
+  /**
+   * Run the forbidden keys audit: files take `context.environment`, so rules limited to other
+   * environments are skipped
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const files: ConfigFile[] = Object.entries(context.files ?? {}).map(([path, content]) => ({
+      path,
+      content,
+      format: 'yaml',
+      ...(context.environment !== undefined ? { environment: context.environment } : {}),
+    }));
+    const errors = files.flatMap(file => findForbiddenKeys(file, context.forbiddenKeys ?? []));
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: [],
+      metadata: {
+        auditType: FORBIDDEN_KEY_RULE_ID,
+        rulesChecked: files.length,
+        rulesPassed: files.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+}
@@ -0,0 +1,49 @@
+import * as fs from 'fs';
+import { ValidationResult, ValidationContext } from '../../shared/types';
+import { FORMAT_LINT_RULE_ID, FormatLintTarget, checkFormat } from '../../application/validation/FormatLintChecks';
+import { FileReaderService } from '../adapters/FileReaderService';
+
+export class FormatLintAuditor {
+  constructor(private readonly reader: FileReaderService = new FileReaderService()) {}
+
+  /**
+   * Run the formatting audit on the raw text of the files; files that are not on disk are skipped
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const targets = await this.readTargets(Object.keys(context.files ?? {}));
+    const findings = checkFormat(targets, context.formatLint);
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: findings.filter(finding => finding.severity !== 'error'),
+      metadata: {
+        auditType: FORMAT_LINT_RULE_ID,
+        rulesChecked: targets.length,
+        rulesPassed: targets.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+
+  /**
+   * Read the raw text and format of the files on disk; in-memory files are skipped
+   */
+  async readTargets(paths: string[]): Promise<FormatLintTarget[]> {
+    return (await Promise.all(paths.map(path => this.readTarget(path))))
+      .flatMap(target => (target ? [target] : []));
+  }
+
+  private async readTarget(path: string): Promise<FormatLintTarget | undefined> {
+    // Guard clause: in-memory file
+    if (!fs.existsSync(path)) {
+      return undefined;
+    }
+
+    // Files of no known format still get the format-independent checks
+    const format = await this.reader.detectFormat(path).catch(() => 'text');
+    return { path, format, text: await fs.promises.readFile(path, 'utf8') };
+  }
+}
@@ -1,30 +1,25 @@
-import { ValidationResult, ValidationContext } from '../../shared/types';
+import { ConfigFile, ValidationResult, ValidationContext } from '../../shared/types';
+import { checkPerformanceCategory, PERFORMANCE_RULE_ID, runPerformanceAudit } from '../../application/validation/PerformanceAudit';
 
 export class PerformanceAuditor {
   /**
-   * Run performance audit on configuration
+   * Run the performance audit: timeouts, connection pools, retries and caches of every file
    */
   async audit(context: ValidationContext): Promise<ValidationResult> {
-    const errors: ValidationResult['errors'] = [];
-    const warnings: ValidationResult['warnings'] = [];
-
-    // TODO: Implement actual performance checks
-    // For now, return mock results
-    warnings.push({
-      code: 'PERFORMANCE_AUDIT_NOT_IMPLEMENTED',
-      message: 'Performance audit not implemented yet',
-      severity: 'warning'
-    });
+    const files: ConfigFile[] = Object.entries(context.files ?? {}).map(([path, content]) => ({ path, content, format: 'yaml' }));
+    const findings = runPerformanceAudit(files, context.performance);
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
 
     return {
       success: errors.length === 0,
       errors,
-      warnings,
+      warnings: findings.filter(finding => finding.severity !== 'error'),
       metadata: {
-        auditType: 'performance',
-        rulesChecked: 0,
-        rulesPassed: 0,
-        rulesFailed: 0
+        auditType: PERFORMANCE_RULE_ID,
+        rulesChecked: files.length,
+        rulesPassed: files.length - failedFiles,
+        rulesFailed: failedFiles
       }
     };
   }
@@ -32,36 +27,21 @@ export class PerformanceAuditor {
   /**
    * Check database connection pool configuration
    */
-  private checkDatabasePoolConfig(config: Record<string, any>): ValidationResult['errors'] {
-    const errors: ValidationResult['errors'] = [];
-    
-    // TODO: Implement database pool validation
-    // Check for optimal connection pool settings
-    
-    return errors;
+  private checkDatabasePoolConfig(config: Record<string, any>, file = 'config'): ValidationResult['errors'] {
+    return checkPerformanceCategory({ path: file, content: config, format: 'yaml' }, 'pools');
   }
 
   /**
    * Check caching configuration
    */
-  private checkCachingConfig(config: Record<string, any>): ValidationResult['errors'] {
-    const errors: ValidationResult['errors'] = [];
-    
-    // TODO: Implement caching validation
-    // Check for proper caching configuration
-    
-    return errors;
+  private checkCachingConfig(config: Record<string, any>, file = 'config'): ValidationResult['errors'] {
+    return checkPerformanceCategory({ path: file, content: config, format: 'yaml' }, 'caches');
   }
 
   /**
    * Check timeout configurations
    */
-  private checkTimeoutConfig(config: Record<string, any>): ValidationResult['errors'] {
-    const errors: ValidationResult['errors'] = [];
-    
-    // TODO: Implement timeout validation
-    // Check for reasonable timeout values
-    
-    return errors;
+  private checkTimeoutConfig(config: Record<string, any>, file = 'config'): ValidationResult['errors'] {
+    return checkPerformanceCategory({ path: file, content: config, format: 'yaml' }, 'timeouts');
   }
-} 
\ No newline at end of file
+}
@@ -0,0 +1,33 @@
+import { ConfigFile, ValidationResult, ValidationContext } from '../../shared/types';
+import { checkValueRules, VALUE_RULE_ID } from '../../application/validation/ValueRules';
+import { readKeyLocations } from '../adapters/KeyLocations';
+
+export class SchemaRulesAuditor {
+  /**
+   * Run the `rules:` audit: every value of a key matching a pattern is checked against its constraints.
+   * Keys of YAML and JSON files on disk are reported with their line.
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const files: ConfigFile[] = Object.entries(context.files ?? {}).map(([path, content]) => ({
+      path,
+      content,
+      format: /\.json$/i.test(path) ? 'json' : /\.ya?ml$/i.test(path) ? 'yaml' : 'unknown',
+    }));
+    const findings = checkValueRules(files, context.valueRules ?? {}, file => readKeyLocations(file.path, file.format));
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: findings.filter(finding => finding.severity === 'warning'),
+      info: findings.filter(finding => finding.severity === 'info'),
+      metadata: {
+        auditType: VALUE_RULE_ID,
+        rulesChecked: files.length,
+        rulesPassed: files.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+}
@@ -1,20 +1,16 @@
 import { ValidationResult, ValidationContext } from '../../shared/types';
+import { scanSecrets } from '../../application/validation/SecretScanning';
 
 export class SecurityAuditor {
   /**
-   * Run security audit on configuration
+   * Run the security audit: scan every value of every file for secrets
    */
   async audit(context: ValidationContext): Promise<ValidationResult> {
-    const errors: ValidationResult['errors'] = [];
-    const warnings: ValidationResult['warnings'] = [];
-
-    // TODO: Implement actual security checks
-    // For now, return mock results
-    warnings.push({
-      code: 'SECURITY_AUDIT_NOT_IMPLEMENTED',
-      message: 'Security audit not implemented yet',
-      severity: 'warning'
-    });
+    const files = Object.entries(context.files ?? {});
+    const findings = files.flatMap(([path, content]) => scanSecrets(path, content));
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const warnings = findings.filter(finding => finding.severity !== 'error');
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
 
     return {
       success: errors.length === 0,
@@ -22,9 +18,9 @@ export class SecurityAuditor {
       warnings,
       metadata: {
         auditType: 'security',
-        rulesChecked: 0,
-        rulesPassed: 0,
-        rulesFailed: 0
+        rulesChecked: files.length,
+        rulesPassed: files.length - failedFiles,
+        rulesFailed: failedFiles
       }
     };
   }
@@ -32,13 +28,8 @@ export class SecurityAuditor {
   /**
    * Check for hardcoded secrets in configuration
    */
-  private checkForHardcodedSecrets(config: Record<string, any>): ValidationResult['errors'] {
-    const errors: ValidationResult['errors'] = [];
-    
-    // TODO: Implement secret detection logic
-    // This would scan for patterns like API keys, passwords, tokens
-    
-    return errors;
+  private checkForHardcodedSecrets(config: Record<string, any>, file = 'config'): ValidationResult['errors'] {
+    return scanSecrets(file, config).filter(finding => finding.severity === 'error');
   }
 
   /**
@@ -0,0 +1,32 @@
+import { ConfigFile, ValidationResult, ValidationContext } from '../../shared/types';
+import {
+  findKeyDifferences,
+  keyDifferenceFinding,
+  VALUE_DIFFERENCE_RULE_ID
+} from '../../application/validation/ValueDifferences';
+
+export class ValueAuditor {
+  /**
+   * Run the value audit: the keys of `value_differences:` set in several files must hold the
+   * same value in each; like validate, nothing is compared when it is not configured
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const files: ConfigFile[] = Object.entries(context.files ?? {}).map(([path, content]) => ({ path, content, format: 'yaml' }));
+    const findings = findKeyDifferences(files, context.valueDifferences ?? []).map(keyDifferenceFinding);
+    const errors = findings.filter(finding => finding.severity === 'error');
+    const failedFiles = new Set(errors.flatMap(error => error.context?.files ?? [])).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: findings.filter(finding => finding.severity === 'warning'),
+      info: findings.filter(finding => finding.severity === 'info'),
+      metadata: {
+        auditType: VALUE_DIFFERENCE_RULE_ID,
+        rulesChecked: files.length,
+        rulesPassed: files.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+}
@@ -0,0 +1,27 @@
+import { ValidationResult, ValidationContext } from '../../shared/types';
+import { checkWorkflows, isWorkflow } from '../../application/validation/WorkflowChecks';
+
+export class WorkflowAuditor {
+  /**
+   * Run the GitHub Actions workflow audit; files that are not workflows are skipped
+   */
+  async audit(context: ValidationContext): Promise<ValidationResult> {
+    const targets = Object.entries(context.files ?? {})
+      .filter(([, content]) => isWorkflow(content))
+      .map(([path, content]) => ({ path, content }));
+    const errors = checkWorkflows(targets);
+    const failedFiles = new Set(errors.map(error => error.context?.file)).size;
+
+    return {
+      success: errors.length === 0,
+      errors,
+      warnings: [],
+      metadata: {
+        auditType: 'workflows',
+        rulesChecked: targets.length,
+        rulesPassed: targets.length - failedFiles,
+        rulesFailed: failedFiles
+      }
+    };
+  }
+}
@@ -1,4 +1,4 @@
-import { PluginMetadata, ValidationRule } from '../../../shared/types';
+import { PluginMetadata, ValidationMetadata, ValidationRule } from '../../../shared/types';
 
 /**
  * Base class for all Praetorian plugins
@@ -148,7 +148,7 @@ export abstract class BasePlugin {
 
 // Pure functions for functional programming approach
 
-const createInitialMetadata = (metadata: PluginMetadata): Record<string, any> => ({
+const createInitialMetadata = (metadata: PluginMetadata): ValidationMetadata => ({
   plugin: metadata.name,
   version: metadata.version,
   rulesChecked: 0,
@@ -179,7 +179,7 @@ const createRuleErrorResult = (
     code: 'PLUGIN_ERROR',
     message: `Plugin ${plugin.getMetadata().name} failed to execute rule ${rule.id}: ${getErrorMessage(error)}`,
     severity: 'error' as const,
-    context: { ruleId: rule.id, plugin: plugin.getMetadata().name }
+    context: { rule: { id: rule.id, plugin: plugin.getMetadata().name } }
   }],
   warnings: [],
   ruleId: rule.id,
@@ -0,0 +1,275 @@
+/**
+ * Pprof - Convert V8 profiles to the pprof format
+ *
+ * Single Responsibility: Turn the CPU and sampling heap profiles produced by
+ * the V8 inspector into gzipped pprof protobufs (profile.proto), readable by
+ * `go tool pprof` and any pprof-compatible viewer. Pure functions only.
+ */
+
+import * as zlib from 'zlib';
+
+export interface StackFrame {
+  name: string;
+  file: string;
+  line: number;
+}
+
+export interface ProfileSample {
+  /** Leaf frame first, as pprof expects */
+  stack: StackFrame[];
+  values: number[];
+}
+
+export interface ProfileValueType {
+  type: string;
+  unit: string;
+}
+
+export interface ProfileData {
+  sampleTypes: ProfileValueType[];
+  samples: ProfileSample[];
+  periodType: ProfileValueType;
+  period: number;
+  /** Wall-clock start of the profile, in epoch milliseconds */
+  timeMs?: number;
+  durationNanos?: number;
+}
+
+/** Call frame as reported by the V8 inspector (0-based line numbers) */
+export interface V8CallFrame {
+  functionName: string;
+  url: string;
+  lineNumber: number;
+}
+
+export interface V8CpuProfile {
+  nodes: Array<{ id: number; callFrame: V8CallFrame; children?: number[] }>;
+  startTime: number;
+  endTime: number;
+  samples?: number[];
+  timeDeltas?: number[];
+}
+
+export interface V8HeapNode {
+  callFrame: V8CallFrame;
+  selfSize: number;
+  children: V8HeapNode[];
+}
+
+export interface V8HeapProfile {
+  head: V8HeapNode;
+}
+
+/**
+ * Default V8 heap sampling interval in bytes
+ */
+export const HEAP_SAMPLING_INTERVAL = 512 * 1024;
+
+const ROOT_FRAMES = new Set(['(root)']);
+
+/**
+ * Minimal protobuf writer covering the wire types used by profile.proto
+ */
+class ProtoWriter {
+  private readonly chunks: Buffer[] = [];
+
+  varint(field: number, value: number | bigint): this {
+    this.chunks.push(encodeVarint((field << 3) | 0), encodeVarint(value));
+    return this;
+  }
+
+  bytes(field: number, value: Buffer): this {
+    this.chunks.push(encodeVarint((field << 3) | 2), encodeVarint(value.length), value);
+    return this;
+  }
+
+  string(field: number, value: string): this {
+    return this.bytes(field, Buffer.from(value, 'utf8'));
+  }
+
+  message(field: number, writer: ProtoWriter): this {
+    return this.bytes(field, writer.toBuffer());
+  }
+
+  packed(field: number, values: number[]): this {
+    return this.bytes(field, Buffer.concat(values.map(value => encodeVarint(value))));
+  }
+
+  toBuffer(): Buffer {
+    return Buffer.concat(this.chunks);
+  }
+}
+
+/**
+ * Pure function to encode a non-negative integer as a protobuf varint
+ */
+export const encodeVarint = (value: number | bigint): Buffer => {
+  const requested = typeof value === 'bigint' ? value : BigInt(Math.floor(value));
+  // Values written by this module are never negative
+  let remaining = requested > 0n ? requested : 0n;
+
+  const bytes: number[] = [];
+  do {
+    const byte = Number(remaining & 0x7fn);
+    remaining >>= 7n;
+    bytes.push(remaining > 0n ? byte | 0x80 : byte);
+  } while (remaining > 0n);
+
+  return Buffer.from(bytes);
+};
+
+/**
+ * Pure function to encode profile data as an uncompressed pprof protobuf
+ */
+export const encodeProfile = (profile: ProfileData): Buffer => {
+  const strings = new Map<string, number>([['', 0]]);
+  const stringId = (value: string): number => {
+    if (!strings.has(value)) {
+      strings.set(value, strings.size);
+    }
+    return strings.get(value)!;
+  };
+
+  const functions = new Map<string, { id: number; frame: StackFrame }>();
+  const locations = new Map<string, { id: number; functionId: number; line: number }>();
+  const locationId = (frame: StackFrame): number => {
+    const functionKey = `${frame.name}\u0000${frame.file}`;
+    if (!functions.has(functionKey)) {
+      functions.set(functionKey, { id: functions.size + 1, frame });
+    }
+    const functionId = functions.get(functionKey)!.id;
+
+    const locationKey = `${functionKey}\u0000${frame.line}`;
+    if (!locations.has(locationKey)) {
+      locations.set(locationKey, { id: locations.size + 1, functionId, line: frame.line });
+    }
+    return locations.get(locationKey)!.id;
+  };
+
+  const valueType = (type: ProfileValueType) =>
+    new ProtoWriter().varint(1, stringId(type.type)).varint(2, stringId(type.unit));
+
+  const writer = new ProtoWriter();
+  profile.sampleTypes.forEach(type => writer.message(1, valueType(type)));
+  profile.samples.forEach(sample => {
+    writer.message(2, new ProtoWriter().packed(1, sample.stack.map(locationId)).packed(2, sample.values));
+  });
+  locations.forEach(location => {
+    writer.message(
+      4,
+      new ProtoWriter()
+        .varint(1, location.id)
+        .message(4, new ProtoWriter().varint(1, location.functionId).varint(2, location.line))
+    );
+  });
+  functions.forEach(({ id, frame }) => {
+    writer.message(
+      5,
+      new ProtoWriter()
+        .varint(1, id)
+        .varint(2, stringId(frame.name))
+        .varint(3, stringId(frame.name))
+        .varint(4, stringId(frame.file))
+    );
+  });
+
+  // Every string is interned by now, so the table can be written last
+  const periodType = valueType(profile.periodType);
+  const tail = new ProtoWriter();
+  if (profile.timeMs !== undefined) {
+    tail.varint(9, BigInt(Math.floor(profile.timeMs)) * 1000000n);
+  }
+  if (profile.durationNanos !== undefined) {
+    tail.varint(10, Math.floor(profile.durationNanos));
+  }
+  tail.message(11, periodType).varint(12, Math.floor(profile.period));
+
+  strings.forEach((_, value) => writer.string(6, value));
+  return Buffer.concat([writer.toBuffer(), tail.toBuffer()]);
+};
+
+/**
+ * Pure function to encode profile data as a gzipped pprof file
+ */
+export const toPprof = (profile: ProfileData): Buffer => zlib.gzipSync(encodeProfile(profile));
+
+/**
+ * Pure function to map a V8 call frame to a pprof frame (1-based lines)
+ */
+export const toStackFrame = (callFrame: V8CallFrame): StackFrame => ({
+  name: callFrame.functionName || '(anonymous)',
+  file: callFrame.url,
+  line: callFrame.lineNumber + 1,
+});
+
+/**
+ * Pure function to convert a V8 CPU profile into sample counts and CPU time per stack
+ */
+export const cpuProfileToData = (cpuProfile: V8CpuProfile): ProfileData => {
+  const nodes = new Map(cpuProfile.nodes.map(node => [node.id, node]));
+  const parents = new Map<number, number>();
+  cpuProfile.nodes.forEach(node => (node.children ?? []).forEach(child => parents.set(child, node.id)));
+
+  const stackOf = (nodeId: number): StackFrame[] => {
+    const stack: StackFrame[] = [];
+    for (let id: number | undefined = nodeId; id !== undefined; id = parents.get(id)) {
+      const node = nodes.get(id);
+      if (node && !ROOT_FRAMES.has(node.callFrame.functionName)) {
+        stack.push(toStackFrame(node.callFrame));
+      }
+    }
+    return stack;
+  };
+
+  // V8 times are in microseconds and timeDeltas[i] is the gap before sample i,
+  // so the time spent in a sample is the delta that follows it
+  const samples = cpuProfile.samples ?? [];
+  const deltas = cpuProfile.timeDeltas ?? [];
+  const totals = new Map<number, { count: number; nanos: number }>();
+  samples.forEach((nodeId, index) => {
+    const total = totals.get(nodeId) ?? { count: 0, nanos: 0 };
+    const nextDelta = deltas[index + 1] ?? 0;
+    totals.set(nodeId, { count: total.count + 1, nanos: total.nanos + Math.max(0, nextDelta) * 1000 });
+  });
+
+  const durationNanos = Math.max(0, cpuProfile.endTime - cpuProfile.startTime) * 1000;
+  return {
+    sampleTypes: [{ type: 'samples', unit: 'count' }, { type: 'cpu', unit: 'nanoseconds' }],
+    samples: [...totals.entries()].map(([nodeId, total]) => ({
+      stack: stackOf(nodeId),
+      values: [total.count, Math.round(total.nanos)],
+    })),
+    periodType: { type: 'cpu', unit: 'nanoseconds' },
+    period: samples.length > 0 ? Math.round(durationNanos / samples.length) : 0,
+    timeMs: Date.now() - Math.round(durationNanos / 1e6),
+    durationNanos,
+  };
+};
+
+/**
+ * Pure function to convert a V8 sampling heap profile into allocated bytes per stack
+ */
+export const heapProfileToData = (
+  heapProfile: V8HeapProfile,
+  samplingInterval: number = HEAP_SAMPLING_INTERVAL
+): ProfileData => {
+  const samples: ProfileSample[] = [];
+  const visit = (node: V8HeapNode, parentStack: StackFrame[]): void => {
+    const stack = ROOT_FRAMES.has(node.callFrame.functionName)
+      ? parentStack
+      : [toStackFrame(node.callFrame), ...parentStack];
+    if (node.selfSize > 0) {
+      samples.push({ stack, values: [node.selfSize] });
+    }
+    node.children.forEach(child => visit(child, stack));
+  };
+  visit(heapProfile.head, []);
+
+  return {
+    sampleTypes: [{ type: 'space', unit: 'bytes' }],
+    samples,
+    periodType: { type: 'space', unit: 'bytes' },
+    period: samplingInterval,
+    timeMs: Date.now(),
+  };
+};
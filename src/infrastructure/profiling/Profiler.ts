@@ -0,0 +1,101 @@
+/**
+ * Profiler - CPU and memory profiling of a single run
+ *
+ * Single Responsibility: Drive the V8 inspector to sample CPU time and heap
+ * allocations while a command runs, then write both as pprof files.
+ */
+
+import * as fs from 'fs';
+import * as inspector from 'inspector';
+import * as path from 'path';
+import {
+  cpuProfileToData,
+  heapProfileToData,
+  HEAP_SAMPLING_INTERVAL,
+  toPprof,
+  V8CpuProfile,
+  V8HeapProfile,
+} from './Pprof';
+
+export interface ProfileTargets {
+  /** Output path of the CPU profile */
+  cpu?: string;
+  /** Output path of the heap allocation profile */
+  mem?: string;
+}
+
+export class RunProfiler {
+  private session?: inspector.Session;
+
+  constructor(private readonly targets: ProfileTargets) {}
+
+  /**
+   * Whether any profile was requested
+   */
+  isActive(): boolean {
+    return Boolean(this.targets.cpu || this.targets.mem);
+  }
+
+  /**
+   * Start sampling; a no-op when no profile was requested
+   */
+  async start(): Promise<void> {
+    // Guard clause: nothing to profile
+    if (!this.isActive()) {
+      return;
+    }
+
+    this.session = new inspector.Session();
+    this.session.connect();
+
+    if (this.targets.cpu) {
+      await this.post('Profiler.enable');
+      await this.post('Profiler.start');
+    }
+    if (this.targets.mem) {
+      await this.post('HeapProfiler.enable');
+      await this.post('HeapProfiler.startSampling', { samplingInterval: HEAP_SAMPLING_INTERVAL });
+    }
+  }
+
+  /**
+   * Stop sampling and write the requested profiles; returns the written paths
+   */
+  async stop(): Promise<string[]> {
+    // Guard clause: never started
+    if (!this.session) {
+      return [];
+    }
+
+    const written: string[] = [];
+    try {
+      if (this.targets.cpu) {
+        const { profile } = await this.post<{ profile: V8CpuProfile }>('Profiler.stop');
+        written.push(this.write(this.targets.cpu, toPprof(cpuProfileToData(profile))));
+      }
+      if (this.targets.mem) {
+        const { profile } = await this.post<{ profile: V8HeapProfile }>('HeapProfiler.stopSampling');
+        written.push(this.write(this.targets.mem, toPprof(heapProfileToData(profile))));
+      }
+    } finally {
+      this.session.disconnect();
+      this.session = undefined;
+    }
+
+    return written;
+  }
+
+  private write(filePath: string, content: Buffer): string {
+    fs.mkdirSync(path.dirname(path.resolve(filePath)), { recursive: true });
+    fs.writeFileSync(filePath, content);
+    return filePath;
+  }
+
+  private post<T = unknown>(method: string, params: object = {}): Promise<T> {
+    return new Promise((resolve, reject) => {
+      this.session!.post(method, params, (error: Error | null, result?: unknown) =>
+        error ? reject(error) : resolve(result as T)
+      );
+    });
+  }
+}
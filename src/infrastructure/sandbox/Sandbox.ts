@@ -0,0 +1,248 @@
+/**
+ * Sandbox - Enforced read-only audit mode
+ *
+ * Single Responsibility: While installed, patch the write functions of `fs`, the
+ * spawning functions of `child_process` and outbound sockets so that the process
+ * cannot write outside one directory, cannot run other programs and cannot reach
+ * the network unless remote sources were explicitly enabled. Every attempt is
+ * refused and recorded, so that a violation fails the run even when the code that
+ * made it catches the error.
+ */
+
+// Default imports are the module objects themselves, so the patches are seen by every importer
+import childProcess from 'child_process';
+import dgram from 'dgram';
+import fs from 'fs';
+import net from 'net';
+import * as os from 'os';
+import * as path from 'path';
+import { fileURLToPath } from 'url';
+import { SandboxViolationError } from '../../shared/utils/ExitCodes';
+
+export interface SandboxOptions {
+  /** Only directory writes are allowed in; created under the system temp dir when undefined */
+  writableDir?: string;
+  /** Allow outbound connections (remote sources enabled on the command line) */
+  allowNetwork?: boolean;
+  /** Files the run was asked to write outside the directory (profiles); their parent directories may be created */
+  writableFiles?: string[];
+}
+
+type Patch = { target: any; name: string; original: (...args: any[]) => any };
+
+// fs functions whose first argument is the path they write
+const FS_WRITERS = [
+  'writeFile', 'appendFile', 'mkdir', 'mkdtemp', 'rm', 'rmdir', 'unlink', 'truncate',
+  'chmod', 'chown', 'lchown', 'utimes', 'lutimes', 'createWriteStream',
+];
+
+// fs functions writing both of their path arguments
+const FS_TWO_PATH_WRITERS = ['rename', 'copyFile', 'cp', 'symlink', 'link'];
+
+const CHILD_PROCESS_FUNCTIONS = ['spawn', 'spawnSync', 'exec', 'execSync', 'execFile', 'execFileSync', 'fork'];
+
+/**
+ * Pure function to check if a path is the directory or lies below it
+ */
+export const isInsideDirectory = (target: string, directory: string): boolean => {
+  const relative = path.relative(path.resolve(directory), path.resolve(target));
+  return relative === '' || (!relative.startsWith('..') && !path.isAbsolute(relative));
+};
+
+/**
+ * Pure function to check if `fs.open` flags allow writing
+ */
+export const isWriteFlag = (flags: unknown): boolean =>
+  typeof flags === 'number'
+    ? (flags & (fs.constants.O_WRONLY | fs.constants.O_RDWR | fs.constants.O_CREAT | fs.constants.O_TRUNC | fs.constants.O_APPEND)) !== 0
+    : typeof flags === 'string' && /[wa+]/.test(flags);
+
+/**
+ * Pure function to turn a path argument into a string; file descriptors and unknown values give undefined
+ */
+const pathOf = (value: unknown): string | undefined => {
+  if (typeof value === 'string') {
+    return value;
+  }
+  if (value instanceof URL) {
+    return fileURLToPath(value);
+  }
+  return Buffer.isBuffer(value) ? value.toString() : undefined;
+};
+
+/**
+ * Pure function to describe the destination of `socket.connect(...)` arguments
+ */
+const destinationOf = (args: any[]): string => {
+  const [first, second] = Array.isArray(args[0]) ? args[0] : args;
+  if (typeof first === 'object' && first !== null) {
+    return first.path ?? `${first.host ?? 'localhost'}:${first.port ?? ''}`;
+  }
+  return typeof second === 'string' ? `${second}:${first}` : String(first);
+};
+
+export class Sandbox {
+  private readonly patches: Patch[] = [];
+  private readonly recorded: string[] = [];
+  private directory?: string;
+  private previousTmpDir?: string;
+
+  constructor(private readonly options: SandboxOptions = {}) {}
+
+  /**
+   * Start refusing writes outside the sandbox directory, child processes and, unless allowed,
+   * network calls. Temp files of the run go to the sandbox directory.
+   */
+  install(): this {
+    const directory = this.options.writableDir
+      ? path.resolve(this.options.writableDir)
+      : fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-sandbox-'));
+    fs.mkdirSync(directory, { recursive: true });
+    this.directory = fs.realpathSync(directory);
+    this.previousTmpDir = process.env.TMPDIR;
+    process.env.TMPDIR = this.directory;
+
+    FS_WRITERS.forEach(name => this.guardFs(name, args => [args[0]]));
+    FS_TWO_PATH_WRITERS.forEach(name => this.guardFs(name, args => [args[0], args[1]]));
+    this.guardFs('open', args => isWriteFlag(args[1]) ? [args[0]] : []);
+    CHILD_PROCESS_FUNCTIONS.forEach(name =>
+      this.patch(childProcess, name, args => `child process ${String(args[0])} (child_process.${name})`)
+    );
+
+    if (!this.options.allowNetwork) {
+      this.patch(net.Socket.prototype, 'connect', args => `network connection to ${destinationOf(args)}`);
+      this.patch(dgram.Socket.prototype, 'send', () => 'network datagram (dgram.send)');
+    }
+
+    return this;
+  }
+
+  /**
+   * Restore the patched functions and the temp dir; the sandbox directory is kept
+   */
+  dispose(): void {
+    this.patches.splice(0).reverse().forEach(({ target, name, original }) => {
+      target[name] = original;
+    });
+    if (this.directory !== undefined) {
+      if (this.previousTmpDir === undefined) {
+        delete process.env.TMPDIR;
+      } else {
+        process.env.TMPDIR = this.previousTmpDir;
+      }
+    }
+  }
+
+  /**
+   * Directory writes are allowed in, once installed
+   */
+  getDirectory(): string | undefined {
+    return this.directory;
+  }
+
+  /**
+   * Every refused attempt so far, including the ones whose error was caught
+   */
+  getViolations(): string[] {
+    return [...this.recorded];
+  }
+
+  /**
+   * Error summarizing the refused attempts, if any
+   */
+  violation(): SandboxViolationError | undefined {
+    // Guard clause: nothing was refused
+    if (this.recorded.length === 0) {
+      return undefined;
+    }
+
+    return new SandboxViolationError(`Sandbox violation: ${[...new Set(this.recorded)].join('; ')}`);
+  }
+
+  /**
+   * Throw when anything was refused, caught or not
+   */
+  assertClean(): void {
+    const error = this.violation();
+    if (error) {
+      throw error;
+    }
+  }
+
+  /**
+   * Check whether a write outside the directory was requested on the command line: the
+   * file itself, or for mkdir one of its parent directories
+   */
+  private isWritableFile(target: string, name: string): boolean {
+    const resolved = path.resolve(target);
+    return (this.options.writableFiles ?? [])
+      .map(file => path.resolve(file))
+      .some(file => file === resolved || (name === 'mkdir' && isInsideDirectory(file, resolved)));
+  }
+
+  /**
+   * Guard the sync, callback and promise variants of an fs write function
+   */
+  private guardFs(name: string, targets: (args: any[]) => unknown[]): void {
+    const refusal = (variant: string) => (args: any[]): string | undefined => {
+      const outside = targets(args)
+        .map(pathOf)
+        .find(target => target !== undefined &&
+          !isInsideDirectory(this.realTarget(target), this.directory!) &&
+          !this.isWritableFile(target, name));
+      return outside === undefined ? undefined : `write to ${path.resolve(outside)} (${variant})`;
+    };
+
+    [name, `${name}Sync`]
+      .filter(variant => typeof (fs as any)[variant] === 'function')
+      .forEach(variant => this.patch(fs, variant, refusal(`fs.${variant}`)));
+    if (typeof (fs.promises as any)[name] === 'function') {
+      this.patch(fs.promises, name, refusal(`fs.promises.${name}`), true);
+    }
+  }
+
+  /**
+   * Path with its closest existing ancestor resolved, so that symlinks into the sandbox
+   * directory (like /tmp on macOS) compare as inside
+   */
+  private realTarget(target: string): string {
+    const resolved = path.resolve(target);
+    const parent = path.dirname(resolved);
+
+    // Guard clause: reached the root
+    if (parent === resolved) {
+      return resolved;
+    }
+
+    try {
+      return path.join(fs.realpathSync(parent), path.basename(resolved));
+    } catch {
+      return path.join(this.realTarget(parent), path.basename(resolved));
+    }
+  }
+
+  /**
+   * Replace a function with one that refuses the calls `refusal` describes
+   */
+  private patch(target: any, name: string, refusal: (args: any[]) => string | undefined, promise = false): void {
+    const original = target[name];
+    const recorded = this.recorded;
+
+    target[name] = function sandboxed(this: unknown, ...args: any[]) {
+      const reason = refusal(args);
+
+      // Guard clause: allowed call
+      if (reason === undefined) {
+        return original.apply(this, args);
+      }
+
+      recorded.push(reason);
+      const error = new SandboxViolationError(`Sandbox refused ${reason}`);
+      if (promise) {
+        return Promise.reject(error);
+      }
+      throw error;
+    };
+    this.patches.push({ target, name, original });
+  }
+}
@@ -0,0 +1,179 @@
+/**
+ * Telemetry - Anonymous, opt-in usage counters
+ *
+ * Single Responsibility: Keep coarse usage counters (commands, formats parsed,
+ * rule categories run) in the user config directory and, only when the user
+ * has opted in and an endpoint is configured, send exactly the payload that
+ * `praetorian telemetry show` prints. No paths, keys, values or identifiers.
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { HttpClient } from '../http/HttpClient';
+import { userConfigDir } from '../parsers/config-parsing/UserConfig';
+
+export interface TelemetryCounters {
+  commands: Record<string, number>;
+  formats: Record<string, number>;
+  ruleCategories: Record<string, number>;
+}
+
+export interface TelemetryState {
+  enabled: boolean;
+  counters: TelemetryCounters;
+}
+
+export interface TelemetryUsage {
+  command: string;
+  formats?: string[];
+  /** Ids of the rules that ran; the field keeps its name so stored counters stay readable */
+  ruleCategories?: string[];
+}
+
+export interface TelemetryPayload {
+  version: string;
+  counters: TelemetryCounters;
+}
+
+/**
+ * Environment variable holding the collection endpoint; nothing is sent without it
+ */
+export const TELEMETRY_ENDPOINT_ENV = 'PRAETORIAN_TELEMETRY_ENDPOINT';
+
+/**
+ * Pure function to create empty counters
+ */
+export const emptyCounters = (): TelemetryCounters => ({ commands: {}, formats: {}, ruleCategories: {} });
+
+/**
+ * Pure function to locate the telemetry state file
+ */
+export const telemetryStatePath = (env: Record<string, string | undefined> = process.env): string =>
+  path.join(userConfigDir(env), 'telemetry.json');
+
+/**
+ * Pure function to check the DO_NOT_TRACK convention, which wins over opt-in
+ */
+export const isTelemetryBlocked = (env: Record<string, string | undefined> = process.env): boolean =>
+  ['1', 'true'].includes((env.DO_NOT_TRACK ?? '').toLowerCase());
+
+const increment = (counts: Record<string, number>, keys: string[]): Record<string, number> =>
+  [...new Set(keys)].reduce((acc, key) => ({ ...acc, [key]: (acc[key] ?? 0) + 1 }), counts);
+
+/**
+ * Pure function to add one run to the counters (each format/category counts once per run)
+ */
+export const recordUsage = (counters: TelemetryCounters, usage: TelemetryUsage): TelemetryCounters => ({
+  commands: increment(counters.commands, [usage.command]),
+  formats: increment(counters.formats, usage.formats ?? []),
+  ruleCategories: increment(counters.ruleCategories, usage.ruleCategories ?? [])
+});
+
+/**
+ * Pure function to build the payload that would be sent
+ */
+export const buildTelemetryPayload = (counters: TelemetryCounters, version: string): TelemetryPayload => ({
+  version,
+  counters
+});
+
+export class TelemetryStore {
+  /**
+   * @param choice - Telemetry choice of the user configuration; wins over the stored opt-in
+   */
+  constructor(
+    private readonly env: Record<string, string | undefined> = process.env,
+    private readonly filePath: string = telemetryStatePath(env),
+    private readonly choice?: boolean
+  ) {}
+
+  /**
+   * Load the stored state; missing or unreadable state means "not opted in"
+   */
+  load(): TelemetryState {
+    try {
+      const stored = JSON.parse(fs.readFileSync(this.filePath, 'utf8'));
+      return {
+        enabled: stored.enabled === true,
+        counters: { ...emptyCounters(), ...(stored.counters ?? {}) }
+      };
+    } catch {
+      return { enabled: false, counters: emptyCounters() };
+    }
+  }
+
+  /**
+   * Opt in or out. Opting out also discards collected counters.
+   */
+  setEnabled(enabled: boolean): TelemetryState {
+    const state = { enabled, counters: enabled ? this.load().counters : emptyCounters() };
+    this.save(state);
+    return state;
+  }
+
+  isEnabled(): boolean {
+    return !isTelemetryBlocked(this.env) && (this.choice ?? this.load().enabled);
+  }
+
+  /**
+   * Tell whether the user configuration makes the choice, so enable/disable have no effect
+   */
+  isChosenByUserConfig(): boolean {
+    return this.choice !== undefined;
+  }
+
+  getEndpoint(): string | undefined {
+    return this.env[TELEMETRY_ENDPOINT_ENV] || undefined;
+  }
+
+  getStatePath(): string {
+    return this.filePath;
+  }
+
+  /**
+   * Count a run. Never throws: telemetry must not affect the command outcome.
+   */
+  record(usage: TelemetryUsage): void {
+    // Guard clause: not opted in
+    if (!this.isEnabled()) {
+      return;
+    }
+
+    try {
+      const state = this.load();
+      this.save({ ...state, counters: recordUsage(state.counters, usage) });
+    } catch {
+      // Ignore: counters are best effort
+    }
+  }
+
+  /**
+   * Send collected counters and reset them. Returns false when nothing was sent.
+   */
+  async flush(version: string): Promise<boolean> {
+    const endpoint = this.getEndpoint();
+
+    // Guard clause: not opted in or nowhere to send
+    if (!this.isEnabled() || !endpoint) {
+      return false;
+    }
+
+    const state = this.load();
+    const payload = JSON.stringify(buildTelemetryPayload(state.counters, version));
+    const response = await new HttpClient({ timeoutMs: 2000, retries: 0, env: this.env })
+      .post(endpoint, payload, { 'content-type': 'application/json' });
+
+    // Guard clause: rejected by the endpoint, keep counters for next time
+    if (response.status < 200 || response.status >= 300) {
+      return false;
+    }
+
+    this.save({ ...state, counters: emptyCounters() });
+    return true;
+  }
+
+  private save(state: TelemetryState): void {
+    fs.mkdirSync(path.dirname(this.filePath), { recursive: true });
+    fs.writeFileSync(this.filePath, `${JSON.stringify(state, null, 2)}\n`, 'utf8');
+  }
+}
@@ -0,0 +1,73 @@
+/**
+ * FindingCap - Limit detailed finding output
+ *
+ * Single Responsibility: Keep the first N findings of a severity and summarize
+ * the rest per code, so large first-time results stay readable in CI logs.
+ */
+
+import { ValidationError } from '../../shared/types';
+import { DEFAULT_LANGUAGE, Language, translate } from '../../shared/i18n';
+
+export interface FindingOverflow {
+  code: string;
+  count: number;
+  files: number;
+}
+
+export interface CappedFindings<T extends ValidationError> {
+  shown: T[];
+  overflow: FindingOverflow[];
+}
+
+/**
+ * Pure function to list the files a finding refers to (`file` or aggregated `files`)
+ */
+export const findingFiles = (finding: ValidationError): string[] => [
+  ...(typeof finding.context?.file === 'string' ? [finding.context.file] : []),
+  ...(Array.isArray(finding.context?.files) ? finding.context.files : [])
+];
+
+/**
+ * Pure function to keep at most `max` findings and count the hidden ones per code
+ */
+export const capFindings = <T extends ValidationError>(findings: T[], max?: number): CappedFindings<T> => {
+  // Guard clause: no cap
+  if (max === undefined || findings.length <= max) {
+    return { shown: findings, overflow: [] };
+  }
+
+  const hidden = findings.slice(max);
+  const codes = [...new Set(hidden.map(finding => finding.code))];
+
+  return {
+    shown: findings.slice(0, max),
+    overflow: codes.map(code => {
+      const ofCode = hidden.filter(finding => finding.code === code);
+      return {
+        code,
+        count: ofCode.length,
+        files: new Set(ofCode.flatMap(findingFiles)).size
+      };
+    })
+  };
+};
+
+/**
+ * Pure function to describe hidden findings, e.g. "…and 312 more MISSING_KEY errors across 14 files"
+ */
+export const formatOverflow = (
+  overflow: FindingOverflow,
+  severity: string,
+  language: Language = DEFAULT_LANGUAGE
+): string => {
+  const files = overflow.files > 0
+    ? translate(overflow.files === 1 ? 'validate.overflow.file' : 'validate.overflow.files', { count: overflow.files }, language)
+    : '';
+
+  return translate('validate.overflow', {
+    count: overflow.count,
+    code: overflow.code,
+    noun: translate(`validate.noun.${severity}`, {}, language),
+    files
+  }, language);
+};
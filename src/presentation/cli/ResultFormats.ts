@@ -0,0 +1,233 @@
+/**
+ * ResultFormats - Machine-readable result serialization
+ *
+ * Single Responsibility: Serialize a validation result for other tools to parse,
+ * with every structured format carrying exactly the same fields as `--output json`,
+ * or mapping its findings onto SARIF 2.1.0 for code-scanning integrations, onto
+ * JUnit XML test reports for CI test reporters and onto GitLab Code Quality issues.
+ */
+
+import { createHash } from 'crypto';
+import * as path from 'path';
+import { pathToFileURL } from 'url';
+import { stringify } from 'yaml';
+import { ValidationError, ValidationResult } from '../../shared/types';
+import { collectFindings } from '../../shared/utils/Findings';
+
+export type StructuredFormat = 'json' | 'yaml' | 'sarif' | 'junit' | 'codeclimate';
+
+export const STRUCTURED_FORMATS: StructuredFormat[] = ['json', 'yaml', 'sarif', 'junit', 'codeclimate'];
+
+export const SARIF_SCHEMA = 'https://json.schemastore.org/sarif-2.1.0.json';
+
+const TOOL_URI = 'https://github.com/syntropysoft/praetorian';
+
+export interface SerializeOptions {
+  /** Version of praetorian, reported as the SARIF tool version */
+  toolVersion?: string;
+  /** Directory SARIF artifact URIs and Code Quality paths are relative to; the working directory by default */
+  cwd?: string;
+  /** Audited files, so that JUnit reports list the files without findings as passing */
+  files?: string[];
+  /** File Code Quality issues about no file point at, as GitLab requires a path */
+  configFile?: string;
+}
+
+const SARIF_LEVELS: Record<ValidationError['severity'], string> = {
+  error: 'error',
+  warning: 'warning',
+  info: 'note',
+};
+
+/**
+ * Pure function to check if an output format is meant for machines rather than people
+ */
+export const isStructuredFormat = (format: string): format is StructuredFormat =>
+  (STRUCTURED_FORMATS as string[]).includes(format);
+
+/**
+ * Pure function to name a file the way code-scanning tools expect: relative to the
+ * repository root with forward slashes, or a file URI when it lies outside
+ */
+export const artifactUri = (file: string, cwd: string = process.cwd()): string => {
+  const relative = path.relative(cwd, path.resolve(cwd, file));
+  return relative.startsWith('..') || path.isAbsolute(relative)
+    ? pathToFileURL(path.resolve(cwd, file)).href
+    : relative.split(path.sep).join('/');
+};
+
+const filesOf = (finding: ValidationError): string[] =>
+  [...new Set([finding.context?.file, ...(finding.context?.files ?? [])].filter((file): file is string => typeof file === 'string'))];
+
+const sarifLocations = (finding: ValidationError, cwd?: string) =>
+  filesOf(finding)
+    .map(file => ({
+      physicalLocation: {
+        artifactLocation: { uri: artifactUri(file, cwd) },
+        ...(finding.context?.line !== undefined
+          ? { region: { startLine: finding.context.line, ...(finding.context.column !== undefined ? { startColumn: finding.context.column } : {}) } }
+          : {}),
+      },
+    }));
+
+/**
+ * Pure function to map a result onto a SARIF 2.1.0 log with one run
+ * @param result - Result whose findings become SARIF results; the finding code is the rule id
+ * @param options - Tool version and the directory artifact URIs are relative to
+ */
+export const toSarif = (result: ValidationResult, options: SerializeOptions = {}): Record<string, unknown> => {
+  const findings = collectFindings(result);
+  const ruleIds = [...new Set(findings.map(finding => finding.code))].sort();
+
+  return {
+    $schema: SARIF_SCHEMA,
+    version: '2.1.0',
+    runs: [{
+      tool: {
+        driver: {
+          name: 'praetorian',
+          informationUri: TOOL_URI,
+          ...(options.toolVersion ? { version: options.toolVersion } : {}),
+          rules: ruleIds.map(id => ({ id, name: id })),
+        },
+      },
+      results: findings.map(finding => {
+        const locations = sarifLocations(finding, options.cwd);
+        const keyPath = finding.path ?? finding.context?.keyPath;
+        return {
+          ruleId: finding.code,
+          ruleIndex: ruleIds.indexOf(finding.code),
+          level: SARIF_LEVELS[finding.severity],
+          message: { text: finding.message },
+          ...(locations.length > 0 ? { locations } : {}),
+          ...(keyPath !== undefined ? { properties: { keyPath } } : {}),
+        };
+      }),
+    }],
+  };
+};
+
+const CODE_QUALITY_SEVERITIES: Record<ValidationError['severity'], string> = {
+  error: 'critical',
+  warning: 'minor',
+  info: 'info',
+};
+
+/**
+ * Pure function to fingerprint a Code Quality issue; the key path is preferred over the
+ * message, which follows the selected language, so that merge requests match issues across runs
+ */
+export const issueFingerprint = (finding: ValidationError, file: string): string =>
+  createHash('sha256')
+    .update(JSON.stringify([finding.code, file, finding.path ?? finding.context?.keyPath ?? finding.message]))
+    .digest('hex');
+
+/**
+ * Pure function to map a result onto GitLab Code Quality (CodeClimate) issues
+ * @param result - Result whose findings become issues; the finding code is the check name
+ * @param options - Directory paths are relative to, and the file findings about no file point at
+ * @returns One issue per finding and affected file
+ */
+export const toCodeQuality = (result: ValidationResult, options: SerializeOptions = {}): Record<string, unknown>[] =>
+  collectFindings(result).flatMap(finding => {
+    const files = filesOf(finding);
+    return (files.length > 0 ? files : [options.configFile ?? 'praetorian.yaml']).map(file => ({
+      type: 'issue',
+      check_name: finding.code,
+      description: finding.message,
+      categories: ['Bug Risk'],
+      severity: CODE_QUALITY_SEVERITIES[finding.severity],
+      fingerprint: issueFingerprint(finding, file),
+      location: {
+        path: artifactUri(file, options.cwd),
+        lines: { begin: finding.context?.line ?? 1 },
+      },
+    }));
+  });
+
+/** Test case of a file without findings in JUnit reports */
+export const JUNIT_CLEAN_CASE = 'no findings';
+
+const xml = (value: string): string =>
+  value
+    // Characters XML 1.0 cannot carry at all
+    .replace(/[\u0000-\u0008\u000B\u000C\u000E-\u001F]/g, '')
+    .replace(/&/g, '&amp;')
+    .replace(/</g, '&lt;')
+    .replace(/>/g, '&gt;')
+    .replace(/"/g, '&quot;');
+
+/**
+ * Pure function to render a result as a JUnit XML report
+ * @param result - Result to render
+ * @param options - Audited files; files with findings are always included
+ * @returns One test suite per file, and one for findings about no file, with one test case per finding
+ * code: failed when the code has errors, passed otherwise with warnings and info in `system-out`
+ */
+export const toJunit = (result: ValidationResult, options: SerializeOptions = {}): string => {
+  const findings = collectFindings(result);
+  const suites = [...new Set([...(options.files ?? []), ...findings.flatMap(filesOf)])]
+    .map(file => ({ name: file, findings: findings.filter(finding => filesOf(finding).includes(file)) }));
+  const unplaced = findings.filter(finding => filesOf(finding).length === 0);
+  const allSuites = [...suites, ...(unplaced.length > 0 ? [{ name: 'praetorian', findings: unplaced }] : [])];
+
+  const cases = (suite: { name: string; findings: ValidationError[] }) => {
+    const codes = [...new Set(suite.findings.map(finding => finding.code))];
+    return codes.length === 0
+      ? [{ name: JUNIT_CLEAN_CASE, errors: [] as ValidationError[], others: [] as ValidationError[] }]
+      : codes.map(code => ({
+        name: code,
+        errors: suite.findings.filter(finding => finding.code === code && finding.severity === 'error'),
+        others: suite.findings.filter(finding => finding.code === code && finding.severity !== 'error'),
+      }));
+  };
+  const rendered = allSuites.map(suite => ({ name: suite.name, cases: cases(suite) }));
+  const total = rendered.reduce((count, suite) => count + suite.cases.length, 0);
+  const failed = rendered.reduce((count, suite) => count + suite.cases.filter(testCase => testCase.errors.length > 0).length, 0);
+  const seconds = ((result.metadata?.duration ?? 0) / 1000).toFixed(3);
+
+  return [
+    '<?xml version="1.0" encoding="UTF-8"?>',
+    `<testsuites name="praetorian" tests="${total}" failures="${failed}" time="${seconds}">`,
+    ...rendered.flatMap(suite => [
+      `  <testsuite name="${xml(suite.name)}" tests="${suite.cases.length}" failures="${suite.cases.filter(testCase => testCase.errors.length > 0).length}">`,
+      ...suite.cases.flatMap(testCase => [
+        `    <testcase classname="${xml(suite.name)}" name="${xml(testCase.name)}">`,
+        ...(testCase.errors.length > 0
+          ? [`      <failure message="${xml(testCase.errors[0].message)}" type="${xml(testCase.name)}">${xml(testCase.errors.map(finding => finding.message).join('\n'))}</failure>`]
+          : []),
+        ...(testCase.others.length > 0
+          ? [`      <system-out>${xml(testCase.others.map(finding => `${finding.severity}: ${finding.message}`).join('\n'))}</system-out>`]
+          : []),
+        '    </testcase>',
+      ]),
+      '  </testsuite>',
+    ]),
+    '</testsuites>',
+  ].join('\n');
+};
+
+/**
+ * Pure function to serialize a result
+ * @param result - Result to serialize, nested finding context included
+ * @param format - Structured format
+ * @param options - Tool version, used by SARIF, working directory, used by SARIF and Code Quality,
+ * audited files, used by JUnit, and the configuration file, used by Code Quality
+ * @returns JSON, YAML of the same document (values JSON would drop are dropped too), a SARIF log,
+ * a JUnit report or Code Quality issues
+ */
+export const serializeResult = (result: ValidationResult, format: StructuredFormat, options: SerializeOptions = {}): string => {
+  // Guard clause: SARIF, JUnit and Code Quality describe findings, not the result document
+  if (format === 'sarif') {
+    return JSON.stringify(toSarif(result, options), null, 2);
+  }
+  if (format === 'junit') {
+    return toJunit(result, options);
+  }
+  if (format === 'codeclimate') {
+    return JSON.stringify(toCodeQuality(result, options), null, 2);
+  }
+
+  const document = JSON.stringify(result, null, 2);
+  return format === 'yaml' ? stringify(JSON.parse(document)).trimEnd() : document;
+};
@@ -0,0 +1,75 @@
+/**
+ * Terminal - Output styling decisions for the CLI
+ *
+ * Single Responsibility: Decide whether colors, emoji and banners may be
+ * written, based on TTY detection, NO_COLOR and the --no-color/--plain flags.
+ */
+
+import chalk from 'chalk';
+
+export interface TerminalOptions {
+  noColor?: boolean;
+  plain?: boolean;
+  quiet?: boolean;
+  isTTY?: boolean;
+  env?: Record<string, string | undefined>;
+}
+
+export interface TerminalStyle {
+  color: boolean;
+  emoji: boolean;
+  banner: boolean;
+}
+
+const EMOJI_PATTERN = /[\p{Extended_Pictographic}\u{FE0F}]\u{FE0F}?[ \t]*/gu;
+
+/**
+ * Pure function to resolve the output style for the current terminal
+ */
+export const resolveTerminalStyle = (options: TerminalOptions = {}): TerminalStyle => {
+  const env = options.env ?? process.env;
+  const isTTY = options.isTTY ?? Boolean(process.stdout.isTTY);
+  const decorated = isTTY && !options.plain;
+  const quiet = options.quiet || isCiEnvironment(env);
+
+  return {
+    color: decorated && !options.noColor && env.NO_COLOR === undefined,
+    emoji: decorated && !quiet,
+    banner: decorated && !quiet
+  };
+};
+
+/**
+ * Pure function to detect a CI environment (CI=true), which enables quiet mode
+ */
+export const isCiEnvironment = (env: Record<string, string | undefined> = process.env): boolean =>
+  (env.CI ?? '').toLowerCase() === 'true';
+
+/**
+ * Pure function to remove emoji (and the space that follows them) from text
+ */
+export const stripEmoji = (text: string): string => text.replace(EMOJI_PATTERN, '');
+
+/**
+ * Apply a resolved style to the shared chalk instance
+ */
+export const applyTerminalStyle = (style: TerminalStyle): void => {
+  if (!style.color) {
+    chalk.level = 0;
+  }
+};
+
+/**
+ * Pure function to adapt a line of output to the resolved style
+ */
+export const styleLine = (text: string, style: TerminalStyle): string =>
+  style.emoji ? text : stripEmoji(text);
+
+/**
+ * Pure function to read styling flags directly from raw argv (before oclif parsing)
+ */
+export const terminalOptionsFromArgv = (argv: string[]): TerminalOptions => ({
+  noColor: argv.includes('--no-color'),
+  plain: argv.includes('--plain'),
+  quiet: argv.includes('--quiet') || argv.includes('-q') || argv.includes('--ci')
+});
@@ -2,6 +2,12 @@
 
 import { run } from '@oclif/core';
 import chalk from 'chalk';
+import { applyTerminalStyle, resolveTerminalStyle, terminalOptionsFromArgv } from './Terminal';
+import { exitCodeFor, isExitOnly } from '../../shared/utils/ExitCodes';
+
+const args = process.argv.slice(2);
+const style = resolveTerminalStyle(terminalOptionsFromArgv(args));
+applyTerminalStyle(style);
 
 // ASCII Art Banner - Professional Praetorian Style with security colors
 const banner = `
@@ -15,9 +21,8 @@ ${chalk.gray('
 ${chalk.white.bold('🛡️  Guardian of Configurations & Security')} ${chalk.gray('|')} ${chalk.blue('Universal Validation Framework for DevSecOps')}
 `;
 
-// Show banner only for help and version commands
-const args = process.argv.slice(2);
-if (args.length === 0 || args.includes('--help') || args.includes('-h') || args.includes('--version') || args.includes('-V')) {
+// Show banner only for help and version commands on an interactive terminal
+if (style.banner && (args.length === 0 || args.includes('--help') || args.includes('-h') || args.includes('--version') || args.includes('-V'))) {
   console.log(banner);
 }
 
@@ -26,7 +31,9 @@ run()
     // Command completed successfully
   })
   .catch((error) => {
-    // Handle errors
-    console.error('Error:', error.message);
-    process.exit(1);
+    // Every command ends here, so this is the one place errors become exit codes
+    if (!isExitOnly(error)) {
+      console.error('Error:', error.message);
+    }
+    process.exit(exitCodeFor(error));
   }); 
\ No newline at end of file
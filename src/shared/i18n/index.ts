@@ -0,0 +1,82 @@
+/**
+ * i18n - Message catalogs for CLI output and findings
+ *
+ * Single Responsibility: Resolve the output language (--lang, PRAETORIAN_LANG,
+ * LC_ALL, LC_MESSAGES, LANG) and translate message ids from the catalogs.
+ * English is the reference catalog and the fallback for missing entries.
+ */
+
+import { renderTemplate } from '../utils/Template';
+import { en } from './messages/en';
+import { es } from './messages/es';
+
+export type Language = 'en' | 'es';
+
+export const LANGUAGES: Language[] = ['en', 'es'];
+
+export const DEFAULT_LANGUAGE: Language = 'en';
+
+const CATALOGS: Record<Language, Record<string, string>> = { en, es };
+
+const FINDING_PREFIX = 'finding.';
+
+/**
+ * Pure function to map a locale such as `es_AR.UTF-8` to a supported language
+ */
+export const parseLanguage = (locale: string | undefined): Language | undefined => {
+  const language = (locale ?? '').toLowerCase().split(/[_.@-]/)[0];
+  return (LANGUAGES as string[]).includes(language) ? language as Language : undefined;
+};
+
+/**
+ * Pure function to resolve the language from an explicit choice and the environment
+ */
+export const resolveLanguage = (
+  explicit?: string,
+  env: Record<string, string | undefined> = process.env
+): Language =>
+  [explicit, env.PRAETORIAN_LANG, env.LC_ALL, env.LC_MESSAGES, env.LANG]
+    .map(parseLanguage)
+    .find(language => language !== undefined) ?? DEFAULT_LANGUAGE;
+
+/**
+ * Pure function to read `--lang <value>` / `--lang=<value>` from raw argv (before oclif parsing)
+ */
+export const languageFromArgv = (argv: string[]): string | undefined => {
+  const index = argv.findIndex(arg => arg === '--lang' || arg.startsWith('--lang='));
+
+  // Guard clause: flag not present
+  if (index === -1) {
+    return undefined;
+  }
+
+  return argv[index].includes('=') ? argv[index].split('=')[1] : argv[index + 1];
+};
+
+/**
+ * Language of the current CLI invocation, used where flags are not parsed yet (command help)
+ */
+export const cliLanguage = (): Language =>
+  resolveLanguage(languageFromArgv(process.argv.slice(2)), process.env);
+
+/**
+ * Pure function to translate a message id, falling back to English and then to the id itself
+ */
+export const translate = (id: string, variables: Record<string, any> = {}, language: Language = DEFAULT_LANGUAGE): string =>
+  renderTemplate(CATALOGS[language][id] ?? en[id] ?? id, variables);
+
+/**
+ * Pure function to get the finding message templates of a language (finding code -> template)
+ */
+export const findingTemplates = (language: Language): Record<string, string> =>
+  Object.fromEntries(
+    Object.entries(CATALOGS[language])
+      .filter(([id]) => id.startsWith(FINDING_PREFIX))
+      .map(([id, template]) => [id.slice(FINDING_PREFIX.length), template])
+  );
+
+/**
+ * Pure function to list message ids missing from a catalog compared to English
+ */
+export const missingTranslations = (language: Language): string[] =>
+  Object.keys(en).filter(id => !(id in CATALOGS[language]));
@@ -0,0 +1,225 @@
+/**
+ * English message catalog (reference language: every other catalog has the same keys)
+ */
+export const en: Record<string, string> = {
+  // Command help
+  'command.validate.description': 'Validate configuration files for key consistency',
+  'command.init.description': 'Initialize a new Praetorian configuration file',
+  'command.telemetry.description': 'Inspect or change anonymous, opt-in usage telemetry',
+  'command.batch.description': 'Validate jobs read as JSON/NDJSON from stdin, one result line per job',
+  'command.inventory.description': 'Export an inventory (config-BOM) of every configured file as JSON',
+  'command.snapshot.create.description': 'Record the hashes and key sets of the configured files as a golden state',
+  'command.snapshot.verify.description': 'Fail when the configured files diverge from a recorded snapshot',
+  'command.config.migrate.description': 'Upgrade praetorian.yaml to the current schema version',
+  'command.config.show.description': 'Print praetorian.yaml, or with --effective the resolved configuration: the user configuration merged under it and templated environments expanded, each setting annotated with its source (only these layers are shown)',
+  'command.docs.generate.description': 'Generate Markdown documentation of every configured key, its type, environments, example and rules',
+  'command.risk.description': 'Classify the config keys changed since a git revision by risk and summarize them for reviewers',
+  'command.matrix.description': 'Lay out the keys of the configured files as a keys × environments matrix in text, HTML or CSV',
+  'command.fix.description': 'Fix trailing whitespace, line endings, final newlines and tab indentation in config files, and sort keys covered by key_order',
+
+  // validate
+  'validate.configNotFound': 'Configuration file not found: {{path}}',
+  'validate.createConfigHint': '\nCreate a configuration file with:',
+  'validate.resultsTitle': '\n📊 Validation Results:\n',
+  'validate.consistent': '✅ All files have consistent keys!',
+  'validate.consistentDetail': '   Your configuration files are properly synchronized across environments.',
+  'validate.inconsistent': '❌ Key inconsistencies found:',
+  'validate.inconsistentDetail': '   The following keys are missing in some configuration files:',
+  'validate.pipelineTip': '\n💡 Tip: Use --pipeline flag for concise CI/CD output',
+  'validate.warnings': '\n⚠️  {{count}} warning(s):',
+  'validate.emptyKeys': '\nℹ️  {{count}} empty key(s) found (informational):',
+  'validate.emptyKeysNote': '    Note: Empty keys are informational only and do not affect validation success',
+  'validate.summary': '\n📈 Summary:',
+  'validate.summary.files': '  • Files compared: {{count}}',
+  'validate.summary.keys': '  • Total keys: {{count}}',
+  'validate.summary.emptyKeys': '  • Empty keys: {{count}}',
+  'validate.summary.duration': '  • Duration: {{duration}}ms',
+  'validate.groups': '\n🗂️  Groups:',
+  'validate.group': '  • {{name}}: {{files}} files, {{errors}} errors, {{warnings}} warnings',
+  'validate.owners': '\n👥 Owners:',
+  'validate.owner': '  • {{team}}{{channel}}: {{errors}} errors, {{warnings}} warnings',
+  'validate.interrupted': '\n⛔ Interrupted by {{signal}}: results above are partial',
+  'validate.performance': '\n⏱️  Performance:',
+  'validate.performance.total': '  • Total: {{duration}}ms',
+  'validate.performance.file': '  • parse {{file}} ({{format}}): {{duration}}ms',
+  'validate.performance.rule': '  • rule {{rule}}: {{duration}}ms',
+  'validate.success': '\n🎉 Validation completed successfully!',
+  'validate.failure': '\n🔧 Fix the inconsistencies above and run validation again.',
+  'validate.overflow': '…and {{count}} more {{code}} {{noun}}{{files}}',
+  'validate.overflow.file': ' across {{count}} file',
+  'validate.overflow.files': ' across {{count}} files',
+  'validate.noun.error': 'errors',
+  'validate.noun.warning': 'warnings',
+  'validate.noun.info': 'notices',
+
+  // init
+  'init.exists': '⚠️  Configuration file already exists: {{path}}',
+  'init.useConfigFlag': 'Use --config to specify a different path',
+  'init.created': '✅ Configuration file created: {{path}}',
+  'init.rulesCreated': '✅ Example rule files created in ./rules/ directory',
+  'init.whatWasCreated': '\n📋 What was created:',
+  'init.created.config': '• {{path}} - Main configuration with core rules',
+  'init.created.structure': '• ./rules/structure.yaml - Structure validation rules',
+  'init.created.format': '• ./rules/format.yaml - Format validation rules',
+  'init.created.security': '• ./rules/security.yaml - Security validation rules',
+  'init.created.schema': '• ./rules/schema.yaml - Schema validation rules',
+  'init.nextSteps': '\n🎯 Next steps:',
+  'init.nextSteps.edit': '1. Edit praetorian.yaml to configure your validation',
+  'init.nextSteps.customize': '2. Customize rule files in ./rules/ directory',
+  'init.nextSteps.addFiles': '3. Add your configuration files to validate',
+  'init.nextSteps.run': '4. Run: praetorian validate',
+  'init.exampleUsage': '\n📖 Example usage:',
+  'init.devsecops.created': '✅ DevSecOps configuration created: {{path}}',
+  'init.devsecops.structureCreated': '✅ DevSecOps structure created in ./rules/ and ./environments/',
+  'init.devsecops.created.config': '• {{path}} - DevSecOps configuration template',
+  'init.devsecops.created.rulesDir': '• ./rules/ - Directory for team-specific rules',
+  'init.devsecops.created.security': '• ./rules/security.yaml - Security rules example',
+  'init.devsecops.created.compliance': '• ./rules/compliance.yaml - Compliance rules example',
+  'init.devsecops.created.environmentsDir': '• ./environments/ - Directory for environment-specific configs',
+  'init.devsecops.created.environments': '• ./environments/environments.yaml - Environment configuration template',
+  'init.devsecops.features': '\n🎯 DevSecOps Features:',
+  'init.devsecops.features.sources': '• Multi-source rule loading (core, local, remote, package, git)',
+  'init.devsecops.features.environments': '• Environment-specific configurations',
+  'init.devsecops.features.visibility': '• Team visibility and collaboration',
+  'init.devsecops.features.pipeline': '• Pipeline integration ready',
+  'init.devsecops.usage': '\n📖 DevSecOps Usage:',
+
+  // telemetry
+  'telemetry.enabled': 'Telemetry enabled. Only the counters shown by `praetorian telemetry show` are sent.',
+  'telemetry.disabled': 'Telemetry disabled and collected counters discarded.',
+  'telemetry.chosenByUserConfig': '`telemetry: {{enabled}}` in {{path}} decides whether telemetry is sent; change it there. This choice only applies once that setting is removed.',
+  'telemetry.status.enabled': 'enabled: {{enabled}}',
+  'telemetry.status.setBy': ' (set by {{path}})',
+  'telemetry.status.stateFile': 'state file: {{path}}',
+  'telemetry.status.endpoint': 'endpoint: {{endpoint}}',
+  'telemetry.status.noEndpoint': 'endpoint: none (set {{variable}} to send)',
+
+  // fix
+  'fix.summary': 'Fixed {{fixed}} of {{total}} file(s)',
+  'fix.summaryDryRun': 'Would fix {{fixed}} of {{total}} file(s)',
+  'fix.notFound': '{{file}}: not found',
+  'fix.byHand': '{{file}}: {{code}} on line(s) {{lines}} must be fixed by hand',
+  'fix.keyOrderByHand': '{{file}}: KEY_ORDER_VIOLATION cannot be fixed in {{format}} files, sort the keys by hand',
+  'fix.unparseable': '{{file}}: skipped, it cannot be parsed',
+  'fix.changesValues': '{{file}}: skipped, fixing would change its values',
+
+  // matrix, inventory, docs generate, snapshot
+  'matrix.title': 'Configuration matrix: {{group}}',
+  'matrix.written': 'Matrix of {{keys}} key(s) across {{files}} file(s) written to {{path}}',
+  'inventory.written': 'Inventory of {{files}} file(s) written to {{path}}',
+  'docs.written': 'Documentation of {{keys}} key(s) in {{files}} file(s) written to {{path}}',
+  'snapshot.written': 'Snapshot of {{files}} file(s) written to {{path}}',
+  'snapshot.matches': '✅ Configuration matches {{path}}',
+  'snapshot.diverges': '❌ Configuration diverges from {{path}}:',
+
+  // config migrate
+  'migrate.current': '{{path}} is already at schema version {{from}} (current: {{current}}).',
+  'migrate.migrated': 'Migrated {{path}} from schema version {{from}} to {{to}}:',
+
+  // Findings (rendered like message templates)
+  'finding.INSUFFICIENT_FILES': 'Need at least 2 files to compare',
+  'finding.MAX_DEPTH_EXCEEDED': 'Nesting deeper than {{maxDepth}} levels was truncated in {{file}}',
+  'finding.MAX_KEYS_EXCEEDED': 'Only the first {{maxKeys}} keys were analyzed in {{file}}',
+  'finding.MISSING_KEY': "Key '{{key}}' is missing in {{file}}",
+  'finding.REQUIRED_KEY_MISSING': "Required key '{{key}}' is missing in {{file}}",
+  'finding.EXTRA_KEY': "Key '{{key}}' in {{file}} is not in the reference {{reference}}",
+  'finding.VALUE_TYPE_MISMATCH': "Key '{{key}}' has different value types across files",
+  'finding.REFERENCE_NOT_FOUND': 'Reference file {{reference}} is not among the compared files',
+  'finding.EMPTY_KEY': "Key '{{key}}' has empty value in {{file}}",
+  'finding.CANARY_MISSING': "🚨 Canary key '{{key}}' was removed from {{file}}",
+  'finding.CANARY_MODIFIED': "🚨 Canary key '{{key}}' was modified in {{file}}",
+  'finding.ENVIRONMENT_LEAKAGE': "Value of '{{keyPath}}' in {{file}} ({{environment}}) looks like {{suspectedEnvironment}}: it mentions '{{token}}'",
+  'finding.ENDPOINT_DNS_FAILED': "Host of '{{key}}' in {{file}} does not resolve: {{url}}",
+  'finding.ENDPOINT_UNREACHABLE': "Endpoint '{{key}}' in {{file}} is unreachable: {{url}} ({{detail}})",
+  'finding.ENDPOINT_TLS_EXPIRED': "TLS certificate of '{{key}}' in {{file}} has expired: {{url}}",
+  'finding.ENDPOINT_REDIRECT_HOST': "Endpoint '{{key}}' in {{file}} redirects to another host: {{url}} -> {{location}}",
+  'finding.INVALID_VALUE_TYPE': "Value of '{{key}}' in {{file}} is not a valid {{type}}",
+  'finding.K8S_LIMIT_BELOW_REQUEST': "{{resourceName}} limit {{limit}} of container '{{container}}' in {{resource}} is below its request {{request}} ({{file}})",
+  'finding.K8S_PROBE_MISSING': "Container '{{container}}' in {{resource}} has no {{probe}} ({{file}})",
+  'finding.K8S_IMAGE_NOT_PINNED': "Image '{{image}}' of container '{{container}}' in {{resource}} is not pinned to a tag or digest ({{file}})",
+  'finding.K8S_REPLICAS_TOO_LOW': '{{resource}} runs {{replicas}} replica(s) in production; at least {{minimum}} are required ({{file}})',
+  'finding.IMAGE_DEFAULT_MISMATCH': "'{{variable}}' in {{file}} differs from the default baked into {{dockerfile}}",
+  'finding.CFN_PARAMETER_DEFAULT_INVALID': "Default of parameter '{{parameter}}' breaks its {{constraint}} constraint ({{file}})",
+  'finding.CFN_MAPPING_KEY_MISSING': "Mapping '{{mapping}}' has no '{{attribute}}' under '{{entry}}' while other entries define it ({{file}})",
+  'finding.CFN_UNDEFINED_REFERENCE': "{{intrinsic}} refers to '{{reference}}', which is neither a parameter nor a resource of the template ({{file}})",
+  'finding.SLS_STAGE_UNRESOLVED': "'{{key}}' does not resolve for stage '{{stage}}': ${{{variable}}} has no value ({{file}})",
+  'finding.SLS_STAGE_KEY_MISSING': "'{{key}}' is set for stage(s) {{presentIn}} but not for stage '{{stage}}' ({{file}})",
+  'finding.VAULT_POLICY_WILDCARD_GRANT': "Policy grants {{capabilities}} on '{{policyPath}}', which matches every path in Vault ({{file}})",
+  'finding.VAULT_POLICY_DENY_MISSING': "'{{policyPath}}' grants {{capabilities}} on {{prefix}}* and no rule denies it ({{file}})",
+  'finding.TF_PROVIDER_INLINE_CREDENTIAL': "'{{variable}}' of provider '{{provider}}' is written in the file; pass it through a variable or the environment ({{file}})",
+  'finding.TF_PROVIDER_TLS_DISABLED': "Provider '{{provider}}' skips TLS verification ('{{variable}}') ({{file}})",
+  'finding.IAM_WILDCARD_ACTION': "Statement '{{key}}' allows every action ({{file}})",
+  'finding.IAM_NOT_ACTION_ALLOW': "Statement '{{key}}' allows everything except its NotAction list, including actions AWS adds later ({{file}})",
+  'finding.IAM_WILDCARD_RESOURCE': "Statement '{{key}}' applies to every resource ({{file}})",
+  'finding.IAM_SENSITIVE_ACTION_UNCONDITIONED': "Statement '{{key}}' allows {{actions}} without a Condition ({{file}})",
+  'finding.OPENAPI_ENDPOINT_UNDOCUMENTED': "'{{endpoint}}' is exposed in {{environment}} but absent from {{specs}} ({{file}})",
+  'finding.OPENAPI_AUTH_WEAKENED': "'{{endpoint}}' requires {{auth}} auth in {{environment}} but {{expectedAuth}} in {{spec}} ({{file}})",
+  'finding.OPENAPI_SERVER_SHARED': "Server {{url}} of {{environment}} is also a server of {{spec}} ({{file}})",
+  'finding.FEATURE_FLAG_MISSING_IN_STAGING': "Flag '{{flag}}' is on in {{environment}} but missing from {{stagingEnvironment}} ({{file}})",
+  'finding.FEATURE_FLAG_OFF_IN_STAGING': "Flag '{{flag}}' is on in {{environment}} but off in {{stagingEnvironment}} ({{stagingFile}})",
+  'finding.FEATURE_FLAG_EXPIRED': "Flag '{{flag}}' expired on {{expiresAt}} and is still defined ({{file}})",
+  'finding.FEATURE_FLAG_ROLLOUT_OVER_THRESHOLD': "Flag '{{flag}}' rolls out to {{percentage}}% of users, above the {{threshold}}% threshold ({{file}})",
+  'finding.LOGGING_DEBUG_IN_PRODUCTION': "Logger '{{logger}}' logs at {{level}} in production ({{file}})",
+  'finding.LOGGING_FIELDS_UNREDACTED': "Sensitive fields {{fields}} are not in the redaction list ({{file}})",
+  'finding.LOGGING_FILE_ROTATION_MISSING': "Output '{{output}}' writes to a file that is never rotated ({{file}})",
+  'finding.MIGRATION_SETTING_DRIFT': "{{tool}} {{setting}} is {{value}} here but {{expected}} in {{expectedIn}} ({{file}})",
+  'finding.MIGRATION_CLEAN_NOT_DISABLED': "{{tool}} {{setting}} is not set to true in production ({{file}})",
+  'finding.MIGRATION_DESTRUCTIVE_OPTION': "{{tool}} {{setting}} is enabled in production and can drop the schema ({{file}})",
+  'finding.CORS_WILDCARD_CREDENTIALS': "CORS block '{{block}}' accepts any origin with credentials in production ({{file}})",
+  'finding.TLS_VERSION_WEAK': "'{{keyPath}}' accepts {{versions}}, older than {{minimum}} ({{file}})",
+  'finding.TLS_CIPHER_WEAK': "'{{keyPath}}' enables weak ciphers: {{ciphers}} ({{file}})",
+  'finding.SECURITY_HEADER_WEAK': "{{header}} at '{{keyPath}}' is weak: {{reason}} ({{file}})",
+  'finding.SECURITY_HEADER_DISABLED': "'{{keyPath}}' turns off {{header}} ({{file}})",
+  'finding.RATE_LIMIT_MISSING': "Public endpoint '{{endpoint}}' has no rate limit at '{{limitKey}}' ({{file}})",
+  'finding.RATE_LIMIT_BURST_EXCESSIVE': "Endpoint '{{endpoint}}' allows a burst of {{burst}}, more than {{factor}} times its limit of {{limit}} ({{file}})",
+  'finding.RATE_LIMIT_QUOTA_UNLIMITED': "Quota '{{quota}}' is unlimited in production ({{file}})",
+  'finding.TLS_ENCRYPTION_DISABLED': "'{{setting}}' is '{{value}}' at '{{keyPath}}' in production; expected {{expected}} ({{file}})",
+  'finding.TLS_VERIFICATION_DISABLED': "'{{setting}}' turns certificate verification off at '{{keyPath}}' in production; expected {{expected}} ({{file}})",
+  'finding.BROKER_ENVIRONMENT_MISMATCH': "'{{keyPath}}' points at {{suspectedEnvironment}} ('{{token}}') from a {{environment}} file ({{file}})",
+  'finding.BROKER_SECURITY_MISSING': "'{{keyPath}}' connects to {{broker}} without TLS in production; expected {{expected}} ({{file}})",
+  'finding.BROKER_CONSUMER_GROUP_NAMING': "Consumer group '{{group}}' does not match {{pattern}} ({{file}})",
+  'finding.BROKER_REPLICATION_TOO_LOW': "Replication factor {{replicationFactor}} at '{{keyPath}}' is below {{minimum}} in production ({{file}})",
+  'finding.CLOUD_IDENTIFIER_NOT_ALLOWED': "{{kind}} {{value}} at '{{keyPath}}' is not allowed in {{environment}} ({{file}})",
+  'finding.CLOUD_IDENTIFIER_SHARED': "{{kind}} {{value}} at '{{keyPath}}' is also used by {{otherEnvironments}}, not only {{environment}} ({{file}})",
+  'finding.CLOUD_IDENTIFIER_INCONSISTENT': "{{kind}} {{value}} at '{{keyPath}}' differs from the one the other {{environment}} files use ({{file}})",
+  'finding.INVALID_TIMEZONE': "'{{keyPath}}' is not an IANA time zone ({{file}})",
+  'finding.INVALID_LOCALE': "'{{keyPath}}' is not a BCP 47 language tag ({{file}})",
+  'finding.INVALID_CURRENCY': "'{{keyPath}}' is not an ISO 4217 currency code ({{file}})",
+  'finding.CRON_EXPRESSION_INVALID': "'{{keyPath}}' is not a valid cron expression: {{reason}} ({{file}})",
+  'finding.CRON_SCHEDULE_TOO_FREQUENT': "'{{keyPath}}' runs more often than every {{minIntervalMs}} ms in production ({{file}})",
+  'finding.DSN_INVALID': "'{{keyPath}}' is a malformed {{kind}} DSN: {{reason}} ({{file}})",
+  'finding.DSN_SHARED_WITH_PRODUCTION': "'{{keyPath}}' sends {{environment}} data to the production destination {{destination}} ({{file}})",
+  'finding.BUDGET_TOTAL_EXCEEDED': "Budget '{{budget}}' totals {{total}} across {{values}} value(s), above {{max}}; largest is '{{keyPath}}' in {{file}}",
+  'finding.BUDGET_VALUE_EXCEEDED': "'{{keyPath}}' is {{amount}}, above the {{max}} allowed by budget '{{budget}}' ({{file}})",
+  'finding.COMMENTED_OUT_CONFIG': "{{assignments}} settings are commented out at lines {{line}}-{{endLine}} of {{file}}",
+  'finding.COMMENTED_OUT_SECRET': "Commented-out block at lines {{line}}-{{endLine}} of {{file}} still sets {{secrets}}",
+  'finding.FORMAT_TRAILING_WHITESPACE': "{{file}} has trailing whitespace on {{count}} line(s), first at line {{line}}",
+  'finding.FORMAT_LINE_ENDINGS': "{{file}} has unexpected line endings on {{count}} line(s), first at line {{line}}",
+  'finding.FORMAT_MISSING_FINAL_NEWLINE': "{{file}} does not end with a newline",
+  'finding.FORMAT_TAB_INDENTATION': "{{file}} is indented with tabs on {{count}} line(s), first at line {{line}}",
+  'finding.FORMAT_MIXED_INDENTATION': "{{file}} mixes tabs and spaces in indentation on {{count}} line(s), first at line {{line}}",
+  'finding.KEY_ORDER_VIOLATION': "'{{keyPath}}' should come before '{{before}}' in {{file}}",
+  'finding.HOOK_LIMIT_EXCEEDED': "{{stage}} hook went over its {{limit}} limit of {{max}} and was killed: {{command}}",
+  'finding.SECRET_DETECTED': "{{secretName}} found in '{{keyPath}}' ({{maskedValue}}) of {{file}}",
+  'finding.HIGH_ENTROPY_VALUE': "'{{keyPath}}' holds a random-looking value ({{maskedValue}}, {{entropy}} bits per character) that may be a secret ({{file}})",
+  'finding.KEY_RENAMED': "Key '{{key}}' is missing in {{file}}, which has '{{renamed}}' instead: probably renamed ({{others}} keep '{{key}}')",
+  'finding.PERF_TIMEOUT_MISSING': "'{{key}}' connects to a remote service without a timeout; calls wait on it as long as the OS lets them ({{file}})",
+  'finding.PERF_TIMEOUT_UNBOUNDED': "'{{key}}' is {{value}}: calls wait forever on a peer that hangs ({{file}})",
+  'finding.PERF_POOL_EMPTY': "'{{key}}' is 0: the pool either holds no connection or, for some drivers, has no limit ({{file}})",
+  'finding.PERF_POOL_UNBOUNDED': "'{{key}}' is {{value}}: the pool can open connections until the server refuses them ({{file}})",
+  'finding.PERF_RETRIES_UNBOUNDED': "'{{key}}' is {{value}}: failed calls are retried forever ({{file}})",
+  'finding.PERF_CACHE_UNBOUNDED': "'{{key}}' is {{value}}: the cache grows until memory runs out ({{file}})",
+  'finding.PERF_VALUE_TOO_LOW': "'{{key}}' is {{value}}, below the {{bound}} required for {{category}} ({{file}})",
+  'finding.PERF_VALUE_TOO_HIGH': "'{{key}}' is {{value}}, above the {{bound}} allowed for {{category}} ({{file}})",
+  'finding.KEY_DIFFERENCE': "'{{key}}' has {{distinct}} different values across {{files}}",
+  'finding.FORBIDDEN_KEY': "Key '{{key}}' is forbidden by '{{pattern}}' ({{file}})",
+  'finding.RULE_TYPE_MISMATCH': "Value of '{{key}}' is not a valid {{type}} ({{location}})",
+  'finding.RULE_VALUE_NOT_ALLOWED': "Value of '{{key}}' is not one of {{allowed}} ({{location}})",
+  'finding.RULE_PATTERN_MISMATCH': "Value of '{{key}}' does not match /{{regex}}/ ({{location}})",
+  'finding.RULE_VALUE_TOO_LOW': "Value of '{{key}}' is {{value}}, below the minimum of {{bound}} ({{location}})",
+  'finding.RULE_VALUE_TOO_HIGH': "Value of '{{key}}' is {{value}}, above the maximum of {{bound}} ({{location}})",
+  'finding.WORKFLOW_PLAINTEXT_SECRET': "'{{variable}}' in {{section}} is written in plain text; use a secrets reference ({{file}})",
+  'finding.WORKFLOW_PERMISSIONS_MISSING': "Job '{{job}}' has no permissions block and neither has the workflow ({{file}})",
+  'finding.WORKFLOW_ACTION_NOT_PINNED': "'{{uses}}' in job '{{job}}' is not pinned to a full commit SHA ({{file}})",
+  'finding.PARSE_ERROR': '{{error}}'
+};
@@ -0,0 +1,225 @@
+/**
+ * Spanish message catalog
+ */
+export const es: Record<string, string> = {
+  // Ayuda de comandos
+  'command.validate.description': 'Valida que los archivos de configuración tengan claves consistentes',
+  'command.init.description': 'Inicializa un nuevo archivo de configuración de Praetorian',
+  'command.telemetry.description': 'Consulta o cambia la telemetría de uso anónima y opcional',
+  'command.batch.description': 'Valida trabajos leídos como JSON/NDJSON desde stdin, una línea de resultado por trabajo',
+  'command.inventory.description': 'Exporta un inventario (config-BOM) de todos los archivos configurados en JSON',
+  'command.snapshot.create.description': 'Registra los hashes y claves de los archivos configurados como estado de referencia',
+  'command.snapshot.verify.description': 'Falla cuando los archivos configurados difieren de una instantánea registrada',
+  'command.config.migrate.description': 'Actualiza praetorian.yaml a la versión actual del esquema',
+  'command.config.show.description': 'Muestra praetorian.yaml o, con --effective, la configuración resuelta: la configuración de usuario combinada por debajo y los entornos con marcadores expandidos, cada ajuste anotado con su origen (solo se muestran estas capas)',
+  'command.docs.generate.description': 'Genera documentación en Markdown de cada clave configurada, su tipo, entornos, ejemplo y reglas',
+  'command.risk.description': 'Clasifica por riesgo las claves de configuración cambiadas desde una revisión de git y las resume para la revisión',
+  'command.matrix.description': 'Muestra las claves de los archivos configurados como una matriz de claves × entornos en texto, HTML o CSV',
+  'command.fix.description': 'Corrige espacios finales, finales de línea, salto de línea final y sangría con tabuladores en los archivos de configuración, y ordena las claves cubiertas por key_order',
+
+  // validate
+  'validate.configNotFound': 'No se encontró el archivo de configuración: {{path}}',
+  'validate.createConfigHint': '\nCrea un archivo de configuración con:',
+  'validate.resultsTitle': '\n📊 Resultados de la validación:\n',
+  'validate.consistent': '✅ ¡Todos los archivos tienen claves consistentes!',
+  'validate.consistentDetail': '   Tus archivos de configuración están sincronizados entre entornos.',
+  'validate.inconsistent': '❌ Se encontraron inconsistencias de claves:',
+  'validate.inconsistentDetail': '   Faltan las siguientes claves en algunos archivos de configuración:',
+  'validate.pipelineTip': '\n💡 Consejo: usa --pipeline para una salida concisa en CI/CD',
+  'validate.warnings': '\n⚠️  {{count}} advertencia(s):',
+  'validate.emptyKeys': '\nℹ️  {{count}} clave(s) vacía(s) (informativo):',
+  'validate.emptyKeysNote': '    Nota: las claves vacías son solo informativas y no afectan el resultado',
+  'validate.summary': '\n📈 Resumen:',
+  'validate.summary.files': '  • Archivos comparados: {{count}}',
+  'validate.summary.keys': '  • Claves totales: {{count}}',
+  'validate.summary.emptyKeys': '  • Claves vacías: {{count}}',
+  'validate.summary.duration': '  • Duración: {{duration}}ms',
+  'validate.groups': '\n🗂️  Grupos:',
+  'validate.group': '  • {{name}}: {{files}} archivos, {{errors}} errores, {{warnings}} advertencias',
+  'validate.owners': '\n👥 Responsables:',
+  'validate.owner': '  • {{team}}{{channel}}: {{errors}} errores, {{warnings}} advertencias',
+  'validate.interrupted': '\n⛔ Interrumpido por {{signal}}: los resultados anteriores son parciales',
+  'validate.performance': '\n⏱️  Rendimiento:',
+  'validate.performance.total': '  • Total: {{duration}}ms',
+  'validate.performance.file': '  • análisis {{file}} ({{format}}): {{duration}}ms',
+  'validate.performance.rule': '  • regla {{rule}}: {{duration}}ms',
+  'validate.success': '\n🎉 ¡Validación completada con éxito!',
+  'validate.failure': '\n🔧 Corrige las inconsistencias anteriores y vuelve a validar.',
+  'validate.overflow': '…y {{count}} {{noun}} {{code}} más{{files}}',
+  'validate.overflow.file': ' en {{count}} archivo',
+  'validate.overflow.files': ' en {{count}} archivos',
+  'validate.noun.error': 'errores',
+  'validate.noun.warning': 'advertencias',
+  'validate.noun.info': 'avisos',
+
+  // init
+  'init.exists': '⚠️  El archivo de configuración ya existe: {{path}}',
+  'init.useConfigFlag': 'Usa --config para indicar otra ruta',
+  'init.created': '✅ Archivo de configuración creado: {{path}}',
+  'init.rulesCreated': '✅ Archivos de reglas de ejemplo creados en ./rules/',
+  'init.whatWasCreated': '\n📋 Qué se creó:',
+  'init.created.config': '• {{path}} - Configuración principal con las reglas base',
+  'init.created.structure': '• ./rules/structure.yaml - Reglas de estructura',
+  'init.created.format': '• ./rules/format.yaml - Reglas de formato',
+  'init.created.security': '• ./rules/security.yaml - Reglas de seguridad',
+  'init.created.schema': '• ./rules/schema.yaml - Reglas de esquema',
+  'init.nextSteps': '\n🎯 Próximos pasos:',
+  'init.nextSteps.edit': '1. Edita praetorian.yaml para configurar la validación',
+  'init.nextSteps.customize': '2. Ajusta los archivos de reglas en ./rules/',
+  'init.nextSteps.addFiles': '3. Agrega los archivos de configuración a validar',
+  'init.nextSteps.run': '4. Ejecuta: praetorian validate',
+  'init.exampleUsage': '\n📖 Ejemplos de uso:',
+  'init.devsecops.created': '✅ Configuración DevSecOps creada: {{path}}',
+  'init.devsecops.structureCreated': '✅ Estructura DevSecOps creada en ./rules/ y ./environments/',
+  'init.devsecops.created.config': '• {{path}} - Plantilla de configuración DevSecOps',
+  'init.devsecops.created.rulesDir': '• ./rules/ - Directorio para reglas del equipo',
+  'init.devsecops.created.security': '• ./rules/security.yaml - Ejemplo de reglas de seguridad',
+  'init.devsecops.created.compliance': '• ./rules/compliance.yaml - Ejemplo de reglas de cumplimiento',
+  'init.devsecops.created.environmentsDir': '• ./environments/ - Directorio para configuraciones por entorno',
+  'init.devsecops.created.environments': '• ./environments/environments.yaml - Plantilla de entornos',
+  'init.devsecops.features': '\n🎯 Funcionalidades DevSecOps:',
+  'init.devsecops.features.sources': '• Carga de reglas desde varias fuentes (core, local, remote, package, git)',
+  'init.devsecops.features.environments': '• Configuraciones por entorno',
+  'init.devsecops.features.visibility': '• Visibilidad y colaboración entre equipos',
+  'init.devsecops.features.pipeline': '• Listo para integrarse en pipelines',
+  'init.devsecops.usage': '\n📖 Uso DevSecOps:',
+
+  // telemetry
+  'telemetry.enabled': 'Telemetría activada. Solo se envían los contadores que muestra `praetorian telemetry show`.',
+  'telemetry.disabled': 'Telemetría desactivada y contadores recogidos descartados.',
+  'telemetry.chosenByUserConfig': '`telemetry: {{enabled}}` en {{path}} decide si se envía telemetría; cámbialo allí. Esta elección solo se aplica cuando se quite ese ajuste.',
+  'telemetry.status.enabled': 'activada: {{enabled}}',
+  'telemetry.status.setBy': ' (fijada por {{path}})',
+  'telemetry.status.stateFile': 'archivo de estado: {{path}}',
+  'telemetry.status.endpoint': 'endpoint: {{endpoint}}',
+  'telemetry.status.noEndpoint': 'endpoint: ninguno (define {{variable}} para enviar)',
+
+  // fix
+  'fix.summary': 'Corregidos {{fixed}} de {{total}} archivo(s)',
+  'fix.summaryDryRun': 'Se corregirían {{fixed}} de {{total}} archivo(s)',
+  'fix.notFound': '{{file}}: no encontrado',
+  'fix.byHand': '{{file}}: {{code}} en la(s) línea(s) {{lines}} debe corregirse a mano',
+  'fix.keyOrderByHand': '{{file}}: KEY_ORDER_VIOLATION no se puede corregir en archivos {{format}}, ordena las claves a mano',
+  'fix.unparseable': '{{file}}: omitido, no se puede analizar',
+  'fix.changesValues': '{{file}}: omitido, corregirlo cambiaría sus valores',
+
+  // matrix, inventory, docs generate, snapshot
+  'matrix.title': 'Matriz de configuración: {{group}}',
+  'matrix.written': 'Matriz de {{keys}} clave(s) en {{files}} archivo(s) escrita en {{path}}',
+  'inventory.written': 'Inventario de {{files}} archivo(s) escrito en {{path}}',
+  'docs.written': 'Documentación de {{keys}} clave(s) en {{files}} archivo(s) escrita en {{path}}',
+  'snapshot.written': 'Instantánea de {{files}} archivo(s) escrita en {{path}}',
+  'snapshot.matches': '✅ La configuración coincide con {{path}}',
+  'snapshot.diverges': '❌ La configuración difiere de {{path}}:',
+
+  // config migrate
+  'migrate.current': '{{path}} ya está en la versión de esquema {{from}} (actual: {{current}}).',
+  'migrate.migrated': '{{path}} migrado de la versión de esquema {{from}} a {{to}}:',
+
+  // Hallazgos
+  'finding.INSUFFICIENT_FILES': 'Se necesitan al menos 2 archivos para comparar',
+  'finding.MAX_DEPTH_EXCEEDED': 'Se truncó el anidamiento de más de {{maxDepth}} niveles en {{file}}',
+  'finding.MAX_KEYS_EXCEEDED': 'Solo se analizaron las primeras {{maxKeys}} claves de {{file}}',
+  'finding.MISSING_KEY': "Falta la clave '{{key}}' en {{file}}",
+  'finding.REQUIRED_KEY_MISSING': "Falta la clave obligatoria '{{key}}' en {{file}}",
+  'finding.EXTRA_KEY': "La clave '{{key}}' de {{file}} no está en la referencia {{reference}}",
+  'finding.VALUE_TYPE_MISMATCH': "La clave '{{key}}' tiene tipos de valor distintos entre archivos",
+  'finding.REFERENCE_NOT_FOUND': 'El archivo de referencia {{reference}} no está entre los archivos comparados',
+  'finding.EMPTY_KEY': "La clave '{{key}}' tiene un valor vacío en {{file}}",
+  'finding.CANARY_MISSING': "🚨 La clave canario '{{key}}' fue eliminada de {{file}}",
+  'finding.CANARY_MODIFIED': "🚨 La clave canario '{{key}}' fue modificada en {{file}}",
+  'finding.ENVIRONMENT_LEAKAGE': "El valor de '{{keyPath}}' en {{file}} ({{environment}}) parece de {{suspectedEnvironment}}: menciona '{{token}}'",
+  'finding.ENDPOINT_DNS_FAILED': "El host de '{{key}}' en {{file}} no se resuelve: {{url}}",
+  'finding.ENDPOINT_UNREACHABLE': "El endpoint '{{key}}' en {{file}} no es accesible: {{url}} ({{detail}})",
+  'finding.ENDPOINT_TLS_EXPIRED': "El certificado TLS de '{{key}}' en {{file}} ha expirado: {{url}}",
+  'finding.ENDPOINT_REDIRECT_HOST': "El endpoint '{{key}}' en {{file}} redirige a otro host: {{url}} -> {{location}}",
+  'finding.INVALID_VALUE_TYPE': "El valor de '{{key}}' en {{file}} no es un {{type}} válido",
+  'finding.K8S_LIMIT_BELOW_REQUEST': "El límite de {{resourceName}} {{limit}} del contenedor '{{container}}' en {{resource}} es menor que su request {{request}} ({{file}})",
+  'finding.K8S_PROBE_MISSING': "El contenedor '{{container}}' en {{resource}} no tiene {{probe}} ({{file}})",
+  'finding.K8S_IMAGE_NOT_PINNED': "La imagen '{{image}}' del contenedor '{{container}}' en {{resource}} no está fijada a un tag o digest ({{file}})",
+  'finding.K8S_REPLICAS_TOO_LOW': '{{resource}} ejecuta {{replicas}} réplica(s) en producción; se requieren al menos {{minimum}} ({{file}})',
+  'finding.IMAGE_DEFAULT_MISMATCH': "'{{variable}}' en {{file}} difiere del valor por defecto incluido en {{dockerfile}}",
+  'finding.CFN_PARAMETER_DEFAULT_INVALID': "El Default del parámetro '{{parameter}}' incumple su restricción {{constraint}} ({{file}})",
+  'finding.CFN_MAPPING_KEY_MISSING': "El mapping '{{mapping}}' no tiene '{{attribute}}' bajo '{{entry}}' mientras otras entradas lo definen ({{file}})",
+  'finding.CFN_UNDEFINED_REFERENCE': "{{intrinsic}} hace referencia a '{{reference}}', que no es un parámetro ni un recurso de la plantilla ({{file}})",
+  'finding.SLS_STAGE_UNRESOLVED': "'{{key}}' no se resuelve para el stage '{{stage}}': ${{{variable}}} no tiene valor ({{file}})",
+  'finding.SLS_STAGE_KEY_MISSING': "'{{key}}' está definido para los stages {{presentIn}} pero no para el stage '{{stage}}' ({{file}})",
+  'finding.VAULT_POLICY_WILDCARD_GRANT': "La política concede {{capabilities}} sobre '{{policyPath}}', que abarca todas las rutas de Vault ({{file}})",
+  'finding.VAULT_POLICY_DENY_MISSING': "'{{policyPath}}' concede {{capabilities}} sobre {{prefix}}* y ninguna regla lo deniega ({{file}})",
+  'finding.TF_PROVIDER_INLINE_CREDENTIAL': "'{{variable}}' del provider '{{provider}}' está escrito en el archivo; pásalo por una variable o el entorno ({{file}})",
+  'finding.TF_PROVIDER_TLS_DISABLED': "El provider '{{provider}}' omite la verificación TLS ('{{variable}}') ({{file}})",
+  'finding.IAM_WILDCARD_ACTION': "El statement '{{key}}' permite todas las acciones ({{file}})",
+  'finding.IAM_NOT_ACTION_ALLOW': "El statement '{{key}}' permite todo salvo su lista NotAction, incluidas las acciones que AWS añada más adelante ({{file}})",
+  'finding.IAM_WILDCARD_RESOURCE': "El statement '{{key}}' se aplica a todos los recursos ({{file}})",
+  'finding.IAM_SENSITIVE_ACTION_UNCONDITIONED': "El statement '{{key}}' permite {{actions}} sin Condition ({{file}})",
+  'finding.OPENAPI_ENDPOINT_UNDOCUMENTED': "'{{endpoint}}' está expuesto en {{environment}} pero no aparece en {{specs}} ({{file}})",
+  'finding.OPENAPI_AUTH_WEAKENED': "'{{endpoint}}' exige autenticación {{auth}} en {{environment}} pero {{expectedAuth}} en {{spec}} ({{file}})",
+  'finding.OPENAPI_SERVER_SHARED': "El servidor {{url}} de {{environment}} también es servidor de {{spec}} ({{file}})",
+  'finding.FEATURE_FLAG_MISSING_IN_STAGING': "El flag '{{flag}}' está activo en {{environment}} pero no existe en {{stagingEnvironment}} ({{file}})",
+  'finding.FEATURE_FLAG_OFF_IN_STAGING': "El flag '{{flag}}' está activo en {{environment}} pero apagado en {{stagingEnvironment}} ({{stagingFile}})",
+  'finding.FEATURE_FLAG_EXPIRED': "El flag '{{flag}}' venció el {{expiresAt}} y sigue definido ({{file}})",
+  'finding.FEATURE_FLAG_ROLLOUT_OVER_THRESHOLD': "El flag '{{flag}}' se despliega al {{percentage}}% de los usuarios, por encima del umbral del {{threshold}}% ({{file}})",
+  'finding.LOGGING_DEBUG_IN_PRODUCTION': "El logger '{{logger}}' registra en nivel {{level}} en producción ({{file}})",
+  'finding.LOGGING_FIELDS_UNREDACTED': "Los campos sensibles {{fields}} no están en la lista de enmascarado ({{file}})",
+  'finding.LOGGING_FILE_ROTATION_MISSING': "La salida '{{output}}' escribe en un archivo que nunca se rota ({{file}})",
+  'finding.MIGRATION_SETTING_DRIFT': "{{tool}} {{setting}} vale {{value}} aquí pero {{expected}} en {{expectedIn}} ({{file}})",
+  'finding.MIGRATION_CLEAN_NOT_DISABLED': "{{tool}} {{setting}} no está en true en producción ({{file}})",
+  'finding.MIGRATION_DESTRUCTIVE_OPTION': "{{tool}} {{setting}} está activado en producción y puede borrar el esquema ({{file}})",
+  'finding.CORS_WILDCARD_CREDENTIALS': "El bloque CORS '{{block}}' acepta cualquier origen con credenciales en producción ({{file}})",
+  'finding.TLS_VERSION_WEAK': "'{{keyPath}}' acepta {{versions}}, anteriores a {{minimum}} ({{file}})",
+  'finding.TLS_CIPHER_WEAK': "'{{keyPath}}' habilita cifrados débiles: {{ciphers}} ({{file}})",
+  'finding.SECURITY_HEADER_WEAK': "{{header}} en '{{keyPath}}' es débil: {{reason}} ({{file}})",
+  'finding.SECURITY_HEADER_DISABLED': "'{{keyPath}}' desactiva {{header}} ({{file}})",
+  'finding.RATE_LIMIT_MISSING': "El endpoint público '{{endpoint}}' no tiene límite de peticiones en '{{limitKey}}' ({{file}})",
+  'finding.RATE_LIMIT_BURST_EXCESSIVE': "El endpoint '{{endpoint}}' permite una ráfaga de {{burst}}, más de {{factor}} veces su límite de {{limit}} ({{file}})",
+  'finding.RATE_LIMIT_QUOTA_UNLIMITED': "La cuota '{{quota}}' es ilimitada en producción ({{file}})",
+  'finding.TLS_ENCRYPTION_DISABLED': "'{{setting}}' vale '{{value}}' en '{{keyPath}}' en producción; se esperaba {{expected}} ({{file}})",
+  'finding.TLS_VERIFICATION_DISABLED': "'{{setting}}' desactiva la verificación de certificados en '{{keyPath}}' en producción; se esperaba {{expected}} ({{file}})",
+  'finding.BROKER_ENVIRONMENT_MISMATCH': "'{{keyPath}}' apunta a {{suspectedEnvironment}} ('{{token}}') desde un archivo de {{environment}} ({{file}})",
+  'finding.BROKER_SECURITY_MISSING': "'{{keyPath}}' se conecta a {{broker}} sin TLS en producción; se esperaba {{expected}} ({{file}})",
+  'finding.BROKER_CONSUMER_GROUP_NAMING': "El grupo de consumidores '{{group}}' no cumple {{pattern}} ({{file}})",
+  'finding.BROKER_REPLICATION_TOO_LOW': "El factor de replicación {{replicationFactor}} en '{{keyPath}}' es menor que {{minimum}} en producción ({{file}})",
+  'finding.CLOUD_IDENTIFIER_NOT_ALLOWED': "{{kind}} {{value}} en '{{keyPath}}' no está permitido en {{environment}} ({{file}})",
+  'finding.CLOUD_IDENTIFIER_SHARED': "{{kind}} {{value}} en '{{keyPath}}' también lo usa {{otherEnvironments}}, no solo {{environment}} ({{file}})",
+  'finding.CLOUD_IDENTIFIER_INCONSISTENT': "{{kind}} {{value}} en '{{keyPath}}' difiere del que usan los demás archivos de {{environment}} ({{file}})",
+  'finding.INVALID_TIMEZONE': "'{{keyPath}}' no es una zona horaria IANA ({{file}})",
+  'finding.INVALID_LOCALE': "'{{keyPath}}' no es una etiqueta de idioma BCP 47 ({{file}})",
+  'finding.INVALID_CURRENCY': "'{{keyPath}}' no es un código de moneda ISO 4217 ({{file}})",
+  'finding.CRON_EXPRESSION_INVALID': "'{{keyPath}}' no es una expresión cron válida: {{reason}} ({{file}})",
+  'finding.CRON_SCHEDULE_TOO_FREQUENT': "'{{keyPath}}' se ejecuta con más frecuencia que cada {{minIntervalMs}} ms en producción ({{file}})",
+  'finding.DSN_INVALID': "'{{keyPath}}' es un DSN de {{kind}} mal formado: {{reason}} ({{file}})",
+  'finding.DSN_SHARED_WITH_PRODUCTION': "'{{keyPath}}' envía datos de {{environment}} al destino de producción {{destination}} ({{file}})",
+  'finding.BUDGET_TOTAL_EXCEEDED': "El presupuesto '{{budget}}' suma {{total}} en {{values}} valor(es), por encima de {{max}}; el mayor es '{{keyPath}}' en {{file}}",
+  'finding.BUDGET_VALUE_EXCEEDED': "'{{keyPath}}' vale {{amount}}, por encima de los {{max}} que permite el presupuesto '{{budget}}' ({{file}})",
+  'finding.COMMENTED_OUT_CONFIG': "Hay {{assignments}} ajustes comentados en las líneas {{line}}-{{endLine}} de {{file}}",
+  'finding.COMMENTED_OUT_SECRET': "El bloque comentado de las líneas {{line}}-{{endLine}} de {{file}} aún define {{secrets}}",
+  'finding.FORMAT_TRAILING_WHITESPACE': "{{file}} tiene espacios al final de {{count}} línea(s), la primera en la línea {{line}}",
+  'finding.FORMAT_LINE_ENDINGS': "{{file}} tiene finales de línea inesperados en {{count}} línea(s), la primera en la línea {{line}}",
+  'finding.FORMAT_MISSING_FINAL_NEWLINE': "{{file}} no termina con un salto de línea",
+  'finding.FORMAT_TAB_INDENTATION': "{{file}} usa tabuladores para sangrar {{count}} línea(s), la primera en la línea {{line}}",
+  'finding.FORMAT_MIXED_INDENTATION': "{{file}} mezcla tabuladores y espacios en la sangría de {{count}} línea(s), la primera en la línea {{line}}",
+  'finding.KEY_ORDER_VIOLATION': "'{{keyPath}}' debería ir antes de '{{before}}' en {{file}}",
+  'finding.HOOK_LIMIT_EXCEEDED': "El hook {{stage}} superó su límite de {{limit}} de {{max}} y se detuvo: {{command}}",
+  'finding.SECRET_DETECTED': "Se encontró {{secretName}} en '{{keyPath}}' ({{maskedValue}}) de {{file}}",
+  'finding.HIGH_ENTROPY_VALUE': "'{{keyPath}}' contiene un valor de aspecto aleatorio ({{maskedValue}}, {{entropy}} bits por carácter) que podría ser un secreto ({{file}})",
+  'finding.KEY_RENAMED': "Falta la clave '{{key}}' en {{file}}, que tiene '{{renamed}}' en su lugar: probablemente renombrada ({{others}} mantienen '{{key}}')",
+  'finding.PERF_TIMEOUT_MISSING': "'{{key}}' se conecta a un servicio remoto sin timeout; las llamadas esperan tanto como lo permita el sistema operativo ({{file}})",
+  'finding.PERF_TIMEOUT_UNBOUNDED': "'{{key}}' vale {{value}}: las llamadas esperan para siempre a un servicio que no responde ({{file}})",
+  'finding.PERF_POOL_EMPTY': "'{{key}}' vale 0: el pool no tiene ninguna conexión o, según el driver, no tiene límite ({{file}})",
+  'finding.PERF_POOL_UNBOUNDED': "'{{key}}' vale {{value}}: el pool puede abrir conexiones hasta que el servidor las rechace ({{file}})",
+  'finding.PERF_RETRIES_UNBOUNDED': "'{{key}}' vale {{value}}: las llamadas fallidas se reintentan para siempre ({{file}})",
+  'finding.PERF_CACHE_UNBOUNDED': "'{{key}}' vale {{value}}: la caché crece hasta agotar la memoria ({{file}})",
+  'finding.PERF_VALUE_TOO_LOW': "'{{key}}' vale {{value}}, por debajo del mínimo de {{bound}} para {{category}} ({{file}})",
+  'finding.PERF_VALUE_TOO_HIGH': "'{{key}}' vale {{value}}, por encima del máximo de {{bound}} para {{category}} ({{file}})",
+  'finding.KEY_DIFFERENCE': "'{{key}}' tiene {{distinct}} valores distintos entre {{files}}",
+  'finding.FORBIDDEN_KEY': "La clave '{{key}}' está prohibida por '{{pattern}}' ({{file}})",
+  'finding.RULE_TYPE_MISMATCH': "El valor de '{{key}}' no es un {{type}} válido ({{location}})",
+  'finding.RULE_VALUE_NOT_ALLOWED': "El valor de '{{key}}' no es uno de {{allowed}} ({{location}})",
+  'finding.RULE_PATTERN_MISMATCH': "El valor de '{{key}}' no coincide con /{{regex}}/ ({{location}})",
+  'finding.RULE_VALUE_TOO_LOW': "El valor de '{{key}}' es {{value}}, por debajo del mínimo de {{bound}} ({{location}})",
+  'finding.RULE_VALUE_TOO_HIGH': "El valor de '{{key}}' es {{value}}, por encima del máximo de {{bound}} ({{location}})",
+  'finding.WORKFLOW_PLAINTEXT_SECRET': "'{{variable}}' en {{section}} está escrito en texto plano; usa una referencia a secrets ({{file}})",
+  'finding.WORKFLOW_PERMISSIONS_MISSING': "El job '{{job}}' no tiene bloque permissions y el workflow tampoco ({{file}})",
+  'finding.WORKFLOW_ACTION_NOT_PINNED': "'{{uses}}' en el job '{{job}}' no está fijado a un SHA de commit completo ({{file}})",
+  'finding.PARSE_ERROR': 'No se pudo leer {{file}}: {{error}}'
+};
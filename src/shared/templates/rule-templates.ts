@@ -10,6 +10,9 @@
 export const DEFAULT_PRAETORIAN_CONFIG = `# Praetorian Configuration
 # This file defines validation rules for your configuration files
 
+# Schema version of this file (upgrade with: praetorian config migrate)
+version: 1
+
 # Configuration files to validate
 files:
   - config-dev.yaml
@@ -10,14 +10,129 @@ export interface ValidationResult {
   warnings: ValidationWarning[];
   info?: ValidationInfo[]; // Nueva sección para información (claves vacías)
   results?: any[]; // Detailed results for each validation
-  metadata?: {
-    duration?: number;
-    rulesChecked?: number;
-    rulesPassed?: number;
-    rulesFailed?: number;
-    filesCompared?: number;
-    [key: string]: any;
-  };
+  metadata?: ValidationMetadata;
+  /** One section per `groups:` entry when files are compared in groups */
+  groups?: GroupSummary[];
+  /** Findings per team when `owners:` is configured */
+  owners?: OwnerSummary[];
+}
+
+/**
+ * Files that are compared among themselves only (a `groups:` entry)
+ */
+export interface AuditGroup {
+  name: string;
+  files: string[];
+}
+
+/**
+ * Outcome of comparing the files of one group
+ */
+export interface GroupSummary {
+  name: string;
+  files: string[];
+  success: boolean;
+  errors: number;
+  warnings: number;
+  info: number;
+}
+
+/**
+ * Findings routed to one team of `owners:`
+ */
+export interface OwnerSummary {
+  team: string;
+  /** Where the team is notified, e.g. a Slack channel */
+  channel?: string;
+  errors: number;
+  warnings: number;
+  info: number;
+}
+
+/**
+ * Typed summary of a validation or audit run (stable JSON contract).
+ * Plugins put anything else under `extensions`.
+ */
+export interface ValidationMetadata {
+  duration?: number;
+  filesCompared?: number;
+  totalKeys?: number;
+  ignoredKeys?: number;
+  requiredKeys?: number;
+  emptyKeys?: number;
+  rulesChecked?: number;
+  rulesPassed?: number;
+  rulesFailed?: number;
+  pluginsChecked?: number;
+  strict?: boolean;
+  /** Plugin that produced the result */
+  plugin?: string;
+  version?: string;
+  /** Auditor that produced the result (security, compliance, performance) */
+  auditType?: string;
+  /** Per-auditor breakdown of an aggregated audit */
+  auditors?: AuditorSummary[];
+  performance?: PerformanceMetadata;
+  /** Failure that aborted the run */
+  error?: string;
+  /** The run was stopped by SIGINT/SIGTERM; findings are partial */
+  interrupted?: boolean;
+  /** Free-form data contributed by plugins */
+  extensions?: Record<string, unknown>;
+}
+
+/**
+ * Checks run by one auditor within an audit
+ */
+export interface AuditorSummary {
+  auditType: string;
+  rulesChecked: number;
+  rulesPassed: number;
+  rulesFailed: number;
+  errors: number;
+  warnings: number;
+}
+
+/**
+ * Timing breakdown of a run, in milliseconds
+ */
+export interface PerformanceMetadata {
+  totalMs: number;
+  files: Array<{ path: string; format: string; parseMs: number }>;
+  rules: Array<{ id: string; evaluationMs: number }>;
+}
+
+/**
+ * Context of a finding. Standard fields mean the same for every rule, so consumers
+ * don't guess rule-specific keys; anything else goes to `extras`.
+ */
+export interface FindingContext {
+  /** File the finding was found in */
+  file?: string;
+  /** Files sharing the finding (aggregated findings) */
+  files?: string[];
+  environment?: string;
+  /** Audit group the finding was found in */
+  group?: string;
+  /** Dotted key path the finding is about (missing, extra, required or empty key) */
+  keyPath?: string;
+  /** Value found in the file; hidden by the redaction policy */
+  observedValue?: unknown;
+  /** Value or pattern the rule expected; hidden by the redaction policy */
+  expectedValue?: unknown;
+  /** Rule (and plugin) that produced the finding */
+  rule?: { id: string; plugin?: string };
+  /** Position in the file */
+  line?: number;
+  column?: number;
+  /** Labels of the file(s) the finding was found in */
+  labels?: Record<string, string>;
+  /** Team of `owners:` the finding is routed to */
+  owner?: string;
+  /** Severity before an `escalate:` rule changed it */
+  escalatedFrom?: ValidationSeverity;
+  /** Rule-specific data, e.g. availableKeys or maxDepth */
+  extras?: Record<string, unknown>;
 }
 
 export interface ValidationError {
@@ -25,7 +140,7 @@ export interface ValidationError {
   message: string;
   severity: 'error' | 'warning' | 'info';
   path?: string;
-  context?: any;
+  context?: FindingContext;
 }
 
 export interface ValidationWarning {
@@ -33,7 +148,7 @@ export interface ValidationWarning {
   message: string;
   severity: 'warning';
   path?: string;
-  context?: any;
+  context?: FindingContext;
 }
 
 export interface ValidationInfo {
@@ -41,7 +156,7 @@ export interface ValidationInfo {
   message: string;
   severity: 'info';
   path?: string;
-  context?: any;
+  context?: FindingContext;
 }
 
 export interface ValidationRule {
@@ -55,26 +170,530 @@ export interface ValidationRule {
   execute(files: ConfigFile[]): Promise<ValidationResult>;
 }
 
+/**
+ * A comment kept by the file reader: its 1-based line and its text without the comment marker
+ */
+export interface ConfigComment {
+  line: number;
+  text: string;
+}
+
 export interface ConfigFile {
   path: string;
   content: Record<string, any>;
   format: string; // Support for all file formats: yaml, json, env, toml, ini, xml
   environment?: string;
+  /** Labels of `labels:` and `files:` entries */
+  labels?: Record<string, string>;
+  /** Full-line and block comments, when the reader was asked to retain them */
+  comments?: ConfigComment[];
   metadata?: {
     size?: number;
     lastModified?: Date;
     encoding?: string;
+    parseMs?: number;
+    /** Read as a key tree in bounded memory, with large values replaced by their digest */
+    streamed?: boolean;
   };
 }
 
+/**
+ * How keys are compared across files (see domain/rules/ComparisonStrategies)
+ */
+export type ComparisonStrategyName = 'strict' | 'subset-of-reference' | 'symmetric' | 'value-aware';
+
+export interface ComparisonSettings {
+  strategy?: ComparisonStrategyName;
+  /** Reference file for subset-of-reference and symmetric; defaults to the first file */
+  reference?: string;
+}
+
+/**
+ * A `files:` entry: a path (or glob), optionally with an explicit parser format
+ */
+export type FileEntry = string | { path: string; format?: string; labels?: Record<string, string> };
+
 export interface PraetorianConfig {
-  files?: string[];
+  /** Schema version; older files are upgraded with `praetorian config migrate` */
+  version?: number | string;
+  files?: FileEntry[];
   ignore_keys?: string[];
   required_keys?: string[];
   schema?: Record<string, string>;
   patterns?: Record<string, string>;
-  forbidden_keys?: string[];
+  /** Key pattern (`*` matches one segment) -> constraints on its values; a list belongs to the rule system */
+  rules?: Record<string, ValueConstraint> | unknown[];
+  /** Rule sources of the rule system; the rule pack ids among them select the packs validate runs */
+  ruleSets?: string[];
+  /** Key patterns no file may set: a pattern, or patterns forbidden in some environments only */
+  forbidden_keys?: Array<string | ForbiddenKeysEntry>;
   environments?: Record<string, string>;
+  formats?: Record<string, string>;
+  /** Formats whose parsers are used, so that files of any other format are refused (every format by default) */
+  parsers?: string[];
+  limits?: {
+    max_depth?: number;
+    max_keys?: number;
+    /** JSON and YAML files above this size are streamed into their key tree (default 64) */
+    stream_above_mb?: number;
+  };
+  escalate?: EscalationConfig | EscalationConfig[];
+  messages?: Record<string, string>;
+  comparison?: ComparisonStrategyName | ComparisonSettings;
+  /** Group name -> files compared among themselves only */
+  groups?: Record<string, FileEntry[]>;
+  /** Files and groups each rule or auditor runs on */
+  scopes?: RuleScope | RuleScope[];
+  /** How raw configuration values are hidden in findings and output */
+  redaction?: RedactionPolicyName | { policy?: RedactionPolicyName; secret_keys?: string[] };
+  /** Flag values that belong to another environment (true for the default tokens) */
+  leakage?: boolean | { enabled?: boolean; tokens?: Record<string, string[]> };
+  /** Feature flag export checks */
+  feature_flags?: { rollout_threshold?: number };
+  /** Environment name -> cloud regions, accounts, projects and subscriptions it may use */
+  cloud_identifiers?: Record<string, CloudAllowList>;
+  /** Kafka and RabbitMQ checks */
+  brokers?: { consumer_group_pattern?: string; min_replication_factor?: number };
+  /** Scheduler keys holding cron expressions, and the shortest interval production accepts */
+  cron?: { keys?: string | string[]; min_interval?: string };
+  /** Key paths of the rate limits and quotas checked by the rate limit rules */
+  rate_limits?: {
+    endpoints?: string | string[];
+    public?: string;
+    limit?: string;
+    burst?: string;
+    burst_factor?: number;
+    quotas?: string | string[];
+  };
+  /** Honeytoken keys that must stay present and unmodified */
+  canaries?: Canary | Canary[];
+  /** Numeric values summed per environment or capped one by one */
+  budgets?: Budget | Budget[];
+  /** Keys whose values must match across the compared files, with the severity of a difference */
+  value_differences?: Array<{
+    /** Key path patterns (`*` matches one segment); a pattern also covers the keys below it */
+    keys: string | string[];
+    /** Severity of a difference (default warning) */
+    severity?: ValidationSeverity;
+  }>;
+  /** Performance foot-gun checks: the keys of each category and the bounds of their values */
+  performance?: {
+    timeouts?: PerformanceBoundsConfig & {
+      /** Report client sections (with a `url`, `endpoint`, `dsn`, ...) that set no timeout (default true) */
+      required?: boolean;
+    };
+    pools?: PerformanceBoundsConfig;
+    retries?: PerformanceBoundsConfig;
+    caches?: PerformanceBoundsConfig;
+  };
+  /** Thresholds of commented-out configuration blocks */
+  commented_config?: {
+    /** Comment lines a block needs to be reported (default 3) */
+    min_lines?: number;
+    /** `key = value` lines a block needs to be reported (default 2) */
+    min_assignments?: number;
+  };
+  /** Formatting lint and `praetorian fix` */
+  format_lint?: {
+    /** Line endings files must use (default lf) */
+    line_endings?: 'lf' | 'crlf';
+    /** Spaces a tab becomes when indentation is fixed (default 2) */
+    tab_width?: number;
+  };
+  /** Canonical key order, checked by rule `key-order` and applied by `praetorian fix` */
+  key_order?: {
+    /** `alphabetical` (default), or the keys that come first in this order; the rest follow alphabetically */
+    order?: 'alphabetical' | string[];
+    /** Globs of the files held to the order (default: every file) */
+    files?: string[];
+  };
+  /** Labels attached to files and groups; they flow into findings and select files (`--label`) */
+  labels?: Array<{
+    files?: string | string[];
+    groups?: string | string[];
+    labels: Record<string, string>;
+  }>;
+  /** Teams owning key patterns or files; findings are routed to them */
+  owners?: Array<{
+    team: string;
+    /** Key path patterns (`*` matches one segment); a pattern also owns the keys below it */
+    keys?: string | string[];
+    /** Globs of the files the team owns */
+    files?: string | string[];
+    /** Where the team is notified, e.g. a Slack channel */
+    channel?: string;
+  }>;
+  /** Shell commands run before and after the audit */
+  hooks?: {
+    pre?: string | string[];
+    post?: string | string[];
+    timeout_ms?: number;
+    cpu_seconds?: number;
+    memory_mb?: number;
+  };
+  http?: {
+    timeout_ms?: number;
+    retries?: number;
+    retry_backoff_ms?: number;
+    proxy?: string;
+    no_proxy?: string;
+    ca_file?: string;
+  };
+}
+
+/**
+ * A `scopes:` entry: restrict rules or auditors to some files or groups.
+ * Rules without a scope run everywhere.
+ */
+export interface RuleScope {
+  /** Rule ids or auditor names (security, compliance, performance) */
+  rules: string[];
+  /** Glob patterns of the files the rules run on */
+  files?: string[];
+  /** Audit groups the rules run on */
+  groups?: string[];
+  /** Labels a file must carry for the rules to run on it */
+  labels?: Record<string, string>;
+  /** Glob patterns of files the rules never run on */
+  exclude?: string[];
+  /** false disables the rules everywhere */
+  enabled?: boolean;
+}
+
+/**
+ * A `canaries:` entry: a honeytoken key whose value must never change or disappear
+ */
+export interface Canary {
+  /** Dotted key path */
+  key: string;
+  /** Expected value */
+  value?: string | number | boolean;
+  /** Hex SHA-256 of the expected value, to keep the value itself out of praetorian.yaml */
+  sha256?: string;
+  /** Glob patterns of the files that carry the canary; every file by default */
+  files?: string[];
+}
+
+/**
+ * Commented-out configuration settings (`commented_config:` in praetorian.yaml)
+ */
+export interface CommentedConfigSettings {
+  minLines?: number;
+  minAssignments?: number;
+}
+
+/**
+ * Formatting lint settings (`format_lint:` in praetorian.yaml)
+ */
+export interface FormatLintSettings {
+  lineEndings?: 'lf' | 'crlf';
+  tabWidth?: number;
+}
+
+/**
+ * Key order settings (`key_order:` in praetorian.yaml)
+ */
+export interface KeyOrderSettings {
+  order: 'alphabetical' | string[];
+  files: string[];
+}
+
+/**
+ * A `labels:` entry, or a `files:` entry with labels
+ */
+export interface LabelRule {
+  /** Glob patterns of the labelled files */
+  files: string[];
+  /** Audit groups whose files are labelled */
+  groups: string[];
+  labels: Record<string, string>;
+}
+
+/**
+ * An `owners:` entry
+ */
+export interface OwnerSettings {
+  team: string;
+  keys: string[];
+  files: string[];
+  channel?: string;
+}
+
+/**
+ * A `budgets:` entry: numeric keys summed per environment (`max_total`) or capped one by one (`max_value`)
+ */
+export interface Budget {
+  /** Name used in findings */
+  name: string;
+  /** Key path patterns of the values; `*` matches one segment or list index */
+  keys: string | string[];
+  /** Largest sum of the values of one environment: a number or a quantity (`64Gi`, `16GB`) */
+  max_total?: number | string;
+  /** Largest single value */
+  max_value?: number | string;
+  /** Only the files of this environment are budgeted */
+  environment?: string;
+}
+
+/**
+ * A budget as the rules read it
+ */
+export interface BudgetSettings {
+  name: string;
+  keys: string[];
+  maxTotal?: number | string;
+  maxValue?: number | string;
+  environment?: string;
+}
+
+/**
+ * A `rules:` entry: constraints on the values of the keys matching its pattern
+ */
+export interface ValueConstraint {
+  /** Built-in value type (see ValueTypes) */
+  type?: string;
+  /** Regular expression a scalar value must match */
+  regex?: string;
+  /** Values allowed */
+  enum?: unknown[];
+  /** Bounds of numeric values: a number or a quantity (`30s`, `512MB`) */
+  min?: number | string;
+  max?: number | string;
+  /** Severity of a violation (default error) */
+  severity?: ValidationSeverity;
+}
+
+/**
+ * Where a key is written in its file (1-based)
+ */
+export interface KeyLocation {
+  line: number;
+  column: number;
+}
+
+/**
+ * The user-level configuration (`$XDG_CONFIG_HOME/praetorian/config.yaml`): defaults of every project,
+ * under the praetorian.yaml of each
+ */
+export interface UserConfig {
+  /** Default `--output` of validate */
+  output?: string;
+  /** false disables colors, like `--no-color` */
+  color?: boolean;
+  /** Endpoints probed at once by `--check-endpoints` */
+  concurrency?: number;
+  /** Telemetry choice; wins over `praetorian telemetry enable` and `disable` */
+  telemetry?: boolean;
+  /** Rule packs of projects whose praetorian.yaml sets no `ruleSets` */
+  ruleSets?: string[];
+}
+
+/**
+ * One source of configuration settings (the user configuration, a praetorian.yaml), in the order they are merged
+ */
+export interface ConfigLayer {
+  /** Where the settings come from, e.g. the path of the file */
+  source: string;
+  /** Settings of the layer */
+  config: Record<string, unknown>;
+}
+
+/**
+ * A `forbidden_keys:` entry limited to some environments, as written in praetorian.yaml
+ */
+export interface ForbiddenKeysEntry {
+  keys: string | string[];
+  /** Environments the keys are forbidden in (all when omitted) */
+  environments?: string | string[];
+}
+
+/**
+ * A `forbidden_keys:` entry as the rules read it; no environments means every environment
+ */
+export interface ForbiddenKeyRule {
+  keys: string[];
+  environments: string[];
+}
+
+/**
+ * A `value_differences:` entry as the rules read it
+ */
+export interface ValueDifferenceRule {
+  keys: string[];
+  severity: ValidationSeverity;
+}
+
+/**
+ * A key holding different values across the compared files
+ */
+export interface KeyDifference {
+  keyPath: string;
+  severity: ValidationSeverity;
+  /** Value of each file setting the key */
+  values: Array<{ file: string; environment?: string; value: unknown }>;
+}
+
+/**
+ * A category of `performance:` as written in praetorian.yaml
+ */
+export interface PerformanceBoundsConfig {
+  /** Key path patterns of the values (`*` matches one segment); the built-in key names by default */
+  keys?: string | string[];
+  /** Smallest value allowed: a number, or a duration for timeouts (`100ms`) */
+  min?: number | string;
+  /** Largest value allowed: a number, a duration for timeouts (`5m`) or a size for caches (`1GB`) */
+  max?: number | string;
+}
+
+/**
+ * A category of the performance checks as the rules read it
+ */
+export interface PerformanceBounds {
+  /** Key path patterns; empty for the built-in key names */
+  keys: string[];
+  min?: number | string;
+  max?: number | string;
+}
+
+/**
+ * Performance check settings (`performance:` in praetorian.yaml)
+ */
+export interface PerformanceSettings {
+  timeouts?: PerformanceBounds & { required?: boolean };
+  pools?: PerformanceBounds;
+  retries?: PerformanceBounds;
+  caches?: PerformanceBounds;
+}
+
+/**
+ * Environment leakage settings (`leakage:` in praetorian.yaml)
+ */
+export interface LeakageSettings {
+  /** Environment name -> tokens that give a value away; replaces the default list of that environment */
+  tokens?: Record<string, string[]>;
+}
+
+/**
+ * Feature flag settings (`feature_flags:` in praetorian.yaml)
+ */
+export interface FeatureFlagSettings {
+  /** Rollout percentage above which percentage rollouts are reported */
+  rolloutThreshold?: number;
+}
+
+/**
+ * Cloud identifiers an environment may use (a `cloud_identifiers:` entry in praetorian.yaml)
+ */
+export interface CloudAllowList {
+  /** AWS and GCP regions (us-east-1, europe-west1) */
+  regions?: string[];
+  /** AWS account ids */
+  accounts?: string[];
+  /** GCP project ids */
+  projects?: string[];
+  /** Azure subscription ids */
+  subscriptions?: string[];
+}
+
+/**
+ * Environment name -> cloud identifiers it may use
+ */
+export type CloudIdentifierSettings = Record<string, CloudAllowList>;
+
+/**
+ * Message broker settings (`brokers:` in praetorian.yaml)
+ */
+export interface BrokerSettings {
+  /** Regular expression every Kafka consumer group id must match */
+  consumerGroupPattern?: string;
+  /** Smallest replication factor production accepts */
+  minReplicationFactor?: number;
+}
+
+/**
+ * Cron expression settings (`cron:` in praetorian.yaml)
+ */
+export interface CronSettings {
+  /** Key path patterns of scheduler keys (`*` matches one segment); Kubernetes CronJobs are always checked */
+  keys: string[];
+  /** Shortest time between two runs of a production schedule, in milliseconds */
+  minIntervalMs?: number;
+}
+
+/**
+ * Rate limit settings (`rate_limits:` in praetorian.yaml)
+ */
+export interface RateLimitSettings {
+  /** Key path patterns of endpoint blocks (`*` matches one segment) */
+  endpoints: string[];
+  /** Key path, inside an endpoint, of the flag marking it public */
+  public?: string;
+  /** Key path, inside an endpoint, of the request limit */
+  limit?: string;
+  /** Key path, inside an endpoint, of the burst allowance */
+  burst?: string;
+  /** Largest burst allowed, as a multiple of the limit */
+  burstFactor?: number;
+  /** Key path patterns of quotas that production must not leave unlimited */
+  quotas: string[];
+}
+
+/**
+ * An `escalate:` entry: change findings of one severity to another in an environment
+ */
+export interface EscalationConfig {
+  environment: string;
+  from: ValidationSeverity;
+  to: ValidationSeverity;
+  codes?: string[];
+}
+
+/**
+ * Redaction policies for raw values leaving the tool
+ */
+export type RedactionPolicyName = 'none' | 'mask-all' | 'mask-secret-keys' | 'hash';
+
+/**
+ * Redaction settings (`redaction:` in praetorian.yaml or --redact)
+ */
+export interface RedactionSettings {
+  policy?: RedactionPolicyName;
+  /** Extra key patterns treated as secrets by mask-secret-keys (e.g. "*.dsn") */
+  secretKeys?: string[];
+  /** Salt of the hash policy; PRAETORIAN_HASH_SALT when undefined */
+  salt?: string;
+}
+
+/**
+ * Shell commands run before (render, decrypt) and after (upload, notify) an audit
+ */
+export interface HookSettings {
+  pre: string[];
+  post: string[];
+  /** Timeout of each command, in milliseconds */
+  timeoutMs?: number;
+  /** CPU time each command may use, in seconds */
+  cpuSeconds?: number;
+  /** Resident memory each command may use, in megabytes */
+  memoryMb?: number;
+}
+
+/**
+ * Settings shared by every remote operation (rule sources, publishers, live sources)
+ */
+export interface HttpSettings {
+  /** Timeout of a single attempt, in milliseconds */
+  timeoutMs?: number;
+  /** Extra attempts after the first one for network errors, timeouts, 408, 429 and 5xx */
+  retries?: number;
+  /** Delay before the first retry; doubled on every further retry */
+  retryBackoffMs?: number;
+  /** Proxy URL; defaults to HTTPS_PROXY / HTTP_PROXY */
+  proxy?: string;
+  /** Comma-separated hosts that bypass the proxy; defaults to NO_PROXY */
+  noProxy?: string;
+  /** PEM bundle used instead of the default trusted CAs */
+  caFile?: string;
 }
 
 export interface PluginConfig {
@@ -99,11 +718,7 @@ export interface AuditResult {
   vulnerabilities?: ValidationError[];
   complianceIssues?: ValidationError[];
   performanceIssues?: ValidationError[];
-  metadata?: {
-    duration?: number;
-    pluginsChecked?: number;
-    [key: string]: any;
-  };
+  metadata?: ValidationMetadata;
 }
 
 export interface AuditIssue {
@@ -126,6 +741,27 @@ export interface ValidationContext {
   ignoreKeys?: string[];
   requiredKeys?: string[];
   strict?: boolean;
+  limits?: {
+    maxDepth?: number;
+    maxKeys?: number;
+  };
+  comparison?: ComparisonSettings;
+  scopes?: RuleScope[];
+  http?: HttpSettings;
+  leakage?: LeakageSettings;
+  cloudIdentifiers?: CloudIdentifierSettings;
+  commentedConfig?: CommentedConfigSettings;
+  formatLint?: FormatLintSettings;
+  performance?: PerformanceSettings;
+  valueDifferences?: ValueDifferenceRule[];
+  forbiddenKeys?: ForbiddenKeyRule[];
+  valueRules?: Record<string, ValueConstraint>;
+  /** Labels of the files, by path */
+  labels?: Record<string, Record<string, string>>;
+  /** Comments of the files, by path; read from disk when missing */
+  comments?: Record<string, ConfigComment[]>;
+  /** Aborted when the run is interrupted; long-running work stops at the next checkpoint */
+  signal?: AbortSignal;
 }
 
 export interface AuditSummary {
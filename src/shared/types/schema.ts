@@ -34,10 +34,10 @@ export interface JsonSchema {
   enum?: any[];
   /** Pattern for string validation */
   pattern?: string;
-  /** Minimum value for numbers */
-  minimum?: number;
-  /** Maximum value for numbers */
-  maximum?: number;
+  /** Minimum value for numbers, durations and sizes (e.g. 1000, "1s" or "10MB") */
+  minimum?: number | string;
+  /** Maximum value for numbers, durations and sizes */
+  maximum?: number | string;
   /** Minimum length for strings */
   minLength?: number;
   /** Maximum length for strings */
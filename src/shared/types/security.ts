@@ -4,7 +4,7 @@
  * Single Responsibility: Define types for security validation
  */
 
-import { ValidationResult, ValidationError, ValidationWarning } from './index';
+import { RedactionSettings, ValidationResult, ValidationError, ValidationWarning } from './index';
 
 /**
  * Security severity levels
@@ -136,6 +136,8 @@ export interface SecurityContext {
   };
   /** Validation options */
   options: SecurityOptions;
+  /** How detected secrets are shown (defaults to partial masking) */
+  redaction?: RedactionSettings;
   /** Custom security validators */
   customValidators?: Record<string, (value: any, context: SecurityContext) => ValidationResult>;
 }
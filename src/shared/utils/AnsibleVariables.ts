@@ -0,0 +1,179 @@
+/**
+ * AnsibleVariables - Ansible inventory groups and group variables
+ *
+ * Single Responsibility: Read the groups of an Ansible inventory (INI or YAML) and merge
+ * the variables each group ends up with the way Ansible does: inventory file vars first,
+ * then `group_vars/all`, then the other `group_vars` files; within each level `all` comes
+ * first and parent groups come before their children. Later values replace earlier ones
+ * key by key, as with the default `hash_behaviour: replace`.
+ * Pure functions, no state, no side effects
+ */
+
+import { isPlainObject } from './KeyPaths';
+
+export interface InventoryGroup {
+  vars: Record<string, unknown>;
+  children: string[];
+  hosts: string[];
+}
+
+export type InventoryGroups = Record<string, InventoryGroup>;
+
+const INI_SECTION = /^\[([^\]:]+)(?::(vars|children|hosts))?\]$/;
+
+const emptyGroup = (): InventoryGroup => ({ vars: {}, children: [], hosts: [] });
+
+// Every inventory has these two, whether it lists them or not
+const implicitGroups = (): InventoryGroups => ({ all: emptyGroup(), ungrouped: emptyGroup() });
+
+const unquote = (value: string): string => value.replace(/^(['"])(.*)\1$/, '$2');
+
+/**
+ * Pure function to read an INI inventory: `[group]` host lines, `[group:vars]` key=value
+ * lines and `[group:children]` group names. Values of `:vars` sections are strings, as in Ansible.
+ */
+export const parseIniInventory = (content: string): InventoryGroups => {
+  const groups = implicitGroups();
+  let section = { group: 'ungrouped', kind: 'hosts' };
+
+  content
+    .split(/\r?\n/)
+    .map(line => line.trim())
+    .filter(line => line !== '' && !line.startsWith('#') && !line.startsWith(';'))
+    .forEach(line => {
+      const header = INI_SECTION.exec(line);
+
+      if (header) {
+        section = { group: header[1].trim(), kind: header[2] ?? 'hosts' };
+        groups[section.group] = groups[section.group] ?? emptyGroup();
+        return;
+      }
+
+      const group = groups[section.group];
+      const name = line.split(/\s+/)[0];
+
+      if (section.kind === 'vars') {
+        const equals = line.indexOf('=');
+        if (equals > 0) {
+          group.vars[line.slice(0, equals).trim()] = unquote(line.slice(equals + 1).trim());
+        }
+      } else if (section.kind === 'children') {
+        group.children = [...new Set([...group.children, name])];
+        groups[name] = groups[name] ?? emptyGroup();
+      } else {
+        group.hosts = [...new Set([...group.hosts, name])];
+      }
+    });
+
+  return groups;
+};
+
+/**
+ * Pure function to read a YAML inventory: nested `hosts`, `vars` and `children` under each group
+ */
+export const yamlInventoryGroups = (content: Record<string, any>): InventoryGroups => {
+  const groups = implicitGroups();
+
+  const visit = (name: string, definition: unknown): void => {
+    const group = groups[name] = groups[name] ?? emptyGroup();
+
+    // Guard clause: a group listed without a body
+    if (!isPlainObject(definition)) {
+      return;
+    }
+
+    const children = isPlainObject(definition.children) ? Object.entries(definition.children) : [];
+    group.vars = { ...group.vars, ...(isPlainObject(definition.vars) ? definition.vars : {}) };
+    group.hosts = [...new Set([...group.hosts, ...(isPlainObject(definition.hosts) ? Object.keys(definition.hosts) : [])])];
+    group.children = [...new Set([...group.children, ...children.map(([child]) => child)])];
+    children.forEach(([child, childDefinition]) => visit(child, childDefinition));
+  };
+
+  Object.entries(content).forEach(([name, definition]) => visit(name, definition));
+  return groups;
+};
+
+/**
+ * Pure function to combine the groups of several inventory sources of one inventory
+ */
+export const combineInventoryGroups = (sources: InventoryGroups[]): InventoryGroups =>
+  sources.reduce<InventoryGroups>((combined, groups) => Object.entries(groups).reduce((merged, [name, group]) => {
+    const existing = merged[name] ?? emptyGroup();
+    return {
+      ...merged,
+      [name]: {
+        vars: { ...existing.vars, ...group.vars },
+        children: [...new Set([...existing.children, ...group.children])],
+        hosts: [...new Set([...existing.hosts, ...group.hosts])],
+      },
+    };
+  }, combined), implicitGroups());
+
+/**
+ * Pure function to list a group and every group above it, `all` first and parents before children
+ * (Ansible orders groups by depth, then by name)
+ */
+export const groupChain = (groups: InventoryGroups, name: string): string[] => {
+  const parentsOf = (child: string): string[] => {
+    const listed = Object.keys(groups).filter(parent => parent !== child && groups[parent].children.includes(child));
+    // Groups nobody lists as a child belong to `all`
+    return child === 'all' ? [] : listed.length > 0 ? listed : ['all'];
+  };
+
+  const ancestors = new Set<string>();
+  const depths = new Map<string, number>();
+  const depthOf = (group: string, seen: string[]): number => {
+    // Guard clause: cyclic children lists are cut where they loop
+    if (seen.includes(group)) {
+      return 0;
+    }
+
+    if (!depths.has(group)) {
+      const parents = parentsOf(group);
+      depths.set(group, parents.length === 0 ? 0 : 1 + Math.max(...parents.map(parent => depthOf(parent, [...seen, group]))));
+    }
+
+    return depths.get(group) as number;
+  };
+
+  const pending = [name];
+  while (pending.length > 0) {
+    const group = pending.pop() as string;
+    if (!ancestors.has(group)) {
+      ancestors.add(group);
+      pending.push(...parentsOf(group));
+    }
+  }
+
+  return [...ancestors].sort((a, b) => depthOf(a, []) - depthOf(b, []) || a.localeCompare(b));
+};
+
+/**
+ * Pure function to compute the variables every group ends up with
+ * @param groups - Groups of the inventory file(s)
+ * @param groupVars - Contents of `group_vars/<group>` by group name
+ * @returns Group name -> merged variables, for every group of the inventory or of group_vars;
+ * `ungrouped` only when it has hosts or variables of its own
+ */
+export const effectiveGroupVariables = (
+  groups: InventoryGroups,
+  groupVars: Record<string, Record<string, unknown>>
+): Record<string, Record<string, unknown>> => {
+  const known = combineInventoryGroups([
+    groups,
+    Object.fromEntries(Object.keys(groupVars).map(name => [name, emptyGroup()])),
+  ]);
+
+  const isListed = (name: string): boolean =>
+    name !== 'ungrouped' || known.ungrouped.hosts.length > 0 || Object.keys(known.ungrouped.vars).length > 0 || name in groupVars;
+
+  return Object.fromEntries(Object.keys(known).filter(isListed).map(name => {
+    const chain = groupChain(known, name);
+    const merged = [
+      ...chain.map(group => known[group].vars),
+      groupVars.all ?? {},
+      ...chain.filter(group => group !== 'all').map(group => groupVars[group] ?? {}),
+    ].reduce<Record<string, unknown>>((variables, level) => ({ ...variables, ...level }), {});
+    return [name, merged];
+  }));
+};
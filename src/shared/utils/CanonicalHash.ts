@@ -0,0 +1,47 @@
+/**
+ * CanonicalHash - Stable hashes of parsed configurations
+ *
+ * Single Responsibility: Write a parsed value as canonical JSON — mapping keys sorted,
+ * dates as ISO strings, missing values dropped — so that the same configuration hashes
+ * alike whatever its key order, whitespace or file format.
+ * Pure functions, no state, no side effects
+ */
+
+import { createHash } from 'crypto';
+
+const isOmitted = (value: unknown): boolean => value === undefined || typeof value === 'function';
+
+/**
+ * Pure function to write a value as canonical JSON
+ * @param value - Parsed value; list items keep their order, mapping keys are sorted by code unit
+ * @returns JSON text, equal for values that only differ in key order or in how the format typed dates
+ */
+export const canonicalJson = (value: unknown): string => {
+  // Dates are strings in JSON, whatever format parsed them
+  if (value instanceof Date) {
+    return JSON.stringify(Number.isNaN(value.getTime()) ? null : value.toISOString());
+  }
+  if (typeof value === 'bigint') {
+    return value.toString();
+  }
+  if (Array.isArray(value)) {
+    return `[${value.map(item => (isOmitted(item) ? 'null' : canonicalJson(item))).join(',')}]`;
+  }
+  if (value !== null && typeof value === 'object') {
+    const record = value as Record<string, unknown>;
+    const entries = Object.keys(record)
+      .filter(key => !isOmitted(record[key]))
+      .sort((a, b) => (a < b ? -1 : a > b ? 1 : 0))
+      .map(key => `${JSON.stringify(key)}:${canonicalJson(record[key])}`);
+    return `{${entries.join(',')}}`;
+  }
+
+  return JSON.stringify(value) ?? 'null';
+};
+
+/**
+ * Pure function to hash a value through its canonical JSON
+ * @returns `sha256:` and the full hex digest
+ */
+export const canonicalHash = (value: unknown): string =>
+  `sha256:${createHash('sha256').update(canonicalJson(value)).digest('hex')}`;
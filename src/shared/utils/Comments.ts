@@ -0,0 +1,68 @@
+/**
+ * Comments - Comment extraction
+ *
+ * Single Responsibility: Pull the full-line and block comments out of the text of a
+ * config file, with their line numbers, for the comment syntax of its format.
+ * Pure functions, no state, no side effects
+ */
+
+import { ConfigComment } from '../types';
+
+/**
+ * Comment syntax of a format
+ */
+export interface CommentSyntax {
+  /** Prefixes of full-line comments (`#`, `;`, `//`) */
+  line: string[];
+  /** Opening and closing delimiters of block comments (`<!--` and `-->`) */
+  block?: [string, string];
+}
+
+export const HASH_COMMENTS: CommentSyntax = { line: ['#'] };
+export const NO_COMMENTS: CommentSyntax = { line: [] };
+
+interface CommentScan {
+  /** Inside a block comment opened on an earlier line */
+  open: boolean;
+  comments: ConfigComment[];
+}
+
+/**
+ * Reads one line: the comment it holds, if any, and whether a block comment is still open after it
+ */
+const scanLine = (scan: CommentScan, text: string, line: number, syntax: CommentSyntax): CommentScan => {
+  const trimmed = text.trim();
+  const [start, end] = syntax.block ?? ['', ''];
+  const opens = syntax.block !== undefined && !scan.open && trimmed.startsWith(start);
+
+  if (scan.open || opens) {
+    const body = opens ? trimmed.slice(start.length) : trimmed;
+    const close = body.indexOf(end);
+    const comment = (close === -1 ? body : body.slice(0, close)).trim();
+    return { open: close === -1, comments: [...scan.comments, { line, text: comment }] };
+  }
+
+  // Trailing comments (`port: 80 # default`) annotate a live value, so only full lines count
+  const prefix = syntax.line.find(marker => trimmed.startsWith(marker));
+  return prefix === undefined
+    ? scan
+    : { open: false, comments: [...scan.comments, { line, text: trimmed.slice(prefix.length).trim() }] };
+};
+
+/**
+ * Pure function to extract the comments of a file
+ * @param text - File text
+ * @param syntax - Comment syntax of the file format
+ * @returns One comment per commented line, with its 1-based line and its text without the marker
+ */
+export const extractComments = (text: string, syntax: CommentSyntax): ConfigComment[] => {
+  // Guard clause: nothing to scan, or a format without comments
+  if (!text || (syntax.line.length === 0 && syntax.block === undefined)) {
+    return [];
+  }
+
+  return text
+    .split(/\r?\n/)
+    .reduce<CommentScan>((scan, line, index) => scanLine(scan, line, index + 1, syntax), { open: false, comments: [] })
+    .comments;
+};
@@ -0,0 +1,195 @@
+/**
+ * Cron - Cron expression parsing
+ *
+ * Single Responsibility: Tell whether a cron-like value is a valid schedule — standard
+ * five-field cron (Kubernetes CronJob), six or seven fields with seconds (Quartz, Spring)
+ * and `@daily`-style macros — and how often it can fire at most.
+ * Pure functions, no state, no side effects
+ */
+
+import { parseDurationString } from './Quantities';
+
+const SECOND = 1000;
+const MINUTE = 60 * SECOND;
+const HOUR = 60 * MINUTE;
+const DAY = 24 * HOUR;
+
+// `@reboot` runs once, so it has no interval
+const MACROS: Record<string, number | undefined> = {
+  '@yearly': 365 * DAY,
+  '@annually': 365 * DAY,
+  '@monthly': 28 * DAY,
+  '@weekly': 7 * DAY,
+  '@daily': DAY,
+  '@midnight': DAY,
+  '@hourly': HOUR,
+  '@reboot': undefined,
+};
+
+interface CronField {
+  name: string;
+  min: number;
+  max: number;
+  /** Names of the values from `min` on (JAN = 1, SUN = 0) */
+  names?: string[];
+}
+
+const SECONDS: CronField = { name: 'seconds', min: 0, max: 59 };
+const MINUTES: CronField = { name: 'minutes', min: 0, max: 59 };
+const HOURS: CronField = { name: 'hours', min: 0, max: 23 };
+const DAYS_OF_MONTH: CronField = { name: 'day of month', min: 1, max: 31 };
+const MONTHS: CronField = {
+  name: 'month', min: 1, max: 12, names: ['JAN', 'FEB', 'MAR', 'APR', 'MAY', 'JUN', 'JUL', 'AUG', 'SEP', 'OCT', 'NOV', 'DEC'],
+};
+// 0 and 7 are both Sunday; Quartz counts from SUN = 1, which stays in range
+const DAYS_OF_WEEK: CronField = { name: 'day of week', min: 0, max: 7, names: ['SUN', 'MON', 'TUE', 'WED', 'THU', 'FRI', 'SAT'] };
+const YEARS: CronField = { name: 'year', min: 1970, max: 2099 };
+
+// Quartz day specials: last day (`L`, `L-3`), nearest weekday (`15W`, `LW`), last or nth weekday (`5L`, `6#3`)
+const QUARTZ_DAY_OF_MONTH = /^(L(-\d{1,2})?|LW|(\d{1,2})W)$/i;
+const QUARTZ_DAY_OF_WEEK = /^([0-7]|[A-Z]{3})(L|#[1-5])$|^L$/i;
+const ITEM = /^(\*|[A-Za-z0-9]+)(?:-([A-Za-z0-9]+))?(?:\/(\d+))?$/;
+// Kubernetes and cron daemons accept a leading time zone
+const TIME_ZONE_PREFIX = /^(CRON_)?TZ=\S+\s+/;
+
+/**
+ * Result of parsing a cron expression: the shortest time between two runs in milliseconds
+ * (absent for `@reboot`), or the reason the expression is invalid
+ */
+export type CronParseResult =
+  | { valid: true; minIntervalMs?: number }
+  | { valid: false; reason: string };
+
+const valueOf = (text: string, field: CronField): number | undefined => {
+  if (/^\d+$/.test(text)) {
+    return Number(text);
+  }
+
+  const index = field.names?.indexOf(text.toUpperCase()) ?? -1;
+  return index === -1 ? undefined : index + (field === MONTHS ? 1 : 0);
+};
+
+/**
+ * Expands one item of a field (a value, range, step or special) into the values it matches,
+ * or explains why it is invalid
+ */
+const expandItem = (item: string, field: CronField, quartz: boolean): number[] | string => {
+  // `?` means "no specific value" for one of the two day fields
+  if (item === '?' && (field === DAYS_OF_MONTH || field === DAYS_OF_WEEK)) {
+    return [field.min];
+  }
+
+  // Quartz day specials match a few days a month; only their numbers are checked
+  if (quartz && field === DAYS_OF_MONTH && QUARTZ_DAY_OF_MONTH.test(item)) {
+    const day = Number(/\d+/.exec(item)?.[0] ?? field.min);
+    return day <= (item.startsWith('L-') ? 30 : 31) && (item.startsWith('L') || day >= 1)
+      ? [field.min]
+      : `'${item}' is out of range for ${field.name}`;
+  }
+
+  if (quartz && field === DAYS_OF_WEEK && QUARTZ_DAY_OF_WEEK.test(item)) {
+    return item.toUpperCase() === 'L' || valueOf(item.replace(/(L|#\d)$/i, ''), field) !== undefined
+      ? [field.min]
+      : `'${item}' is not a valid ${field.name} value`;
+  }
+
+  const match = ITEM.exec(item);
+
+  // Guard clause: not a value, range or step
+  if (!match) {
+    return `'${item}' is not a valid ${field.name} value`;
+  }
+
+  const [, start, end, step] = match;
+  const from = start === '*' ? field.min : valueOf(start, field);
+  const to = start === '*' ? field.max : end !== undefined ? valueOf(end, field) : step !== undefined ? field.max : from;
+  const increment = Number(step ?? 1);
+
+  // Guard clause: unknown name, or a range of `*`
+  if (from === undefined || to === undefined || (start === '*' && end !== undefined)) {
+    return `'${item}' is not a valid ${field.name} value`;
+  }
+
+  // Guard clause: outside the field or backwards
+  if (from < field.min || to > field.max || from > to) {
+    return `'${item}' is out of range for ${field.name} (${field.min}-${field.max})`;
+  }
+
+  return increment < 1
+    ? `'${item}' has a step of 0`
+    : Array.from({ length: Math.floor((to - from) / increment) + 1 }, (_, index) => from + index * increment);
+};
+
+/**
+ * Expands a comma-separated field into the values it matches, or the first reason it is invalid
+ */
+const expandField = (text: string, field: CronField, quartz: boolean): number[] | string => {
+  const items = text.split(',').map(item => expandItem(item, field, quartz));
+  return items.find((item): item is string => typeof item === 'string') ?? (items as number[][]).flat();
+};
+
+/**
+ * Shortest gap between the times of day an expression fires at; one run a day counts as a day
+ */
+const minIntervalOf = (seconds: number[], minutes: number[], hours: number[]): number => {
+  const times = [...new Set(hours.flatMap(hour => minutes.flatMap(minute => seconds.map(second => hour * 3600 + minute * 60 + second))))]
+    .sort((a, b) => a - b);
+  // The gap before the first run wraps around from the last run of the previous day
+  const gaps = times.map((time, index) => (index === 0 ? times[0] + 86400 - times[times.length - 1] : time - times[index - 1]));
+
+  return Math.min(...gaps) * SECOND;
+};
+
+/**
+ * Pure function to parse a cron expression.
+ * Five fields are standard cron; six or seven fields start with seconds and accept the Quartz
+ * `?`, `L`, `W` and `#` specials (the seventh field is the year). `@hourly`-style macros and
+ * `@every <duration>` are accepted too, as is a leading `CRON_TZ=` or `TZ=`.
+ * @param expression - Cron expression
+ * @param allowSeconds - Whether six and seven field expressions are accepted (Kubernetes takes five)
+ * @returns Whether the expression is valid, with the shortest time between two runs or the reason it is not
+ */
+export const parseCron = (expression: string, allowSeconds: boolean = true): CronParseResult => {
+  const text = expression.trim().replace(TIME_ZONE_PREFIX, '');
+
+  // Guard clause: empty value
+  if (text === '') {
+    return { valid: false, reason: 'the expression is empty' };
+  }
+
+  if (text.startsWith('@every ')) {
+    const interval = parseDurationString(text.slice('@every '.length));
+    return interval !== undefined && interval > 0
+      ? { valid: true, minIntervalMs: interval }
+      : { valid: false, reason: `'${text.slice('@every '.length)}' is not a duration` };
+  }
+
+  if (text.startsWith('@')) {
+    const macro = text.toLowerCase();
+    return Object.prototype.hasOwnProperty.call(MACROS, macro)
+      ? { valid: true, ...(MACROS[macro] !== undefined ? { minIntervalMs: MACROS[macro] } : {}) }
+      : { valid: false, reason: `'${text}' is not a known macro` };
+  }
+
+  const parts = text.split(/\s+/);
+  const quartz = parts.length === 6 || parts.length === 7;
+
+  // Guard clause: wrong number of fields
+  if (!(parts.length === 5 || (quartz && allowSeconds))) {
+    return { valid: false, reason: `expected ${allowSeconds ? '5, 6 or 7' : '5'} fields, found ${parts.length}` };
+  }
+
+  const fields = quartz
+    ? [SECONDS, MINUTES, HOURS, DAYS_OF_MONTH, MONTHS, DAYS_OF_WEEK, YEARS].slice(0, parts.length)
+    : [MINUTES, HOURS, DAYS_OF_MONTH, MONTHS, DAYS_OF_WEEK];
+  const expanded = parts.map((part, index) => expandField(part, fields[index], quartz));
+  const reason = expanded.find((values): values is string => typeof values === 'string');
+
+  // Guard clause: invalid field
+  if (reason !== undefined) {
+    return { valid: false, reason };
+  }
+
+  const [seconds, minutes, hours] = quartz ? expanded as number[][] : [[0], ...(expanded as number[][])];
+  return { valid: true, minIntervalMs: minIntervalOf(seconds, minutes, hours) };
+};
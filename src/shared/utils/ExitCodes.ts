@@ -0,0 +1,130 @@
+/**
+ * ExitCodes - Process exit codes and the errors that map to them
+ *
+ * Single Responsibility: Let pipelines tell "the configuration has findings" from
+ * "praetorian.yaml is wrong" from "the tool could not read its inputs". Errors are
+ * classified where they are thrown; commands turn them into exit codes here only.
+ */
+
+import { ValidationResult } from '../types';
+
+export const EXIT_CODES = {
+  /** No findings at or above the failure threshold */
+  SUCCESS: 0,
+  /** Findings at or above the failure threshold */
+  FINDINGS: 1,
+  /** Invalid command line or praetorian.yaml */
+  USAGE: 2,
+  /** A file or command could not be read, parsed or run */
+  IO: 3,
+  /** A remote source could not be reached */
+  REMOTE: 4,
+  /** A write, child process or network call broke the --assert-no-writes sandbox */
+  SANDBOX: 5,
+  /** Stopped by SIGINT/SIGTERM; partial results were flushed */
+  INTERRUPTED: 130
+} as const;
+
+export type ExitCode = typeof EXIT_CODES[keyof typeof EXIT_CODES];
+
+/**
+ * Failure threshold of `--fail-on`: errors (the default), warnings too, or never
+ */
+export type FailOn = 'error' | 'warning' | 'never';
+
+export const FAIL_ON_LEVELS: FailOn[] = ['error', 'warning', 'never'];
+
+/**
+ * Pure function to map a result to its exit code under a failure threshold
+ * @param result - Result of the run; an unsuccessful result has errors, escalated ones included
+ * @param failOn - Lowest severity that fails the run
+ */
+export const exitCodeForResult = (result: Pick<ValidationResult, 'success' | 'warnings'>, failOn: FailOn = 'error'): number => {
+  const failed = failOn === 'never'
+    ? false
+    : !result.success || (failOn === 'warning' && result.warnings.length > 0);
+  return failed ? EXIT_CODES.FINDINGS : EXIT_CODES.SUCCESS;
+};
+
+/**
+ * Error carrying the exit code the process should end with
+ */
+export class PraetorianError extends Error {
+  constructor(message: string, readonly exitCode: ExitCode) {
+    super(message);
+    this.name = new.target.name;
+  }
+}
+
+/**
+ * Invalid command line usage or configuration (exit code 2)
+ */
+export class ConfigError extends PraetorianError {
+  constructor(message: string) {
+    super(message, EXIT_CODES.USAGE);
+  }
+}
+
+/**
+ * Unreadable or unparseable file, or a failing local command (exit code 3)
+ */
+export class IoError extends PraetorianError {
+  constructor(message: string) {
+    super(message, EXIT_CODES.IO);
+  }
+}
+
+/**
+ * Unreachable or failing remote source (exit code 4)
+ */
+export class RemoteError extends PraetorianError {
+  constructor(message: string) {
+    super(message, EXIT_CODES.REMOTE);
+  }
+}
+
+/**
+ * Write, child process or network call outside what the sandbox allows (exit code 5)
+ */
+export class SandboxViolationError extends PraetorianError {
+  constructor(message: string) {
+    super(message, EXIT_CODES.SANDBOX);
+  }
+}
+
+/**
+ * Run stopped by SIGINT/SIGTERM (exit code 130)
+ */
+export class InterruptedError extends PraetorianError {
+  constructor(message: string = 'Interrupted') {
+    super(message, EXIT_CODES.INTERRUPTED);
+  }
+}
+
+/**
+ * Throws when a run has been interrupted; call it at checkpoints of long-running work
+ */
+export const throwIfInterrupted = (signal?: AbortSignal): void => {
+  if (signal?.aborted) {
+    throw new InterruptedError();
+  }
+};
+
+/**
+ * Pure function to map any error to an exit code.
+ * oclif errors (bad flags, `this.exit`) keep their code; unclassified errors mean the tool broke.
+ */
+export const exitCodeFor = (error: unknown): number => {
+  if (error instanceof PraetorianError) {
+    return error.exitCode;
+  }
+
+  const oclifExit = (error as { oclif?: { exit?: unknown } } | undefined)?.oclif?.exit;
+  return typeof oclifExit === 'number' ? oclifExit : EXIT_CODES.IO;
+};
+
+/**
+ * Pure function to check if an error only carries an exit code (oclif `this.exit`)
+ */
+export const isExitOnly = (error: unknown): boolean =>
+  (error as { code?: unknown } | undefined)?.code === 'EEXIT';
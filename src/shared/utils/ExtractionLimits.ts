@@ -0,0 +1,48 @@
+/**
+ * ExtractionLimits - Bound the key trees every rule walks
+ *
+ * Single Responsibility: Truncate pathological files (nested too deep, too many keys)
+ * once, and describe what was cut as warnings, so no later pass traverses them whole.
+ */
+
+import { ConfigFile, ValidationWarning } from '../types';
+import { DEFAULT_EXTRACTION_LIMITS, ExtractionLimits, truncateToLimits } from './KeyPaths';
+
+/**
+ * Pure function to fill the limits that are not configured with the defaults
+ */
+export const resolveExtractionLimits = (limits: Partial<ExtractionLimits> = {}): ExtractionLimits => ({
+  maxDepth: limits.maxDepth ?? DEFAULT_EXTRACTION_LIMITS.maxDepth,
+  maxKeys: limits.maxKeys ?? DEFAULT_EXTRACTION_LIMITS.maxKeys,
+});
+
+/**
+ * Pure function to truncate files to the extraction limits
+ * @returns The truncated files, in order, and one warning per limit a file went over
+ */
+export const limitFiles = (
+  files: ConfigFile[],
+  limits: ExtractionLimits
+): { files: ConfigFile[]; warnings: ValidationWarning[] } => {
+  const truncated = files.map(file => ({ file, truncation: truncateToLimits(file.content, limits) }));
+
+  const warnings = truncated.flatMap(({ file, truncation }) => [
+    ...(truncation.depthExceeded ? [{
+      code: 'MAX_DEPTH_EXCEEDED',
+      message: `Nesting deeper than ${limits.maxDepth} levels was truncated in ${file.path}`,
+      severity: 'warning' as const,
+      context: { file: file.path, extras: { maxDepth: limits.maxDepth } }
+    }] : []),
+    ...(truncation.keysExceeded ? [{
+      code: 'MAX_KEYS_EXCEEDED',
+      message: `Only the first ${limits.maxKeys} keys were analyzed in ${file.path}`,
+      severity: 'warning' as const,
+      context: { file: file.path, extras: { maxKeys: limits.maxKeys } }
+    }] : [])
+  ]);
+
+  return {
+    files: truncated.map(({ file, truncation }) => ({ ...file, content: truncation.content })),
+    warnings
+  };
+};
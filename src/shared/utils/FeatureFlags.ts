@@ -0,0 +1,259 @@
+/**
+ * FeatureFlags - Feature flag definitions of LaunchDarkly and Unleash exports
+ *
+ * Single Responsibility: Recognize the feature flag files of LaunchDarkly (flag API exports
+ * with one state per environment, SDK/relay flag data files) and Unleash (client API and
+ * export files, with or without per-environment states) and read every flag into the same
+ * shape: whether it is on, the largest percentage a rollout serves it to, and the expiry
+ * date recorded for it. Flag data without its own environment takes the file's.
+ * Pure functions, no state, no side effects
+ */
+
+import { isPlainObject, joinKeyPath } from './KeyPaths';
+
+export type FeatureFlagSource = 'launchdarkly' | 'unleash';
+
+/**
+ * @interface FeatureFlagState
+ * @description A flag in one environment
+ */
+export interface FeatureFlagState {
+  flag: string;
+  /** Environment named by the export; undefined when the file holds one environment */
+  environment?: string;
+  enabled: boolean;
+  keyPath: string;
+  /** Largest share of users a percentage rollout serves the flag to */
+  rollout?: { percentage: number; keyPath: string };
+}
+
+/**
+ * @interface FeatureFlagExpiry
+ * @description Date after which a flag should be gone (`expiresAt`, `expiry`, `removeBy`, ...)
+ */
+export interface FeatureFlagExpiry {
+  flag: string;
+  date: string;
+  keyPath: string;
+}
+
+export interface FeatureFlagExport {
+  source: FeatureFlagSource;
+  states: FeatureFlagState[];
+  expiries: FeatureFlagExpiry[];
+}
+
+const EXPIRY_KEY = /^(expir|remove[_-]?by)/i;
+// LaunchDarkly rollout weights are thousandths of a percent
+const LAUNCHDARKLY_WEIGHT_SCALE = 1000;
+
+const round = (value: number): number => Math.round(value * 100) / 100;
+
+const largestRollout = (rollouts: Array<{ percentage: number; keyPath: string }>): FeatureFlagState['rollout'] =>
+  rollouts.reduce<FeatureFlagState['rollout']>((largest, rollout) =>
+    largest === undefined || rollout.percentage > largest.percentage ? rollout : largest, undefined);
+
+/**
+ * Pure function to find the expiry dates written on a flag, as plain fields or as
+ * LaunchDarkly custom properties (`customProperties.<key>.value`)
+ */
+export const flagExpiries = (flag: string, definition: Record<string, any>, keyPath: string): FeatureFlagExpiry[] => [
+  ...Object.entries(definition)
+    .filter(([key, value]) => EXPIRY_KEY.test(key) && (typeof value === 'string' || value instanceof Date))
+    .map(([key, value]) => ({
+      flag,
+      date: value instanceof Date ? value.toISOString().slice(0, 10) : value,
+      keyPath: joinKeyPath(keyPath, key),
+    })),
+  ...Object.entries(isPlainObject(definition.customProperties) ? definition.customProperties : {})
+    .filter(([key, property]) => isPlainObject(property) && (EXPIRY_KEY.test(key) || EXPIRY_KEY.test(String(property.name))))
+    .map(([key, property]) => ({ flag, date: String([(property as any).value].flat()[0]), keyPath: joinKeyPath(keyPath, `customProperties.${key}`) }))
+    .filter(expiry => expiry.date !== 'undefined'),
+];
+
+/**
+ * Pure function to compute the share of users a LaunchDarkly rollout serves the flag to:
+ * the weight of every variation but the off one (a `false` value, or `offVariation`)
+ */
+export const launchDarklyRolloutShare = (rollout: unknown, variations: unknown, offVariation: unknown): number | undefined => {
+  // Guard clause: not a percentage rollout
+  if (!isPlainObject(rollout) || !Array.isArray(rollout.variations)) {
+    return undefined;
+  }
+
+  const values = Array.isArray(variations)
+    ? variations.map(variation => (isPlainObject(variation) && 'value' in variation ? variation.value : variation))
+    : undefined;
+  // Boolean flags list `true` first, so the off variation is 1 unless told otherwise
+  const isOff = (index: number): boolean => values ? values[index] === false : index === (typeof offVariation === 'number' ? offVariation : 1);
+
+  const served = rollout.variations
+    .filter(isPlainObject)
+    .filter(bucket => !isOff(Number(bucket.variation)))
+    .reduce((total, bucket) => total + (Number(bucket.weight) || 0), 0);
+  return round(served / LAUNCHDARKLY_WEIGHT_SCALE);
+};
+
+/**
+ * Pure function to read a LaunchDarkly flag configuration (the flag of a data file, or one
+ * environment of an API flag): `on`, and the rollouts of its fallthrough and rules
+ */
+const launchDarklyState = (
+  flag: string,
+  configuration: Record<string, any>,
+  keyPath: string,
+  variations: unknown,
+  environment?: string
+): FeatureFlagState => {
+  const rollouts = [
+    { rollout: configuration.fallthrough?.rollout, keyPath: joinKeyPath(keyPath, 'fallthrough.rollout') },
+    ...(Array.isArray(configuration.rules) ? configuration.rules : [])
+      .map((rule: any, index: number) => ({ rollout: rule?.rollout, keyPath: joinKeyPath(keyPath, `rules.${index}.rollout`) })),
+  ].flatMap(({ rollout, keyPath: rolloutPath }) => {
+    const percentage = launchDarklyRolloutShare(rollout, variations, configuration.offVariation);
+    return percentage === undefined ? [] : [{ percentage, keyPath: rolloutPath }];
+  });
+
+  return {
+    flag,
+    ...(environment !== undefined ? { environment } : {}),
+    enabled: configuration.on === true,
+    keyPath: joinKeyPath(keyPath, 'on'),
+    ...(rollouts.length > 0 ? { rollout: largestRollout(rollouts) } : {}),
+  };
+};
+
+/**
+ * Pure function to read a LaunchDarkly export: `items` of the flags API, each with its
+ * `environments`, or a flag data file with `flags` and/or `flagValues`
+ */
+export const readLaunchDarklyFlags = (content: Record<string, any>): FeatureFlagExport | undefined => {
+  if (Array.isArray(content.items) && content.items.some((item: unknown) => isPlainObject(item) && isPlainObject(item.environments))) {
+    const flags = content.items
+      .map((item: unknown, index: number) => ({ item, keyPath: `items.${index}` }))
+      .filter(({ item }: { item: unknown }) => isPlainObject(item) && typeof item.key === 'string');
+
+    return {
+      source: 'launchdarkly',
+      states: flags.flatMap(({ item, keyPath }: { item: Record<string, any>; keyPath: string }) =>
+        Object.entries(isPlainObject(item.environments) ? item.environments : {})
+          .filter(([, configuration]) => isPlainObject(configuration))
+          .map(([environment, configuration]) => launchDarklyState(
+            item.key, configuration as Record<string, any>, joinKeyPath(keyPath, `environments.${environment}`), item.variations, environment
+          ))),
+      expiries: flags.flatMap(({ item, keyPath }: { item: Record<string, any>; keyPath: string }) => flagExpiries(item.key, item, keyPath)),
+    };
+  }
+
+  const flags = isPlainObject(content.flags)
+    ? Object.entries(content.flags).filter(([, definition]) => isPlainObject(definition) && typeof definition.on === 'boolean')
+    : [];
+  const values = isPlainObject(content.flagValues) ? Object.entries(content.flagValues) : [];
+
+  // Guard clause: neither an API export nor a flag data file
+  if (flags.length === 0 && values.length === 0) {
+    return undefined;
+  }
+
+  return {
+    source: 'launchdarkly',
+    states: [
+      ...flags.map(([flag, definition]) => launchDarklyState(flag, definition as Record<string, any>, `flags.${flag}`, (definition as any).variations)),
+      // A flag value is served to everyone; only `false` turns a boolean flag off
+      ...values.map(([flag, value]) => ({ flag, enabled: value !== false && value !== null, keyPath: `flagValues.${flag}` })),
+    ],
+    expiries: flags.flatMap(([flag, definition]) => flagExpiries(flag, definition as Record<string, any>, `flags.${flag}`)),
+  };
+};
+
+/**
+ * Pure function to find the largest percentage among Unleash strategies
+ * (`rollout` of flexibleRollout, `percentage` of the gradual rollouts)
+ */
+const unleashRollout = (strategies: unknown, keyPath: (index: number) => string): FeatureFlagState['rollout'] =>
+  largestRollout((Array.isArray(strategies) ? strategies : []).flatMap((strategy, index) => {
+    const parameters = isPlainObject(strategy) && isPlainObject(strategy.parameters) ? strategy.parameters : {};
+    const parameter = ['rollout', 'percentage'].find(name => parameters[name] !== undefined && !isNaN(Number(parameters[name])));
+    return parameter ? [{ percentage: Number(parameters[parameter]), keyPath: joinKeyPath(keyPath(index), `parameters.${parameter}`) }] : [];
+  }));
+
+/**
+ * Pure function to read an Unleash export: `features` with `enabled` and `strategies`
+ * (client API, v3 exports), features with their `environments` (admin API), or
+ * `featureEnvironments` and `featureStrategies` next to the features (v4 exports)
+ */
+export const readUnleashFlags = (content: Record<string, any>): FeatureFlagExport | undefined => {
+  const features = (Array.isArray(content.features) ? content.features : [])
+    .map((feature: unknown, index: number) => ({ feature, keyPath: `features.${index}` }))
+    .filter(({ feature }: { feature: unknown }) => isPlainObject(feature) && typeof feature.name === 'string') as Array<{ feature: Record<string, any>; keyPath: string }>;
+
+  // Guard clause: not an Unleash file
+  if (features.length === 0) {
+    return undefined;
+  }
+
+  const expiries = features.flatMap(({ feature, keyPath }) => flagExpiries(feature.name, feature, keyPath));
+
+  if (Array.isArray(content.featureEnvironments)) {
+    const strategies = Array.isArray(content.featureStrategies) ? content.featureStrategies : [];
+    return {
+      source: 'unleash',
+      states: content.featureEnvironments
+        .map((state: unknown, index: number) => ({ state, index }))
+        .filter(({ state }: { state: any }) => isPlainObject(state) && typeof state.featureName === 'string' && typeof state.environment === 'string')
+        .map(({ state, index }: { state: Record<string, any>; index: number }) => {
+          const indexes = strategies
+            .map((strategy: any, strategyIndex: number) => ({ strategy, strategyIndex }))
+            .filter(({ strategy }: { strategy: any }) => strategy?.featureName === state.featureName && strategy?.environment === state.environment);
+          const rollout = unleashRollout(indexes.map(({ strategy }: { strategy: unknown }) => strategy), position => `featureStrategies.${indexes[position].strategyIndex}`);
+          return {
+            flag: state.featureName,
+            environment: state.environment,
+            enabled: state.enabled === true,
+            keyPath: `featureEnvironments.${index}.enabled`,
+            ...(rollout ? { rollout } : {}),
+          };
+        }),
+      expiries,
+    };
+  }
+
+  return {
+    source: 'unleash',
+    states: features.flatMap(({ feature, keyPath }): FeatureFlagState[] => {
+      if (Array.isArray(feature.environments)) {
+        return feature.environments
+          .map((environment: unknown, index: number) => ({ environment, index }))
+          .filter(({ environment }: { environment: any }) => isPlainObject(environment) && typeof environment.name === 'string')
+          .map(({ environment, index }: { environment: Record<string, any>; index: number }) => {
+            const environmentPath = joinKeyPath(keyPath, `environments.${index}`);
+            const rollout = unleashRollout(environment.strategies, position => joinKeyPath(environmentPath, `strategies.${position}`));
+            return {
+              flag: feature.name,
+              environment: environment.name,
+              enabled: environment.enabled === true,
+              keyPath: joinKeyPath(environmentPath, 'enabled'),
+              ...(rollout ? { rollout } : {}),
+            };
+          });
+      }
+
+      const rollout = unleashRollout(feature.strategies, position => joinKeyPath(keyPath, `strategies.${position}`));
+      return [{
+        flag: feature.name,
+        enabled: feature.enabled === true,
+        keyPath: joinKeyPath(keyPath, 'enabled'),
+        ...(rollout ? { rollout } : {}),
+      }];
+    }),
+    expiries,
+  };
+};
+
+/**
+ * Pure function to read the flags of a parsed file
+ * @param content - Parsed file
+ * @returns The flags, or undefined when the file is not a LaunchDarkly or Unleash export
+ */
+export const readFeatureFlags = (content: unknown): FeatureFlagExport | undefined =>
+  isPlainObject(content) ? readLaunchDarklyFlags(content) ?? readUnleashFlags(content) : undefined;
@@ -0,0 +1,44 @@
+/**
+ * FileEntries - Pure functions for `files:` entries of praetorian.yaml
+ *
+ * Single Responsibility: Read paths, explicit formats and labels from file entries,
+ * which are either plain paths or `{ path, format, labels }` objects.
+ */
+
+import { FileEntry, LabelRule } from '../types';
+
+/**
+ * Pure function to get the path of a file entry
+ */
+export const getFileEntryPath = (entry: FileEntry): string =>
+  typeof entry === 'string' ? entry : entry.path;
+
+/**
+ * Pure function to get the paths of a list of file entries
+ */
+export const getFileEntryPaths = (entries: FileEntry[] | undefined): string[] =>
+  Array.isArray(entries) ? entries.map(getFileEntryPath) : [];
+
+/**
+ * Pure function to collect explicit formats (path or glob -> format) from file entries
+ */
+export const getFileEntryFormats = (entries: FileEntry[] | undefined): Record<string, string> =>
+  Object.fromEntries(
+    (Array.isArray(entries) ? entries : [])
+      .flatMap(entry => typeof entry === 'object' && entry.format ? [[entry.path, entry.format]] : [])
+  );
+
+/**
+ * Pure function to turn the labels of file entries into label rules (label values as strings)
+ */
+export const getFileEntryLabels = (entries: FileEntry[] | undefined): LabelRule[] =>
+  (Array.isArray(entries) ? entries : [])
+    .flatMap(entry => typeof entry === 'object' && entry.labels
+      ? [{ files: [entry.path], groups: [], labels: stringifyLabels(entry.labels) }]
+      : []);
+
+/**
+ * Pure function to write label values as strings (`tier: 1` is the label `tier=1`)
+ */
+export const stringifyLabels = (labels: Record<string, unknown> = {}): Record<string, string> =>
+  Object.fromEntries(Object.entries(labels).map(([name, value]) => [name, String(value)]));
@@ -0,0 +1,34 @@
+/**
+ * Findings - Pure functions over the findings of a validation result
+ *
+ * Single Responsibility: Flatten errors/warnings/info into one list and rebuild
+ * a result from such a list, so post-processing steps can work on findings
+ * without caring which bucket they came from.
+ */
+
+import { ValidationError, ValidationInfo, ValidationResult, ValidationWarning } from '../types';
+
+/**
+ * Pure function to list every finding of a result (errors, then warnings, then info)
+ */
+export const collectFindings = (result: ValidationResult): ValidationError[] => [
+  ...result.errors,
+  ...result.warnings,
+  ...(result.info ?? [])
+];
+
+/**
+ * Pure function to rebuild a result from findings, bucketing them by severity.
+ * The result fails when any finding is an error.
+ */
+export const withFindings = (result: ValidationResult, findings: ValidationError[]): ValidationResult => {
+  const errors = findings.filter(finding => finding.severity === 'error');
+
+  return {
+    ...result,
+    success: errors.length === 0,
+    errors,
+    warnings: findings.filter(finding => finding.severity === 'warning') as ValidationWarning[],
+    info: findings.filter(finding => finding.severity === 'info') as ValidationInfo[]
+  };
+};
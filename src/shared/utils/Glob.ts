@@ -0,0 +1,61 @@
+/**
+ * Glob - Pure functions for file glob patterns
+ *
+ * Single Responsibility: Match file paths against glob patterns
+ * Supports `**` (any number of directories), `*` (within a segment) and `?`.
+ * Patterns without a `/` are matched against the file name only.
+ */
+
+/**
+ * Pure function to normalize path separators to `/`
+ */
+export const normalizePath = (filePath: string): string =>
+  filePath.replace(/\\/g, '/').replace(/^\.\//, '');
+
+/**
+ * Pure function to check if a pattern contains glob syntax
+ */
+export const hasGlobMagic = (pattern: string): boolean => /[*?]/.test(pattern);
+
+/**
+ * Pure function to convert a glob pattern into a regular expression
+ */
+export const globToRegExp = (pattern: string): RegExp => {
+  const normalized = normalizePath(pattern);
+  let source = '';
+
+  for (let i = 0; i < normalized.length; i++) {
+    const char = normalized[i];
+
+    if (char === '*' && normalized[i + 1] === '*') {
+      const followedBySlash = normalized[i + 2] === '/';
+      source += followedBySlash ? '(?:.*/)?' : '.*';
+      i += followedBySlash ? 2 : 1;
+    } else if (char === '*') {
+      source += '[^/]*';
+    } else if (char === '?') {
+      source += '[^/]';
+    } else {
+      source += char.replace(/[.+^${}()|[\]\\]/g, '\\$&');
+    }
+  }
+
+  return new RegExp(`^${source}$`);
+};
+
+/**
+ * Pure function to check if a file path matches a glob pattern
+ */
+export const matchesGlob = (filePath: string, pattern: string): boolean => {
+  // Guard clause: empty input
+  if (!filePath || !pattern) {
+    return false;
+  }
+
+  const normalizedPath = normalizePath(filePath);
+  const target = pattern.includes('/')
+    ? normalizedPath
+    : normalizedPath.split('/').pop() ?? normalizedPath;
+
+  return globToRegExp(pattern).test(target);
+};
@@ -0,0 +1,63 @@
+/**
+ * Interrupt - SIGINT/SIGTERM handling for long runs
+ *
+ * Single Responsibility: Turn the first SIGINT/SIGTERM into an aborted AbortSignal
+ * that in-flight work checks, so the command can flush the findings produced so far
+ * and exit with the interrupted code instead of dying mid-print. A second signal
+ * exits immediately.
+ */
+
+import { EXIT_CODES } from './ExitCodes';
+
+export const INTERRUPT_SIGNALS: NodeJS.Signals[] = ['SIGINT', 'SIGTERM'];
+
+export class RunInterrupt {
+  private readonly controller = new AbortController();
+  private received?: NodeJS.Signals;
+
+  private readonly onSignal = (signal: NodeJS.Signals): void => {
+    // Guard clause: second signal, the user really wants out
+    if (this.received) {
+      this.target.exit(EXIT_CODES.INTERRUPTED);
+      return;
+    }
+
+    this.received = signal;
+    this.controller.abort();
+  };
+
+  /**
+   * @param target - Process whose signals are handled (injectable for tests)
+   */
+  constructor(private readonly target: NodeJS.Process = process) {}
+
+  /**
+   * Start handling SIGINT/SIGTERM
+   */
+  listen(): this {
+    INTERRUPT_SIGNALS.forEach(signal => this.target.on(signal, this.onSignal));
+    return this;
+  }
+
+  /**
+   * Stop handling signals, restoring the default behaviour
+   */
+  dispose(): void {
+    INTERRUPT_SIGNALS.forEach(signal => this.target.removeListener(signal, this.onSignal));
+  }
+
+  get signal(): AbortSignal {
+    return this.controller.signal;
+  }
+
+  isInterrupted(): boolean {
+    return this.controller.signal.aborted;
+  }
+
+  /**
+   * Signal that interrupted the run, if any
+   */
+  getSignal(): NodeJS.Signals | undefined {
+    return this.received;
+  }
+}
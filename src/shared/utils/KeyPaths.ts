@@ -0,0 +1,189 @@
+/**
+ * KeyPaths - Pure functions for dotted key paths
+ *
+ * Single Responsibility: Extract and match dotted key paths in parsed configurations
+ * Pure functions, no state, no side effects
+ */
+
+const WILDCARD = '*';
+
+/**
+ * Pure function to check if a value is a plain (non-array) object
+ */
+export const isPlainObject = (value: any): value is Record<string, any> =>
+  value !== null && typeof value === 'object' && !Array.isArray(value);
+
+/**
+ * Pure function to join a prefix and a key into a dotted path
+ */
+export const joinKeyPath = (prefix: string, key: string): string =>
+  prefix ? `${prefix}.${key}` : key;
+
+/**
+ * Pure function to extract every dotted key path of an object
+ */
+export const extractKeyPaths = (obj: any, prefix = ''): Set<string> => {
+  // Guard clause: nothing to traverse
+  if (!isPlainObject(obj)) {
+    return new Set();
+  }
+
+  return new Set(
+    Object.entries(obj).flatMap(([key, value]) => {
+      const fullKey = joinKeyPath(prefix, key);
+      return [fullKey, ...extractKeyPaths(value, fullKey)];
+    })
+  );
+};
+
+/**
+ * Pure function to map every dotted key path of an object to its value
+ */
+export const extractKeyValues = (obj: any, prefix = ''): Map<string, any> => {
+  // Guard clause: nothing to traverse
+  if (!isPlainObject(obj)) {
+    return new Map();
+  }
+
+  return new Map(
+    Object.entries(obj).flatMap(([key, value]) => {
+      const fullKey = joinKeyPath(prefix, key);
+      return [[fullKey, value] as [string, any], ...extractKeyValues(value, fullKey)];
+    })
+  );
+};
+
+/**
+ * Pure function to check if a key pattern contains wildcards
+ */
+export const isWildcardPattern = (pattern: string): boolean =>
+  typeof pattern === 'string' && pattern.includes(WILDCARD);
+
+/**
+ * Pure function to check if a key matches a pattern where `*` matches exactly one segment
+ */
+export const matchesKeyPattern = (key: string, pattern: string): boolean => {
+  const keySegments = key.split('.');
+  const patternSegments = pattern.split('.');
+
+  return keySegments.length === patternSegments.length &&
+    patternSegments.every((segment, index) => segment === WILDCARD || segment === keySegments[index]);
+};
+
+/**
+ * Pure function to resolve the concrete paths of an object matching a segment pattern
+ */
+export const resolveKeyPattern = (obj: any, pattern: string): Array<{ path: string; value: any }> => {
+  const resolveSegments = (value: any, segments: string[], prefix: string): Array<{ path: string; value: any }> => {
+    // Guard clause: all segments consumed
+    if (segments.length === 0) {
+      return [{ path: prefix, value }];
+    }
+
+    // Guard clause: cannot descend further
+    if (!isPlainObject(value)) {
+      return [];
+    }
+
+    const [segment, ...rest] = segments;
+    const keys = segment === WILDCARD
+      ? Object.keys(value)
+      : Object.prototype.hasOwnProperty.call(value, segment) ? [segment] : [];
+
+    return keys.flatMap(key => resolveSegments(value[key], rest, joinKeyPath(prefix, key)));
+  };
+
+  // Guard clause: empty pattern
+  if (!pattern) {
+    return [];
+  }
+
+  return resolveSegments(obj, pattern.split('.'), '');
+};
+
+/**
+ * Pure function to find which occurrences of a wildcard required key are missing.
+ *
+ * `services.*.healthcheck` means every object matching `services.*` must contain
+ * `healthcheck`. When the last segment is itself a wildcard, at least one match is required.
+ */
+export const findMissingWildcardKeys = (obj: any, pattern: string): string[] => {
+  const segments = pattern.split('.');
+  const child = segments[segments.length - 1];
+
+  if (child === WILDCARD) {
+    return resolveKeyPattern(obj, pattern).length > 0 ? [] : [pattern];
+  }
+
+  const parentPattern = segments.slice(0, -1).join('.');
+  const parents = parentPattern
+    ? resolveKeyPattern(obj, parentPattern).filter(parent => isPlainObject(parent.value))
+    : [{ path: '', value: obj }];
+
+  return parents
+    .filter(parent => !Object.prototype.hasOwnProperty.call(parent.value, child))
+    .map(parent => joinKeyPath(parent.path, child));
+};
+
+/**
+ * Limits applied while traversing a configuration
+ */
+export interface ExtractionLimits {
+  maxDepth: number;
+  maxKeys: number;
+}
+
+export const DEFAULT_EXTRACTION_LIMITS: ExtractionLimits = {
+  maxDepth: 64,
+  maxKeys: 100000
+};
+
+/**
+ * Value stored in place of a subtree dropped by the extraction limits
+ */
+export const TRUNCATED_VALUE = '[truncated]';
+
+export interface TruncationResult {
+  content: any;
+  keyCount: number;
+  depthExceeded: boolean;
+  keysExceeded: boolean;
+}
+
+/**
+ * Function to copy an object keeping at most `maxDepth` levels and `maxKeys` keys.
+ *
+ * Subtrees deeper than the limit are replaced by TRUNCATED_VALUE and keys beyond
+ * the key budget are dropped, so later passes never traverse pathological input.
+ */
+export const truncateToLimits = (obj: any, limits: ExtractionLimits = DEFAULT_EXTRACTION_LIMITS): TruncationResult => {
+  const state = { keyCount: 0, depthExceeded: false, keysExceeded: false };
+
+  const copyLevel = (value: Record<string, any>, depth: number): Record<string, any> => {
+    const copy: Record<string, any> = {};
+
+    for (const [key, child] of Object.entries(value)) {
+      if (state.keyCount >= limits.maxKeys) {
+        state.keysExceeded = true;
+        break;
+      }
+
+      state.keyCount++;
+
+      if (!isPlainObject(child)) {
+        copy[key] = child;
+      } else if (depth >= limits.maxDepth) {
+        state.depthExceeded = true;
+        copy[key] = TRUNCATED_VALUE;
+      } else {
+        copy[key] = copyLevel(child, depth + 1);
+      }
+    }
+
+    return copy;
+  };
+
+  const content = isPlainObject(obj) ? copyLevel(obj, 1) : obj;
+
+  return { content, ...state };
+};
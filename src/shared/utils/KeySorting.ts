@@ -0,0 +1,187 @@
+/**
+ * KeySorting - Canonical key order
+ *
+ * Single Responsibility: Compare keys in a canonical order (alphabetical, or a declared
+ * order first) and rewrite the text of JSON, YAML, properties and .env files into that
+ * order, keeping comments with the key they precede.
+ * Pure functions, no state, no side effects
+ */
+
+import { isPlainObject } from './KeyPaths';
+
+/**
+ * `alphabetical`, or the keys that come first in this order; the others follow alphabetically
+ */
+export type KeyOrder = 'alphabetical' | string[];
+
+export type KeyComparator = (a: string, b: string) => number;
+
+// JavaScript objects put integer-like keys first, whatever order the file had
+export const INTEGER_KEY = /^(0|[1-9]\d*)$/;
+
+/**
+ * Pure function to build the comparator of an order
+ * @param order - `alphabetical` or declared keys
+ * @returns Comparator ranking declared keys first, then case-insensitive alphabetical order
+ */
+export const keyComparator = (order: KeyOrder): KeyComparator => {
+  const declared = Array.isArray(order) ? order : [];
+  const rank = (key: string): number => (declared.includes(key) ? declared.indexOf(key) : declared.length);
+
+  return (a, b) => {
+    const [lowerA, lowerB] = [a.toLowerCase(), b.toLowerCase()];
+    return rank(a) - rank(b)
+      || (lowerA < lowerB ? -1 : lowerA > lowerB ? 1 : 0)
+      || (a < b ? -1 : a > b ? 1 : 0);
+  };
+};
+
+/**
+ * Sorts the mappings of a value that are reached through mappings; list items keep their key order
+ */
+const sortValue = (value: unknown, compare: KeyComparator): unknown =>
+  isPlainObject(value)
+    ? Object.fromEntries(Object.keys(value).sort(compare).map(key => [key, sortValue(value[key], compare)]))
+    : value;
+
+const sortJson = (text: string, compare: KeyComparator): string | undefined => {
+  const indentation = /\n([ \t]+)\S/.exec(text)?.[1] ?? '  ';
+
+  try {
+    return `${JSON.stringify(sortValue(JSON.parse(text), compare), null, indentation)}${text.endsWith('\n') ? '\n' : ''}`;
+  } catch {
+    return undefined;
+  }
+};
+
+interface Entry {
+  key: string;
+  /** Comment and blank lines before the key, which move with it */
+  leading: string[];
+  lines: string[];
+}
+
+type LineKey = (line: string, index: number) => string | undefined;
+
+const YAML_COMMENT = /^\s*(#|$)/;
+
+/**
+ * Splits lines into entries: each key line with the lines that belong to it and the comments before it.
+ * Lines before the first entry that end with a blank line stay put as a header; trailing comments stay at the end.
+ */
+const entriesOf = (
+  lines: string[],
+  keyOf: LineKey,
+  comment: RegExp
+): { header: string[]; entries: Entry[]; trailer: string[] } => {
+  const { entries, pending } = lines.reduce<{ entries: Entry[]; pending: string[] }>(({ entries: found, pending: waiting }, line, index) => {
+    const key = keyOf(line, index);
+
+    if (key !== undefined) {
+      return { entries: [...found, { key, leading: waiting, lines: [line] }], pending: [] };
+    }
+
+    // Comments and blank lines wait for the next key; other lines belong to the current one
+    if (comment.test(line) || found.length === 0) {
+      return { entries: found, pending: [...waiting, line] };
+    }
+
+    const last = found[found.length - 1];
+    return { entries: [...found.slice(0, -1), { ...last, lines: [...last.lines, ...waiting, line] }], pending: [] };
+  }, { entries: [], pending: [] });
+
+  const first = entries[0]?.leading ?? [];
+  const headerEnd = first.map(line => line.trim() === '').lastIndexOf(true) + 1;
+
+  return {
+    header: first.slice(0, headerEnd),
+    entries: entries.map((entry, index) => (index === 0 ? { ...entry, leading: first.slice(headerEnd) } : entry)),
+    trailer: pending,
+  };
+};
+
+const joinEntries = (
+  parts: { header: string[]; entries: Entry[]; trailer: string[] },
+  compare: KeyComparator,
+  body: (entry: Entry) => string[] = entry => entry.lines
+): string[] => [
+  ...parts.header,
+  ...[...parts.entries].sort((a, b) => compare(a.key, b.key)).flatMap(entry => [...entry.leading, ...body(entry)]),
+  ...parts.trailer,
+];
+
+const indentOf = (line: string): number => /^ */.exec(line)?.[0].length ?? 0;
+
+// `key:`, `"quoted key":` and `'quoted key':`, followed by a value, a comment or nothing
+const YAML_KEY = /^( *)(?:"([^"]+)"|'([^']+)'|([^\s#'"\-?][^:#]*?|-[^\s:#][^:#]*?))\s*:(?:\s|$)/;
+
+/**
+ * Sorts the mapping whose keys are at the indentation of its first key line, and the mappings nested in it
+ */
+const sortYamlMapping = (lines: string[], compare: KeyComparator): string[] => {
+  const indent = indentOf(lines.find(line => !YAML_COMMENT.test(line)) ?? '');
+  const keyOf = (line: string): string | undefined => {
+    const match = YAML_KEY.exec(line);
+    return match && match[1].length === indent ? match[2] ?? match[3] ?? match[4] : undefined;
+  };
+
+  return joinEntries(entriesOf(lines, keyOf, YAML_COMMENT), compare, entry => {
+    const [keyLine, ...rest] = entry.lines;
+    const child = rest.find(line => !YAML_COMMENT.test(line));
+    // Only a key with nothing after the colon opens a nested mapping; list items keep their order
+    const nested = /:\s*(#.*)?$/.test(keyLine) && child !== undefined && indentOf(child) > indent && !/^\s*- /.test(child)
+      && !/^\s*-$/.test(child);
+    return nested ? [keyLine, ...sortYamlMapping(rest, compare)] : entry.lines;
+  });
+};
+
+const sortYaml = (text: string, compare: KeyComparator): string | undefined => {
+  const lines = text.split('\n');
+
+  // Guard clause: multi-document files, directives, complex keys and tab indentation are not rewritten
+  if (lines.some(line => /^(---|\.\.\.|%)/.test(line) || /^\s*\? /.test(line) || /^ *\t/.test(line))) {
+    return undefined;
+  }
+
+  return sortYamlMapping(lines, compare).join('\n');
+};
+
+const FLAT_FORMATS: Record<string, { key: RegExp; comment: RegExp }> = {
+  env: { key: /^\s*(?:export\s+)?([A-Za-z_][\w.\-]*)\s*=/, comment: /^\s*(#|$)/ },
+  properties: { key: /^\s*((?:\\.|[^=:\s\\])+)\s*[=:\s]/, comment: /^\s*(#|!|$)/ },
+};
+
+const sortFlat = (text: string, format: { key: RegExp; comment: RegExp }, compare: KeyComparator): string => {
+  const lines = text.split('\n');
+  // A properties value ending with an odd number of backslashes continues on the next line
+  const continued = (index: number): boolean => index > 0 && /(^|[^\\])(\\\\)*\\\r?$/.test(lines[index - 1]);
+  const keyOf: LineKey = (line, index) => (format.comment.test(line) || continued(index) ? undefined : format.key.exec(line)?.[1]);
+
+  return joinEntries(entriesOf(lines, keyOf, format.comment), compare).join('\n');
+};
+
+/**
+ * Pure function to rewrite the text of a file into canonical key order
+ * @param text - File text
+ * @param format - Parser format; `json`, `yaml`, `properties` and `env` can be rewritten
+ * @param compare - Key comparator
+ * @returns Rewritten text, or undefined when the format (or this YAML file) cannot be rewritten
+ */
+export const sortKeysInText = (text: string, format: string, compare: KeyComparator): string | undefined => {
+  const trailingNewline = text.endsWith('\n');
+  const body = trailingNewline ? text.slice(0, -1) : text;
+
+  switch (format) {
+    case 'json':
+      return sortJson(text, compare);
+    case 'yaml': {
+      const sorted = sortYaml(body, compare);
+      return sorted === undefined ? undefined : `${sorted}${trailingNewline ? '\n' : ''}`;
+    }
+    case 'env':
+    case 'properties':
+      return `${sortFlat(body, FLAT_FORMATS[format], compare)}${trailingNewline ? '\n' : ''}`;
+    default:
+      return undefined;
+  }
+};
@@ -0,0 +1,58 @@
+/**
+ * Labels - Pure functions for file labels
+ *
+ * Single Responsibility: Resolve the labels (`tier: critical`, `service: payments`)
+ * that `labels:` entries and `files:` entries attach to a file or its groups, and match
+ * label sets against selectors such as `--label tier=critical`.
+ * Pure functions, no state, no side effects
+ */
+
+import { AuditGroup, LabelRule } from '../types';
+import { matchesGlob } from './Glob';
+
+export type Labels = Record<string, string>;
+
+/**
+ * Pure function to parse a `key=value` selector
+ * @returns The selector as labels, or undefined when it has no `=` or no key
+ */
+export const parseLabelSelector = (text: string): Labels | undefined => {
+  const separator = text.indexOf('=');
+
+  // Guard clause: not a key=value pair
+  if (separator <= 0) {
+    return undefined;
+  }
+
+  return { [text.slice(0, separator).trim()]: text.slice(separator + 1).trim() };
+};
+
+/**
+ * Pure function to check that labels carry every label of a selector
+ */
+export const matchesLabels = (labels: Labels = {}, selector: Labels = {}): boolean =>
+  Object.entries(selector).every(([key, value]) => labels[key] === String(value));
+
+/**
+ * Pure function to resolve the labels of a file
+ * @param filePath - Path of the file
+ * @param rules - Label rules, in declaration order; later rules override earlier ones
+ * @param groups - Audit groups, for rules that label groups
+ * @returns Labels of the rules matching the file or one of its groups
+ */
+export const labelsOfFile = (filePath: string, rules: LabelRule[], groups: AuditGroup[] = []): Labels => {
+  const memberOf = groups.filter(group => group.files.includes(filePath)).map(group => group.name);
+
+  return rules
+    .filter(rule => rule.files.some(pattern => matchesGlob(filePath, pattern)) || rule.groups.some(group => memberOf.includes(group)))
+    .reduce<Labels>((labels, rule) => ({ ...labels, ...rule.labels }), {});
+};
+
+/**
+ * Pure function to keep the labels every set agrees on
+ * @returns Labels with the same value in all sets; none for no sets
+ */
+export const commonLabels = (sets: Labels[]): Labels => {
+  const [first, ...rest] = sets;
+  return Object.fromEntries(Object.entries(first ?? {}).filter(([key, value]) => rest.every(labels => labels[key] === value)));
+};
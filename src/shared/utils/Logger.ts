@@ -0,0 +1,113 @@
+/**
+ * Logger - Leveled structured logging
+ *
+ * Single Responsibility: Emit progress/diagnostic records on stderr so that
+ * result output written to stdout is never mixed with status chatter.
+ */
+
+export type LogLevel = 'debug' | 'info' | 'warn' | 'error' | 'silent';
+
+export type LogFormat = 'text' | 'json';
+
+export const LOG_LEVELS: LogLevel[] = ['debug', 'info', 'warn', 'error', 'silent'];
+
+export const LOG_FORMATS: LogFormat[] = ['text', 'json'];
+
+export interface LoggerOptions {
+  level?: LogLevel;
+  format?: LogFormat;
+  stream?: { write(chunk: string): any };
+  attributes?: Record<string, any>;
+}
+
+const LEVEL_WEIGHTS: Record<LogLevel, number> = {
+  debug: 10,
+  info: 20,
+  warn: 30,
+  error: 40,
+  silent: 100
+};
+
+/**
+ * Pure function to render a single attribute value for the text format
+ */
+const formatTextValue = (value: any): string => {
+  const text = typeof value === 'string' ? value : JSON.stringify(value);
+  return /[\s"=]/.test(text ?? '') ? JSON.stringify(text) : String(text);
+};
+
+/**
+ * Pure function to render a log record as `key=value` pairs
+ */
+export const formatTextRecord = (record: Record<string, any>): string =>
+  Object.entries(record)
+    .filter(([, value]) => value !== undefined)
+    .map(([key, value]) => `${key}=${formatTextValue(value)}`)
+    .join(' ');
+
+export class Logger {
+  private readonly level: LogLevel;
+  private readonly format: LogFormat;
+  private readonly stream: { write(chunk: string): any };
+  private readonly attributes: Record<string, any>;
+
+  constructor(options: LoggerOptions = {}) {
+    this.level = options.level ?? 'warn';
+    this.format = options.format ?? 'text';
+    this.stream = options.stream ?? process.stderr;
+    this.attributes = options.attributes ?? {};
+  }
+
+  debug(message: string, attributes: Record<string, any> = {}): void {
+    this.log('debug', message, attributes);
+  }
+
+  info(message: string, attributes: Record<string, any> = {}): void {
+    this.log('info', message, attributes);
+  }
+
+  warn(message: string, attributes: Record<string, any> = {}): void {
+    this.log('warn', message, attributes);
+  }
+
+  error(message: string, attributes: Record<string, any> = {}): void {
+    this.log('error', message, attributes);
+  }
+
+  /**
+   * Create a logger that adds the given attributes to every record
+   */
+  with(attributes: Record<string, any>): Logger {
+    return new Logger({
+      level: this.level,
+      format: this.format,
+      stream: this.stream,
+      attributes: { ...this.attributes, ...attributes }
+    });
+  }
+
+  /**
+   * Check if records of a level would be emitted
+   */
+  isEnabled(level: LogLevel): boolean {
+    return level !== 'silent' && LEVEL_WEIGHTS[level] >= LEVEL_WEIGHTS[this.level];
+  }
+
+  private log(level: LogLevel, message: string, attributes: Record<string, any>): void {
+    // Guard clause: level filtered out
+    if (!this.isEnabled(level)) {
+      return;
+    }
+
+    const record = {
+      time: new Date().toISOString(),
+      level: level.toUpperCase(),
+      msg: message,
+      ...this.attributes,
+      ...attributes
+    };
+
+    const line = this.format === 'json' ? JSON.stringify(record) : formatTextRecord(record);
+    this.stream.write(`${line}\n`);
+  }
+}
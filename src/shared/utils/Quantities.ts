@@ -0,0 +1,158 @@
+/**
+ * Quantities - Canonical durations and sizes
+ *
+ * Single Responsibility: Parse duration ("30s", "1h30m", "PT30S") and size ("10MB", "512Mi")
+ * values into milliseconds and bytes, so rules compare quantities instead of spellings:
+ * `30s`, `30000ms` and `30000` are the same timeout.
+ * Pure functions, no state, no side effects
+ */
+
+export type QuantityKind = 'duration' | 'size';
+
+export interface Quantity {
+  kind: QuantityKind;
+  /** Milliseconds for durations, bytes for sizes */
+  amount: number;
+}
+
+const SECOND = 1000;
+const MINUTE = 60 * SECOND;
+const HOUR = 60 * MINUTE;
+const DAY = 24 * HOUR;
+
+/**
+ * Duration units in milliseconds; lowercase only, so `10M` stays a size
+ */
+export const DURATION_UNITS: Record<string, number> = {
+  ns: 1e-6,
+  us: 1e-3,
+  µs: 1e-3,
+  ms: 1,
+  s: SECOND,
+  m: MINUTE,
+  h: HOUR,
+  d: DAY,
+  w: 7 * DAY,
+};
+
+const KIB = 1024;
+
+/**
+ * Size units in bytes (binary multiples: `1MB` = `1MiB` = 1048576 bytes)
+ */
+export const SIZE_UNITS: Record<string, number> = {
+  b: 1,
+  kb: KIB,
+  mb: KIB ** 2,
+  gb: KIB ** 3,
+  tb: KIB ** 4,
+  pb: KIB ** 5,
+  kib: KIB,
+  mib: KIB ** 2,
+  gib: KIB ** 3,
+  tib: KIB ** 4,
+  pib: KIB ** 5,
+};
+
+// Single-letter size suffixes as used by JVM and Kubernetes settings (`512M`, `2Gi`); uppercase only
+const SHORT_SIZE_UNITS: Record<string, number> = {
+  K: KIB,
+  M: KIB ** 2,
+  G: KIB ** 3,
+  T: KIB ** 4,
+  P: KIB ** 5,
+  Ki: KIB,
+  Mi: KIB ** 2,
+  Gi: KIB ** 3,
+  Ti: KIB ** 4,
+  Pi: KIB ** 5,
+};
+
+const NUMBER = '\\d+(?:\\.\\d+)?';
+const DURATION_PART = new RegExp(`(${NUMBER})(ns|us|µs|ms|s|m|h|d|w)`, 'g');
+const UNIT_DURATION = new RegExp(`^(?:${NUMBER}(?:ns|us|µs|ms|s|m|h|d|w))+$`);
+const ISO_DURATION = /^P(?!$)(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?=\d)(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$/;
+const SIZE = new RegExp(`^(${NUMBER})\\s*([a-zA-Z]+)$`);
+const PLAIN_NUMBER = new RegExp(`^-?${NUMBER}$`);
+
+/**
+ * Pure function to parse a duration written with units into milliseconds.
+ * Accepts Go-style durations (`30s`, `1h30m`, `250ms`) and ISO 8601 durations (`PT30S`, `P1D`;
+ * years and months count as 365 and 30 days). Bare numbers are not durations.
+ */
+export const parseDurationString = (text: string): number | undefined => {
+  const trimmed = text.trim();
+
+  if (UNIT_DURATION.test(trimmed)) {
+    return [...trimmed.matchAll(DURATION_PART)]
+      .reduce((total, [, amount, unit]) => total + Number(amount) * DURATION_UNITS[unit], 0);
+  }
+
+  const iso = ISO_DURATION.exec(trimmed);
+
+  // Guard clause: no known duration syntax
+  if (!iso) {
+    return undefined;
+  }
+
+  const [, years, months, weeks, days, hours, minutes, seconds] = iso.map(part => Number(part ?? 0));
+  return (years * 365 + months * 30 + weeks * 7 + days) * DAY + hours * HOUR + minutes * MINUTE + seconds * SECOND;
+};
+
+/**
+ * Pure function to parse a size written with a unit into bytes (`10MB`, `512Mi`, `1.5 GiB`).
+ * Bare numbers are not sizes.
+ */
+export const parseSizeString = (text: string): number | undefined => {
+  const match = SIZE.exec(text.trim());
+
+  // Guard clause: no number followed by a unit
+  if (!match) {
+    return undefined;
+  }
+
+  const [, amount, unit] = match;
+  const multiplier = SHORT_SIZE_UNITS[unit] ?? (/[bB]$/.test(unit) ? SIZE_UNITS[unit.toLowerCase()] : undefined);
+  return multiplier !== undefined ? Number(amount) * multiplier : undefined;
+};
+
+/**
+ * Pure function to read a value written with a duration or size unit
+ */
+export const quantityOf = (value: unknown): Quantity | undefined => {
+  // Guard clause: only strings carry units
+  if (typeof value !== 'string') {
+    return undefined;
+  }
+
+  const duration = parseDurationString(value);
+  if (duration !== undefined) {
+    return { kind: 'duration', amount: duration };
+  }
+
+  const size = parseSizeString(value);
+  return size !== undefined ? { kind: 'size', amount: size } : undefined;
+};
+
+/**
+ * Pure function to normalize a value to its amount in base units (milliseconds or bytes).
+ * Numbers and numeric strings are taken as already in base units.
+ * @param kind - Expected kind; a value of the other kind is not normalized
+ */
+export const normalizeQuantity = (value: unknown, kind?: QuantityKind): number | undefined => {
+  if (typeof value === 'number') {
+    return Number.isFinite(value) ? value : undefined;
+  }
+
+  // Guard clause: not a string either
+  if (typeof value !== 'string') {
+    return undefined;
+  }
+
+  if (PLAIN_NUMBER.test(value.trim())) {
+    return Number(value);
+  }
+
+  const quantity = quantityOf(value);
+  return quantity && (!kind || quantity.kind === kind) ? quantity.amount : undefined;
+};
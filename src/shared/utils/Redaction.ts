@@ -0,0 +1,131 @@
+/**
+ * Redaction - Policies hiding raw configuration values
+ *
+ * Single Responsibility: Decide how a raw value is shown wherever it could leave
+ * the tool (finding context, rendered messages, secret snippets). Security teams
+ * pick a policy so no plaintext values are emitted.
+ */
+
+import { createHash, createHmac } from 'crypto';
+import { FindingContext, RedactionPolicyName, RedactionSettings, ValidationError, ValidationResult } from '../types';
+import { canonicalJson } from './CanonicalHash';
+import { collectFindings, withFindings } from './Findings';
+import { globToRegExp } from './Glob';
+
+export interface Redactor {
+  name: RedactionPolicyName;
+  /** Redacts a raw value found under a configuration key */
+  redact(value: unknown, key?: string): unknown;
+}
+
+export const REDACTION_POLICIES: RedactionPolicyName[] = ['none', 'mask-all', 'mask-secret-keys', 'hash'];
+
+export const REDACTED = '***';
+
+/**
+ * Environment variable holding the salt of the hash policy; a shared secret, so it is never read from praetorian.yaml
+ */
+export const HASH_SALT_ENV = 'PRAETORIAN_HASH_SALT';
+
+/** Keys whose values are secrets under mask-secret-keys */
+export const SECRET_KEY_PATTERN = /(pass(word|wd)?|secret|token|api[_-]?key|private[_-]?key|credential|auth)/i;
+
+/** Finding context fields that carry raw values */
+export const VALUE_FIELDS: Array<keyof FindingContext> = ['observedValue', 'expectedValue'];
+
+/**
+ * Pure function to check if a value is empty (empty values carry no plaintext and stay visible)
+ */
+const isEmpty = (value: unknown): boolean =>
+  value === null || value === undefined || value === '';
+
+/**
+ * Pure function to apply a leaf redaction to a value and everything nested in it
+ */
+const deepRedact = (value: unknown, redactLeaf: (leaf: unknown) => unknown): unknown => {
+  if (Array.isArray(value)) return value.map(item => deepRedact(item, redactLeaf));
+  if (value && typeof value === 'object') {
+    return Object.fromEntries(Object.entries(value).map(([key, item]) => [key, deepRedact(item, redactLeaf)]));
+  }
+  return isEmpty(value) ? value : redactLeaf(value);
+};
+
+/**
+ * Pure function to hash a value so equal values stay comparable without being readable.
+ * With a salt the hash is an HMAC: only parties sharing the salt can compare values, and
+ * short or common values cannot be looked up in precomputed tables. Mappings hash alike
+ * whatever their key order.
+ */
+export const hashValue = (value: unknown, salt?: string): string =>
+  salt
+    ? `hmac-sha256:${createHmac('sha256', salt).update(canonicalJson(value)).digest('hex').slice(0, 16)}`
+    : `sha256:${createHash('sha256').update(canonicalJson(value)).digest('hex').slice(0, 16)}`;
+
+/**
+ * Pure function to find the salt of the hash policy: the settings, else the environment
+ */
+export const hashSaltOf = (settings: RedactionSettings = {}, env: Record<string, string | undefined> = process.env): string | undefined =>
+  settings.salt || env[HASH_SALT_ENV] || undefined;
+
+/**
+ * Pure function to check if a key holds a secret
+ */
+export const isSecretKey = (key: string | undefined, extraPatterns: string[] = []): boolean =>
+  key !== undefined &&
+  (SECRET_KEY_PATTERN.test(key) || extraPatterns.some(pattern => globToRegExp(pattern).test(key)));
+
+/**
+ * Pure function to check if a name is a known redaction policy
+ */
+export const isRedactionPolicy = (name: unknown): name is RedactionPolicyName =>
+  typeof name === 'string' && (REDACTION_POLICIES as string[]).includes(name);
+
+/**
+ * Pure function to build the redactor of a policy (none by default)
+ * @param settings - Policy, secret key patterns and hash salt
+ * @param env - Environment holding PRAETORIAN_HASH_SALT when the settings carry no salt
+ */
+export const createRedactor = (settings: RedactionSettings = {}, env: Record<string, string | undefined> = process.env): Redactor => {
+  switch (settings.policy) {
+    case 'mask-all':
+      return { name: 'mask-all', redact: value => deepRedact(value, () => REDACTED) };
+    case 'mask-secret-keys':
+      return {
+        name: 'mask-secret-keys',
+        redact: (value, key) => isSecretKey(key, settings.secretKeys) ? deepRedact(value, () => REDACTED) : value
+      };
+    case 'hash': {
+      const salt = hashSaltOf(settings, env);
+      return { name: 'hash', redact: value => deepRedact(value, leaf => hashValue(leaf, salt)) };
+    }
+    default:
+      return { name: 'none', redact: value => value };
+  }
+};
+
+/**
+ * Pure function to redact the value fields of a finding's context
+ */
+export const redactFinding = (finding: ValidationError, redactor: Redactor): ValidationError => {
+  // Guard clause: nothing that could carry a value
+  if (!finding.context || redactor.name === 'none') {
+    return finding;
+  }
+
+  const key = finding.path ?? finding.context.keyPath;
+  const redacted = Object.fromEntries(
+    VALUE_FIELDS
+      .filter(field => finding.context?.[field] !== undefined)
+      .map(field => [field, redactor.redact(finding.context?.[field], key)])
+  );
+
+  return { ...finding, context: { ...finding.context, ...redacted } };
+};
+
+/**
+ * Pure function to redact every finding of a result
+ */
+export const redactResult = (result: ValidationResult, redactor: Redactor): ValidationResult =>
+  redactor.name === 'none'
+    ? result
+    : withFindings(result, collectFindings(result).map(finding => redactFinding(finding, redactor)));
@@ -80,7 +80,7 @@ export class ResultBuilder {
         code: 'VALIDATION_ERROR',
         message: error instanceof Error ? error.message : 'Unknown validation error',
         severity: 'error',
-        context: { error: error }
+        context: { extras: { error } }
       }],
       warnings: [],
       metadata: {
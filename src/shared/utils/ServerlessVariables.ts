@@ -0,0 +1,228 @@
+/**
+ * ServerlessVariables - Serverless Framework variable resolution
+ *
+ * Single Responsibility: Resolve the `${source:address, fallback}` variables of a serverless.yml
+ * for one stage — `opt:stage`, `sls:stage`, `self:`, `param:` and `env:` — far enough to tell
+ * which keys a stage ends up with. Sources resolved at deploy time (`ssm:`, `file(...)`, `cf:`, ...)
+ * are kept as written.
+ * Pure functions, no state, no side effects
+ */
+
+import { isPlainObject } from './KeyPaths';
+
+export interface VariableScope {
+  /** Stage being resolved; without one, `${opt:stage}` falls back to its default */
+  stage?: string;
+  /** The whole configuration, for `${self:...}` */
+  self: Record<string, any>;
+  /** Process environment, for `${env:...}` */
+  env?: Record<string, string | undefined>;
+}
+
+export interface Resolution {
+  /** Resolved value; undefined when a `self:` or `param:` variable has no value and no fallback */
+  value: unknown;
+  /** Addresses that did not resolve, e.g. `self:custom.prod.dbHost` */
+  unresolved: string[];
+}
+
+// Sources whose values live in the file itself: a missing value there is drift
+const FILE_SOURCES = ['self', 'param'];
+const MAX_DEPTH = 20;
+
+/**
+ * Pure function to find the `}` closing the `${` at a position
+ */
+const closingBrace = (text: string, start: number): number => {
+  let depth = 0;
+
+  for (let index = start; index < text.length; index++) {
+    if (text.startsWith('${', index)) {
+      depth++;
+      index++;
+    } else if (text[index] === '}' && --depth === 0) {
+      return index;
+    }
+  }
+
+  return -1;
+};
+
+/**
+ * Pure function to split a variable body at the commas outside nested variables and quotes
+ */
+export const splitVariableBody = (body: string): string[] => {
+  const parts: string[] = [];
+  let depth = 0;
+  let quote: string | undefined;
+  let current = '';
+
+  for (let index = 0; index < body.length; index++) {
+    const char = body[index];
+
+    if (quote) {
+      quote = char === quote ? undefined : quote;
+    } else if (char === '"' || char === "'") {
+      quote = char;
+    } else if (body.startsWith('${', index)) {
+      depth++;
+    } else if (char === '}') {
+      depth--;
+    } else if (char === ',' && depth === 0) {
+      parts.push(current.trim());
+      current = '';
+      continue;
+    }
+
+    current += char;
+  }
+
+  return [...parts, current.trim()];
+};
+
+/**
+ * Pure function to read a dotted path of an object
+ */
+const valueAt = (content: unknown, dottedPath: string): unknown =>
+  dottedPath === ''
+    ? content
+    : dottedPath.split('.').reduce<unknown>(
+      (value, key) => (isPlainObject(value) || Array.isArray(value) ? (value as any)[key] : undefined),
+      content
+    );
+
+/**
+ * Pure function to look up one address; undefined when the source has no value for it
+ */
+const lookup = (address: string, scope: VariableScope, depth: number): Resolution | undefined => {
+  const colon = address.indexOf(':');
+  const source = colon > 0 ? address.slice(0, colon) : '';
+  const target = address.slice(colon + 1);
+
+  switch (source) {
+    case 'opt':
+      return target === 'stage' && scope.stage !== undefined ? { value: scope.stage, unresolved: [] } : undefined;
+    case 'sls':
+      return target === 'stage' && scope.stage !== undefined ? { value: scope.stage, unresolved: [] } : undefined;
+    case 'env':
+      return scope.env?.[target] !== undefined ? { value: scope.env[target], unresolved: [] } : undefined;
+    case 'self': {
+      const value = valueAt(scope.self, target);
+      return value !== undefined ? resolveValue(value, scope, depth + 1) : undefined;
+    }
+    case 'param': {
+      const params = isPlainObject(scope.self.params) ? scope.self.params : {};
+      const value = [scope.stage, 'default']
+        .map(stage => (stage !== undefined && isPlainObject(params[stage]) ? params[stage][target] : undefined))
+        .find(candidate => candidate !== undefined);
+      return value !== undefined ? resolveValue(value, scope, depth + 1) : undefined;
+    }
+    default:
+      // Resolved at deploy time (ssm, s3, cf, file(...)) or not a Serverless variable at all (`${AWS::Region}`)
+      return { value: `\${${address}}`, unresolved: [] };
+  }
+};
+
+/**
+ * Pure function to resolve a fallback: a quoted literal, a number, a bare address or a variable
+ */
+const resolveFallback = (fallback: string, scope: VariableScope, depth: number): Resolution | undefined => {
+  const quoted = /^(['"])(.*)\1$/.exec(fallback);
+
+  if (quoted) {
+    return { value: quoted[2], unresolved: [] };
+  }
+
+  if (/^-?\d+(\.\d+)?$/.test(fallback)) {
+    return { value: Number(fallback), unresolved: [] };
+  }
+
+  if (/^[a-zA-Z]+:/.test(fallback)) {
+    return lookup(fallback, scope, depth);
+  }
+
+  const resolution = resolveText(fallback, scope, depth + 1);
+  return resolution.unresolved.length === 0 && resolution.value !== undefined ? resolution : undefined;
+};
+
+/**
+ * Pure function to resolve the body of one `${...}` variable
+ */
+const resolveVariable = (body: string, scope: VariableScope, depth: number): Resolution => {
+  const [addressText, ...fallbacks] = splitVariableBody(body);
+  const address = resolveText(addressText, scope, depth + 1);
+  const addressName = String(address.value);
+
+  // Guard clause: cyclic references never settle
+  if (depth > MAX_DEPTH) {
+    return { value: undefined, unresolved: [addressName] };
+  }
+
+  const found = address.unresolved.length === 0 ? lookup(addressName, scope, depth) : undefined;
+  const resolved = found ?? fallbacks.reduce<Resolution | undefined>(
+    (result, fallback) => result ?? resolveFallback(fallback, scope, depth),
+    undefined
+  );
+
+  if (resolved) {
+    return resolved;
+  }
+
+  // CLI options and environment variables are supplied at deploy time; only file values can drift
+  const source = addressName.slice(0, addressName.indexOf(':'));
+  return FILE_SOURCES.includes(source) || address.unresolved.length > 0
+    ? { value: undefined, unresolved: address.unresolved.length > 0 ? address.unresolved : [addressName] }
+    : { value: `\${${body}}`, unresolved: [] };
+};
+
+/**
+ * Pure function to resolve the variables of a string. A string that is exactly one variable
+ * takes the type of its value (a `${self:custom.env}` can be a whole mapping).
+ */
+export const resolveText = (text: string, scope: VariableScope, depth: number = 0): Resolution => {
+  const start = text.indexOf('${');
+  const end = start >= 0 ? closingBrace(text, start) : -1;
+
+  // Guard clause: nothing (well-formed) to resolve
+  if (end < 0) {
+    return { value: text, unresolved: [] };
+  }
+
+  const variable = resolveVariable(text.slice(start + 2, end), scope, depth);
+
+  if (start === 0 && end === text.length - 1) {
+    return variable;
+  }
+
+  const rest = resolveText(text.slice(end + 1), scope, depth);
+  const inserted = variable.value === undefined ? text.slice(start, end + 1) : String(variable.value);
+  return {
+    value: `${text.slice(0, start)}${inserted}${rest.value}`,
+    unresolved: [...variable.unresolved, ...rest.unresolved],
+  };
+};
+
+/**
+ * Pure function to resolve every string of a value
+ */
+export const resolveValue = (value: unknown, scope: VariableScope, depth: number = 0): Resolution => {
+  if (typeof value === 'string') {
+    return resolveText(value, scope, depth);
+  }
+
+  if (Array.isArray(value)) {
+    const items = value.map(item => resolveValue(item, scope, depth));
+    return { value: items.map(item => item.value), unresolved: items.flatMap(item => item.unresolved) };
+  }
+
+  // Guard clause: numbers, booleans and null hold no variables
+  if (!isPlainObject(value)) {
+    return { value, unresolved: [] };
+  }
+
+  const entries = Object.entries(value).map(([key, child]) => [key, resolveValue(child, scope, depth)] as const);
+  return {
+    value: Object.fromEntries(entries.map(([key, child]) => [key, child.value])),
+    unresolved: entries.flatMap(([, child]) => child.unresolved),
+  };
+};
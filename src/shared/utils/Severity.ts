@@ -0,0 +1,27 @@
+/**
+ * Severity - Pure functions for finding severities
+ *
+ * Single Responsibility: Order and compare validation severities
+ */
+
+import { ValidationSeverity } from '../types';
+
+export const SEVERITIES: ValidationSeverity[] = ['info', 'warning', 'error'];
+
+const SEVERITY_RANK: Record<ValidationSeverity, number> = {
+  info: 0,
+  warning: 1,
+  error: 2
+};
+
+/**
+ * Pure function to check if a value is a known severity
+ */
+export const isSeverity = (value: any): value is ValidationSeverity =>
+  typeof value === 'string' && Object.prototype.hasOwnProperty.call(SEVERITY_RANK, value);
+
+/**
+ * Pure function to check if a severity is at or above a threshold
+ */
+export const isAtLeast = (severity: ValidationSeverity, threshold: ValidationSeverity): boolean =>
+  SEVERITY_RANK[severity] >= SEVERITY_RANK[threshold];
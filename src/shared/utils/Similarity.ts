@@ -0,0 +1,65 @@
+/**
+ * Similarity - Close matches between names
+ *
+ * Single Responsibility: Measure how far apart two names are and pick the closest candidates,
+ * for "did you mean" hints and rename detection.
+ * Pure functions, no state, no side effects
+ */
+
+/** Largest edit distance, relative to the longer name, still read as a typo */
+export const TYPO_DISTANCE_RATIO = 0.34;
+
+/** Most suggestions given for one name */
+export const MAX_SUGGESTIONS = 3;
+
+/**
+ * Pure function to count the single-character edits turning one text into another
+ */
+export const levenshtein = (a: string, b: string): number => {
+  const row = Array.from({ length: b.length + 1 }, (_, index) => index);
+
+  [...a].forEach((charA, indexA) => {
+    let diagonal = row[0];
+    row[0] = indexA + 1;
+    [...b].forEach((charB, indexB) => {
+      const above = row[indexB + 1];
+      row[indexB + 1] = Math.min(above + 1, row[indexB] + 1, diagonal + (charA === charB ? 0 : 1));
+      diagonal = above;
+    });
+  });
+
+  return row[b.length];
+};
+
+/**
+ * Pure function to pick the candidates closest to a name, ignoring case
+ * @param name - Name looked for
+ * @param candidates - Names that exist
+ * @returns At most MAX_SUGGESTIONS candidates within TYPO_DISTANCE_RATIO of the name, closest first
+ */
+export const closestMatches = (name: string, candidates: Iterable<string>): string[] =>
+  [...new Set(candidates)]
+    .filter(candidate => candidate !== name)
+    .map(candidate => ({
+      candidate,
+      ratio: levenshtein(name.toLowerCase(), candidate.toLowerCase()) / Math.max(name.length, candidate.length),
+    }))
+    .filter(match => match.ratio <= TYPO_DISTANCE_RATIO)
+    .sort((a, b) => a.ratio - b.ratio || a.candidate.localeCompare(b.candidate))
+    .slice(0, MAX_SUGGESTIONS)
+    .map(match => match.candidate);
+
+/**
+ * Pure function to write suggestions as a message suffix
+ * @returns ` (did you mean 'a' or 'b'?)`, or an empty string without suggestions
+ */
+export const didYouMean = (suggestions: string[]): string => {
+  // Guard clause: nothing to suggest
+  if (suggestions.length === 0) {
+    return '';
+  }
+
+  const quoted = suggestions.map(suggestion => `'${suggestion}'`);
+  const listed = quoted.length === 1 ? quoted[0] : `${quoted.slice(0, -1).join(', ')} or ${quoted[quoted.length - 1]}`;
+  return ` (did you mean ${listed}?)`;
+};
@@ -0,0 +1,21 @@
+/**
+ * Template - Pure `{{placeholder}}` rendering
+ *
+ * Single Responsibility: Fill `{{name}}` placeholders from a variables object.
+ * Dotted names read nested values; unknown names render empty.
+ */
+
+const PLACEHOLDER = /\{\{\s*([\w.]+)\s*\}\}/g;
+
+/**
+ * Pure function to render a template
+ */
+export const renderTemplate = (template: string, variables: Record<string, any>): string =>
+  template.replace(PLACEHOLDER, (_match, name: string) => {
+    const value = name.split('.').reduce((current: any, segment) => current?.[segment], variables);
+
+    if (value === undefined || value === null) {
+      return '';
+    }
+    return Array.isArray(value) ? value.join(', ') : typeof value === 'object' ? JSON.stringify(value) : String(value);
+  });
@@ -0,0 +1,42 @@
+/**
+ * Timing - Helpers for performance metadata
+ *
+ * Single Responsibility: Measure durations and assemble the per-file and
+ * per-rule timing breakdown stored in result metadata.
+ */
+
+import { performance } from 'perf_hooks';
+import { ConfigFile, PerformanceMetadata } from '../types';
+
+/**
+ * Pure function to round a duration to hundredths of a millisecond
+ */
+export const roundMs = (ms: number): number => Math.round(ms * 100) / 100;
+
+/**
+ * Run an async operation and report how long it took
+ */
+export const measure = async <T>(operation: () => Promise<T>): Promise<{ value: T; ms: number }> => {
+  const startedAt = performance.now();
+  const value = await operation();
+  return { value, ms: roundMs(performance.now() - startedAt) };
+};
+
+/**
+ * Pure function to build performance metadata from parsed files and rule timings
+ */
+export const buildPerformanceMetadata = (
+  files: ConfigFile[],
+  rules: Array<{ id: string; evaluationMs: number }>,
+  totalMs: number
+): PerformanceMetadata => ({
+  totalMs: roundMs(totalMs),
+  files: files.map(file => ({ path: file.path, format: file.format, parseMs: file.metadata?.parseMs ?? 0 })),
+  rules
+});
+
+/**
+ * Pure function to list the slowest entries first
+ */
+export const slowestFirst = <T>(entries: T[], duration: (entry: T) => number): T[] =>
+  [...entries].sort((a, b) => duration(b) - duration(a));
@@ -0,0 +1,167 @@
+/**
+ * ValueTypes - Built-in value types referenced by name
+ *
+ * Single Responsibility: Tell whether a configuration value has a given type —
+ * structural (string, number, ...) or syntactic (ipv4, cidr, hostname, duration, size, timezone, ...) —
+ * so `schema:` entries and format rules catch common mistakes without regex authoring.
+ * Pure functions, no state, no side effects
+ */
+
+import { isIP, isIPv4, isIPv6 } from 'net';
+import { parseCron } from './Cron';
+import { parseDurationString, parseSizeString } from './Quantities';
+
+const HOSTNAME_LABEL = /^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$/;
+const EMAIL = /^[^\s@]+@[^\s@]+\.[^\s@]+$/;
+const URL_SCHEME = /^[a-zA-Z][a-zA-Z0-9+.-]*:\/\//;
+
+// Active ISO 4217 codes, funds and precious metals included; XTS (testing) and XXX (no currency) left out
+const CURRENCY_CODES = new Set(`
+  AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD
+  CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL
+  GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD
+  KYD KZT LAK LBP LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO
+  NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS
+  SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND
+  VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XCG XDR XOF XPD XPF XPT XSU XUA YER ZAR ZMW ZWG ZWL
+`.trim().split(/\s+/));
+
+/**
+ * Pure function to check a hostname (RFC 1123); a trailing dot is allowed
+ */
+export const isHostname = (value: string): boolean => {
+  const name = value.endsWith('.') ? value.slice(0, -1) : value;
+
+  // Guard clause: empty or too long
+  if (name.length === 0 || name.length > 253) {
+    return false;
+  }
+
+  return name.split('.').every(label => HOSTNAME_LABEL.test(label));
+};
+
+/**
+ * Pure function to check a CIDR block such as `10.0.0.0/8` or `2001:db8::/32`
+ */
+export const isCidr = (value: string): boolean => {
+  const match = /^([^/]+)\/(\d{1,3})$/.exec(value);
+
+  // Guard clause: no prefix length
+  if (!match) {
+    return false;
+  }
+
+  const version = isIP(match[1]);
+  const prefix = Number(match[2]);
+  return version !== 0 && prefix <= (version === 4 ? 32 : 128);
+};
+
+/**
+ * Pure function to check a port number (1-65535), given as a number or a numeric string
+ */
+export const isPort = (value: unknown): boolean => {
+  const text = typeof value === 'number' ? String(value) : value;
+  return typeof text === 'string' && /^\d{1,5}$/.test(text) && Number(text) >= 1 && Number(text) <= 65535;
+};
+
+/**
+ * Pure function to check an absolute URL with a scheme and a host
+ */
+export const isUrl = (value: string): boolean => {
+  // Guard clause: `new URL` alone accepts `localhost:8080` as a URL with scheme `localhost:`
+  if (!URL_SCHEME.test(value)) {
+    return false;
+  }
+
+  try {
+    return new URL(value).host !== '' || value.startsWith('file:');
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Pure function to check an IANA time zone such as `Europe/Madrid`, `UTC` or `Etc/GMT+5`;
+ * links (`US/Eastern`) are accepted, raw offsets (`+02:00`) are not
+ */
+export const isTimezone = (value: string): boolean => {
+  try {
+    return /^[A-Za-z]/.test(value) && new Intl.DateTimeFormat('en-US', { timeZone: value }).resolvedOptions().timeZone !== '';
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Pure function to check a BCP 47 language tag such as `en`, `es-AR` or `zh-Hant-TW`;
+ * POSIX locales (`en_US.UTF-8`) are not tags
+ */
+export const isLocale = (value: string): boolean => {
+  try {
+    return Intl.getCanonicalLocales(value).length === 1;
+  } catch {
+    return false;
+  }
+};
+
+/**
+ * Pure function to check an ISO 4217 currency code; `usd` is accepted as some payment APIs use lower case
+ */
+export const isCurrency = (value: string): boolean =>
+  /^([A-Z]{3}|[a-z]{3})$/.test(value) && CURRENCY_CODES.has(value.toUpperCase());
+
+const stringType = (check: (value: string) => boolean) =>
+  (value: unknown): boolean => typeof value === 'string' && check(value);
+
+/**
+ * Pure function registry of the structural types
+ */
+export const STRUCTURAL_TYPES: Record<string, (value: unknown) => boolean> = {
+  string: value => typeof value === 'string',
+  number: value => typeof value === 'number' && Number.isFinite(value),
+  integer: value => typeof value === 'number' && Number.isInteger(value),
+  boolean: value => typeof value === 'boolean',
+  object: value => value !== null && typeof value === 'object' && !Array.isArray(value),
+  array: value => Array.isArray(value),
+};
+
+/**
+ * Pure function registry of the syntactic types, also usable as `format` of rules
+ */
+export const FORMAT_TYPES: Record<string, (value: unknown) => boolean> = {
+  ipv4: stringType(isIPv4),
+  ipv6: stringType(isIPv6),
+  cidr: stringType(isCidr),
+  hostname: stringType(isHostname),
+  email: stringType(value => EMAIL.test(value)),
+  duration: stringType(value => parseDurationString(value) !== undefined),
+  size: stringType(value => parseSizeString(value) !== undefined),
+  url: stringType(isUrl),
+  port: isPort,
+  timezone: stringType(isTimezone),
+  locale: stringType(isLocale),
+  currency: stringType(isCurrency),
+  cron: stringType(value => parseCron(value).valid),
+};
+
+export const VALUE_TYPES: Record<string, (value: unknown) => boolean> = { ...STRUCTURAL_TYPES, ...FORMAT_TYPES };
+
+export const VALUE_TYPE_NAMES = Object.keys(VALUE_TYPES);
+
+/**
+ * Pure function to check if a name is a built-in value type
+ */
+export const isValueType = (name: unknown): name is string =>
+  typeof name === 'string' && Object.prototype.hasOwnProperty.call(VALUE_TYPES, name);
+
+/**
+ * Pure function to check if a name is a built-in syntactic type
+ */
+export const isFormatType = (name: unknown): name is string =>
+  typeof name === 'string' && Object.prototype.hasOwnProperty.call(FORMAT_TYPES, name);
+
+/**
+ * Pure function to check a value against a built-in value type; unknown types never match
+ */
+export const matchesValueType = (name: string, value: unknown): boolean =>
+  isValueType(name) && VALUE_TYPES[name](value);
@@ -6,6 +6,7 @@
 
 import {
   calculateAuditSummary,
+  calculateAuditorBreakdown,
   calculateMetrics,
   calculateScore,
   calculateGrade,
@@ -164,6 +165,29 @@ describe('AuditCalculator', () => {
     });
   });
 
+  describe('calculateAuditorBreakdown', () => {
+    it('should return one entry per auditor result', () => {
+      const results: ValidationResult[] = [
+        {
+          success: false,
+          errors: [{ code: 'HARDCODED_SECRET', message: 'Secret found', severity: 'error' }],
+          warnings: [],
+          metadata: { auditType: 'security', rulesChecked: 3, rulesPassed: 2, rulesFailed: 1 }
+        },
+        { success: true, errors: [], warnings: [] }
+      ];
+
+      expect(calculateAuditorBreakdown(results)).toEqual([
+        { auditType: 'security', rulesChecked: 3, rulesPassed: 2, rulesFailed: 1, errors: 1, warnings: 0 },
+        { auditType: 'unknown', rulesChecked: 0, rulesPassed: 0, rulesFailed: 0, errors: 0, warnings: 0 }
+      ]);
+    });
+
+    it('should return an empty breakdown for no results', () => {
+      expect(calculateAuditorBreakdown([])).toEqual([]);
+    });
+  });
+
   describe('calculateScore', () => {
     it('should return 100 for no metrics', () => {
       const result = calculateScore(null as any);
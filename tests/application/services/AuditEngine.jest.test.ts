@@ -23,6 +23,28 @@ describe('AuditEngine', () => {
     expect(result).toHaveProperty('grade');
   });
 
+  it('should report a typed per-auditor breakdown in metadata', async () => {
+    const result = await auditEngine.audit(context);
+
+    expect(result.metadata?.auditors?.map(auditor => auditor.auditType))
+      .toEqual(['security', 'compliance', 'performance']);
+    expect(result.metadata?.rulesChecked).toBe(result.totalChecks);
+  });
+
+  it('should skip auditors scoped away from every file', async () => {
+    const result = await auditEngine.audit({
+      ...context,
+      files: { 'app.yaml': {}, '.env': {} },
+      scopes: [
+        { rules: ['security'], files: ['*.env', 'secrets/*.yaml'] },
+        { rules: ['compliance'], files: ['schemas/*.json'] },
+        { rules: ['performance'], enabled: false }
+      ]
+    });
+
+    expect(result.metadata?.auditors?.map(auditor => auditor.auditType)).toEqual(['security']);
+  });
+
   it('should handle invalid context gracefully', async () => {
     await expect(auditEngine.audit(null as any)).rejects.toThrow();
   });
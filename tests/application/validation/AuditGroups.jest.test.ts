@@ -0,0 +1,77 @@
+import {
+  compareByGroup,
+  filesOfGroup,
+  groupFiles,
+  mergeGroupResults
+} from '../../../src/application/validation/AuditGroups';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string): ConfigFile => ({ path, format: 'yaml', content: {} });
+
+const missingIn = (files: ConfigFile[]): ValidationResult => ({
+  success: files.length < 2,
+  errors: files.slice(1).map(({ path }) => ({
+    code: 'MISSING_KEY',
+    message: `Key 'port' is missing in ${path}`,
+    severity: 'error' as const,
+    path: 'port',
+    context: { file: path }
+  })),
+  warnings: [],
+  metadata: { duration: 5, filesCompared: files.length, totalKeys: 2, rulesChecked: 1 }
+});
+
+describe('AuditGroups', () => {
+  const groups = [
+    { name: 'service-a', files: ['a/dev.yaml', 'a/prod.yaml'] },
+    { name: 'service-b', files: ['b/dev.yaml', 'shared.yaml'] },
+    { name: 'service-c', files: ['shared.yaml'] }
+  ];
+  const loaded = ['a/dev.yaml', 'a/prod.yaml', 'b/dev.yaml', 'shared.yaml'].map(file);
+
+  it('should list each group file once', () => {
+    expect(groupFiles(groups)).toEqual(['a/dev.yaml', 'a/prod.yaml', 'b/dev.yaml', 'shared.yaml']);
+  });
+
+  it('should pick the loaded files of a group', () => {
+    expect(filesOfGroup(groups[1], loaded).map(({ path }) => path)).toEqual(['b/dev.yaml', 'shared.yaml']);
+    expect(filesOfGroup({ name: 'x', files: ['./a/dev.yaml'] }, loaded)).toHaveLength(1);
+  });
+
+  it('should compare files only within their group', async () => {
+    const compared: string[][] = [];
+
+    const result = await compareByGroup(groups, loaded, async files => {
+      compared.push(files.map(({ path }) => path));
+      return missingIn(files);
+    });
+
+    expect(compared).toEqual([['a/dev.yaml', 'a/prod.yaml'], ['b/dev.yaml', 'shared.yaml'], ['shared.yaml']]);
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.context?.group)).toEqual(['service-a', 'service-b']);
+  });
+
+  it('should keep the groups compared before an interruption', async () => {
+    const controller = new AbortController();
+
+    const result = await compareByGroup(groups, loaded, async files => {
+      controller.abort();
+      return missingIn(files);
+    }, controller.signal);
+
+    expect(result.groups?.map(({ name }) => name)).toEqual(['service-a']);
+  });
+
+  it('should summarize each group and sum the metadata', () => {
+    const result = mergeGroupResults(
+      groups.map(group => ({ group, result: missingIn(filesOfGroup(group, loaded)) }))
+    );
+
+    expect(result.groups).toEqual([
+      { name: 'service-a', files: ['a/dev.yaml', 'a/prod.yaml'], success: false, errors: 1, warnings: 0, info: 0 },
+      { name: 'service-b', files: ['b/dev.yaml', 'shared.yaml'], success: false, errors: 1, warnings: 0, info: 0 },
+      { name: 'service-c', files: ['shared.yaml'], success: true, errors: 0, warnings: 0, info: 0 }
+    ]);
+    expect(result.metadata).toMatchObject({ duration: 15, filesCompared: 4, totalKeys: 6, rulesChecked: 3 });
+  });
+});
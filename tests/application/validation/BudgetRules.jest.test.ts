@@ -0,0 +1,67 @@
+import {
+  budgetAmount,
+  checkBudgets,
+  withBudgetFindings
+} from '../../../src/application/validation/BudgetRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+const deployment = (replicas: unknown, memory: string) => ({
+  spec: { replicas, template: { spec: { containers: [{ resources: { limits: { memory } } }, { resources: { limits: { memory: '256Mi' } } }] } } }
+});
+
+describe('BudgetRules', () => {
+  it('should read numbers, Kubernetes quantities and sizes', () => {
+    expect(budgetAmount(3)).toBe(3);
+    expect(budgetAmount('500m')).toBe(0.5);
+    expect(budgetAmount('2Gi')).toBe(2 * 1024 ** 3);
+    expect(budgetAmount('${REPLICAS}')).toBeUndefined();
+  });
+
+  it('should sum values per environment and cap single values', () => {
+    const findings = checkBudgets([
+      file('api-prod.yaml', { api: deployment(12, '4Gi') }),
+      file('worker-prod.yaml', { worker: deployment(10, '1Gi') }),
+      file('api-staging.yaml', { api: deployment(12, '16Gi') }),
+      file('api-dev.yaml', { api: deployment('${REPLICAS}', '512Mi') })
+    ], [
+      { name: 'replicas', keys: ['*.spec.replicas'], maxTotal: 20 },
+      { name: 'memory', keys: ['*.spec.template.spec.containers.*.resources.limits.memory'], maxValue: '8Gi' }
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.context?.file, finding.path])).toEqual([
+      ['BUDGET_TOTAL_EXCEEDED', 'api-prod.yaml', 'api.spec.replicas'],
+      ['BUDGET_VALUE_EXCEEDED', 'api-staging.yaml', 'api.spec.template.spec.containers.0.resources.limits.memory']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ budget: 'replicas', total: 22, max: 20, values: 2 });
+    expect(findings[0].context?.environment).toBe('prod');
+  });
+
+  it('should only budget the files of the given environment', () => {
+    const files = [
+      file('a.yaml', { api: { spec: { replicas: 30 } } }, 'staging'),
+      file('b.yaml', { api: { spec: { replicas: 5 } } }, 'production')
+    ];
+
+    expect(checkBudgets(files, [{ name: 'replicas', keys: ['*.spec.replicas'], maxTotal: 20, environment: 'prod' }])).toEqual([]);
+    expect(checkBudgets(files, [{ name: 'replicas', keys: ['*.spec.replicas'], maxTotal: 20, environment: 'staging' }]))
+      .toHaveLength(1);
+  });
+
+  it('should fail a passing result when a budget is exceeded', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const files = [file('app.yaml', { workers: 64 })];
+    const updated = withBudgetFindings(result, files, [{ name: 'workers', keys: ['workers'], maxValue: 32 }]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['BUDGET_VALUE_EXCEEDED']);
+    expect(withBudgetFindings(result, files, [])).toBe(result);
+    expect(withBudgetFindings(result, files, [{ name: 'workers', keys: ['workers'], maxValue: 64 }])).toBe(result);
+  });
+});
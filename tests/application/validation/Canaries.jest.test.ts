@@ -0,0 +1,43 @@
+import { canaryHash, checkCanaries, withCanaries } from '../../../src/application/validation/Canaries';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, format: 'yaml', content });
+
+const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+describe('Canaries', () => {
+  const files = [
+    file('config/dev.yaml', { billing: { token: 'pk_canary' }, marker: 42 }),
+    file('config/prod.yaml', { billing: { token: 'pk_live_changed' } }),
+    file('config/stage.yaml', { marker: 42 })
+  ];
+
+  it('should report removed and modified canaries without their values', () => {
+    const findings = checkCanaries([{ key: 'billing.token', value: 'pk_canary' }], files);
+
+    expect(findings.map(({ code, context }) => [code, context?.file])).toEqual([
+      ['CANARY_MODIFIED', 'config/prod.yaml'],
+      ['CANARY_MISSING', 'config/stage.yaml']
+    ]);
+    expect(JSON.stringify(findings)).not.toContain('pk_');
+    expect(findings[0].context).toMatchObject({ keyPath: 'billing.token', rule: { id: 'canary' } });
+  });
+
+  it('should compare hashed canaries and honor file patterns', () => {
+    const canary = { key: 'marker', sha256: canaryHash(42), files: ['*/dev.yaml', '*/stage.yaml'] };
+
+    expect(checkCanaries([canary], files)).toEqual([]);
+    expect(checkCanaries([{ ...canary, sha256: canaryHash(43) }], files)).toHaveLength(2);
+  });
+
+  it('should fail the result only when a canary does not match', () => {
+    const intact = [{ key: 'marker', value: 42, files: ['config/dev.yaml'] }];
+
+    expect(withCanaries(passing, [], files)).toBe(passing);
+    expect(withCanaries(passing, intact, files)).toBe(passing);
+
+    const result = withCanaries(passing, [{ key: 'marker', value: 1 }], files);
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.code)).toEqual(['CANARY_MODIFIED', 'CANARY_MISSING', 'CANARY_MODIFIED']);
+  });
+});
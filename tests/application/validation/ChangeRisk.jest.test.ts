@@ -0,0 +1,61 @@
+import {
+  assessChangeRisk,
+  classifyChange,
+  keyChanges,
+  reachesRisk,
+  renderRiskSummary
+} from '../../../src/application/validation/ChangeRisk';
+
+const rulesFor = (key: string): string[] => (key === 'db.host' || key === 'port' ? ['required'] : []);
+
+describe('ChangeRisk', () => {
+  it('should list added, removed and modified leaf keys', () => {
+    expect(keyChanges({
+      path: 'prod.yaml',
+      environment: 'prod',
+      before: { db: { host: 'a', password: 'x' }, old: 1 },
+      after: { db: { host: 'b', password: 'x' }, fresh: [1] }
+    })).toEqual([
+      { file: 'prod.yaml', environment: 'prod', key: 'db.host', kind: 'modified' },
+      { file: 'prod.yaml', environment: 'prod', key: 'fresh', kind: 'added' },
+      { file: 'prod.yaml', environment: 'prod', key: 'old', kind: 'removed' }
+    ]);
+    expect(keyChanges({ path: 'new.yaml', after: { a: 1 } })).toEqual([{ file: 'new.yaml', key: 'a', kind: 'added' }]);
+  });
+
+  it('should rate secrets and uncovered production changes high', () => {
+    const rate = (file: string, key: string, kind: 'added' | 'removed' | 'modified', environment?: string) =>
+      classifyChange({ file, key, kind, ...(environment ? { environment } : {}) }, { rulesFor });
+
+    expect(rate('dev.yaml', 'api.token', 'modified', 'dev')).toMatchObject({ risk: 'high', reasons: ['secret', 'uncovered'] });
+    expect(rate('app.yaml', 'fresh', 'added', 'production')).toMatchObject({ risk: 'high', reasons: ['production', 'uncovered'] });
+    expect(rate('app.yaml', 'db.host', 'removed', 'prod')).toMatchObject({ risk: 'high', reasons: ['production', 'removed'] });
+    expect(rate('app.yaml', 'db.host', 'modified', 'prod')).toMatchObject({ risk: 'medium', reasons: ['production'], rules: ['required'] });
+    expect(rate('config/app-staging.yaml', 'cache.ttl', 'modified')).toMatchObject({ risk: 'medium', reasons: ['uncovered'] });
+    expect(rate('config/app-staging.yaml', 'port', 'modified')).toMatchObject({ risk: 'low', reasons: [] });
+    expect(rate('config/app-prod.yaml', 'port', 'modified')).toMatchObject({ risk: 'medium', reasons: ['production'] });
+  });
+
+  it('should sort changes by risk and tell whether a level is reached', () => {
+    const changes = assessChangeRisk([
+      { path: 'staging.yaml', environment: 'staging', before: { port: 80 }, after: { port: 81 } },
+      { path: 'prod.yaml', environment: 'prod', before: { secret_key: 'a' }, after: { secret_key: 'b' } }
+    ], { rulesFor });
+
+    expect(changes.map(change => [change.key, change.risk])).toEqual([['secret_key', 'high'], ['port', 'low']]);
+    expect(reachesRisk(changes, 'high')).toBe(true);
+    expect(reachesRisk(changes.slice(1), 'medium')).toBe(false);
+  });
+
+  it('should render a summary for reviewers without values', () => {
+    const changes = assessChangeRisk([
+      { path: 'prod.yaml', environment: 'prod', before: { db: { password: 'hunter2' } }, after: { db: { password: 'hunter3' } } }
+    ]);
+    const markdown = renderRiskSummary(changes, 'origin/main');
+
+    expect(markdown).toContain('1 configuration key(s) changed since `origin/main`: 1 high, 0 medium, 0 low.');
+    expect(markdown).toContain('| high | `prod.yaml` (prod) | `db.password` | modified | secret-bearing, production, no rule covers it | — |');
+    expect(markdown).not.toContain('hunter');
+    expect(renderRiskSummary([], 'HEAD')).toContain('No configuration keys changed since `HEAD`.');
+  });
+});
@@ -0,0 +1,94 @@
+import {
+  brokenParameterConstraints,
+  checkCloudFormation,
+  isCloudFormationTemplate,
+  templateReferences,
+  withCloudFormationFindings
+} from '../../../src/application/validation/CloudFormationChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const template = (content: Record<string, any>): ConfigFile => ({
+  path: 'template.yaml',
+  format: 'yaml',
+  content: { AWSTemplateFormatVersion: '2010-09-09', ...content },
+});
+
+describe('CloudFormationChecks', () => {
+  it('should recognize templates by version or AWS resource types', () => {
+    expect(isCloudFormationTemplate({ AWSTemplateFormatVersion: '2010-09-09', Resources: {} })).toBe(true);
+    expect(isCloudFormationTemplate({ Resources: { Queue: { Type: 'AWS::SQS::Queue' } } })).toBe(true);
+    expect(isCloudFormationTemplate({ Resources: { cpu: '500m' } })).toBe(false);
+    expect(isCloudFormationTemplate({ database: { host: 'db' } })).toBe(false);
+  });
+
+  it('should list the constraints a parameter default breaks', () => {
+    expect(brokenParameterConstraints({ Type: 'String', Default: 'xl', AllowedValues: ['small', 'large'] })).toEqual(['AllowedValues']);
+    expect(brokenParameterConstraints({ Type: 'String', Default: 'Prod', AllowedPattern: '[a-z]+', MaxLength: 3 })).toEqual(['AllowedPattern', 'MaxLength']);
+    expect(brokenParameterConstraints({ Type: 'Number', Default: 0, MinValue: 1 })).toEqual(['MinValue']);
+    expect(brokenParameterConstraints({ Type: 'CommaDelimitedList', Default: 'a, b', AllowedValues: ['a', 'b'] })).toEqual([]);
+    expect(brokenParameterConstraints({ Type: 'String', AllowedValues: ['a'] })).toEqual([]);
+  });
+
+  it('should report parameter defaults and incomplete per-environment mappings', () => {
+    const findings = checkCloudFormation([template({
+      Parameters: { Env: { Type: 'String', Default: 'qa', AllowedValues: ['dev', 'prod'] } },
+      Mappings: { EnvConfig: { dev: { InstanceType: 't3.micro', MinSize: 1 }, prod: { InstanceType: 'm5.large' } } },
+      Resources: { Queue: { Type: 'AWS::SQS::Queue' } },
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['CFN_PARAMETER_DEFAULT_INVALID', 'Parameters.Env.Default'],
+      ['CFN_MAPPING_KEY_MISSING', 'Mappings.EnvConfig.prod.MinSize'],
+    ]);
+    expect(findings[0].message).not.toContain('qa');
+    expect(findings[0].context).toMatchObject({ observedValue: 'qa', rule: { id: 'cloudformation' } });
+  });
+
+  it('should collect Ref, GetAtt and Sub references', () => {
+    const references = templateReferences({
+      Name: { 'Fn::Sub': ['${Prefix}-${Suffix}-${!Literal}', { Suffix: { Ref: 'Env' } }] },
+      Arn: { 'Fn::GetAtt': ['Role', 'Arn'] },
+    }, 'Resources.Bucket.Properties');
+
+    expect(references).toEqual([
+      { keyPath: 'Resources.Bucket.Properties.Name.Fn::Sub', name: 'Prefix', intrinsic: 'Fn::Sub' },
+      { keyPath: 'Resources.Bucket.Properties.Name.Fn::Sub.1.Suffix.Ref', name: 'Env', intrinsic: 'Ref' },
+      { keyPath: 'Resources.Bucket.Properties.Arn.Fn::GetAtt', name: 'Role', intrinsic: 'Fn::GetAtt' },
+    ]);
+  });
+
+  it('should report references to undeclared parameters and resources', () => {
+    const findings = checkCloudFormation([template({
+      Parameters: { Env: { Type: 'String' } },
+      Resources: {
+        Bucket: {
+          Type: 'AWS::S3::Bucket',
+          Properties: { BucketName: { 'Fn::Sub': '${AWS::StackName}-${Env}-${Stage}' }, Role: { 'Fn::GetAtt': ['Env', 'Arn'] } },
+        },
+      },
+      Outputs: { Name: { Value: { Ref: 'Bucket' } }, Missing: { Value: { Ref: 'Topic' } } },
+    })]);
+
+    expect(findings.map(finding => [finding.path, finding.context?.extras?.reference])).toEqual([
+      ['Resources.Bucket.Properties.BucketName.Fn::Sub', 'Stage'],
+      ['Resources.Bucket.Properties.Role.Fn::GetAtt', 'Env'],
+      ['Outputs.Missing.Value.Ref', 'Topic'],
+    ]);
+  });
+
+  it('should accept the resources SAM generates', () => {
+    const sam = template({
+      Transform: 'AWS::Serverless-2016-10-31',
+      Resources: { Api: { Type: 'AWS::Serverless::Function' } },
+      Outputs: { Role: { Value: { 'Fn::GetAtt': ['ApiRole', 'Arn'] } }, Url: { Value: { 'Fn::Sub': '${ServerlessRestApi}.execute-api' } } },
+    });
+
+    expect(checkCloudFormation([sam])).toEqual([]);
+  });
+
+  it('should leave results untouched without templates', () => {
+    const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+    expect(withCloudFormationFindings(passing, [{ path: 'app.yaml', format: 'yaml', content: { port: 80 } }])).toBe(passing);
+  });
+});
@@ -0,0 +1,87 @@
+import {
+  checkCloudIdentities,
+  cloudIdentifiers,
+  withCloudIdentityFindings
+} from '../../../src/application/validation/CloudIdentityChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+describe('CloudIdentityChecks', () => {
+  it('should extract regions, accounts, projects and subscriptions from values', () => {
+    const identifiers = cloudIdentifiers({
+      role: 'arn:aws:iam::111122223333:role/deploy',
+      image: '111122223333.dkr.ecr.us-east-1.amazonaws.com/app:1.0',
+      gcp: { project_id: 'acme-staging', topic: 'projects/acme-shared/topics/events', zone: 'europe-west1-b' },
+      azure: { scope: '/subscriptions/0F3A1B2C-1111-2222-3333-444455556666/resourceGroups/app' },
+      aws: { account_id: 444455556666 },
+      notes: 'plus-east-1 and us-east-12 are not regions'
+    });
+
+    expect(identifiers.map(identifier => [identifier.kind, identifier.value, identifier.keyPath])).toEqual([
+      ['account', '111122223333', 'role'],
+      ['region', 'us-east-1', 'image'],
+      ['account', '111122223333', 'image'],
+      ['project', 'acme-staging', 'gcp.project_id'],
+      ['project', 'acme-shared', 'gcp.topic'],
+      ['region', 'europe-west1', 'gcp.zone'],
+      ['subscription', '0f3a1b2c-1111-2222-3333-444455556666', 'azure.scope'],
+      ['account', '444455556666', 'aws.account_id']
+    ]);
+  });
+
+  it('should report identifiers outside the allow-list of their environment', () => {
+    const findings = checkCloudIdentities([
+      file('app-staging.yaml', {
+        aws: { region: 'eu-west-1', role: 'arn:aws:iam::555566667777:role/app', backup: 's3://bucket-us-west-2' }
+      }),
+      file('app-prod.yaml', { aws: { region: 'us-east-1', account_id: '555566667777' } })
+    ], {
+      staging: { regions: ['eu-west-1'], accounts: ['222233334444'] },
+      production: { accounts: ['555566667777'] }
+    });
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['CLOUD_IDENTIFIER_NOT_ALLOWED', 'error', 'aws.role'],
+      ['CLOUD_IDENTIFIER_NOT_ALLOWED', 'error', 'aws.backup']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ kind: 'account', value: '555566667777', belongsTo: 'production' });
+    expect(findings[0].message).toContain('it belongs to production');
+    expect(findings[1].context?.extras).toEqual({ kind: 'region', value: 'us-west-2', belongsTo: '' });
+  });
+
+  it('should report shared and inconsistent identifiers without allow-lists', () => {
+    const findings = checkCloudIdentities([
+      file('config/dev.yaml', { gcp: { project: 'acme-dev' } }),
+      file('config/dev-worker.yaml', { gcp: { project: 'acme-dev' } }),
+      file('config/dev-jobs.yaml', { gcp: { project: 'acme-sandbox' } }),
+      file('config/staging.yaml', { aws: { role: 'arn:aws:iam::555566667777:role/app' } }),
+      file('config/prod.yaml', { aws: { account_id: '555566667777' } }),
+      file('config/shared.yaml', { aws: { account_id: '555566667777' } })
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.context?.file])).toEqual([
+      ['CLOUD_IDENTIFIER_SHARED', 'error', 'config/staging.yaml'],
+      ['CLOUD_IDENTIFIER_INCONSISTENT', 'warning', 'config/dev-jobs.yaml']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ kind: 'account', value: '555566667777', otherEnvironments: 'prod' });
+  });
+
+  it('should fail a passing result when staging points at the production account', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const files = [
+      file('app.yaml', { account_id: '555566667777' }, 'staging'),
+      file('app.yaml', { account_id: '555566667777' }, 'production')
+    ];
+    const updated = withCloudIdentityFindings(result, files);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['CLOUD_IDENTIFIER_SHARED']);
+    expect(withCloudIdentityFindings(result, [files[1]])).toBe(result);
+  });
+});
@@ -0,0 +1,51 @@
+import {
+  checkCommentedConfig,
+  commentedBlocks,
+  withCommentedConfigFindings
+} from '../../../src/application/validation/CommentedConfigChecks';
+import { ConfigComment, ValidationResult } from '../../../src/shared/types';
+
+const comments = (first: number, ...texts: string[]): ConfigComment[] =>
+  texts.map((text, index) => ({ line: first + index, text }));
+
+describe('CommentedConfigChecks', () => {
+  it('should group consecutive comment lines and tell settings from prose', () => {
+    const blocks = commentedBlocks([
+      ...comments(1, 'database:', 'host: old-db', 'port = 5432'),
+      ...comments(10, 'TODO: drop the legacy queue', 'Remember: this one is no longer used at all', '<timeout>30</timeout>')
+    ]);
+
+    expect(blocks).toEqual([
+      { startLine: 1, endLine: 3, keys: ['host', 'port'], proseLines: 0 },
+      { startLine: 10, endLine: 12, keys: ['timeout'], proseLines: 2 }
+    ]);
+  });
+
+  it('should report blocks over the thresholds, as errors when they set a secret', () => {
+    const findings = checkCommentedConfig([
+      { path: 'app.yaml', comments: comments(4, 'legacy:', '  url: https://old.example.com', '  api_key: abc123', '  retries: 3') },
+      { path: 'app.env', comments: comments(1, 'export LOG_LEVEL=debug', 'export LOG_FORMAT=json', '') },
+      { path: 'notes.ini', comments: comments(1, 'This file is generated.', 'Do not edit it by hand:', 'mode = strict') },
+      { path: 'app.json' }
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.context?.file, finding.context?.line])).toEqual([
+      ['COMMENTED_OUT_SECRET', 'error', 'app.yaml', 4],
+      ['COMMENTED_OUT_CONFIG', 'warning', 'app.env', 1]
+    ]);
+    expect(findings[0].context?.extras).toEqual({
+      endLine: 7, lines: 4, assignments: 3, keys: ['url', 'api_key', 'retries'], secrets: ['api_key']
+    });
+    expect(findings[0].message).not.toContain('abc123');
+  });
+
+  it('should apply configured thresholds and stay off unless configured', () => {
+    const files = [{ path: 'app.env', comments: comments(1, 'export LOG_LEVEL=debug', 'export LOG_FORMAT=json', '') }];
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+
+    expect(checkCommentedConfig(files, { minLines: 4 })).toEqual([]);
+    expect(checkCommentedConfig(files, { minAssignments: 3 })).toEqual([]);
+    expect(withCommentedConfigFindings(result, files)).toBe(result);
+    expect(withCommentedConfigFindings(result, files, {}).warnings.map(warning => warning.code)).toEqual(['COMMENTED_OUT_CONFIG']);
+  });
+});
@@ -0,0 +1,81 @@
+import {
+  buildComparisonMatrix,
+  cellHash,
+  MISSING,
+  PRESENT,
+  renderMatrixCsv,
+  renderMatrixHtml,
+  renderMatrixText,
+} from '../../../src/application/validation/ComparisonMatrix';
+
+describe('ComparisonMatrix', () => {
+  const columns = [
+    { name: 'dev', content: { database: { host: 'localhost', port: 5432 }, feature: { beta: true }, debug: true } },
+    { name: 'staging', content: { database: { host: 'localhost', port: 5432 }, feature: { beta: true } } },
+    { name: 'prod', content: { database: { host: 'db.internal', port: 5432 } } },
+  ];
+
+  describe('buildComparisonMatrix', () => {
+    it('should list the keys missing somewhere or with differing values', () => {
+      const matrix = buildComparisonMatrix(columns);
+
+      expect(matrix.columns).toEqual(['dev', 'staging', 'prod']);
+      expect(matrix.rows).toEqual([
+        { key: 'database.host', cells: [cellHash('localhost'), cellHash('localhost'), cellHash('db.internal')] },
+        { key: 'debug', cells: [PRESENT, MISSING, MISSING] },
+        { key: 'feature.beta', cells: [PRESENT, PRESENT, MISSING] },
+      ]);
+    });
+
+    it('should keep the keys that agree everywhere with all', () => {
+      const matrix = buildComparisonMatrix(columns, { all: true });
+
+      expect(matrix.rows.find(row => row.key === 'database.port')?.cells).toEqual([PRESENT, PRESENT, PRESENT]);
+    });
+
+    it('should leave ignored keys out', () => {
+      const matrix = buildComparisonMatrix(columns, { ignoreKeys: ['database.*', 'debug'] });
+
+      expect(matrix.rows.map(row => row.key)).toEqual(['feature.beta']);
+    });
+
+    it('should never show values', () => {
+      const matrix = buildComparisonMatrix([
+        { name: 'a', content: { password: 'hunter2' } },
+        { name: 'b', content: { password: 'swordfish' } },
+      ]);
+
+      expect(JSON.stringify(matrix)).not.toContain('hunter2');
+      expect(matrix.rows[0].cells).toEqual([cellHash('hunter2'), cellHash('swordfish')]);
+      expect(cellHash('hunter2')).toMatch(/^[0-9a-f]{8}$/);
+    });
+  });
+
+  describe('renderers', () => {
+    const matrix = buildComparisonMatrix(columns);
+
+    it('should align the text table', () => {
+      const lines = renderMatrixText(matrix).split('\n');
+
+      expect(lines[0]).toBe('Key            dev       staging   prod');
+      expect(lines[3]).toBe('debug          ✓         ✗         ✗');
+    });
+
+    it('should say so when every key agrees', () => {
+      expect(renderMatrixText({ columns: ['dev', 'prod'], rows: [] })).toContain('Every key is present with the same value in dev, prod');
+    });
+
+    it('should write one CSV row per key, quoting where needed', () => {
+      const csv = renderMatrixCsv({ columns: ['dev', 'prod, eu'], rows: [{ key: 'a.b', cells: [PRESENT, MISSING] }] });
+
+      expect(csv).toBe('key,dev,"prod, eu"\na.b,✓,✗\n');
+    });
+
+    it('should escape names in the HTML page', () => {
+      const html = renderMatrixHtml({ columns: ['<dev>', 'prod'], rows: [{ key: 'a', cells: [PRESENT, MISSING] }] });
+
+      expect(html).toContain('<th>&lt;dev&gt;</th>');
+      expect(html).toContain('<td class="missing">✗</td>');
+    });
+  });
+});
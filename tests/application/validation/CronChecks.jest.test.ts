@@ -0,0 +1,70 @@
+import {
+  checkCronSchedules,
+  cronEntries,
+  withCronFindings
+} from '../../../src/application/validation/CronChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+const cronJob = (schedule: string) => ({
+  apiVersion: 'batch/v1',
+  kind: 'CronJob',
+  metadata: { name: 'report' },
+  spec: { schedule, jobTemplate: {} }
+});
+
+describe('CronChecks', () => {
+  it('should list CronJob schedules and configured keys once per key path', () => {
+    expect(cronEntries(cronJob('0 * * * *'), ['spec.schedule'])).toEqual([
+      { keyPath: 'spec.schedule', value: '0 * * * *', kubernetes: true }
+    ]);
+    expect(cronEntries({ jobs: { cleanup: { schedule: '@daily' }, sync: { enabled: true } } }, ['jobs.*.schedule'])).toEqual([
+      { keyPath: 'jobs.cleanup.schedule', value: '@daily', kubernetes: false }
+    ]);
+  });
+
+  it('should report invalid expressions and too frequent production schedules', () => {
+    const content = {
+      jobs: {
+        cleanup: { schedule: '*/1 * * * *' },
+        report: { schedule: '0 25 * * *' },
+        digest: { schedule: '0 0 8 ? * MON-FRI' },
+        templated: { schedule: '${DIGEST_SCHEDULE}' }
+      }
+    };
+    const settings = { keys: ['jobs.*.schedule'], minIntervalMs: 300000 };
+
+    const findings = checkCronSchedules([file('jobs-prod.yaml', content), file('jobs-dev.yaml', content)], settings);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.context?.file, finding.path])).toEqual([
+      ['CRON_SCHEDULE_TOO_FREQUENT', 'warning', 'jobs-prod.yaml', 'jobs.cleanup.schedule'],
+      ['CRON_EXPRESSION_INVALID', 'error', 'jobs-prod.yaml', 'jobs.report.schedule'],
+      ['CRON_EXPRESSION_INVALID', 'error', 'jobs-dev.yaml', 'jobs.report.schedule']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ expression: '*/1 * * * *', intervalMs: 60000, minIntervalMs: 300000 });
+    expect(findings[1].context?.extras).toMatchObject({ reason: "'25' is out of range for hours (0-23)" });
+  });
+
+  it('should hold CronJobs to five fields', () => {
+    const findings = checkCronSchedules([file('cronjob.yaml', cronJob('0 */5 * * * *'))]);
+
+    expect(findings.map(finding => [finding.code, finding.context?.extras?.reason])).toEqual([
+      ['CRON_EXPRESSION_INVALID', 'expected 5 fields, found 6']
+    ]);
+  });
+
+  it('should fail a passing result when a schedule does not parse', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withCronFindings(result, [file('app.yaml', { scheduler: { cron: 5 } })], { keys: ['scheduler.cron'] });
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['CRON_EXPRESSION_INVALID']);
+    expect(withCronFindings(result, [file('app.yaml', { scheduler: { cron: '@hourly' } })], { keys: ['scheduler.cron'] })).toBe(result);
+  });
+});
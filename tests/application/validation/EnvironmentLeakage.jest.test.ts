@@ -0,0 +1,57 @@
+import {
+  detectLeakage,
+  inferEnvironment,
+  leakageTokens,
+  mentionsToken,
+  withLeakage
+} from '../../../src/application/validation/EnvironmentLeakage';
+import { ValidationResult } from '../../../src/shared/types';
+
+describe('EnvironmentLeakage', () => {
+  const tokens = leakageTokens();
+
+  it('should match tokens as whole words only', () => {
+    expect(mentionsToken('db-prod-1.internal', 'prod')).toBe(true);
+    expect(mentionsToken('http://127.0.0.1:8080', '127.0.0.1')).toBe(true);
+    expect(mentionsToken('product-catalog', 'prod')).toBe(false);
+  });
+
+  it('should infer the environment from the file name when it is unambiguous', () => {
+    expect(inferEnvironment('config/prod.yaml', tokens)).toBe('prod');
+    expect(inferEnvironment('config/app-development.json', tokens)).toBe('dev');
+    expect(inferEnvironment('config/app.yaml', tokens)).toBeUndefined();
+  });
+
+  it('should flag values that belong to another environment', () => {
+    const warnings = detectLeakage([
+      { path: 'dev.yaml', content: { db: { host: 'db-prod-1.internal' }, cache: 'localhost' } },
+      { path: 'prod.yaml', content: { api: 'http://localhost:3000', catalog: 'product-service', port: 80 } },
+      { path: 'shared.yaml', content: { host: 'db-prod-1.internal' } }
+    ]);
+
+    expect(warnings.map(({ code, context }) => [code, context?.file, context?.keyPath, context?.extras?.suspectedEnvironment])).toEqual([
+      ['ENVIRONMENT_LEAKAGE', 'dev.yaml', 'db.host', 'prod'],
+      ['ENVIRONMENT_LEAKAGE', 'prod.yaml', 'api', 'dev']
+    ]);
+    expect(warnings[0].severity).toBe('warning');
+  });
+
+  it('should leave values that also name their own environment alone', () => {
+    expect(detectLeakage([{ path: 'x.yaml', environment: 'dev', content: { bridge: 'dev-to-prod-bridge' } }])).toEqual([]);
+  });
+
+  it('should use configured token lists and environments', () => {
+    const settings = { tokens: { qa: ['qa', 'uat'] } };
+    const warnings = detectLeakage([{ path: 'app.yaml', environment: 'prod', content: { url: 'https://uat.example.com' } }], settings);
+
+    expect(warnings[0].context?.extras).toEqual({ suspectedEnvironment: 'qa', token: 'uat' });
+  });
+
+  it('should only add warnings when the check is enabled', () => {
+    const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+    const targets = [{ path: 'dev.yaml', content: { host: 'prod-db' } }];
+
+    expect(withLeakage(passing, targets, undefined)).toBe(passing);
+    expect(withLeakage(passing, targets, {})).toMatchObject({ success: true, warnings: [{ code: 'ENVIRONMENT_LEAKAGE' }] });
+  });
+});
@@ -0,0 +1,93 @@
+import {
+  checkFeatureFlags,
+  withFeatureFlagFindings
+} from '../../../src/application/validation/FeatureFlagChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'json',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+const unleash = (features: Array<Record<string, any>>): Record<string, any> => ({ version: 1, features });
+
+const now = new Date('2025-01-15T00:00:00Z');
+
+describe('FeatureFlagChecks', () => {
+  it('should report flags on in production but missing or off in staging', () => {
+    const findings = checkFeatureFlags([
+      file('flags.staging.json', unleash([{ name: 'checkout', enabled: false }])),
+      file('flags.prod.json', unleash([{ name: 'checkout', enabled: true }, { name: 'payouts', enabled: true }, { name: 'legacy', enabled: false }]))
+    ], {}, now);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path, finding.context?.environment])).toEqual([
+      ['FEATURE_FLAG_OFF_IN_STAGING', 'warning', 'features.0.enabled', 'prod'],
+      ['FEATURE_FLAG_MISSING_IN_STAGING', 'error', 'features.1.enabled', 'prod']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ flag: 'checkout', stagingEnvironment: 'staging', stagingFile: 'flags.staging.json' });
+  });
+
+  it('should compare the environments of one LaunchDarkly export', () => {
+    const findings = checkFeatureFlags([file('launchdarkly.json', {
+      items: [
+        { key: 'search', environments: { production: { on: true }, staging: { on: true } } },
+        { key: 'exports', environments: { production: { on: true }, staging: { on: false } } }
+      ]
+    })], {}, now);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['FEATURE_FLAG_OFF_IN_STAGING', 'items.1.environments.production.on']
+    ]);
+  });
+
+  it('should skip the staging comparison without staging flags', () => {
+    expect(checkFeatureFlags([file('flags.prod.json', unleash([{ name: 'checkout', enabled: true }]))], {}, now)).toEqual([]);
+  });
+
+  it('should warn about expired flags and rollouts above the threshold', () => {
+    const flags = unleash([
+      { name: 'old-banner', enabled: true, expiresAt: '2024-12-31' },
+      { name: 'new-banner', enabled: true, expiresAt: '2025-06-30' },
+      { name: 'search', enabled: true, strategies: [{ name: 'flexibleRollout', parameters: { rollout: '75' } }] }
+    ]);
+
+    expect(checkFeatureFlags([file('flags.json', flags)], {}, now).map(finding => [finding.code, finding.path])).toEqual([
+      ['FEATURE_FLAG_EXPIRED', 'features.0.expiresAt'],
+      ['FEATURE_FLAG_ROLLOUT_OVER_THRESHOLD', 'features.2.strategies.0.parameters.rollout']
+    ]);
+    expect(checkFeatureFlags([file('flags.json', flags)], { rolloutThreshold: 80 }, now).map(finding => finding.code)).toEqual([
+      'FEATURE_FLAG_EXPIRED'
+    ]);
+  });
+
+  it('should take the environment of flag data files from the environments mapping', () => {
+    const findings = checkFeatureFlags([
+      file('ld/a.json', { flagValues: { checkout: true } }, 'production'),
+      file('ld/b.json', { flagValues: {} }, 'staging')
+    ], {}, now);
+
+    // b.json has no flags, so staging never appears
+    expect(findings).toEqual([]);
+
+    const withStaging = checkFeatureFlags([
+      file('ld/a.json', { flagValues: { checkout: true } }, 'production'),
+      file('ld/b.json', { flagValues: { search: true } }, 'staging')
+    ], {}, now);
+
+    expect(withStaging.map(finding => finding.code)).toEqual(['FEATURE_FLAG_MISSING_IN_STAGING']);
+  });
+
+  it('should fail a passing result when production turns on a flag staging lacks', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withFeatureFlagFindings(result, [
+      file('flags.staging.json', unleash([{ name: 'search', enabled: true }])),
+      file('flags.prod.json', unleash([{ name: 'checkout', enabled: true }]))
+    ], {}, now);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['FEATURE_FLAG_MISSING_IN_STAGING']);
+    expect(withFeatureFlagFindings(result, [], {}, now)).toBe(result);
+  });
+});
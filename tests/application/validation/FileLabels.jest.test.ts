@@ -0,0 +1,32 @@
+import { selectLabelledFiles, withFileLabels } from '../../../src/application/validation/FileLabels';
+import { ValidationResult } from '../../../src/shared/types';
+
+const labels = {
+  'payments.yaml': { tier: 'critical', service: 'payments' },
+  'ledger.yaml': { tier: 'critical', service: 'ledger' },
+  'blog.yaml': {}
+};
+
+describe('FileLabels', () => {
+  it('should select the files carrying the labels', () => {
+    expect(selectLabelledFiles(['payments.yaml', 'ledger.yaml', 'blog.yaml'], labels, { tier: 'critical' })).toEqual(['payments.yaml', 'ledger.yaml']);
+    expect(selectLabelledFiles(['payments.yaml', 'blog.yaml'], labels, {})).toEqual(['payments.yaml', 'blog.yaml']);
+  });
+
+  it('should carry the labels of the files into findings', () => {
+    const result: ValidationResult = {
+      success: false,
+      errors: [{ code: 'A', message: 'a', severity: 'error', context: { file: 'payments.yaml' } }],
+      warnings: [
+        { code: 'B', message: 'b', severity: 'warning', context: { files: ['payments.yaml', 'ledger.yaml'] } },
+        { code: 'C', message: 'c', severity: 'warning', context: { file: 'blog.yaml' } }
+      ]
+    };
+    const labelled = withFileLabels(result, labels);
+
+    expect(labelled.errors[0].context?.labels).toEqual({ tier: 'critical', service: 'payments' });
+    expect(labelled.warnings[0].context?.labels).toEqual({ tier: 'critical' });
+    expect(labelled.warnings[1]).toBe(result.warnings[1]);
+    expect(withFileLabels(result, { 'blog.yaml': {} })).toBe(result);
+  });
+});
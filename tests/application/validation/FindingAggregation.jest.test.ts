@@ -0,0 +1,58 @@
+import {
+  aggregateFindings,
+  aggregateResult,
+  deduplicateFindings
+} from '../../../src/application/validation/FindingAggregation';
+import { ValidationError } from '../../../src/shared/types';
+
+const missing = (file: string, key = 'database.host'): ValidationError => ({
+  code: 'MISSING_KEY',
+  message: `Key '${key}' is missing in ${file}`,
+  severity: 'error',
+  path: key,
+  context: { file, keyPath: key, extras: { availableKeys: [] } }
+});
+
+describe('FindingAggregation', () => {
+  it('should drop exact duplicates', () => {
+    expect(deduplicateFindings([missing('a.yaml'), missing('a.yaml')])).toHaveLength(1);
+  });
+
+  it('should group the same finding across files', () => {
+    const aggregated = aggregateFindings([missing('a.yaml'), missing('b.yaml'), missing('a.yaml', 'api.port')]);
+
+    expect(aggregated).toHaveLength(2);
+    expect(aggregated[0].message).toBe("Key 'database.host' is missing in a.yaml, b.yaml");
+    expect(aggregated[0].context).toEqual({ keyPath: 'database.host', files: ['a.yaml', 'b.yaml'], extras: {} });
+    expect(aggregated[1]).toEqual(missing('a.yaml', 'api.port'));
+  });
+
+  it('should keep suggestions only when every file has the same ones', () => {
+    const suggested = (file: string, suggestion: string): ValidationError => ({
+      ...missing(file),
+      message: `Key 'database.host' is missing in ${file} (did you mean '${suggestion}'?)`,
+      context: { file, keyPath: 'database.host', extras: { availableKeys: [], suggestions: [suggestion] } }
+    });
+
+    const same = aggregateFindings([suggested('a.yaml', 'databse.host'), suggested('b.yaml', 'databse.host')]);
+    const different = aggregateFindings([suggested('a.yaml', 'databse.host'), suggested('b.yaml', 'database.hots')]);
+
+    expect(same[0].message).toBe("Key 'database.host' is missing in a.yaml, b.yaml (did you mean 'databse.host'?)");
+    expect(same[0].context?.extras).toEqual({ suggestions: ['databse.host'] });
+    expect(different[0].message).toBe("Key 'database.host' is missing in a.yaml, b.yaml");
+    expect(different[0].context?.extras).toEqual({});
+  });
+
+  it('should keep findings without file or path as they are', () => {
+    const warning: ValidationError = { code: 'INSUFFICIENT_FILES', message: 'Need at least 2 files', severity: 'warning' };
+
+    expect(aggregateFindings([warning, warning])).toEqual([warning]);
+  });
+
+  it('should aggregate every bucket of a result', () => {
+    const result = aggregateResult({ success: false, errors: [missing('a.yaml'), missing('b.yaml')], warnings: [] });
+
+    expect(result.success).toBe(false);
+    expect(result.errors).toHaveLength(1);
+  });
+});
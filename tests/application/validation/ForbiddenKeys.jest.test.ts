@@ -0,0 +1,56 @@
+import { appliesToEnvironment, findForbiddenKeys, withForbiddenKeys } from '../../../src/application/validation/ForbiddenKeys';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile =>
+  ({ path, format: 'yaml', content, ...(environment !== undefined ? { environment } : {}) });
+
+const rules = [
+  { keys: ['root_password'], environments: [] },
+  { keys: ['debug', '*.insecure'], environments: ['prod'] },
+];
+
+describe('ForbiddenKeys', () => {
+  it('should apply rules without environments everywhere and the others to their environments only', () => {
+    expect(appliesToEnvironment(rules[0])).toBe(true);
+    expect(appliesToEnvironment(rules[1], 'Prod')).toBe(true);
+    expect(appliesToEnvironment(rules[1], 'dev')).toBe(false);
+    expect(appliesToEnvironment(rules[1])).toBe(false);
+  });
+
+  it('should report the forbidden keys of a file', () => {
+    const errors = findForbiddenKeys(file('prod.yaml', {
+      root_password: 'x',
+      debug: true,
+      tls: { insecure: true, version: '1.3' },
+      api: { debug: true },
+    }, 'prod'), rules);
+
+    expect(errors.map(error => `${error.path} ${error.context?.extras?.pattern}`)).toEqual([
+      'root_password root_password',
+      'debug debug',
+      'tls.insecure *.insecure',
+    ]);
+    expect(errors[1]).toMatchObject({
+      code: 'FORBIDDEN_KEY',
+      severity: 'error',
+      context: { file: 'prod.yaml', environment: 'prod', keyPath: 'debug', rule: { id: 'forbidden-keys' } },
+    });
+  });
+
+  it('should allow keys outside the environments they are forbidden in', () => {
+    expect(findForbiddenKeys(file('dev.yaml', { debug: true, tls: { insecure: true } }, 'dev'), rules)).toEqual([]);
+    expect(findForbiddenKeys(file('app.yaml', { debug: true }), rules)).toEqual([]);
+  });
+
+  it('should fail the result only when a file sets a forbidden key', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const files = [file('dev.yaml', { debug: true }, 'dev'), file('prod.yaml', { debug: true }, 'prod')];
+
+    expect(withForbiddenKeys(result, files, [])).toBe(result);
+    expect(withForbiddenKeys(result, [files[0]], rules)).toBe(result);
+
+    const failed = withForbiddenKeys(result, files, rules);
+    expect(failed.success).toBe(false);
+    expect(failed.errors.map(error => error.context?.file)).toEqual(['prod.yaml']);
+  });
+});
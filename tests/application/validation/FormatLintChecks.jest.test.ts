@@ -0,0 +1,53 @@
+import { checkFormat, fixFormat, lintFormat, withFormatLintFindings } from '../../../src/application/validation/FormatLintChecks';
+
+describe('FormatLintChecks', () => {
+  it('should report trailing whitespace, line endings and a missing final newline', () => {
+    const text = 'a: 1 \r\nb: 2\nc: 3';
+
+    expect(lintFormat(text, 'yaml')).toEqual([
+      { code: 'FORMAT_TRAILING_WHITESPACE', lines: [1], fixable: true },
+      { code: 'FORMAT_LINE_ENDINGS', lines: [1], fixable: true },
+      { code: 'FORMAT_MISSING_FINAL_NEWLINE', lines: [3], fixable: true }
+    ]);
+    expect(lintFormat(text, 'yaml', { lineEndings: 'crlf' }).find(issue => issue.code === 'FORMAT_LINE_ENDINGS')?.lines).toEqual([2]);
+    expect(lintFormat('a: 1\n', 'yaml')).toEqual([]);
+  });
+
+  it('should report tabs in YAML and properties, and tabs mixed with spaces elsewhere', () => {
+    expect(lintFormat('a:\n\tb: 1\n', 'yaml')).toEqual([{ code: 'FORMAT_TAB_INDENTATION', lines: [2], fixable: false }]);
+    expect(lintFormat('a=1\n\tb=2\n', 'properties')).toEqual([{ code: 'FORMAT_TAB_INDENTATION', lines: [2], fixable: true }]);
+    expect(lintFormat('{\n\t"a": 1\n}\n', 'json')).toEqual([]);
+    expect(lintFormat('{\n\t"a": 1,\n  "b": 2\n}\n', 'json')).toEqual([{ code: 'FORMAT_MIXED_INDENTATION', lines: [2], fixable: true }]);
+  });
+
+  it('should fix what is fixable and leave tab-indented YAML alone', () => {
+    expect(fixFormat('a=1  \r\n\tb=2', 'properties')).toBe('a=1\n  b=2\n');
+    expect(fixFormat('a=1\n\tb=2\n', 'properties', { tabWidth: 4, lineEndings: 'crlf' })).toBe('a=1\r\n    b=2\r\n');
+    expect(fixFormat('a:\n\tb: 1\n', 'yaml')).toBe('a:\n\tb: 1\n');
+    expect(fixFormat('a:\n\tb: 1 ', 'yaml')).toBe('a:\n\tb: 1\n');
+  });
+
+  it('should raise errors only for tab-indented YAML', () => {
+    const findings = checkFormat([
+      { path: 'app.yaml', format: 'yaml', text: 'a:\n\tb: 1\n' },
+      { path: 'app.properties', format: 'properties', text: 'a=1 \n' }
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.context?.line])).toEqual([
+      ['FORMAT_TAB_INDENTATION', 'error', 2],
+      ['FORMAT_TRAILING_WHITESPACE', 'warning', 1]
+    ]);
+    expect(findings[1].context?.extras).toEqual({ lines: [1], count: 1, fixable: true });
+  });
+
+  it('should add the findings to a result only when the check is configured', () => {
+    const result = { success: true, errors: [], warnings: [] };
+    const files = [{ path: 'app.yaml', format: 'yaml', text: 'a:\n\tb: 1\n' }];
+
+    expect(withFormatLintFindings(result, files)).toBe(result);
+    expect(withFormatLintFindings(result, files, {})).toMatchObject({
+      success: false,
+      errors: [{ code: 'FORMAT_TAB_INDENTATION' }]
+    });
+  });
+});
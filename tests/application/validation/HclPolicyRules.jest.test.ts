@@ -0,0 +1,84 @@
+import {
+  checkHclPolicies,
+  vaultPathCovers,
+  vaultPathRules,
+  withHclPolicyFindings
+} from '../../../src/application/validation/HclPolicyRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const hcl = (content: Record<string, any>, path: string = 'policy.hcl'): ConfigFile => ({ path, format: 'hcl', content });
+
+describe('HclPolicyRules', () => {
+  it('should read path rules from HCL keys and JSON policies', () => {
+    expect(vaultPathRules({
+      'path.secret/*': { capabilities: ['read'] },
+      'variable.region': { default: 'eu' }
+    })).toEqual([{ keyPath: 'path.secret/*', path: 'secret/*', capabilities: ['read'] }]);
+    expect(vaultPathRules({ path: { 'sys/*': { capabilities: ['deny'] } } })).toEqual([
+      { keyPath: 'path.sys/*', path: 'sys/*', capabilities: ['deny'] }
+    ]);
+  });
+
+  it('should match Vault path globs', () => {
+    expect(vaultPathCovers('*', 'sys/policy')).toBe(true);
+    expect(vaultPathCovers('+/*', 'sys/policy')).toBe(true);
+    expect(vaultPathCovers('secret/+/config', 'secret/app/config')).toBe(true);
+    expect(vaultPathCovers('secret/*', 'sys/policy')).toBe(false);
+    expect(vaultPathCovers('secret/app', 'secret/app/config')).toBe(false);
+  });
+
+  it('should report catch-all paths with full access', () => {
+    const findings = checkHclPolicies([hcl({
+      'path.*': { capabilities: ['create', 'read', 'update', 'delete', 'list'] },
+      'path.+/*': { capabilities: ['read', 'sudo'] }
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['VAULT_POLICY_WILDCARD_GRANT', 'error', 'path.*.capabilities'],
+      ['VAULT_POLICY_WILDCARD_GRANT', 'error', 'path.+/*.capabilities']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ policyPath: '*', capabilities: 'create, read, update, delete, list' });
+  });
+
+  it('should warn when a wildcard reaches a sensitive mount nothing denies', () => {
+    const findings = checkHclPolicies([hcl({
+      'path.+/*': { capabilities: ['read', 'list'] },
+      'path.sys/*': { capabilities: ['deny'] },
+      'path.auth/token/lookup-self': { capabilities: ['read'] }
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.context?.extras?.prefix])).toEqual([
+      ['VAULT_POLICY_DENY_MISSING', 'warning', 'auth/'],
+      ['VAULT_POLICY_DENY_MISSING', 'warning', 'identity/']
+    ]);
+  });
+
+  it('should accept policies scoped to their own mounts', () => {
+    expect(checkHclPolicies([hcl({
+      'path.secret/data/app/*': { capabilities: ['create', 'read', 'update', 'delete', 'list'] },
+      'path.sys/leases/renew': { capabilities: ['update'] }
+    })])).toEqual([]);
+  });
+
+  it('should report inline provider credentials without their values and disabled TLS', () => {
+    const findings = checkHclPolicies([hcl({
+      'provider.aws': { region: 'eu-west-1', access_key: 'AKIAEXAMPLE', secret_key: 'var.aws_secret_key' },
+      'provider.vault': { address: 'https', token: 'hvs.example', skip_tls_verify: true, auth_login: { password: 'file("pw")' } }
+    }, 'main.tf')]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['TF_PROVIDER_INLINE_CREDENTIAL', 'error', 'provider.aws.access_key'],
+      ['TF_PROVIDER_INLINE_CREDENTIAL', 'error', 'provider.vault.token'],
+      ['TF_PROVIDER_TLS_DISABLED', 'warning', 'provider.vault.skip_tls_verify']
+    ]);
+    expect(findings.map(finding => finding.message).join('\n')).not.toContain('hvs.example');
+  });
+
+  it('should fail the result only for errors', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [], metadata: { duration: 0, filesCompared: 1, totalKeys: 0 } };
+
+    expect(withHclPolicyFindings(result, [hcl({ 'provider.vault': { insecure: true } })]).success).toBe(true);
+    expect(withHclPolicyFindings(result, [hcl({ 'path.*': { capabilities: ['sudo'] } })]).success).toBe(false);
+    expect(withHclPolicyFindings(result, [hcl({ 'variable.region': { default: 'eu' } })])).toBe(result);
+  });
+});
@@ -0,0 +1,74 @@
+import {
+  actionMatches,
+  checkIamPolicies,
+  policyStatements,
+  withIamPolicyFindings
+} from '../../../src/application/validation/IamPolicyRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (content: Record<string, any>, path: string = 'policy.json', format: string = 'json'): ConfigFile => ({ path, format, content });
+
+describe('IamPolicyRules', () => {
+  it('should match action patterns case-insensitively', () => {
+    expect(actionMatches('iam:*', 'iam:PassRole')).toBe(true);
+    expect(actionMatches('IAM:passrole', 'iam:PassRole')).toBe(true);
+    expect(actionMatches('iam:Put?olePolicy', 'iam:PutRolePolicy')).toBe(true);
+    expect(actionMatches('s3:Get*', 'iam:PassRole')).toBe(false);
+  });
+
+  it('should find statements in documents, serverless configs, Terraform blocks and JSON strings', () => {
+    const statements = policyStatements({
+      provider: { iamRoleStatements: [{ Effect: 'Allow', Action: ['s3:GetObject'], Resource: 'arn:aws:s3:::bucket/*' }] },
+      'data.aws_iam_policy_document.deploy': { statement: { actions: ['ec2:Describe*'], resources: ['*'] } },
+      'resource.aws_iam_policy.legacy': {
+        policy: '{"Version":"2012-10-17","Statement":{"Effect":"Deny","NotAction":"s3:*","Resource":"*"}}'
+      }
+    });
+
+    expect(statements.map(statement => [statement.keyPath, statement.effect, statement.fields.action])).toEqual([
+      ['provider.iamRoleStatements.0', 'Allow', 'Action'],
+      ['data.aws_iam_policy_document.deploy.statement', 'Allow', 'actions'],
+      ['resource.aws_iam_policy.legacy.policy.Statement', 'Deny', 'Action']
+    ]);
+    expect(statements[2].notActions).toEqual(['s3:*']);
+  });
+
+  it('should report wildcard actions, NotAction allows and wildcard resources', () => {
+    const findings = checkIamPolicies([file({
+      Version: '2012-10-17',
+      Statement: [
+        { Effect: 'Allow', Action: '*', Resource: '*' },
+        { Effect: 'Allow', NotAction: 'iam:*', Resource: 'arn:aws:s3:::bucket' },
+        { Effect: 'Deny', Action: '*', Resource: '*' }
+      ]
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['IAM_WILDCARD_ACTION', 'error', 'Statement.0.Action'],
+      ['IAM_WILDCARD_RESOURCE', 'warning', 'Statement.0.Resource'],
+      ['IAM_NOT_ACTION_ALLOW', 'error', 'Statement.1.NotAction']
+    ]);
+  });
+
+  it('should warn about sensitive actions granted without a Condition', () => {
+    const findings = checkIamPolicies([file({
+      Statement: [
+        { Effect: 'Allow', Action: ['iam:*', 's3:GetObject'], Resource: 'arn:aws:iam::123456789012:role/app' },
+        { Effect: 'Allow', Action: 'sts:AssumeRole', Resource: 'arn:aws:iam::123456789012:role/ci', Condition: { StringEquals: { 'aws:PrincipalTag/team': 'ci' } } }
+      ]
+    })]);
+
+    expect(findings).toHaveLength(1);
+    expect(findings[0]).toMatchObject({ code: 'IAM_SENSITIVE_ACTION_UNCONDITIONED', severity: 'warning', path: 'Statement.0.Action' });
+    expect(findings[0].context?.extras?.actions).toContain('iam:PassRole');
+  });
+
+  it('should fail the result for errors only and leave clean files alone', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [], metadata: { duration: 0, filesCompared: 1, totalKeys: 0 } };
+    const terraform = file({ 'data.aws_iam_policy_document.all': { statement: { actions: ['*'], resources: ['*'] } } }, 'iam.tf', 'hcl');
+
+    expect(withIamPolicyFindings(result, [terraform]).success).toBe(false);
+    expect(withIamPolicyFindings(result, [file({ Statement: [{ Effect: 'Allow', Action: 'ec2:DescribeInstances', Resource: '*' }] })]).success).toBe(true);
+    expect(withIamPolicyFindings(result, [file({ database: { host: 'db' } })])).toBe(result);
+  });
+});
@@ -0,0 +1,51 @@
+import {
+  checkImageDefaults,
+  composeEnvironment,
+  runtimeEnvironments,
+  withImageDefaults
+} from '../../../src/application/validation/ImageDefaults';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+describe('ImageDefaults', () => {
+  const dockerfile: ConfigFile = {
+    path: 'Dockerfile',
+    format: 'dockerfile',
+    content: { NODE_ENV: 'production', LOG_LEVEL: 'info', ARG: { NODE_ENV: 'x' } }
+  };
+  const envFile: ConfigFile = { path: '.env.prod', format: 'env', content: { NODE_ENV: 'production', LOG_LEVEL: 'debug' } };
+  const compose: ConfigFile = {
+    path: 'docker-compose.yml',
+    format: 'yaml',
+    content: { services: { api: { environment: ['LOG_LEVEL=info', 'NODE_ENV=development', 'FROM_HOST'] }, db: { image: 'postgres:16' } } }
+  };
+
+  it('should read compose environments as mappings or lists', () => {
+    expect(composeEnvironment({ PORT: 8080, EMPTY: null })).toEqual({ PORT: '8080' });
+    expect(composeEnvironment(['A=1', 'B=x=y', 'FROM_HOST'])).toEqual({ A: '1', B: 'x=y' });
+    expect(runtimeEnvironments(compose).map(environment => environment.keyPath)).toEqual(['services.api.environment']);
+    expect(runtimeEnvironments({ path: 'app.yaml', format: 'yaml', content: { port: 1 } })).toEqual([]);
+  });
+
+  it('should warn about runtime values that differ from the image defaults', () => {
+    const warnings = checkImageDefaults([dockerfile, envFile, compose]);
+
+    expect(warnings.map(({ code, path, context }) => [code, context?.file, path])).toEqual([
+      ['IMAGE_DEFAULT_MISMATCH', '.env.prod', 'LOG_LEVEL'],
+      ['IMAGE_DEFAULT_MISMATCH', 'docker-compose.yml', 'services.api.environment.NODE_ENV']
+    ]);
+    expect(warnings[0].context).toMatchObject({
+      observedValue: 'debug',
+      expectedValue: 'info',
+      rule: { id: 'image-defaults' },
+      extras: { variable: 'LOG_LEVEL', dockerfile: 'Dockerfile' }
+    });
+    expect(warnings[1].context?.extras).toMatchObject({ service: 'api' });
+  });
+
+  it('should keep results without Dockerfiles untouched', () => {
+    expect(withImageDefaults(passing, [envFile, compose])).toBe(passing);
+    expect(withImageDefaults(passing, [dockerfile, envFile]).warnings).toHaveLength(1);
+  });
+});
@@ -0,0 +1,43 @@
+import { checkKeyOrder, isKeyOrderTarget, withKeyOrderFindings } from '../../../src/application/validation/KeyOrderRules';
+import { ValidationResult } from '../../../src/shared/types';
+
+const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+describe('KeyOrderRules', () => {
+  it('should report the first misplaced key of each mapping out of order', () => {
+    const findings = checkKeyOrder([
+      {
+        path: 'app.yaml',
+        format: 'yaml',
+        content: { server: { port: 80, host: 'x' }, database: { url: 'y' }, users: [{ name: 'b', age: 2 }] }
+      }
+    ], { order: 'alphabetical', files: [] });
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path, finding.context?.extras?.before])).toEqual([
+      ['KEY_ORDER_VIOLATION', 'warning', 'database', 'server'],
+      ['KEY_ORDER_VIOLATION', 'warning', 'server.host', 'port']
+    ]);
+  });
+
+  it('should rank declared keys first and skip mappings with integer-like keys', () => {
+    const files = [{ path: 'package.json', format: 'json', content: { name: 'a', version: '1', author: 'x', codes: { 2: 'b', 10: 'a' } } }];
+
+    expect(checkKeyOrder(files, { order: ['name', 'version'], files: [] })).toEqual([]);
+    expect(checkKeyOrder(files, { order: 'alphabetical', files: [] })[0].path).toBe('author');
+  });
+
+  it('should only check the files matching the globs', () => {
+    const settings = { order: 'alphabetical' as const, files: ['config/*.yaml'] };
+
+    expect(isKeyOrderTarget('config/app.yaml', settings)).toBe(true);
+    expect(isKeyOrderTarget('app.json', settings)).toBe(false);
+    expect(checkKeyOrder([{ path: 'app.json', format: 'json', content: { b: 1, a: 2 } }], settings)).toEqual([]);
+  });
+
+  it('should leave the result untouched when the check is off', () => {
+    const files = [{ path: 'app.yaml', format: 'yaml', content: { b: 1, a: 2 } }];
+
+    expect(withKeyOrderFindings(passing, files)).toBe(passing);
+    expect(withKeyOrderFindings(passing, files, { order: 'alphabetical', files: [] }).warnings).toHaveLength(1);
+  });
+});
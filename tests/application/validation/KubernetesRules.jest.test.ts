@@ -0,0 +1,110 @@
+import {
+  checkKubernetes,
+  isImagePinned,
+  kubernetesResources,
+  parseKubernetesQuantity,
+  withKubernetesFindings
+} from '../../../src/application/validation/KubernetesRules';
+import { ValidationResult } from '../../../src/shared/types';
+
+const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+const deployment = (name: string, container: Record<string, any>, replicas?: number) => ({
+  apiVersion: 'apps/v1',
+  kind: 'Deployment',
+  metadata: { name },
+  spec: {
+    ...(replicas !== undefined ? { replicas } : {}),
+    template: { spec: { containers: [container] } }
+  }
+});
+
+const healthy = {
+  name: 'api',
+  image: 'registry:5000/team/api:1.4.2',
+  resources: { requests: { cpu: '250m', memory: '256Mi' }, limits: { cpu: '1', memory: '1G' } },
+  readinessProbe: { httpGet: { path: '/ready', port: 8080 } },
+  livenessProbe: { httpGet: { path: '/live', port: 8080 } }
+};
+
+describe('KubernetesRules', () => {
+  it('should parse Kubernetes quantities', () => {
+    expect(parseKubernetesQuantity('500m')).toBe(0.5);
+    expect(parseKubernetesQuantity('2')).toBe(2);
+    expect(parseKubernetesQuantity('512Mi')).toBe(536870912);
+    expect(parseKubernetesQuantity('1G')).toBe(1e9);
+    expect(parseKubernetesQuantity('1e3')).toBe(1000);
+    expect(parseKubernetesQuantity('lots')).toBeUndefined();
+  });
+
+  it('should tell pinned images apart', () => {
+    expect(isImagePinned('nginx:1.25')).toBe(true);
+    expect(isImagePinned('nginx@sha256:abc')).toBe(true);
+    expect(isImagePinned('nginx')).toBe(false);
+    expect(isImagePinned('nginx:latest')).toBe(false);
+    expect(isImagePinned('registry:5000/nginx')).toBe(false);
+  });
+
+  it('should find manifests in single, List and multi-document files', () => {
+    const single = deployment('api', healthy);
+
+    expect(kubernetesResources(single)).toEqual([{ keyPath: '', manifest: single }]);
+    expect(kubernetesResources({ apiVersion: 'v1', kind: 'List', items: [single] })[0].keyPath).toBe('items.0');
+    expect(kubernetesResources({ 'Deployment/api': single }).map(resource => resource.keyPath)).toEqual(['Deployment/api']);
+    expect(kubernetesResources({ database: { host: 'db' } })).toEqual([]);
+  });
+
+  it('should accept a healthy workload', () => {
+    expect(checkKubernetes([{ path: 'k8s/prod.yaml', format: 'yaml', content: deployment('api', healthy, 3) }])).toEqual([]);
+  });
+
+  it('should report limits below requests, missing probes and unpinned images', () => {
+    const container = {
+      name: 'worker',
+      image: 'worker:latest',
+      resources: { requests: { cpu: '1', memory: '2Gi' }, limits: { cpu: '500m', memory: '4Gi' } },
+      readinessProbe: { exec: { command: ['true'] } }
+    };
+    const findings = checkKubernetes([{ path: 'k8s/dev.yaml', format: 'yaml', content: deployment('worker', container) }]);
+
+    expect(findings.map(({ code, severity, path }) => [code, severity, path])).toEqual([
+      ['K8S_LIMIT_BELOW_REQUEST', 'error', 'spec.template.spec.containers.0.resources.limits.cpu'],
+      ['K8S_PROBE_MISSING', 'warning', 'spec.template.spec.containers.0.livenessProbe'],
+      ['K8S_IMAGE_NOT_PINNED', 'error', 'spec.template.spec.containers.0.image']
+    ]);
+    expect(findings[0].context).toMatchObject({
+      rule: { id: 'kubernetes' },
+      extras: { resource: 'Deployment/worker', container: 'worker', resourceName: 'cpu' }
+    });
+  });
+
+  it('should require two replicas in production only', () => {
+    const content = deployment('api', healthy);
+
+    expect(checkKubernetes([{ path: 'k8s/dev.yaml', format: 'yaml', content }])).toEqual([]);
+    expect(checkKubernetes([{ path: 'deploy.yaml', format: 'yaml', content, environment: 'production' }])
+      .map(finding => [finding.code, finding.context?.extras?.replicas])).toEqual([['K8S_REPLICAS_TOO_LOW', 1]]);
+  });
+
+  it('should not ask init containers or jobs for probes', () => {
+    const job = {
+      apiVersion: 'batch/v1',
+      kind: 'CronJob',
+      metadata: { name: 'cleanup' },
+      spec: { jobTemplate: { spec: { template: { spec: { containers: [{ name: 'cleanup', image: 'cleanup:2.0' }] } } } } }
+    };
+    const withInit = deployment('api', healthy);
+    withInit.spec.template.spec = { ...withInit.spec.template.spec, initContainers: [{ name: 'migrate', image: 'migrate:1.0' }] } as any;
+
+    expect(checkKubernetes([
+      { path: 'cron.yaml', format: 'yaml', content: job },
+      { path: 'api.yaml', format: 'yaml', content: withInit }
+    ])).toEqual([]);
+  });
+
+  it('should leave ordinary configuration files alone', () => {
+    const files = [{ path: 'config.yaml', format: 'yaml', content: { image: 'nginx:latest' } }];
+
+    expect(withKubernetesFindings(passing, files)).toBe(passing);
+  });
+});
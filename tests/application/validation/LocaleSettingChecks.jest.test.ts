@@ -0,0 +1,41 @@
+import {
+  checkLocaleSettings,
+  localeSettingOf,
+  withLocaleSettingFindings
+} from '../../../src/application/validation/LocaleSettingChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, format: 'yaml', content });
+
+describe('LocaleSettingChecks', () => {
+  it('should match well-known keys by their last segment', () => {
+    expect(['spring.jackson.time-zone', 'TZ', 'i18n.supported_locales', 'billing.currency_code', 'currency.symbol']
+      .map(keyPath => localeSettingOf(keyPath)))
+      .toEqual(['timezone', 'timezone', 'locale', 'currency', undefined]);
+  });
+
+  it('should report invalid time zones, locales and currencies', () => {
+    const findings = checkLocaleSettings([file('app.yaml', {
+      app: { timezone: 'America/Buenos_Aires', TZ: 'GMT+3', locale: 'en_US.UTF-8' },
+      i18n: { supported_locales: ['es-AR', 'pt_BR', 'en'], fallback_locale: '${FALLBACK_LOCALE}' },
+      billing: { currency: 'EUR', currencies: ['usd', 'EURO'], locale: { default: 'es' } }
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.path, finding.context?.observedValue])).toEqual([
+      ['INVALID_TIMEZONE', 'app.TZ', 'GMT+3'],
+      ['INVALID_LOCALE', 'app.locale', 'en_US.UTF-8'],
+      ['INVALID_LOCALE', 'i18n.supported_locales', 'pt_BR'],
+      ['INVALID_CURRENCY', 'billing.currencies', 'EURO']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ type: 'timezone', expected: 'an IANA time zone' });
+  });
+
+  it('should fail a passing result when a currency code is invalid', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withLocaleSettingFindings(result, [file('shop.yaml', { default_currency: '$' })]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['INVALID_CURRENCY']);
+    expect(withLocaleSettingFindings(result, [file('shop.yaml', { default_currency: 'ARS' })])).toBe(result);
+  });
+});
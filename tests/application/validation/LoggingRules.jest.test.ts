@@ -0,0 +1,154 @@
+import {
+  checkLogging,
+  levelName,
+  loggingConfig,
+  redactionLists,
+  withLoggingFindings
+} from '../../../src/application/validation/LoggingRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, format: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format,
+  content,
+  ...(environment ? { environment } : {})
+});
+
+// logback.xml as parsed by the XML reader (attributes merged, root element dropped)
+const logback = (rootLevel: string): Record<string, any> => ({
+  appender: [
+    { name: 'FILE', class: 'ch.qos.logback.core.FileAppender', file: '/var/log/app.log' },
+    {
+      name: 'JSON',
+      class: 'ch.qos.logback.core.rolling.RollingFileAppender',
+      rollingPolicy: { class: 'ch.qos.logback.core.rolling.TimeBasedRollingPolicy', fileNamePattern: 'app.%d.log' },
+      encoder: {
+        class: 'net.logstash.logback.encoder.LogstashEncoder',
+        jsonGeneratorDecorator: {
+          class: 'net.logstash.logback.mask.MaskingJsonGeneratorDecorator',
+          path: ['password', 'headers/authorization'],
+          value: '(secret|api[_-]?key|token|cookie)'
+        }
+      }
+    }
+  ],
+  logger: { name: 'com.example.sql', level: 'TRACE' },
+  root: { level: rootLevel, 'appender-ref': { ref: 'FILE' } }
+});
+
+describe('LoggingRules', () => {
+  it('should read levels written as attributes, elements and substitutions', () => {
+    expect(levelName('DEBUG')).toBe('debug');
+    expect(levelName({ value: 'Info' })).toBe('info');
+    expect(levelName('${LOG_LEVEL:-debug}')).toBe('debug');
+    expect(levelName('${env:LOG_LEVEL:-WARN}')).toBe('warn');
+    expect(levelName(undefined)).toBeUndefined();
+  });
+
+  it('should read logback levels and file appenders', () => {
+    const config = loggingConfig(file('logback.xml', 'xml', logback('INFO')));
+
+    expect(config?.framework).toBe('logback');
+    expect(config?.levels).toEqual([
+      { keyPath: 'root.level', logger: 'root', level: 'info' },
+      { keyPath: 'logger.level', logger: 'com.example.sql', level: 'trace' }
+    ]);
+    expect(config?.fileOutputs).toEqual([
+      { keyPath: 'appender.0', name: 'FILE', rotated: false },
+      { keyPath: 'appender.1', name: 'JSON', rotated: true }
+    ]);
+  });
+
+  it('should read log4j2 XML, JSON and properties configurations', () => {
+    const xml = loggingConfig(file('log4j2.xml', 'xml', {
+      status: 'WARN',
+      Appenders: {
+        Console: { name: 'STDOUT' },
+        RollingFile: { name: 'ROLLING', fileName: 'app.log', Policies: { SizeBasedTriggeringPolicy: { size: '10 MB' } } },
+        File: { name: 'AUDIT', fileName: 'audit.log' }
+      },
+      Loggers: { Root: { level: 'debug' }, AsyncLogger: [{ name: 'com.example', level: 'info' }] }
+    }));
+    const json = loggingConfig(file('log4j2.json', 'json', {
+      configuration: { appenders: { Appender: [{ type: 'RollingFile', name: 'R' }] }, loggers: { root: { level: 'error' } } }
+    }));
+    const properties = loggingConfig(file('log4j2.properties', 'properties', {
+      'appender.file.type': 'File',
+      'appender.file.name': 'LOGFILE',
+      'appender.rolling.type': 'RollingFile',
+      'appender.rolling.policies.type': 'Policies',
+      'rootLogger': 'debug, LOGFILE',
+      'logger.app.name': 'com.example',
+      'logger.app.level': 'trace'
+    }));
+
+    expect(xml?.levels.map(level => [level.keyPath, level.level])).toEqual([['Loggers.Root.level', 'debug'], ['Loggers.AsyncLogger.0.level', 'info']]);
+    expect(xml?.fileOutputs.map(output => [output.name, output.rotated])).toEqual([['ROLLING', true], ['AUDIT', false]]);
+    expect(json?.levels).toEqual([{ keyPath: 'configuration.loggers.root.level', logger: 'root', level: 'error' }]);
+    expect(json?.fileOutputs).toEqual([{ keyPath: 'configuration.appenders.Appender.0', name: 'R', rotated: false }]);
+    expect(properties?.levels.map(level => [level.logger, level.level])).toEqual([['root', 'debug'], ['com.example', 'trace']]);
+    expect(properties?.fileOutputs).toEqual([
+      { keyPath: 'appender.file.type', name: 'LOGFILE', rotated: false },
+      { keyPath: 'appender.rolling.type', name: 'rolling', rotated: true }
+    ]);
+  });
+
+  it('should read zap configurations and skip other files', () => {
+    const zap = loggingConfig(file('zap.json', 'json', {
+      level: 'debug',
+      encoding: 'json',
+      outputPaths: ['stdout', '/var/log/app.log', 'lumberjack:///var/log/app-rotated.log']
+    }));
+
+    expect(zap?.levels).toEqual([{ keyPath: 'level', logger: 'root', level: 'debug' }]);
+    expect(zap?.fileOutputs).toEqual([
+      { keyPath: 'outputPaths.1', name: '/var/log/app.log', rotated: false },
+      { keyPath: 'outputPaths.2', name: 'lumberjack:///var/log/app-rotated.log', rotated: true }
+    ]);
+    expect(loggingConfig(file('app.json', 'json', { level: 'debug', database: { host: 'db' } }))).toBeUndefined();
+  });
+
+  it('should find redaction lists by name or class', () => {
+    expect(redactionLists(logback('INFO'))).toEqual([{
+      keyPath: 'appender.1.encoder.jsonGeneratorDecorator',
+      entries: ['net.logstash.logback.mask.MaskingJsonGeneratorDecorator', 'password', 'headers/authorization', '(secret|api[_-]?key|token|cookie)']
+    }]);
+    expect(redactionLists({ redact: { paths: ['req.headers.cookie', 'password'] } })).toEqual([
+      { keyPath: 'redact', entries: ['req.headers.cookie', 'password'] }
+    ]);
+  });
+
+  it('should report DEBUG levels in production only', () => {
+    const production = checkLogging([file('logback-prod.xml', 'xml', logback('DEBUG'))]);
+    const development = checkLogging([file('logback-dev.xml', 'xml', logback('DEBUG'))]);
+
+    expect(production.filter(finding => finding.code === 'LOGGING_DEBUG_IN_PRODUCTION').map(finding => [finding.path, finding.severity])).toEqual([
+      ['root.level', 'error'],
+      ['logger.level', 'error']
+    ]);
+    expect(development.map(finding => finding.code)).toEqual(['LOGGING_FILE_ROTATION_MISSING']);
+    expect(development[0].path).toBe('appender.0');
+  });
+
+  it('should list the sensitive fields no redaction list covers', () => {
+    const findings = checkLogging([file('zap.yaml', 'yaml', {
+      level: 'info',
+      outputPaths: ['stdout'],
+      redact: ['password', 'req.headers.authorization']
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['LOGGING_FIELDS_UNREDACTED', 'warning', 'redact']
+    ]);
+    expect(findings[0].context?.extras).toMatchObject({ fields: 'secret, token, api_key, cookie' });
+  });
+
+  it('should fail a passing result when production logs at DEBUG', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withLoggingFindings(result, [file('logback.xml', 'xml', logback('DEBUG'), 'production')]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['LOGGING_DEBUG_IN_PRODUCTION', 'LOGGING_DEBUG_IN_PRODUCTION']);
+    expect(withLoggingFindings(result, [file('app.json', 'json', { port: 8080 })])).toBe(result);
+  });
+});
@@ -0,0 +1,83 @@
+import {
+  brokerConfig,
+  checkMessageBrokers,
+  withMessageBrokerFindings
+} from '../../../src/application/validation/MessageBrokerRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+describe('MessageBrokerRules', () => {
+  it('should read Kafka and RabbitMQ settings by key name', () => {
+    const config = brokerConfig({
+      spring: {
+        kafka: {
+          'bootstrap-servers': 'kafka-1:9092,kafka-2:9092',
+          consumer: { 'group-id': 'orders' },
+          properties: { 'security.protocol': 'SASL_SSL' }
+        },
+        rabbitmq: { host: 'rabbit.internal' }
+      },
+      amqp_url: 'amqps://mq/vhost'
+    });
+
+    expect(config.endpoints.map(endpoint => [endpoint.broker, endpoint.keyPath])).toEqual([
+      ['kafka', 'spring.kafka.bootstrap-servers'],
+      ['rabbitmq', 'spring.rabbitmq.host'],
+      ['rabbitmq', 'amqp_url']
+    ]);
+    expect(config.consumerGroups).toEqual([{ keyPath: 'spring.kafka.consumer.group-id', value: 'orders' }]);
+    expect(config.securityProtocol).toBe(true);
+    expect(brokerConfig({ project: { groupId: 'com.example' } }).consumerGroups).toEqual([]);
+  });
+
+  it('should report foreign brokers, unsecured production clients, low replication and misnamed groups', () => {
+    const findings = checkMessageBrokers([
+      file('kafka-prod.yaml', {
+        kafka: {
+          'bootstrap.servers': 'kafka-staging-1:9092',
+          'group.id': 'billing',
+          'default.replication.factor': 2,
+          'offsets.topic.replication.factor': -1
+        },
+        rabbitmq: { url: 'amqp://mq-prod:5672' }
+      }),
+      file('kafka-dev.yaml', { kafka: { 'bootstrap.servers': 'localhost:9092', 'group.id': 'billing.dev', 'replication.factor': 1 } }),
+      file('.env.staging', { KAFKA_BOOTSTRAP_SERVERS: 'kafka-prod:9092' })
+    ], { consumerGroupPattern: '^[a-z]+\\.(dev|staging|prod)$' });
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['BROKER_ENVIRONMENT_MISMATCH', 'error', 'kafka.bootstrap.servers'],
+      ['BROKER_SECURITY_MISSING', 'error', 'kafka.bootstrap.servers'],
+      ['BROKER_SECURITY_MISSING', 'error', 'rabbitmq.url'],
+      ['BROKER_REPLICATION_TOO_LOW', 'error', 'kafka.default.replication.factor'],
+      ['BROKER_CONSUMER_GROUP_NAMING', 'warning', 'kafka.group.id'],
+      ['BROKER_ENVIRONMENT_MISMATCH', 'error', 'KAFKA_BOOTSTRAP_SERVERS']
+    ]);
+    expect(findings[0].context?.extras).toMatchObject({ suspectedEnvironment: 'staging', token: 'staging' });
+    expect(findings[3].context?.extras).toEqual({ replicationFactor: 2, minimum: 3 });
+  });
+
+  it('should accept secure listeners and read Strimzi topic replicas', () => {
+    const findings = checkMessageBrokers([
+      file('kafka.yaml', { 'bootstrap.servers': 'SASL_SSL://kafka-1:9093,SSL://kafka-2:9093' }, 'production'),
+      file('topic.yaml', { apiVersion: 'kafka.strimzi.io/v1beta2', kind: 'KafkaTopic', spec: { partitions: 6, replicas: 1 } }, 'production')
+    ], { minReplicationFactor: 2 });
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([['BROKER_REPLICATION_TOO_LOW', 'spec.replicas']]);
+  });
+
+  it('should fail a passing result when a broker belongs to another environment', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withMessageBrokerFindings(result, [file('app-dev.yaml', { kafka: { 'bootstrap.servers': 'kafka.prd:9092' } })]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['BROKER_ENVIRONMENT_MISMATCH']);
+    expect(withMessageBrokerFindings(result, [file('app-dev.yaml', { 'bootstrap.servers': 'localhost:9092' })])).toBe(result);
+  });
+});
@@ -0,0 +1,44 @@
+import { applyMessageTemplates, renderTemplate } from '../../../src/application/validation/MessageTemplates';
+import { ValidationResult } from '../../../src/shared/types';
+
+describe('MessageTemplates', () => {
+  it('should render placeholders, nested values and lists', () => {
+    expect(renderTemplate('{{ key }} in {{file}} ({{context.files}}) {{unknown}}!', {
+      key: 'db.host',
+      file: 'a.yaml',
+      context: { files: ['a.yaml', 'b.yaml'] }
+    })).toBe('db.host in a.yaml (a.yaml, b.yaml) !');
+  });
+
+  it('should replace messages of templated codes only', () => {
+    const result: ValidationResult = {
+      success: false,
+      errors: [
+        { code: 'MISSING_KEY', message: 'original', severity: 'error', path: 'db.host', context: { file: 'prod.yaml' } },
+        { code: 'PARSE_ERROR', message: 'bad yaml', severity: 'error', context: { file: 'dev.yaml' } }
+      ],
+      warnings: []
+    };
+
+    const rendered = applyMessageTemplates(
+      result,
+      { MISSING_KEY: '[{{environment}}] {{key}} missing in {{file}}, see https://runbooks.example.com/{{code}}' },
+      { environmentFiles: { 'prod.yaml': 'prod' } }
+    );
+
+    expect(rendered.errors.map(error => error.message)).toEqual([
+      '[prod] db.host missing in prod.yaml, see https://runbooks.example.com/MISSING_KEY',
+      'bad yaml'
+    ]);
+  });
+
+  it('should fall back to the environment a finding inferred for its file', () => {
+    const result: ValidationResult = {
+      success: true,
+      errors: [],
+      warnings: [{ code: 'ENVIRONMENT_LEAKAGE', message: 'original', severity: 'warning', context: { file: 'dev.yaml', environment: 'dev' } }]
+    };
+
+    expect(applyMessageTemplates(result, { ENVIRONMENT_LEAKAGE: '{{environment}}: {{file}}' }).warnings[0].message).toBe('dev: dev.yaml');
+  });
+});
@@ -0,0 +1,72 @@
+import {
+  checkMigrations,
+  migrationSettings,
+  withMigrationFindings
+} from '../../../src/application/validation/MigrationChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+const flyway = (settings: Record<string, unknown>): Record<string, any> => ({ spring: { flyway: settings } });
+
+describe('MigrationChecks', () => {
+  it('should read settings from sections, variables and tool-named files', () => {
+    const spring = migrationSettings(file('application.yaml', flyway({ locations: 'classpath:db/migration', 'clean-disabled': true, placeholders: { owner: 'app' } })));
+    const variables = migrationSettings(file('.env.prod', { FLYWAY_BASELINE_VERSION: '3', DATABASE_URL: 'postgres://db' }));
+    const liquibase = migrationSettings(file('liquibase.properties', { changeLogFile: 'db/changelog.xml', url: 'jdbc:postgresql://db', defaultSchemaName: 'app' }));
+    const migrate = migrationSettings(file('migrate.yaml', { migrate: { path: 'file://migrations', database: 'postgres://db' } }));
+
+    expect(spring.map(setting => [setting.tool, setting.setting, setting.keyPath])).toEqual([
+      ['flyway', 'locations', 'spring.flyway.locations'],
+      ['flyway', 'cleanDisabled', 'spring.flyway.clean-disabled']
+    ]);
+    expect(variables.map(setting => [setting.tool, setting.setting, setting.value])).toEqual([['flyway', 'baselineVersion', '3']]);
+    expect(liquibase.map(setting => setting.setting)).toEqual(['changeLog', 'defaultSchema']);
+    expect(migrate.map(setting => [setting.tool, setting.setting])).toEqual([['golang-migrate', 'source']]);
+  });
+
+  it('should report settings that differ from the value most environments use', () => {
+    const findings = checkMigrations([
+      file('application-dev.yaml', flyway({ schemas: 'app, audit', locations: 'classpath:db/migration' })),
+      file('application-staging.yaml', flyway({ schemas: ['app', 'audit'], locations: 'classpath:db/migration', baselineVersion: 2 })),
+      file('application-prod.yaml', flyway({ schemas: 'app', locations: 'classpath:db/migration', cleanDisabled: true }))
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.path, finding.context?.environment])).toEqual([
+      ['MIGRATION_SETTING_DRIFT', 'spring.flyway.schemas', 'prod'],
+      ['MIGRATION_SETTING_DRIFT', 'spring.flyway.baselineVersion', 'staging']
+    ]);
+    expect(findings[0].context?.extras).toMatchObject({ value: 'app', expected: 'app,audit', expectedIn: 'dev, staging' });
+    expect(findings[1].message).toContain('baselineVersion is 2 in staging but (unset) in dev, prod');
+  });
+
+  it('should require cleanDisabled and reject destructive options in production', () => {
+    const findings = checkMigrations([
+      file('flyway-prod.yaml', { flyway: { locations: 'filesystem:sql', cleanOnValidationError: 'true' } }),
+      file('.env.production', { FLYWAY_LOCATIONS: 'filesystem:sql' }),
+      file('liquibase-prod.properties', { changeLogFile: 'changelog.xml', dropFirst: true }),
+      file('flyway-dev.yaml', { flyway: { locations: 'filesystem:sql', cleanDisabled: false } })
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['MIGRATION_CLEAN_NOT_DISABLED', 'flyway.cleanDisabled'],
+      ['MIGRATION_DESTRUCTIVE_OPTION', 'flyway.cleanOnValidationError'],
+      ['MIGRATION_CLEAN_NOT_DISABLED', 'FLYWAY_CLEAN_DISABLED'],
+      ['MIGRATION_DESTRUCTIVE_OPTION', 'dropFirst']
+    ]);
+  });
+
+  it('should fail a passing result when production can drop its schema', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withMigrationFindings(result, [file('config.yaml', { flyway: { cleanDisabled: false } }, 'production')]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['MIGRATION_CLEAN_NOT_DISABLED']);
+    expect(withMigrationFindings(result, [file('config.yaml', { database: { host: 'db' } })])).toBe(result);
+  });
+});
@@ -0,0 +1,127 @@
+import {
+  checkOpenApi,
+  isOpenApiSpec,
+  openApiEndpoints,
+  openApiServers,
+  requirementLevel,
+  withOpenApiFindings
+} from '../../../src/application/validation/OpenApiChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const spec = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content: { openapi: '3.0.3', ...content },
+  ...(environment ? { environment } : {})
+});
+
+const schemes = {
+  apiKey: { type: 'apiKey', in: 'header', name: 'X-Api-Key' },
+  bearer: { type: 'http', scheme: 'bearer' },
+  basic: { type: 'http', scheme: 'basic' }
+};
+
+describe('OpenApiChecks', () => {
+  it('should recognize OpenAPI 3 and Swagger 2 documents', () => {
+    expect(isOpenApiSpec({ openapi: '3.1.0', paths: {} })).toBe(true);
+    expect(isOpenApiSpec({ swagger: '2.0', paths: {} })).toBe(true);
+    expect(isOpenApiSpec({ openapi: '3.1.0' })).toBe(false);
+    expect(isOpenApiSpec({ paths: {} })).toBe(false);
+  });
+
+  it('should rate requirements by their weakest alternative and strongest scheme', () => {
+    expect(requirementLevel(undefined, schemes)).toBe('none');
+    expect(requirementLevel([{ bearer: [] }], schemes)).toBe('token');
+    expect(requirementLevel([{ bearer: [] }, { apiKey: [] }], schemes)).toBe('apiKey/basic');
+    expect(requirementLevel([{ basic: [], bearer: [] }], schemes)).toBe('token');
+    expect(requirementLevel([{ bearer: [] }, {}], schemes)).toBe('none');
+  });
+
+  it('should list endpoints with operation security overriding the global one', () => {
+    const endpoints = openApiEndpoints({
+      security: [{ bearer: [] }],
+      components: { securitySchemes: schemes },
+      paths: {
+        '/users/{id}': { get: {}, delete: { security: [] }, parameters: [] },
+        '/{proxy+}': { 'x-amazon-apigateway-any-method': {} }
+      }
+    });
+
+    expect(endpoints.map(endpoint => [endpoint.label, endpoint.route, endpoint.keyPath, endpoint.auth])).toEqual([
+      ['GET /users/{id}', '/users/{}', 'paths./users/{id}.get', 'token'],
+      ['DELETE /users/{id}', '/users/{}', 'paths./users/{id}.delete', 'none'],
+      ['ANY /{proxy+}', '/{}', 'paths./{proxy+}.x-amazon-apigateway-any-method', 'token']
+    ]);
+  });
+
+  it('should read OpenAPI 3 servers and Swagger 2 hosts', () => {
+    expect(openApiServers({ servers: [{ url: 'https://api.example.com/v1' }, {}] })).toEqual(['https://api.example.com/v1']);
+    expect(openApiServers({ host: 'api.example.com', basePath: '/v1', schemes: ['https'] })).toEqual(['https://api.example.com/v1']);
+    expect(openApiServers({})).toEqual([]);
+  });
+
+  it('should report production endpoints missing from the other specs and weakened auth', () => {
+    const staging = spec('openapi.staging.yaml', {
+      security: [{ bearer: [] }],
+      components: { securitySchemes: schemes },
+      paths: { '/users/{id}': { get: {} } }
+    });
+    const production = spec('openapi.prod.yaml', {
+      components: { securitySchemes: schemes },
+      paths: {
+        '/users/{userId}': { get: { security: [{ apiKey: [] }] } },
+        '/internal/debug': { post: {} }
+      }
+    });
+
+    const findings = checkOpenApi([staging, production]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['OPENAPI_AUTH_WEAKENED', 'error', 'paths./users/{userId}.get'],
+      ['OPENAPI_ENDPOINT_UNDOCUMENTED', 'error', 'paths./internal/debug.post']
+    ]);
+    expect(findings[0].context?.extras).toEqual({
+      endpoint: 'GET /users/{userId}',
+      auth: 'apiKey/basic',
+      expectedAuth: 'token',
+      spec: 'openapi.staging.yaml'
+    });
+    expect(findings[1].context?.environment).toBe('prod');
+  });
+
+  it('should match any-method gateway operations and use specs without an environment as references', () => {
+    const findings = checkOpenApi([
+      spec('openapi.yaml', { paths: { '/{proxy+}': { 'x-amazon-apigateway-any-method': {} } } }),
+      spec('gateway-export.json', { paths: { '/{path}': { get: {}, post: {} } } }, 'production')
+    ]);
+
+    expect(findings).toEqual([]);
+  });
+
+  it('should warn when production shares a server with another environment', () => {
+    const findings = checkOpenApi([
+      spec('api.dev.yaml', { servers: [{ url: 'https://api.example.com' }], paths: {} }),
+      spec('api.prod.yaml', { servers: [{ url: 'https://eu.api.example.com' }, { url: 'https://api.example.com' }], paths: {} })
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['OPENAPI_SERVER_SHARED', 'warning', 'servers.1.url']
+    ]);
+  });
+
+  it('should skip production specs with nothing to compare to', () => {
+    expect(checkOpenApi([spec('api.prod.yaml', { paths: { '/health': { get: {} } } })])).toEqual([]);
+  });
+
+  it('should fail a passing result when production exposes an undocumented endpoint', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withOpenApiFindings(result, [
+      spec('api.staging.yaml', { paths: {} }),
+      spec('api.prod.yaml', { paths: { '/admin': { get: {} } } })
+    ]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['OPENAPI_ENDPOINT_UNDOCUMENTED']);
+    expect(withOpenApiFindings(result, [])).toBe(result);
+  });
+});
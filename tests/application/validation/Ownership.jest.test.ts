@@ -0,0 +1,45 @@
+import { ownerOf, ownsKey, UNOWNED, withOwners } from '../../../src/application/validation/Ownership';
+import { OwnerSettings, ValidationResult } from '../../../src/shared/types';
+
+const owners: OwnerSettings[] = [
+  { team: 'payments', keys: ['payments', 'billing.*.stripe_key'], files: [], channel: '#payments-alerts' },
+  { team: 'platform', keys: [], files: ['infra/**'] }
+];
+
+describe('Ownership', () => {
+  it('should own a key and the keys below it', () => {
+    expect(ownsKey('payments.stripe.key', 'payments')).toBe(true);
+    expect(ownsKey('billing.eu.stripe_key', 'billing.*.stripe_key')).toBe(true);
+    expect(ownsKey('billing.eu', 'billing.*.stripe_key')).toBe(false);
+    expect(ownsKey('paymentsx', 'payments')).toBe(false);
+  });
+
+  it('should prefer the key owner over the file owner', () => {
+    expect(ownerOf({ code: 'X', message: 'm', severity: 'error', context: { file: 'infra/app.yaml', keyPath: 'payments.url' } }, owners)?.team)
+      .toBe('payments');
+    expect(ownerOf({ code: 'X', message: 'm', severity: 'error', context: { files: ['app.yaml', 'infra/prod.yaml'], keyPath: 'db' } }, owners)?.team)
+      .toBe('platform');
+    expect(ownerOf({ code: 'X', message: 'm', severity: 'error', path: 'db' }, owners)).toBeUndefined();
+  });
+
+  it('should tag findings and count them per team', () => {
+    const result: ValidationResult = {
+      success: false,
+      errors: [{ code: 'A', message: 'a', severity: 'error', path: 'payments.key' }],
+      warnings: [
+        { code: 'B', message: 'b', severity: 'warning', context: { file: 'infra/main.tf' } },
+        { code: 'C', message: 'c', severity: 'warning', path: 'other' }
+      ]
+    };
+    const routed = withOwners(result, owners);
+
+    expect(routed.errors[0].context?.owner).toBe('payments');
+    expect(routed.warnings.map(warning => warning.context?.owner)).toEqual(['platform', UNOWNED]);
+    expect(routed.owners).toEqual([
+      { team: 'payments', channel: '#payments-alerts', errors: 1, warnings: 0, info: 0 },
+      { team: 'platform', errors: 0, warnings: 1, info: 0 },
+      { team: UNOWNED, errors: 0, warnings: 1, info: 0 }
+    ]);
+    expect(withOwners(result, [])).toBe(result);
+  });
+});
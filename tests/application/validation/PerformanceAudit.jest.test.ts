@@ -0,0 +1,94 @@
+import {
+  checkPerformanceCategory,
+  runPerformanceAudit,
+  withPerformanceAudit,
+} from '../../../src/application/validation/PerformanceAudit';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (content: Record<string, any>): ConfigFile => ({ path: 'app.yaml', format: 'yaml', content });
+
+const codesAndPaths = (files: ConfigFile[], settings = {}) =>
+  runPerformanceAudit(files, settings).map(finding => `${finding.code} ${finding.path}`);
+
+describe('PerformanceAudit', () => {
+  it('should report the built-in foot-guns by key name', () => {
+    expect(codesAndPaths([file({
+      api: { url: 'https://api.internal', timeout: '0s' },
+      payments: { base_url: 'https://pay.internal' },
+      database: { pool: { min: 0, max: 0 }, maxPoolSize: -1, max_open_conns: 'unlimited' },
+      client: { max_retries: -1, retry: { maxAttempts: 50 } },
+      cache: { max_size: 0, ttl: 300 },
+      session: { cache_size: 'unlimited' },
+    })])).toEqual([
+      'PERF_TIMEOUT_UNBOUNDED api.timeout',
+      'PERF_TIMEOUT_MISSING payments',
+      'PERF_POOL_EMPTY database.pool.max',
+      'PERF_POOL_UNBOUNDED database.maxPoolSize',
+      'PERF_POOL_UNBOUNDED database.max_open_conns',
+      'PERF_RETRIES_UNBOUNDED client.max_retries',
+      'PERF_VALUE_TOO_HIGH client.retry.maxAttempts',
+      'PERF_CACHE_UNBOUNDED cache.max_size',
+      'PERF_CACHE_UNBOUNDED session.cache_size',
+    ]);
+  });
+
+  it('should accept sane values and placeholders', () => {
+    expect(codesAndPaths([file({
+      search: { endpoint: 'https://search.internal', read_timeout: '5s', pool_size: 10, retries: 3 },
+      cache: { max_entries: 1000, size: '${CACHE_SIZE}' },
+      client: { retries: 0 },
+    })])).toEqual([]);
+  });
+
+  it('should let a timeout cover the clients nested under it', () => {
+    expect(codesAndPaths([file({
+      http: { timeout: 30000, clients: { search: { endpoint: 'https://search.internal' } } },
+    })])).toEqual([]);
+  });
+
+  it('should hold values to the configured bounds, reading durations and sizes', () => {
+    const settings = {
+      timeouts: { keys: [], max: '60s' },
+      pools: { keys: [], max: 200 },
+      retries: { keys: [], min: 1 },
+      caches: { keys: [], max: '1GB' },
+    };
+
+    expect(codesAndPaths([file({
+      a: { timeout: '2m', pool_size: 500, cache: { max_memory: '2GB' }, retries: 0 },
+    })], settings)).toEqual([
+      'PERF_VALUE_TOO_HIGH a.timeout',
+      'PERF_VALUE_TOO_HIGH a.pool_size',
+      'PERF_VALUE_TOO_LOW a.retries',
+      'PERF_VALUE_TOO_HIGH a.cache.max_memory',
+    ]);
+  });
+
+  it('should replace the built-in key names with configured patterns', () => {
+    const findings = checkPerformanceCategory(
+      file({ svc: { pool: { size: 0 }, hikari: { 'maximum-pool-size': 1000 } } }),
+      'pools',
+      { pools: { keys: ['svc.hikari.maximum-pool-size'], max: 100 } }
+    );
+
+    expect(findings).toHaveLength(1);
+    expect(findings[0]).toMatchObject({
+      code: 'PERF_VALUE_TOO_HIGH',
+      severity: 'warning',
+      context: { file: 'app.yaml', rule: { id: 'performance' }, extras: { category: 'pools', value: 1000, bound: 100 } },
+    });
+  });
+
+  it('should not require timeouts when told so', () => {
+    expect(codesAndPaths([file({ svc: { url: 'https://svc.internal' } })], { timeouts: { keys: [], required: false } })).toEqual([]);
+  });
+
+  it('should only add findings when configured', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const files = [file({ db: { pool_size: 0 } })];
+
+    expect(withPerformanceAudit(result, files, undefined)).toBe(result);
+    expect(withPerformanceAudit(result, files, {}).warnings.map(warning => warning.code)).toEqual(['PERF_POOL_EMPTY']);
+    expect(withPerformanceAudit(result, files, {}).success).toBe(true);
+  });
+});
@@ -0,0 +1,75 @@
+import {
+  checkRateLimits,
+  isUnlimited,
+  withRateLimitFindings
+} from '../../../src/application/validation/RateLimitRules';
+import { ConfigFile, RateLimitSettings, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+const settings: RateLimitSettings = {
+  endpoints: ['routes.*'],
+  quotas: ['plans.*.quota'],
+  public: 'exposure',
+  limit: 'throttle.rate',
+  burst: 'throttle.burst'
+};
+
+describe('RateLimitRules', () => {
+  it('should recognize unlimited values', () => {
+    expect([-1, '-1', 'Unlimited', 'none', 0, 100, '100r/s', null].map(isUnlimited))
+      .toEqual([true, true, true, true, false, false, false, false]);
+  });
+
+  it('should report unlimited public endpoints, excessive bursts and unlimited production quotas', () => {
+    const findings = checkRateLimits([
+      file('gateway-prod.yaml', {
+        routes: {
+          login: { exposure: 'public', throttle: { rate: '10r/s', burst: 50 } },
+          search: { exposure: 'public' },
+          export: { exposure: 'public', throttle: { rate: 'unlimited' } },
+          admin: { exposure: 'internal' },
+          health: { exposure: 'public', throttle: { rate: 100, burst: 150 } }
+        },
+        plans: { free: { quota: 1000 }, enterprise: { quota: -1 } }
+      }),
+      file('gateway-dev.yaml', { plans: { enterprise: { quota: 'unlimited' } } })
+    ], settings);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['RATE_LIMIT_BURST_EXCESSIVE', 'warning', 'routes.login.throttle.burst'],
+      ['RATE_LIMIT_MISSING', 'error', 'routes.search.throttle.rate'],
+      ['RATE_LIMIT_MISSING', 'error', 'routes.export.throttle.rate'],
+      ['RATE_LIMIT_QUOTA_UNLIMITED', 'error', 'plans.enterprise.quota']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ endpoint: 'routes.login', burst: 50, limit: 10, factor: 2, maximum: 20 });
+    expect(findings[3].context?.environment).toBe('prod');
+  });
+
+  it('should use the default key paths and burst factor', () => {
+    const findings = checkRateLimits([file('api.yaml', {
+      apis: {
+        orders: { public: true, rate_limit: { limit: 20, burst: 100 } },
+        users: { public: true }
+      }
+    })], { endpoints: ['apis.*'], quotas: [], burstFactor: 10 });
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([['RATE_LIMIT_MISSING', 'apis.users.rate_limit.limit']]);
+  });
+
+  it('should stay off without settings and fail a passing result otherwise', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const files = [file('gateway.yaml', { routes: { search: { exposure: 'public' } } })];
+
+    expect(withRateLimitFindings(result, files)).toBe(result);
+
+    const updated = withRateLimitFindings(result, files, settings);
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['RATE_LIMIT_MISSING']);
+  });
+});
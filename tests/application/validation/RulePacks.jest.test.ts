@@ -0,0 +1,90 @@
+import { RulePack, runRulePacks, selectRulePacks } from '../../../src/application/validation/RulePacks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+import { withFindings } from '../../../src/shared/utils/Findings';
+
+const file = (path: string): ConfigFile => ({ path, format: 'yaml', content: {} });
+
+const passed: ValidationResult = { success: true, errors: [], warnings: [] };
+
+// Reports one warning per file it runs on, naming the pack
+const reporting = (id: string, extra: Partial<RulePack> = {}): RulePack => ({
+  id,
+  run: (result, files) => withFindings(result, [
+    ...result.warnings,
+    ...files.map(candidate => ({ code: id, message: candidate.path, severity: 'warning' as const })),
+  ]),
+  ...extra,
+});
+
+describe('RulePacks', () => {
+  const files = [file('app.yaml'), file('secrets/db.yaml')];
+
+  it('should run the packs in order, each on the result of the one before', async () => {
+    const { result } = await runRulePacks(passed, [reporting('first'), reporting('second')], [], files);
+
+    expect(result.warnings.map(warning => `${warning.code} ${warning.message}`)).toEqual([
+      'first app.yaml',
+      'first secrets/db.yaml',
+      'second app.yaml',
+      'second secrets/db.yaml',
+    ]);
+  });
+
+  it('should give each pack the files it is scoped to', async () => {
+    const { result } = await runRulePacks(passed, [reporting('secrets'), reporting('off')], [
+      { rules: ['secrets'], files: ['secrets/*.yaml'] },
+      { rules: ['off'], enabled: false },
+    ], files);
+
+    expect(result.warnings.map(warning => `${warning.code} ${warning.message}`)).toEqual(['secrets secrets/db.yaml']);
+  });
+
+  it('should let a pack run on its own candidate files and await async packs', async () => {
+    const workflows = reporting('workflows', { files: [file('.github/workflows/ci.yml')] });
+    const run = workflows.run;
+    const { result } = await runRulePacks(passed, [{ ...workflows, run: async (current, scoped) => run(current, scoped) }], [], files);
+
+    expect(result.warnings.map(warning => warning.message)).toEqual(['.github/workflows/ci.yml']);
+  });
+
+  it('should time each pack by its id', async () => {
+    const { timings } = await runRulePacks(passed, [reporting('first'), reporting('second')], [], files);
+
+    expect(timings.map(timing => timing.id)).toEqual(['first', 'second']);
+    timings.forEach(timing => expect(timing.evaluationMs).toBeGreaterThanOrEqual(0));
+  });
+
+  it('should skip disabled packs without timing them', async () => {
+    const { result, timings } = await runRulePacks(passed, [
+      reporting('configured'),
+      reporting('unconfigured', { enabled: false }),
+      reporting('off'),
+    ], [{ rules: ['off'], enabled: false }], files);
+
+    expect(timings.map(timing => timing.id)).toEqual(['configured']);
+    expect(new Set(result.warnings.map(warning => warning.code))).toEqual(new Set(['configured']));
+  });
+
+  it('should return the comparison result when no pack runs', async () => {
+    expect(await runRulePacks(passed, [], [], files)).toEqual({ result: passed, timings: [] });
+  });
+
+  describe('selectRulePacks', () => {
+    const packs = [reporting('kubernetes'), reporting('key-order', { enabled: false }), reporting('budgets')];
+    const enabled = (selected: RulePack[]) => selected.filter(pack => pack.enabled !== false).map(pack => pack.id);
+
+    it('should keep only the packs named by id', () => {
+      expect(enabled(selectRulePacks(packs, ['budgets', './rules/team.yaml']))).toEqual(['budgets']);
+    });
+
+    it('should keep every pack without a selection among them', () => {
+      expect(selectRulePacks(packs)).toBe(packs);
+      expect(selectRulePacks(packs, ['@praetorian/core/all', 'budgets'])).toBe(packs);
+      expect(selectRulePacks(packs, ['./rules/team.yaml'])).toBe(packs);
+    });
+
+    it('should not enable a pack that has nothing to check', () => {
+      expect(enabled(selectRulePacks(packs, ['key-order', 'kubernetes']))).toEqual(['kubernetes']);
+    });
+  });
+});
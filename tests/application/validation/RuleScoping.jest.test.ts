@@ -0,0 +1,64 @@
+import {
+  isInScope,
+  isRuleEnabled,
+  scopeFileMap,
+  scopeFiles
+} from '../../../src/application/validation/RuleScoping';
+import { validate } from '../../../src/application/validation/ValidationEngine';
+import { RuleScope } from '../../../src/shared/types';
+
+describe('RuleScoping', () => {
+  const scopes: RuleScope[] = [
+    { rules: ['secrets'], files: ['*.env', 'secrets/*.yaml'] },
+    { rules: ['schema'], groups: ['app-config'], exclude: ['legacy/*.yaml'] },
+    { rules: ['performance'], enabled: false }
+  ];
+
+  it('should run unscoped rules everywhere', () => {
+    expect(isInScope(scopes, 'equality-rule', { file: 'app.yaml' })).toBe(true);
+    expect(isInScope([], 'secrets', {})).toBe(true);
+  });
+
+  it('should restrict rules to matching files or groups', () => {
+    expect(isInScope(scopes, 'secrets', { file: 'config/.env' })).toBe(true);
+    expect(isInScope(scopes, 'secrets', { file: 'secrets/db.yaml' })).toBe(true);
+    expect(isInScope(scopes, 'secrets', { file: 'app.yaml' })).toBe(false);
+    expect(isInScope(scopes, 'schema', { file: 'app.yaml', group: 'app-config' })).toBe(true);
+    expect(isInScope(scopes, 'schema', { file: 'app.yaml', group: 'service-b' })).toBe(false);
+  });
+
+  it('should let exclude and enabled: false win', () => {
+    expect(isInScope(scopes, 'schema', { file: 'legacy/app.yaml', group: 'app-config' })).toBe(false);
+    expect(isRuleEnabled(scopes, 'performance')).toBe(false);
+    expect(isInScope(scopes, 'performance', { file: 'app.yaml' })).toBe(false);
+  });
+
+  it('should filter files and file maps', () => {
+    const files = [{ path: 'app.yaml' }, { path: '.env' }];
+
+    expect(scopeFiles(scopes, 'secrets', files)).toEqual([{ path: '.env' }]);
+    expect(scopeFiles(scopes, 'schema', files, 'app-config')).toEqual(files);
+    expect(scopeFileMap(scopes, 'secrets', { 'app.yaml': {}, '.env': { TOKEN: 'x' } })).toEqual({ '.env': { TOKEN: 'x' } });
+  });
+
+  it('should restrict rules to files carrying the labels of a scope', () => {
+    const labelled = [{ rules: ['budgets'], labels: { tier: 'critical' } }];
+    const files = [{ path: 'payments.yaml', labels: { tier: 'critical', service: 'payments' } }, { path: 'blog.yaml' }];
+
+    expect(scopeFiles(labelled, 'budgets', files)).toEqual([files[0]]);
+    expect(scopeFileMap(labelled, 'budgets', { 'payments.yaml': {}, 'blog.yaml': {} }, { 'payments.yaml': { tier: 'critical' } }))
+      .toEqual({ 'payments.yaml': {} });
+  });
+
+  it('should only apply rules in scope of the validated file', () => {
+    const rules: any[] = [
+      { id: 'secrets', type: 'structure', severity: 'error', enabled: true, requiredProperties: ['token'] },
+      { id: 'other', type: 'structure', severity: 'error', enabled: true, requiredProperties: [] }
+    ];
+
+    const output = validate({ data: {}, rules, scopes, context: { filePath: 'app.yaml' } });
+
+    expect(output.valid).toBe(true);
+    expect(output.metadata.rulesApplied).toBe(1);
+  });
+});
@@ -0,0 +1,92 @@
+import {
+  checkSecurityPolicies,
+  parseTlsVersion,
+  withSecurityPolicyFindings
+} from '../../../src/application/validation/SecurityPolicyRules';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+describe('SecurityPolicyRules', () => {
+  it('should read TLS versions as the common configs write them', () => {
+    expect(['TLSv1.1', 'TLS1_0', 'VersionTLS13', 'SSLv3', 'TLSv1.2_2021', 'TLSv1_2016', 'TLS'].map(parseTlsVersion))
+      .toEqual([1.1, 1, 1.3, 0, 1.2, 1, undefined]);
+  });
+
+  it('should report CORS blocks open to any origin with credentials in production only', () => {
+    const findings = checkSecurityPolicies([
+      file('config-prod.yaml', { server: { cors: { origin: '*', credentials: true } } }),
+      file('gateway-prod.json', { headers: { 'Access-Control-Allow-Origin': '*', 'Access-Control-Allow-Credentials': 'true' } }),
+      file('api.yaml', { cors: { origin: true, credentials: true } }, 'production'),
+      file('site-prod.yaml', { cors: { origins: ['https://app.example.com'], credentials: true } }),
+      file('config-dev.yaml', { cors: { origin: '*', credentials: true } })
+    ]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['CORS_WILDCARD_CREDENTIALS', 'error', 'server.cors.origin'],
+      ['CORS_WILDCARD_CREDENTIALS', 'error', 'headers.Access-Control-Allow-Origin'],
+      ['CORS_WILDCARD_CREDENTIALS', 'error', 'cors.origin']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ block: 'server.cors' });
+  });
+
+  it('should report TLS versions older than 1.2 and weak ciphers', () => {
+    const findings = checkSecurityPolicies([file('edge.yaml', {
+      http: { ssl_protocols: 'TLSv1 TLSv1.1 TLSv1.2', ssl_ciphers: 'ECDHE-RSA-AES128-GCM-SHA256:DES-CBC3-SHA:!RC4:!aNULL' },
+      server: { tls: { minVersion: 'VersionTLS12', cipherSuites: ['TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256', 'TLS_RSA_WITH_RC4_128_SHA'] } },
+      distribution: { https: { minimumProtocolVersion: 'TLSv1_2016' } },
+      app: { minVersion: '1.0' }
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['TLS_VERSION_WEAK', 'http.ssl_protocols'],
+      ['TLS_VERSION_WEAK', 'distribution.https.minimumProtocolVersion'],
+      ['TLS_CIPHER_WEAK', 'http.ssl_ciphers'],
+      ['TLS_CIPHER_WEAK', 'server.tls.cipherSuites']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ versions: 'TLSv1, TLSv1.1', minimum: 'TLS 1.2' });
+    expect(findings[2].context?.extras).toEqual({ ciphers: 'DES-CBC3-SHA' });
+    expect(findings[3].message).toContain('TLS_RSA_WITH_RC4_128_SHA');
+  });
+
+  it('should warn about disabled and weak security headers', () => {
+    const findings = checkSecurityPolicies([file('security.json', {
+      helmet: {
+        frameguard: false,
+        hsts: { maxAge: 86400 },
+        contentSecurityPolicy: { directives: { scriptSrc: ["'self'", "'unsafe-inline'"] } },
+        noSniff: true
+      },
+      headers: {
+        'X-Frame-Options': 'ALLOW-FROM https://partner.example.com',
+        'Strict-Transport-Security': 'max-age=63072000; includeSubDomains',
+        'X-Content-Type-Options': 'nosniff'
+      },
+      spring: { security: { headers: { 'frame-options': { disable: true } } } }
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.severity, finding.path])).toEqual([
+      ['SECURITY_HEADER_DISABLED', 'warning', 'helmet.frameguard'],
+      ['SECURITY_HEADER_WEAK', 'warning', 'helmet.hsts'],
+      ['SECURITY_HEADER_WEAK', 'warning', 'helmet.contentSecurityPolicy'],
+      ['SECURITY_HEADER_WEAK', 'warning', 'headers.X-Frame-Options'],
+      ['SECURITY_HEADER_DISABLED', 'warning', 'spring.security.headers.frame-options']
+    ]);
+    expect(findings[1].context?.extras).toEqual({ header: 'Strict-Transport-Security', reason: 'max-age 86400 is shorter than 31536000 seconds' });
+    expect(findings[2].message).toContain("'unsafe-inline'");
+  });
+
+  it('should fail a passing result when TLS accepts old versions', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withSecurityPolicyFindings(result, [file('config.yaml', { ssl: { protocols: ['SSLv3', 'TLSv1.2'] } })]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['TLS_VERSION_WEAK']);
+    expect(withSecurityPolicyFindings(result, [file('config.yaml', { tls: { minVersion: 'TLSv1.3' } })])).toBe(result);
+  });
+});
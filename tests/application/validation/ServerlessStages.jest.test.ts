@@ -0,0 +1,94 @@
+import {
+  checkServerless,
+  isServerlessConfig,
+  resolveStage,
+  serverlessStages,
+  withServerlessFindings
+} from '../../../src/application/validation/ServerlessStages';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const config = {
+  service: 'api',
+  provider: {
+    name: 'aws',
+    stage: "${opt:stage, 'dev'}",
+    environment: {
+      DB_HOST: '${self:custom.stages.${sls:stage}.dbHost}',
+      CACHE_TTL: '${self:custom.stages.${sls:stage}.cacheTtl}',
+      API_KEY: '${env:API_KEY}',
+    },
+  },
+  custom: {
+    stages: {
+      dev: { dbHost: 'dev-db', cacheTtl: 30 },
+      prod: { dbHost: 'prod-db' },
+    },
+  },
+};
+
+const file: ConfigFile = { path: 'serverless.yml', format: 'yaml', content: config };
+
+describe('ServerlessStages', () => {
+  it('should recognize Serverless Framework configurations', () => {
+    expect(isServerlessConfig(config)).toBe(true);
+    expect(isServerlessConfig({ service: 'api', provider: 'aws' })).toBe(false);
+    expect(isServerlessConfig({ database: { host: 'db' } })).toBe(false);
+  });
+
+  it('should find stages in params, custom.stages, the default stage and stage-indexed mappings', () => {
+    expect(serverlessStages(config)).toEqual(['dev', 'prod']);
+    expect(serverlessStages({
+      service: 'api',
+      provider: { name: 'aws' },
+      params: { default: {}, staging: {} },
+      custom: { domain: '${self:custom.domains.${sls:stage}}', domains: { qa: 'qa.example.com' } },
+    })).toEqual(['staging', 'dev', 'qa']);
+  });
+
+  it('should resolve the key set of a stage', () => {
+    const view = resolveStage(config, 'prod');
+
+    expect(view.keys.has('provider.environment.DB_HOST')).toBe(true);
+    expect(view.keys.has('custom.stages.dbHost')).toBe(true);
+    expect(view.keys.has('custom.stages.cacheTtl')).toBe(false);
+    expect(view.unresolved).toEqual([
+      { keyPath: 'provider.environment.CACHE_TTL', variable: 'self:custom.stages.prod.cacheTtl' },
+    ]);
+  });
+
+  it('should report unresolved variables and keys missing in a stage', () => {
+    const findings = checkServerless([file]);
+
+    expect(findings.map(finding => [finding.code, finding.path, finding.context?.extras?.stage])).toEqual([
+      ['SLS_STAGE_UNRESOLVED', 'provider.environment.CACHE_TTL', 'prod'],
+      ['SLS_STAGE_KEY_MISSING', 'custom.stages.cacheTtl', 'prod'],
+    ]);
+    expect(findings[1].context).toMatchObject({ environment: 'prod', rule: { id: 'serverless' }, extras: { presentIn: 'dev' } });
+  });
+
+  it('should only report the topmost missing key', () => {
+    const nested = {
+      ...config,
+      provider: { name: 'aws' },
+      custom: { stages: { dev: { db: { host: 'a', port: 1 } }, prod: {} } },
+      functions: { worker: { environment: { DB: '${self:custom.stages.${sls:stage}}' } } },
+    };
+
+    expect(checkServerless([{ ...file, content: nested }]).map(finding => finding.path)).toEqual([
+      'functions.worker.environment.DB.db',
+      'custom.stages.db',
+    ]);
+  });
+
+  it('should leave results untouched for other files and consistent stages', () => {
+    const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+    const consistent = {
+      ...config,
+      provider: { name: 'aws', environment: { DB_HOST: '${self:custom.stages.${sls:stage}.dbHost}' } },
+      custom: { stages: { dev: { dbHost: 'dev-db' }, prod: { dbHost: 'prod-db' } } },
+    };
+
+    expect(withServerlessFindings(passing, [{ path: 'app.yaml', format: 'yaml', content: { port: 1 } }])).toBe(passing);
+    expect(withServerlessFindings(passing, [{ ...file, content: consistent }])).toBe(passing);
+  });
+});
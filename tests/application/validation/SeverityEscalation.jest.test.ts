@@ -0,0 +1,58 @@
+import { applySeverityEscalation, findingEnvironment } from '../../../src/application/validation/SeverityEscalation';
+import { ValidationResult } from '../../../src/shared/types';
+
+describe('SeverityEscalation', () => {
+  const result: ValidationResult = {
+    success: true,
+    errors: [],
+    warnings: [
+      { code: 'MAX_DEPTH_EXCEEDED', message: 'deep', severity: 'warning', context: { file: 'config-prod.yaml' } },
+      { code: 'MAX_DEPTH_EXCEEDED', message: 'deep', severity: 'warning', context: { file: 'config-dev.yaml' } }
+    ]
+  };
+  const environmentFiles = { 'config-dev.yaml': 'dev', './config-prod.yaml': 'prod' };
+
+  it('should attribute findings to the environment of their file', () => {
+    expect(findingEnvironment(result.warnings[0], { environmentFiles })).toBe('prod');
+    expect(findingEnvironment({ code: 'X', message: '', severity: 'error' }, { environment: 'staging' })).toBe('staging');
+  });
+
+  it('should escalate matching findings and fail the result', () => {
+    const escalated = applySeverityEscalation(
+      result,
+      [{ environment: 'prod', from: 'warning', to: 'error' }],
+      { environmentFiles }
+    );
+
+    expect(escalated.success).toBe(false);
+    expect(escalated.errors).toHaveLength(1);
+    expect(escalated.errors[0].context).toEqual(
+      expect.objectContaining({ file: 'config-prod.yaml', escalatedFrom: 'warning', environment: 'prod' })
+    );
+    expect(escalated.warnings).toHaveLength(1);
+  });
+
+  it('should relax findings and restrict rules to codes', () => {
+    const failing: ValidationResult = {
+      success: false,
+      errors: [
+        { code: 'MISSING_KEY', message: 'missing', severity: 'error', context: { file: 'config-dev.yaml' } },
+        { code: 'PARSE_ERROR', message: 'broken', severity: 'error', context: { file: 'config-dev.yaml' } }
+      ],
+      warnings: []
+    };
+
+    const relaxed = applySeverityEscalation(
+      failing,
+      [{ environment: 'dev', from: 'error', to: 'warning', codes: ['MISSING_KEY'] }],
+      { environmentFiles }
+    );
+
+    expect(relaxed.errors.map(error => error.code)).toEqual(['PARSE_ERROR']);
+    expect(relaxed.warnings.map(warning => warning.code)).toEqual(['MISSING_KEY']);
+  });
+
+  it('should return the result untouched without rules', () => {
+    expect(applySeverityEscalation(result, [])).toBe(result);
+  });
+});
@@ -0,0 +1,67 @@
+import {
+  checkTlsSettings,
+  tlsSettingOf,
+  withTlsSettingFindings
+} from '../../../src/application/validation/TlsSettingChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile => ({
+  path,
+  format: 'yaml',
+  content,
+  ...(environment ? { environment } : {})
+});
+
+describe('TlsSettingChecks', () => {
+  it('should match settings by the last segments of a key path', () => {
+    expect(['database.ssl.mode', 'kafka.ssl.endpoint.identification.algorithm', 'redis.tls', 'mongo.tls.insecure', 'email.verify']
+      .map(keyPath => tlsSettingOf(keyPath)?.key))
+      .toEqual(['ssl.mode', 'ssl.endpoint.identification.algorithm', 'tls', 'tls.insecure', undefined]);
+  });
+
+  it('should report insecure TLS settings of production files only', () => {
+    const content = {
+      database: { url: 'postgres://db:5432/app?sslmode=disable&connect_timeout=5', ssl: { mode: 'verify-full' } },
+      kafka: { 'security.protocol': 'SASL_PLAINTEXT', 'ssl.endpoint.identification.algorithm': null },
+      redis: { tls: { enabled: 'false' } },
+      http: { insecureSkipVerify: true, verify: false },
+      elastic: { verify_certs: '/etc/ca.pem' },
+      env: { NODE_TLS_REJECT_UNAUTHORIZED: '0' }
+    };
+    const findings = checkTlsSettings([file('app-prod.yaml', content), file('app-dev.yaml', content)]);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['TLS_ENCRYPTION_DISABLED', 'database.url'],
+      ['TLS_ENCRYPTION_DISABLED', 'kafka.security.protocol'],
+      ['TLS_VERIFICATION_DISABLED', 'kafka.ssl.endpoint.identification.algorithm'],
+      ['TLS_ENCRYPTION_DISABLED', 'redis.tls.enabled'],
+      ['TLS_VERIFICATION_DISABLED', 'http.insecureSkipVerify'],
+      ['TLS_VERIFICATION_DISABLED', 'env.NODE_TLS_REJECT_UNAUTHORIZED']
+    ]);
+    expect(findings[0].context?.extras).toEqual({ setting: 'sslmode', value: 'disable', expected: 'require, verify-ca or verify-full' });
+    expect(findings[0].context?.environment).toBe('prod');
+  });
+
+  it('should leave Terraform provider blocks to the hcl-policies rule', () => {
+    const hcl: ConfigFile = {
+      path: 'main.tf',
+      format: 'hcl',
+      environment: 'production',
+      content: {
+        provider: { vault: { skip_tls_verify: true } },
+        consul: { tls: { insecure_skip_verify: true } }
+      }
+    };
+
+    expect(checkTlsSettings([hcl]).map(finding => finding.path)).toEqual(['consul.tls.insecure_skip_verify']);
+  });
+
+  it('should fail a passing result when production turns TLS off', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+    const updated = withTlsSettingFindings(result, [file('config.yaml', { jdbc: 'jdbc:mysql://db/app?useSSL=false' }, 'production')]);
+
+    expect(updated.success).toBe(false);
+    expect(updated.errors.map(error => error.code)).toEqual(['TLS_ENCRYPTION_DISABLED']);
+    expect(withTlsSettingFindings(result, [file('config.yaml', { redis: { tls: true } }, 'production')])).toBe(result);
+  });
+});
@@ -0,0 +1,92 @@
+import {
+  findKeyDifferences,
+  keyDifferenceFinding,
+  withValueDifferences,
+} from '../../../src/application/validation/ValueDifferences';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>, environment?: string): ConfigFile =>
+  ({ path, format: 'yaml', content, ...(environment !== undefined ? { environment } : {}) });
+
+const staging = file('staging.yaml', { api: { timeout: '30s', url: 'https://staging.internal' }, replicas: 2 }, 'staging');
+const prod = file('prod.yaml', { api: { timeout: '60s', url: 'https://prod.internal' }, replicas: 2 }, 'prod');
+
+describe('ValueDifferences', () => {
+  it('should report the owned keys whose values differ', () => {
+    const differences = findKeyDifferences([staging, prod], [{ keys: ['api.timeout', 'replicas'], severity: 'error' }]);
+
+    expect(differences).toEqual([{
+      keyPath: 'api.timeout',
+      severity: 'error',
+      values: [
+        { file: 'staging.yaml', environment: 'staging', value: '30s' },
+        { file: 'prod.yaml', environment: 'prod', value: '60s' },
+      ],
+    }]);
+  });
+
+  it('should cover the keys below a pattern and give them the severity of the first rule owning them', () => {
+    const differences = findKeyDifferences([staging, prod], [
+      { keys: ['api.url'], severity: 'info' },
+      { keys: ['api'], severity: 'warning' },
+    ]);
+
+    expect(differences.map(difference => `${difference.keyPath} ${difference.severity}`)).toEqual([
+      'api.timeout warning',
+      'api.url info',
+    ]);
+  });
+
+  it('should compare durations, sizes and numbers by amount', () => {
+    const differences = findKeyDifferences([
+      file('a.yaml', { timeout: '30s', memory: '1GB', port: 80 }),
+      file('b.yaml', { timeout: 30000, memory: '1024MB', port: 80.0 }),
+    ], [{ keys: ['*'], severity: 'warning' }]);
+
+    expect(differences).toEqual([]);
+  });
+
+  it('should compare strings without a unit as text', () => {
+    const differences = findKeyDifferences([
+      file('a.yaml', { version: '1.10', tag: '007', port: 80 }),
+      file('b.yaml', { version: '1.1', tag: '7', port: '80' }),
+    ], [{ keys: ['*'], severity: 'warning' }]);
+
+    expect(differences.map(difference => difference.keyPath)).toEqual(['version', 'tag', 'port']);
+  });
+
+  it('should skip keys set in a single file', () => {
+    expect(findKeyDifferences([
+      file('a.yaml', { debug: true }),
+      file('b.yaml', {}),
+    ], [{ keys: ['*'], severity: 'warning' }])).toEqual([]);
+  });
+
+  it('should keep the values out of the message', () => {
+    const finding = keyDifferenceFinding(findKeyDifferences([staging, prod], [{ keys: ['api.url'], severity: 'warning' }])[0]);
+
+    expect(finding).toMatchObject({
+      code: 'KEY_DIFFERENCE',
+      message: "'api.url' has 2 different values across staging.yaml, prod.yaml",
+      severity: 'warning',
+      path: 'api.url',
+      context: {
+        files: ['staging.yaml', 'prod.yaml'],
+        observedValue: { 'staging.yaml': 'https://staging.internal', 'prod.yaml': 'https://prod.internal' },
+        rule: { id: 'value-differences' },
+        extras: { distinct: 2 },
+      },
+    });
+  });
+
+  it('should only add findings when configured', () => {
+    const result: ValidationResult = { success: true, errors: [], warnings: [] };
+
+    expect(withValueDifferences(result, [staging, prod], [])).toBe(result);
+    expect(withValueDifferences(result, [staging, prod], [{ keys: ['replicas'], severity: 'error' }])).toBe(result);
+
+    const differing = withValueDifferences(result, [staging, prod], [{ keys: ['api.timeout'], severity: 'error' }]);
+    expect(differing.success).toBe(false);
+    expect(differing.errors.map(error => error.code)).toEqual(['KEY_DIFFERENCE']);
+  });
+});
@@ -0,0 +1,57 @@
+import { checkValueRules, withValueRules } from '../../../src/application/validation/ValueRules';
+import { ConfigFile, KeyLocation, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, format: 'yaml', content });
+
+const passed: ValidationResult = { success: true, errors: [], warnings: [] };
+
+describe('ValueRules', () => {
+  it('should report the values breaking their constraints', () => {
+    const findings = checkValueRules([file('prod.yaml', {
+      database: { port: 80, host: 'db' },
+      log: { level: 'verbose' },
+      api: { url: 'http://api', timeout: '90s' },
+      auth: { url: 'https://auth' },
+    })], {
+      'database.port': { type: 'port', min: 1024 },
+      'log.level': { enum: ['debug', 'info', 'warn', 'error'] },
+      '*.url': { regex: '^https://' },
+      'api.timeout': { max: '60s', severity: 'warning' },
+    });
+
+    expect(findings.map(finding => `${finding.code} ${finding.path} ${finding.severity}`)).toEqual([
+      'RULE_VALUE_TOO_LOW database.port error',
+      'RULE_VALUE_NOT_ALLOWED log.level error',
+      'RULE_PATTERN_MISMATCH api.url error',
+      'RULE_VALUE_TOO_HIGH api.timeout warning',
+    ]);
+    expect(findings[0].context?.extras).toMatchObject({ value: '80', bound: 1024, pattern: 'database.port', location: 'prod.yaml' });
+  });
+
+  it('should report only the type when a value has the wrong one', () => {
+    const findings = checkValueRules([file('a.yaml', { port: 'eighty' })], { port: { type: 'port', regex: '^\\d+$', min: 1 } });
+
+    expect(findings.map(finding => finding.code)).toEqual(['RULE_TYPE_MISMATCH']);
+    expect(findings[0].message).toBe("Value of 'port' is not a valid port (a.yaml)");
+  });
+
+  it('should leave absent keys and values within their constraints alone', () => {
+    expect(checkValueRules([file('a.yaml', { port: 8080 })], { port: { min: 1024, max: 65535 }, host: { regex: '^db' } })).toEqual([]);
+  });
+
+  it('should point at the line of the key when it is located', () => {
+    const locate = () => new Map<string, KeyLocation>([['log.level', { line: 3, column: 3 }]]);
+    const [finding] = checkValueRules([file('a.yaml', { log: { level: 'loud' } })], { 'log.level': { enum: ['info'] } }, locate);
+
+    expect(finding.message).toBe("Value of 'log.level' is not one of info (a.yaml:3)");
+    expect(finding.context).toMatchObject({ line: 3, column: 3, observedValue: 'loud', expectedValue: ['info'] });
+  });
+
+  it('should fail the result only when a value breaks a constraint', () => {
+    const files = [file('a.yaml', { port: 80 })];
+
+    expect(withValueRules(passed, files, {})).toBe(passed);
+    expect(withValueRules(passed, files, { port: { max: 100 } })).toBe(passed);
+    expect(withValueRules(passed, files, { port: { min: 1024 } }).success).toBe(false);
+  });
+});
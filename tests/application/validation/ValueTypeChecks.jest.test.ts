@@ -0,0 +1,40 @@
+import { checkValueTypes, withValueTypes } from '../../../src/application/validation/ValueTypeChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, format: 'yaml', content });
+
+const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+
+describe('ValueTypeChecks', () => {
+  const files = [
+    file('dev.yaml', { network: { cidr: '10.0.0.0/16' }, services: { api: { port: 8080 }, db: { port: 70000 } } }),
+    file('prod.yaml', { network: { cidr: '10.0.0.0/40' }, services: { api: { port: '443' } } })
+  ];
+
+  it('should report values of the wrong type, including wildcard keys', () => {
+    const findings = checkValueTypes({ 'network.cidr': 'cidr', 'services.*.port': 'port' }, files);
+
+    expect(findings.map(({ code, path, context }) => [code, context?.file, path])).toEqual([
+      ['INVALID_VALUE_TYPE', 'dev.yaml', 'services.db.port'],
+      ['INVALID_VALUE_TYPE', 'prod.yaml', 'network.cidr']
+    ]);
+    expect(findings[1].context).toMatchObject({
+      observedValue: '10.0.0.0/40',
+      rule: { id: 'value-types' },
+      extras: { type: 'cidr' }
+    });
+  });
+
+  it('should leave absent keys to required_keys', () => {
+    expect(checkValueTypes({ 'network.gateway': 'ipv4' }, files)).toEqual([]);
+  });
+
+  it('should fail the result only when a value has the wrong type', () => {
+    expect(withValueTypes(passing, {}, files)).toBe(passing);
+    expect(withValueTypes(passing, { 'services.api.port': 'port' }, files)).toBe(passing);
+
+    const result = withValueTypes(passing, { 'network.cidr': 'cidr' }, files);
+    expect(result.success).toBe(false);
+    expect(result.errors).toHaveLength(1);
+  });
+});
@@ -0,0 +1,107 @@
+import {
+  checkWorkflows,
+  isActionPinned,
+  isWorkflow,
+  withWorkflowFindings,
+  workflowSettings
+} from '../../../src/application/validation/WorkflowChecks';
+import { ConfigFile, ValidationResult } from '../../../src/shared/types';
+
+const SHA = 'b4ffde65f46336ab88eb53be808477a3936bae11';
+
+const workflow = (content: Record<string, any>): ConfigFile => ({
+  path: '.github/workflows/ci.yml',
+  format: 'yaml',
+  content: { name: 'CI', on: { push: {} }, ...content },
+});
+
+describe('WorkflowChecks', () => {
+  it('should recognize workflows by their trigger and jobs', () => {
+    expect(isWorkflow({ on: 'push', jobs: { build: {} } })).toBe(true);
+    expect(isWorkflow({ jobs: { build: {} } })).toBe(false);
+    expect(isWorkflow({ on: 'push', jobs: [] })).toBe(false);
+  });
+
+  it('should tell pinned actions from movable references', () => {
+    expect(isActionPinned(`actions/checkout@${SHA}`)).toBe(true);
+    expect(isActionPinned('./.github/actions/build')).toBe(true);
+    expect(isActionPinned('docker://alpine@sha256:abc123')).toBe(true);
+    expect(isActionPinned('actions/checkout@v4')).toBe(false);
+    expect(isActionPinned('org/repo/.github/workflows/deploy.yml@main')).toBe(false);
+    expect(isActionPinned('docker://alpine:3.19')).toBe(false);
+  });
+
+  it('should list env, with and secrets entries with their location', () => {
+    const settings = workflowSettings(workflow({
+      env: { CI: 'true' },
+      jobs: {
+        build: {
+          container: { image: 'node:20', env: { NODE_ENV: 'test' } },
+          steps: [{ name: 'Deploy', uses: 'org/deploy@v1', with: { api_key: '${{ secrets.API_KEY }}' } }],
+        },
+        release: { uses: 'org/repo/.github/workflows/release.yml@main', secrets: { token: 'abc' } },
+      },
+    }).content);
+
+    expect(settings.map(({ keyPath, section, job, step }) => [keyPath, section, job, step])).toEqual([
+      ['env.CI', 'env', undefined, undefined],
+      ['jobs.build.container.env.NODE_ENV', 'env', 'build', undefined],
+      ['jobs.build.steps.0.with.api_key', 'with', 'build', 'Deploy'],
+      ['jobs.release.secrets.token', 'secrets', 'release', undefined],
+    ]);
+  });
+
+  it('should report plaintext secrets without putting the value in the message', () => {
+    const findings = checkWorkflows([workflow({
+      permissions: { contents: 'read' },
+      env: { DB_PASSWORD: 'hunter2', LOG_LEVEL: 'debug', API_TOKEN: '${{ secrets.API_TOKEN }}' },
+      jobs: {
+        build: { steps: [{ run: 'make', env: { NPM_TOKEN: 'npm_abc' } }] },
+        release: { uses: `org/repo/.github/workflows/release.yml@${SHA}`, secrets: 'inherit' },
+      },
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.path])).toEqual([
+      ['WORKFLOW_PLAINTEXT_SECRET', 'env.DB_PASSWORD'],
+      ['WORKFLOW_PLAINTEXT_SECRET', 'jobs.build.steps.0.env.NPM_TOKEN'],
+    ]);
+    expect(findings[0].message).not.toContain('hunter2');
+    expect(findings[0].context).toMatchObject({ observedValue: 'hunter2', rule: { id: 'workflows' } });
+    expect(findings[1].context?.extras).toEqual({ variable: 'NPM_TOKEN', section: 'env', job: 'build', step: 'step 1' });
+  });
+
+  it('should require a permissions block on the workflow or on every job', () => {
+    const jobs = {
+      build: { steps: [] },
+      deploy: { permissions: { 'id-token': 'write' }, steps: [] },
+    };
+
+    expect(checkWorkflows([workflow({ jobs })]).map(finding => [finding.code, finding.path])).toEqual([
+      ['WORKFLOW_PERMISSIONS_MISSING', 'jobs.build.permissions'],
+    ]);
+    expect(checkWorkflows([workflow({ permissions: 'read-all', jobs })])).toEqual([]);
+  });
+
+  it('should report actions and reusable workflows not pinned to a commit SHA', () => {
+    const findings = checkWorkflows([workflow({
+      permissions: {},
+      jobs: {
+        build: { steps: [{ uses: 'actions/checkout@v4' }, { uses: `actions/setup-node@${SHA}` }, { uses: './local' }] },
+        deploy: { uses: 'org/repo/.github/workflows/deploy.yml@main' },
+      },
+    })]);
+
+    expect(findings.map(finding => [finding.code, finding.path, finding.context?.extras?.uses])).toEqual([
+      ['WORKFLOW_ACTION_NOT_PINNED', 'jobs.build.steps.0.uses', 'actions/checkout@v4'],
+      ['WORKFLOW_ACTION_NOT_PINNED', 'jobs.deploy.uses', 'org/repo/.github/workflows/deploy.yml@main'],
+    ]);
+  });
+
+  it('should leave results untouched when no workflow breaks a rule', () => {
+    const passing: ValidationResult = { success: true, errors: [], warnings: [] };
+    const config: ConfigFile = { path: 'config.yaml', format: 'yaml', content: { password: 'x' } };
+
+    expect(withWorkflowFindings(passing, [config])).toBe(passing);
+    expect(withWorkflowFindings(passing, [workflow({ jobs: { build: {} } })]).success).toBe(false);
+  });
+});
@@ -146,6 +146,18 @@ describe('RangeValidator', () => {
       
       expect(errors).toHaveLength(0);
     });
+
+    it('should compare durations and sizes in base units', () => {
+      const timeout: JsonSchema = { type: 'string', minimum: '1s', maximum: 300000 };
+      const memory: JsonSchema = { type: 'string', maximum: '1GB' };
+
+      expect(RangeValidator.validateNumberRange('30s', timeout, 'path')).toHaveLength(0);
+      expect(RangeValidator.validateNumberRange('10m', timeout, 'path')[0].code).toBe('MAXIMUM_ERROR');
+      expect(RangeValidator.validateNumberRange(500, timeout, 'path')[0]).toMatchObject({ code: 'MINIMUM_ERROR', expected: '1s' });
+      expect(RangeValidator.validateNumberRange('512Mi', memory, 'path')).toHaveLength(0);
+      expect(RangeValidator.validateNumberRange('2GB', memory, 'path')).toHaveLength(1);
+      expect(RangeValidator.validateNumberRange('fast', timeout, 'path')).toHaveLength(0);
+    });
   });
 
   describe('isNumber', () => {
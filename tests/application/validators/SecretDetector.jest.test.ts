@@ -118,6 +118,15 @@ describe('SecretDetector', () => {
       
       expect(result).toEqual([]);
     });
+
+    it('should keep the secret out of the snippet under a redaction policy', () => {
+      const content = 'api_key=sk-test12345678901234567890';
+
+      const [result] = detectSecrets(content, [mockRule], { ...mockContext, redaction: { policy: 'mask-all' } });
+
+      expect(result.maskedValue).toBe('***');
+      expect(result.context).toBe('api_key=***');
+    });
   });
 
   describe('looksLikeSecret', () => {
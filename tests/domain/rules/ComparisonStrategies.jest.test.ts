@@ -0,0 +1,84 @@
+import {
+  COMPARISON_STRATEGIES,
+  getComparisonStrategy,
+  isComparisonStrategy,
+  kindsDiffer,
+  quantityAwareKind,
+  valueKind
+} from '../../../src/domain/rules/ComparisonStrategies';
+import { EqualityRule } from '../../../src/domain/rules/EqualityRule';
+import { ConfigFile } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, content, format: 'yaml' });
+
+describe('ComparisonStrategies', () => {
+  const base = file('base.yaml', { db: { host: 'a', port: 5432 }, debug: true });
+  const prod = file('prod.yaml', { db: { host: 'b', port: '5432' }, replicas: 3 });
+  const isIgnored = (key: string) => key === 'debug';
+
+  const codesAndPaths = (strategy: string, reference?: string) =>
+    getComparisonStrategy(strategy as any)
+      .compare({ files: [base, prod], isIgnored, reference })
+      .errors.map(error => `${error.code} ${error.path ?? ''} ${error.context?.file ?? ''}`.trim());
+
+  it('should expose every strategy by name', () => {
+    expect(COMPARISON_STRATEGIES.every(isComparisonStrategy)).toBe(true);
+    expect(isComparisonStrategy('toString')).toBe(false);
+    expect(getComparisonStrategy().name).toBe('strict');
+  });
+
+  it('strict should require every key found anywhere in every file', () => {
+    expect(codesAndPaths('strict')).toEqual(['MISSING_KEY replicas base.yaml']);
+  });
+
+  it('subset-of-reference should allow extra keys outside the reference', () => {
+    expect(codesAndPaths('subset-of-reference')).toEqual([]);
+    expect(codesAndPaths('subset-of-reference', './prod.yaml')).toEqual(['MISSING_KEY replicas base.yaml']);
+  });
+
+  it('symmetric should report both missing and extra keys against the reference', () => {
+    expect(codesAndPaths('symmetric')).toEqual(['EXTRA_KEY replicas prod.yaml']);
+  });
+
+  it('should report a reference that is not among the compared files', () => {
+    expect(codesAndPaths('symmetric', 'staging.yaml')).toEqual(['REFERENCE_NOT_FOUND']);
+  });
+
+  it('value-aware should warn when a key holds different value types', () => {
+    const { warnings } = getComparisonStrategy('value-aware').compare({ files: [base, prod], isIgnored });
+
+    expect(warnings.map(warning => warning.path)).toEqual(['db.port']);
+    expect(warnings[0].message).toBe("Key 'db.port' has different value types across files: number in base.yaml, string in prod.yaml");
+  });
+
+  it('value-aware should read durations and sizes as quantities', () => {
+    const dev = file('dev.yaml', { timeout: '30s', cache: '10MB', retry: '5s' });
+    const stage = file('stage.yaml', { timeout: 30000, cache: 10485760, retry: '1GB' });
+    const { warnings } = getComparisonStrategy('value-aware').compare({ files: [dev, stage], isIgnored });
+
+    expect(warnings.map(warning => warning.path)).toEqual(['retry']);
+    expect(warnings[0].message).toContain('duration in dev.yaml, size in stage.yaml');
+  });
+
+  it('should describe quantity-aware value kinds', () => {
+    expect([quantityAwareKind('1h30m'), quantityAwareKind('512Mi'), quantityAwareKind('fast')])
+      .toEqual(['duration', 'size', 'string']);
+    expect(kindsDiffer(['duration', 'number'])).toBe(false);
+    expect(kindsDiffer(['duration', 'string'])).toBe(true);
+    expect(kindsDiffer(['number', 'string'])).toBe(true);
+  });
+
+  it('should describe value kinds', () => {
+    expect([valueKind([]), valueKind({}), valueKind('x'), valueKind(1), valueKind(false)])
+      .toEqual(['array', 'object', 'string', 'number', 'boolean']);
+  });
+
+  it('should be selected through the validation context', async () => {
+    const result = await new EqualityRule().execute([base, prod], {
+      ignoreKeys: ['debug'],
+      comparison: { strategy: 'subset-of-reference' }
+    });
+
+    expect(result.success).toBe(true);
+  });
+});
@@ -382,6 +382,79 @@ describe('EqualityRule', () => {
     });
   });
 
+  describe('required keys with wildcards', () => {
+    it('should require the child key in every matching parent', async () => {
+      const files: ConfigFile[] = [
+        {
+          path: 'config-dev.yaml',
+          content: { services: { api: { healthcheck: '/health' }, worker: { healthcheck: '/ready' } } },
+          format: 'yaml'
+        },
+        {
+          path: 'config-prod.yaml',
+          content: { services: { api: { healthcheck: '/health' }, worker: { healthcheck: null } } },
+          format: 'yaml'
+        }
+      ];
+
+      const result = await equalityRule.execute(files, { requiredKeys: ['services.*.healthcheck'] });
+
+      expect(result.success).toBe(true);
+    });
+
+    it('should report each parent missing the child key', async () => {
+      const files: ConfigFile[] = [
+        {
+          path: 'config-dev.yaml',
+          content: { db: { timeout: 5 }, cache: { timeout: 1 } },
+          format: 'yaml'
+        },
+        {
+          path: 'config-prod.yaml',
+          content: { db: { timeout: 5 }, cache: {} },
+          format: 'yaml'
+        }
+      ];
+
+      const result = await equalityRule.execute(files, { requiredKeys: ['*.timeout'] });
+      const requiredErrors = result.errors.filter(error => error.code === 'REQUIRED_KEY_MISSING');
+
+      expect(requiredErrors).toHaveLength(1);
+      expect(requiredErrors[0].path).toBe('cache.timeout');
+      expect(requiredErrors[0].message).toBe("Required key 'cache.timeout' is missing in config-prod.yaml");
+      expect(requiredErrors[0].context?.keyPath).toBe('cache.timeout');
+      expect(requiredErrors[0].context?.extras?.requiredKey).toBe('*.timeout');
+    });
+  });
+
+  describe('extraction limits', () => {
+    it('should truncate deep structures and emit a warning', async () => {
+      const files: ConfigFile[] = [
+        { path: 'a.json', content: { a: { b: { c: { d: 1 } } } }, format: 'json' },
+        { path: 'b.json', content: { a: { b: { c: { e: 1 } } } }, format: 'json' }
+      ];
+
+      const result = await equalityRule.execute(files, { limits: { maxDepth: 2 } });
+
+      expect(result.success).toBe(true);
+      expect(result.warnings.map(warning => warning.code)).toEqual(['MAX_DEPTH_EXCEEDED', 'MAX_DEPTH_EXCEEDED']);
+    });
+
+    it('should stop counting keys after maxKeys and emit a warning', async () => {
+      const files: ConfigFile[] = [
+        { path: 'a.json', content: { a: 1, b: 2, c: 3 }, format: 'json' },
+        { path: 'b.json', content: { a: 1, b: 2 }, format: 'json' }
+      ];
+
+      const result = await equalityRule.execute(files, { limits: { maxKeys: 2 } });
+
+      expect(result.success).toBe(true);
+      expect(result.warnings).toHaveLength(1);
+      expect(result.warnings[0].code).toBe('MAX_KEYS_EXCEEDED');
+      expect(result.warnings[0].context?.file).toBe('a.json');
+    });
+  });
+
   describe('extractAllKeys', () => {
     it('should extract keys from simple object', () => {
       const obj = { key1: 'value1', key2: 'value2' };
@@ -0,0 +1,93 @@
+import { getComparisonStrategy } from '../../../src/domain/rules/ComparisonStrategies';
+import { EqualityRule } from '../../../src/domain/rules/EqualityRule';
+import { detectRenames, renameScore } from '../../../src/domain/rules/KeyRenames';
+import { ConfigFile } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, content, format: 'yaml' });
+
+const compare = (files: ConfigFile[], strategy = 'strict', reference?: string) => {
+  const input = { files, isIgnored: () => false, reference };
+  return detectRenames(getComparisonStrategy(strategy as any).compare(input), input)
+    .errors.map(error => `${error.code} ${error.path} ${error.context?.file}`);
+};
+
+describe('KeyRenames', () => {
+  it('should score close sibling names and equal sections only', () => {
+    expect(renameScore({ key: 'db.db_host', value: 'a' }, { key: 'db.dbHost', value: 'b' })).toBeGreaterThan(0);
+    expect(renameScore({ key: 'timeout', value: 1 }, { key: 'timout', value: 2 })).toBeLessThanOrEqual(0.25);
+    expect(renameScore({ key: 'database', value: { host: 'a' } }, { key: 'storage', value: { host: 'a' } })).toBe(0);
+    expect(renameScore({ key: 'database', value: { host: 'a' } }, { key: 'storage', value: { host: 'b' } })).toBeUndefined();
+    expect(renameScore({ key: 'api.timeout', value: 1 }, { key: 'db.timeout', value: 1 })).toBeUndefined();
+    expect(renameScore({ key: 'key1', value: 1 }, { key: 'port', value: 1 })).toBeUndefined();
+    expect(renameScore({ key: 'a', value: 1 }, { key: 'b', value: 1 })).toBeUndefined();
+  });
+
+  it('should report a renamed key once instead of missing keys on both sides', () => {
+    const errors = compare([
+      file('dev.yaml', { db: { db_host: 'a' }, port: 80 }),
+      file('prod.yaml', { db: { dbHost: 'b' }, port: 80 })
+    ]);
+
+    expect(errors).toEqual(['KEY_RENAMED db.dbHost dev.yaml']);
+  });
+
+  it('should report the rename where the odd name is', () => {
+    const errors = compare([
+      file('dev.yaml', { timeout: 1 }),
+      file('staging.yaml', { timeout: 1 }),
+      file('prod.yaml', { timout: 1 })
+    ]);
+
+    expect(errors).toEqual(['KEY_RENAMED timeout prod.yaml']);
+  });
+
+  it('should cover the nested keys of a renamed section', () => {
+    const errors = compare([
+      file('dev.yaml', { database: { host: 'db', port: 5432 } }),
+      file('prod.yaml', { storage: { host: 'db', port: 5432 } })
+    ]);
+
+    expect(errors).toEqual(['KEY_RENAMED storage dev.yaml']);
+  });
+
+  it('should replace the extra key of the symmetric strategy', () => {
+    const errors = compare([
+      file('base.yaml', { replicas: 1 }),
+      file('prod.yaml', { replica: 3 })
+    ], 'symmetric');
+
+    expect(errors).toEqual(['KEY_RENAMED replicas prod.yaml']);
+  });
+
+  it('should keep unrelated missing keys', () => {
+    const errors = compare([
+      file('dev.yaml', { database: { port: 5432 }, api: {} }),
+      file('prod.yaml', { database: {}, api: { timeout: 30 } })
+    ]);
+
+    expect(errors.sort()).toEqual(['MISSING_KEY api.timeout dev.yaml', 'MISSING_KEY database.port prod.yaml']);
+  });
+
+  it('should pair each key once', () => {
+    const errors = compare([
+      file('dev.yaml', { cache_ttl: 1, cache_tll: 2 }),
+      file('prod.yaml', { cacheTtl: 1 })
+    ]);
+
+    expect(errors.filter(error => error.startsWith('KEY_RENAMED'))).toHaveLength(1);
+    expect(errors).toHaveLength(2);
+  });
+
+  it('should be applied by the equality rule', async () => {
+    const result = await new EqualityRule().execute([
+      file('dev.yaml', { logLevel: 'debug' }),
+      file('prod.yaml', { log_level: 'warn' })
+    ]);
+
+    expect(result.errors).toHaveLength(1);
+    expect(result.errors[0]).toMatchObject({
+      code: 'KEY_RENAMED',
+      context: { extras: { renamed: expect.any(String) } }
+    });
+  });
+});
@@ -0,0 +1,43 @@
+import { getComparisonStrategy } from '../../../src/domain/rules/ComparisonStrategies';
+import { EqualityRule } from '../../../src/domain/rules/EqualityRule';
+import { suggestMissingKeys } from '../../../src/domain/rules/KeySuggestions';
+import { ConfigFile } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, content, format: 'yaml' });
+
+describe('KeySuggestions', () => {
+  const dev = file('dev.yaml', { database: { host: 'db', port: 5432 } });
+  const prod = file('prod.yaml', { databse: { host: 'db' }, database: { port: 5432 } });
+
+  it('should suggest the keys of the file that look like a typo of the missing one', () => {
+    const input = { files: [dev, prod], isIgnored: () => false };
+    const errors = suggestMissingKeys(getComparisonStrategy('subset-of-reference').compare(input), input).errors;
+
+    expect(errors).toHaveLength(1);
+    expect(errors[0].message).toBe("Key 'database.host' is missing in prod.yaml (did you mean 'databse.host'?)");
+    expect(errors[0].context?.extras).toEqual({ availableKeys: ['databse', 'databse.host', 'database', 'database.port'], suggestions: ['databse.host'] });
+  });
+
+  it('should not suggest keys the other files have too', () => {
+    const input = { files: [file('a.yaml', { key1: 1, key2: 2 }), file('b.yaml', { key1: 1 })], isIgnored: () => false };
+    const [error] = suggestMissingKeys(getComparisonStrategy('strict').compare(input), input).errors;
+
+    expect(error.message).toBe("Key 'key2' is missing in b.yaml");
+    expect(error.context?.extras?.suggestions).toBeUndefined();
+  });
+
+  it('should not suggest ignored keys', () => {
+    const input = { files: [dev, prod], isIgnored: (key: string) => key.startsWith('databse') };
+    const errors = suggestMissingKeys(getComparisonStrategy('subset-of-reference').compare(input), input).errors;
+
+    expect(errors[0].context?.extras?.suggestions).toBeUndefined();
+  });
+
+  it('should be applied by the equality rule', async () => {
+    const result = await new EqualityRule().execute([dev, prod]);
+
+    expect(result.errors.map(error => error.message)).toContain(
+      "Key 'database.host' is missing in prod.yaml (did you mean 'databse.host'?)"
+    );
+  });
+});
@@ -134,7 +134,7 @@ describe('FileAdapterFactory', () => {
     it('should return all registered adapters', () => {
       const adapters = FileAdapterFactory.getAllAdapters();
       
-      expect(adapters).toHaveLength(9); // YAML, JSON, ENV, TOML, INI, XML, Properties, HCL, PLIST
+      expect(adapters).toHaveLength(10); // YAML, JSON, ENV, TOML, INI, XML, Properties, HCL, PLIST, Dockerfile
       expect(adapters.some(adapter => adapter instanceof YamlFileAdapter)).toBe(true);
       expect(adapters.some(adapter => adapter instanceof JsonFileAdapter)).toBe(true);
       expect(adapters.some(adapter => adapter instanceof EnvFileAdapter)).toBe(true);
@@ -146,4 +146,50 @@ describe('FileAdapterFactory', () => {
       expect(adapters.some(adapter => adapter instanceof PlistFileAdapterV2)).toBe(true);
     });
   });
-}); 
\ No newline at end of file
+
+  describe('getAdapterForFormat', () => {
+    it('should return the adapter for a format name', () => {
+      expect(FileAdapterFactory.getAdapterForFormat('ini')).toBeInstanceOf(IniFileAdapter);
+      expect(FileAdapterFactory.getAdapterForFormat('JSON')).toBeInstanceOf(JsonFileAdapter);
+    });
+
+    it('should resolve format aliases', () => {
+      expect(FileAdapterFactory.getAdapterForFormat('yml')).toBeInstanceOf(YamlFileAdapter);
+      expect(FileAdapterFactory.getAdapterForFormat('dotenv')).toBeInstanceOf(EnvFileAdapter);
+    });
+
+    it('should throw for unknown formats', () => {
+      expect(() => FileAdapterFactory.getAdapterForFormat('cue')).toThrow('Unsupported format: cue');
+    });
+  });
+
+  describe('getSupportedFormats', () => {
+    it('should list the format of every adapter', () => {
+      expect(FileAdapterFactory.getSupportedFormats()).toEqual(
+        expect.arrayContaining(['yaml', 'json', 'env', 'toml', 'ini', 'xml', 'properties', 'hcl', 'plist', 'dockerfile'])
+      );
+    });
+  });
+
+  describe('lazy adapters', () => {
+    it('should tell the format of a file without loading its adapter', () => {
+      expect(FileAdapterFactory.getFormatFor('main.tf')).toBe('hcl');
+      expect(FileAdapterFactory.getFormatFor('config.yml')).toBe('yaml');
+      expect(FileAdapterFactory.getFormatFor('README')).toBeUndefined();
+    });
+
+    it('should load a registered lazy adapter once, on first use', () => {
+      const load = jest.fn(() => new JsonFileAdapter());
+      FileAdapterFactory.registerAdapter({ format: 'jsonc', extensions: ['.jsonc'], load });
+
+      expect(FileAdapterFactory.isSupported('tsconfig.jsonc')).toBe(true);
+      expect(FileAdapterFactory.getSupportedFormats()).toContain('jsonc');
+      expect(load).not.toHaveBeenCalled();
+
+      const adapter = FileAdapterFactory.getAdapter('tsconfig.jsonc');
+
+      expect(FileAdapterFactory.getAdapterForFormat('jsonc')).toBe(adapter);
+      expect(load).toHaveBeenCalledTimes(1);
+    });
+  });
+});
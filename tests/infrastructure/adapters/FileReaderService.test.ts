@@ -1,4 +1,4 @@
-import { FileReaderService } from '../../../src/infrastructure/adapters/FileReaderService';
+import { FileReaderService, extractErrorLocation } from '../../../src/infrastructure/adapters/FileReaderService';
 
 // Mock fs module
 jest.mock('fs', () => ({
@@ -109,6 +109,18 @@ database:
           port: 5432
         }
       });
+      expect(result.metadata?.parseMs).toBeGreaterThanOrEqual(0);
+    });
+
+    it('should retain comments when asked to', async () => {
+      mockExistsSync.mockReturnValue(true);
+      mockReadFile.mockResolvedValue('# host: old-db\nhost: db\n');
+
+      const result = await new FileReaderService({}, { retainComments: true }).readFile('config.yaml');
+
+      expect(result.content).toEqual({ host: 'db' });
+      expect(result.comments).toEqual([{ line: 1, text: 'host: old-db' }]);
+      expect((await fileReaderService.readFile('config.yaml')).comments).toBeUndefined();
     });
 
     it('should read and parse JSON file', async () => {
@@ -171,4 +183,79 @@ database:
       );
     });
   });
-}); 
\ No newline at end of file
+
+  describe('readFilesTolerant', () => {
+    it('should keep reading after a file fails to parse', async () => {
+      mockExistsSync.mockReturnValue(true);
+      mockReadFile
+        .mockResolvedValueOnce('{ "api": ')
+        .mockResolvedValueOnce('database:\n  host: localhost\n');
+
+      const result = await fileReaderService.readFilesTolerant(['broken.json', 'config.yaml']);
+
+      expect(result.files).toHaveLength(1);
+      expect(result.files[0].path).toBe('config.yaml');
+      expect(result.failures).toHaveLength(1);
+      expect(result.failures[0].path).toBe('broken.json');
+      expect(result.failures[0].error).toContain('broken.json');
+    });
+
+    it('should record unsupported files as failures', async () => {
+      const result = await fileReaderService.readFilesTolerant(['config.txt']);
+
+      expect(result.files).toHaveLength(0);
+      expect(result.failures[0].error).toContain('Unsupported file format');
+    });
+  });
+
+  describe('extractErrorLocation', () => {
+    it('should extract line and column from parser messages', () => {
+      expect(extractErrorLocation('bad indentation of a mapping entry (3:5)')).toEqual({ line: 3, column: 5 });
+      expect(extractErrorLocation('Unexpected token (line 2 column 7)')).toEqual({ line: 2, column: 7 });
+      expect(extractErrorLocation('Invalid entry at line 4')).toEqual({ line: 4 });
+      expect(extractErrorLocation('Unexpected end of JSON input')).toEqual({});
+    });
+  });
+
+  describe('format resolution', () => {
+    it('should sniff the format of files with unknown extensions', async () => {
+      mockExistsSync.mockReturnValue(true);
+      mockReadFile
+        .mockResolvedValueOnce('{ "api": { "port": 3000 } }')
+        .mockResolvedValueOnce('{ "api": { "port": 3000 } }');
+
+      const result = await fileReaderService.readFile('settings.config');
+
+      expect(result.format).toBe('json');
+      expect(result.content).toEqual({ api: { port: 3000 } });
+    });
+
+    it('should apply configured format overrides before extension detection', async () => {
+      const service = new FileReaderService({ 'configs/*.conf': 'properties' });
+      mockExistsSync.mockReturnValue(true);
+      mockReadFile.mockResolvedValueOnce('server.port=8080\n');
+
+      const result = await service.readFile('configs/app.conf');
+
+      expect(result.format).toBe('properties');
+    });
+
+    it('should refuse formats whose parsers are not enabled', async () => {
+      const service = new FileReaderService({}, { parsers: ['yaml', 'json'] });
+      mockExistsSync.mockReturnValue(true);
+
+      await expect(service.readFile('main.tf')).rejects.toThrow('The hcl parser is not enabled: main.tf. Enabled parsers: yaml, json');
+      expect(mockReadFile).not.toHaveBeenCalled();
+    });
+
+    it('should accept enabled parsers by their aliases', async () => {
+      const service = new FileReaderService({}, { parsers: ['yml'] });
+      mockExistsSync.mockReturnValue(true);
+      mockReadFile.mockResolvedValueOnce('app: api\n');
+
+      const result = await service.readFile('config.yaml');
+
+      expect(result.content).toEqual({ app: 'api' });
+    });
+  });
+});
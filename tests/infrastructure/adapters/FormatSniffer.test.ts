@@ -0,0 +1,28 @@
+import { sniffFormat } from '../../../src/infrastructure/adapters/FormatSniffer';
+
+describe('FormatSniffer', () => {
+  it('should detect JSON objects', () => {
+    expect(sniffFormat('{ "database": { "host": "localhost" } }')).toBe('json');
+  });
+
+  it('should detect YAML mappings', () => {
+    expect(sniffFormat('database:\n  host: localhost\n')).toBe('yaml');
+  });
+
+  it('should detect INI sections', () => {
+    expect(sniffFormat('[database]\nhost = localhost\nport = 5432\n')).toBe('ini');
+  });
+
+  it('should detect env files', () => {
+    expect(sniffFormat('# comment\nDATABASE_HOST=localhost\nexport API_KEY=abc\n')).toBe('env');
+  });
+
+  it('should detect properties files', () => {
+    expect(sniffFormat('database.host=localhost\ndatabase.port=5432\n')).toBe('properties');
+  });
+
+  it('should return undefined for unknown or empty content', () => {
+    expect(sniffFormat('')).toBeUndefined();
+    expect(sniffFormat('just some free text')).toBeUndefined();
+  });
+});
@@ -0,0 +1,38 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { keyLocations, readKeyLocations } from '../../../src/infrastructure/adapters/KeyLocations';
+
+describe('KeyLocations', () => {
+  it('should locate the keys of a YAML text', () => {
+    const locations = keyLocations('database:\n  host: db\n  port: 5432\nhosts:\n  - a\n  - b\n');
+
+    expect(locations.get('database')).toEqual({ line: 1, column: 1 });
+    expect(locations.get('database.port')).toEqual({ line: 3, column: 3 });
+    expect(locations.get('hosts.1')).toEqual({ line: 6, column: 5 });
+  });
+
+  it('should locate the keys of a JSON text', () => {
+    const locations = keyLocations('{\n  "api": {\n    "timeout": 30\n  }\n}\n');
+
+    expect(locations.get('api.timeout')).toEqual({ line: 3, column: 5 });
+  });
+
+  it('should locate nothing in a text that does not parse', () => {
+    expect(keyLocations('a: [1, 2\n').size).toBe(0);
+  });
+
+  it('should read YAML files and skip other formats and missing files', () => {
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-locations-'));
+    const filePath = path.join(dir, 'config.yaml');
+    fs.writeFileSync(filePath, 'log:\n  level: info\n');
+
+    try {
+      expect(readKeyLocations(filePath, 'yaml').get('log.level')).toEqual({ line: 2, column: 3 });
+      expect(readKeyLocations(filePath, 'env').size).toBe(0);
+      expect(readKeyLocations(path.join(dir, 'missing.yaml'), 'yaml').size).toBe(0);
+    } finally {
+      fs.rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
@@ -0,0 +1,194 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { FileReaderService } from '../../../src/infrastructure/adapters/FileReaderService';
+import {
+  JsonKeyTreeBuilder,
+  splitYamlEntry,
+  STREAMED_VALUE_LIMIT,
+  streamKeyTree,
+  StreamingUnsupportedError,
+  YamlKeyTreeBuilder,
+} from '../../../src/infrastructure/adapters/StreamingKeyTree';
+
+const feedJson = (text: string, chunkSize: number): Record<string, any> => {
+  const builder = new JsonKeyTreeBuilder();
+  for (let index = 0; index < text.length; index += chunkSize) {
+    builder.write(text.slice(index, index + chunkSize));
+  }
+  return builder.end();
+};
+
+const feedYaml = (text: string): Record<string, any> => {
+  const builder = new YamlKeyTreeBuilder();
+  text.split('\n').forEach(line => builder.writeLine(line));
+  return builder.end();
+};
+
+describe('StreamingKeyTree', () => {
+  describe('JsonKeyTreeBuilder', () => {
+    const document = {
+      database: { host: 'db.internal', port: 5432, password: 'quote " and \\ and é', replicas: [1, { zone: 'a' }] },
+      debug: false,
+      timeout: -1.5e3,
+      proxy: null,
+      tags: [],
+    };
+
+    it.each([1, 3, 64, 100000])('should read the same tree whatever the chunk size (%i)', chunkSize => {
+      expect(feedJson(JSON.stringify(document, null, 2), chunkSize)).toEqual(document);
+    });
+
+    it('should digest values past the limit', () => {
+      const tree = feedJson(JSON.stringify({
+        certificate: 'x'.repeat(STREAMED_VALUE_LIMIT + 1),
+        hosts: Array.from({ length: 500 }, (_, index) => `host-${index}`),
+      }), 7);
+
+      expect(tree.certificate).toMatch(/^sha256:[0-9a-f]{16}$/);
+      expect(tree.hosts).toEqual([expect.stringMatching(/^sha256:[0-9a-f]{16}$/)]);
+    });
+
+    it('should digest equal arrays alike, whatever their whitespace', () => {
+      const hosts = Array.from({ length: 500 }, (_, index) => `host-${index}`);
+
+      expect(feedJson(JSON.stringify({ hosts }, null, 2), 5).hosts).toEqual(feedJson(JSON.stringify({ hosts }), 5).hosts);
+    });
+
+    it('should keep __proto__ as an own key, as JSON.parse does', () => {
+      const tree = feedJson('{"__proto__": {"admin": true}}', 4);
+
+      expect(Object.keys(tree)).toEqual(['__proto__']);
+      expect(({} as any).admin).toBeUndefined();
+    });
+
+    it.each([
+      ['{"a": 1,}', "Unexpected '}' at line 1, column 9"],
+      ['{"a" 1}', "Unexpected '1' at line 1, column 6"],
+      ['{\n  "a": tru\n}', "Unexpected token 'tru'"],
+      ['{"a": [1, 2}', "Unexpected '}'"],
+      ['{"a": 1', 'Unexpected end of JSON input'],
+      ['[1, 2]', 'expected object'],
+    ])('should reject %j', (text, message) => {
+      expect(() => feedJson(text, 2)).toThrow(message);
+    });
+  });
+
+  describe('splitYamlEntry', () => {
+    it('should split plain and quoted keys from their value', () => {
+      expect(splitYamlEntry('url: http://example.com')).toEqual({ key: 'url', rest: 'http://example.com' });
+      expect(splitYamlEntry('"a: b": 1')).toEqual({ key: 'a: b', rest: '1' });
+      expect(splitYamlEntry('nested:')).toEqual({ key: 'nested', rest: '' });
+      expect(splitYamlEntry('- item')).toBeUndefined();
+      expect(splitYamlEntry('just text')).toBeUndefined();
+    });
+  });
+
+  describe('YamlKeyTreeBuilder', () => {
+    it('should read block mappings, sequences and scalars as the YAML parser does', () => {
+      const tree = feedYaml([
+        '# generated',
+        '---',
+        'database:',
+        '  host: db.internal # primary',
+        "  'port': 5432",
+        '  replicas:',
+        '  - zone: a',
+        '    weight: 2',
+        '  - zone: b',
+        '  flags: [a, b]',
+        '  options: {ssl: true}',
+        '  banner: |',
+        '    line one',
+        '    line two',
+        '  proxy:',
+        'debug: false',
+        'empty:',
+      ].join('\n'));
+
+      expect(tree).toEqual({
+        database: {
+          host: 'db.internal',
+          port: 5432,
+          replicas: [{ zone: 'a', weight: 2 }, { zone: 'b' }],
+          flags: ['a', 'b'],
+          options: { ssl: true },
+          banner: 'line one\nline two\n',
+          proxy: null,
+        },
+        debug: false,
+        empty: null,
+      });
+    });
+
+    it('should digest block sequences past the limit', () => {
+      const lines = ['hosts:', ...Array.from({ length: 200 }, (_, index) => `  - host-${index}`), 'port: 80'];
+
+      expect(feedYaml(lines.join('\n'))).toEqual({ hosts: [expect.stringMatching(/^sha256:/)], port: 80 });
+    });
+
+    it.each([
+      'base: &base\n  a: 1',
+      'a: 1\n---\nb: 2',
+      '- a\n- b',
+      'message: "spans\n  two lines"',
+      'a: 1\n   b: 2',
+    ])('should leave %j to the YAML parser', text => {
+      expect(() => feedYaml(text)).toThrow(StreamingUnsupportedError);
+    });
+  });
+
+  describe('reading files', () => {
+    let directory: string;
+
+    beforeEach(() => {
+      directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-stream-test-'));
+    });
+
+    afterEach(() => {
+      fs.rmSync(directory, { recursive: true, force: true });
+    });
+
+    it('should stream files of either format', async () => {
+      fs.writeFileSync(path.join(directory, 'app.json'), '\uFEFF{"app": {"name": "api"}}');
+      fs.writeFileSync(path.join(directory, 'app.yaml'), 'app:\r\n  name: api\r\n');
+
+      await expect(streamKeyTree(path.join(directory, 'app.json'), 'json')).resolves.toEqual({ app: { name: 'api' } });
+      await expect(streamKeyTree(path.join(directory, 'app.yaml'), 'yaml')).resolves.toEqual({ app: { name: 'api' } });
+    });
+
+    it('should stream files above the threshold only', async () => {
+      const large = path.join(directory, 'large.json');
+      const small = path.join(directory, 'small.json');
+      fs.writeFileSync(large, JSON.stringify({ blob: 'x'.repeat(5000) }));
+      fs.writeFileSync(small, JSON.stringify({ blob: 'x' }));
+      const reader = new FileReaderService({}, { streamAboveBytes: 1000 });
+
+      const streamed = await reader.readFile(large);
+      const parsed = await reader.readFile(small);
+
+      expect(streamed.metadata?.streamed).toBe(true);
+      expect(streamed.content.blob).toMatch(/^sha256:/);
+      expect(parsed.metadata?.streamed).toBeUndefined();
+      expect(parsed.content).toEqual({ blob: 'x' });
+    });
+
+    it('should parse YAML the streaming reader does not cover whole', async () => {
+      const file = path.join(directory, 'anchors.yaml');
+      fs.writeFileSync(file, `base: &base\n  a: 1\nderived:\n  <<: *base\n  padding: '${'x'.repeat(100)}'\n`);
+
+      const read = await new FileReaderService({}, { streamAboveBytes: 10 }).readFile(file);
+
+      expect(read.metadata?.streamed).toBeUndefined();
+      expect(read.content.derived.a).toBe(1);
+    });
+
+    it('should report malformed streamed JSON with its location', async () => {
+      const file = path.join(directory, 'broken.json');
+      fs.writeFileSync(file, `{\n  "a": 1,\n  "b" 2\n}${' '.repeat(100)}`);
+
+      await expect(new FileReaderService({}, { streamAboveBytes: 10 }).readFile(file))
+        .rejects.toThrow(/Failed to parse JSON file .*broken\.json: Unexpected '2' at line 3, column 7/);
+    });
+  });
+});
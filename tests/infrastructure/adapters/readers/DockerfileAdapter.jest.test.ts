@@ -0,0 +1,92 @@
+/**
+ * DockerfileAdapter Tests
+ *
+ * Tests for ENV/ARG extraction from Dockerfiles
+ */
+
+import {
+  DockerfileAdapter,
+  expandDockerVariables,
+  parseDockerfileContent,
+  splitDockerWords
+} from '../../../../src/infrastructure/adapters/readers/DockerfileAdapter';
+
+describe('DockerfileAdapter', () => {
+  const adapter = new DockerfileAdapter();
+
+  it('should handle Dockerfile and Containerfile names', () => {
+    expect(adapter.canHandle('Dockerfile')).toBe(true);
+    expect(adapter.canHandle('docker/Dockerfile.prod')).toBe(true);
+    expect(adapter.canHandle('api.Dockerfile')).toBe(true);
+    expect(adapter.canHandle('Containerfile')).toBe(true);
+    expect(adapter.canHandle('Dockerfile-notes.md')).toBe(false);
+    expect(adapter.canHandle('config.yaml')).toBe(false);
+    expect(adapter.getFormat()).toBe('dockerfile');
+  });
+});
+
+describe('parseDockerfileContent', () => {
+  it('should return empty object for empty content', () => {
+    expect(parseDockerfileContent('')).toEqual({});
+    expect(parseDockerfileContent(undefined as any)).toEqual({});
+  });
+
+  it('should extract ENV in both forms, with quotes and continuation lines', () => {
+    const content = [
+      'FROM node:20',
+      '# comment',
+      'ENV NODE_ENV=production \\',
+      '    GREETING="hello world"',
+      'ENV LOG_LEVEL info'
+    ].join('\n');
+
+    expect(parseDockerfileContent(content)).toEqual({
+      NODE_ENV: 'production',
+      GREETING: 'hello world',
+      LOG_LEVEL: 'info'
+    });
+  });
+
+  it('should expand ARG defaults and keep them under ARG', () => {
+    const content = [
+      'ARG APP_ENV=staging',
+      'FROM node:20',
+      'ARG APP_ENV',
+      'ARG PORT=8080',
+      'ENV NODE_ENV=$APP_ENV URL=http://localhost:${PORT}'
+    ].join('\n');
+
+    expect(parseDockerfileContent(content)).toEqual({
+      NODE_ENV: 'staging',
+      URL: 'http://localhost:8080',
+      ARG: { APP_ENV: 'staging', PORT: '8080' }
+    });
+  });
+
+  it('should report the final stage, inheriting from named stages only', () => {
+    const content = [
+      'FROM node:20 AS base',
+      'ENV SHARED=1',
+      'FROM base AS build',
+      'ENV BUILD_ONLY=1',
+      'FROM base',
+      'ENV PORT=3000'
+    ].join('\n');
+
+    expect(parseDockerfileContent(content)).toEqual({ SHARED: '1', PORT: '3000' });
+  });
+});
+
+describe('Dockerfile word and variable helpers', () => {
+  it('should split words honoring quotes and escapes', () => {
+    expect(splitDockerWords('A="x y" B=c\\ d C=\'$E\'')).toEqual(['A=x y', 'B=c d', 'C=$E']);
+  });
+
+  it('should expand variables with defaults and alternatives', () => {
+    const variables = { HOST: 'db', EMPTY: '' };
+
+    expect(expandDockerVariables('$HOST:${PORT:-5432}', variables)).toBe('db:5432');
+    expect(expandDockerVariables('${HOST:+set}${EMPTY:+set}', variables)).toBe('set');
+    expect(expandDockerVariables('${MISSING}', variables)).toBe('');
+  });
+});
@@ -285,6 +285,59 @@ describe('parseHclContent', () => {
     });
   });
 
+  describe('Heredocs', () => {
+    it('should keep JSON heredocs as objects', () => {
+      const content = [
+        'resource "aws_iam_policy" "deploy" {',
+        '  name = "deploy"',
+        '  policy = <<EOF',
+        '{',
+        '  "Version": "2012-10-17",',
+        '  "Statement": [{ "Effect": "Allow", "Action": "s3:GetObject", "Resource": "*" }]',
+        '}',
+        'EOF',
+        '}'
+      ].join('\n');
+
+      expect(parseHclContent(content)).toEqual({
+        'resource.aws_iam_policy.deploy': {
+          name: 'deploy',
+          policy: {
+            Version: '2012-10-17',
+            Statement: [{ Effect: 'Allow', Action: 's3:GetObject', Resource: '*' }]
+          }
+        }
+      });
+    });
+
+    it('should read other heredocs as text', () => {
+      const content = 'resource "aws_instance" "web" {\n  user_data = <<-SCRIPT\n    echo hello\n    SCRIPT\n}';
+
+      expect(parseHclContent(content)).toEqual({ 'resource.aws_instance.web': { user_data: 'echo hello' } });
+    });
+  });
+
+  describe('Vault policies', () => {
+    it('should parse path rules with capabilities spanning several lines', () => {
+      const content = [
+        'path "secret/data/app/*" {',
+        '  capabilities = [',
+        '    "read",',
+        '    "list",',
+        '  ]',
+        '}',
+        'path "sys/*" {',
+        '  capabilities = ["deny"]',
+        '}'
+      ].join('\n');
+
+      expect(parseHclContent(content)).toEqual({
+        'path.secret/data/app/*': { capabilities: ['read', 'list'] },
+        'path.sys/*': { capabilities: ['deny'] }
+      });
+    });
+  });
+
   describe('Edge cases', () => {
     it('should handle blocks with no properties', () => {
       const content = 'variable "empty" {\n}';
@@ -131,6 +131,65 @@ describe('parseYamlContent', () => {
     });
   });
 
+  describe('Multi-document files', () => {
+    it('should key Kubernetes documents by kind and name', () => {
+      const content = [
+        'apiVersion: v1\nkind: Service\nmetadata:\n  name: api',
+        'apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: api',
+        'plain: true'
+      ].join('\n---\n');
+
+      expect(Object.keys(parseYamlContent(content))).toEqual(['Service/api', 'Deployment/api', 'document3']);
+    });
+
+    it('should parse a single document with a leading separator as before', () => {
+      expect(parseYamlContent('---\nname: John\n')).toEqual({ name: 'John' });
+    });
+  });
+
+  describe('CloudFormation intrinsic functions', () => {
+    it('should read short-form tags as their long form', () => {
+      const content = [
+        'Resources:',
+        '  Bucket:',
+        '    Type: AWS::S3::Bucket',
+        '    Properties:',
+        "      BucketName: !Sub '${AWS::StackName}-${Env}'",
+        '      Role: !GetAtt Role.Arn',
+        '      Size: !FindInMap [Sizes, !Ref Env, Bucket]',
+        '      Tags: !If',
+        '        - IsProd',
+        '        - !Ref ProdTags',
+        '        - !Ref AWS::NoValue'
+      ].join('\n');
+
+      expect(parseYamlContent(content).Resources.Bucket.Properties).toEqual({
+        BucketName: { 'Fn::Sub': '${AWS::StackName}-${Env}' },
+        Role: { 'Fn::GetAtt': ['Role', 'Arn'] },
+        Size: { 'Fn::FindInMap': ['Sizes', { Ref: 'Env' }, 'Bucket'] },
+        Tags: { 'Fn::If': ['IsProd', { Ref: 'ProdTags' }, { Ref: 'AWS::NoValue' }] }
+      });
+    });
+
+    it('should read Ansible vault and unsafe values as strings', () => {
+      const content = [
+        'db_password: !vault |',
+        '  $ANSIBLE_VAULT;1.1;AES256',
+        '  6162636465',
+        "motd: !unsafe '{{ not a template }}'"
+      ].join('\n');
+
+      expect(parseYamlContent(content)).toEqual({
+        db_password: '$ANSIBLE_VAULT;1.1;AES256\n6162636465\n',
+        motd: '{{ not a template }}'
+      });
+    });
+
+    it('should still reject unknown tags', () => {
+      expect(() => parseYamlContent('value: !Custom thing')).toThrow('Invalid YAML syntax');
+    });
+  });
+
   describe('Basic parsing', () => {
     it('should parse simple YAML object', () => {
       const content = 'name: John\nage: 30\ncity: New York';
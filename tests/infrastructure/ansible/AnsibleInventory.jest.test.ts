@@ -0,0 +1,88 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import {
+  findInventories,
+  loadAnsibleInventories,
+  readAnsibleInventory,
+  readGroupVars
+} from '../../../src/infrastructure/ansible/AnsibleInventory';
+
+describe('AnsibleInventory', () => {
+  let directory: string;
+
+  const write = (relativePath: string, content: string): void => {
+    const filePath = path.join(directory, relativePath);
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, content);
+  };
+
+  beforeEach(() => {
+    directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-ansible-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(directory, { recursive: true, force: true });
+  });
+
+  it('should find one inventory per environment directory', () => {
+    write('inventories/dev/hosts', '[web]\nweb1\n');
+    write('inventories/prod/group_vars/all.yml', 'ntp: ntp.internal\n');
+    write('inventories/README.md', '# inventories\n');
+
+    expect(findInventories([path.join(directory, 'inventories')])).toEqual([
+      path.join(directory, 'inventories', 'dev'),
+      path.join(directory, 'inventories', 'prod')
+    ]);
+    expect(findInventories([path.join(directory, 'inventories', 'dev')])).toEqual([path.join(directory, 'inventories', 'dev')]);
+  });
+
+  it('should reject a path without inventories', () => {
+    expect(() => findInventories([path.join(directory, 'missing')])).toThrow('No Ansible inventory found');
+  });
+
+  it('should merge group_vars files and directories and skip vault-encrypted files', async () => {
+    write('group_vars/all.yml', 'ntp: ntp.internal\n');
+    write('group_vars/web/main.yml', 'http_port: 80\nworkers: 2\n');
+    write('group_vars/web/tuning.yml', 'workers: 8\n');
+    write('group_vars/web/vault', '$ANSIBLE_VAULT;1.1;AES256\n6162636465\n');
+    write('group_vars/db', 'port: 5432\n');
+
+    expect(await readGroupVars(path.join(directory, 'group_vars'))).toEqual({
+      all: { ntp: 'ntp.internal' },
+      web: { http_port: 80, workers: 8 },
+      db: { port: 5432 }
+    });
+  });
+
+  it('should read an inventory as groups with their effective variables', async () => {
+    write('prod/hosts.yml', [
+      'all:',
+      '  children:',
+      '    web:',
+      '      hosts:',
+      '        web1: {}',
+      '      vars:',
+      '        http_port: 8080'
+    ].join('\n'));
+    write('prod/group_vars/all.yml', 'ntp: ntp.internal\n');
+    write('prod/group_vars/web.yml', 'workers: 8\n');
+    write('prod/host_vars/web1.yml', 'rack: r12\n');
+
+    const file = await readAnsibleInventory(path.join(directory, 'prod'));
+
+    expect(file).toMatchObject({ path: path.join(directory, 'prod'), format: 'ansible', environment: 'prod' });
+    expect(file.content).toEqual({
+      all: { ntp: 'ntp.internal' },
+      web: { ntp: 'ntp.internal', http_port: 8080, workers: 8 }
+    });
+  });
+
+  it('should read a bare host list as an INI inventory', async () => {
+    write('dev/hosts', 'web1\nweb2\n');
+
+    const [file] = await loadAnsibleInventories([path.join(directory, 'dev')]);
+
+    expect(file.content).toEqual({ all: {}, ungrouped: {} });
+  });
+});
@@ -0,0 +1,60 @@
+import { jobContext, parseBatchInput, readInlineFiles } from '../../../src/infrastructure/batch/BatchJobs';
+import { ConfigError } from '../../../src/shared/utils/ExitCodes';
+
+describe('BatchJobs', () => {
+  describe('parseBatchInput', () => {
+    it('should read NDJSON with one job per line', () => {
+      const jobs = parseBatchInput('{"id":"api","files":["a.yaml","b.yaml"]}\n\n{"files":["c.json"]}\n');
+
+      expect(jobs).toEqual([
+        { id: 'api', files: ['a.yaml', 'b.yaml'], inline: [] },
+        { id: '2', files: ['c.json'], inline: [] }
+      ]);
+    });
+
+    it('should read a JSON array, a jobs object or a single job', () => {
+      const job = { files: ['a.yaml'], inline: [{ name: 'b.env', content: 'PORT=1' }] };
+
+      expect(parseBatchInput(JSON.stringify([job, job], null, 2))).toHaveLength(2);
+      expect(parseBatchInput(JSON.stringify({ jobs: [job] }))).toHaveLength(1);
+      expect(parseBatchInput(JSON.stringify(job))[0].inline).toEqual([{ name: 'b.env', content: 'PORT=1' }]);
+      expect(parseBatchInput('  ')).toEqual([]);
+    });
+
+    it('should read a job or a jobs object laid out over several lines', () => {
+      const job = { id: 'api', files: ['a.yaml', 'b.yaml'] };
+
+      expect(parseBatchInput(JSON.stringify(job, null, 2))).toEqual([{ id: 'api', files: ['a.yaml', 'b.yaml'], inline: [] }]);
+      expect(parseBatchInput(JSON.stringify({ jobs: [job, { files: ['c.json'] }] }, null, 2))).toEqual([
+        { id: 'api', files: ['a.yaml', 'b.yaml'], inline: [] },
+        { id: '2', files: ['c.json'], inline: [] }
+      ]);
+      expect(() => parseBatchInput('{\n  "jobs": [\n')).toThrow('input: invalid JSON');
+    });
+
+    it('should reject jobs without files or with malformed inline contents', () => {
+      expect(() => parseBatchInput('{"id":"empty"}')).toThrow(ConfigError);
+      expect(() => parseBatchInput('{"inline":[{"name":"a.yaml"}]}')).toThrow('inline files need');
+      expect(() => parseBatchInput('{"files":"a.yaml"}')).toThrow('"files" must be a list');
+      expect(() => parseBatchInput('{"files":["a"]}\nnot json')).toThrow('line 2: invalid JSON');
+    });
+  });
+
+  it('should apply per-job keys over the base context', () => {
+    const [job] = parseBatchInput('{"files":["a.yaml"],"requiredKeys":["port"]}');
+
+    expect(jobContext(job, { ignoreKeys: ['debug'] })).toEqual({ ignoreKeys: ['debug'], requiredKeys: ['port'] });
+  });
+
+  it('should parse inline contents under their own names', async () => {
+    const files = await readInlineFiles([
+      { name: 'dev.yaml', content: 'port: 80\n' },
+      { name: 'prod', format: 'json', content: '{"port": 443, "host": "x"}' }
+    ]);
+
+    expect(files.map(({ path, format, content }) => ({ path, format, content }))).toEqual([
+      { path: 'dev.yaml', format: 'yaml', content: { port: 80 } },
+      { path: 'prod', format: 'json', content: { port: 443, host: 'x' } }
+    ]);
+  });
+});
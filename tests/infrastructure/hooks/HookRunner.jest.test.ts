@@ -0,0 +1,122 @@
+import * as fs from 'fs';
+import * as path from 'path';
+import { PassThrough } from 'stream';
+import {
+  groupUsage,
+  HookLimitError,
+  hookEnvironment,
+  hookLimitFinding,
+  parseProcStat,
+  runHooks,
+  runPostHooks
+} from '../../../src/infrastructure/hooks/HookRunner';
+
+const node = (script: string) => `"${process.execPath}" -e "${script}"`;
+
+// CPU time and memory are sampled from /proc
+const onLinux = fs.existsSync('/proc/self/stat') ? it : it.skip;
+
+const capture = () => {
+  const output = new PassThrough();
+  const chunks: string[] = [];
+  output.on('data', chunk => chunks.push(chunk.toString()));
+  return { output, text: () => chunks.join('') };
+};
+
+describe('HookRunner', () => {
+  const result = { success: false, errors: [{ code: 'MISSING_KEY', message: 'missing', severity: 'error' as const }], warnings: [] };
+
+  it('should describe the audit in PRAETORIAN_* variables', () => {
+    expect(hookEnvironment('pre', { config: 'praetorian.yaml', environment: 'prod', files: ['a.yaml', 'b.yaml'] })).toEqual({
+      PRAETORIAN_HOOK: 'pre',
+      PRAETORIAN_CONFIG: 'praetorian.yaml',
+      PRAETORIAN_ENV: 'prod',
+      PRAETORIAN_FILES: ['a.yaml', 'b.yaml'].join(path.delimiter)
+    });
+    expect(hookEnvironment('post', { files: [], result })).toMatchObject({
+      PRAETORIAN_SUCCESS: 'false',
+      PRAETORIAN_ERRORS: '1',
+      PRAETORIAN_WARNINGS: '0'
+    });
+  });
+
+  it('should run commands in order and forward their output', async () => {
+    const { output, text } = capture();
+
+    await runHooks('pre', [node('console.log(process.env.PRAETORIAN_HOOK)'), node('console.log(2)')], { files: [] }, { output });
+
+    expect(text()).toBe('pre\n2\n');
+  });
+
+  it('should stop at the first failing command', async () => {
+    const { output, text } = capture();
+
+    await expect(runHooks('pre', [node('process.exit(3)'), node('console.log(1)')], { files: [] }, { output }))
+      .rejects.toThrow('pre hook failed with exit code 3');
+    expect(text()).toBe('');
+  });
+
+  it('should time out long-running commands', async () => {
+    await expect(runHooks('pre', [node('setTimeout(() => {}, 2000)')], { files: [] }, { timeoutMs: 100, output: new PassThrough() }))
+      .rejects.toThrow('Hook timed out after 100ms');
+  });
+
+  it('should name the stage of a hook killed for going over a limit', async () => {
+    const run = runHooks('post', [node('setTimeout(() => {}, 2000)')], { files: [] }, { timeoutMs: 100, output: new PassThrough() });
+
+    await expect(run).rejects.toBeInstanceOf(HookLimitError);
+    await expect(run).rejects.toMatchObject({ breach: { stage: 'post', limit: 'time', max: 100 } });
+  });
+
+  onLinux('should kill commands going over their CPU time limit', async () => {
+    await expect(runHooks('pre', [node('for (;;) {}')], { files: [] }, { cpuSeconds: 0.5, timeoutMs: 10000, output: new PassThrough() }))
+      .rejects.toThrow('Hook exceeded its CPU time limit of 0.5s');
+  });
+
+  onLinux('should kill commands going over their memory limit', async () => {
+    await expect(runHooks(
+      'pre',
+      [node('const b = Buffer.alloc(300 * 1024 * 1024, 1); setTimeout(() => {}, 5000)')],
+      { files: [] },
+      { memoryMb: 150, timeoutMs: 10000, output: new PassThrough() }
+    )).rejects.toThrow('Hook exceeded its memory limit of 150 MB');
+  });
+
+  it('should read the CPU time, memory and process group of a process', () => {
+    const stat = '4242 (my (odd) hook) S 1 4240 4240 0 -1 4194304 100 0 0 0 30 20 5 5 20 0 1 0 100 1000000 512 18446744073709551615';
+
+    expect(parseProcStat(stat)).toEqual({ pid: 4242, pgrp: 4240, cpuTicks: 60, rssPages: 512 });
+    expect(parseProcStat('garbage')).toBeUndefined();
+  });
+
+  it('should add up the usage of the processes of a group only', () => {
+    const usage = groupUsage([
+      { pid: 1, pgrp: 7, cpuTicks: 150, rssPages: 256 },
+      { pid: 2, pgrp: 7, cpuTicks: 50, rssPages: 256 },
+      { pid: 3, pgrp: 8, cpuTicks: 999, rssPages: 999 },
+    ], 7);
+
+    expect(usage).toEqual({ cpuSeconds: 2, memoryMb: 2 });
+  });
+
+  it('should report a breach as an error finding', () => {
+    expect(hookLimitFinding({ stage: 'pre', command: 'helm template', limit: 'time', max: 100 })).toEqual({
+      code: 'HOOK_LIMIT_EXCEEDED',
+      message: 'Pre hook timed out after 100ms: helm template',
+      severity: 'error',
+      context: { extras: { stage: 'pre', command: 'helm template', limit: 'time', max: 100 } }
+    });
+  });
+
+  it('should hand the result file to post hooks', async () => {
+    const { output, text } = capture();
+
+    await runPostHooks(
+      [node("console.log(require('fs').readFileSync(process.env.PRAETORIAN_RESULT_FILE, 'utf8').includes('MISSING_KEY'))")],
+      { files: [], result },
+      { output }
+    );
+
+    expect(text()).toBe('true\n');
+  });
+});
@@ -0,0 +1,68 @@
+import * as http from 'http';
+import { AddressInfo } from 'net';
+import { HttpClient } from '../../../src/infrastructure/http/HttpClient';
+import { probeEndpoint, probeEndpoints, redirectsToOtherHost } from '../../../src/infrastructure/http/EndpointProbe';
+
+describe('EndpointProbe', () => {
+  const resolves = async () => ({ address: '127.0.0.1' });
+
+  it('should tell redirects to other hosts from same-host redirects', () => {
+    expect(redirectsToOtherHost('https://a.example.com/x', '/login')).toBe(false);
+    expect(redirectsToOtherHost('https://a.example.com/x', 'https://A.example.com/y')).toBe(false);
+    expect(redirectsToOtherHost('https://a.example.com/x', 'https://b.example.com/')).toBe(true);
+    expect(redirectsToOtherHost('https://a.example.com/x', undefined)).toBe(false);
+  });
+
+  it('should report hosts that do not resolve without sending requests', async () => {
+    const result = await probeEndpoint('https://nowhere.invalid/', {
+      head: true,
+      lookup: async () => { throw new Error('getaddrinfo ENOTFOUND nowhere.invalid'); }
+    });
+
+    expect(result).toEqual({ url: 'https://nowhere.invalid/', problem: 'DNS_FAILED', detail: 'getaddrinfo ENOTFOUND nowhere.invalid' });
+  });
+
+  describe('head requests', () => {
+    let server: http.Server;
+    let baseUrl: string;
+    const methods: string[] = [];
+
+    beforeEach(async () => {
+      server = http.createServer((request, response) => {
+        methods.push(request.method ?? '');
+        if (request.url === '/moved') {
+          response.writeHead(301, { location: 'https://elsewhere.example.com/' });
+        }
+        response.end();
+      });
+      await new Promise<void>(resolve => server.listen(0, '127.0.0.1', () => resolve()));
+      baseUrl = `http://127.0.0.1:${(server.address() as AddressInfo).port}`;
+    });
+
+    afterEach(async () => {
+      server.closeAllConnections();
+      await new Promise(resolve => server.close(resolve));
+    });
+
+    it('should report redirects to another host and accept reachable endpoints', async () => {
+      const client = new HttpClient({ retries: 0, env: {} });
+      const results = await probeEndpoints([`${baseUrl}/ok`, `${baseUrl}/moved`, `${baseUrl}/ok`], { head: true, client, lookup: resolves });
+
+      expect(results.get(`${baseUrl}/ok`)).toEqual({ url: `${baseUrl}/ok` });
+      expect(results.get(`${baseUrl}/moved`)).toMatchObject({ problem: 'REDIRECT_HOST', location: 'https://elsewhere.example.com/' });
+      expect(methods).toEqual(['HEAD', 'HEAD']);
+    });
+
+    it('should report endpoints that do not answer', async () => {
+      const client = new HttpClient({ retries: 0, env: {} });
+      server.closeAllConnections();
+      await new Promise(resolve => server.close(resolve));
+      server = http.createServer();
+      await new Promise<void>(resolve => server.listen(0, '127.0.0.1', () => resolve()));
+
+      const result = await probeEndpoint(`${baseUrl}/ok`, { head: true, client, lookup: resolves });
+
+      expect(result.problem).toBe('UNREACHABLE');
+    });
+  });
+});
@@ -0,0 +1,70 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { buildConfigDocs, documentKeys, renderConfigDocs, rulesForKey } from '../../../src/infrastructure/inventory/ConfigDocs';
+import { createRedactor } from '../../../src/shared/utils/Redaction';
+
+describe('ConfigDocs', () => {
+  let directory: string;
+
+  beforeEach(() => {
+    directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-docs-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(directory, { recursive: true, force: true });
+  });
+
+  it('should list the rules that apply to a key', () => {
+    expect(rulesForKey('services.api.port', {
+      requiredKeys: ['services.*.port'],
+      schema: { 'services.*.port': 'port' },
+      budgets: [{ name: 'ports', keys: ['services.*.port'] }],
+      ignoreKeys: ['other']
+    })).toEqual(['required', 'type `port`', 'budget `ports`']);
+  });
+
+  it('should describe leaf keys across environments and redact secrets', () => {
+    const keys = documentKeys([
+      { path: 'dev.yaml', environment: 'dev', content: { db: { host: 'localhost', password: 'dev-pass', pool: 5 } } },
+      { path: 'prod.yaml', environment: 'prod', content: { db: { host: 'db.internal', password: 'hunter2', pool: '20' } } }
+    ], { requiredKeys: ['db.host', 'db.port'], schema: { 'db.host': 'hostname' } });
+
+    expect(keys).toEqual([
+      { key: 'db.host', types: ['hostname'], setIn: ['dev', 'prod'], example: 'localhost', rules: ['required', 'type `hostname`'] },
+      { key: 'db.password', types: ['string'], setIn: ['dev', 'prod'], example: '***', rules: [] },
+      { key: 'db.pool', types: ['number', 'string'], setIn: ['dev', 'prod'], example: 5, rules: [] },
+      { key: 'db.port', types: [], setIn: [], rules: ['required'] }
+    ]);
+  });
+
+  it('should apply the configured redaction to every example', () => {
+    const [doc] = documentKeys([{ path: 'app.yaml', content: { host: 'x' } }], {}, createRedactor({ policy: 'mask-all' }));
+
+    expect(doc.example).toBe('***');
+  });
+
+  it('should render Markdown tables that survive pipes in values', () => {
+    const markdown = renderConfigDocs(
+      [{ key: 'log.format', types: ['string'], setIn: ['prod'], example: 'a|b', rules: [] }],
+      [{ path: 'prod.yaml', environment: 'prod', content: {} }],
+      'praetorian.yaml'
+    );
+
+    expect(markdown).toContain('Generated by `praetorian docs generate` from `praetorian.yaml`');
+    expect(markdown).toContain('| `prod.yaml` | prod | — | 1 |');
+    expect(markdown).toContain('| `log.format` | string | prod | `a\\|b` | — |');
+  });
+
+  it('should read the configured files and keep unreadable ones', async () => {
+    const file = path.join(directory, 'prod.yaml');
+    fs.writeFileSync(file, 'api:\n  token: hunter2\n  url: https://api.example.com\n');
+
+    const docs = await buildConfigDocs({ environments: { prod: file }, files: [path.join(directory, 'missing.yaml')] });
+
+    expect(docs.keys.map(doc => [doc.key, doc.example])).toEqual([['api.token', '***'], ['api.url', 'https://api.example.com']]);
+    expect(docs.files[1].error).toBeDefined();
+    expect(docs.markdown).not.toContain('hunter2');
+    expect(docs.markdown).toContain('unreadable:');
+  });
+});
@@ -0,0 +1,91 @@
+import { execFileSync } from 'child_process';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { gitChangedFiles, gitShowFile, readChangedFiles } from '../../../src/infrastructure/inventory/GitRevisions';
+import { IoError } from '../../../src/shared/utils/ExitCodes';
+
+const git = (...args: string[]): string =>
+  execFileSync('git', ['-c', 'user.name=test', '-c', 'user.email=test@example.com', ...args], { encoding: 'utf8' });
+
+describe('GitRevisions', () => {
+  const previousCwd = process.cwd();
+  const previousPath = process.env.PATH;
+  let repository: string;
+
+  beforeEach(() => {
+    repository = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-git-'));
+    process.chdir(repository);
+    git('init', '-q');
+    fs.writeFileSync('prod.yaml', 'port: 80\n');
+    fs.writeFileSync('dev.yaml', 'port: 8080\n');
+    fs.writeFileSync('notes.txt', 'unrelated\n');
+    git('add', '-A');
+    git('commit', '-qm', 'base');
+  });
+
+  afterEach(() => {
+    process.env.PATH = previousPath;
+    process.chdir(previousCwd);
+    fs.rmSync(repository, { recursive: true, force: true });
+  });
+
+  it('should list the files changed in the working tree and between revisions', async () => {
+    fs.writeFileSync('prod.yaml', 'port: 443\n');
+
+    expect(await gitChangedFiles('HEAD')).toEqual(['prod.yaml']);
+
+    git('commit', '-qam', 'tls');
+    fs.writeFileSync('dev.yaml', 'port: 8443\n');
+
+    expect(await gitChangedFiles('HEAD~1', 'HEAD')).toEqual(['prod.yaml']);
+    expect(await gitChangedFiles('HEAD~1')).toEqual(['dev.yaml', 'prod.yaml']);
+  });
+
+  it('should show a file at a revision and nothing where it does not exist', async () => {
+    fs.writeFileSync('prod.yaml', 'port: 443\n');
+
+    expect(await gitShowFile('HEAD', 'prod.yaml')).toBe('port: 80\n');
+    expect(await gitShowFile('HEAD', path.join(repository, 'prod.yaml'))).toBe('port: 80\n');
+    expect(await gitShowFile('HEAD', 'staging.yaml')).toBeUndefined();
+  });
+
+  it('should parse the configured files changed since a revision at both revisions', async () => {
+    fs.writeFileSync('prod.yaml', 'port: 443\ntls: true\n');
+    fs.writeFileSync('notes.txt', 'changed\n');
+    fs.writeFileSync('staging.yaml', 'port: 8443\n');
+    git('add', 'staging.yaml');
+
+    const revisions = await readChangedFiles(
+      { environments: { prod: 'prod.yaml', dev: 'dev.yaml', staging: 'staging.yaml' } },
+      { base: 'HEAD' }
+    );
+
+    expect(revisions).toEqual([
+      { path: 'prod.yaml', environment: 'prod', before: { port: 80 }, after: { port: 443, tls: true } },
+      { path: 'staging.yaml', environment: 'staging', after: { port: 8443 } },
+    ]);
+  });
+
+  it('should compare against a head revision instead of the working tree', async () => {
+    git('rm', '-q', 'dev.yaml');
+    git('commit', '-qm', 'drop dev');
+    fs.writeFileSync('prod.yaml', 'port: 443\n');
+
+    const revisions = await readChangedFiles({ environments: { prod: 'prod.yaml', dev: 'dev.yaml' } }, { base: 'HEAD~1', head: 'HEAD' });
+
+    expect(revisions).toEqual([{ path: 'dev.yaml', environment: 'dev', before: { port: 8080 } }]);
+  });
+
+  it('should fail with an I/O error on an unknown revision', async () => {
+    await expect(gitChangedFiles('no-such-ref')).rejects.toThrow(IoError);
+    await expect(gitChangedFiles('no-such-ref')).rejects.toThrow(/git diff failed: .*no-such-ref/);
+  });
+
+  it('should fail with an I/O error when git is not installed', async () => {
+    process.env.PATH = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-no-git-'));
+
+    await expect(gitChangedFiles('HEAD')).rejects.toThrow(IoError);
+    await expect(readChangedFiles({ files: ['prod.yaml'] }, { base: 'HEAD' })).rejects.toThrow(/git diff failed/);
+  });
+});
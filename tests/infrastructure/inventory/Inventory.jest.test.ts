@@ -0,0 +1,84 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { buildInventory, countSecretKeys, hashLeafValues, inventoryTargets } from '../../../src/infrastructure/inventory/Inventory';
+import { hashValue } from '../../../src/shared/utils/Redaction';
+
+describe('Inventory', () => {
+  let directory: string;
+
+  beforeEach(() => {
+    directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-inventory-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(directory, { recursive: true, force: true });
+  });
+
+  it('should list each file once with its environment and group', () => {
+    expect(inventoryTargets({
+      environments: { dev: 'dev.yaml', prod: 'prod.yaml' },
+      groups: [{ name: 'api', files: ['api.yaml', 'prod.yaml'] }],
+      files: ['dev.yaml', 'extra.json']
+    })).toEqual([
+      { path: 'dev.yaml', environment: 'dev' },
+      { path: 'prod.yaml', environment: 'prod', group: 'api' },
+      { path: 'api.yaml', group: 'api' },
+      { path: 'extra.json' }
+    ]);
+  });
+
+  it('should count keys whose name marks a secret', () => {
+    const content = { db: { host: 'x', password: 'p' }, api_key: 'k', signing: { cert: 'c' } };
+
+    expect(countSecretKeys(content)).toBe(2);
+    expect(countSecretKeys(content, ['cert'])).toBe(3);
+  });
+
+  it('should describe files without their values and keep unreadable ones', async () => {
+    const file = path.join(directory, 'prod.yaml');
+    fs.writeFileSync(file, 'db:\n  host: db.internal\n  password: hunter2\n');
+
+    const inventory = await buildInventory(
+      { environments: { prod: file }, files: [path.join(directory, 'missing.yaml')] },
+      { config: 'praetorian.yaml', now: new Date('2026-01-05T10:00:00Z') }
+    );
+
+    expect(inventory).toMatchObject({ version: 1, generatedAt: '2026-01-05T10:00:00.000Z', config: 'praetorian.yaml' });
+    expect(inventory.files[0]).toMatchObject({
+      path: file,
+      environment: 'prod',
+      format: 'yaml',
+      size: fs.statSync(file).size,
+      keyCount: 3,
+      secretKeyCount: 1
+    });
+    expect(inventory.files[0].sha256).toMatch(/^sha256:[0-9a-f]{64}$/);
+    expect(inventory.files[0].lastModifiedInGit).toBeUndefined();
+    expect(JSON.stringify(inventory)).not.toContain('hunter2');
+    expect(inventory.files[1].error).toBeDefined();
+  });
+
+  it('should list salted hashes of the values only when a salt is given', async () => {
+    const file = path.join(directory, 'prod.yaml');
+    fs.writeFileSync(file, 'db:\n  host: db.internal\n  password: hunter2\n');
+
+    const plain = await buildInventory({ files: [file] });
+    const hashed = await buildInventory({ files: [file] }, { hashSalt: 'shared-secret' });
+
+    expect(plain.files[0].values).toBeUndefined();
+    expect(hashed.files[0].values).toEqual({
+      'db.host': hashValue('db.internal', 'shared-secret'),
+      'db.password': hashValue('hunter2', 'shared-secret')
+    });
+    expect(JSON.stringify(hashed)).not.toContain('hunter2');
+  });
+
+  it('should hash equal values equally under the same salt only', () => {
+    const ours = hashLeafValues({ token: 'abc123' }, 'salt-a');
+
+    expect(ours).toEqual(hashLeafValues({ token: 'abc123' }, 'salt-a'));
+    expect(ours).not.toEqual(hashLeafValues({ token: 'abc123' }, 'salt-b'));
+    expect(ours.token).toMatch(/^hmac-sha256:[0-9a-f]{16}$/);
+  });
+});
@@ -0,0 +1,85 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { compareSnapshots, createSnapshot, readSnapshot, Snapshot, writeSnapshot } from '../../../src/infrastructure/inventory/Snapshot';
+import { ConfigError, IoError } from '../../../src/shared/utils/ExitCodes';
+
+const snapshot = (files: Snapshot['files']): Snapshot => ({ version: 1, createdAt: '2026-01-05T10:00:00.000Z', files });
+
+describe('Snapshot', () => {
+  let directory: string;
+
+  beforeEach(() => {
+    directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-snapshot-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(directory, { recursive: true, force: true });
+  });
+
+  it('should record hashes and sorted key sets without values', async () => {
+    const file = path.join(directory, 'prod.yaml');
+    fs.writeFileSync(file, 'port: 80\ndb:\n  password: hunter2\n');
+
+    const recorded = await createSnapshot({ environments: { prod: file } }, { now: new Date('2026-01-05T10:00:00Z') });
+
+    expect(recorded.files).toEqual([
+      {
+        path: file,
+        environment: 'prod',
+        sha256: expect.stringMatching(/^sha256:/),
+        contentHash: expect.stringMatching(/^sha256:/),
+        keys: ['db', 'db.password', 'port'],
+      }
+    ]);
+    expect(JSON.stringify(recorded)).not.toContain('hunter2');
+  });
+
+  it('should report no drift for an unchanged state', () => {
+    const entry = { path: 'a.yaml', sha256: 'sha256:1', keys: ['port'] };
+
+    expect(compareSnapshots(snapshot([entry]), snapshot([entry]))).toEqual([]);
+  });
+
+  it('should not report reformatted files as drift', async () => {
+    const file = path.join(directory, 'prod.yaml');
+    fs.writeFileSync(file, 'port: 80\ndb:\n  host: db.internal\n');
+    const recorded = await createSnapshot({ files: [file] });
+
+    fs.writeFileSync(file, '# reordered\ndb: { host: db.internal }\nport: 80\n');
+    const reformatted = await createSnapshot({ files: [file] });
+    fs.writeFileSync(file, 'port: 81\ndb:\n  host: db.internal\n');
+    const changed = await createSnapshot({ files: [file] });
+
+    expect(compareSnapshots(recorded, reformatted)).toEqual([]);
+    expect(compareSnapshots(recorded, changed)).toEqual([{ kind: 'CONTENT_CHANGED', path: file }]);
+  });
+
+  it('should report added, removed and changed files with their key changes', () => {
+    const recorded = snapshot([
+      { path: 'a.yaml', sha256: 'sha256:1', keys: ['host', 'port'] },
+      { path: 'gone.yaml', sha256: 'sha256:2', keys: [] }
+    ]);
+    const current = snapshot([
+      { path: 'a.yaml', sha256: 'sha256:3', keys: ['port', 'timeout'] },
+      { path: 'new.yaml', sha256: 'sha256:4', keys: [] }
+    ]);
+
+    expect(compareSnapshots(recorded, current)).toEqual([
+      { kind: 'FILE_REMOVED', path: 'gone.yaml' },
+      { kind: 'CONTENT_CHANGED', path: 'a.yaml' },
+      { kind: 'KEYS_ADDED', path: 'a.yaml', keys: ['timeout'] },
+      { kind: 'KEYS_REMOVED', path: 'a.yaml', keys: ['host'] },
+      { kind: 'FILE_ADDED', path: 'new.yaml' }
+    ]);
+  });
+
+  it('should round-trip snapshots and reject missing or unreadable ones', async () => {
+    const file = path.join(directory, 'snapshot.json');
+    writeSnapshot(file, snapshot([]));
+
+    expect(readSnapshot(file).files).toEqual([]);
+    expect(() => readSnapshot(path.join(directory, 'missing.json'))).toThrow(ConfigError);
+    await expect(createSnapshot({ files: [path.join(directory, 'missing.yaml')] })).rejects.toThrow(IoError);
+  });
+});
@@ -13,7 +13,11 @@ jest.mock('../../../src/infrastructure/parsers/config-parsing/ConfigFileOperatio
   stringifyToYaml: jest.fn(),
   resolvePath: jest.fn(),
   getDirectoryName: jest.fn(),
-  joinPath: jest.fn()
+  joinPath: jest.fn(),
+  expandFileGlob: jest.fn(),
+  pathPlaceholders: jest.requireActual('../../../src/infrastructure/parsers/config-parsing/ConfigFileOperations').pathPlaceholders,
+  fillPathTemplate: jest.requireActual('../../../src/infrastructure/parsers/config-parsing/ConfigFileOperations').fillPathTemplate,
+  discoverPathTemplateValues: jest.fn()
 }));
 
 jest.mock('../../../src/infrastructure/parsers/config-parsing/ConfigValidation', () => ({
@@ -136,7 +140,18 @@ describe('ConfigParser', () => {
         errors: ['Missing required field: files']
       });
       
-      expect(() => configParser.load()).toThrow('Configuration validation failed: Missing required field: files');
+      expect(() => configParser.load()).toThrow(/^Configuration validation failed: Missing required field: files$/);
+    });
+
+    it('should report unknown fields and reject them in strict mode', () => {
+      mockConfigFileOps.readFileSync.mockReturnValue({
+        success: true,
+        content: 'files:\n  - file1.yaml\nignore_key:\n  - temp\n'
+      });
+
+      expect(configParser.getUnknownFields()).toEqual([{ path: 'ignore_key', line: 3, column: 1 }]);
+      expect(() => new ConfigParser('test-config.yaml', { strict: true }).load())
+        .toThrow(/^Unknown field "ignore_key" at line 3, column 1/);
     });
   });
 
@@ -147,6 +162,25 @@ describe('ConfigParser', () => {
       expect(result).toEqual(['file1.yaml', 'file2.yaml']);
     });
 
+    it('should accept object entries and expand glob paths', () => {
+      mockConfig.files = [{ path: 'configs/*.conf', format: 'ini' }, 'base.yaml'];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      mockConfigFileOps.expandFileGlob.mockReturnValue(['configs/a.conf', 'configs/b.conf']);
+
+      const result = configParser.getFilesToCompare();
+
+      expect(mockConfigFileOps.expandFileGlob).toHaveBeenCalledWith('configs/*.conf');
+      expect(result).toEqual(['configs/a.conf', 'configs/b.conf', 'base.yaml']);
+    });
+
+    it('should throw error when glob entries match no files', () => {
+      mockConfig.files = ['configs/*.conf'];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      mockConfigFileOps.expandFileGlob.mockReturnValue([]);
+
+      expect(() => configParser.getFilesToCompare()).toThrow('No files matched the configured patterns: configs/*.conf');
+    });
+
     it('should return environment files when files array is empty', () => {
       mockConfig.files = [];
       mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
@@ -192,6 +226,58 @@ describe('ConfigParser', () => {
 
   });
 
+  describe('environment templates', () => {
+    beforeEach(() => {
+      mockConfig.environments = { dev: 'configs/{service}/dev.yaml', prod: 'configs/{service}/prod.yaml' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      // orders has no prod file yet
+      mockConfigFileOps.discoverPathTemplateValues.mockImplementation((template: string) =>
+        template.endsWith('dev.yaml') ? [{ service: 'orders' }, { service: 'billing' }] : [{ service: 'billing' }]);
+    });
+
+    it('should expand every environment for every discovered service', () => {
+      expect(configParser.getEnvironmentFiles()).toEqual([
+        'configs/billing/dev.yaml', 'configs/billing/prod.yaml', 'configs/orders/dev.yaml', 'configs/orders/prod.yaml',
+      ]);
+      expect(configParser.getEnvironmentFiles('prod')).toEqual(['configs/billing/prod.yaml', 'configs/orders/prod.yaml']);
+      expect(configParser.getEnvironmentFileMap()['configs/orders/prod.yaml']).toBe('prod');
+    });
+
+    it('should group the files of each service', () => {
+      expect(configParser.getGroups()).toEqual([
+        { name: 'billing', files: ['configs/billing/dev.yaml', 'configs/billing/prod.yaml'] },
+        { name: 'orders', files: ['configs/orders/dev.yaml', 'configs/orders/prod.yaml'] },
+      ]);
+      expect(configParser.getTemplateGroups('dev')).toEqual([
+        { name: 'billing', files: ['configs/billing/dev.yaml'] },
+        { name: 'orders', files: ['configs/orders/dev.yaml'] },
+      ]);
+      expect(configParser.getEnvironments()).toEqual({});
+    });
+
+    it('should list the expanded files of each environment', () => {
+      expect(configParser.getExpandedEnvironments()).toEqual({
+        dev: ['configs/billing/dev.yaml', 'configs/orders/dev.yaml'],
+        prod: ['configs/billing/prod.yaml', 'configs/orders/prod.yaml'],
+      });
+    });
+
+    it('should fail when no service is found', () => {
+      mockConfigFileOps.discoverPathTemplateValues.mockReturnValue([]);
+
+      expect(() => configParser.getEnvironmentFiles()).toThrow('No files matched the environment templates');
+    });
+
+    it('should fail when the templates use different placeholders', () => {
+      mockConfig.environments = { dev: 'configs/{env}.yaml', prod: 'configs/{env}-{region}.yaml' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(() => configParser.getEnvironmentFiles()).toThrow(
+        'Environment templates must all use the same placeholders: configs/{env}.yaml uses {env}; configs/{env}-{region}.yaml uses {env}, {region}'
+      );
+    });
+  });
+
   describe('getIgnoreKeys', () => {
     it('should return ignore keys array', () => {
       const result = configParser.getIgnoreKeys();
@@ -277,6 +363,348 @@ describe('ConfigParser', () => {
     });
   });
 
+  describe('user configuration', () => {
+    it('should merge the user configuration under the file', () => {
+      const parser = new ConfigParser('test-config.yaml', { userConfig: { output: 'json', color: false, forbidden_keys: ['debug'] } as any });
+
+      expect(parser.load()).toMatchObject({ output: 'json', color: false, forbidden_keys: ['password', 'secret'] });
+    });
+
+    it('should take the rule sets of the file, else those of the user configuration', () => {
+      expect(new ConfigParser('test-config.yaml', { userConfig: { ruleSets: ['kubernetes'] } }).getRuleSets()).toEqual(['kubernetes']);
+      expect(new ConfigParser('test-config.yaml').getRuleSets()).toBeUndefined();
+
+      mockConfig.ruleSets = ['budgets'];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(new ConfigParser('test-config.yaml', { userConfig: { ruleSets: ['kubernetes'] } }).getRuleSets()).toEqual(['budgets']);
+    });
+  });
+
+  describe('getForbiddenKeyRules', () => {
+    it('should apply plain patterns everywhere and entries to their environments', () => {
+      mockConfig.forbidden_keys = ['password', { keys: 'debug', environments: ['prod'] }];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getForbiddenKeyRules()).toEqual([
+        { keys: ['password'], environments: [] },
+        { keys: ['debug'], environments: ['prod'] },
+      ]);
+      expect(configParser.getForbiddenKeys()).toEqual(['password', 'debug']);
+    });
+  });
+
+  describe('getValueRules', () => {
+    it('should return the constraints of a rules mapping', () => {
+      mockConfig.rules = { 'database.port': { type: 'port', min: 1024 } };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getValueRules()).toEqual({ 'database.port': { type: 'port', min: 1024 } });
+    });
+
+    it('should leave a list of rules to the rule system', () => {
+      mockConfig.rules = [{ id: 'custom' }];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getValueRules()).toEqual({});
+    });
+  });
+
+  describe('getLimits', () => {
+    it('should map configured limits to camelCase', () => {
+      mockConfig.limits = { max_depth: 10, max_keys: 500 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      const result = configParser.getLimits();
+
+      expect(result).toEqual({ maxDepth: 10, maxKeys: 500 });
+    });
+
+    it('should return empty object when limits are not configured', () => {
+      const result = configParser.getLimits();
+
+      expect(result).toEqual({});
+    });
+  });
+
+  describe('getStreamAboveBytes', () => {
+    it('should convert the configured megabytes to bytes', () => {
+      mockConfig.limits = { stream_above_mb: 0.5 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getStreamAboveBytes()).toBe(524288);
+    });
+
+    it('should default to 64 MB', () => {
+      expect(configParser.getStreamAboveBytes()).toBe(64 * 1024 * 1024);
+    });
+  });
+
+  describe('getParsers', () => {
+    it('should return the enabled formats', () => {
+      mockConfig.parsers = ['yaml', 'json'];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getParsers()).toEqual(['yaml', 'json']);
+    });
+
+    it('should enable every format by default', () => {
+      expect(configParser.getParsers()).toBeUndefined();
+    });
+  });
+
+  describe('getEscalationRules', () => {
+    it('should wrap a single escalate object in a list', () => {
+      mockConfig.escalate = { environment: 'prod', from: 'warning', to: 'error' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getEscalationRules()).toEqual([{ environment: 'prod', from: 'warning', to: 'error' }]);
+    });
+
+    it('should return empty list when escalation is not configured', () => {
+      expect(configParser.getEscalationRules()).toEqual([]);
+    });
+  });
+
+  describe('getCanaries', () => {
+    it('should wrap a single canary in a list', () => {
+      mockConfig.canaries = { key: 'billing.token', value: 'pk_canary' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getCanaries()).toEqual([{ key: 'billing.token', value: 'pk_canary' }]);
+    });
+
+    it('should return empty list when no canaries are configured', () => {
+      expect(configParser.getCanaries()).toEqual([]);
+    });
+  });
+
+  describe('getLeakage', () => {
+    it('should be off unless configured', () => {
+      expect(configParser.getLeakage()).toBeUndefined();
+    });
+
+    it('should use the default tokens for `leakage: true` and keep configured ones', () => {
+      mockConfig.leakage = true;
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getLeakage()).toEqual({});
+
+      mockConfig.leakage = { tokens: { qa: ['qa', 'uat'] } };
+      expect(configParser.getLeakage()).toEqual({ tokens: { qa: ['qa', 'uat'] } });
+
+      mockConfig.leakage = { enabled: false, tokens: { qa: ['qa'] } };
+      expect(configParser.getLeakage()).toBeUndefined();
+    });
+  });
+
+  describe('getFeatureFlagSettings', () => {
+    it('should map the rollout threshold and default to no settings', () => {
+      expect(configParser.getFeatureFlagSettings()).toEqual({});
+
+      mockConfig.feature_flags = { rollout_threshold: 80 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getFeatureFlagSettings()).toEqual({ rolloutThreshold: 80 });
+    });
+  });
+
+  describe('getBudgets', () => {
+    it('should map budgets and keep amounts as written', () => {
+      expect(configParser.getBudgets()).toEqual([]);
+
+      mockConfig.budgets = { name: 'memory', keys: '*.resources.limits.memory', max_total: '64Gi' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getBudgets()).toEqual([{ name: 'memory', keys: ['*.resources.limits.memory'], maxTotal: '64Gi' }]);
+    });
+  });
+
+  describe('getLabelRules', () => {
+    it('should read labels entries, then labelled file entries, with values as strings', () => {
+      expect(configParser.getLabelRules()).toEqual([]);
+
+      mockConfig.labels = [{ groups: 'payments', labels: { tier: 'critical' } }];
+      mockConfig.files = ['app.yaml', { path: 'config/*.yaml', labels: { tier: 2 } }];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getLabelRules()).toEqual([
+        { files: [], groups: ['payments'], labels: { tier: 'critical' } },
+        { files: ['config/*.yaml'], groups: [], labels: { tier: '2' } }
+      ]);
+    });
+  });
+
+  describe('getOwners', () => {
+    it('should normalize keys and files to lists', () => {
+      expect(configParser.getOwners()).toEqual([]);
+
+      mockConfig.owners = [{ team: 'payments', keys: 'payments', channel: '#payments' }, { team: 'platform', files: ['infra/**'] }];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getOwners()).toEqual([
+        { team: 'payments', keys: ['payments'], files: [], channel: '#payments' },
+        { team: 'platform', keys: [], files: ['infra/**'] }
+      ]);
+    });
+  });
+
+  describe('getKeyOrderSettings', () => {
+    it('should be off unless configured and default to alphabetical order', () => {
+      expect(configParser.getKeyOrderSettings()).toBeUndefined();
+
+      mockConfig.key_order = { files: ['*.yaml'] };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getKeyOrderSettings()).toEqual({ order: 'alphabetical', files: ['*.yaml'] });
+
+      mockConfig.key_order = { order: ['name', 'version'] };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getKeyOrderSettings()).toEqual({ order: ['name', 'version'], files: [] });
+    });
+  });
+
+  describe('getFormatLintSettings', () => {
+    it('should map line endings and tab width', () => {
+      expect(configParser.getFormatLintSettings()).toBeUndefined();
+
+      mockConfig.format_lint = {};
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getFormatLintSettings()).toEqual({});
+
+      mockConfig.format_lint = { line_endings: 'crlf', tab_width: 4 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getFormatLintSettings()).toEqual({ lineEndings: 'crlf', tabWidth: 4 });
+    });
+  });
+
+  describe('getPerformanceSettings', () => {
+    it('should be off unless configured and list the keys of each category', () => {
+      expect(configParser.getPerformanceSettings()).toBeUndefined();
+
+      mockConfig.performance = { timeouts: { max: '60s', required: false }, pools: { keys: 'db.pool_size', max: 50 } };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getPerformanceSettings()).toEqual({
+        timeouts: { keys: [], max: '60s', required: false },
+        pools: { keys: ['db.pool_size'], max: 50 },
+        retries: { keys: [] },
+        caches: { keys: [] },
+      });
+    });
+  });
+
+  describe('getValueDifferenceRules', () => {
+    it('should list the key patterns and default the severity to warning', () => {
+      expect(configParser.getValueDifferenceRules()).toEqual([]);
+
+      mockConfig.value_differences = [{ keys: 'api.timeout', severity: 'error' }, { keys: ['features'] }];
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getValueDifferenceRules()).toEqual([
+        { keys: ['api.timeout'], severity: 'error' },
+        { keys: ['features'], severity: 'warning' },
+      ]);
+    });
+  });
+
+  describe('getCommentedConfigSettings', () => {
+    it('should be off unless configured and map the thresholds', () => {
+      expect(configParser.getCommentedConfigSettings()).toBeUndefined();
+
+      mockConfig.commented_config = { min_lines: 5 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getCommentedConfigSettings()).toEqual({ minLines: 5 });
+    });
+  });
+
+  describe('getCronSettings', () => {
+    it('should map scheduler keys and parse the minimum interval', () => {
+      expect(configParser.getCronSettings()).toEqual({ keys: [] });
+
+      mockConfig.cron = { keys: 'jobs.*.schedule', min_interval: '5m' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getCronSettings()).toEqual({ keys: ['jobs.*.schedule'], minIntervalMs: 300000 });
+    });
+  });
+
+  describe('getCloudIdentifiers', () => {
+    it('should map allow-lists per environment and read accounts as strings', () => {
+      expect(configParser.getCloudIdentifiers()).toEqual({});
+
+      mockConfig.cloud_identifiers = {
+        staging: { regions: ['eu-west-1'], accounts: [222233334444] as unknown as string[] },
+        production: { projects: ['acme-prod'] }
+      };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getCloudIdentifiers()).toEqual({
+        staging: { regions: ['eu-west-1'], accounts: ['222233334444'] },
+        production: { projects: ['acme-prod'] }
+      });
+    });
+  });
+
+  describe('getBrokerSettings', () => {
+    it('should map the brokers section and default to no settings', () => {
+      expect(configParser.getBrokerSettings()).toEqual({});
+
+      mockConfig.brokers = { consumer_group_pattern: '^[a-z-]+$', min_replication_factor: 2 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getBrokerSettings()).toEqual({ consumerGroupPattern: '^[a-z-]+$', minReplicationFactor: 2 });
+    });
+  });
+
+  describe('getRateLimitSettings', () => {
+    it('should stay off without endpoints or quotas', () => {
+      expect(configParser.getRateLimitSettings()).toBeUndefined();
+
+      mockConfig.rate_limits = { burst_factor: 3 };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+      expect(configParser.getRateLimitSettings()).toBeUndefined();
+    });
+
+    it('should map key paths to settings and accept single patterns', () => {
+      mockConfig.rate_limits = { endpoints: 'routes.*', limit: 'throttle.rate', burst_factor: 3, quotas: ['plans.*.quota'] };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getRateLimitSettings()).toEqual({
+        endpoints: ['routes.*'],
+        quotas: ['plans.*.quota'],
+        limit: 'throttle.rate',
+        burstFactor: 3
+      });
+    });
+  });
+
+  describe('getHttpSettings', () => {
+    it('should map the http section to camelCase settings', () => {
+      mockConfig.http = { timeout_ms: 5000, retries: 3, proxy: 'http://proxy:3128', ca_file: 'certs/ca.pem' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      expect(configParser.getHttpSettings()).toEqual({
+        timeoutMs: 5000,
+        retries: 3,
+        proxy: 'http://proxy:3128',
+        caFile: 'certs/ca.pem'
+      });
+    });
+
+    it('should return empty settings when http is not configured', () => {
+      expect(configParser.getHttpSettings()).toEqual({});
+    });
+  });
+
+  describe('getFormatOverrides', () => {
+    it('should list formats of file entries before the formats map', () => {
+      mockConfig.files = [{ path: 'configs/*.conf', format: 'ini' }, 'base.yaml'];
+      mockConfig.formats = { '*.conf': 'properties', '*.cfg': 'ini' };
+      mockConfigFileOps.parseYamlContent.mockReturnValue(mockConfig);
+
+      const result = configParser.getFormatOverrides();
+
+      expect(Object.entries(result)).toEqual([
+        ['configs/*.conf', 'ini'],
+        ['*.conf', 'properties'],
+        ['*.cfg', 'ini']
+      ]);
+    });
+
+    it('should return empty object when no formats are configured', () => {
+      expect(configParser.getFormatOverrides()).toEqual({});
+    });
+  });
+
   describe('getEnvironments', () => {
     it('should return environments object', () => {
       const result = configParser.getEnvironments();
@@ -0,0 +1,46 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import {
+  discoverPathTemplateValues,
+  fillPathTemplate,
+  pathPlaceholders,
+} from '../../../../src/infrastructure/parsers/config-parsing/ConfigFileOperations';
+
+describe('ConfigFileOperations', () => {
+  describe('path templates', () => {
+    let directory: string;
+
+    beforeEach(() => {
+      directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-templates-test-'));
+      ['billing', 'orders', 'search.v2'].forEach(service => fs.mkdirSync(path.join(directory, service)));
+      fs.writeFileSync(path.join(directory, 'billing', 'prod.yaml'), 'a: 1\n');
+      fs.writeFileSync(path.join(directory, 'search.v2', 'prod.yaml'), 'a: 1\n');
+      fs.writeFileSync(path.join(directory, 'orders', 'dev.yaml'), 'a: 1\n');
+    });
+
+    afterEach(() => {
+      fs.rmSync(directory, { recursive: true, force: true });
+    });
+
+    it('should list and fill placeholders', () => {
+      expect(pathPlaceholders('configs/{service}/{env}-{service}.yaml')).toEqual(['service', 'env']);
+      expect(pathPlaceholders('configs/prod.yaml')).toEqual([]);
+      expect(fillPathTemplate('configs/{service}/{env}.yaml', { service: 'billing' })).toBe('configs/billing/{env}.yaml');
+    });
+
+    it('should discover the placeholder values of existing files', () => {
+      const template = `${directory}/{service}/prod.yaml`.replace(/\\/g, '/');
+
+      expect(discoverPathTemplateValues(template)).toEqual([{ service: 'billing' }, { service: 'search.v2' }]);
+    });
+
+    it('should give a repeated placeholder the same value', () => {
+      fs.writeFileSync(path.join(directory, 'billing', 'billing.yaml'), 'a: 1\n');
+      fs.writeFileSync(path.join(directory, 'orders', 'billing.yaml'), 'a: 1\n');
+      const template = `${directory}/{service}/{service}.yaml`.replace(/\\/g, '/');
+
+      expect(discoverPathTemplateValues(template)).toEqual([{ service: 'billing' }]);
+    });
+  });
+});
@@ -0,0 +1,54 @@
+import {
+  CONFIG_MIGRATIONS,
+  migrateConfigContent,
+  pendingMigrations
+} from '../../../../src/infrastructure/parsers/config-parsing/ConfigMigrations';
+import { CONFIG_SCHEMA_VERSION } from '../../../../src/infrastructure/parsers/config-parsing/ConfigSchema';
+import { validateVersionSection } from '../../../../src/infrastructure/parsers/config-parsing/ConfigValidation';
+
+describe('ConfigMigrations', () => {
+  it('should list migrations from the current version onwards', () => {
+    expect(pendingMigrations(0)).toEqual(CONFIG_MIGRATIONS);
+    expect(pendingMigrations(CONFIG_SCHEMA_VERSION)).toEqual([]);
+  });
+
+  it('should declare the schema version of unversioned files and keep comments', () => {
+    const result = migrateConfigContent('# Team settings\n\nfiles:\n  - a.yaml # base\n');
+
+    expect(result.from).toBe(0);
+    expect(result.to).toBe(1);
+    expect(result.applied).toHaveLength(1);
+    expect(result.content).toContain('# Team settings');
+    expect(result.content).toContain('# base');
+    expect(result.content.indexOf('version: 1')).toBeLessThan(result.content.indexOf('files:'));
+  });
+
+  it('should replace legacy version labels in place', () => {
+    const result = migrateConfigContent('files:\n  - a.yaml\nversion: "1.0.0"\n');
+
+    expect(result.content).toBe('files:\n  - a.yaml\nversion: 1\n');
+  });
+
+  it('should leave current files untouched', () => {
+    const content = 'version: 1\nfiles:\n  - a.yaml\n';
+
+    expect(migrateConfigContent(content)).toEqual({ content, from: 1, to: 1, applied: [] });
+  });
+
+  it('should refuse files written for a newer schema', () => {
+    expect(() => migrateConfigContent('version: 99\nfiles: [a.yaml]\n')).toThrow('upgrade praetorian');
+  });
+
+  it('should validate numeric schema versions only', () => {
+    const errorsFor = (version: unknown) => {
+      const errors: string[] = [];
+      validateVersionSection({ files: ['a.yaml'], version } as any, errors);
+      return errors;
+    };
+
+    expect(errorsFor(1)).toEqual([]);
+    expect(errorsFor('1.0.0')).toEqual([]);
+    expect(errorsFor(0)).toEqual(['"version" must be a positive integer']);
+    expect(errorsFor(CONFIG_SCHEMA_VERSION + 1)[0]).toContain('newer than the supported version');
+  });
+});
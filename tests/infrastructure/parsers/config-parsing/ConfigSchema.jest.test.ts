@@ -0,0 +1,74 @@
+import {
+  findUnknownConfigFields,
+  formatUnknownConfigField
+} from '../../../../src/infrastructure/parsers/config-parsing/ConfigSchema';
+
+describe('ConfigSchema', () => {
+  it('should accept every documented section', () => {
+    const content = [
+      'files:',
+      '  - base.yaml',
+      '  - path: settings.conf',
+      '    format: ini',
+      'environments:',
+      '  dev: config-dev.yaml',
+      'limits:',
+      '  max_depth: 10',
+      'escalate:',
+      '  environment: prod',
+      '  from: warning',
+      '  to: error',
+      'http:',
+      '  timeout_ms: 5000',
+      'messages:',
+      '  MISSING_KEY: "{{key}} is missing"',
+      'ruleSets:',
+      '  - "@praetorian/core/all"',
+    ].join('\n');
+
+    expect(findUnknownConfigFields(content)).toEqual([]);
+  });
+
+  it('should report misspelled top-level and nested fields with their location', () => {
+    const content = [
+      'files:',
+      '  - path: settings.conf',
+      '    fromat: ini',
+      'http:',
+      '  timout_ms: 5000',
+      'ignore_key:',
+      '  - debug',
+    ].join('\n');
+
+    expect(findUnknownConfigFields(content)).toEqual([
+      { path: 'files[0].fromat', line: 3, column: 5 },
+      { path: 'http.timout_ms', line: 5, column: 3 },
+      { path: 'ignore_key', line: 6, column: 1 },
+    ]);
+  });
+
+  it('should check every rule of an escalate list', () => {
+    const content = [
+      'escalate:',
+      '  - environment: prod',
+      '    from: warning',
+      '    to: error',
+      '  - environment: staging',
+      '    form: info',
+      '    to: warning',
+    ].join('\n');
+
+    expect(findUnknownConfigFields(content).map(field => field.path)).toEqual(['escalate[1].form']);
+  });
+
+  it('should leave free-form maps and invalid YAML alone', () => {
+    expect(findUnknownConfigFields('schema:\n  any.key: number\n')).toEqual([]);
+    expect(findUnknownConfigFields('files: [unclosed\n')).toEqual([]);
+    expect(findUnknownConfigFields('')).toEqual([]);
+  });
+
+  it('should format unknown fields for messages', () => {
+    expect(formatUnknownConfigField({ path: 'http.timout_ms', line: 5, column: 3 }))
+      .toBe('Unknown field "http.timout_ms" at line 5, column 3');
+  });
+});
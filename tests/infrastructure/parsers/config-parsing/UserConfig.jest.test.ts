@@ -0,0 +1,80 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import {
+  loadUserConfig,
+  mergeUserConfig,
+  settingOrigins,
+  userConfigPath,
+  validateUserConfig
+} from '../../../../src/infrastructure/parsers/config-parsing/UserConfig';
+
+describe('UserConfig', () => {
+  let configHome: string;
+
+  beforeEach(() => {
+    configHome = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-user-config-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(configHome, { recursive: true, force: true });
+  });
+
+  const write = (content: string): string => {
+    const filePath = userConfigPath({ XDG_CONFIG_HOME: configHome });
+    fs.mkdirSync(path.dirname(filePath), { recursive: true });
+    fs.writeFileSync(filePath, content);
+    return filePath;
+  };
+
+  it('should live under XDG_CONFIG_HOME', () => {
+    expect(userConfigPath({ XDG_CONFIG_HOME: '/cfg' })).toBe(path.join('/cfg', 'praetorian', 'config.yaml'));
+    expect(userConfigPath({})).toBe(path.join(os.homedir(), '.config', 'praetorian', 'config.yaml'));
+  });
+
+  it('should read the defaults of the user', () => {
+    const filePath = write('output: json\ncolor: false\nconcurrency: 4\ntelemetry: false\nruleSets:\n  - kubernetes\n');
+
+    expect(loadUserConfig(filePath)).toEqual({
+      output: 'json',
+      color: false,
+      concurrency: 4,
+      telemetry: false,
+      ruleSets: ['kubernetes'],
+    });
+  });
+
+  it('should have no defaults without a file or with an empty one', () => {
+    expect(loadUserConfig(path.join(configHome, 'missing.yaml'))).toEqual({});
+    expect(loadUserConfig(write(''))).toEqual({});
+  });
+
+  it('should reject unknown and mistyped fields', () => {
+    expect(validateUserConfig({ output: 'json', colour: false, concurrency: 0, telemetry: 'yes', ruleSets: 'kubernetes' })).toEqual([
+      '"colour" is not a user configuration field (expected one of: output, color, concurrency, telemetry, ruleSets)',
+      '"concurrency" must be a positive integer',
+      '"telemetry" must be a boolean',
+      '"ruleSets" must be an array of rule pack names or paths',
+    ]);
+    expect(validateUserConfig(['output'])).toHaveLength(1);
+    expect(() => loadUserConfig(write('color: maybe\n'))).toThrow(/Invalid user configuration .*"color" must be a boolean/);
+  });
+
+  it('should let the project configuration win', () => {
+    expect(mergeUserConfig(
+      { output: 'json', color: false, ruleSets: ['kubernetes'] },
+      { files: ['a.yaml'], color: true, ruleSets: ['budgets'] } as any
+    )).toEqual({ output: 'json', color: true, ruleSets: ['budgets'], files: ['a.yaml'] });
+  });
+
+  it('should name the layer each setting comes from', () => {
+    expect(settingOrigins([
+      { source: '~/.config/praetorian/config.yaml', config: { output: 'json', color: false } },
+      { source: 'praetorian.yaml', config: { files: ['a.yaml'], color: true } },
+    ])).toEqual({
+      output: '~/.config/praetorian/config.yaml',
+      color: 'praetorian.yaml',
+      files: 'praetorian.yaml',
+    });
+  });
+});
@@ -0,0 +1,24 @@
+import { CloudIdentityAuditor } from '../../../src/infrastructure/plugins/CloudIdentityAuditor';
+
+describe('CloudIdentityAuditor', () => {
+  it('should fail files that share an account with another environment', async () => {
+    const result = await new CloudIdentityAuditor().audit({
+      files: { 'config/staging.yaml': { aws: { account_id: '555566667777' } }, 'config/prod.yaml': { aws: { account_id: '555566667777' } } }
+    });
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.context?.file)).toEqual(['config/staging.yaml']);
+    expect(result.metadata).toMatchObject({ auditType: 'cloud-identifiers', rulesChecked: 2, rulesPassed: 1, rulesFailed: 1 });
+  });
+
+  it('should check the context environment against its allow-list', async () => {
+    const result = await new CloudIdentityAuditor().audit({
+      environment: 'staging',
+      cloudIdentifiers: { staging: { regions: ['eu-west-1'] } },
+      files: { 'app.yaml': { aws: { region: 'us-east-1' } } }
+    });
+
+    expect(result.errors[0].code).toBe('CLOUD_IDENTIFIER_NOT_ALLOWED');
+    expect(result.errors[0].context).toMatchObject({ environment: 'staging', extras: { kind: 'region', value: 'us-east-1' } });
+  });
+});
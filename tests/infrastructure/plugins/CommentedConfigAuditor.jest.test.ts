@@ -0,0 +1,32 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { CommentedConfigAuditor } from '../../../src/infrastructure/plugins/CommentedConfigAuditor';
+
+describe('CommentedConfigAuditor', () => {
+  it('should fail files whose commented-out blocks still set a secret', async () => {
+    const result = await new CommentedConfigAuditor().audit({
+      files: { 'in-memory.yaml': {} },
+      comments: { 'in-memory.yaml': [{ line: 1, text: 'db_password: hunter2' }, { line: 2, text: 'db_user: app' }, { line: 3, text: '' }] }
+    });
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.code)).toEqual(['COMMENTED_OUT_SECRET']);
+    expect(result.metadata).toMatchObject({ auditType: 'commented-config', rulesChecked: 1, rulesPassed: 0, rulesFailed: 1 });
+  });
+
+  it('should read comments from disk with the syntax of the file format', async () => {
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-comments-'));
+    const file = path.join(dir, 'app.properties');
+    fs.writeFileSync(file, 'server.port=8080\n! server.host=old\n! server.timeout=30\n! server.mode=legacy\n');
+
+    try {
+      const result = await new CommentedConfigAuditor().audit({ files: { [file]: {}, 'missing.yaml': {} }, commentedConfig: { minLines: 3 } });
+
+      expect(result.success).toBe(true);
+      expect(result.warnings.map(warning => [warning.code, warning.context?.line])).toEqual([['COMMENTED_OUT_CONFIG', 2]]);
+    } finally {
+      fs.rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
@@ -0,0 +1,22 @@
+import { EnvironmentLeakageAuditor } from '../../../src/infrastructure/plugins/EnvironmentLeakageAuditor';
+
+describe('EnvironmentLeakageAuditor', () => {
+  it('should warn about leaking values without failing the audit', async () => {
+    const result = await new EnvironmentLeakageAuditor().audit({
+      files: { 'config/dev.yaml': { db: { host: 'prod-db.internal' } }, 'config/prod.yaml': { db: { host: 'prod-db.internal' } } }
+    });
+
+    expect(result.success).toBe(true);
+    expect(result.warnings.map(warning => warning.context?.file)).toEqual(['config/dev.yaml']);
+    expect(result.metadata).toMatchObject({ auditType: 'leakage', rulesChecked: 2 });
+  });
+
+  it('should apply the context environment to every file', async () => {
+    const result = await new EnvironmentLeakageAuditor().audit({
+      environment: 'prod',
+      files: { 'app.yaml': { cache: 'redis://localhost:6379' } }
+    });
+
+    expect(result.warnings[0].context).toMatchObject({ environment: 'prod', extras: { suspectedEnvironment: 'dev' } });
+  });
+});
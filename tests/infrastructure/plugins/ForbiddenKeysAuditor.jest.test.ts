@@ -0,0 +1,40 @@
+import { ForbiddenKeysAuditor } from '../../../src/infrastructure/plugins/ForbiddenKeysAuditor';
+import { ValidationContext } from '../../../src/shared/types';
+
+describe('ForbiddenKeysAuditor', () => {
+  const context = (extra: Partial<ValidationContext> = {}): ValidationContext => ({
+    strict: false,
+    ignoreKeys: [],
+    requiredKeys: [],
+    files: {
+      'a.yaml': { debug: true, root_password: 'x' },
+      'b.yaml': { port: 80 },
+    },
+    forbiddenKeys: [
+      { keys: ['root_password'], environments: [] },
+      { keys: ['debug'], environments: ['prod'] },
+    ],
+    ...extra
+  });
+
+  it('should report the keys forbidden everywhere', async () => {
+    const result = await new ForbiddenKeysAuditor().audit(context());
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.path)).toEqual(['root_password']);
+    expect(result.metadata).toEqual({ auditType: 'forbidden-keys', rulesChecked: 2, rulesPassed: 1, rulesFailed: 1 });
+  });
+
+  it('should apply the rules of the environment of the context', async () => {
+    const result = await new ForbiddenKeysAuditor().audit(context({ environment: 'prod' }));
+
+    expect(result.errors.map(error => error.path)).toEqual(['debug', 'root_password']);
+  });
+
+  it('should pass without forbidden keys', async () => {
+    const result = await new ForbiddenKeysAuditor().audit(context({ forbiddenKeys: undefined }));
+
+    expect(result.success).toBe(true);
+    expect(result.errors).toEqual([]);
+  });
+});
@@ -0,0 +1,25 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { FormatLintAuditor } from '../../../src/infrastructure/plugins/FormatLintAuditor';
+
+describe('FormatLintAuditor', () => {
+  it('should lint the files on disk with their format and skip in-memory files', async () => {
+    const dir = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-format-'));
+    const yamlFile = path.join(dir, 'app.yaml');
+    const envFile = path.join(dir, '.env');
+    fs.writeFileSync(yamlFile, 'server:\n\tport: 80\n');
+    fs.writeFileSync(envFile, 'PORT=80');
+
+    try {
+      const result = await new FormatLintAuditor().audit({ files: { [yamlFile]: {}, [envFile]: {}, 'memory.yaml': {} } });
+
+      expect(result.success).toBe(false);
+      expect(result.errors.map(error => [error.code, error.context?.file])).toEqual([['FORMAT_TAB_INDENTATION', yamlFile]]);
+      expect(result.warnings.map(warning => [warning.code, warning.context?.file])).toEqual([['FORMAT_MISSING_FINAL_NEWLINE', envFile]]);
+      expect(result.metadata).toMatchObject({ auditType: 'format-lint', rulesChecked: 2, rulesPassed: 1, rulesFailed: 1 });
+    } finally {
+      fs.rmSync(dir, { recursive: true, force: true });
+    }
+  });
+});
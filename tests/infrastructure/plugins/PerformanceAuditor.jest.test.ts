@@ -22,10 +22,7 @@ describe('PerformanceAuditor', () => {
       expect(result).toBeDefined();
       expect(result.success).toBe(true);
       expect(result.errors).toEqual([]);
-      expect(result.warnings).toHaveLength(1);
-      expect(result.warnings[0].code).toBe('PERFORMANCE_AUDIT_NOT_IMPLEMENTED');
-      expect(result.warnings[0].message).toBe('Performance audit not implemented yet');
-      expect(result.warnings[0].severity).toBe('warning');
+      expect(result.warnings).toEqual([]);
       expect(result.metadata).toEqual({
         auditType: 'performance',
         rulesChecked: 0,
@@ -41,13 +38,32 @@ describe('PerformanceAuditor', () => {
       expect(result.errors).toHaveLength(0);
     });
 
-    it('should return success true when no errors (current implementation)', async () => {
-      // Current implementation always returns success=true since private methods are not called
-      const result = await performanceAuditor.audit(mockContext);
-      
+    it('should warn about the foot-guns of every file', async () => {
+      const result = await performanceAuditor.audit({
+        ...mockContext,
+        files: {
+          'prod.yaml': { database: { pool_size: 0 }, client: { max_retries: -1 } },
+          'dev.yaml': { database: { pool_size: 5 } }
+        }
+      });
+
       expect(result.success).toBe(true);
       expect(result.errors).toHaveLength(0);
-      expect(result.warnings).toHaveLength(1);
+      expect(result.warnings.map(warning => `${warning.code} ${warning.context?.file}`)).toEqual([
+        'PERF_POOL_EMPTY prod.yaml',
+        'PERF_RETRIES_UNBOUNDED prod.yaml'
+      ]);
+      expect(result.metadata).toMatchObject({ rulesChecked: 2, rulesPassed: 2, rulesFailed: 0 });
+    });
+
+    it('should apply the performance settings of the context', async () => {
+      const result = await performanceAuditor.audit({
+        ...mockContext,
+        files: { 'prod.yaml': { client: { retries: 6 } } },
+        performance: { retries: { keys: [], max: 5 } }
+      });
+
+      expect(result.warnings.map(warning => warning.code)).toEqual(['PERF_VALUE_TOO_HIGH']);
     });
 
     it('should handle empty context', async () => {
@@ -130,7 +146,7 @@ describe('PerformanceAuditor', () => {
       expect(results).toHaveLength(3);
       results.forEach(result => {
         expect(result.success).toBe(true);
-        expect(result.warnings).toHaveLength(1);
+        expect(result.warnings).toHaveLength(0);
       });
     });
   });
@@ -275,6 +291,12 @@ describe('PerformanceAuditor', () => {
     });
 
     describe('checkTimeoutConfig', () => {
+      it('should report a timeout of 0', () => {
+        const result = (performanceAuditor as any).checkTimeoutConfig({ api: { timeout: 0 } });
+
+        expect(result.map((finding: any) => finding.code)).toEqual(['PERF_TIMEOUT_UNBOUNDED']);
+      });
+
       it('should return empty array for empty config', () => {
         const config = {};
         const result = (performanceAuditor as any).checkTimeoutConfig(config);
@@ -414,7 +436,7 @@ describe('PerformanceAuditor', () => {
       const result = await performanceAuditor.audit(mockContext);
       
       expect(result.metadata!.auditType).toBe('performance');
-      expect(result.warnings[0].code).toBe('PERFORMANCE_AUDIT_NOT_IMPLEMENTED');
+      expect(result.warnings).toHaveLength(0);
     });
   });
 });
@@ -0,0 +1,35 @@
+import { SchemaRulesAuditor } from '../../../src/infrastructure/plugins/SchemaRulesAuditor';
+import { ValidationContext } from '../../../src/shared/types';
+
+describe('SchemaRulesAuditor', () => {
+  const context = (extra: Partial<ValidationContext> = {}): ValidationContext => ({
+    strict: false,
+    ignoreKeys: [],
+    requiredKeys: [],
+    files: {
+      'a.yaml': { database: { port: 80 } },
+      'b.yaml': { database: { port: 5432 }, log: { level: 'loud' } },
+    },
+    valueRules: {
+      'database.port': { min: 1024 },
+      'log.level': { enum: ['info', 'debug'], severity: 'warning' },
+    },
+    ...extra
+  });
+
+  it('should report the values breaking their constraints', async () => {
+    const result = await new SchemaRulesAuditor().audit(context());
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => `${error.context?.file} ${error.path}`)).toEqual(['a.yaml database.port']);
+    expect(result.warnings.map(warning => warning.code)).toEqual(['RULE_VALUE_NOT_ALLOWED']);
+    expect(result.metadata).toEqual({ auditType: 'schema-rules', rulesChecked: 2, rulesPassed: 1, rulesFailed: 1 });
+  });
+
+  it('should pass without value rules', async () => {
+    const result = await new SchemaRulesAuditor().audit(context({ valueRules: undefined }));
+
+    expect(result.success).toBe(true);
+    expect(result.errors).toEqual([]);
+  });
+});
@@ -0,0 +1,46 @@
+import { ValueAuditor } from '../../../src/infrastructure/plugins/ValueAuditor';
+import { ValidationContext } from '../../../src/shared/types';
+
+describe('ValueAuditor', () => {
+  const context = (extra: Partial<ValidationContext> = {}): ValidationContext => ({
+    strict: false,
+    ignoreKeys: [],
+    requiredKeys: [],
+    files: {
+      'staging.yaml': { api: { timeout: 30 }, log: { level: 'debug' } },
+      'prod.yaml': { api: { timeout: 60 }, log: { level: 'warn' } },
+    },
+    ...extra
+  });
+
+  it('should compare nothing without value_differences, like validate', async () => {
+    const result = await new ValueAuditor().audit(context());
+
+    expect(result.success).toBe(true);
+    expect(result.warnings).toEqual([]);
+    expect(result.metadata).toEqual({ auditType: 'value-differences', rulesChecked: 2, rulesPassed: 2, rulesFailed: 0 });
+  });
+
+  it('should report the differences of the configured keys as warnings', async () => {
+    const result = await new ValueAuditor().audit(context({ valueDifferences: [{ keys: ['*'], severity: 'warning' }] }));
+
+    expect(result.success).toBe(true);
+    expect(result.warnings.map(warning => warning.path)).toEqual(['api.timeout', 'log.level']);
+  });
+
+  it('should apply the configured patterns and severities', async () => {
+    const result = await new ValueAuditor().audit(context({ valueDifferences: [{ keys: ['api'], severity: 'error' }] }));
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.path)).toEqual(['api.timeout']);
+    expect(result.warnings).toEqual([]);
+    expect(result.metadata).toMatchObject({ rulesPassed: 0, rulesFailed: 2 });
+  });
+
+  it('should pass without files', async () => {
+    const result = await new ValueAuditor().audit({ strict: false, ignoreKeys: [], requiredKeys: [] });
+
+    expect(result.success).toBe(true);
+    expect(result.errors).toEqual([]);
+  });
+});
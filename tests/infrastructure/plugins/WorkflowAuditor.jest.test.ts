@@ -0,0 +1,17 @@
+import { WorkflowAuditor } from '../../../src/infrastructure/plugins/WorkflowAuditor';
+
+describe('WorkflowAuditor', () => {
+  it('should audit workflows only and fail on findings', async () => {
+    const result = await new WorkflowAuditor().audit({
+      files: {
+        '.github/workflows/ci.yml': { on: 'push', jobs: { build: { steps: [{ uses: 'actions/checkout@v4' }] } } },
+        '.github/workflows/lint.yml': { on: 'push', permissions: {}, jobs: { lint: { steps: [{ run: 'npm run lint' }] } } },
+        'config.yaml': { password: 'not a workflow' },
+      }
+    });
+
+    expect(result.success).toBe(false);
+    expect(result.errors.map(error => error.code)).toEqual(['WORKFLOW_PERMISSIONS_MISSING', 'WORKFLOW_ACTION_NOT_PINNED']);
+    expect(result.metadata).toMatchObject({ auditType: 'workflows', rulesChecked: 2, rulesPassed: 1, rulesFailed: 1 });
+  });
+});
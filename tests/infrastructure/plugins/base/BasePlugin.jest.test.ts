@@ -401,8 +401,7 @@ describe('BasePlugin', () => {
       expect(result.errors.length).toBeGreaterThan(0); // At least one rule should fail
       expect(result.errors[0].code).toBe('PLUGIN_ERROR');
       expect(result.errors[0].message).toContain('failed to execute rule');
-      expect(result.errors[0].context.ruleId).toBe('test-rule-1');
-      expect(result.errors[0].context.plugin).toBe('TestPlugin');
+      expect(result.errors[0].context.rule).toEqual({ id: 'test-rule-1', plugin: 'TestPlugin' });
     });
   });
 
@@ -0,0 +1,111 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import * as zlib from 'zlib';
+import {
+  cpuProfileToData,
+  encodeProfile,
+  encodeVarint,
+  heapProfileToData,
+  toPprof
+} from '../../../src/infrastructure/profiling/Pprof';
+import { RunProfiler } from '../../../src/infrastructure/profiling/Profiler';
+
+const frame = (functionName: string, lineNumber: number = 0) => ({ functionName, url: 'file:///app.js', lineNumber });
+
+describe('Pprof', () => {
+  it('should encode varints with continuation bits', () => {
+    expect([...encodeVarint(1)]).toEqual([1]);
+    expect([...encodeVarint(300)]).toEqual([0xac, 0x02]);
+    expect([...encodeVarint(0)]).toEqual([0]);
+    expect(encodeVarint(1760000000000000000n).length).toBe(9);
+  });
+
+  it('should attribute CPU samples and time to full stacks', () => {
+    const data = cpuProfileToData({
+      nodes: [
+        { id: 1, callFrame: frame('(root)'), children: [2] },
+        { id: 2, callFrame: frame('main', 9), children: [3] },
+        { id: 3, callFrame: frame('parse', 19) }
+      ],
+      startTime: 0,
+      endTime: 300,
+      samples: [3, 3, 2],
+      timeDeltas: [0, 100, 200]
+    });
+
+    expect(data.sampleTypes.map(type => type.type)).toEqual(['samples', 'cpu']);
+    expect(data.samples).toEqual([
+      {
+        stack: [
+          { name: 'parse', file: 'file:///app.js', line: 20 },
+          { name: 'main', file: 'file:///app.js', line: 10 }
+        ],
+        values: [2, 300000]
+      },
+      { stack: [{ name: 'main', file: 'file:///app.js', line: 10 }], values: [1, 0] }
+    ]);
+    expect(data.durationNanos).toBe(300000);
+  });
+
+  it('should keep heap nodes that allocated memory', () => {
+    const data = heapProfileToData({
+      head: {
+        callFrame: frame('(root)'),
+        selfSize: 0,
+        children: [{ callFrame: frame(''), selfSize: 2048, children: [] }]
+      }
+    });
+
+    expect(data.samples).toEqual([
+      { stack: [{ name: '(anonymous)', file: 'file:///app.js', line: 1 }], values: [2048] }
+    ]);
+    expect(data.periodType).toEqual({ type: 'space', unit: 'bytes' });
+  });
+
+  it('should intern strings and gzip the protobuf', () => {
+    const data = heapProfileToData({
+      head: { callFrame: frame('loadConfig'), selfSize: 64, children: [] }
+    });
+    const encoded = encodeProfile(data);
+    const gzipped = toPprof(data);
+
+    expect(encoded.includes(Buffer.from('loadConfig'))).toBe(true);
+    expect(gzipped.subarray(0, 2)).toEqual(Buffer.from([0x1f, 0x8b]));
+    expect(zlib.gunzipSync(gzipped)).toEqual(encoded);
+  });
+
+  describe('RunProfiler', () => {
+    let outputDir: string;
+
+    beforeEach(() => {
+      outputDir = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-profile-'));
+    });
+
+    afterEach(() => {
+      fs.rmSync(outputDir, { recursive: true, force: true });
+    });
+
+    it('should do nothing when no profile is requested', async () => {
+      const profiler = new RunProfiler({});
+
+      await profiler.start();
+
+      expect(profiler.isActive()).toBe(false);
+      expect(await profiler.stop()).toEqual([]);
+    });
+
+    it('should write CPU and heap profiles', async () => {
+      const cpu = path.join(outputDir, 'cpu.pb.gz');
+      const mem = path.join(outputDir, 'nested', 'mem.pb.gz');
+      const profiler = new RunProfiler({ cpu, mem });
+
+      await profiler.start();
+      Array.from({ length: 1000 }, (_, index) => ({ index, label: `key-${index}` }));
+
+      expect(await profiler.stop()).toEqual([cpu, mem]);
+      expect(fs.readFileSync(cpu).subarray(0, 2)).toEqual(Buffer.from([0x1f, 0x8b]));
+      expect(fs.existsSync(mem)).toBe(true);
+    });
+  });
+});
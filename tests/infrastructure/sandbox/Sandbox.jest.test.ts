@@ -0,0 +1,132 @@
+import { execFileSync } from 'child_process';
+import * as fs from 'fs';
+import * as http from 'http';
+import * as os from 'os';
+import * as path from 'path';
+import { isInsideDirectory, isWriteFlag, Sandbox } from '../../../src/infrastructure/sandbox/Sandbox';
+import { EXIT_CODES, SandboxViolationError } from '../../../src/shared/utils/ExitCodes';
+
+describe('Sandbox', () => {
+  let directory: string;
+  let outside: string;
+  let sandbox: Sandbox | undefined;
+
+  beforeEach(() => {
+    directory = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-sandbox-test-'));
+    outside = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-outside-test-'));
+  });
+
+  afterEach(() => {
+    sandbox?.dispose();
+    sandbox = undefined;
+    fs.rmSync(directory, { recursive: true, force: true });
+    fs.rmSync(outside, { recursive: true, force: true });
+  });
+
+  describe('isInsideDirectory', () => {
+    it('should accept the directory and paths below it only', () => {
+      expect(isInsideDirectory('/tmp/box', '/tmp/box')).toBe(true);
+      expect(isInsideDirectory('/tmp/box/a/b.json', '/tmp/box')).toBe(true);
+      expect(isInsideDirectory('/tmp/box/../etc/passwd', '/tmp/box')).toBe(false);
+      expect(isInsideDirectory('/tmp/boxed', '/tmp/box')).toBe(false);
+    });
+  });
+
+  describe('isWriteFlag', () => {
+    it('should tell writing open flags from reading ones', () => {
+      expect(isWriteFlag('r')).toBe(false);
+      expect(isWriteFlag(undefined)).toBe(false);
+      expect(isWriteFlag('w')).toBe(true);
+      expect(isWriteFlag('r+')).toBe(true);
+      expect(isWriteFlag('a')).toBe(true);
+      expect(isWriteFlag(fs.constants.O_RDONLY)).toBe(false);
+      expect(isWriteFlag(fs.constants.O_WRONLY | fs.constants.O_CREAT)).toBe(true);
+    });
+  });
+
+  it('should allow reads anywhere and writes inside its directory', () => {
+    fs.writeFileSync(path.join(outside, 'config.yaml'), 'a: 1\n');
+    sandbox = new Sandbox({ writableDir: directory }).install();
+
+    expect(fs.readFileSync(path.join(outside, 'config.yaml'), 'utf8')).toBe('a: 1\n');
+    fs.mkdirSync(path.join(directory, 'nested', 'deeper'), { recursive: true });
+    fs.writeFileSync(path.join(directory, 'nested', 'deeper', 'result.json'), '{}');
+
+    expect(sandbox.getViolations()).toEqual([]);
+    expect(() => sandbox!.assertClean()).not.toThrow();
+  });
+
+  it('should refuse and record writes outside its directory', async () => {
+    sandbox = new Sandbox({ writableDir: directory }).install();
+    const target = path.join(outside, 'written.txt');
+
+    expect(() => fs.writeFileSync(target, 'x')).toThrow(SandboxViolationError);
+    expect(() => fs.openSync(target, 'a')).toThrow(SandboxViolationError);
+    expect(() => fs.renameSync(path.join(directory, 'a'), target)).toThrow(SandboxViolationError);
+    await expect(fs.promises.writeFile(target, 'x')).rejects.toThrow(SandboxViolationError);
+
+    expect(fs.existsSync(target)).toBe(false);
+    expect(sandbox.getViolations()).toHaveLength(4);
+  });
+
+  it('should allow the files it was asked to write outside its directory', () => {
+    const profile = path.join(outside, 'profiles', 'cpu.pb.gz');
+    sandbox = new Sandbox({ writableDir: directory, writableFiles: [profile] }).install();
+
+    fs.mkdirSync(path.dirname(profile), { recursive: true });
+    fs.writeFileSync(profile, 'x');
+    expect(() => fs.writeFileSync(path.join(outside, 'profiles', 'other.txt'), 'x')).toThrow(SandboxViolationError);
+    expect(() => fs.rmSync(path.join(outside, 'profiles'), { recursive: true })).toThrow(SandboxViolationError);
+
+    expect(fs.readFileSync(profile, 'utf8')).toBe('x');
+    expect(sandbox.getViolations()).toHaveLength(2);
+  });
+
+  it('should fail on a refused write even when its error was caught', () => {
+    sandbox = new Sandbox({ writableDir: directory }).install();
+
+    try {
+      fs.writeFileSync(path.join(outside, 'swallowed.txt'), 'x');
+    } catch {
+      // The caller carries on
+    }
+
+    const violation = sandbox.violation();
+    expect(violation?.exitCode).toBe(EXIT_CODES.SANDBOX);
+    expect(violation?.message).toContain('swallowed.txt');
+    expect(() => sandbox!.assertClean()).toThrow(SandboxViolationError);
+  });
+
+  it('should refuse child processes', () => {
+    sandbox = new Sandbox({ writableDir: directory }).install();
+
+    expect(() => execFileSync('node', ['--version'])).toThrow(SandboxViolationError);
+    expect(sandbox.getViolations()[0]).toContain('child process node');
+  });
+
+  it('should refuse network connections unless allowed', () => {
+    sandbox = new Sandbox({ writableDir: directory }).install();
+
+    expect(() => http.get('http://127.0.0.1:9/')).toThrow(SandboxViolationError);
+    expect(sandbox.getViolations()[0]).toContain('network connection to 127.0.0.1:9');
+  });
+
+  it('should send temp files to its directory and restore everything once disposed', () => {
+    const previous = process.env.TMPDIR;
+    sandbox = new Sandbox({ writableDir: directory }).install();
+
+    expect(os.tmpdir()).toBe(fs.realpathSync(directory));
+
+    sandbox.dispose();
+    expect(process.env.TMPDIR).toBe(previous);
+    fs.writeFileSync(path.join(outside, 'after.txt'), 'x');
+    expect(fs.existsSync(path.join(outside, 'after.txt'))).toBe(true);
+  });
+
+  it('should create a temp directory when none is given', () => {
+    sandbox = new Sandbox().install();
+
+    expect(fs.existsSync(sandbox.getDirectory()!)).toBe(true);
+    fs.rmSync(sandbox.getDirectory()!, { recursive: true, force: true });
+  });
+});
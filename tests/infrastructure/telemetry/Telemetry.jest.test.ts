@@ -0,0 +1,100 @@
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import {
+  TelemetryStore,
+  buildTelemetryPayload,
+  emptyCounters,
+  isTelemetryBlocked,
+  recordUsage,
+  telemetryStatePath
+} from '../../../src/infrastructure/telemetry/Telemetry';
+
+describe('Telemetry', () => {
+  let configHome: string;
+
+  beforeEach(() => {
+    configHome = fs.mkdtempSync(path.join(os.tmpdir(), 'praetorian-telemetry-'));
+  });
+
+  afterEach(() => {
+    fs.rmSync(configHome, { recursive: true, force: true });
+  });
+
+  describe('pure helpers', () => {
+    it('should count each format and category once per run', () => {
+      const counters = recordUsage(emptyCounters(), {
+        command: 'validate',
+        formats: ['yaml', 'yaml', 'json'],
+        ruleCategories: ['compliance']
+      });
+
+      expect(recordUsage(counters, { command: 'validate', formats: ['yaml'] })).toEqual({
+        commands: { validate: 2 },
+        formats: { yaml: 2, json: 1 },
+        ruleCategories: { compliance: 1 }
+      });
+    });
+
+    it('should build a payload with version and counters only', () => {
+      expect(buildTelemetryPayload(emptyCounters(), '1.0.0')).toEqual({
+        version: '1.0.0',
+        counters: { commands: {}, formats: {}, ruleCategories: {} }
+      });
+    });
+
+    it('should store state under XDG_CONFIG_HOME', () => {
+      expect(telemetryStatePath({ XDG_CONFIG_HOME: '/cfg' })).toBe(path.join('/cfg', 'praetorian', 'telemetry.json'));
+    });
+
+    it('should honor DO_NOT_TRACK', () => {
+      expect(isTelemetryBlocked({ DO_NOT_TRACK: '1' })).toBe(true);
+      expect(isTelemetryBlocked({})).toBe(false);
+    });
+  });
+
+  describe('TelemetryStore', () => {
+    it('should not record anything until the user opts in', () => {
+      const store = new TelemetryStore({ XDG_CONFIG_HOME: configHome });
+
+      store.record({ command: 'validate' });
+
+      expect(store.isEnabled()).toBe(false);
+      expect(fs.existsSync(store.getStatePath())).toBe(false);
+    });
+
+    it('should record usage once enabled and discard it when disabled', () => {
+      const store = new TelemetryStore({ XDG_CONFIG_HOME: configHome });
+
+      store.setEnabled(true);
+      store.record({ command: 'validate', formats: ['yaml'] });
+      expect(store.load().counters.commands).toEqual({ validate: 1 });
+
+      store.setEnabled(false);
+      expect(store.load()).toEqual({ enabled: false, counters: emptyCounters() });
+    });
+
+    it('should stay disabled under DO_NOT_TRACK even after opting in', () => {
+      const store = new TelemetryStore({ XDG_CONFIG_HOME: configHome, DO_NOT_TRACK: '1' });
+
+      store.setEnabled(true);
+
+      expect(store.isEnabled()).toBe(false);
+    });
+
+    it('should follow the choice of the user configuration over the stored opt-in', () => {
+      new TelemetryStore({ XDG_CONFIG_HOME: configHome }).setEnabled(true);
+
+      expect(new TelemetryStore({ XDG_CONFIG_HOME: configHome }, undefined, false).isEnabled()).toBe(false);
+      expect(new TelemetryStore({ XDG_CONFIG_HOME: configHome }, undefined, false).isChosenByUserConfig()).toBe(true);
+      expect(new TelemetryStore({ XDG_CONFIG_HOME: configHome, DO_NOT_TRACK: '1' }, undefined, true).isEnabled()).toBe(false);
+    });
+
+    it('should not send without an endpoint', async () => {
+      const store = new TelemetryStore({ XDG_CONFIG_HOME: configHome });
+      store.setEnabled(true);
+
+      await expect(store.flush('1.0.0')).resolves.toBe(false);
+    });
+  });
+});
@@ -0,0 +1,46 @@
+import { capFindings, findingFiles, formatOverflow } from '../../../src/presentation/cli/FindingCap';
+import { ValidationError } from '../../../src/shared/types';
+
+const finding = (code: string, file: string): ValidationError => ({
+  code,
+  message: `${code} in ${file}`,
+  severity: 'error',
+  context: { file }
+});
+
+describe('FindingCap', () => {
+  it('should leave findings untouched without a cap or under it', () => {
+    const findings = [finding('MISSING_KEY', 'a.yaml')];
+
+    expect(capFindings(findings)).toEqual({ shown: findings, overflow: [] });
+    expect(capFindings(findings, 5)).toEqual({ shown: findings, overflow: [] });
+  });
+
+  it('should summarize hidden findings per code and file count', () => {
+    const findings = [
+      finding('MISSING_KEY', 'a.yaml'),
+      finding('MISSING_KEY', 'b.yaml'),
+      finding('MISSING_KEY', 'b.yaml'),
+      finding('PARSE_ERROR', 'c.yaml')
+    ];
+
+    const capped = capFindings(findings, 1);
+
+    expect(capped.shown).toEqual([findings[0]]);
+    expect(capped.overflow).toEqual([
+      { code: 'MISSING_KEY', count: 2, files: 1 },
+      { code: 'PARSE_ERROR', count: 1, files: 1 }
+    ]);
+  });
+
+  it('should read aggregated file lists', () => {
+    expect(findingFiles({ code: 'X', message: '', severity: 'error', context: { files: ['a', 'b'] } })).toEqual(['a', 'b']);
+  });
+
+  it('should format the overflow summary', () => {
+    expect(formatOverflow({ code: 'MISSING_KEY', count: 312, files: 14 }, 'error'))
+      .toBe('…and 312 more MISSING_KEY errors across 14 files');
+    expect(formatOverflow({ code: 'MISSING_KEY', count: 2, files: 1 }, 'error', 'es'))
+      .toBe('…y 2 errores MISSING_KEY más en 1 archivo');
+  });
+});
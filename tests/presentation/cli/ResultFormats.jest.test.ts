@@ -0,0 +1,183 @@
+import { parse } from 'yaml';
+import * as path from 'path';
+import {
+  artifactUri,
+  isStructuredFormat,
+  issueFingerprint,
+  JUNIT_CLEAN_CASE,
+  serializeResult,
+  toCodeQuality,
+  toJunit,
+  toSarif,
+} from '../../../src/presentation/cli/ResultFormats';
+import { ValidationResult } from '../../../src/shared/types';
+
+describe('ResultFormats', () => {
+  const result: ValidationResult = {
+    success: false,
+    errors: [{
+      code: 'MISSING_KEY',
+      message: "Key 'database.host' is missing in prod.yaml",
+      severity: 'error',
+      context: { file: 'prod.yaml', keyPath: 'database.host', observedValue: undefined, extras: { availableKeys: ['database.port'] } },
+    }],
+    warnings: [],
+    metadata: { duration: 12, filesCompared: 2, totalKeys: 4 },
+  };
+
+  it('should tell machine-readable formats from the pretty one', () => {
+    expect(isStructuredFormat('json')).toBe(true);
+    expect(isStructuredFormat('yaml')).toBe(true);
+    expect(isStructuredFormat('sarif')).toBe(true);
+    expect(isStructuredFormat('pretty')).toBe(false);
+  });
+
+  it('should keep the JSON output as it was', () => {
+    expect(serializeResult(result, 'json')).toBe(JSON.stringify(result, null, 2));
+  });
+
+  it('should write YAML holding the same document as the JSON output', () => {
+    const yaml = serializeResult(result, 'yaml');
+
+    expect(parse(yaml)).toEqual(JSON.parse(serializeResult(result, 'json')));
+    expect(yaml).toContain('keyPath: database.host');
+    expect(yaml).not.toContain('observedValue');
+  });
+
+  describe('SARIF', () => {
+    const cwd = path.resolve('/repo');
+    const sarifResult: ValidationResult = {
+      success: false,
+      errors: [{
+        code: 'MISSING_KEY',
+        message: "Key 'database.host' is missing",
+        severity: 'error',
+        context: { files: [path.join(cwd, 'config', 'prod.yaml'), 'config/staging.yaml'], keyPath: 'database.host' },
+      }],
+      warnings: [{ code: 'PARSE_WARNING', message: 'Odd indentation', severity: 'warning', context: { file: 'config/dev.yaml', line: 4, column: 2 } }],
+      info: [{ code: 'EMPTY_VALUE', message: 'Empty value', severity: 'info' }],
+    };
+
+    it('should map every finding to a result with its rule, level and locations', () => {
+      const run = (toSarif(sarifResult, { toolVersion: '1.2.3', cwd }) as any).runs[0];
+
+      expect(run.tool.driver).toMatchObject({ name: 'praetorian', version: '1.2.3' });
+      expect(run.tool.driver.rules.map((rule: { id: string }) => rule.id)).toEqual(['EMPTY_VALUE', 'MISSING_KEY', 'PARSE_WARNING']);
+      expect(run.results).toEqual([
+        {
+          ruleId: 'MISSING_KEY',
+          ruleIndex: 1,
+          level: 'error',
+          message: { text: "Key 'database.host' is missing" },
+          locations: [
+            { physicalLocation: { artifactLocation: { uri: 'config/prod.yaml' } } },
+            { physicalLocation: { artifactLocation: { uri: 'config/staging.yaml' } } },
+          ],
+          properties: { keyPath: 'database.host' },
+        },
+        {
+          ruleId: 'PARSE_WARNING',
+          ruleIndex: 2,
+          level: 'warning',
+          message: { text: 'Odd indentation' },
+          locations: [{ physicalLocation: { artifactLocation: { uri: 'config/dev.yaml' }, region: { startLine: 4, startColumn: 2 } } }],
+        },
+        { ruleId: 'EMPTY_VALUE', ruleIndex: 0, level: 'note', message: { text: 'Empty value' } },
+      ]);
+    });
+
+    it('should write a SARIF 2.1.0 log', () => {
+      const log = JSON.parse(serializeResult(sarifResult, 'sarif', { cwd }));
+
+      expect(log.version).toBe('2.1.0');
+      expect(log.$schema).toContain('sarif-2.1.0');
+    });
+
+    it('should name files outside the working directory by file URI', () => {
+      expect(artifactUri('config/app.yaml', cwd)).toBe('config/app.yaml');
+      expect(artifactUri(path.resolve('/elsewhere/app.yaml'), cwd)).toMatch(/^file:\/\//);
+    });
+  });
+
+  describe('JUnit', () => {
+    const junitResult: ValidationResult = {
+      success: false,
+      errors: [
+        { code: 'MISSING_KEY', message: "Key 'database.host' is missing", severity: 'error', context: { files: ['prod.yaml', 'staging.yaml'] } },
+        { code: 'MISSING_KEY', message: "Key 'cache.ttl' is missing", severity: 'error', context: { file: 'prod.yaml' } },
+      ],
+      warnings: [{ code: 'EMPTY_VALUE', message: 'Key <api.url> & co are empty', severity: 'warning', context: { file: 'prod.yaml' } }],
+      info: [{ code: 'PLUGIN_NOTE', message: 'Loaded 2 plugins', severity: 'info' }],
+      metadata: { duration: 1500 },
+    };
+
+    it('should write one suite per file with one test case per finding code', () => {
+      const report = toJunit(junitResult, { files: ['dev.yaml', 'prod.yaml', 'staging.yaml'] });
+
+      expect(report).toMatch(/^<\?xml version="1.0" encoding="UTF-8"\?>\n<testsuites name="praetorian" tests="5" failures="2" time="1.500">/);
+      expect(report).toContain(`<testsuite name="dev.yaml" tests="1" failures="0">\n    <testcase classname="dev.yaml" name="${JUNIT_CLEAN_CASE}">\n    </testcase>`);
+      expect(report).toContain('<testsuite name="prod.yaml" tests="2" failures="1">');
+      expect(report).toContain(`<failure message="Key 'database.host' is missing" type="MISSING_KEY">Key 'database.host' is missing\nKey 'cache.ttl' is missing</failure>`);
+      expect(report).toContain('<testsuite name="praetorian" tests="1" failures="0">');
+      expect(report).toContain('<system-out>info: Loaded 2 plugins</system-out>');
+    });
+
+    it('should pass codes with warnings only and escape their messages', () => {
+      const report = toJunit(junitResult);
+
+      expect(report).toContain('<testcase classname="prod.yaml" name="EMPTY_VALUE">\n      <system-out>warning: Key &lt;api.url&gt; &amp; co are empty</system-out>\n    </testcase>');
+      expect(report).not.toContain('dev.yaml');
+    });
+
+    it('should be served by serializeResult', () => {
+      expect(isStructuredFormat('junit')).toBe(true);
+      expect(serializeResult(junitResult, 'junit', { files: ['dev.yaml'] })).toBe(toJunit(junitResult, { files: ['dev.yaml'] }));
+    });
+  });
+
+  describe('Code Quality', () => {
+    const cwd = path.resolve('/repo');
+    const qualityResult: ValidationResult = {
+      success: false,
+      errors: [{
+        code: 'MISSING_KEY',
+        message: "Key 'database.host' is missing",
+        severity: 'error',
+        context: { files: ['config/prod.yaml', 'config/staging.yaml'], keyPath: 'database.host' },
+      }],
+      warnings: [{ code: 'PARSE_WARNING', message: 'Odd indentation', severity: 'warning', context: { file: 'config/dev.yaml', line: 4 } }],
+      info: [{ code: 'PLUGIN_NOTE', message: 'Loaded 2 plugins', severity: 'info' }],
+    };
+
+    it('should write one issue per finding and affected file', () => {
+      const issues = toCodeQuality(qualityResult, { cwd, configFile: 'praetorian.yaml' });
+
+      expect(issues).toHaveLength(4);
+      expect(issues[0]).toEqual({
+        type: 'issue',
+        check_name: 'MISSING_KEY',
+        description: "Key 'database.host' is missing",
+        categories: ['Bug Risk'],
+        severity: 'critical',
+        fingerprint: issueFingerprint(qualityResult.errors[0], 'config/prod.yaml'),
+        location: { path: 'config/prod.yaml', lines: { begin: 1 } },
+      });
+      expect(issues[2]).toMatchObject({ severity: 'minor', location: { path: 'config/dev.yaml', lines: { begin: 4 } } });
+      expect(issues[3]).toMatchObject({ severity: 'info', location: { path: 'praetorian.yaml' } });
+    });
+
+    it('should keep fingerprints stable across languages and distinct across files', () => {
+      const finding = qualityResult.errors[0];
+      const translated = { ...finding, message: "Falta la clave 'database.host'" };
+
+      expect(issueFingerprint(translated, 'config/prod.yaml')).toBe(issueFingerprint(finding, 'config/prod.yaml'));
+      expect(issueFingerprint(finding, 'config/staging.yaml')).not.toBe(issueFingerprint(finding, 'config/prod.yaml'));
+      expect(issueFingerprint(finding, 'config/prod.yaml')).toMatch(/^[0-9a-f]{64}$/);
+    });
+
+    it('should be served by serializeResult as a JSON array', () => {
+      expect(isStructuredFormat('codeclimate')).toBe(true);
+      expect(JSON.parse(serializeResult(qualityResult, 'codeclimate', { cwd }))).toHaveLength(4);
+    });
+  });
+});
@@ -0,0 +1,64 @@
+import {
+  resolveTerminalStyle,
+  stripEmoji,
+  isCiEnvironment,
+  styleLine,
+  terminalOptionsFromArgv
+} from '../../../src/presentation/cli/Terminal';
+
+describe('Terminal', () => {
+  describe('resolveTerminalStyle', () => {
+    it('should enable colors, emoji and banner on a TTY', () => {
+      expect(resolveTerminalStyle({ isTTY: true, env: {} })).toEqual({ color: true, emoji: true, banner: true });
+    });
+
+    it('should disable all decorations when not a TTY', () => {
+      expect(resolveTerminalStyle({ isTTY: false, env: {} })).toEqual({ color: false, emoji: false, banner: false });
+    });
+
+    it('should honor NO_COLOR and --no-color without dropping emoji', () => {
+      expect(resolveTerminalStyle({ isTTY: true, env: { NO_COLOR: '' } }).color).toBe(false);
+      expect(resolveTerminalStyle({ isTTY: true, env: {}, noColor: true })).toEqual({ color: false, emoji: true, banner: true });
+    });
+
+    it('should disable everything in plain mode', () => {
+      expect(resolveTerminalStyle({ isTTY: true, env: {}, plain: true })).toEqual({ color: false, emoji: false, banner: false });
+    });
+  });
+
+  describe('quiet mode', () => {
+    it('should suppress emoji and banner but keep colors', () => {
+      expect(resolveTerminalStyle({ isTTY: true, env: {}, quiet: true })).toEqual({ color: true, emoji: false, banner: false });
+    });
+
+    it('should auto-enable when CI=true', () => {
+      expect(resolveTerminalStyle({ isTTY: true, env: { CI: 'true' } }).banner).toBe(false);
+      expect(isCiEnvironment({ CI: 'true' })).toBe(true);
+      expect(isCiEnvironment({ CI: 'false' })).toBe(false);
+      expect(isCiEnvironment({})).toBe(false);
+    });
+  });
+
+  describe('stripEmoji', () => {
+    it('should remove emoji and trailing spacing', () => {
+      expect(stripEmoji('✅ All files have consistent keys!')).toBe('All files have consistent keys!');
+      expect(stripEmoji('\n⚠️  2 warning(s):')).toBe('\n2 warning(s):');
+      expect(stripEmoji('  • plain bullet')).toBe('  • plain bullet');
+    });
+  });
+
+  describe('styleLine', () => {
+    it('should keep emoji only when enabled', () => {
+      expect(styleLine('❌ FAILED', { color: false, emoji: true, banner: false })).toBe('❌ FAILED');
+      expect(styleLine('❌ FAILED', { color: false, emoji: false, banner: false })).toBe('FAILED');
+    });
+  });
+
+  describe('terminalOptionsFromArgv', () => {
+    it('should read styling flags from raw arguments', () => {
+      expect(terminalOptionsFromArgv(['validate', '--no-color'])).toEqual({ noColor: true, plain: false, quiet: false });
+      expect(terminalOptionsFromArgv(['--plain'])).toEqual({ noColor: false, plain: true, quiet: false });
+      expect(terminalOptionsFromArgv(['validate', '--ci'])).toEqual({ noColor: false, plain: false, quiet: true });
+    });
+  });
+});
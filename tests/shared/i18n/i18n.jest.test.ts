@@ -0,0 +1,45 @@
+import {
+  findingTemplates,
+  languageFromArgv,
+  LANGUAGES,
+  missingTranslations,
+  parseLanguage,
+  resolveLanguage,
+  translate
+} from '../../../src/shared/i18n';
+
+describe('i18n', () => {
+  it('should keep every catalog complete', () => {
+    LANGUAGES.forEach(language => expect(missingTranslations(language)).toEqual([]));
+  });
+
+  it('should parse locales into supported languages', () => {
+    expect(parseLanguage('es_AR.UTF-8')).toBe('es');
+    expect(parseLanguage('en-US')).toBe('en');
+    expect(parseLanguage('fr_FR.UTF-8')).toBeUndefined();
+    expect(parseLanguage(undefined)).toBeUndefined();
+  });
+
+  it('should prefer the flag, then PRAETORIAN_LANG, then the locale variables', () => {
+    expect(resolveLanguage('es', { LANG: 'en_US.UTF-8' })).toBe('es');
+    expect(resolveLanguage(undefined, { PRAETORIAN_LANG: 'es', LANG: 'en_US.UTF-8' })).toBe('es');
+    expect(resolveLanguage(undefined, { LANG: 'es_ES.UTF-8' })).toBe('es');
+    expect(resolveLanguage(undefined, { LANG: 'C' })).toBe('en');
+  });
+
+  it('should read --lang from raw argv', () => {
+    expect(languageFromArgv(['validate', '--lang', 'es'])).toBe('es');
+    expect(languageFromArgv(['validate', '--lang=es'])).toBe('es');
+    expect(languageFromArgv(['validate'])).toBeUndefined();
+  });
+
+  it('should translate with variables and fall back to the id', () => {
+    expect(translate('validate.summary.files', { count: 3 }, 'es')).toBe('  • Archivos comparados: 3');
+    expect(translate('validate.summary.files', { count: 3 })).toBe('  • Files compared: 3');
+    expect(translate('unknown.id')).toBe('unknown.id');
+  });
+
+  it('should expose finding templates by code', () => {
+    expect(findingTemplates('es').MISSING_KEY).toBe("Falta la clave '{{key}}' en {{file}}");
+  });
+});
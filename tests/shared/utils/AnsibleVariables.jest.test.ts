@@ -0,0 +1,95 @@
+import {
+  effectiveGroupVariables,
+  groupChain,
+  parseIniInventory,
+  yamlInventoryGroups
+} from '../../../src/shared/utils/AnsibleVariables';
+
+describe('AnsibleVariables', () => {
+  describe('parseIniInventory', () => {
+    it('should read hosts, vars and children', () => {
+      const groups = parseIniInventory([
+        '# production',
+        'bastion.example.com',
+        '[web]',
+        'web1 ansible_host=10.0.0.1',
+        'web2',
+        '[web:vars]',
+        'http_port = 80',
+        'motd="hello world"',
+        '[prod:children]',
+        'web'
+      ].join('\n'));
+
+      expect(groups.ungrouped.hosts).toEqual(['bastion.example.com']);
+      expect(groups.web).toEqual({ vars: { http_port: '80', motd: 'hello world' }, children: [], hosts: ['web1', 'web2'] });
+      expect(groups.prod.children).toEqual(['web']);
+      expect(groups.all).toEqual({ vars: {}, children: [], hosts: [] });
+    });
+  });
+
+  describe('yamlInventoryGroups', () => {
+    it('should walk nested children', () => {
+      const groups = yamlInventoryGroups({
+        all: {
+          vars: { ntp: 'ntp.internal' },
+          children: {
+            prod: { children: { web: { hosts: { web1: { ansible_host: '10.0.0.1' } }, vars: { http_port: 80 } } } }
+          }
+        }
+      });
+
+      expect(groups.all).toEqual({ vars: { ntp: 'ntp.internal' }, children: ['prod'], hosts: [] });
+      expect(groups.prod.children).toEqual(['web']);
+      expect(groups.web).toEqual({ vars: { http_port: 80 }, children: [], hosts: ['web1'] });
+    });
+  });
+
+  describe('groupChain', () => {
+    it('should order all, parents and the group itself by depth', () => {
+      const groups = parseIniInventory('[prod:children]\nweb\n[east:children]\nweb\n[web]\nweb1\n');
+
+      expect(groupChain(groups, 'web')).toEqual(['all', 'east', 'prod', 'web']);
+      expect(groupChain(groups, 'all')).toEqual(['all']);
+    });
+
+    it('should survive cyclic children lists', () => {
+      const groups = parseIniInventory('[a:children]\nb\n[b:children]\na\n');
+
+      expect(groupChain(groups, 'a').sort()).toEqual(['a', 'b']);
+    });
+  });
+
+  describe('effectiveGroupVariables', () => {
+    it('should let group_vars override inventory vars and children override parents', () => {
+      const groups = parseIniInventory([
+        '[all:vars]',
+        'region=eu',
+        'log_level=info',
+        '[prod:children]',
+        'web',
+        '[prod:vars]',
+        'log_level=warn',
+        '[web:vars]',
+        'region=us'
+      ].join('\n'));
+
+      const effective = effectiveGroupVariables(groups, {
+        all: { region: 'eu-west-1', ntp: 'ntp.internal' },
+        prod: { replicas: 3 },
+        web: { replicas: 5 }
+      });
+
+      expect(effective.web).toEqual({ region: 'eu-west-1', log_level: 'warn', ntp: 'ntp.internal', replicas: 5 });
+      expect(effective.prod).toEqual({ region: 'eu-west-1', log_level: 'warn', ntp: 'ntp.internal', replicas: 3 });
+      expect(effective.all).toEqual({ region: 'eu-west-1', log_level: 'info', ntp: 'ntp.internal' });
+    });
+
+    it('should list groups that only exist in group_vars and skip an empty ungrouped', () => {
+      const effective = effectiveGroupVariables(parseIniInventory(''), { db: { port: 5432 } });
+
+      expect(Object.keys(effective).sort()).toEqual(['all', 'db']);
+      expect(effective.db).toEqual({ port: 5432 });
+    });
+  });
+});
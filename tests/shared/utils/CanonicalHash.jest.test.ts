@@ -0,0 +1,37 @@
+import * as yaml from 'js-yaml';
+import { canonicalHash, canonicalJson } from '../../../src/shared/utils/CanonicalHash';
+
+describe('CanonicalHash', () => {
+  describe('canonicalJson', () => {
+    it('should sort mapping keys at every depth and keep list order', () => {
+      expect(canonicalJson({ b: 1, a: { d: [3, 1], c: null } })).toBe('{"a":{"c":null,"d":[3,1]},"b":1}');
+    });
+
+    it('should sort integer-like keys as text', () => {
+      expect(canonicalJson({ b: 1, 10: 'x', 9: 'y' })).toBe('{"10":"x","9":"y","b":1}');
+    });
+
+    it('should drop missing values as JSON does', () => {
+      expect(canonicalJson({ a: undefined, b: [undefined] })).toBe('{"b":[null]}');
+    });
+
+    it('should write dates as ISO strings', () => {
+      expect(canonicalJson({ at: new Date('2026-01-05T10:00:00Z') })).toBe('{"at":"2026-01-05T10:00:00.000Z"}');
+    });
+  });
+
+  describe('canonicalHash', () => {
+    it('should hash the same configuration alike whatever its format', () => {
+      const fromJson = JSON.parse('{"port": 80, "db": {"host": "db.internal"}, "released": "2026-01-05T10:00:00.000Z"}');
+      const fromYaml = yaml.load('released: 2026-01-05T10:00:00Z\ndb:\n  host: db.internal\nport: 80\n');
+
+      expect(canonicalHash(fromYaml)).toBe(canonicalHash(fromJson));
+      expect(canonicalHash(fromJson)).toMatch(/^sha256:[0-9a-f]{64}$/);
+    });
+
+    it('should tell different values apart', () => {
+      expect(canonicalHash({ port: 80 })).not.toBe(canonicalHash({ port: '80' }));
+      expect(canonicalHash([1, 2])).not.toBe(canonicalHash([2, 1]));
+    });
+  });
+});
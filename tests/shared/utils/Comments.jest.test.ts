@@ -0,0 +1,24 @@
+import { extractComments, HASH_COMMENTS, NO_COMMENTS } from '../../../src/shared/utils/Comments';
+
+describe('Comments', () => {
+  it('should keep full-line comments with their line and without the marker', () => {
+    const text = '# database:\n#   host: old-db\nport: 80 # default\n  ; not a hash comment\n';
+
+    expect(extractComments(text, HASH_COMMENTS)).toEqual([
+      { line: 1, text: 'database:' },
+      { line: 2, text: 'host: old-db' }
+    ]);
+    expect(extractComments(text, { line: ['#', ';'] })).toHaveLength(3);
+    expect(extractComments(text, NO_COMMENTS)).toEqual([]);
+  });
+
+  it('should keep every line of a block comment', () => {
+    const text = '<config>\n  <!-- <host>old</host>\n  <port>81</port> -->\n  <!-- single -->\n</config>';
+
+    expect(extractComments(text, { line: [], block: ['<!--', '-->'] })).toEqual([
+      { line: 2, text: '<host>old</host>' },
+      { line: 3, text: '<port>81</port>' },
+      { line: 4, text: 'single' }
+    ]);
+  });
+});
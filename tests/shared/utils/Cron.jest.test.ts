@@ -0,0 +1,30 @@
+import { parseCron } from '../../../src/shared/utils/Cron';
+
+describe('Cron', () => {
+  it('should accept standard, seconds-first and macro expressions with their shortest interval', () => {
+    expect(parseCron('*/5 * * * *')).toEqual({ valid: true, minIntervalMs: 300000 });
+    expect(parseCron('0 9-17 * * MON-FRI')).toEqual({ valid: true, minIntervalMs: 3600000 });
+    expect(parseCron('0 0/15 * * * ?')).toEqual({ valid: true, minIntervalMs: 900000 });
+    expect(parseCron('0 0 12 ? * 6#3 2030')).toEqual({ valid: true, minIntervalMs: 86400000 });
+    expect(parseCron('0 0 12 L-3 * ?').valid).toBe(true);
+    expect(parseCron('CRON_TZ=Europe/Madrid 0 2 * * *')).toEqual({ valid: true, minIntervalMs: 86400000 });
+    expect(parseCron('@hourly')).toEqual({ valid: true, minIntervalMs: 3600000 });
+    expect(parseCron('@every 30s')).toEqual({ valid: true, minIntervalMs: 30000 });
+    expect(parseCron('@reboot')).toEqual({ valid: true });
+  });
+
+  it('should explain why an expression is invalid', () => {
+    expect(parseCron('60 * * * *')).toEqual({ valid: false, reason: "'60' is out of range for minutes (0-59)" });
+    expect(parseCron('* * * *')).toEqual({ valid: false, reason: 'expected 5, 6 or 7 fields, found 4' });
+    expect(parseCron('*/0 * * * *')).toEqual({ valid: false, reason: "'*/0' has a step of 0" });
+    expect(parseCron('0 0 * JAN-XYZ *')).toEqual({ valid: false, reason: "'JAN-XYZ' is not a valid month value" });
+    expect(parseCron('0 0 12 L * ?', true).valid).toBe(true);
+    expect(parseCron('0 0 L * *').valid).toBe(false);
+    expect(parseCron('@fortnightly').valid).toBe(false);
+    expect(parseCron('')).toEqual({ valid: false, reason: 'the expression is empty' });
+  });
+
+  it('should reject seconds when only five fields are allowed', () => {
+    expect(parseCron('0 */5 * * * *', false)).toEqual({ valid: false, reason: 'expected 5 fields, found 6' });
+  });
+});
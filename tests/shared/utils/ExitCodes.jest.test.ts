@@ -0,0 +1,48 @@
+import {
+  ConfigError,
+  EXIT_CODES,
+  exitCodeFor,
+  exitCodeForResult,
+  IoError,
+  isExitOnly,
+  RemoteError
+} from '../../../src/shared/utils/ExitCodes';
+import { ConfigParser } from '../../../src/infrastructure/parsers/ConfigParser';
+import { FileReaderService } from '../../../src/infrastructure/adapters/FileReaderService';
+
+describe('ExitCodes', () => {
+  it('should map classified errors to their exit codes', () => {
+    expect(exitCodeFor(new ConfigError('bad config'))).toBe(EXIT_CODES.USAGE);
+    expect(exitCodeFor(new IoError('unreadable'))).toBe(EXIT_CODES.IO);
+    expect(exitCodeFor(new RemoteError('unreachable'))).toBe(EXIT_CODES.REMOTE);
+  });
+
+  it('should keep oclif exit codes and treat anything else as a tool failure', () => {
+    expect(exitCodeFor(Object.assign(new Error('Unexpected argument'), { oclif: { exit: 2 } }))).toBe(2);
+    expect(exitCodeFor(Object.assign(new Error('EEXIT: 1'), { code: 'EEXIT', oclif: { exit: 1 } }))).toBe(1);
+    expect(exitCodeFor(new TypeError('undefined is not a function'))).toBe(EXIT_CODES.IO);
+    expect(exitCodeFor(undefined)).toBe(EXIT_CODES.IO);
+  });
+
+  it('should fail a run on findings at or above --fail-on', () => {
+    const withErrors = { success: false, warnings: [] };
+    const withWarnings = { success: true, warnings: [{ code: 'EMPTY_VALUE', message: 'Empty value', severity: 'warning' as const }] };
+    const clean = { success: true, warnings: [] };
+
+    expect(exitCodeForResult(withErrors)).toBe(EXIT_CODES.FINDINGS);
+    expect(exitCodeForResult(withWarnings)).toBe(EXIT_CODES.SUCCESS);
+    expect(exitCodeForResult(withWarnings, 'warning')).toBe(EXIT_CODES.FINDINGS);
+    expect(exitCodeForResult(clean, 'warning')).toBe(EXIT_CODES.SUCCESS);
+    expect(exitCodeForResult(withErrors, 'never')).toBe(EXIT_CODES.SUCCESS);
+  });
+
+  it('should recognize exit-only errors', () => {
+    expect(isExitOnly({ code: 'EEXIT' })).toBe(true);
+    expect(isExitOnly(new ConfigError('bad config'))).toBe(false);
+  });
+
+  it('should classify configuration and file errors where they are thrown', async () => {
+    expect(() => new ConfigParser('missing-praetorian.yaml').load()).toThrow(ConfigError);
+    await expect(new FileReaderService().readFiles(['missing-config.yaml'])).rejects.toBeInstanceOf(IoError);
+  });
+});
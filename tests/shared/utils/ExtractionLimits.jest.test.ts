@@ -0,0 +1,33 @@
+import { limitFiles, resolveExtractionLimits } from '../../../src/shared/utils/ExtractionLimits';
+import { DEFAULT_EXTRACTION_LIMITS, TRUNCATED_VALUE } from '../../../src/shared/utils/KeyPaths';
+import { ConfigFile } from '../../../src/shared/types';
+
+const file = (path: string, content: Record<string, any>): ConfigFile => ({ path, format: 'yaml', content });
+
+describe('ExtractionLimits', () => {
+  it('should fill the limits that are not configured with the defaults', () => {
+    expect(resolveExtractionLimits()).toEqual(DEFAULT_EXTRACTION_LIMITS);
+    expect(resolveExtractionLimits({ maxDepth: 3 })).toEqual({ maxDepth: 3, maxKeys: DEFAULT_EXTRACTION_LIMITS.maxKeys });
+  });
+
+  it('should truncate the files going over the limits and warn about each', () => {
+    const limited = limitFiles([
+      file('deep.yaml', { a: { b: { c: 1 } } }),
+      file('wide.yaml', { a: 1, b: 2, c: 3 }),
+      file('fine.yaml', { a: 1 }),
+    ], { maxDepth: 2, maxKeys: 2 });
+
+    expect(limited.files.map(limitedFile => limitedFile.path)).toEqual(['deep.yaml', 'wide.yaml', 'fine.yaml']);
+    expect(limited.files[0].content).toEqual({ a: { b: TRUNCATED_VALUE } });
+    expect(Object.keys(limited.files[1].content)).toHaveLength(2);
+    expect(limited.files[2].content).toEqual({ a: 1 });
+    expect(limited.warnings.map(warning => `${warning.code} ${warning.context?.file}`)).toEqual([
+      'MAX_DEPTH_EXCEEDED deep.yaml',
+      'MAX_KEYS_EXCEEDED wide.yaml',
+    ]);
+  });
+
+  it('should leave files within the limits without warnings', () => {
+    expect(limitFiles([file('a.yaml', { a: { b: 1 } })], DEFAULT_EXTRACTION_LIMITS).warnings).toEqual([]);
+  });
+});
@@ -0,0 +1,105 @@
+import {
+  flagExpiries,
+  launchDarklyRolloutShare,
+  readFeatureFlags
+} from '../../../src/shared/utils/FeatureFlags';
+
+describe('FeatureFlags', () => {
+  describe('readFeatureFlags', () => {
+    it('should read every environment of a LaunchDarkly API export', () => {
+      const flags = readFeatureFlags({
+        items: [{
+          key: 'new-checkout',
+          variations: [{ value: true }, { value: false }],
+          customProperties: { expiry: { name: 'Expiry', value: ['2024-03-01'] } },
+          environments: {
+            production: { on: true, fallthrough: { rollout: { variations: [{ variation: 0, weight: 75000 }, { variation: 1, weight: 25000 }] } } },
+            staging: { on: false, fallthrough: { variation: 0 } }
+          }
+        }]
+      });
+
+      expect(flags?.source).toBe('launchdarkly');
+      expect(flags?.states).toEqual([
+        {
+          flag: 'new-checkout',
+          environment: 'production',
+          enabled: true,
+          keyPath: 'items.0.environments.production.on',
+          rollout: { percentage: 75, keyPath: 'items.0.environments.production.fallthrough.rollout' }
+        },
+        { flag: 'new-checkout', environment: 'staging', enabled: false, keyPath: 'items.0.environments.staging.on' }
+      ]);
+      expect(flags?.expiries).toEqual([
+        { flag: 'new-checkout', date: '2024-03-01', keyPath: 'items.0.customProperties.expiry' }
+      ]);
+    });
+
+    it('should read LaunchDarkly flag data files', () => {
+      const flags = readFeatureFlags({
+        flags: { 'dark-mode': { on: true, variations: [true, false], rules: [{ rollout: { variations: [{ variation: 0, weight: 10000 }] } }] } },
+        flagValues: { 'beta-banner': false, 'theme': 'blue' }
+      });
+
+      expect(flags?.states.map(state => [state.flag, state.enabled, state.rollout?.percentage])).toEqual([
+        ['dark-mode', true, 10],
+        ['beta-banner', false, undefined],
+        ['theme', true, undefined]
+      ]);
+    });
+
+    it('should read Unleash client exports and per-environment exports', () => {
+      const client = readFeatureFlags({
+        version: 1,
+        features: [{ name: 'search-v2', enabled: true, expiresAt: '2030-01-01', strategies: [{ name: 'flexibleRollout', parameters: { rollout: '40' } }] }]
+      });
+      const perEnvironment = readFeatureFlags({
+        features: [{ name: 'search-v2' }],
+        featureEnvironments: [{ featureName: 'search-v2', environment: 'production', enabled: true }],
+        featureStrategies: [
+          { featureName: 'search-v2', environment: 'development', parameters: { rollout: '100' } },
+          { featureName: 'search-v2', environment: 'production', name: 'gradualRolloutRandom', parameters: { percentage: '90' } }
+        ]
+      });
+
+      expect(client).toEqual({
+        source: 'unleash',
+        states: [{
+          flag: 'search-v2',
+          enabled: true,
+          keyPath: 'features.0.enabled',
+          rollout: { percentage: 40, keyPath: 'features.0.strategies.0.parameters.rollout' }
+        }],
+        expiries: [{ flag: 'search-v2', date: '2030-01-01', keyPath: 'features.0.expiresAt' }]
+      });
+      expect(perEnvironment?.states).toEqual([{
+        flag: 'search-v2',
+        environment: 'production',
+        enabled: true,
+        keyPath: 'featureEnvironments.0.enabled',
+        rollout: { percentage: 90, keyPath: 'featureStrategies.1.parameters.percentage' }
+      }]);
+    });
+
+    it('should ignore files that are not flag exports', () => {
+      expect(readFeatureFlags({ database: { host: 'localhost' } })).toBeUndefined();
+      expect(readFeatureFlags({ features: ['search'] })).toBeUndefined();
+      expect(readFeatureFlags('text')).toBeUndefined();
+    });
+  });
+
+  it('should count every variation but the off one in a LaunchDarkly rollout', () => {
+    const rollout = { variations: [{ variation: 0, weight: 30000 }, { variation: 1, weight: 50000 }, { variation: 2, weight: 20000 }] };
+
+    expect(launchDarklyRolloutShare(rollout, undefined, undefined)).toBe(50);
+    expect(launchDarklyRolloutShare(rollout, undefined, 2)).toBe(80);
+    expect(launchDarklyRolloutShare(rollout, ['a', 'b', false], undefined)).toBe(80);
+    expect(launchDarklyRolloutShare({ bucketBy: 'key' }, undefined, undefined)).toBeUndefined();
+  });
+
+  it('should read expiry fields written as YAML dates', () => {
+    expect(flagExpiries('legacy', { removeBy: new Date('2023-06-30T00:00:00Z') }, 'flags.legacy')).toEqual([
+      { flag: 'legacy', date: '2023-06-30', keyPath: 'flags.legacy.removeBy' }
+    ]);
+  });
+});
@@ -0,0 +1,20 @@
+import {
+  getFileEntryPath,
+  getFileEntryPaths,
+  getFileEntryFormats
+} from '../../../src/shared/utils/FileEntries';
+
+describe('FileEntries', () => {
+  const entries = ['base.yaml', { path: 'configs/*.conf', format: 'ini' }, { path: 'app.json' }];
+
+  it('should read paths from strings and objects', () => {
+    expect(getFileEntryPath('base.yaml')).toBe('base.yaml');
+    expect(getFileEntryPaths(entries)).toEqual(['base.yaml', 'configs/*.conf', 'app.json']);
+    expect(getFileEntryPaths(undefined)).toEqual([]);
+  });
+
+  it('should collect explicit formats only', () => {
+    expect(getFileEntryFormats(entries)).toEqual({ 'configs/*.conf': 'ini' });
+    expect(getFileEntryFormats(undefined)).toEqual({});
+  });
+});
@@ -0,0 +1,43 @@
+import { globToRegExp, hasGlobMagic, matchesGlob, normalizePath } from '../../../src/shared/utils/Glob';
+
+describe('Glob', () => {
+  describe('normalizePath', () => {
+    it('should use forward slashes and drop a leading ./', () => {
+      expect(normalizePath('configs\\app.yaml')).toBe('configs/app.yaml');
+      expect(normalizePath('./configs/app.yaml')).toBe('configs/app.yaml');
+    });
+  });
+
+  describe('hasGlobMagic', () => {
+    it('should detect glob characters', () => {
+      expect(hasGlobMagic('configs/*.conf')).toBe(true);
+      expect(hasGlobMagic('configs/app.conf')).toBe(false);
+    });
+  });
+
+  describe('globToRegExp', () => {
+    it('should translate single and double stars', () => {
+      expect(globToRegExp('configs/*.yaml').test('configs/app.yaml')).toBe(true);
+      expect(globToRegExp('configs/*.yaml').test('configs/nested/app.yaml')).toBe(false);
+      expect(globToRegExp('configs/**/*.yaml').test('configs/nested/deep/app.yaml')).toBe(true);
+      expect(globToRegExp('configs/**/*.yaml').test('configs/app.yaml')).toBe(true);
+    });
+  });
+
+  describe('matchesGlob', () => {
+    it('should match patterns without slashes against the file name', () => {
+      expect(matchesGlob('deploy/app.config', '*.config')).toBe(true);
+      expect(matchesGlob('deploy/app.yaml', '*.config')).toBe(false);
+    });
+
+    it('should match patterns with slashes against the full path', () => {
+      expect(matchesGlob('./configs/nginx.conf', 'configs/*.conf')).toBe(true);
+      expect(matchesGlob('other/nginx.conf', 'configs/*.conf')).toBe(false);
+    });
+
+    it('should not match empty input', () => {
+      expect(matchesGlob('', '*.yaml')).toBe(false);
+      expect(matchesGlob('app.yaml', '')).toBe(false);
+    });
+  });
+});
@@ -0,0 +1,54 @@
+import { EventEmitter } from 'events';
+import { RunInterrupt } from '../../../src/shared/utils/Interrupt';
+import { EXIT_CODES, InterruptedError, throwIfInterrupted } from '../../../src/shared/utils/ExitCodes';
+
+const fakeProcess = () => {
+  const emitter = new EventEmitter() as EventEmitter & { exit: jest.Mock };
+  emitter.exit = jest.fn();
+  return emitter;
+};
+
+describe('RunInterrupt', () => {
+  it('should abort its signal on the first SIGINT or SIGTERM', () => {
+    const target = fakeProcess();
+    const interrupt = new RunInterrupt(target as unknown as NodeJS.Process).listen();
+
+    expect(interrupt.isInterrupted()).toBe(false);
+    target.emit('SIGTERM', 'SIGTERM');
+
+    expect(interrupt.isInterrupted()).toBe(true);
+    expect(interrupt.signal.aborted).toBe(true);
+    expect(interrupt.getSignal()).toBe('SIGTERM');
+    expect(target.exit).not.toHaveBeenCalled();
+  });
+
+  it('should exit with the interrupted code on a second signal', () => {
+    const target = fakeProcess();
+    new RunInterrupt(target as unknown as NodeJS.Process).listen();
+
+    target.emit('SIGINT', 'SIGINT');
+    target.emit('SIGINT', 'SIGINT');
+
+    expect(target.exit).toHaveBeenCalledWith(EXIT_CODES.INTERRUPTED);
+  });
+
+  it('should stop listening once disposed', () => {
+    const target = fakeProcess();
+    const interrupt = new RunInterrupt(target as unknown as NodeJS.Process).listen();
+
+    interrupt.dispose();
+
+    expect(target.listenerCount('SIGINT')).toBe(0);
+    expect(target.listenerCount('SIGTERM')).toBe(0);
+  });
+
+  it('should throw an interrupted error at checkpoints once aborted', () => {
+    const controller = new AbortController();
+
+    expect(() => throwIfInterrupted(controller.signal)).not.toThrow();
+    controller.abort();
+
+    expect(() => throwIfInterrupted(controller.signal)).toThrow(InterruptedError);
+    expect(new InterruptedError().exitCode).toBe(130);
+  });
+});
@@ -0,0 +1,114 @@
+import {
+  extractKeyPaths,
+  isWildcardPattern,
+  matchesKeyPattern,
+  resolveKeyPattern,
+  findMissingWildcardKeys,
+  truncateToLimits,
+  TRUNCATED_VALUE
+} from '../../../src/shared/utils/KeyPaths';
+
+describe('KeyPaths', () => {
+  describe('extractKeyPaths', () => {
+    it('should extract nested keys with dot notation', () => {
+      const keys = extractKeyPaths({ a: { b: 1, c: { d: 2 } }, e: [1, 2] });
+
+      expect(keys).toEqual(new Set(['a', 'a.b', 'a.c', 'a.c.d', 'e']));
+    });
+
+    it('should return empty set for non-objects', () => {
+      expect(extractKeyPaths(null)).toEqual(new Set());
+      expect(extractKeyPaths([1, 2])).toEqual(new Set());
+      expect(extractKeyPaths('value')).toEqual(new Set());
+    });
+  });
+
+  describe('isWildcardPattern', () => {
+    it('should detect wildcard segments', () => {
+      expect(isWildcardPattern('services.*.healthcheck')).toBe(true);
+      expect(isWildcardPattern('database.host')).toBe(false);
+    });
+  });
+
+  describe('matchesKeyPattern', () => {
+    it('should match exactly one segment per wildcard', () => {
+      expect(matchesKeyPattern('services.api.healthcheck', 'services.*.healthcheck')).toBe(true);
+      expect(matchesKeyPattern('services.api.v1.healthcheck', 'services.*.healthcheck')).toBe(false);
+      expect(matchesKeyPattern('db.timeout', '*.timeout')).toBe(true);
+      expect(matchesKeyPattern('db.port', '*.timeout')).toBe(false);
+    });
+  });
+
+  describe('resolveKeyPattern', () => {
+    it('should resolve concrete paths for a wildcard pattern', () => {
+      const obj = { services: { api: { port: 1 }, worker: { port: 2 } } };
+
+      expect(resolveKeyPattern(obj, 'services.*').map(match => match.path)).toEqual([
+        'services.api',
+        'services.worker'
+      ]);
+    });
+
+    it('should return no matches when the path does not exist', () => {
+      expect(resolveKeyPattern({ a: 1 }, 'b.*')).toEqual([]);
+    });
+  });
+
+  describe('findMissingWildcardKeys', () => {
+    it('should report every matching parent missing the child key', () => {
+      const obj = {
+        services: {
+          api: { healthcheck: '/health' },
+          worker: { replicas: 2 },
+          cron: { replicas: 1 }
+        }
+      };
+
+      expect(findMissingWildcardKeys(obj, 'services.*.healthcheck')).toEqual([
+        'services.worker.healthcheck',
+        'services.cron.healthcheck'
+      ]);
+    });
+
+    it('should evaluate top-level wildcards against object values only', () => {
+      const obj = { db: { timeout: 5 }, cache: { size: 10 }, name: 'app' };
+
+      expect(findMissingWildcardKeys(obj, '*.timeout')).toEqual(['cache.timeout']);
+    });
+
+    it('should be satisfied when no parent matches', () => {
+      expect(findMissingWildcardKeys({ other: {} }, 'services.*.healthcheck')).toEqual([]);
+    });
+
+    it('should require at least one match when the last segment is a wildcard', () => {
+      expect(findMissingWildcardKeys({ services: {} }, 'services.*')).toEqual(['services.*']);
+      expect(findMissingWildcardKeys({ services: { api: {} } }, 'services.*')).toEqual([]);
+    });
+  });
+
+  describe('truncateToLimits', () => {
+    it('should keep content untouched when within limits', () => {
+      const obj = { a: { b: { c: 1 } } };
+      const result = truncateToLimits(obj, { maxDepth: 5, maxKeys: 10 });
+
+      expect(result.content).toEqual(obj);
+      expect(result.keyCount).toBe(3);
+      expect(result.depthExceeded).toBe(false);
+      expect(result.keysExceeded).toBe(false);
+    });
+
+    it('should replace subtrees deeper than maxDepth', () => {
+      const result = truncateToLimits({ a: { b: { c: 1 } } }, { maxDepth: 2, maxKeys: 10 });
+
+      expect(result.content).toEqual({ a: { b: TRUNCATED_VALUE } });
+      expect(result.depthExceeded).toBe(true);
+    });
+
+    it('should stop after maxKeys keys', () => {
+      const result = truncateToLimits({ a: 1, b: 2, c: 3 }, { maxDepth: 5, maxKeys: 2 });
+
+      expect(result.content).toEqual({ a: 1, b: 2 });
+      expect(result.keysExceeded).toBe(true);
+    });
+  });
+});
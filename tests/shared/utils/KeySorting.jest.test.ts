@@ -0,0 +1,75 @@
+import { keyComparator, sortKeysInText } from '../../../src/shared/utils/KeySorting';
+
+const alphabetical = keyComparator('alphabetical');
+
+describe('KeySorting', () => {
+  it('should rank declared keys first, then sort case-insensitively', () => {
+    expect(['b', 'version', 'A', 'name'].sort(keyComparator(['name', 'version']))).toEqual(['name', 'version', 'A', 'b']);
+    expect(['b', 'B', 'a'].sort(alphabetical)).toEqual(['a', 'B', 'b']);
+  });
+
+  it('should sort nested YAML mappings and keep comments, block scalars and lists in place', () => {
+    const yaml = [
+      '# Service config',
+      '',
+      '# the server',
+      'server:',
+      '  port: 80',
+      '  tls:',
+      '    key: |',
+      '      abc',
+      '',
+      '      def',
+      '    cert: x',
+      'database:',
+      '  users:',
+      '    - name: b',
+      '      age: 2',
+      '  url: postgres://x',
+      ''
+    ].join('\n');
+
+    expect(sortKeysInText(yaml, 'yaml', alphabetical)).toBe([
+      '# Service config',
+      '',
+      'database:',
+      '  url: postgres://x',
+      '  users:',
+      '    - name: b',
+      '      age: 2',
+      '# the server',
+      'server:',
+      '  port: 80',
+      '  tls:',
+      '    cert: x',
+      '    key: |',
+      '      abc',
+      '',
+      '      def',
+      ''
+    ].join('\n'));
+  });
+
+  it('should not rewrite multi-document YAML', () => {
+    expect(sortKeysInText('b: 1\n---\na: 2\n', 'yaml', alphabetical)).toBeUndefined();
+  });
+
+  it('should sort properties and .env files with their comments and continuation lines', () => {
+    expect(sortKeysInText('b=2\n# about a\na=1\\\n  more\nc=3', 'properties', alphabetical)).toBe('# about a\na=1\\\n  more\nb=2\nc=3');
+    expect(sortKeysInText('export B=1\nA=2\n', 'env', alphabetical)).toBe('A=2\nexport B=1\n');
+  });
+
+  it('should sort JSON objects with the indentation of the file, leaving arrays alone', () => {
+    const json = '{\n    "b": {"d": 1, "c": 2},\n    "a": [{"z": 1, "y": 2}]\n}\n';
+
+    expect(JSON.parse(sortKeysInText(json, 'json', alphabetical) as string)).toEqual(JSON.parse(json));
+    expect(sortKeysInText(json, 'json', alphabetical)).toBe(
+      '{\n    "a": [\n        {\n            "z": 1,\n            "y": 2\n        }\n    ],\n    "b": {\n        "c": 2,\n        "d": 1\n    }\n}\n'
+    );
+    expect(sortKeysInText('{ broken', 'json', alphabetical)).toBeUndefined();
+  });
+
+  it('should leave other formats to be sorted by hand', () => {
+    expect(sortKeysInText('[b]\nx=1\n[a]\n', 'ini', alphabetical)).toBeUndefined();
+  });
+});
@@ -0,0 +1,33 @@
+import { commonLabels, labelsOfFile, matchesLabels, parseLabelSelector } from '../../../src/shared/utils/Labels';
+
+describe('Labels', () => {
+  it('should parse key=value selectors', () => {
+    expect(parseLabelSelector('tier=critical')).toEqual({ tier: 'critical' });
+    expect(parseLabelSelector('url=a=b')).toEqual({ url: 'a=b' });
+    expect(parseLabelSelector('tier')).toBeUndefined();
+    expect(parseLabelSelector('=critical')).toBeUndefined();
+  });
+
+  it('should match every label of a selector', () => {
+    expect(matchesLabels({ tier: 'critical', service: 'payments' }, { tier: 'critical' })).toBe(true);
+    expect(matchesLabels({ tier: 'critical' }, { tier: 'critical', service: 'payments' })).toBe(false);
+    expect(matchesLabels(undefined, {})).toBe(true);
+  });
+
+  it('should resolve labels from file globs and groups, later rules winning', () => {
+    const rules = [
+      { files: ['config/**'], groups: [], labels: { tier: 'standard', owner: 'platform' } },
+      { files: [], groups: ['payments'], labels: { tier: 'critical', service: 'payments' } }
+    ];
+    const groups = [{ name: 'payments', files: ['config/payments.yaml'] }];
+
+    expect(labelsOfFile('config/payments.yaml', rules, groups)).toEqual({ tier: 'critical', owner: 'platform', service: 'payments' });
+    expect(labelsOfFile('config/blog.yaml', rules, groups)).toEqual({ tier: 'standard', owner: 'platform' });
+    expect(labelsOfFile('other.yaml', rules, groups)).toEqual({});
+  });
+
+  it('should keep the labels all sets agree on', () => {
+    expect(commonLabels([{ tier: 'critical', service: 'a' }, { tier: 'critical', service: 'b' }])).toEqual({ tier: 'critical' });
+    expect(commonLabels([])).toEqual({});
+  });
+});
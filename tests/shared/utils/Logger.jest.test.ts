@@ -0,0 +1,58 @@
+import { Logger, formatTextRecord } from '../../../src/shared/utils/Logger';
+
+describe('Logger', () => {
+  const createStream = () => {
+    const lines: string[] = [];
+    return { lines, write: (chunk: string) => lines.push(chunk) };
+  };
+
+  it('should filter records below the configured level', () => {
+    const stream = createStream();
+    const logger = new Logger({ level: 'warn', stream });
+
+    logger.debug('debug message');
+    logger.info('info message');
+    logger.warn('warn message');
+
+    expect(stream.lines).toHaveLength(1);
+    expect(stream.lines[0]).toContain('level=WARN');
+    expect(stream.lines[0]).toContain('msg="warn message"');
+  });
+
+  it('should emit nothing when silent', () => {
+    const stream = createStream();
+    const logger = new Logger({ level: 'silent', stream });
+
+    logger.error('error message');
+
+    expect(stream.lines).toHaveLength(0);
+  });
+
+  it('should emit JSON records with attributes', () => {
+    const stream = createStream();
+    const logger = new Logger({ level: 'debug', format: 'json', stream });
+
+    logger.info('Loading files', { count: 3 });
+
+    const record = JSON.parse(stream.lines[0]);
+    expect(record.level).toBe('INFO');
+    expect(record.msg).toBe('Loading files');
+    expect(record.count).toBe(3);
+    expect(record.time).toEqual(expect.any(String));
+  });
+
+  it('should carry attributes from with()', () => {
+    const stream = createStream();
+    const logger = new Logger({ level: 'info', format: 'json', stream }).with({ command: 'validate' });
+
+    logger.info('started');
+
+    expect(JSON.parse(stream.lines[0]).command).toBe('validate');
+  });
+
+  describe('formatTextRecord', () => {
+    it('should quote values containing spaces and skip undefined', () => {
+      expect(formatTextRecord({ msg: 'hello world', count: 2, missing: undefined })).toBe('msg="hello world" count=2');
+    });
+  });
+});
@@ -0,0 +1,48 @@
+import {
+  normalizeQuantity,
+  parseDurationString,
+  parseSizeString,
+  quantityOf
+} from '../../../src/shared/utils/Quantities';
+
+describe('Quantities', () => {
+  it('should parse Go-style and ISO 8601 durations into milliseconds', () => {
+    expect(parseDurationString('30s')).toBe(30000);
+    expect(parseDurationString('1m')).toBe(60000);
+    expect(parseDurationString('1h30m')).toBe(5400000);
+    expect(parseDurationString('250ms')).toBe(250);
+    expect(parseDurationString('1.5h')).toBe(5400000);
+    expect(parseDurationString('PT30S')).toBe(30000);
+    expect(parseDurationString('P1DT2H')).toBe(93600000);
+    expect(parseDurationString('30000')).toBeUndefined();
+    expect(parseDurationString('10M')).toBeUndefined();
+  });
+
+  it('should parse sizes into bytes with binary multiples', () => {
+    expect(parseSizeString('10MB')).toBe(10485760);
+    expect(parseSizeString('10mb')).toBe(10485760);
+    expect(parseSizeString('512Mi')).toBe(536870912);
+    expect(parseSizeString('1.5 GiB')).toBe(1610612736);
+    expect(parseSizeString('2K')).toBe(2048);
+    expect(parseSizeString('64B')).toBe(64);
+    expect(parseSizeString('10m')).toBeUndefined();
+    expect(parseSizeString('10XB')).toBeUndefined();
+  });
+
+  it('should tell durations from sizes', () => {
+    expect(quantityOf('10m')).toEqual({ kind: 'duration', amount: 600000 });
+    expect(quantityOf('10M')).toEqual({ kind: 'size', amount: 10485760 });
+    expect(quantityOf(30000)).toBeUndefined();
+    expect(quantityOf('fast')).toBeUndefined();
+  });
+
+  it('should normalize numbers, numeric strings and quantities to base units', () => {
+    expect(normalizeQuantity(30000)).toBe(30000);
+    expect(normalizeQuantity('30000')).toBe(30000);
+    expect(normalizeQuantity('30s')).toBe(30000);
+    expect(normalizeQuantity('30s', 'duration')).toBe(30000);
+    expect(normalizeQuantity('30s', 'size')).toBeUndefined();
+    expect(normalizeQuantity(Infinity)).toBeUndefined();
+    expect(normalizeQuantity(true)).toBeUndefined();
+  });
+});
@@ -0,0 +1,74 @@
+import {
+  createRedactor,
+  hashValue,
+  isSecretKey,
+  redactFinding,
+  redactResult
+} from '../../../src/shared/utils/Redaction';
+import { ValidationError } from '../../../src/shared/types';
+
+const finding = (path: string, value: unknown): ValidationError => ({
+  code: 'VALUE_MISMATCH',
+  message: `Key '${path}' differs`,
+  severity: 'warning',
+  path,
+  context: { file: 'prod.yaml', keyPath: path, observedValue: value, expectedValue: { 'dev.yaml': 'other' } }
+});
+
+describe('Redaction', () => {
+  it('should leave values alone by default', () => {
+    const original = finding('db.password', 'hunter2');
+
+    expect(redactFinding(original, createRedactor())).toBe(original);
+  });
+
+  it('should mask every value but keep empty ones visible', () => {
+    const redactor = createRedactor({ policy: 'mask-all' });
+
+    expect(redactFinding(finding('api.port', 8080), redactor).context).toEqual({
+      file: 'prod.yaml',
+      keyPath: 'api.port',
+      observedValue: '***',
+      expectedValue: { 'dev.yaml': '***' }
+    });
+    expect(redactor.redact('')).toBe('');
+    expect(redactor.redact([1, null])).toEqual(['***', null]);
+  });
+
+  it('should mask only values of secret-looking keys', () => {
+    const redactor = createRedactor({ policy: 'mask-secret-keys', secretKeys: ['*.dsn'] });
+
+    expect(redactFinding(finding('db.password', 'hunter2'), redactor).context?.observedValue).toBe('***');
+    expect(redactFinding(finding('sentry.dsn', 'https://x@sentry.io/1'), redactor).context?.observedValue).toBe('***');
+    expect(redactFinding(finding('api.port', 8080), redactor).context?.observedValue).toBe(8080);
+    expect(isSecretKey('auth.client_secret')).toBe(true);
+    expect(isSecretKey('server.host')).toBe(false);
+  });
+
+  it('should hash values so equal values stay comparable', () => {
+    const redacted = redactFinding(finding('db.password', 'hunter2'), createRedactor({ policy: 'hash' }));
+
+    expect(redacted.context?.observedValue).toBe(hashValue('hunter2'));
+    expect(redacted.context?.expectedValue).toEqual({ 'dev.yaml': hashValue('other') });
+    expect(String(redacted.context?.observedValue)).toMatch(/^sha256:[0-9a-f]{16}$/);
+  });
+
+  it('should salt hashes with the settings or PRAETORIAN_HASH_SALT', () => {
+    const fromSettings = redactFinding(finding('db.password', 'hunter2'), createRedactor({ policy: 'hash', salt: 'team-salt' }, {}));
+    const fromEnv = redactFinding(finding('db.password', 'hunter2'), createRedactor({ policy: 'hash' }, { PRAETORIAN_HASH_SALT: 'team-salt' }));
+
+    expect(fromSettings.context?.observedValue).toBe(hashValue('hunter2', 'team-salt'));
+    expect(fromEnv.context?.observedValue).toBe(hashValue('hunter2', 'team-salt'));
+    expect(hashValue('hunter2', 'team-salt')).toMatch(/^hmac-sha256:[0-9a-f]{16}$/);
+    expect(hashValue('hunter2', 'team-salt')).not.toBe(hashValue('hunter2', 'other-salt'));
+  });
+
+  it('should redact every finding of a result', () => {
+    const result = redactResult(
+      { success: true, errors: [], warnings: [finding('db.password', 'hunter2') as any] },
+      createRedactor({ policy: 'mask-all' })
+    );
+
+    expect(JSON.stringify(result)).not.toContain('hunter2');
+  });
+});
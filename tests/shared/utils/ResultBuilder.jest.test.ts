@@ -254,7 +254,7 @@ describe('ResultBuilder', () => {
         code: 'VALIDATION_ERROR',
         message: 'Test error message',
         severity: 'error',
-        context: { error }
+        context: { extras: { error } }
       });
       expect(result.warnings).toHaveLength(0);
       expect(result.metadata).toEqual({
@@ -273,7 +273,7 @@ describe('ResultBuilder', () => {
         code: 'VALIDATION_ERROR',
         message: 'Unknown validation error',
         severity: 'error',
-        context: { error }
+        context: { extras: { error } }
       });
       expect(result.metadata).toEqual({
         duration: expect.any(Number),
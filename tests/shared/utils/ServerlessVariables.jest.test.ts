@@ -0,0 +1,47 @@
+import { resolveText, resolveValue, splitVariableBody } from '../../../src/shared/utils/ServerlessVariables';
+
+describe('ServerlessVariables', () => {
+  const self = {
+    service: 'api',
+    provider: { stage: "${opt:stage, 'dev'}" },
+    params: { default: { logLevel: 'info' }, prod: { logLevel: 'warn' } },
+    custom: { hosts: { dev: 'dev-db', prod: 'prod-db' }, loop: '${self:custom.loop}' },
+  };
+
+  it('should split variable bodies outside nested variables and quotes', () => {
+    expect(splitVariableBody("self:custom.${opt:stage, 'dev'}, 'a,b', 10")).toEqual(["self:custom.${opt:stage, 'dev'}", "'a,b'", '10']);
+  });
+
+  it('should resolve the stage, self references and params', () => {
+    expect(resolveText('${self:service}-${sls:stage}', { stage: 'prod', self }).value).toBe('api-prod');
+    expect(resolveText('${self:custom.hosts.${opt:stage}}', { stage: 'prod', self }).value).toBe('prod-db');
+    expect(resolveText('${param:logLevel}', { stage: 'prod', self }).value).toBe('warn');
+    expect(resolveText('${param:logLevel}', { stage: 'dev', self }).value).toBe('info');
+    expect(resolveText('${self:provider.stage}', { self }).value).toBe('dev');
+  });
+
+  it('should keep the type of a string that is a single variable', () => {
+    expect(resolveText('${self:custom.hosts}', { stage: 'dev', self }).value).toEqual({ dev: 'dev-db', prod: 'prod-db' });
+    expect(resolveText('${opt:timeout, 30}', { stage: 'dev', self }).value).toBe(30);
+  });
+
+  it('should try fallbacks in order', () => {
+    expect(resolveText("${self:custom.hosts.qa, self:custom.hosts.dev, 'local'}", { stage: 'qa', self }).value).toBe('dev-db');
+    expect(resolveText("${env:DB_HOST, 'localhost'}", { self, env: {} }).value).toBe('localhost');
+    expect(resolveText('${env:DB_HOST}', { self, env: { DB_HOST: 'db.internal' } }).value).toBe('db.internal');
+  });
+
+  it('should report missing file values and keep deploy-time sources as written', () => {
+    expect(resolveText('${self:custom.hosts.${sls:stage}}', { stage: 'qa', self })).toEqual({
+      value: undefined,
+      unresolved: ['self:custom.hosts.qa'],
+    });
+    expect(resolveText('${ssm:/app/${sls:stage}/db}', { stage: 'qa', self })).toEqual({ value: '${ssm:/app/qa/db}', unresolved: [] });
+    expect(resolveText('${env:UNSET}', { self, env: {} })).toEqual({ value: '${env:UNSET}', unresolved: [] });
+    expect(resolveText('${self:custom.loop}', { self }).unresolved).toEqual(['self:custom.loop']);
+  });
+
+  it('should resolve nested values', () => {
+    expect(resolveValue({ hosts: ['${self:custom.hosts.dev}'], port: 5432 }, { self }).value).toEqual({ hosts: ['dev-db'], port: 5432 });
+  });
+});
@@ -0,0 +1,19 @@
+import { isAtLeast, isSeverity, SEVERITIES } from '../../../src/shared/utils/Severity';
+
+describe('Severity', () => {
+  it('should list severities from lowest to highest', () => {
+    expect(SEVERITIES).toEqual(['info', 'warning', 'error']);
+  });
+
+  it('should compare severities against a threshold', () => {
+    expect(isAtLeast('error', 'warning')).toBe(true);
+    expect(isAtLeast('warning', 'warning')).toBe(true);
+    expect(isAtLeast('info', 'warning')).toBe(false);
+  });
+
+  it('should recognize known severities', () => {
+    expect(isSeverity('error')).toBe(true);
+    expect(isSeverity('critical')).toBe(false);
+    expect(isSeverity(undefined)).toBe(false);
+  });
+});
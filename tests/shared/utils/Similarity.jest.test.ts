@@ -0,0 +1,21 @@
+import { closestMatches, didYouMean, levenshtein } from '../../../src/shared/utils/Similarity';
+
+describe('Similarity', () => {
+  it('should count edits between names', () => {
+    expect(levenshtein('timeout', 'timout')).toBe(1);
+    expect(levenshtein('kitten', 'sitting')).toBe(3);
+    expect(levenshtein('', 'abc')).toBe(3);
+  });
+
+  it('should pick the closest candidates, ignoring case', () => {
+    expect(closestMatches('database.host', ['databse.host', 'database.port', 'Database.Host', 'cache.ttl']))
+      .toEqual(['Database.Host', 'databse.host', 'database.port']);
+    expect(closestMatches('port', ['host', 'debug'])).toEqual([]);
+  });
+
+  it('should write suggestions as a message suffix', () => {
+    expect(didYouMean([])).toBe('');
+    expect(didYouMean(['a'])).toBe(" (did you mean 'a'?)");
+    expect(didYouMean(['a', 'b', 'c'])).toBe(" (did you mean 'a', 'b' or 'c'?)");
+  });
+});
@@ -0,0 +1,39 @@
+import { buildPerformanceMetadata, measure, roundMs, slowestFirst } from '../../../src/shared/utils/Timing';
+import { ConfigFile } from '../../../src/shared/types';
+
+describe('Timing', () => {
+  it('should round durations to hundredths of a millisecond', () => {
+    expect(roundMs(1.23456)).toBe(1.23);
+    expect(roundMs(0)).toBe(0);
+  });
+
+  it('should return the value and duration of an operation', async () => {
+    const { value, ms } = await measure(async () => 42);
+
+    expect(value).toBe(42);
+    expect(ms).toBeGreaterThanOrEqual(0);
+  });
+
+  it('should build per-file and per-rule metadata', () => {
+    const files: ConfigFile[] = [
+      { path: 'a.yaml', format: 'yaml', content: {}, metadata: { parseMs: 1.5 } },
+      { path: 'b.json', format: 'json', content: {} },
+    ];
+
+    expect(buildPerformanceMetadata(files, [{ id: 'equality-rule', evaluationMs: 0.4 }], 3.456)).toEqual({
+      totalMs: 3.46,
+      files: [
+        { path: 'a.yaml', format: 'yaml', parseMs: 1.5 },
+        { path: 'b.json', format: 'json', parseMs: 0 },
+      ],
+      rules: [{ id: 'equality-rule', evaluationMs: 0.4 }],
+    });
+  });
+
+  it('should order entries from slowest to fastest without mutating the input', () => {
+    const entries = [{ ms: 1 }, { ms: 3 }, { ms: 2 }];
+
+    expect(slowestFirst(entries, entry => entry.ms)).toEqual([{ ms: 3 }, { ms: 2 }, { ms: 1 }]);
+    expect(entries[0]).toEqual({ ms: 1 });
+  });
+});
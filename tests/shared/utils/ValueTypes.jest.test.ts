@@ -0,0 +1,83 @@
+import {
+  isCidr,
+  isCurrency,
+  isFormatType,
+  isHostname,
+  isLocale,
+  isPort,
+  isTimezone,
+  isUrl,
+  isValueType,
+  matchesValueType
+} from '../../../src/shared/utils/ValueTypes';
+
+describe('ValueTypes', () => {
+  it('should check CIDR blocks of both IP versions', () => {
+    expect(isCidr('10.0.0.0/8')).toBe(true);
+    expect(isCidr('2001:db8::/32')).toBe(true);
+    expect(isCidr('10.0.0.0/33')).toBe(false);
+    expect(isCidr('10.0.0.0')).toBe(false);
+    expect(isCidr('10.0.0.256/24')).toBe(false);
+  });
+
+  it('should check hostnames label by label', () => {
+    expect(isHostname('db-1.internal.example.com')).toBe(true);
+    expect(isHostname('example.com.')).toBe(true);
+    expect(isHostname('-bad.example.com')).toBe(false);
+    expect(isHostname('under_score.example.com')).toBe(false);
+    expect(isHostname(`${'a'.repeat(64)}.com`)).toBe(false);
+  });
+
+  it('should accept ports as numbers or numeric strings', () => {
+    expect(isPort(5432)).toBe(true);
+    expect(isPort('8080')).toBe(true);
+    expect(isPort(0)).toBe(false);
+    expect(isPort('65536')).toBe(false);
+    expect(isPort('80a')).toBe(false);
+  });
+
+  it('should require a scheme and a host in URLs', () => {
+    expect(isUrl('https://api.example.com/v1')).toBe(true);
+    expect(isUrl('postgres://user@db:5432/app')).toBe(true);
+    expect(isUrl('localhost:8080')).toBe(false);
+    expect(isUrl('http://')).toBe(false);
+  });
+
+  it('should check time zones, language tags and currency codes', () => {
+    expect(isTimezone('Europe/Madrid')).toBe(true);
+    expect(isTimezone('Etc/GMT+5')).toBe(true);
+    expect(isTimezone('+02:00')).toBe(false);
+    expect(isTimezone('Mars/Olympus')).toBe(false);
+    expect(isLocale('zh-Hant-TW')).toBe(true);
+    expect(isLocale('en_US.UTF-8')).toBe(false);
+    expect(isCurrency('EUR')).toBe(true);
+    expect(isCurrency('usd')).toBe(true);
+    expect(isCurrency('Usd')).toBe(false);
+    expect(isCurrency('EURO')).toBe(false);
+    expect(isCurrency('XXX')).toBe(false);
+  });
+
+  it('should match values by type name', () => {
+    expect(matchesValueType('ipv4', '192.168.1.10')).toBe(true);
+    expect(matchesValueType('ipv4', '192.168.1')).toBe(false);
+    expect(matchesValueType('ipv6', '::1')).toBe(true);
+    expect(matchesValueType('email', 'ops@example.com')).toBe(true);
+    expect(matchesValueType('duration', '1h30m')).toBe(true);
+    expect(matchesValueType('duration', 'PT30S')).toBe(true);
+    expect(matchesValueType('duration', '30')).toBe(false);
+    expect(matchesValueType('integer', 3)).toBe(true);
+    expect(matchesValueType('integer', 3.5)).toBe(false);
+    expect(matchesValueType('object', [])).toBe(false);
+    expect(matchesValueType('cidr', 10)).toBe(false);
+    expect(matchesValueType('timezone', 'UTC')).toBe(true);
+    expect(matchesValueType('currency', 978)).toBe(false);
+  });
+
+  it('should never match unknown type names', () => {
+    expect(isValueType('cidr')).toBe(true);
+    expect(isValueType('toString')).toBe(false);
+    expect(isFormatType('port')).toBe(true);
+    expect(isFormatType('number')).toBe(false);
+    expect(matchesValueType('ip', '10.0.0.1')).toBe(false);
+  });
+});